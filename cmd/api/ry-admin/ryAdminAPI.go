@@ -13,7 +13,9 @@ import (
 	"github.com/onasunnymorning/domain-os/internal/domain/entities"
 	"github.com/onasunnymorning/domain-os/internal/infrastructure/broker/rabbitmq"
 	"github.com/onasunnymorning/domain-os/internal/infrastructure/db/postgres"
+	"github.com/onasunnymorning/domain-os/internal/infrastructure/dnsseckeys"
 	"github.com/onasunnymorning/domain-os/internal/infrastructure/snowflakeidgenerator"
+	"github.com/onasunnymorning/domain-os/internal/infrastructure/tmch"
 	"github.com/onasunnymorning/domain-os/internal/infrastructure/web/ianaregistrars"
 	"github.com/onasunnymorning/domain-os/internal/infrastructure/web/icannspec5"
 	"github.com/onasunnymorning/domain-os/internal/interface/rest"
@@ -25,6 +27,7 @@ import (
 	"github.com/gin-contrib/cors"
 	ginzap "github.com/gin-contrib/zap"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
 	ginprometheus "github.com/zsais/go-gin-prometheus"
 
 	docs "github.com/onasunnymorning/domain-os/docs" // Import docs pkg to be able to access docs.json https://github.com/swaggo/swag/issues/830#issuecomment-725587162
@@ -233,6 +236,9 @@ func main() {
 	// NNDNs
 	nndnRepo := postgres.NewGormNNDNRepository(gormDB)
 	nndnService := services.NewNNDNService(nndnRepo)
+	// Allocation tokens
+	allocationTokenRepo := postgres.NewAllocationTokenRepository(gormDB)
+	allocationTokenService := services.NewAllocationTokenService(allocationTokenRepo)
 	// FX
 	fxRepo := postgres.NewFXRepository(gormDB)
 	fxService := services.NewFXService(fxRepo)
@@ -259,9 +265,33 @@ func main() {
 	hostRepo := postgres.NewGormHostRepository(gormDB)
 	hostAddressRepo := postgres.NewGormHostAddressRepository(gormDB)
 	hostService := services.NewHostService(hostRepo, hostAddressRepo, roidService)
+	// Poll messages
+	pollMessageRepo := postgres.NewPollMessageRepository(gormDB)
+	pollService := services.NewPollService(pollMessageRepo)
 	// Domains
 	domainRepo := postgres.NewDomainRepository(gormDB)
-	domainService := services.NewDomainService(domainRepo, hostRepo, *roidService, nndnRepo, tldRepo, phaseRepo, premiumLabelRepo, fxRepo, registrarRepo)
+	domainTransferRepo := postgres.NewDomainTransferRepository(gormDB)
+	domainApplicationRepo := postgres.NewDomainApplicationRepository(gormDB)
+	// The TMCH Domain Name Label list determines which domains require a claims notice during the claims phase
+	claimsRepo := tmch.NewDNL()
+	if dnlFile := os.Getenv("TMCH_DNL_FILE"); dnlFile != "" {
+		claimsRepo, err = tmch.LoadDNLFile(dnlFile)
+		if err != nil {
+			log.Fatalf("Error loading the TMCH DNL: %v", err)
+		}
+	}
+	domainService := services.NewDomainService(domainRepo, hostRepo, *roidService, nndnRepo, tldRepo, phaseRepo, premiumLabelRepo, fxRepo, registrarRepo, pollMessageRepo, domainTransferRepo, domainApplicationRepo, claimsRepo, allocationTokenRepo)
+	// Zones
+	zoneService := services.NewZoneService(tldRepo, dnsRecRepo, domainRepo)
+	// DNSSEC keys are managed in a directory that is shared with the DNS server, which signs the zones
+	var dnssecService *services.DNSSECService
+	if keyDir := os.Getenv("DNSSEC_KEY_DIR"); keyDir != "" {
+		dnssecCfg, err := services.ParseDNSSECConfig(os.Getenv)
+		if err != nil {
+			log.Fatalf("Error reading the DNSSEC configuration: %v", err)
+		}
+		dnssecService = services.NewDNSSECService(dnsseckeys.NewKeyDirectory(keyDir), tldRepo, *dnssecCfg)
+	}
 
 	// REMOVEME:
 	// Quotes
@@ -271,12 +301,22 @@ func main() {
 	// domainService.QuoteService = *quoteService
 
 	// Whois
-	whoisService := services.NewWhoisService(domainRepo, registrarRepo)
+	whoisService := services.NewWhoisService(domainRepo, hostRepo, registrarRepo, contactRepo, tldRepo)
+	// The web whois queries are limited and counted for the monthly activity report, the counts are served with the Prometheus metrics
+	rddsRateLimitCfg, err := services.ParseRDDSRateLimitConfig(os.Getenv("RDDS_RATE_LIMIT"), os.Getenv("RDDS_RATE_LIMIT_BURST"), os.Getenv("RDDS_RATE_LIMIT_ALLOWLIST"))
+	if err != nil {
+		log.Fatalf("Error reading the RDDS rate limit: %v", err)
+	}
+	rddsRateLimiter := services.NewTokenBucketRDDSRateLimiter(*rddsRateLimitCfg, prometheus.DefaultRegisterer)
 
 	// Create Gin Engine/Router
 	// r := gin.Default()
 	// Create a new Gin router without any default middleware.
 	r := gin.New()
+	// Only the proxies in front of the API may set the client IP through X-Forwarded-For, by default none are trusted
+	if err := r.SetTrustedProxies(rest.ParseTrustedProxies(os.Getenv("TRUSTED_PROXIES"))); err != nil {
+		log.Fatalf("Error reading TRUSTED_PROXIES: %v", err)
+	}
 	// Use ginzap middleware to log requests with Zap
 	r.Use(ginzap.Ginzap(logger, time.RFC3339, true))
 
@@ -306,8 +346,9 @@ func main() {
 
 	rest.NewPingController(r)
 	rest.NewRegistryOperatorController(r, registryOperatorService, TokenAuthMiddleware())
-	rest.NewTLDController(r, tldService, domainService, TokenAuthMiddleware())
+	rest.NewTLDController(r, tldService, domainService, zoneService, TokenAuthMiddleware())
 	rest.NewNNDNController(r, nndnService, TokenAuthMiddleware())
+	rest.NewAllocationTokenController(r, allocationTokenService, TokenAuthMiddleware())
 	rest.NewSyncController(r, syncService, TokenAuthMiddleware())
 	rest.NewSpec5Controller(r, spec5Service, TokenAuthMiddleware())
 	rest.NewIANARegistrarController(r, ianaRegistrarService, TokenAuthMiddleware())
@@ -322,7 +363,11 @@ func main() {
 	rest.NewPremiumController(r, premiumListService, premiumLabelService, TokenAuthMiddleware())
 	rest.NewFXController(r, fxService, TokenAuthMiddleware())
 	// rest.NewQuoteController(r, quoteService, TokenAuthMiddleware())
-	rest.NewWhoisController(r, whoisService, TokenAuthMiddleware())
+	rest.NewWhoisController(r, whoisService, rest.RDDSRateLimitMiddleware(rddsRateLimiter, services.RDDS_SERVICE_WEB_WHOIS), TokenAuthMiddleware())
+	rest.NewPollController(r, pollService, TokenAuthMiddleware())
+	if dnssecService != nil {
+		rest.NewDNSSECController(r, dnssecService, TokenAuthMiddleware())
+	}
 
 	// Serve the swagger documentation
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(
@@ -9,12 +9,19 @@ import (
 	"strings"
 	"time"
 
+	"github.com/onasunnymorning/domain-os/internal/application/controllers"
 	"github.com/onasunnymorning/domain-os/internal/application/services"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/infrastructure/db/postgres"
 	"github.com/onasunnymorning/domain-os/internal/interface/cli/escrow"
 	"github.com/urfave/cli/v2"
 )
 
-const APP_VERSION = "0.2.2"
+const (
+	APP_VERSION = "0.2.2"
+	// RYDE_PASSPHRASE_ENV is the environment variable holding the passphrase of the private key used to sign or decrypt a deposit
+	RYDE_PASSPHRASE_ENV = "RYDE_KEY_PASSPHRASE"
+)
 
 func main() {
 	start := time.Now()
@@ -81,9 +88,70 @@ func main() {
 			{
 				Name:    "generate",
 				Aliases: []string{"gen"},
-				Usage:   "export all relevant data from the Database and create an XML escrow deposit file",
+				Usage:   "export all relevant data from the Database and create an XML escrow deposit file for a TLD",
 				Action:  generateDeposit,
 				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:        "type",
+						Aliases:     []string{"t"},
+						Usage:       "type of deposit to generate: full, diff, incr or auto (a FULL deposit on Sundays and a DIFF deposit on the other days)",
+						Required:    false,
+						Value:       "full",
+						DefaultText: "full",
+					},
+					&cli.StringFlag{
+						Name:        "output",
+						Aliases:     []string{"o"},
+						Usage:       "name of the deposit file, the report is written next to it with a .rep extension",
+						Required:    false,
+						DefaultText: "<tld>_<YYYY-MM-DD>_<type>_S1_R<resend>.xml",
+					},
+					&cli.IntFlag{
+						Name:        "batch-size",
+						Aliases:     []string{"b"},
+						Usage:       "amount of objects to read from the database at a time",
+						Required:    false,
+						Value:       1000,
+						DefaultText: "1000",
+					},
+					&cli.IntFlag{
+						Name:        "indent",
+						Aliases:     []string{"i"},
+						Usage:       "amount of indentation to use in the output file",
+						Required:    false,
+						Value:       0,
+						DefaultText: "0 - no indentation - single line of XML",
+					},
+					&cli.IntFlag{
+						Name:        "concurrency",
+						Aliases:     []string{"c"},
+						Usage:       "amount of goroutines to use for concurrent processing",
+						Required:    false,
+						Value:       10,
+						DefaultText: "10",
+					},
+				},
+			},
+			{
+				Name:   "brda",
+				Usage:  "export the domains and registrars of a TLD from the Database and create a thin Bulk Registration Data Access (BRDA) file",
+				Action: generateBRDA,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:        "output",
+						Aliases:     []string{"o"},
+						Usage:       "name of the BRDA file, the report is written next to it with a .rep extension",
+						Required:    false,
+						DefaultText: "<tld>_<YYYY-MM-DD>_thin_S1_R0.xml",
+					},
+					&cli.IntFlag{
+						Name:        "batch-size",
+						Aliases:     []string{"b"},
+						Usage:       "amount of objects to read from the database at a time",
+						Required:    false,
+						Value:       1000,
+						DefaultText: "1000",
+					},
 					&cli.IntFlag{
 						Name:        "indent",
 						Aliases:     []string{"i"},
@@ -102,6 +170,60 @@ func main() {
 					},
 				},
 			},
+			{
+				Name:    "package",
+				Aliases: []string{"pkg"},
+				Usage:   "compress, encrypt and sign an RDE escrow deposit file (XML) into a .ryde file and a detached .sig signature. A passphrase protecting the signing key is read from " + RYDE_PASSPHRASE_ENV,
+				Action:  packageDeposit,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "encryption-key",
+						Aliases:  []string{"e"},
+						Usage:    "file containing the OpenPGP public key of the escrow agent",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "signing-key",
+						Aliases:  []string{"s"},
+						Usage:    "file containing the OpenPGP private key of the registry used to sign the deposit",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:    "verify",
+				Aliases: []string{"vfy"},
+				Usage:   "verify the signature of a .ryde file and decrypt it into an RDE escrow deposit file (XML). A passphrase protecting the decryption key is read from " + RYDE_PASSPHRASE_ENV,
+				Action:  verifyDeposit,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:        "signature",
+						Aliases:     []string{"sig"},
+						Usage:       "file containing the detached signature of the .ryde file",
+						Required:    false,
+						DefaultText: "<name of the .ryde file>.sig",
+					},
+					&cli.StringFlag{
+						Name:     "verification-key",
+						Aliases:  []string{"k"},
+						Usage:    "file containing the OpenPGP public key of the depositor used to verify the signature",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "decryption-key",
+						Aliases:  []string{"d"},
+						Usage:    "file containing the OpenPGP private key used to decrypt the deposit",
+						Required: true,
+					},
+					&cli.BoolFlag{
+						Name:     "analyze",
+						Aliases:  []string{"a"},
+						Usage:    "analyze the deposit after it has been decrypted",
+						Value:    false,
+						Required: false,
+					},
+				},
+			},
 		},
 	}
 
@@ -162,11 +284,165 @@ func importDeposit(c *cli.Context) error {
 	return nil
 }
 
+func packageDeposit(c *cli.Context) error {
+	filename := c.Args().First()
+	if filename == "" {
+		return errors.New("please provide a filename")
+	}
+
+	encryptTo, err := services.ReadRyDEKeyRing(c.String("encryption-key"), "")
+	if err != nil {
+		return err
+	}
+	signWith, err := services.ReadRyDEKeyRing(c.String("signing-key"), os.Getenv(RYDE_PASSPHRASE_ENV))
+	if err != nil {
+		return err
+	}
+	if len(signWith) != 1 {
+		return fmt.Errorf("expected a single signing key in %s, found %d", c.String("signing-key"), len(signWith))
+	}
+
+	packager := services.NewRyDEService(services.RyDEKeys{EncryptTo: encryptTo, SignWith: signWith[0]})
+	rydeFile, sigFile, err := packager.Package(filename)
+	if err != nil {
+		return err
+	}
+	log.Printf("Deposit packaged in %s, signature written to %s\n", rydeFile, sigFile)
+
+	return nil
+}
+
+func verifyDeposit(c *cli.Context) error {
+	filename := c.Args().First()
+	if filename == "" {
+		return errors.New("please provide a filename")
+	}
+
+	verifyWith, err := services.ReadRyDEKeyRing(c.String("verification-key"), "")
+	if err != nil {
+		return err
+	}
+	decryptWith, err := services.ReadRyDEKeyRing(c.String("decryption-key"), os.Getenv(RYDE_PASSPHRASE_ENV))
+	if err != nil {
+		return err
+	}
+
+	unpacker := services.NewRyDEService(services.RyDEKeys{DecryptWith: decryptWith, VerifyWith: verifyWith})
+	depositFile, err := unpacker.Unpack(filename, c.String("signature"))
+	if err != nil {
+		return err
+	}
+	log.Printf("Signature verified, deposit written to %s\n", depositFile)
+
+	if !c.Bool("analyze") {
+		return nil
+	}
+	escrowService, err := services.NewXMLEscrowService(depositFile)
+	if err != nil {
+		return err
+	}
+	return escrow.NewEscrowAnalysisController(escrowService).Analyze(false)
+}
+
 func generateDeposit(c *cli.Context) error {
-	log.Println("Generate command - not implemented")
+	tld := c.Args().First()
+	if tld == "" {
+		return errors.New("please provide a TLD")
+	}
+
+	depositType := c.String("type")
+	if strings.EqualFold(depositType, "auto") {
+		depositType = entities.RDEDepositTypeForDay(time.Now())
+	}
+	depositType, err := entities.ValidateRDEDepositType(depositType)
+	if err != nil {
+		return err
+	}
+
+	repos, err := getEscrowGeneratorRepositories()
+	if err != nil {
+		return err
+	}
+
+	generator := controllers.NewEscrowGenerator(
+		controllers.EscrowGeneratorParams{
+			Tld:            tld,
+			DepositType:    depositType,
+			OutputFile:     c.String("output"),
+			BatchSize:      c.Int("batch-size"),
+			MaxConcurrency: c.Int("concurrency"),
+			Indent:         c.Int("indent"),
+		},
+		repos,
+	)
+
+	if err := generator.Generate(c.Context); err != nil {
+		return err
+	}
+	log.Printf("Deposit written to %s, report written to %s\n", generator.Params.OutputFile, generator.ReportFile())
+
+	return nil
+}
+
+func generateBRDA(c *cli.Context) error {
+	tld := c.Args().First()
+	if tld == "" {
+		return errors.New("please provide a TLD")
+	}
+
+	repos, err := getEscrowGeneratorRepositories()
+	if err != nil {
+		return err
+	}
+
+	generator := controllers.NewBRDAGenerator(
+		controllers.EscrowGeneratorParams{
+			Tld:            tld,
+			OutputFile:     c.String("output"),
+			BatchSize:      c.Int("batch-size"),
+			MaxConcurrency: c.Int("concurrency"),
+			Indent:         c.Int("indent"),
+		},
+		repos,
+	)
+
+	if err := generator.Generate(c.Context); err != nil {
+		return err
+	}
+	log.Printf("BRDA file written to %s, report written to %s\n", generator.Params.OutputFile, generator.ReportFile())
+
 	return nil
 }
 
+// getEscrowGeneratorRepositories connects to the database configured in the environment and returns the repositories the generators read from
+func getEscrowGeneratorRepositories() (controllers.EscrowGeneratorRepositories, error) {
+	gormDB, err := postgres.NewConnection(
+		postgres.Config{
+			User:    os.Getenv("DB_USER"),
+			Pass:    os.Getenv("DB_PASS"),
+			Host:    os.Getenv("DB_HOST"),
+			Port:    os.Getenv("DB_PORT"),
+			DBName:  os.Getenv("DB_NAME"),
+			SSLmode: os.Getenv("DB_SSLMODE"),
+		},
+	)
+	if err != nil {
+		return controllers.EscrowGeneratorRepositories{}, err
+	}
+
+	return controllers.EscrowGeneratorRepositories{
+		Domain:    postgres.NewDomainRepository(gormDB),
+		Host:      postgres.NewGormHostRepository(gormDB),
+		Contact:   postgres.NewContactRepository(gormDB),
+		Registrar: postgres.NewGormRegistrarRepository(gormDB),
+		NNDN:      postgres.NewGormNNDNRepository(gormDB),
+		TLD:       postgres.NewGormTLDRepo(gormDB),
+		Transfer:  postgres.NewDomainTransferRepository(gormDB),
+		Deposit:   postgres.NewEscrowDepositRepository(gormDB),
+		Deletes:   postgres.NewDeletedObjectRepository(gormDB),
+	}, nil
+}
+
 func printVersion(c *cli.Context) error {
 	fmt.Printf("Version %s\n", APP_VERSION)
 	return nil
@@ -26,10 +26,12 @@ const (
 	ScheduleTypePurge    = "purge"
 	ScheduleTypeUpdateFX = "updatefx"
 	ScheduleTypeRestore  = "restore"
+	ScheduleTypeTransfer = "transfer"
+	ScheduleTypeZSK      = "zsk-rollover"
 )
 
 var (
-	SupportedScheduleTypes = []string{ScheduleTypeExpiry, ScheduleTypePurge, ScheduleTypeUpdateFX, ScheduleTypeRestore}
+	SupportedScheduleTypes = []string{ScheduleTypeExpiry, ScheduleTypePurge, ScheduleTypeUpdateFX, ScheduleTypeRestore, ScheduleTypeTransfer, ScheduleTypeZSK}
 )
 
 func main() {
@@ -81,6 +83,12 @@ func main() {
 				Usage:   "process pendingRestore domains using the ",
 				Action:  restore,
 			},
+			{
+				Name:    "transfer",
+				Aliases: []string{"tr", "t"},
+				Usage:   "auto-approve pending transfers past the transfer grace period",
+				Action:  autoApproveTransfers,
+			},
 
 			{
 				Name:      "schedule",
@@ -91,7 +99,7 @@ func main() {
 					{
 						Name:    "create",
 						Aliases: []string{"c", "cr"},
-						Usage:   "create temporal schedules (expiry and purge)",
+						Usage:   "create temporal schedules (expiry and purge), zsk-rollover takes the TLD as a second argument",
 						Action:  createTemporalSchedules,
 					},
 					{
@@ -217,6 +225,30 @@ func purge(c *cli.Context) error {
 	return nil
 }
 
+func autoApproveTransfers(c *cli.Context) error {
+	// Query the API for a batch of expired pending transfers
+	log.Println("Querying expired transfers...")
+	transfers, err := activities.ListExpiredTransfers(getCorrelationIDFromContext(c))
+	if err != nil {
+		return err
+	}
+	log.Println("Found", len(transfers), "expired transfers")
+
+	// Process the batch of expired transfers
+	log.Println("Processing expired transfers...")
+	for _, transfer := range transfers {
+		// Approve the transfer on behalf of the registry
+		err := activities.ApproveDomainTransfer(getCorrelationIDFromContext(c), transfer.DomainName.String())
+		if err != nil {
+			log.Printf("Failed to approve transfer of domain %s: %s\n", transfer.DomainName, err)
+			continue
+		}
+		log.Println("Transfer of domain", transfer.DomainName, "approved")
+	}
+
+	return nil
+}
+
 // createTemporalExpirySchedule automates the creation of a temporal schedule as defined in schedules.CreateExpiryScheduleHourly. Use this to set up the schedules when deploying an instance of the application. Note that the environment variables must be set for this to work and there is no facility yet to updated/delete schedules. Use the temporal web UI to manage schedules.
 func createTemporalExpirySchedule(cfg *temporal.TemporalClientconfig) error {
 	// Create the schedule
@@ -269,6 +301,35 @@ func createTemporalUpdateFXSchedule(cfg *temporal.TemporalClientconfig) error {
 	return nil
 }
 
+// createTemporalTransferAutoApproveSchedule automates the creation of a temporal schedule as defined in schedules.CreateTransferAutoApproveScheduleHourly. Use this to set up the schedules when deploying an instance of the application. Note that the environment variables must be set for this to work and there is no facility yet to updated/delete schedules. Use the temporal web UI to manage schedules.
+func createTemporalTransferAutoApproveSchedule(cfg *temporal.TemporalClientconfig) error {
+	// Create the schedule
+	scheduleID, err := schedules.CreateTransferAutoApproveScheduleHourly(*cfg)
+	if err != nil {
+		return err
+	}
+
+	log.Println("Created schedule with ID:", scheduleID)
+
+	return nil
+}
+
+// createTemporalZSKRolloverSchedule automates the creation of a temporal schedule as defined in schedules.CreateZSKRolloverSchedule for the TLD. Use this once the TLD zone is signed.
+func createTemporalZSKRolloverSchedule(cfg *temporal.TemporalClientconfig, tldName string) error {
+	if tldName == "" {
+		return errors.New("missing TLD name, usage: schedule create zsk-rollover <tld>")
+	}
+	// Create the schedule
+	scheduleID, err := schedules.CreateZSKRolloverSchedule(*cfg, strings.ToLower(tldName))
+	if err != nil {
+		return err
+	}
+
+	log.Println("Created schedule with ID:", scheduleID)
+
+	return nil
+}
+
 // createTemporalSchedules is a CLI command that creates a temporal schedule for domain lifecycle operations. It takes a single argument, either 'expiry' or 'purge', to specify the type of schedule to create.
 func createTemporalSchedules(c *cli.Context) error {
 	// Check if the first argument is a valid schedule (expiry or purge)
@@ -296,6 +357,10 @@ func createTemporalSchedules(c *cli.Context) error {
 		return createTemporalUpdateFXSchedule(cfg)
 	case "restore":
 		return createTemporalRestoreSchedule(cfg)
+	case "transfer":
+		return createTemporalTransferAutoApproveSchedule(cfg)
+	case ScheduleTypeZSK:
+		return createTemporalZSKRolloverSchedule(cfg, c.Args().Get(1))
 	}
 
 	return errors.New("invalid schedule type")
@@ -322,8 +387,18 @@ func restore(c *cli.Context) error {
 		return err
 	}
 
-	// Renew the domains
+	// Complete the restores and renew the domains
 	for _, domain := range restoredDomains {
+		// Complete the restore, domains for which no restore report was received are skipped
+		dom, err := activities.CompleteDomainRestore(correlationID, domain.Name)
+		if err != nil {
+			return err
+		}
+		if dom.Status.PendingRestore || dom.Status.PendingDelete {
+			log.Printf("restore of %s not completed, restore report pending or overdue\n", domain.Name)
+			continue
+		}
+
 		// Create the renew command
 		cmd := commands.RenewDomainCommand{
 			Name:  domain.Name,
@@ -331,18 +406,11 @@ func restore(c *cli.Context) error {
 			Years: 1,
 		}
 
-		// Renew the domain
-		err := activities.RenewDomain(correlationID, cmd, false)
+		// Force-Renew the domain
+		err = activities.RenewDomain(correlationID, cmd, true)
 		if err != nil {
 			return err
 		}
-
-		// Unset pendingRestore flag
-		// err = activities.UnsetPendingRestoreFlag(correlationID, domain.Name)
-		// if err != nil {
-		// 	return err
-		// }
-
 	}
 	return nil
 }
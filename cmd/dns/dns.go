@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/miekg/dns"
+	"github.com/onasunnymorning/domain-os/internal/application/interfaces"
+	"github.com/onasunnymorning/domain-os/internal/application/services"
+	"github.com/onasunnymorning/domain-os/internal/domain/repositories"
+	"github.com/onasunnymorning/domain-os/internal/infrastructure/db/postgres"
+	"github.com/onasunnymorning/domain-os/internal/infrastructure/dnsseckeys"
+	"github.com/onasunnymorning/domain-os/internal/infrastructure/zonejournal"
+	"github.com/onasunnymorning/domain-os/internal/interface/dnsserver"
+	"gorm.io/gorm"
+)
+
+const (
+	// DNS_PORT is the default port for DNS servers.
+	DNS_PORT = "53"
+)
+
+// The DNS server is the primary for the zones of the TLDs that have DNS enabled.
+// It serves zone transfers (AXFR/IXFR) to the secondaries and notifies them when a zone changes, see the dnsserver package for the configuration.
+// If DNSSEC_KEY_DIR is set, the zones that have keys in that directory are signed with DNSSEC, see services.ParseDNSSECConfig for the signing configuration.
+// If DNS_JOURNAL_DIR is set, the published zones and their journals are stored in that directory so a restart doesn't publish new serials or break incremental transfers.
+func main() {
+	// Set up a context to handle signals for graceful shutdown.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := dnsserver.ParseConfig(os.Getenv)
+	if err != nil {
+		log.Fatalf("Error reading configuration: %v", err)
+	}
+
+	// Set up the database connection.
+	db, err := setupDB()
+	if err != nil {
+		log.Fatalf("Error setting up database: %v", err)
+	}
+	tldRepo := postgres.NewGormTLDRepo(db)
+	dnsRecRepo := postgres.NewGormDNSRecordRepository(db)
+	domRepo := postgres.NewDomainRepository(db)
+
+	// Set up the signer if DNSSEC is enabled
+	var signer interfaces.ZoneSigner
+	if keyDir := os.Getenv("DNSSEC_KEY_DIR"); keyDir != "" {
+		dnssecCfg, err := services.ParseDNSSECConfig(os.Getenv)
+		if err != nil {
+			log.Fatalf("Error reading DNSSEC configuration: %v", err)
+		}
+		signer = services.NewZoneSigner(dnsseckeys.NewKeyDirectory(keyDir), *dnssecCfg)
+		log.Printf("Signing zones with the DNSSEC keys in %s", keyDir)
+	}
+
+	// Store the journals if a directory is configured, otherwise they are kept in memory
+	var journals repositories.ZoneJournalRepository
+	if journalDir := os.Getenv("DNS_JOURNAL_DIR"); journalDir != "" {
+		journals = zonejournal.NewJournalDirectory(journalDir)
+		log.Printf("Storing the zone journals in %s", journalDir)
+	}
+
+	// Set up the primary
+	zoneSvc := services.NewZoneService(tldRepo, dnsRecRepo, domRepo)
+	primary := dnsserver.NewPrimary(zoneSvc, signer, journals, *cfg)
+	go primary.Run(ctx)
+
+	// Listen on TCP for zone transfers and on UDP for SOA queries
+	port := DNS_PORT
+	if p := os.Getenv("DNS_PORT"); p != "" {
+		port = p
+	}
+	servers := []*dns.Server{
+		{Addr: ":" + port, Net: "tcp", Handler: primary, TsigSecret: cfg.TSIGSecrets()},
+		{Addr: ":" + port, Net: "udp", Handler: primary, TsigSecret: cfg.TSIGSecrets()},
+	}
+	for _, srv := range servers {
+		go func(srv *dns.Server) {
+			log.Printf("DNS server running on %s/%s", srv.Addr, srv.Net)
+			if err := srv.ListenAndServe(); err != nil {
+				log.Fatalf("Error starting %s server: %v", srv.Net, err)
+			}
+		}(srv)
+	}
+
+	<-ctx.Done()
+	log.Println("Shutting down gracefully...")
+	for _, srv := range servers {
+		if err := srv.Shutdown(); err != nil {
+			log.Printf("Error shutting down %s server: %v", srv.Net, err)
+		}
+	}
+}
+
+func setupDB() (*gorm.DB, error) {
+	return postgres.NewConnection(
+		postgres.Config{
+			User:    os.Getenv("DB_USER"),
+			Pass:    os.Getenv("DB_PASS"),
+			Host:    os.Getenv("DB_HOST"),
+			Port:    os.Getenv("DB_PORT"),
+			DBName:  os.Getenv("DB_NAME"),
+			SSLmode: os.Getenv("DB_SSLMODE"),
+		},
+	)
+}
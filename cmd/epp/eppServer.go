@@ -8,12 +8,19 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"fmt"
+	"log"
 	"math/big"
 	"net"
+	"os"
 	"time"
 
-	"github.com/beevik/etree"
-	epp "github.com/dotse/epp-lib"
+	epplib "github.com/dotse/epp-lib"
+	"github.com/google/uuid"
+	"github.com/onasunnymorning/domain-os/internal/application/services"
+	"github.com/onasunnymorning/domain-os/internal/infrastructure/db/postgres"
+	"github.com/onasunnymorning/domain-os/internal/infrastructure/snowflakeidgenerator"
+	"github.com/onasunnymorning/domain-os/internal/infrastructure/tmch"
+	"github.com/onasunnymorning/domain-os/internal/interface/epp"
 	"github.com/sirupsen/logrus"
 )
 
@@ -47,16 +54,67 @@ func (l *LogrusLogger) Debugf(format string, args ...interface{}) {
 func main() {
 	logger := NewLogrusLogger()
 
-	commandMux := &epp.CommandMux{}
+	// SETUP
+	gormDB, err := postgres.NewConnection(
+		postgres.Config{
+			User:    os.Getenv("DB_USER"),
+			Pass:    os.Getenv("DB_PASS"),
+			Host:    os.Getenv("DB_HOST"),
+			Port:    os.Getenv("DB_PORT"),
+			DBName:  os.Getenv("DB_NAME"),
+			SSLmode: os.Getenv("DB_SSLMODE"),
+		},
+	)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	idGenerator, err := snowflakeidgenerator.NewIDGenerator()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	roidService := services.NewRoidService(idGenerator)
+	hostRepo := postgres.NewGormHostRepository(gormDB)
+	nndnRepo := postgres.NewGormNNDNRepository(gormDB)
+	tldRepo := postgres.NewGormTLDRepo(gormDB)
+	phaseRepo := postgres.NewGormPhaseRepository(gormDB)
+	premiumLabelRepo := postgres.NewGORMPremiumLabelRepository(gormDB)
+	fxRepo := postgres.NewFXRepository(gormDB)
+	registrarRepo := postgres.NewGormRegistrarRepository(gormDB)
+	domainRepo := postgres.NewDomainRepository(gormDB)
+	domainTransferRepo := postgres.NewDomainTransferRepository(gormDB)
+	pollMessageRepo := postgres.NewPollMessageRepository(gormDB)
+	pollService := services.NewPollService(pollMessageRepo)
+	domainApplicationRepo := postgres.NewDomainApplicationRepository(gormDB)
+	allocationTokenRepo := postgres.NewAllocationTokenRepository(gormDB)
+	// The TMCH Domain Name Label list determines which domains require a claims notice during the claims phase
+	claimsRepo := tmch.NewDNL()
+	if dnlFile := os.Getenv("TMCH_DNL_FILE"); dnlFile != "" {
+		claimsRepo, err = tmch.LoadDNLFile(dnlFile)
+		if err != nil {
+			log.Fatalf("Error loading the TMCH DNL: %v", err)
+		}
+	}
+	domainService := services.NewDomainService(domainRepo, hostRepo, *roidService, nndnRepo, tldRepo, phaseRepo, premiumLabelRepo, fxRepo, registrarRepo, pollMessageRepo, domainTransferRepo, domainApplicationRepo, claimsRepo, allocationTokenRepo)
+	contactRepo := postgres.NewContactRepository(gormDB)
+	contactService := services.NewContactService(contactRepo, *roidService)
+	hostAddressRepo := postgres.NewGormHostAddressRepository(gormDB)
+	hostService := services.NewHostService(hostRepo, hostAddressRepo, roidService)
+	registrarService := services.NewRegistrarService(registrarRepo)
+
+	commandMux := &epplib.CommandMux{Logger: logger}
+
+	// Greeting, hello and session commands
+	epp.NewSessionController(commandMux, registrarService)
 
-	commandMux.BindGreeting(sendGreeting)
-	commandMux.Bind(epp.NewXMLPathBuilder().AddOrphan("//hello", epp.NamespaceIETFEPP10.String()).String(), sendGreeting)
-	commandMux.BindCommand("check", epp.NamespaceIETFDomain10.String(), respondToDomainCheckCommand)
-	// commandMux.BindCommand("info", epp.NamespaceIETFContact10.String(),
-	// 	funcTharHandlesContactInfoCommand,
-	// )
+	// Object commands
+	epp.NewDomainController(commandMux, domainService)
+	epp.NewContactController(commandMux, contactService)
+	epp.NewHostController(commandMux, hostService)
 
-	server := &epp.Server{
+	// Poll command
+	epp.NewPollController(commandMux, pollService)
+
+	server := &epplib.Server{
 		HandleCommand: commandMux.Handle,
 		Greeting:      commandMux.GetGreeting,
 		TLSConfig: tls.Config{
@@ -64,13 +122,15 @@ func main() {
 			ClientAuth:   tls.RequireAnyClientCert,
 			MinVersion:   tls.VersionTLS12,
 		},
-		ConnContext:    logConnection,
+		ConnContext: func(ctx context.Context, conn *tls.Conn) (context.Context, error) {
+			return logConnection(epp.ContextWithPollService(ctx, pollService), conn)
+		},
 		Timeout:        time.Hour,
 		IdleTimeout:    350 * time.Second,
 		WriteTimeout:   2 * time.Minute,
 		ReadTimeout:    10 * time.Second,
 		Logger:         logger,
-		MaxMessageSize: 1000,
+		MaxMessageSize: 64 * 1024,
 	}
 
 	listener, err := net.ListenTCP("tcp", &net.TCPAddr{
@@ -114,75 +174,16 @@ func generateCertificate() tls.Certificate {
 	}
 }
 
-// sendGreeting adheres to the CommandFunc signature and sends a greeting.
-func sendGreeting(ctx context.Context, rw epp.Writer, _ *etree.Document) {
-	rw.Write([]byte(getGreetingXML()))
-}
-
-// getGreetingXML returns the XML for a greeting.
-func getGreetingXML() string {
-	return `<?xml version="1.0" encoding="UTF-8" standalone="no"?><epp xmlns="urn:ietf:params:xml:ns:epp-1.0"><response><result code="1000"><msg>Welcome Stranger</msg></result><trID><clTRID>ABC-12345</clTRID><svTRID>APEX-123</svTRID></trID></response></epp>`
-}
-
 // logConnection implements the
 // ConnContext func(ctx context.Context, conn *tls.Conn) (context.Context, error)
 // interface and is a placeholder for connection management.
-// We simply log to the console that a connection has been established.
+// It sets up an empty EPP session and logs to the console that a connection has been established.
+// The client is bound to the session when it sends a successful <login> command.
 func logConnection(ctx context.Context, conn *tls.Conn) (context.Context, error) {
 	// add the connection ID to the context
-	ctx = context.WithValue(ctx, "cid", "12345")
-	fmt.Printf("Connection with id %s established\n", ctx.Value("cid"))
-	return ctx, nil
-}
+	ctx = context.WithValue(ctx, "cid", uuid.NewString())
+	ctx = epp.ContextWithSession(ctx, epp.NewSession())
 
-// respondToDomainCheckCommand is a placeholder function that responds to a domain check command.
-func respondToDomainCheckCommand(ctx context.Context, rw epp.Writer, doc *etree.Document) {
-	// SETUP
-	// Domain service
-	// gormDB, err := postgres.NewConnection(
-	// 	postgres.Config{
-	// 		User:    os.Getenv("DB_USER"),
-	// 		Pass:    os.Getenv("DB_PASS"),
-	// 		Host:    "localhost",
-	// 		Port:    os.Getenv("DB_PORT"),
-	// 		DBName:  os.Getenv("DB_NAME"),
-	// 		SSLmode: "require",
-	// 	},
-	// )
-	// if err != nil {
-	// 	log.Fatalln(err)
-	// }
-	// idGenerator, err := snowflakeidgenerator.NewIDGenerator()
-	// if err != nil {
-	// 	panic(err)
-	// }
-	// roidService := services.NewRoidService(idGenerator)
-	// hostRepo := postgres.NewGormHostRepository(gormDB)
-	// nndnRepo := postgres.NewGormNNDNRepository(gormDB)
-	// tldRepo := postgres.NewGormTLDRepo(gormDB)
-	// phaseRepo := postgres.NewGormPhaseRepository(gormDB)
-	// premiumLabelRepo := postgres.NewGORMPremiumLabelRepository(gormDB)
-	// fxRepo := postgres.NewFXRepository(gormDB)
-	// domainRepo := postgres.NewDomainRepository(gormDB)
-	// ds := services.NewDomainService(domainRepo, hostRepo, *roidService, nndnRepo, tldRepo, phaseRepo, premiumLabelRepo, fxRepo)
-	// // Get a list of domain names to check
-	// domainNames := doc.FindElements("//domain:name")
-	// results := make([]*queries.DomainCheckResult, len(domainNames))
-	// for i, domainName := range domainNames {
-	// 	fmt.Printf("Checking domain: %s\n", domainName.Text())
-	// 	result, err := ds.CheckDomain(ctx, &queries.DomainCheckQuery{
-	// 		DomainName: entities.DomainName(domainName.Text()),
-	// 	})
-	// 	if err != nil {
-	// 		log.Fatalf("Error checking domain: %s\n", err)
-	// 	}
-	// 	results[i] = result
-	// 	fmt.Println(results)
-	// }
-	rw.Write([]byte(dummyDomainCheckResponse()))
-}
-
-// dummyDomainCheckResponse returns a dummy domain check response.
-func dummyDomainCheckResponse() string {
-	return `<?xml version="1.0" encoding="UTF-8" standalone="no"?><epp xmlns="urn:ietf:params:xml:ns:epp-1.0"><response><result code="1000"><msg>Welcome Stranger</msg></result><resData><domain:chkData xmlns:domain="urn:ietf:params:xml:ns:domain-1.0"><domain:cd><domain:name avail="1">geoff.smoketestcnic</domain:name></domain:cd></domain:chkData></resData> <trID><clTRID>ABC-12345</clTRID><svTRID>APEX-123</svTRID></trID></response></epp>`
+	fmt.Printf("Connection with id %s established from %s\n", ctx.Value("cid"), conn.RemoteAddr())
+	return ctx, nil
 }
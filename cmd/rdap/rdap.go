@@ -0,0 +1,116 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onasunnymorning/domain-os/internal/application/services"
+	"github.com/onasunnymorning/domain-os/internal/infrastructure/db/postgres"
+	"github.com/onasunnymorning/domain-os/internal/interface/rest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// RDAP_PORT is the default port for the RDAP server, RDAP is expected to be served over HTTPS by a proxy in front of it.
+	RDAP_PORT = "8080"
+)
+
+func main() {
+	// Set up the database connection.
+	db, err := setupDB()
+	if err != nil {
+		log.Fatalf("Error setting up database: %v", err)
+	}
+	domRepo := postgres.NewDomainRepository(db)
+	hostRepo := postgres.NewGormHostRepository(db)
+	contactRepo := postgres.NewContactRepository(db)
+	rarRepo := postgres.NewGormRegistrarRepository(db)
+	tldRepo := postgres.NewGormTLDRepo(db)
+
+	// Privileged requesters get the unredacted contact data, the tokens are configured per TLD as JSON, e.g. {"apex": ["token1"], "*": ["token2"]}
+	access, err := services.ParseRDDSAccessPolicy(os.Getenv("RDDS_FULL_ACCESS_TOKENS"))
+	if err != nil {
+		log.Fatalf("Error reading RDDS_FULL_ACCESS_TOKENS: %v", err)
+	}
+
+	// Set up the RDAP Service
+	rdapSvc := services.NewRDAPService(domRepo, hostRepo, contactRepo, rarRepo, tldRepo, access, os.Getenv("RDAP_BASE_URL"), os.Getenv("RDAP_TOS_URL"))
+	if pageSize := os.Getenv("RDAP_SEARCH_PAGE_SIZE"); pageSize != "" {
+		rdapSvc.SearchPageSize, err = strconv.Atoi(pageSize)
+		if err != nil || rdapSvc.SearchPageSize < 1 {
+			log.Fatalf("Error reading RDAP_SEARCH_PAGE_SIZE: expected a positive number, got %q", pageSize)
+		}
+	}
+	if notice := os.Getenv("RDAP_SEARCH_TRUNCATION_NOTICE"); notice != "" {
+		rdapSvc.TruncationNotice = notice
+	}
+
+	// Set up the rate limiter, which also counts the queries for the monthly activity report
+	rateLimitCfg, err := services.ParseRDDSRateLimitConfig(os.Getenv("RDDS_RATE_LIMIT"), os.Getenv("RDDS_RATE_LIMIT_BURST"), os.Getenv("RDDS_RATE_LIMIT_ALLOWLIST"))
+	if err != nil {
+		log.Fatalf("Error reading the RDDS rate limit: %v", err)
+	}
+	limiter := services.NewTokenBucketRDDSRateLimiter(*rateLimitCfg, prometheus.DefaultRegisterer)
+	serveMetrics()
+
+	r := gin.New()
+	// Only the proxies in front of the server may set the client IP through X-Forwarded-For, by default none are trusted
+	if err := r.SetTrustedProxies(rest.ParseTrustedProxies(os.Getenv("TRUSTED_PROXIES"))); err != nil {
+		log.Fatalf("Error reading TRUSTED_PROXIES: %v", err)
+	}
+	r.Use(gin.Logger(), gin.Recovery(), corsHeaders())
+	rest.NewRDAPController(r, rdapSvc, rest.RDDSRateLimitMiddleware(limiter, services.RDDS_SERVICE_RDAP))
+
+	port := os.Getenv("RDAP_PORT")
+	if port == "" {
+		port = RDAP_PORT
+	}
+	log.Printf("RDAP server running on port %s", port)
+	if err := r.Run(":" + port); err != nil {
+		log.Fatalf("Error starting server: %v", err)
+	}
+}
+
+// corsHeaders allows RDAP responses to be used by web clients as required by the RDAP Technical Implementation Guide
+func corsHeaders() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Header("Access-Control-Allow-Origin", "*")
+		ctx.Next()
+	}
+}
+
+// serveMetrics serves the Prometheus metrics, including the query counts, on METRICS_PORT if it is set.
+// The metrics are kept off the RDAP port as that one is public.
+func serveMetrics() {
+	port := os.Getenv("METRICS_PORT")
+	if port == "" {
+		return
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	go func() {
+		log.Printf("Metrics served on port %s", port)
+		if err := http.ListenAndServe(":"+port, mux); err != nil {
+			log.Printf("Error serving metrics: %v", err)
+		}
+	}()
+}
+
+func setupDB() (*gorm.DB, error) {
+	return postgres.NewConnection(
+		postgres.Config{
+			User:    os.Getenv("DB_USER"),
+			Pass:    os.Getenv("DB_PASS"),
+			Host:    os.Getenv("DB_HOST"),
+			Port:    os.Getenv("DB_PORT"),
+			DBName:  os.Getenv("DB_NAME"),
+			SSLmode: os.Getenv("DB_SSLMODE"),
+		},
+	)
+}
@@ -3,17 +3,25 @@ package main
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
+	"net/netip"
 	"os"
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
+	"github.com/onasunnymorning/domain-os/internal/application/interfaces"
 	"github.com/onasunnymorning/domain-os/internal/application/queries"
 	"github.com/onasunnymorning/domain-os/internal/application/services"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
 	"github.com/onasunnymorning/domain-os/internal/infrastructure/db/postgres"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"gorm.io/gorm"
 )
@@ -21,6 +29,8 @@ import (
 const (
 	// WHOIS_PORT is the default port for WHOIS servers.
 	WHOIS_PORT = 43
+	// WHOIS_CONNECTION_TIMEOUT is the time a client has to send its query and read the response
+	WHOIS_CONNECTION_TIMEOUT = 10 * time.Second
 )
 
 func main() {
@@ -33,10 +43,21 @@ func main() {
 		log.Fatalf("Error setting up database: %v", err)
 	}
 	domRepo := postgres.NewDomainRepository(db)
+	hostRepo := postgres.NewGormHostRepository(db)
 	rarRepo := postgres.NewGormRegistrarRepository(db)
+	contactRepo := postgres.NewContactRepository(db)
+	tldRepo := postgres.NewGormTLDRepo(db)
 
 	// Set up the Whois Service
-	WhoisSvc := services.NewWhoisService(domRepo, rarRepo)
+	WhoisSvc := services.NewWhoisService(domRepo, hostRepo, rarRepo, contactRepo, tldRepo)
+
+	// Set up the rate limiter, which also counts the queries for the monthly activity report
+	rateLimitCfg, err := services.ParseRDDSRateLimitConfig(os.Getenv("RDDS_RATE_LIMIT"), os.Getenv("RDDS_RATE_LIMIT_BURST"), os.Getenv("RDDS_RATE_LIMIT_ALLOWLIST"))
+	if err != nil {
+		log.Fatalf("Error reading the RDDS rate limit: %v", err)
+	}
+	limiter := services.NewTokenBucketRDDSRateLimiter(*rateLimitCfg, prometheus.DefaultRegisterer)
+	serveMetrics()
 
 	// Listen on port 43 for incoming WHOIS requests.
 	listener, err := net.Listen("tcp", ":"+fmt.Sprint(WHOIS_PORT))
@@ -57,7 +78,7 @@ func main() {
 		}
 
 		// Handle each connection in a new goroutine to allow concurrent clients.
-		go handleConnection(ctx, conn, WhoisSvc)
+		go handleConnection(ctx, conn, WhoisSvc, limiter)
 	}
 
 	<-ctx.Done()
@@ -65,8 +86,16 @@ func main() {
 	stop()
 }
 
-func handleConnection(ctx context.Context, conn net.Conn, svc *services.WhoisService) {
+func handleConnection(ctx context.Context, conn net.Conn, svc *services.WhoisService, limiter interfaces.RDDSRateLimiter) {
 	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(WHOIS_CONNECTION_TIMEOUT))
+
+	// Refuse the query if the client exceeded its limit, a remote address that cannot be parsed is not limited
+	source, _ := netip.ParseAddrPort(conn.RemoteAddr().String())
+	if !limiter.Allow(services.RDDS_SERVICE_WHOIS, source.Addr()) {
+		conn.Write([]byte(fmt.Sprintf("Error: %v\n", services.ErrRDDSQueryLimitExceeded)))
+		return
+	}
 
 	// Read the query from the connection.
 	reader := bufio.NewReader(conn)
@@ -95,19 +124,57 @@ func getWHOISResponse(ctx context.Context, query string, svc *services.WhoisServ
 	switch t {
 	case queries.WhoisQueryTypeDomainName:
 		resp, err := svc.GetDomainWhois(ctx, query)
-		if err != nil {
-			return fmt.Sprintf("Error getting WHOIS information for domain: %v\n", err)
+		if err == nil {
+			return resp.String()
 		}
-		return resp.String()
+		// A name that is not a registered domain may be a nameserver
+		if errors.Is(err, entities.ErrDomainNotFound) {
+			ns, nsErr := svc.GetNameserverWhois(ctx, query)
+			if nsErr == nil {
+				return ns.String()
+			}
+			if !errors.Is(nsErr, entities.ErrHostNotFound) {
+				return fmt.Sprintf("Error getting WHOIS information for nameserver: %v\n", nsErr)
+			}
+		}
+		return fmt.Sprintf("Error getting WHOIS information for domain: %v\n", err)
 	case queries.WhoisQueryTypeIP:
-		return fmt.Sprintf("Only Domain objects are supported for now - received WHOIS query for IP: %s\n", query)
+		nameservers, err := svc.GetNameserverWhoisByIP(ctx, query)
+		if err != nil {
+			return fmt.Sprintf("Error getting WHOIS information for IP address: %v\n", err)
+		}
+		responses := make([]string, len(nameservers))
+		for i, ns := range nameservers {
+			responses[i] = ns.String()
+		}
+		return strings.Join(responses, "\n")
 	case queries.WhoisQueryTypeRegistrar:
-		return fmt.Sprintf("Only Domain objects are supported for now - received WHOIS query for Registrar               s: %s\n", query)
+		resp, err := svc.GetRegistrarWhois(ctx, query)
+		if err != nil {
+			return fmt.Sprintf("Error getting WHOIS information for registrar: %v\n", err)
+		}
+		return resp.String()
 	default:
 		return fmt.Sprintf("Unknown WHOIS query type for: %s\n", query)
 	}
 }
 
+// serveMetrics serves the Prometheus metrics, including the query counts, on METRICS_PORT if it is set
+func serveMetrics() {
+	port := os.Getenv("METRICS_PORT")
+	if port == "" {
+		return
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	go func() {
+		log.Printf("Metrics served on port %s", port)
+		if err := http.ListenAndServe(":"+port, mux); err != nil {
+			log.Printf("Error serving metrics: %v", err)
+		}
+	}()
+}
+
 func setupDB() (*gorm.DB, error) {
 	return postgres.NewConnection(
 		postgres.Config{
@@ -34,7 +34,9 @@ func main() {
 	w.RegisterWorkflow(workflows.ExpiryLoop)
 	w.RegisterWorkflow(workflows.PurgeLoop)
 	w.RegisterWorkflow(workflows.RestoreWorkflow)
+	w.RegisterWorkflow(workflows.TransferAutoApproveLoop)
 	w.RegisterWorkflow(workflows.SyncRegistrarsWorkflow)
+	w.RegisterWorkflow(workflows.ZSKRolloverWorkflow)
 
 	// Register the activities
 	w.RegisterActivity(activities.CheckDomainCanAutoRenew)
@@ -46,9 +48,13 @@ func main() {
 	w.RegisterActivity(activities.GetPurgeableDomainCount)
 	w.RegisterActivity(activities.ListPurgeableDomains)
 	w.RegisterActivity(activities.ListRestoredDomains)
+	w.RegisterActivity(activities.ListExpiredTransfers)
+	w.RegisterActivity(activities.ApproveDomainTransfer)
 	w.RegisterActivity(activities.GetDomain)
 	w.RegisterActivity(activities.RenewDomain)
 	w.RegisterActivity(activities.UnSetDomainStatus)
+	w.RegisterActivity(activities.SetDomainStatus)
+	w.RegisterActivity(activities.CompleteDomainRestore)
 	w.RegisterActivity(activities.SyncIanaRegistrars)
 	w.RegisterActivity(activities.CountRegistrars)
 	w.RegisterActivity(activities.GetIANARegistrars)
@@ -56,6 +62,10 @@ func main() {
 	w.RegisterActivity(activities.SetRegistrarStatus)
 	w.RegisterActivity(activities.GetRegistrarListItems)
 	w.RegisterActivity(activities.CreateRegistrar)
+	w.RegisterActivity(activities.PrepublishZSK)
+	w.RegisterActivity(activities.ActivateZSK)
+	w.RegisterActivity(activities.RemoveRetiredZSKs)
+	w.RegisterActivity(activities.PublishDS)
 
 	// Start listening to the Task Queue.
 	err = w.Run(worker.InterruptCh())
@@ -0,0 +1,44 @@
+package activities
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ActivateZSK makes the pre-published ZSK with the key tag sign the TLD zone through the admin API. The ZSKs that were active until now stop signing.
+func ActivateZSK(correlationID, tldName string, keyTag uint16) error {
+	ENDPOINT := fmt.Sprintf("%s/tlds/%s/dnssec/zsk/%d/activate", BASEURL, tldName, keyTag)
+
+	// Set up an API client
+	client := http.Client{}
+
+	qParams := map[string]string{"correlationID": correlationID}
+	URL, err := getURLAndSetQueryParams(ENDPOINT, qParams)
+	if err != nil {
+		return fmt.Errorf("failed to add query params: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", URL.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Add("Authorization", BEARER_TOKEN)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to activate ZSK: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("(%d) %s", resp.StatusCode, body)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package activities
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ActivateZSKTestSuite struct {
+	suite.Suite
+	originalTransport http.RoundTripper
+	mockTransport     *MockRoundTripper
+}
+
+func (suite *ActivateZSKTestSuite) SetupTest() {
+	// Save the original transport and replace it with a mock
+	suite.originalTransport = http.DefaultTransport
+	suite.mockTransport = &MockRoundTripper{}
+	http.DefaultTransport = suite.mockTransport
+}
+
+func (suite *ActivateZSKTestSuite) TearDownTest() {
+	// Restore the original transport
+	http.DefaultTransport = suite.originalTransport
+}
+
+func (suite *ActivateZSKTestSuite) TestActivateZSK_Success() {
+	suite.mockTransport.Response = &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(`{"zone":"example.","keyTag":12345,"type":"ZSK"}`)),
+	}
+
+	err := ActivateZSK("testCorrelationID", "example", 12345)
+	suite.NoError(err, "Expected no error for successful activation")
+}
+
+func (suite *ActivateZSKTestSuite) TestActivateZSK_NotFound() {
+	suite.mockTransport.Response = &http.Response{
+		StatusCode: http.StatusNotFound,
+		Body:       io.NopCloser(bytes.NewBufferString(`{"error":"TLD not found"}`)),
+	}
+
+	err := ActivateZSK("testCorrelationID", "example", 12345)
+	suite.Error(err, "Expected an error for an unknown TLD")
+	suite.Contains(err.Error(), "404", "Error should include HTTP status code")
+	suite.Contains(err.Error(), "TLD not found", "Error should include response body")
+}
+
+func (suite *ActivateZSKTestSuite) TestActivateZSK_NetworkError() {
+	suite.mockTransport.Err = fmt.Errorf("network error")
+
+	err := ActivateZSK("testCorrelationID", "example", 12345)
+	suite.Error(err, "Expected an error for network failure")
+	suite.Contains(err.Error(), "failed to activate ZSK", "Error should indicate the failure")
+	suite.Contains(err.Error(), "network error", "Error should include network error details")
+}
+
+func TestActivateZSKTestSuite(t *testing.T) {
+	suite.Run(t, new(ActivateZSKTestSuite))
+}
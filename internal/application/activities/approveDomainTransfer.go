@@ -0,0 +1,56 @@
+package activities
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+)
+
+// ApproveDomainTransfer approves the pending transfer of a domain on behalf of the registry through the admin API.
+// This moves the domain to the gaining registrar and notifies both registrars.
+func ApproveDomainTransfer(correlationID, domainName string) error {
+	ENDPOINT := fmt.Sprintf("%s/domains/%s/transfer/approve", BASEURL, domainName)
+
+	// An empty ClID approves the transfer on behalf of the registry
+	jsonData, err := json.Marshal(commands.DomainTransferActionCommand{})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	// Set up an API client
+	client := http.Client{}
+
+	qParams := make(map[string]string)
+	qParams["correlationID"] = correlationID
+	URL, err := getURLAndSetQueryParams(ENDPOINT, qParams)
+	if err != nil {
+		return fmt.Errorf("failed to add query params: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", URL.String(), bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Add("Authorization", BEARER_TOKEN)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to approve transfer: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("(%d) %s", resp.StatusCode, body)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package activities
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ApproveDomainTransferTestSuite struct {
+	suite.Suite
+	originalTransport http.RoundTripper
+	mockTransport     *MockRoundTripper
+}
+
+func (suite *ApproveDomainTransferTestSuite) SetupTest() {
+	// Save the original transport and replace it with a mock
+	suite.originalTransport = http.DefaultTransport
+	suite.mockTransport = &MockRoundTripper{}
+	http.DefaultTransport = suite.mockTransport
+}
+
+func (suite *ApproveDomainTransferTestSuite) TearDownTest() {
+	// Restore the original transport
+	http.DefaultTransport = suite.originalTransport
+}
+
+func (suite *ApproveDomainTransferTestSuite) TestApproveDomainTransfer_Success() {
+	suite.mockTransport.Response = &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(`{"Status": "approved"}`)),
+	}
+
+	err := ApproveDomainTransfer("testCorrelationID", "example.com")
+	suite.NoError(err, "Expected no error for successful transfer approval")
+}
+
+func (suite *ApproveDomainTransferTestSuite) TestApproveDomainTransfer_BadRequest() {
+	suite.mockTransport.Response = &http.Response{
+		StatusCode: http.StatusBadRequest,
+		Body:       io.NopCloser(bytes.NewBufferString(`domain is not pending transfer`)),
+	}
+
+	err := ApproveDomainTransfer("testCorrelationID", "example.com")
+	suite.Error(err, "Expected an error for bad request")
+	suite.Contains(err.Error(), "400", "Error should include HTTP status code")
+	suite.Contains(err.Error(), "domain is not pending transfer", "Error should include response body")
+}
+
+func (suite *ApproveDomainTransferTestSuite) TestApproveDomainTransfer_NetworkError() {
+	suite.mockTransport.Err = fmt.Errorf("network error")
+
+	err := ApproveDomainTransfer("testCorrelationID", "example.com")
+	suite.Error(err, "Expected an error for network failure")
+	suite.Contains(err.Error(), "failed to approve transfer", "Error should indicate failure to approve transfer")
+	suite.Contains(err.Error(), "network error", "Error should include network error details")
+}
+
+func TestApproveDomainTransferTestSuite(t *testing.T) {
+	suite.Run(t, new(ApproveDomainTransferTestSuite))
+}
@@ -0,0 +1,55 @@
+package activities
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// CompleteDomainRestore sends a POST request to the admin API to complete the restore of a domain in pendingRestore status.
+// It returns the domain in its resulting state: if the pendingRestore status is still set the restore report is pending, if the pendingDelete status is set the report was not received in time and the domain was returned to the redemption period.
+func CompleteDomainRestore(correlationID, domainName string) (*entities.Domain, error) {
+	ENDPOINT := fmt.Sprintf("%s/domains/%s/restore/complete", BASEURL, domainName)
+
+	// Set up an API client
+	client := http.Client{}
+
+	qParams := make(map[string]string)
+	qParams["correlation_id"] = correlationID
+	URL, err := getURLAndSetQueryParams(ENDPOINT, qParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create URL: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", URL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Add("Authorization", BEARER_TOKEN)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete domain restore: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to complete domain restore (%d): %s", resp.StatusCode, body)
+	}
+
+	domain := &entities.Domain{}
+	err = json.Unmarshal(body, domain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return domain, nil
+}
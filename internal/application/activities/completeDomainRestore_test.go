@@ -0,0 +1,76 @@
+package activities
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CompleteDomainRestoreTestSuite struct {
+	suite.Suite
+	originalTransport http.RoundTripper
+	mockTransport     *MockRoundTripper
+}
+
+func (suite *CompleteDomainRestoreTestSuite) SetupTest() {
+	// Save the original transport and replace it with a mock
+	suite.originalTransport = http.DefaultTransport
+	suite.mockTransport = &MockRoundTripper{}
+	http.DefaultTransport = suite.mockTransport
+}
+
+func (suite *CompleteDomainRestoreTestSuite) TearDownTest() {
+	// Restore the original transport
+	http.DefaultTransport = suite.originalTransport
+}
+
+func (suite *CompleteDomainRestoreTestSuite) TestCompleteDomainRestore_Success() {
+	suite.mockTransport.Response = &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(`{"Name":"example.com","Status":{"PendingRestore":true}}`)),
+	}
+
+	dom, err := CompleteDomainRestore("correlation-id", "example.com")
+	suite.NoError(err, "Expected no error for successful request")
+	suite.Equal("example.com", dom.Name.String())
+	suite.True(dom.Status.PendingRestore)
+}
+
+func (suite *CompleteDomainRestoreTestSuite) TestCompleteDomainRestore_BadRequest() {
+	suite.mockTransport.Response = &http.Response{
+		StatusCode: http.StatusBadRequest,
+		Body:       io.NopCloser(bytes.NewBufferString(`Bad Request`)),
+	}
+
+	_, err := CompleteDomainRestore("correlation-id", "example.com")
+	suite.Error(err, "Expected an error for bad request")
+	suite.Contains(err.Error(), "failed to complete domain restore", "Error should indicate failure to complete the restore")
+	suite.Contains(err.Error(), "400", "Error should include HTTP status code")
+}
+
+func (suite *CompleteDomainRestoreTestSuite) TestCompleteDomainRestore_NetworkError() {
+	suite.mockTransport.Err = fmt.Errorf("network error")
+
+	_, err := CompleteDomainRestore("correlation-id", "example.com")
+	suite.Error(err, "Expected an error for network failure")
+	suite.Contains(err.Error(), "network error", "Error should include network error details")
+}
+
+func (suite *CompleteDomainRestoreTestSuite) TestCompleteDomainRestore_InvalidJSON() {
+	suite.mockTransport.Response = &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(`{invalid`)),
+	}
+
+	_, err := CompleteDomainRestore("correlation-id", "example.com")
+	suite.Error(err, "Expected an error for invalid JSON")
+	suite.Contains(err.Error(), "failed to unmarshal response")
+}
+
+func TestCompleteDomainRestoreTestSuite(t *testing.T) {
+	suite.Run(t, new(CompleteDomainRestoreTestSuite))
+}
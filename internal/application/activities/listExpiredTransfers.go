@@ -0,0 +1,67 @@
+package activities
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/interface/rest/response"
+)
+
+// ListExpiredTransfers returns a list of pending domain transfers that have passed their ExpiryDate (the TransferGP of the phase policy) without being approved, rejected or cancelled. It gets these through the admin API.
+func ListExpiredTransfers(correlationID string) ([]entities.DomainTransfer, error) {
+	ENDPOINT := fmt.Sprintf("%s/domains/transfers", BASEURL)
+
+	// set the correlation ID, pagesize and filters
+	qParams := make(map[string]string)
+	qParams["correlationID"] = correlationID
+	qParams["pagesize"] = fmt.Sprintf("%d", BATCHSIZE)
+	qParams["status_equals"] = string(entities.TransferStatusPending)
+	qParams["expires_before"] = time.Now().UTC().Format(time.RFC3339)
+	URL, err := getURLAndSetQueryParams(ENDPOINT, qParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add query params: %w", err)
+	}
+
+	// Set up an API client
+	client := http.Client{}
+
+	// Retrieve the list of transfers
+	req, err := http.NewRequest("GET", URL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Add("Authorization", BEARER_TOKEN)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch expired transfers: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch expired transfers (%d): %s", resp.StatusCode, body)
+	}
+
+	// Parse the result
+	listResponse := &ListDomainTransfersResult{}
+	err = json.Unmarshal(body, &listResponse)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to unmarshal response"), err)
+	}
+
+	return listResponse.Data, nil
+}
+
+type ListDomainTransfersResult struct {
+	Meta response.PaginationMetaData `json:"meta"`
+	Data []entities.DomainTransfer   `json:"data"`
+}
@@ -0,0 +1,102 @@
+package activities
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/suite"
+)
+
+type ListExpiredTransfersTestSuite struct {
+	suite.Suite
+	originalTransport http.RoundTripper
+	mockTransport     *MockRoundTripper
+}
+
+func (suite *ListExpiredTransfersTestSuite) SetupTest() {
+	// Save the original transport and replace it with a mock
+	suite.originalTransport = http.DefaultTransport
+	suite.mockTransport = &MockRoundTripper{}
+	http.DefaultTransport = suite.mockTransport
+}
+
+func (suite *ListExpiredTransfersTestSuite) TearDownTest() {
+	// Restore the original transport
+	http.DefaultTransport = suite.originalTransport
+}
+
+func (suite *ListExpiredTransfersTestSuite) TestListExpiredTransfers_Success() {
+	body := `{
+		"meta": {
+			"pageSize": 1000
+		},
+		"data": [
+			{
+				"ID": "2b1d6c8e-3f4a-4c1e-9b7d-1a2b3c4d5e6f",
+				"DomainName": "example1.com",
+				"GainingRegistrar": "gainingRar",
+				"LosingRegistrar": "losingRar",
+				"Status": "pending"
+			},
+			{
+				"ID": "8f7e6d5c-4b3a-4291-8e7f-6a5b4c3d2e1f",
+				"DomainName": "example2.com",
+				"GainingRegistrar": "gainingRar",
+				"LosingRegistrar": "losingRar",
+				"Status": "pending"
+			}
+		]
+	}`
+	suite.mockTransport.Response = &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+	}
+
+	result, err := ListExpiredTransfers("testCorrelationID")
+	suite.NoError(err, "Expected no error for successful response")
+	suite.Len(result, 2, "Expected two transfers in the result")
+	suite.Equal(entities.DomainName("example1.com"), result[0].DomainName, "Expected first domain name to match")
+	suite.Equal(entities.ClIDType("gainingRar"), result[1].GainingRegistrar, "Expected gaining registrar to match")
+	suite.Equal(entities.TransferStatusPending, result[1].Status, "Expected transfer to be pending")
+}
+
+func (suite *ListExpiredTransfersTestSuite) TestListExpiredTransfers_BadRequest() {
+	suite.mockTransport.Response = &http.Response{
+		StatusCode: http.StatusBadRequest,
+		Body:       io.NopCloser(bytes.NewBufferString(`Bad Request`)),
+	}
+
+	result, err := ListExpiredTransfers("testCorrelationID")
+	suite.Error(err, "Expected an error for bad request")
+	suite.Nil(result, "Expected no result for bad request")
+	suite.Contains(err.Error(), "400", "Error should include HTTP status code")
+}
+
+func (suite *ListExpiredTransfersTestSuite) TestListExpiredTransfers_NetworkError() {
+	suite.mockTransport.Err = fmt.Errorf("network error")
+
+	result, err := ListExpiredTransfers("testCorrelationID")
+	suite.Error(err, "Expected an error for network failure")
+	suite.Nil(result, "Expected no result for network error")
+	suite.Contains(err.Error(), "failed to fetch expired transfers", "Error should indicate network failure")
+}
+
+func (suite *ListExpiredTransfersTestSuite) TestListExpiredTransfers_ParseError() {
+	suite.mockTransport.Response = &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(`invalid json`)),
+	}
+
+	result, err := ListExpiredTransfers("testCorrelationID")
+	suite.Error(err, "Expected an error for invalid JSON response")
+	suite.Nil(result, "Expected no result for invalid JSON")
+	suite.Contains(err.Error(), "failed to unmarshal response", "Error should indicate parse failure")
+}
+
+func TestListExpiredTransfersTestSuite(t *testing.T) {
+	suite.Run(t, new(ListExpiredTransfersTestSuite))
+}
@@ -0,0 +1,52 @@
+package activities
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/onasunnymorning/domain-os/internal/interface/rest/response"
+)
+
+// PrepublishZSK creates a new ZSK for the TLD through the admin API. The key is added to the DNSKEY RRset of the zone but does not sign it until it is activated.
+func PrepublishZSK(correlationID, tldName string) (*response.DNSSECKey, error) {
+	ENDPOINT := fmt.Sprintf("%s/tlds/%s/dnssec/zsk/prepublish", BASEURL, tldName)
+
+	// Set up an API client
+	client := http.Client{}
+
+	qParams := map[string]string{"correlationID": correlationID}
+	URL, err := getURLAndSetQueryParams(ENDPOINT, qParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add query params: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", URL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Add("Authorization", BEARER_TOKEN)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pre-publish ZSK: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("(%d) %s", resp.StatusCode, body)
+	}
+
+	var key response.DNSSECKey
+	if err := json.Unmarshal(body, &key); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return &key, nil
+}
@@ -0,0 +1,66 @@
+package activities
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PrepublishZSKTestSuite struct {
+	suite.Suite
+	originalTransport http.RoundTripper
+	mockTransport     *MockRoundTripper
+}
+
+func (suite *PrepublishZSKTestSuite) SetupTest() {
+	// Save the original transport and replace it with a mock
+	suite.originalTransport = http.DefaultTransport
+	suite.mockTransport = &MockRoundTripper{}
+	http.DefaultTransport = suite.mockTransport
+}
+
+func (suite *PrepublishZSKTestSuite) TearDownTest() {
+	// Restore the original transport
+	http.DefaultTransport = suite.originalTransport
+}
+
+func (suite *PrepublishZSKTestSuite) TestPrepublishZSK_Success() {
+	suite.mockTransport.Response = &http.Response{
+		StatusCode: http.StatusCreated,
+		Body:       io.NopCloser(bytes.NewBufferString(`{"zone":"example.","keyTag":12345,"type":"ZSK","algorithm":"ECDSAP256SHA256"}`)),
+	}
+
+	key, err := PrepublishZSK("testCorrelationID", "example")
+	suite.NoError(err, "Expected no error for successful pre-publish")
+	suite.Equal(uint16(12345), key.KeyTag)
+	suite.Equal("ZSK", key.Type)
+}
+
+func (suite *PrepublishZSKTestSuite) TestPrepublishZSK_NotFound() {
+	suite.mockTransport.Response = &http.Response{
+		StatusCode: http.StatusNotFound,
+		Body:       io.NopCloser(bytes.NewBufferString(`{"error":"TLD not found"}`)),
+	}
+
+	_, err := PrepublishZSK("testCorrelationID", "example")
+	suite.Error(err, "Expected an error for an unknown TLD")
+	suite.Contains(err.Error(), "404", "Error should include HTTP status code")
+	suite.Contains(err.Error(), "TLD not found", "Error should include response body")
+}
+
+func (suite *PrepublishZSKTestSuite) TestPrepublishZSK_NetworkError() {
+	suite.mockTransport.Err = fmt.Errorf("network error")
+
+	_, err := PrepublishZSK("testCorrelationID", "example")
+	suite.Error(err, "Expected an error for network failure")
+	suite.Contains(err.Error(), "failed to pre-publish ZSK", "Error should indicate the failure")
+	suite.Contains(err.Error(), "network error", "Error should include network error details")
+}
+
+func TestPrepublishZSKTestSuite(t *testing.T) {
+	suite.Run(t, new(PrepublishZSKTestSuite))
+}
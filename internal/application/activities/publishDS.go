@@ -0,0 +1,52 @@
+package activities
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/onasunnymorning/domain-os/internal/interface/rest/response"
+)
+
+// PublishDS stores the DS records of the TLD for submission to the parent zone through the admin API and returns them
+func PublishDS(correlationID, tldName string) (*response.DSResponse, error) {
+	ENDPOINT := fmt.Sprintf("%s/tlds/%s/dnssec/ds", BASEURL, tldName)
+
+	// Set up an API client
+	client := http.Client{}
+
+	qParams := map[string]string{"correlationID": correlationID}
+	URL, err := getURLAndSetQueryParams(ENDPOINT, qParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add query params: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", URL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Add("Authorization", BEARER_TOKEN)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish DS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("(%d) %s", resp.StatusCode, body)
+	}
+
+	var ds response.DSResponse
+	if err := json.Unmarshal(body, &ds); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return &ds, nil
+}
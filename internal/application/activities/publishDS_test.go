@@ -0,0 +1,66 @@
+package activities
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PublishDSTestSuite struct {
+	suite.Suite
+	originalTransport http.RoundTripper
+	mockTransport     *MockRoundTripper
+}
+
+func (suite *PublishDSTestSuite) SetupTest() {
+	// Save the original transport and replace it with a mock
+	suite.originalTransport = http.DefaultTransport
+	suite.mockTransport = &MockRoundTripper{}
+	http.DefaultTransport = suite.mockTransport
+}
+
+func (suite *PublishDSTestSuite) TearDownTest() {
+	// Restore the original transport
+	http.DefaultTransport = suite.originalTransport
+}
+
+func (suite *PublishDSTestSuite) TestPublishDS_Success() {
+	suite.mockTransport.Response = &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(`{"tld":"example","ds":["example.\t3600\tIN\tDS\t12345 13 2 E2D3C916F6DEEAC73294E8268FB5885044A833FC5459588F4A9184CFC41A5766"]}`)),
+	}
+
+	ds, err := PublishDS("testCorrelationID", "example")
+	suite.NoError(err, "Expected no error for successful DS publication")
+	suite.Equal("example", ds.TLD)
+	suite.Len(ds.DS, 1)
+}
+
+func (suite *PublishDSTestSuite) TestPublishDS_NotFound() {
+	suite.mockTransport.Response = &http.Response{
+		StatusCode: http.StatusNotFound,
+		Body:       io.NopCloser(bytes.NewBufferString(`{"error":"TLD not found"}`)),
+	}
+
+	_, err := PublishDS("testCorrelationID", "example")
+	suite.Error(err, "Expected an error for an unknown TLD")
+	suite.Contains(err.Error(), "404", "Error should include HTTP status code")
+	suite.Contains(err.Error(), "TLD not found", "Error should include response body")
+}
+
+func (suite *PublishDSTestSuite) TestPublishDS_NetworkError() {
+	suite.mockTransport.Err = fmt.Errorf("network error")
+
+	_, err := PublishDS("testCorrelationID", "example")
+	suite.Error(err, "Expected an error for network failure")
+	suite.Contains(err.Error(), "failed to publish DS", "Error should indicate the failure")
+	suite.Contains(err.Error(), "network error", "Error should include network error details")
+}
+
+func TestPublishDSTestSuite(t *testing.T) {
+	suite.Run(t, new(PublishDSTestSuite))
+}
@@ -0,0 +1,52 @@
+package activities
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/onasunnymorning/domain-os/internal/interface/rest/response"
+)
+
+// RemoveRetiredZSKs removes the ZSKs that no longer sign the TLD zone from its DNSKEY RRset through the admin API and returns the removed keys
+func RemoveRetiredZSKs(correlationID, tldName string) ([]response.DNSSECKey, error) {
+	ENDPOINT := fmt.Sprintf("%s/tlds/%s/dnssec/zsk/retired", BASEURL, tldName)
+
+	// Set up an API client
+	client := http.Client{}
+
+	qParams := map[string]string{"correlationID": correlationID}
+	URL, err := getURLAndSetQueryParams(ENDPOINT, qParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add query params: %w", err)
+	}
+
+	req, err := http.NewRequest("DELETE", URL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Add("Authorization", BEARER_TOKEN)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove retired ZSKs: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("(%d) %s", resp.StatusCode, body)
+	}
+
+	var keys []response.DNSSECKey
+	if err := json.Unmarshal(body, &keys); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return keys, nil
+}
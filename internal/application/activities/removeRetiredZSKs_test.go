@@ -0,0 +1,66 @@
+package activities
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RemoveRetiredZSKsTestSuite struct {
+	suite.Suite
+	originalTransport http.RoundTripper
+	mockTransport     *MockRoundTripper
+}
+
+func (suite *RemoveRetiredZSKsTestSuite) SetupTest() {
+	// Save the original transport and replace it with a mock
+	suite.originalTransport = http.DefaultTransport
+	suite.mockTransport = &MockRoundTripper{}
+	http.DefaultTransport = suite.mockTransport
+}
+
+func (suite *RemoveRetiredZSKsTestSuite) TearDownTest() {
+	// Restore the original transport
+	http.DefaultTransport = suite.originalTransport
+}
+
+func (suite *RemoveRetiredZSKsTestSuite) TestRemoveRetiredZSKs_Success() {
+	suite.mockTransport.Response = &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(`[{"zone":"example.","keyTag":12345,"type":"ZSK"}]`)),
+	}
+
+	keys, err := RemoveRetiredZSKs("testCorrelationID", "example")
+	suite.NoError(err, "Expected no error for successful removal")
+	suite.Len(keys, 1)
+	suite.Equal(uint16(12345), keys[0].KeyTag)
+}
+
+func (suite *RemoveRetiredZSKsTestSuite) TestRemoveRetiredZSKs_NotFound() {
+	suite.mockTransport.Response = &http.Response{
+		StatusCode: http.StatusNotFound,
+		Body:       io.NopCloser(bytes.NewBufferString(`{"error":"TLD not found"}`)),
+	}
+
+	_, err := RemoveRetiredZSKs("testCorrelationID", "example")
+	suite.Error(err, "Expected an error for an unknown TLD")
+	suite.Contains(err.Error(), "404", "Error should include HTTP status code")
+	suite.Contains(err.Error(), "TLD not found", "Error should include response body")
+}
+
+func (suite *RemoveRetiredZSKsTestSuite) TestRemoveRetiredZSKs_NetworkError() {
+	suite.mockTransport.Err = fmt.Errorf("network error")
+
+	_, err := RemoveRetiredZSKs("testCorrelationID", "example")
+	suite.Error(err, "Expected an error for network failure")
+	suite.Contains(err.Error(), "failed to remove retired ZSKs", "Error should indicate the failure")
+	suite.Contains(err.Error(), "network error", "Error should include network error details")
+}
+
+func TestRemoveRetiredZSKsTestSuite(t *testing.T) {
+	suite.Run(t, new(RemoveRetiredZSKsTestSuite))
+}
@@ -0,0 +1,25 @@
+package commands
+
+import "time"
+
+// CreateAllocationTokenCommand is the command to create an allocation token. The token is bound to the TLD, or to the TLD of the domain if a domain is provided.
+type CreateAllocationTokenCommand struct {
+	Token string `json:"Token"` // if not provided, a random token is generated
+	AllocationTokenBinding
+}
+
+// UpdateAllocationTokenCommand is the command to update the binding of an existing allocation token, all fields are replaced
+type UpdateAllocationTokenCommand struct {
+	AllocationTokenBinding
+}
+
+// AllocationTokenBinding holds the domain, registrar and phase an allocation token is bound to and the conditions under which it can be used
+type AllocationTokenBinding struct {
+	TLDName            string    `json:"TLDName"`            // required if no DomainName is provided
+	DomainName         string    `json:"DomainName"`         // if empty the token can be used for any domain in the TLD
+	ClID               string    `json:"ClID"`               // if empty any registrar can use the token
+	PhaseName          string    `json:"PhaseName"`          // if empty the token can be used in any phase
+	ExpiresAt          time.Time `json:"ExpiresAt"`          // if empty the token does not expire
+	MultiUse           bool      `json:"MultiUse"`           // by default a token can only be redeemed once
+	DiscountPercentage int       `json:"DiscountPercentage"` // discount on the registration price in percent (0-100)
+}
@@ -0,0 +1,38 @@
+package commands
+
+import "github.com/onasunnymorning/domain-os/internal/domain/entities"
+
+// CreateDomainApplicationCommand is a command to apply for a domain in a launch phase that requires validation
+type CreateDomainApplicationCommand struct {
+	Name         string                 `json:"Name" binding:"required"`
+	ClID         string                 `json:"ClID" binding:"required"`
+	PhaseName    string                 `json:"PhaseName" binding:"required"` // The launch phase to apply in
+	AuthInfo     string                 `json:"AuthInfo"  binding:"required"`
+	RegistrantID string                 `json:"RegistrantID"` // Contacts must exist before applying for a domain
+	AdminID      string                 `json:"AdminID"`      // Contacts must exist before applying for a domain
+	TechID       string                 `json:"TechID"`       // Contacts must exist before applying for a domain
+	BillingID    string                 `json:"BillingID"`    // Contacts must exist before applying for a domain
+	Years        int                    `json:"Years"`        // if not provided, it will be 1
+	HostNames    []string               `json:"HostNames"`    // HostNames must exist before applying for a domain
+	Mark         string                 `json:"Mark"`         // Required in the sunrise phase, the mark of the trademark holder (e.g. an encoded signed mark)
+	ClaimsNotice *entities.ClaimsNotice `json:"ClaimsNotice"` // Required during the claims phase if the domain matches a trademark
+}
+
+// ApplyContactDataPolicy modifies the command’s registrant, admin, tech, and billing
+// contact IDs according to the provided contact data policy. It returns an error if
+// the operation fails due to invalid or missing data.
+func (cmd *CreateDomainApplicationCommand) ApplyContactDataPolicy(policy entities.ContactDataPolicy) error {
+	return applyContactDataPolicy(
+		policy,
+		&cmd.RegistrantID,
+		&cmd.AdminID,
+		&cmd.TechID,
+		&cmd.BillingID,
+	)
+}
+
+// DomainApplicationStatusCommand is a command to change the status of a domain application, e.g. after validating the mark
+type DomainApplicationStatusCommand struct {
+	Status string `json:"Status" binding:"required"` // validated, invalid, pendingAllocation or rejected
+	Reason string `json:"Reason"`                    // optional, e.g. why the application is invalid
+}
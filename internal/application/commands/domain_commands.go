@@ -1,7 +1,10 @@
 package commands
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/onasunnymorning/domain-os/internal/domain/entities"
@@ -13,17 +16,21 @@ var (
 
 // RegisterDomainCommand is a command to register a domain
 type RegisterDomainCommand struct {
-	Name         string       `json:"Name" binding:"required"`
-	ClID         string       `json:"ClID" binding:"required"`
-	AuthInfo     string       `json:"AuthInfo"  binding:"required"`
-	RegistrantID string       `json:"RegistrantID"` // Contacts must exist before registering a domain
-	AdminID      string       `json:"AdminID"`      // Contacts must exist before registering a domain
-	TechID       string       `json:"TechID"`       // Contacts must exist before registering a domain
-	BillingID    string       `json:"BillingID"`    // Contacts must exist before registering a domain
-	Years        int          `json:"Years"`        // if not provided, it will be 1
-	HostNames    []string     `json:"HostNames"`    // HostNames must exist before registering a domain
-	PhaseName    string       `json:"PhaseName"`    // Optional, if provided the domain will be registered (and validated) in this phase, if omitted the active GA phase will be used
-	Fee          FeeExtension `json:"Fee"`          // Optional, if provided must match the calculated fee, if not provided the fee calculated fee will be used regardless of the amount or class
+	Name            string                 `json:"Name" binding:"required"`
+	ClID            string                 `json:"ClID" binding:"required"`
+	AuthInfo        string                 `json:"AuthInfo"  binding:"required"`
+	RegistrantID    string                 `json:"RegistrantID"`    // Contacts must exist before registering a domain
+	AdminID         string                 `json:"AdminID"`         // Contacts must exist before registering a domain
+	TechID          string                 `json:"TechID"`          // Contacts must exist before registering a domain
+	BillingID       string                 `json:"BillingID"`       // Contacts must exist before registering a domain
+	Years           int                    `json:"Years"`           // if not provided, it will be 1
+	HostNames       []string               `json:"HostNames"`       // HostNames must exist before registering a domain
+	PhaseName       string                 `json:"PhaseName"`       // Optional, if provided the domain will be registered (and validated) in this phase, if omitted the active GA phase will be used
+	Fee             FeeExtension           `json:"Fee"`             // Optional, if provided must match the calculated fee, if not provided the fee calculated fee will be used regardless of the amount or class
+	SecDNS          entities.DomainSecDNS  `json:"SecDNS"`          // Optional, the DNSSEC delegation data of the domain
+	Mark            string                 `json:"Mark"`            // Required in the sunrise phase, the mark of the trademark holder (e.g. an encoded signed mark)
+	ClaimsNotice    *entities.ClaimsNotice `json:"ClaimsNotice"`    // Required during the claims phase if the domain matches a trademark
+	AllocationToken string                 `json:"AllocationToken"` // Optional, allows the registration of a blocked domain and applies the discount of the token
 }
 
 // ApplyContactDataPolicy modifies the command’s registrant, admin, tech, and billing
@@ -47,10 +54,77 @@ type RenewDomainCommand struct {
 	Fee   FeeExtension `json:"Fee"`   // Optional, if provided must match the calculated fee, if not provided, the renew is allowed and any cost
 }
 
+// TransferDomainCommand is a command to request the transfer of a domain to the registrar with the provided ClID
+type TransferDomainCommand struct {
+	Name     string `json:"Name" binding:"required"`
+	ClID     string `json:"ClID" binding:"required"`     // the gaining registrar
+	AuthInfo string `json:"AuthInfo" binding:"required"` // the authInfo of the domain
+	Years    int    `json:"Years"`                       // if not provided, it will be 1
+}
+
+// ToEntity converts the TransferDomainCommand to an entities.RequestTransferCommand
+func (cmd *TransferDomainCommand) ToEntity() *entities.RequestTransferCommand {
+	return &entities.RequestTransferCommand{
+		DomainName:       cmd.Name,
+		GainingRegistrar: cmd.ClID,
+		AuthInfo:         cmd.AuthInfo,
+		Years:            cmd.Years,
+	}
+}
+
+// DomainTransferActionCommand is a command to approve, reject or cancel the pending transfer of a domain
+type DomainTransferActionCommand struct {
+	ClID   string `json:"ClID"`   // the registrar acting on the transfer, leave empty to act on behalf of the registry
+	Reason string `json:"Reason"` // optional, only used when rejecting a transfer
+}
+
+// RestoreReportCommand is a command to submit the restore report that completes the restore of a domain in pendingRestore status (RFC 3915)
+type RestoreReportCommand struct {
+	Name       string    `json:"Name" binding:"required"`
+	ClID       string    `json:"ClID"`       // the sponsoring registrar, leave empty to submit the report on behalf of the registry
+	PreData    string    `json:"PreData"`    // the registration data before the domain was deleted
+	PostData   string    `json:"PostData"`   // the registration data at the time the report is submitted
+	DelTime    time.Time `json:"DelTime"`    // the time the domain was deleted
+	ResTime    time.Time `json:"ResTime"`    // the time the restore was requested
+	ResReason  string    `json:"ResReason"`  // the reason for restoring the domain
+	Statements []string  `json:"Statements"` // exactly two statements are required
+	Other      string    `json:"Other"`      // optional, information supporting the statements
+}
+
+// ToEntity converts the RestoreReportCommand to an entities.DomainRestoreReport
+func (cmd *RestoreReportCommand) ToEntity() entities.DomainRestoreReport {
+	return entities.DomainRestoreReport{
+		PreData:    cmd.PreData,
+		PostData:   cmd.PostData,
+		DelTime:    cmd.DelTime.UTC(),
+		ResTime:    cmd.ResTime.UTC(),
+		ResReason:  cmd.ResReason,
+		Statements: cmd.Statements,
+		Other:      cmd.Other,
+	}
+}
+
 // FeeExtension is a struct that can optionally be included in commands to provide information about the price
 type FeeExtension struct {
 	Currency string `json:"Currency"`
 	Amount   int64  `json:"Amount"`
+	// Provided is set when the client provided the fee, so an agreed fee of 0 can be told apart from no fee
+	Provided bool `json:"-"`
+}
+
+// UnmarshalJSON decodes the FeeExtension and marks it as provided
+func (f *FeeExtension) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	type feeExtension FeeExtension
+	var fee feeExtension
+	if err := json.Unmarshal(data, &fee); err != nil {
+		return err
+	}
+	*f = FeeExtension(fee)
+	f.Provided = true
+	return nil
 }
 
 // IsZero checks if the FeeExtension instance is equal to the zero value of FeeExtension.
@@ -59,6 +133,18 @@ func (f FeeExtension) IsZero() bool {
 	return f == FeeExtension{}
 }
 
+// Validate checks the FeeExtension against the quote for the transaction. The currency is only compared if it is set.
+// It returns ErrFeeMismatch if the client would be charged a different amount than it agreed to.
+func (f FeeExtension) Validate(quote *entities.Quote) error {
+	if f.Currency != "" && strings.ToUpper(f.Currency) != quote.Price.Currency().Code {
+		return errors.Join(entities.ErrFeeMismatch, fmt.Errorf("currency mismatch: requested %s, got %s", strings.ToUpper(f.Currency), quote.Price.Currency().Code))
+	}
+	if f.Amount != quote.Price.Amount() {
+		return errors.Join(entities.ErrFeeMismatch, fmt.Errorf("amount mismatch: requested %d, got %d", f.Amount, quote.Price.Amount()))
+	}
+	return nil
+}
+
 // CreateDomainCommand is a command to create a domain. This is intended for admin or import purposes. Normal Registrar transactions should use the RegisterDomainCommand and RenewDomainCommand ...
 type CreateDomainCommand struct {
 	RoID               string                        `json:"RoID"` // if not provided, it will be generated
@@ -81,6 +167,7 @@ type CreateDomainCommand struct {
 	Status             entities.DomainStatus         `json:"Status"`
 	RGPStatus          entities.DomainRGPStatus      `json:"RGPStatus"`
 	GrandFathering     entities.DomainGrandFathering `json:"GrandFathering"`
+	SecDNS             entities.DomainSecDNS         `json:"SecDNS"`
 	EnforcePhasePolicy bool                          `json:"EnforcePhasePolicy"`
 }
 
@@ -166,6 +253,7 @@ func (cmd *CreateDomainCommand) FromRdeDomain(rdeDomain *entities.RDEDomain) (*F
 	cmd.Status = dom.Status
 	cmd.RGPStatus = dom.RGPStatus
 	cmd.RenewedYears = dom.RenewedYears
+	cmd.SecDNS = dom.SecDNS
 
 	return &finalResult, nil
 }
@@ -189,6 +277,7 @@ type UpdateDomainCommand struct {
 	Status             entities.DomainStatus         `json:"Status"`
 	RGPStatus          entities.DomainRGPStatus      `json:"RGPStatus"`
 	GrandFathering     entities.DomainGrandFathering `json:"GrandFathering"`
+	SecDNS             entities.DomainSecDNS         `json:"SecDNS"`
 	EnforcePhasePolicy bool                          `json:"EnforcePhasePolicy"`
 }
 
@@ -223,6 +312,57 @@ func (cmd *UpdateDomainCommand) FromEntity(dom *entities.Domain) {
 	cmd.UpdatedAt = dom.UpdatedAt
 	cmd.Status = dom.Status
 	cmd.RGPStatus = dom.RGPStatus
+	cmd.SecDNS = dom.SecDNS
+}
+
+// ApplyDomainUpdateCommand holds all changes of a single domain update as sent by a registrar (e.g. an EPP <update>).
+// The changes are applied together and persisted in a single transaction: either all of them succeed or none are saved.
+// The command only holds what changes, the changes are applied to the domain as it is stored when the update is persisted so concurrent updates are not lost.
+type ApplyDomainUpdateCommand struct {
+	Name string
+	// ClID is the registrar requesting the update, it must sponsor the domain
+	ClID        string
+	RemStatuses []string
+	AddStatuses []string
+	// RemContacts are removed before AddContacts are added so a contact can be replaced in a single command
+	RemContacts []DomainContactChange
+	AddContacts []DomainContactChange
+	// RegistrantID and AuthInfo are nil if they don't change
+	RegistrantID *string
+	AuthInfo     *string
+	// SecDNS is nil if the DNSSEC delegation data doesn't change
+	SecDNS   *DomainSecDNSChanges
+	RemHosts []string
+	AddHosts []string
+	// EnforcePhasePolicy applies the contact data policy of the current GA phase when the contacts change
+	EnforcePhasePolicy bool
+}
+
+// DomainContactChange is a contact that is added to or removed from a domain
+type DomainContactChange struct {
+	// Type is the contact type: admin, tech or billing
+	Type string
+	ID   string
+}
+
+// DomainSecDNSChanges holds the changes to the DNSSEC delegation data of a domain.
+// DS records are removed before they are added so a DS record can be replaced in a single command (RFC 5910 section 5.2.5).
+type DomainSecDNSChanges struct {
+	RemAll    bool
+	RemDSData []entities.DomainDSData
+	AddDSData []entities.DomainDSData
+	// MaxSigLife is nil if it doesn't change
+	MaxSigLife *int
+}
+
+// HasContactChanges returns true if the command changes the contacts or the authInfo of the domain
+func (cmd *ApplyDomainUpdateCommand) HasContactChanges() bool {
+	return len(cmd.RemContacts) > 0 || len(cmd.AddContacts) > 0 || cmd.RegistrantID != nil || cmd.AuthInfo != nil
+}
+
+// HasObjectChanges returns true if the command changes more than the statuses of the domain, these changes are not allowed when the domain has an update prohibited status
+func (cmd *ApplyDomainUpdateCommand) HasObjectChanges() bool {
+	return cmd.HasContactChanges() || cmd.SecDNS != nil || len(cmd.RemHosts) > 0 || len(cmd.AddHosts) > 0
 }
 
 // applyContactDataPolicy enforces the appropriate contact data policy rules for the
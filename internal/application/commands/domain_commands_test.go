@@ -1,9 +1,11 @@
 package commands
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
+	"github.com/Rhymond/go-money"
 	"github.com/onasunnymorning/domain-os/internal/domain/entities"
 	"github.com/stretchr/testify/require"
 )
@@ -152,6 +154,9 @@ func TestUpdateDomainCommand_FromEntity(t *testing.T) {
 		UpdatedAt:    time.Now(),
 		Status:       entities.DomainStatus{},
 		RGPStatus:    entities.DomainRGPStatus{},
+		SecDNS: entities.DomainSecDNS{
+			DSData: []entities.DomainDSData{{KeyTag: 12345, Alg: 13, DigestType: 2, Digest: "E2D3C916F6DEEAC73294E8268FB5885044A833FC5459588F4A9184CFC41A5766"}},
+		},
 	}
 
 	cmd := &UpdateDomainCommand{}
@@ -172,6 +177,7 @@ func TestUpdateDomainCommand_FromEntity(t *testing.T) {
 	require.Equal(t, dom.UpdatedAt, cmd.UpdatedAt)
 	require.Equal(t, dom.Status, cmd.Status)
 	require.Equal(t, dom.RGPStatus, cmd.RGPStatus)
+	require.Equal(t, dom.SecDNS, cmd.SecDNS)
 }
 
 func TestRegisterDomainCommand_ApplyContactDataPolicy(t *testing.T) {
@@ -609,3 +615,43 @@ func TestFeeExtension_IsZero(t *testing.T) {
 		})
 	}
 }
+
+func TestFeeExtension_UnmarshalJSON(t *testing.T) {
+	var cmd RenewDomainCommand
+	require.NoError(t, json.Unmarshal([]byte(`{"Name":"example.com","ClID":"ClID-1","Fee":{"Currency":"USD","Amount":0}}`), &cmd))
+	require.True(t, cmd.Fee.Provided)
+	require.Equal(t, FeeExtension{Currency: "USD", Provided: true}, cmd.Fee)
+
+	cmd = RenewDomainCommand{}
+	require.NoError(t, json.Unmarshal([]byte(`{"Name":"example.com","ClID":"ClID-1"}`), &cmd))
+	require.False(t, cmd.Fee.Provided)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"Name":"example.com","ClID":"ClID-1","Fee":null}`), &cmd))
+	require.False(t, cmd.Fee.Provided)
+}
+
+func TestFeeExtension_Validate(t *testing.T) {
+	quote := entities.NewQuote("USD")
+	quote.Price = money.New(1500, "USD")
+
+	tcases := []struct {
+		name    string
+		fee     FeeExtension
+		wantErr error
+	}{
+		{"match", FeeExtension{Currency: "USD", Amount: 1500}, nil},
+		{"match lower case currency", FeeExtension{Currency: "usd", Amount: 1500}, nil},
+		{"match without currency", FeeExtension{Amount: 1500}, nil},
+		{"currency mismatch", FeeExtension{Currency: "EUR", Amount: 1500}, entities.ErrFeeMismatch},
+		{"amount too low", FeeExtension{Currency: "USD", Amount: 1000}, entities.ErrFeeMismatch},
+		{"amount too high", FeeExtension{Currency: "USD", Amount: 2000}, entities.ErrFeeMismatch},
+		{"zero amount", FeeExtension{Currency: "USD"}, entities.ErrFeeMismatch},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.fee.Validate(quote)
+			require.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
@@ -27,6 +27,29 @@ type UpdateRegistrarStatusCommand struct {
 	OldStatus string
 }
 
+// SetRegistrarEPPPasswordCommand represents a command to set the password a registrar uses to login to the EPP server.
+type SetRegistrarEPPPasswordCommand struct {
+	Password string `json:"Password" binding:"required"`
+}
+
+// EPPLoginCommand represents the EPP <login> command of a registrar including the user agent of the login security extension (RFC 8807).
+// If NewPassword is set, it replaces the current password after successful authentication.
+type EPPLoginCommand struct {
+	ClID        string
+	Password    string
+	NewPassword string
+	UserAgent   entities.EPPUserAgent
+}
+
+// EPPLoginResult is the result of a successful EPP login
+type EPPLoginResult struct {
+	Registrar *entities.Registrar
+	// FailedLogins is the number of failed login attempts since the previous successful login
+	FailedLogins int
+	// PasswordChanged is true if the password was changed as part of the login
+	PasswordChanged bool
+}
+
 // ChunkCreateRegistrarCommands returns a channel that yields slices of size chunkSize.
 func ChunkCreateRegistrarCommands(cmds []CreateRegistrarCommand, chunkSize int) <-chan []CreateRegistrarCommand {
 	ch := make(chan []CreateRegistrarCommand)
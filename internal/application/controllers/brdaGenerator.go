@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// BRDAGenerator generates the weekly Bulk Registration Data Access (BRDA) file for a TLD as required by Specification 4 of the Registry Agreement.
+// A BRDA file uses the deposit format of RFC 9022 but only contains the thin domain data and the registrars that sponsor the domains.
+// The schema only allows the FULL, DIFF and INCR types, so the deposit and its report are of kind FULL and the file name identifies it as a BRDA file.
+type BRDAGenerator struct {
+	*EscrowGenerator
+}
+
+// NewBRDAGenerator creates a new instance of BRDAGenerator. Only the Domain, Registrar and TLD repositories are used.
+func NewBRDAGenerator(params EscrowGeneratorParams, repos EscrowGeneratorRepositories) *BRDAGenerator {
+	g := NewEscrowGenerator(params, repos)
+	g.thin = true
+	return &BRDAGenerator{EscrowGenerator: g}
+}
+
+// Generate creates the BRDA file and the matching report. BRDA files are not recorded as deposits, DIFF and INCR deposits do not build on them.
+func (g *BRDAGenerator) Generate(ctx context.Context) error {
+	if err := g.setDefaults(ctx); err != nil {
+		return err
+	}
+
+	err := g.generate(entities.NewRDEBRDAMenu(), func(e *xml.Encoder) error {
+		if err := g.writeDomains(ctx, e); err != nil {
+			return err
+		}
+		return g.writeRegistrars(ctx, e)
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Generated BRDA file %s for %s: %d domains, %d registrars", g.Deposit.ID, g.Header.TLD, g.Header.DomainCount(), g.Header.RegistrarCount())
+	return nil
+}
+
+// setDefaults validates the parameters, sets the defaults and initializes the BRDA deposit
+func (g *BRDAGenerator) setDefaults(ctx context.Context) error {
+	if err := g.setCommonDefaults(ctx); err != nil {
+		return err
+	}
+	if g.Params.OutputFile == "" {
+		g.Params.OutputFile = fmt.Sprintf("%s_%s_thin_S1_R0.xml", g.Params.Tld, g.Params.Watermark.Format(time.DateOnly))
+	}
+
+	g.Deposit = entities.NewRDEDeposit(g.Params.Watermark.Format("200601021504"), entities.RDEReportTypeFULL, g.Params.Watermark)
+	g.Deposit.FileName = filepath.Base(g.Params.OutputFile)
+	return nil
+}
@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"context"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/domain/repositories"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBRDAGenerator_Generate(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "apex_2024-03-03_thin_S1_R0.xml")
+	watermark := time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)
+	eg := getTestEscrowGenerator(t, EscrowGeneratorParams{}, nil)
+	g := NewBRDAGenerator(EscrowGeneratorParams{Tld: "apex", OutputFile: outputFile, Indent: 2, Watermark: watermark}, eg.Repos)
+
+	require.NoError(t, g.Generate(context.Background()))
+	require.Equal(t, 1, g.Header.DomainCount())
+	require.Equal(t, 1, g.Header.RegistrarCount())
+	require.Len(t, g.Header.Count, 2)
+
+	b, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	deposit := string(b)
+	require.Contains(t, deposit, `<rde:deposit type="FULL" id="202403030000"`)
+	require.Contains(t, deposit, `<rdeDom:name>example.apex</rdeDom:name>`)
+	require.Contains(t, deposit, `<domain:hostObj>ns1.example.apex</domain:hostObj>`)
+	require.Contains(t, deposit, `<rdeRegistrar:id>GoMamma</rdeRegistrar:id>`)
+	// Thin data only
+	require.NotContains(t, deposit, `<rdeDom:registrant>`)
+	require.NotContains(t, deposit, `<rdeDom:contact`)
+	require.NotContains(t, deposit, `<rdeDom:trnData>`)
+	require.NotContains(t, deposit, `<rdeHost:host>`)
+	require.NotContains(t, deposit, `<rdeContact:contact>`)
+	require.NotContains(t, deposit, `<rdeEppParams:eppParams>`)
+	require.NotContains(t, deposit, `<rde:objURI>urn:ietf:params:xml:ns:rdeContact-1.0</rde:objURI>`)
+
+	var parsed struct {
+		Header entities.RDEHeader `xml:"contents>header"`
+	}
+	require.NoError(t, xml.Unmarshal(b, &parsed))
+	require.Equal(t, g.Header, parsed.Header)
+
+	b, err = os.ReadFile(g.ReportFile())
+	require.NoError(t, err)
+	require.Contains(t, string(b), `<kind>FULL</kind>`)
+
+	// BRDA files are not recorded as deposits
+	g.Repos.Deposit.(*repositories.MockEscrowDepositRepository).AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestBRDAGenerator_DefaultOutputFile(t *testing.T) {
+	eg := getTestEscrowGenerator(t, EscrowGeneratorParams{}, nil)
+	g := NewBRDAGenerator(EscrowGeneratorParams{Tld: "apex", Watermark: time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)}, eg.Repos)
+	require.NoError(t, g.setDefaults(context.Background()))
+	require.Equal(t, "apex_2024-03-03_thin_S1_R0.xml", g.Params.OutputFile)
+	require.Equal(t, entities.RDEReportTypeFULL, g.Deposit.Type)
+}
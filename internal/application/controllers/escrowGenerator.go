@@ -1,26 +1,697 @@
 package controllers
 
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/domain/repositories"
+)
+
+const (
+	DEFAULT_ESCROW_BATCH_SIZE      = 1000
+	DEFAULT_ESCROW_MAX_CONCURRENCY = 10
+)
+
+var (
+	ErrMissingTLD       = errors.New("please provide a TLD to generate a deposit for")
+	ErrInvalidWatermark = errors.New("invalid watermark")
+)
+
+// EscrowGenerator generates an RDE escrow deposit (RFC 8909 / RFC 9022) and the matching report for a TLD straight from the database.
+// FULL deposits contain all objects of the TLD. DIFF and INCR deposits contain the objects that changed and were deleted since the watermark of the
+// previous deposit (DIFF) or the previous FULL deposit (INCR).
 type EscrowGenerator struct {
 	Params EscrowGeneratorParams
+	Repos  EscrowGeneratorRepositories
+
+	// Deposit and Header hold the deposit that was generated
+	Deposit *entities.RDEDeposit
+	Header  entities.RDEHeader
+
+	// since is the watermark of the deposit a DIFF or INCR deposit builds on, it is zero for a FULL deposit
+	since time.Time
+	// deletes are the objects that were deleted since the previous deposit
+	deletes []*entities.DeletedObject
+	// thin is set when generating a BRDA file, the domains are written without their contacts and transfer data
+	thin bool
+
+	// The objects referenced by the domains in the deposit, that need to be escrowed as well
+	mu         sync.Mutex
+	hostRoids  map[int64]struct{}
+	contactIDs map[string]struct{}
+	rarClIDs   map[string]struct{}
+}
+
+// EscrowGeneratorRepositories holds the repositories the escrow generator reads from
+type EscrowGeneratorRepositories struct {
+	Domain    repositories.DomainRepository
+	Host      repositories.HostRepository
+	Contact   repositories.ContactRepository
+	Registrar repositories.RegistrarRepository
+	NNDN      repositories.NNDNRepository
+	TLD       repositories.TLDRepository
+	Transfer  repositories.DomainTransferRepository
+	Deposit   repositories.EscrowDepositRepository
+	Deletes   repositories.DeletedObjectRepository
 }
 
 // NewEscrowGenerator creates a new instance of EscrowGenerator
-func NewEscrowGenerator(params EscrowGeneratorParams) *EscrowGenerator {
+func NewEscrowGenerator(params EscrowGeneratorParams, repos EscrowGeneratorRepositories) *EscrowGenerator {
 	return &EscrowGenerator{
 		Params: params,
+		Repos:  repos,
 	}
 }
 
 // EscrowGeneratorParams is a struct to hold the parameters for the escrow generator
 type EscrowGeneratorParams struct {
-	Tld            string
-	OutputFile     string
-	BatchSize      int
+	Tld string
+	// DepositType is one of FULL, DIFF or INCR and defaults to FULL.
+	// If there is no previous deposit to build a DIFF or INCR deposit on, a FULL deposit is generated instead.
+	DepositType string
+	// OutputFile defaults to <tld>_<YYYY-MM-DD>_<type>_S1_R<resend>.xml, the report is written next to it with a .rep extension
+	OutputFile string
+	// BatchSize is the number of objects that are read from the database at a time
+	BatchSize int
+	// MaxConcurrency is the maximum number of concurrent database reads
 	MaxConcurrency int
+	// Indent is the number of spaces used to indent the XML, 0 writes the objects without indentation
+	Indent int
+	// Watermark defaults to the current time
+	Watermark time.Time
+	// IDNTableRefs are the IDN tables used by the TLD, these are not stored in the database
+	IDNTableRefs []entities.RDEIdnTableReference
+}
+
+// Generate creates an escrow deposit file and the matching report and records the deposit
+func (c *EscrowGenerator) Generate(ctx context.Context) error {
+	if err := c.setDefaults(ctx); err != nil {
+		return err
+	}
+	// The deposit is recorded before the files are written, so a failed or interrupted run is resent under the same ID
+	if err := c.saveDeposit(ctx); err != nil {
+		return err
+	}
+
+	err := c.generate(entities.NewRDEMenu(), func(e *xml.Encoder) error {
+		return c.writeObjects(ctx, e)
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Generated %s deposit %s for %s: %d domains, %d hosts, %d contacts, %d registrars, %d NNDNs, %d deletes", c.Deposit.Type, c.Deposit.ID, c.Header.TLD, c.Header.DomainCount(), c.Header.HostCount(), c.Header.ContactCount(), c.Header.RegistrarCount(), c.Header.NNDNCount(), len(c.deletes))
+	return nil
+}
+
+// generate writes the deposit file and the matching report. The objects are written by writeObjects.
+// The objects are written to a temporary file first so the header, which comes before the objects, contains the correct counts.
+func (c *EscrowGenerator) generate(menu *entities.RDEMenu, writeObjects func(e *xml.Encoder) error) error {
+	body, err := os.CreateTemp(filepath.Dir(c.Params.OutputFile), "."+filepath.Base(c.Params.OutputFile)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(body.Name())
+	defer body.Close()
+
+	e := xml.NewEncoder(body)
+	if c.Params.Indent > 0 {
+		// The objects are nested in <rde:deposit><rde:contents>
+		indent := strings.Repeat(" ", c.Params.Indent)
+		e.Indent(strings.Repeat(indent, 2), indent)
+	}
+	if err := writeObjects(e); err != nil {
+		return err
+	}
+	if err := e.Flush(); err != nil {
+		return err
+	}
+
+	if _, err := body.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if err := c.writeDeposit(body, menu); err != nil {
+		return err
+	}
+	return c.writeReport()
+}
+
+// writeObjects writes the objects of the deposit to the encoder and fetches the deletes for DIFF and INCR deposits
+func (c *EscrowGenerator) writeObjects(ctx context.Context, e *xml.Encoder) error {
+	if err := c.writeDomains(ctx, e); err != nil {
+		return err
+	}
+	if err := c.writeHosts(ctx, e); err != nil {
+		return err
+	}
+	if err := c.writeContacts(ctx, e); err != nil {
+		return err
+	}
+	if err := c.writeRegistrars(ctx, e); err != nil {
+		return err
+	}
+	if c.Deposit.Type == entities.RDEReportTypeFULL {
+		if err := c.writeIDNTableRefs(e); err != nil {
+			return err
+		}
+	}
+	if err := c.writeNNDNs(ctx, e); err != nil {
+		return err
+	}
+	if c.Deposit.Type != entities.RDEReportTypeFULL {
+		deletes, err := c.Repos.Deletes.ListDeletedAfter(ctx, c.Params.Tld, c.since)
+		if err != nil {
+			return err
+		}
+		c.deletes = deletes
+		return nil
+	}
+	if err := e.Encode(entities.NewRDEEppParameters()); err != nil {
+		return err
+	}
+	c.Header.SetCount(entities.EPP_PARAMS_URI, 1)
+	return nil
+}
+
+// setDefaults validates the parameters, sets the defaults and initializes the deposit
+func (c *EscrowGenerator) setDefaults(ctx context.Context) error {
+	if err := c.setCommonDefaults(ctx); err != nil {
+		return err
+	}
+
+	depositType := entities.RDEReportTypeFULL
+	if c.Params.DepositType != "" {
+		t, err := entities.ValidateRDEDepositType(c.Params.DepositType)
+		if err != nil {
+			return err
+		}
+		depositType = t
+	}
+	if depositType != entities.RDEReportTypeFULL {
+		if _, err := c.getPreviousDeposit(ctx, depositType); err != nil {
+			if !errors.Is(err, entities.ErrEscrowDepositNotFound) {
+				return err
+			}
+			log.Printf("No previous deposit found for %s, generating a FULL deposit instead of %s", c.Params.Tld, depositType)
+			depositType = entities.RDEReportTypeFULL
+		}
+	}
+
+	// A deposit of the same type for the same day is a resend of that deposit
+	orig, err := c.Repos.Deposit.GetByDay(ctx, c.Params.Tld, depositType, c.Params.Watermark)
+	if err != nil && !errors.Is(err, entities.ErrEscrowDepositNotFound) {
+		return err
+	}
+	if orig != nil {
+		if err := c.setResendDefaults(ctx, orig); err != nil {
+			return err
+		}
+	} else {
+		c.Deposit = entities.NewRDEDeposit(c.Params.Watermark.Format("200601021504"), depositType, c.Params.Watermark)
+		if depositType != entities.RDEReportTypeFULL {
+			prev, err := c.getPreviousDeposit(ctx, depositType)
+			if err != nil {
+				return err
+			}
+			if !c.Params.Watermark.After(prev.Watermark) {
+				return fmt.Errorf("%w: watermark %s is not after the watermark of the previous deposit %s", ErrInvalidWatermark, c.Params.Watermark.Format(time.RFC3339), prev.ID)
+			}
+			c.since = prev.Watermark
+			c.Deposit.PrevID = prev.ID
+		}
+	}
+
+	if c.Params.OutputFile == "" {
+		c.Params.OutputFile = fmt.Sprintf("%s_%s_%s_S1_R%d.xml", c.Params.Tld, c.Params.Watermark.Format(time.DateOnly), strings.ToLower(depositType), c.Deposit.Resend)
+	}
+	c.Deposit.FileName = filepath.Base(c.Params.OutputFile)
+	return nil
+}
+
+// setResendDefaults initializes the deposit as a resend of the original deposit.
+// A resend keeps the ID, watermark and base (the previous deposit) of the original deposit and only increments the resend number.
+func (c *EscrowGenerator) setResendDefaults(ctx context.Context, orig *entities.EscrowDeposit) error {
+	if !c.Params.Watermark.Equal(orig.Watermark) {
+		log.Printf("Resending %s deposit %s for %s with its original watermark %s", orig.Type, orig.ID, c.Params.Tld, orig.Watermark.Format(time.RFC3339))
+	}
+	c.Params.Watermark = orig.Watermark
+	c.Deposit = entities.NewRDEDeposit(orig.ID, orig.Type, orig.Watermark)
+	c.Deposit.PrevID = orig.PrevID
+	c.Deposit.Resend = orig.Resend + 1
+	if orig.PrevID != "" {
+		prev, err := c.Repos.Deposit.GetByID(ctx, c.Params.Tld, orig.PrevID)
+		if err != nil {
+			return err
+		}
+		c.since = prev.Watermark
+	}
+	return nil
+}
+
+// setCommonDefaults validates the TLD, sets the defaults shared by all types of deposits and initializes the header
+func (c *EscrowGenerator) setCommonDefaults(ctx context.Context) error {
+	if c.Params.Tld == "" {
+		return ErrMissingTLD
+	}
+	if _, err := c.Repos.TLD.GetByName(ctx, c.Params.Tld, false); err != nil {
+		return err
+	}
+	if c.Params.BatchSize <= 0 {
+		c.Params.BatchSize = DEFAULT_ESCROW_BATCH_SIZE
+	}
+	if c.Params.MaxConcurrency <= 0 {
+		c.Params.MaxConcurrency = DEFAULT_ESCROW_MAX_CONCURRENCY
+	}
+	if c.Params.Watermark.IsZero() {
+		c.Params.Watermark = time.Now()
+	}
+	c.Params.Watermark = c.Params.Watermark.UTC()
+
+	c.Header = entities.RDEHeader{TLD: c.Params.Tld}
+	c.hostRoids = map[int64]struct{}{}
+	c.contactIDs = map[string]struct{}{}
+	c.rarClIDs = map[string]struct{}{}
+	return nil
+}
+
+// getPreviousDeposit returns the deposit a DIFF (the latest deposit) or INCR (the latest FULL deposit) deposit builds on
+func (c *EscrowGenerator) getPreviousDeposit(ctx context.Context, depositType string) (*entities.EscrowDeposit, error) {
+	if depositType == entities.RDEReportTypeINCR {
+		return c.Repos.Deposit.GetLatest(ctx, c.Params.Tld, entities.RDEReportTypeFULL)
+	}
+	return c.Repos.Deposit.GetLatest(ctx, c.Params.Tld, "")
+}
+
+// writeDomains streams all domains of the TLD, or those that changed since the previous deposit, to the encoder and keeps track of the hosts, contacts and registrars they reference
+func (c *EscrowGenerator) writeDomains(ctx context.Context, e *xml.Encoder) error {
+	count := 0
+	cursor := ""
+	for {
+		domains, next, err := c.Repos.Domain.ListDomains(ctx, queries.ListItemsQuery{
+			PageSize:   c.Params.BatchSize,
+			PageCursor: cursor,
+			Filter:     queries.ListDomainsFilter{TldEquals: c.Params.Tld, UpdatedAfter: c.since},
+		})
+		if err != nil {
+			return err
+		}
+
+		rdeDomains, err := fetchConcurrently(domains, c.Params.MaxConcurrency, func(d *entities.Domain) (*entities.RDEDomain, error) {
+			return c.getRDEDomain(ctx, d.Name.String())
+		})
+		if err != nil {
+			return err
+		}
+		for _, d := range rdeDomains {
+			if err := e.Encode(d); err != nil {
+				return err
+			}
+		}
+		count += len(rdeDomains)
+
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	c.Header.SetCount(entities.DOMAIN_URI, count)
+	return nil
+}
+
+// getRDEDomain gets the domain including its hosts and pending transfer and adds the objects it references to the deposit.
+// For BRDA files the thin domain is returned and only its registrar is added.
+func (c *EscrowGenerator) getRDEDomain(ctx context.Context, name string) (*entities.RDEDomain, error) {
+	dom, err := c.Repos.Domain.GetDomainByName(ctx, name, true)
+	if err != nil {
+		return nil, err
+	}
+	if c.thin {
+		c.addRegistrar(dom.ClID)
+		return entities.NewRDEThinDomainFromEntity(dom, c.Params.Watermark), nil
+	}
+	rdeDomain := entities.NewRDEDomainFromEntity(dom, c.Params.Watermark)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, h := range dom.Hosts {
+		roid, err := h.RoID.Int64()
+		if err != nil {
+			return nil, err
+		}
+		c.hostRoids[roid] = struct{}{}
+	}
+	for _, id := range []entities.ClIDType{dom.RegistrantID, dom.AdminID, dom.TechID, dom.BillingID} {
+		if id != "" {
+			c.contactIDs[id.String()] = struct{}{}
+		}
+	}
+	c.rarClIDs[dom.ClID.String()] = struct{}{}
+
+	if dom.Status.PendingTransfer {
+		transfer, err := c.Repos.Transfer.GetLatestByDomainName(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if transfer.Status == entities.TransferStatusPending {
+			rdeDomain.TrnData = entities.NewRDETrnDataFromEntity(transfer)
+			c.rarClIDs[transfer.GainingRegistrar.String()] = struct{}{}
+		}
+	}
+
+	return rdeDomain, nil
+}
+
+// writeHosts writes the hosts referenced by the domains in the deposit, and for DIFF and INCR deposits the hosts of the TLD that changed, to the encoder
+func (c *EscrowGenerator) writeHosts(ctx context.Context, e *xml.Encoder) error {
+	if !c.since.IsZero() {
+		if err := c.addChangedHosts(ctx); err != nil {
+			return err
+		}
+	}
+	count, err := writeInBatches(c, e, sortedKeys(c.hostRoids), func(roid int64) (*entities.RDEHost, error) {
+		host, err := c.Repos.Host.GetHostByRoid(ctx, roid)
+		if err != nil {
+			return nil, err
+		}
+		c.addRegistrar(host.ClID)
+		return entities.NewRDEHostFromEntity(host), nil
+	})
+	if err != nil {
+		return err
+	}
+	c.Header.SetCount(entities.HOST_URI, count)
+	return nil
+}
+
+// writeContacts writes the contacts referenced by the domains in the deposit, and for DIFF and INCR deposits the contacts of the TLD that changed, to the encoder
+func (c *EscrowGenerator) writeContacts(ctx context.Context, e *xml.Encoder) error {
+	if !c.since.IsZero() {
+		if err := c.addChangedContacts(ctx); err != nil {
+			return err
+		}
+	}
+	count, err := writeInBatches(c, e, sortedKeys(c.contactIDs), func(id string) (*entities.RDEContact, error) {
+		contact, err := c.Repos.Contact.GetContactByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		c.addRegistrar(contact.ClID)
+		return entities.NewRDEContactFromEntity(contact), nil
+	})
+	if err != nil {
+		return err
+	}
+	c.Header.SetCount(entities.CONTACT_URI, count)
+	return nil
+}
+
+// addChangedHosts adds the hosts used by domains in the TLD that changed since the previous deposit
+func (c *EscrowGenerator) addChangedHosts(ctx context.Context) error {
+	cursor := ""
+	for {
+		hosts, next, err := c.Repos.Host.ListHosts(ctx, queries.ListItemsQuery{
+			PageSize:   c.Params.BatchSize,
+			PageCursor: cursor,
+			Filter:     queries.ListHostsFilter{TldEquals: c.Params.Tld, UpdatedAfter: c.since},
+		})
+		if err != nil {
+			return err
+		}
+		for _, h := range hosts {
+			roid, err := h.RoID.Int64()
+			if err != nil {
+				return err
+			}
+			c.hostRoids[roid] = struct{}{}
+		}
+
+		if next == "" {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// addChangedContacts adds the contacts used by domains in the TLD that changed since the previous deposit
+func (c *EscrowGenerator) addChangedContacts(ctx context.Context) error {
+	cursor := ""
+	for {
+		contacts, next, err := c.Repos.Contact.ListContacts(ctx, queries.ListItemsQuery{
+			PageSize:   c.Params.BatchSize,
+			PageCursor: cursor,
+			Filter:     queries.ListContactsFilter{TldEquals: c.Params.Tld, UpdatedAfter: c.since},
+		})
+		if err != nil {
+			return err
+		}
+		for _, contact := range contacts {
+			c.contactIDs[contact.ID.String()] = struct{}{}
+		}
+
+		if next == "" {
+			return nil
+		}
+		cursor = next
+	}
 }
 
-// Generate creates an escrow deposit file
-func (c *EscrowGenerator) Generate() error {
+// writeRegistrars writes the registrars that sponsor or are involved in a transfer of the objects in the deposit to the encoder
+func (c *EscrowGenerator) writeRegistrars(ctx context.Context, e *xml.Encoder) error {
+	count, err := writeInBatches(c, e, sortedKeys(c.rarClIDs), func(clid string) (*entities.RDERegistrar, error) {
+		rar, err := c.Repos.Registrar.GetByClID(ctx, clid, false)
+		if err != nil {
+			return nil, err
+		}
+		return entities.NewRDERegistrarFromEntity(rar), nil
+	})
+	if err != nil {
+		return err
+	}
+	c.Header.SetCount(entities.REGISTRAR_URI, count)
+	return nil
+}
 
+// writeIDNTableRefs writes the IDN table references that were passed in the parameters to the encoder
+func (c *EscrowGenerator) writeIDNTableRefs(e *xml.Encoder) error {
+	for i := range c.Params.IDNTableRefs {
+		if err := e.Encode(&c.Params.IDNTableRefs[i]); err != nil {
+			return err
+		}
+	}
+	c.Header.SetCount(entities.IDN_URI, len(c.Params.IDNTableRefs))
 	return nil
 }
+
+// writeNNDNs streams all NNDNs of the TLD, or those that changed since the previous deposit, to the encoder
+func (c *EscrowGenerator) writeNNDNs(ctx context.Context, e *xml.Encoder) error {
+	count := 0
+	cursor := ""
+	for {
+		nndns, next, err := c.Repos.NNDN.ListNNDNs(ctx, queries.ListItemsQuery{
+			PageSize:   c.Params.BatchSize,
+			PageCursor: cursor,
+			Filter:     queries.ListNndnsFilter{TldEquals: c.Params.Tld, UpdatedAfter: c.since},
+		})
+		if err != nil {
+			return err
+		}
+		for _, n := range nndns {
+			if err := e.Encode(entities.NewRDENNDNFromEntity(n)); err != nil {
+				return err
+			}
+		}
+		count += len(nndns)
+
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	c.Header.SetCount(entities.NNDN_URI, count)
+	return nil
+}
+
+// writeDeposit writes the deposit file, wrapping the objects read from body with the deposit, menu and header elements and adding the deletes
+func (c *EscrowGenerator) writeDeposit(body io.Reader, menu *entities.RDEMenu) error {
+	f, err := os.Create(c.Params.OutputFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(xml.Header); err != nil {
+		return err
+	}
+	e := xml.NewEncoder(f)
+	indent := strings.Repeat(" ", c.Params.Indent)
+	e.Indent("", indent)
+
+	start := c.Deposit.StartElement()
+	contents := xml.StartElement{Name: xml.Name{Local: "rde:contents"}}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if err := e.EncodeElement(c.Deposit.Watermark, xml.StartElement{Name: xml.Name{Local: "rde:watermark"}}); err != nil {
+		return err
+	}
+	if err := e.Encode(menu); err != nil {
+		return err
+	}
+	if err := e.EncodeToken(contents); err != nil {
+		return err
+	}
+	if err := e.Encode(&c.Header); err != nil {
+		return err
+	}
+	if err := e.Flush(); err != nil {
+		return err
+	}
+
+	if indent != "" {
+		if _, err := f.WriteString("\n"); err != nil {
+			return err
+		}
+	}
+	if _, err := io.Copy(f, body); err != nil {
+		return err
+	}
+
+	if err := e.EncodeToken(contents.End()); err != nil {
+		return err
+	}
+	if len(c.deletes) > 0 {
+		deletes := xml.StartElement{Name: xml.Name{Local: "rde:deletes"}}
+		if err := e.EncodeToken(deletes); err != nil {
+			return err
+		}
+		for _, d := range c.deletes {
+			if err := e.Encode(entities.NewRDEDeleteFromEntity(d)); err != nil {
+				return err
+			}
+		}
+		if err := e.EncodeToken(deletes.End()); err != nil {
+			return err
+		}
+	}
+	if err := e.EncodeToken(start.End()); err != nil {
+		return err
+	}
+	return e.Flush()
+}
+
+// writeReport writes the report matching the deposit next to the deposit file
+func (c *EscrowGenerator) writeReport() error {
+	report := entities.NewRDEReport(c.Deposit.ID, c.Deposit.Resend, time.Now().UTC(), c.Params.Watermark, c.Header)
+	report.Kind = c.Deposit.Type
+	b, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.ReportFile(), append([]byte(xml.Header), b...), 0644)
+}
+
+// ReportFile returns the name of the report file, which is the name of the deposit file with a .rep extension
+func (c *EscrowGenerator) ReportFile() string {
+	return strings.TrimSuffix(c.Params.OutputFile, filepath.Ext(c.Params.OutputFile)) + ".rep"
+}
+
+// saveDeposit records the deposit so the next DIFF or INCR deposit can build on it
+func (c *EscrowGenerator) saveDeposit(ctx context.Context) error {
+	_, err := c.Repos.Deposit.Save(ctx, &entities.EscrowDeposit{
+		ID:        c.Deposit.ID,
+		TLDName:   c.Params.Tld,
+		Type:      c.Deposit.Type,
+		PrevID:    c.Deposit.PrevID,
+		Resend:    c.Deposit.Resend,
+		Watermark: c.Params.Watermark,
+		FileName:  c.Deposit.FileName,
+		CreatedAt: time.Now().UTC(),
+	})
+	return err
+}
+
+// addRegistrar adds a registrar that needs to be escrowed
+func (c *EscrowGenerator) addRegistrar(clid entities.ClIDType) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rarClIDs[clid.String()] = struct{}{}
+}
+
+// writeInBatches fetches the objects for the keys in batches, writes them to the encoder and returns the number of objects written
+func writeInBatches[K any, R any](c *EscrowGenerator, e *xml.Encoder, keys []K, fetch func(K) (R, error)) (int, error) {
+	for batch := range slices.Chunk(keys, c.Params.BatchSize) {
+		objects, err := fetchConcurrently(batch, c.Params.MaxConcurrency, fetch)
+		if err != nil {
+			return 0, err
+		}
+		for _, o := range objects {
+			if err := e.Encode(o); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(keys), nil
+}
+
+// fetchConcurrently calls fetch for each of the items using at most workers goroutines.
+// The results are returned in the order of the items, if any of the calls fail the first error is returned.
+func fetchConcurrently[T any, R any](items []T, workers int, fetch func(T) (R, error)) ([]R, error) {
+	results := make([]R, len(items))
+	jobs := make(chan int, len(items))
+	wg := sync.WaitGroup{}
+	var mu sync.Mutex
+	var firstErr error
+
+	// Start workers
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				r, err := fetch(items[j])
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+					continue
+				}
+				results[j] = r
+			}
+		}()
+	}
+
+	// Send the jobs to the workers
+	for i := range items {
+		jobs <- i
+	}
+	close(jobs)
+
+	// Wait for all workers to finish
+	wg.Wait()
+
+	return results, firstErr
+}
+
+// sortedKeys returns the keys of the map in ascending order so the deposit is deterministic
+func sortedKeys[K int64 | string](m map[K]struct{}) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
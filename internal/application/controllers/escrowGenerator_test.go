@@ -0,0 +1,390 @@
+package controllers
+
+import (
+	"context"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/domain/repositories"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTLDRepository only implements the methods used by the generator
+type fakeTLDRepository struct {
+	repositories.TLDRepository
+}
+
+func (r *fakeTLDRepository) GetByName(ctx context.Context, name string, preloadAll bool) (*entities.TLD, error) {
+	if name != "apex" {
+		return nil, entities.ErrTLDNotFound
+	}
+	return entities.NewTLD("apex", "ry-1")
+}
+
+// fakeContactRepository only implements the methods used by the generator
+type fakeContactRepository struct {
+	repositories.ContactRepository
+}
+
+func (r *fakeContactRepository) GetContactByID(ctx context.Context, id string) (*entities.Contact, error) {
+	c, err := entities.NewContact(id, "1_CONT-APEX", id+"@apex.domains", "str0NGP@ZZw0rd", "GoMamma")
+	if err != nil {
+		return nil, err
+	}
+	addr, err := entities.NewAddress("Brussels", "BE")
+	if err != nil {
+		return nil, err
+	}
+	pi, err := entities.NewContactPostalInfo("int", "John Doe", addr)
+	if err != nil {
+		return nil, err
+	}
+	return c, c.AddPostalInfo(pi)
+}
+
+func (r *fakeContactRepository) ListContacts(ctx context.Context, params queries.ListItemsQuery) ([]*entities.Contact, string, error) {
+	c, err := r.GetContactByID(ctx, "changed-1")
+	if err != nil {
+		return nil, "", err
+	}
+	return []*entities.Contact{c}, "", nil
+}
+
+// fakeNNDNRepository only implements the methods used by the generator
+type fakeNNDNRepository struct {
+	repositories.NNDNRepository
+}
+
+func (r *fakeNNDNRepository) ListNNDNs(ctx context.Context, params queries.ListItemsQuery) ([]*entities.NNDN, string, error) {
+	n, err := entities.NewNNDN("blocked.apex")
+	if err != nil {
+		return nil, "", err
+	}
+	return []*entities.NNDN{n}, "", nil
+}
+
+// getTestEscrowGenerator returns a generator for the "apex" TLD. If prev is not nil it is returned as the previous deposit of the TLD.
+func getTestEscrowGenerator(t *testing.T, params EscrowGeneratorParams, prev *entities.EscrowDeposit) *EscrowGenerator {
+	t.Helper()
+	return getTestEscrowGeneratorWithResend(t, params, prev, nil)
+}
+
+// getTestEscrowGeneratorWithResend returns a generator for the "apex" TLD. If orig is not nil it is returned as the deposit of the same day, which makes the deposit a resend of orig.
+func getTestEscrowGeneratorWithResend(t *testing.T, params EscrowGeneratorParams, prev, orig *entities.EscrowDeposit) *EscrowGenerator {
+	t.Helper()
+	depositRepo := new(repositories.MockEscrowDepositRepository)
+	deletesRepo := new(repositories.MockDeletedObjectRepository)
+	if prev != nil {
+		depositRepo.On("GetLatest", mock.Anything, "apex", mock.Anything).Return(prev, nil)
+		depositRepo.On("GetByID", mock.Anything, "apex", prev.ID).Return(prev, nil)
+	} else {
+		depositRepo.On("GetLatest", mock.Anything, "apex", mock.Anything).Return(nil, entities.ErrEscrowDepositNotFound)
+	}
+	if orig != nil {
+		depositRepo.On("GetByDay", mock.Anything, "apex", orig.Type, mock.Anything).Return(orig, nil)
+	}
+	depositRepo.On("GetByDay", mock.Anything, "apex", mock.Anything, mock.Anything).Return(nil, entities.ErrEscrowDepositNotFound)
+	depositRepo.On("Save", mock.Anything, mock.Anything).Return(&entities.EscrowDeposit{}, nil)
+	deletesRepo.On("ListDeletedAfter", mock.Anything, "apex", mock.Anything).Return([]*entities.DeletedObject{
+		entities.NewDeletedObject(entities.DOMAIN_URI, "apex", "gone.apex", "4_DOM-APEX"),
+		entities.NewDeletedObject(entities.HOST_URI, "", "ns2.gone.apex", "5_HOST-APEX"),
+	}, nil)
+
+	domainRepo := new(repositories.MockDomainRepository)
+	rarRepo := new(repositories.MockRegistrarRepository)
+	transferRepo := new(repositories.MockDomainTransferRepository)
+	hostRepo := repositories.NewMockHostRepository()
+
+	host, err := entities.NewHost("ns1.example.apex", "2_HOST-APEX", "GoMamma")
+	require.NoError(t, err)
+	_, err = host.AddAddress("195.238.2.21")
+	require.NoError(t, err)
+	hostRepo.GetHostByRoidFunc = func(ctx context.Context, roid int64) (*entities.Host, error) {
+		require.Equal(t, int64(2), roid)
+		return host, nil
+	}
+	hostRepo.ListHostsFunc = func(ctx context.Context, params queries.ListItemsQuery) ([]*entities.Host, string, error) {
+		return []*entities.Host{host}, "", nil
+	}
+
+	dom, err := entities.NewDomain("3_DOM-APEX", "example.apex", "GoMamma", "str0NGP@ZZw0rd")
+	require.NoError(t, err)
+	dom.RegistrantID = "reg-1"
+	dom.AdminID = "reg-1"
+	dom.TechID = "tech-1"
+	dom.Status = entities.DomainStatus{PendingTransfer: true}
+	dom.Hosts = []*entities.Host{host}
+	domainRepo.On("ListDomains", mock.Anything, mock.Anything).Return([]*entities.Domain{dom}, nil)
+	domainRepo.On("GetDomainByName", mock.Anything, "example.apex", true).Return(dom, nil)
+
+	transfer := entities.NewDomainTransfer(5)
+	transfer.DomainName = "example.apex"
+	transfer.GainingRegistrar = "Gaining"
+	transfer.LosingRegistrar = "GoMamma"
+	transferRepo.On("GetLatestByDomainName", mock.Anything, "example.apex").Return(&transfer, nil)
+
+	for _, clid := range []string{"GoMamma", "Gaining"} {
+		addr, err := entities.NewAddress("Brussels", "BE")
+		require.NoError(t, err)
+		pi, err := entities.NewRegistrarPostalInfo("int", addr)
+		require.NoError(t, err)
+		rar, err := entities.NewRegistrar(clid, clid+" Inc", "abuse@"+clid+".com", 1234, [2]*entities.RegistrarPostalInfo{pi})
+		require.NoError(t, err)
+		rarRepo.On("GetByClID", mock.Anything, clid, false).Return(rar, nil)
+	}
+
+	return NewEscrowGenerator(params, EscrowGeneratorRepositories{
+		Domain:    domainRepo,
+		Host:      hostRepo,
+		Contact:   &fakeContactRepository{},
+		Registrar: rarRepo,
+		NNDN:      &fakeNNDNRepository{},
+		TLD:       &fakeTLDRepository{},
+		Transfer:  transferRepo,
+		Deposit:   depositRepo,
+		Deletes:   deletesRepo,
+	})
+}
+
+func TestEscrowGenerator_Generate(t *testing.T) {
+	for _, indent := range []int{0, 2} {
+		outputFile := filepath.Join(t.TempDir(), "apex_2024-03-01_full_S1_R0.xml")
+		watermark := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+		g := getTestEscrowGenerator(t, EscrowGeneratorParams{
+			Tld:          "apex",
+			OutputFile:   outputFile,
+			Indent:       indent,
+			Watermark:    watermark,
+			IDNTableRefs: []entities.RDEIdnTableReference{{ID: "LATN", Url: "https://apex.domains/idn/latn.txt", UrlPolicy: "https://apex.domains/idn"}},
+		}, nil)
+
+		require.NoError(t, g.Generate(context.Background()))
+		require.Equal(t, 1, g.Header.DomainCount())
+		require.Equal(t, 1, g.Header.HostCount())
+		require.Equal(t, 2, g.Header.ContactCount())
+		require.Equal(t, 2, g.Header.RegistrarCount())
+		require.Equal(t, 1, g.Header.IDNCount())
+		require.Equal(t, 1, g.Header.NNDNCount())
+
+		b, err := os.ReadFile(outputFile)
+		require.NoError(t, err)
+		deposit := string(b)
+		require.Contains(t, deposit, `<rde:deposit type="FULL" id="202403010000"`)
+		require.Contains(t, deposit, `<rde:watermark>2024-03-01T00:00:00Z</rde:watermark>`)
+		require.Contains(t, deposit, `<rdeDom:name>example.apex</rdeDom:name>`)
+		require.Contains(t, deposit, `<rdeDom:trStatus>pending</rdeDom:trStatus>`)
+		require.Contains(t, deposit, `<rdeHost:addr ip="v4">195.238.2.21</rdeHost:addr>`)
+		require.Contains(t, deposit, `<rdeNNDN:aName>blocked.apex</rdeNNDN:aName>`)
+		require.Contains(t, deposit, `<rdeIDN:idnTableRef id="LATN">`)
+		require.Contains(t, deposit, `<rdeEppParams:eppParams>`)
+
+		// The deposit is well formed and the header can be read back
+		var parsed struct {
+			Watermark string               `xml:"watermark"`
+			Header    entities.RDEHeader   `xml:"contents>header"`
+			Domains   []entities.RDEDomain `xml:"contents>domain"`
+		}
+		require.NoError(t, xml.Unmarshal(b, &parsed))
+		require.Equal(t, "2024-03-01T00:00:00Z", parsed.Watermark)
+		require.Equal(t, g.Header, parsed.Header)
+		require.Len(t, parsed.Domains, 1)
+
+		// The report matches the deposit
+		b, err = os.ReadFile(g.ReportFile())
+		require.NoError(t, err)
+		require.Contains(t, string(b), `<id>202403010000</id>`)
+		require.Contains(t, string(b), `<kind>FULL</kind>`)
+		require.Contains(t, string(b), `<rdeHeader:count uri="urn:ietf:params:xml:ns:rdeDomain-1.0">1</rdeHeader:count>`)
+
+		// The deposit is recorded
+		g.Repos.Deposit.(*repositories.MockEscrowDepositRepository).AssertCalled(t, "Save", mock.Anything, mock.MatchedBy(func(d *entities.EscrowDeposit) bool {
+			return d.ID == "202403010000" && d.Type == entities.RDEReportTypeFULL && d.TLDName == "apex" && d.Watermark.Equal(watermark)
+		}))
+	}
+}
+
+func TestEscrowGenerator_Generate_Diff(t *testing.T) {
+	dir := t.TempDir()
+	watermark := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+	prev := &entities.EscrowDeposit{ID: "202403010000", TLDName: "apex", Type: entities.RDEReportTypeFULL, Watermark: watermark.AddDate(0, 0, -1)}
+	g := getTestEscrowGenerator(t, EscrowGeneratorParams{
+		Tld:          "apex",
+		DepositType:  "diff",
+		OutputFile:   filepath.Join(dir, "apex_2024-03-02_diff_S1_R0.xml"),
+		Indent:       2,
+		Watermark:    watermark,
+		IDNTableRefs: []entities.RDEIdnTableReference{{ID: "LATN", Url: "https://apex.domains/idn/latn.txt"}},
+	}, prev)
+
+	require.NoError(t, g.Generate(context.Background()))
+	require.Equal(t, 1, g.Header.DomainCount())
+	require.Equal(t, 1, g.Header.HostCount())
+	// The referenced contacts and the contact that changed
+	require.Equal(t, 3, g.Header.ContactCount())
+	// IDN tables and EPP parameters are only included in FULL deposits
+	require.Equal(t, 0, g.Header.IDNCount())
+
+	b, err := os.ReadFile(g.Params.OutputFile)
+	require.NoError(t, err)
+	deposit := string(b)
+	require.Contains(t, deposit, `<rde:deposit type="DIFF" id="202403020000" prevId="202403010000"`)
+	require.Contains(t, deposit, `<rdeContact:id>changed-1</rdeContact:id>`)
+	require.NotContains(t, deposit, `<rdeEppParams:eppParams>`)
+	require.Contains(t, deposit, "<rde:deletes>")
+	require.Contains(t, deposit, "<rdeDom:delete>")
+	require.Contains(t, deposit, "<rdeDom:name>gone.apex</rdeDom:name>")
+	require.Contains(t, deposit, "<rdeHost:roid>5_HOST-APEX</rdeHost:roid>")
+
+	// The contents are read until </rde:contents>, the deletes follow them
+	var parsed struct {
+		Domains []entities.RDEDomain `xml:"contents>domain"`
+		Deletes []struct {
+			Name string `xml:"name"`
+		} `xml:"deletes>delete"`
+	}
+	require.NoError(t, xml.Unmarshal(b, &parsed))
+	require.Len(t, parsed.Domains, 1)
+	require.Len(t, parsed.Deletes, 2)
+
+	b, err = os.ReadFile(g.ReportFile())
+	require.NoError(t, err)
+	require.Contains(t, string(b), `<kind>DIFF</kind>`)
+
+	// The watermark must be after the previous deposit
+	g = getTestEscrowGenerator(t, EscrowGeneratorParams{Tld: "apex", DepositType: "DIFF", OutputFile: filepath.Join(dir, "out.xml"), Watermark: prev.Watermark}, prev)
+	require.ErrorIs(t, g.Generate(context.Background()), ErrInvalidWatermark)
+}
+
+func TestEscrowGenerator_Generate_Resend(t *testing.T) {
+	watermark := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+	full := &entities.EscrowDeposit{ID: "202403010000", TLDName: "apex", Type: entities.RDEReportTypeFULL, Watermark: watermark.AddDate(0, 0, -1)}
+	diff := &entities.EscrowDeposit{ID: "202403020000", TLDName: "apex", Type: entities.RDEReportTypeDIFF, PrevID: full.ID, Watermark: watermark, Resend: 0}
+
+	tc := []struct {
+		name string
+		// latest is the most recent deposit of the TLD, orig is the deposit that is resent
+		latest, orig  *entities.EscrowDeposit
+		depositType   string
+		watermark     time.Time
+		wantPrevID    string
+		wantSince     time.Time
+		wantFileName  string
+		wantDepositID string
+	}{
+		{
+			name:          "FULL with the same watermark",
+			latest:        full,
+			orig:          full,
+			depositType:   entities.RDEReportTypeFULL,
+			watermark:     full.Watermark,
+			wantFileName:  "apex_2024-03-01_full_S1_R1.xml",
+			wantDepositID: full.ID,
+		},
+		{
+			name:          "FULL with a later watermark on the same day",
+			latest:        full,
+			orig:          full,
+			depositType:   entities.RDEReportTypeFULL,
+			watermark:     full.Watermark.Add(6 * time.Hour),
+			wantFileName:  "apex_2024-03-01_full_S1_R1.xml",
+			wantDepositID: full.ID,
+		},
+		{
+			name:          "DIFF with the same watermark",
+			latest:        diff,
+			orig:          diff,
+			depositType:   entities.RDEReportTypeDIFF,
+			watermark:     diff.Watermark,
+			wantPrevID:    full.ID,
+			wantSince:     full.Watermark,
+			wantFileName:  "apex_2024-03-02_diff_S1_R1.xml",
+			wantDepositID: diff.ID,
+		},
+		{
+			name:          "DIFF with a later watermark on the same day builds on the same base",
+			latest:        diff,
+			orig:          diff,
+			depositType:   entities.RDEReportTypeDIFF,
+			watermark:     diff.Watermark.Add(6 * time.Hour),
+			wantPrevID:    full.ID,
+			wantSince:     full.Watermark,
+			wantFileName:  "apex_2024-03-02_diff_S1_R1.xml",
+			wantDepositID: diff.ID,
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			g := getTestEscrowGeneratorWithResend(t, EscrowGeneratorParams{Tld: "apex", DepositType: tt.depositType, Watermark: tt.watermark}, tt.latest, tt.orig)
+			g.Repos.Deposit.(*repositories.MockEscrowDepositRepository).On("GetByID", mock.Anything, "apex", full.ID).Return(full, nil)
+
+			// The default file name has the resend number
+			require.NoError(t, g.setDefaults(context.Background()))
+			require.Equal(t, tt.wantFileName, g.Deposit.FileName)
+
+			g.Params.OutputFile = filepath.Join(t.TempDir(), tt.wantFileName)
+			require.NoError(t, g.Generate(context.Background()))
+			require.Equal(t, tt.wantDepositID, g.Deposit.ID)
+			require.Equal(t, tt.wantPrevID, g.Deposit.PrevID)
+			require.Equal(t, 1, g.Deposit.Resend)
+			require.True(t, tt.wantSince.Equal(g.since))
+
+			b, err := os.ReadFile(g.Params.OutputFile)
+			require.NoError(t, err)
+			require.Contains(t, string(b), `id="`+tt.wantDepositID+`"`)
+			require.Contains(t, string(b), `resend="1"`)
+			require.Contains(t, string(b), `<rde:watermark>`+tt.orig.Watermark.Format(time.RFC3339)+`</rde:watermark>`)
+
+			// The resend replaces the record of the original deposit
+			g.Repos.Deposit.(*repositories.MockEscrowDepositRepository).AssertCalled(t, "Save", mock.Anything, mock.MatchedBy(func(d *entities.EscrowDeposit) bool {
+				return d.ID == tt.wantDepositID && d.Type == tt.depositType && d.PrevID == tt.wantPrevID && d.Resend == 1 && d.Watermark.Equal(tt.orig.Watermark)
+			}))
+		})
+	}
+}
+
+func TestEscrowGenerator_Generate_IncrWithoutPreviousDeposit(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "out.xml")
+	g := getTestEscrowGenerator(t, EscrowGeneratorParams{Tld: "apex", DepositType: "INCR", OutputFile: outputFile, Watermark: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)}, nil)
+
+	require.NoError(t, g.Generate(context.Background()))
+	require.Equal(t, entities.RDEReportTypeFULL, g.Deposit.Type)
+	require.Empty(t, g.Deposit.PrevID)
+
+	b, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	require.Contains(t, string(b), `<rdeEppParams:eppParams>`)
+	require.NotContains(t, string(b), `<rde:deletes>`)
+}
+
+func TestEscrowGenerator_Generate_Errors(t *testing.T) {
+	g := getTestEscrowGenerator(t, EscrowGeneratorParams{}, nil)
+	require.ErrorIs(t, g.Generate(context.Background()), ErrMissingTLD)
+
+	g = getTestEscrowGenerator(t, EscrowGeneratorParams{Tld: "unknown", OutputFile: filepath.Join(t.TempDir(), "out.xml")}, nil)
+	require.ErrorIs(t, g.Generate(context.Background()), entities.ErrTLDNotFound)
+
+	g = getTestEscrowGenerator(t, EscrowGeneratorParams{Tld: "apex", DepositType: "weekly", OutputFile: filepath.Join(t.TempDir(), "out.xml")}, nil)
+	require.ErrorIs(t, g.Generate(context.Background()), entities.ErrInvalidDepositType)
+}
+
+func TestFetchConcurrently(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	results, err := fetchConcurrently(items, 3, func(i int) (int, error) { return i * i, nil })
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 4, 9, 16, 25, 36, 49, 64, 81, 100}, results)
+
+	_, err = fetchConcurrently(items, 3, func(i int) (int, error) {
+		if i == 5 {
+			return 0, entities.ErrInvalidDomainRoID
+		}
+		return i, nil
+	})
+	require.ErrorIs(t, err, entities.ErrInvalidDomainRoID)
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// AllocationTokenService is the interface for the allocation token service
+type AllocationTokenService interface {
+	CreateAllocationToken(ctx context.Context, cmd *commands.CreateAllocationTokenCommand) (*entities.AllocationToken, error)
+	UpdateAllocationToken(ctx context.Context, token string, cmd *commands.UpdateAllocationTokenCommand) (*entities.AllocationToken, error)
+	GetAllocationToken(ctx context.Context, token string) (*entities.AllocationToken, error)
+	DeleteAllocationToken(ctx context.Context, token string) error
+	ListAllocationTokens(ctx context.Context, params queries.ListItemsQuery) ([]*entities.AllocationToken, string, error)
+	CountAllocationTokens(ctx context.Context, filter queries.ListAllocationTokensFilter) (int64, error)
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/miekg/dns"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// DNSSECService is the interface for managing the DNSSEC keys of the TLD zones
+type DNSSECService interface {
+	ListKeys(ctx context.Context, tldName string) ([]*entities.DNSSECKey, error)
+	CreateKey(ctx context.Context, tldName string, keyType entities.DNSSECKeyType) (*entities.DNSSECKey, error)
+	PrepublishZSK(ctx context.Context, tldName string) (*entities.DNSSECKey, error)
+	ActivateZSK(ctx context.Context, tldName string, keyTag uint16) (*entities.DNSSECKey, error)
+	RemoveRetiredZSKs(ctx context.Context, tldName string) ([]*entities.DNSSECKey, error)
+	GetDS(ctx context.Context, tldName string) ([]*dns.DS, error)
+	PublishDS(ctx context.Context, tldName string) ([]*dns.DS, error)
+}
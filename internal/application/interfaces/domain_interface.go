@@ -33,9 +33,11 @@ type DomainService interface {
 
 	// These are Registrar services
 	// CheckDomain checks if a domain is available
-	CheckDomainAvailability(ctx context.Context, domainname, phaseName string) (*queries.DomainCheckResult, error)
+	CheckDomainAvailability(ctx context.Context, domainname, phaseName, clID, allocationToken string) (*queries.DomainCheckResult, error)
 	// GetQuote returns a quote for a domain transaction
 	GetQuote(ctx context.Context, q *queries.QuoteRequest) (*entities.Quote, error)
+	// ApplyDomainUpdate applies all changes of a registrar's domain update in a single transaction
+	ApplyDomainUpdate(ctx context.Context, cmd *commands.ApplyDomainUpdateCommand) (*entities.Domain, error)
 	// RegisterDomain registers a domain as a registrar and supports the fee extension
 	RegisterDomain(ctx context.Context, cmd *commands.RegisterDomainCommand) (*entities.Domain, error)
 	// RenewDomain renews a domain as a registrar and supports the fee extension
@@ -49,10 +51,50 @@ type DomainService interface {
 	// ExpireDomain expires a domain
 	ExpireDomain(ctx context.Context, domainName string) (*entities.Domain, error)
 	// RestoreDomain restores a domain as a registrar
-	RestoreDomain(ctx context.Context, domainName string) (*entities.Domain, error)
+	RestoreDomain(ctx context.Context, domainName string, fee commands.FeeExtension) (*entities.Domain, error)
+	// ReportDomainRestore submits the restore report of a domain in pendingRestore status
+	ReportDomainRestore(ctx context.Context, cmd *commands.RestoreReportCommand) (*entities.Domain, error)
+	// CompleteDomainRestore completes the restore of a domain once the restore report was received, or returns it to the redemption period if the report is overdue
+	CompleteDomainRestore(ctx context.Context, domainName string) (*entities.Domain, error)
 	// PurgeDomain purges a domain after it has reached it's purge date
 	PurgeDomain(ctx context.Context, domainName string) error
 
+	// These are Transfer services
+	// RequestDomainTransfer starts a transfer of a domain to the gaining registrar in the command
+	RequestDomainTransfer(ctx context.Context, cmd *entities.RequestTransferCommand) (*entities.DomainTransfer, error)
+	// ApproveDomainTransfer approves the pending transfer of a domain as the losing registrar (or the registry if clid is empty)
+	ApproveDomainTransfer(ctx context.Context, domainName, clid string) (*entities.DomainTransfer, error)
+	// RejectDomainTransfer rejects the pending transfer of a domain as the losing registrar (or the registry if clid is empty)
+	RejectDomainTransfer(ctx context.Context, domainName, clid, reason string) (*entities.DomainTransfer, error)
+	// CancelDomainTransfer withdraws the pending transfer of a domain as the gaining registrar (or the registry if clid is empty)
+	CancelDomainTransfer(ctx context.Context, domainName, clid string) (*entities.DomainTransfer, error)
+	// GetDomainTransfer returns the most recent transfer of a domain
+	GetDomainTransfer(ctx context.Context, domainName string) (*entities.DomainTransfer, error)
+	// ListDomainTransfers returns a list of domain transfers
+	ListDomainTransfers(ctx context.Context, params queries.ListItemsQuery) ([]*entities.DomainTransfer, string, error)
+	// CountDomainTransfers returns the number of domain transfers matching the filter
+	CountDomainTransfers(ctx context.Context, filter queries.ListDomainTransfersFilter) (int64, error)
+
+	// These are Launch services
+	// GetClaimKey returns the key to retrieve the trademark claims notice of a domain, or an empty string if the domain does not match a trademark
+	GetClaimKey(ctx context.Context, domainName string) (string, error)
+	// CreateDomainApplication applies for a domain in a launch phase that requires validation
+	CreateDomainApplication(ctx context.Context, cmd *commands.CreateDomainApplicationCommand) (*entities.DomainApplication, error)
+	// GetDomainApplication returns a domain application by its ID
+	GetDomainApplication(ctx context.Context, id string) (*entities.DomainApplication, error)
+	// UpdateDomainApplication updates the contacts, nameservers and authInfo of a domain application
+	UpdateDomainApplication(ctx context.Context, app *entities.DomainApplication) (*entities.DomainApplication, error)
+	// WithdrawDomainApplication deletes a domain application as the applicant (or the registry if clid is empty)
+	WithdrawDomainApplication(ctx context.Context, id, clid string) error
+	// SetDomainApplicationStatus sets the status of a domain application as the registry
+	SetDomainApplicationStatus(ctx context.Context, id string, status entities.ApplicationStatus, reason string) (*entities.DomainApplication, error)
+	// AllocateDomainApplication registers the domain to the applicant and rejects the competing applications
+	AllocateDomainApplication(ctx context.Context, id string) (*entities.DomainApplication, error)
+	// ListDomainApplications returns a list of domain applications
+	ListDomainApplications(ctx context.Context, params queries.ListItemsQuery) ([]*entities.DomainApplication, string, error)
+	// CountDomainApplications returns the number of domain applications matching the filter
+	CountDomainApplications(ctx context.Context, filter queries.ListDomainApplicationsFilter) (int64, error)
+
 	// These are DNS services
 	GetNSRecordsPerTLD(ctx context.Context, params queries.ActiveDomainsWithHostsQuery) ([]dns.RR, error)
 	GetGlueRecordsPerTLD(ctx context.Context, tld string) ([]dns.RR, error)
@@ -16,6 +16,8 @@ type HostService interface {
 	// GetHostByNameAndClID gets a host by its name and clid
 	GetHostByNameAndClID(ctx context.Context, name, clid string) (*entities.Host, error)
 	DeleteHostByRoID(ctx context.Context, roidString string) error
+	// UpdateHost validates and saves the host, addresses are managed through AddHostAddress and RemoveHostAddress
+	UpdateHost(ctx context.Context, h *entities.Host) (*entities.Host, error)
 	ListHosts(ctx context.Context, params queries.ListItemsQuery) ([]*entities.Host, string, error)
 	AddHostAddress(ctx context.Context, roidString, ip string) (*entities.Host, error)
 	RemoveHostAddress(ctx context.Context, roidString, ip string) (*entities.Host, error)
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// PollService is the interface for the registrar poll message queue
+type PollService interface {
+	Enqueue(ctx context.Context, msg *entities.PollMessage) (*entities.PollMessage, error)
+	Peek(ctx context.Context, clid string) (*entities.PollMessage, int64, error)
+	Ack(ctx context.Context, clid string, id int64) (*entities.PollMessage, int64, error)
+	GetByID(ctx context.Context, id int64) (*entities.PollMessage, error)
+	Delete(ctx context.Context, id int64) error
+	List(ctx context.Context, params queries.ListItemsQuery) ([]*entities.PollMessage, string, error)
+	Count(ctx context.Context, filter queries.ListPollMessagesFilter) (int64, error)
+}
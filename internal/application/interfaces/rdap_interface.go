@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// RDAPService is the interface for the RDAP service. The token identifies privileged requesters that get the unredacted contact data.
+type RDAPService interface {
+	GetDomain(ctx context.Context, name, token string) (*entities.RDAPDomain, error)
+	GetNameserver(ctx context.Context, name string) (*entities.RDAPNameserver, error)
+	GetEntity(ctx context.Context, handle, token string) (*entities.RDAPEntity, error)
+	Help(ctx context.Context) *entities.RDAPHelp
+	SearchDomains(ctx context.Context, name, nsLdhName, cursor string) (*entities.RDAPDomainSearchResults, error)
+	SearchNameservers(ctx context.Context, name, ip, cursor string) (*entities.RDAPNameserverSearchResults, error)
+	SearchEntities(ctx context.Context, fn, cursor, token string) (*entities.RDAPEntitySearchResults, error)
+}
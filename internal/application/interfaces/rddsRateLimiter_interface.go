@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"net/netip"
+)
+
+// RDDSRateLimiter limits and counts the queries to the RDDS services (WHOIS, web WHOIS and RDAP) per source address
+type RDDSRateLimiter interface {
+	// Allow counts the query of the service and reports whether a query from the address may be answered
+	Allow(service string, addr netip.Addr) bool
+}
@@ -19,4 +19,8 @@ type RegistrarService interface {
 	List(ctx context.Context, params queries.ListItemsQuery) ([]*entities.RegistrarListItem, string, error)
 	Count(ctx context.Context) (int64, error)
 	SetStatus(ctx context.Context, clid string, status entities.RegistrarStatus) error
+	// SetEPPPassword sets the password the registrar uses to login to the EPP server
+	SetEPPPassword(ctx context.Context, clid, password string) error
+	// LoginEPP authenticates a registrar at the EPP server, enforcing the password expiry and failed login lockout, and records the login
+	LoginEPP(ctx context.Context, cmd *commands.EPPLoginCommand) (*commands.EPPLoginResult, error)
 }
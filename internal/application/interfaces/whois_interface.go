@@ -8,4 +8,7 @@ import (
 
 type WhoisService interface {
 	GetDomainWhois(ctx context.Context, dn string) (*entities.WhoisResponse, error)
+	GetNameserverWhois(ctx context.Context, name string) (*entities.WhoisNameserverResponse, error)
+	GetNameserverWhoisByIP(ctx context.Context, ip string) ([]*entities.WhoisNameserverResponse, error)
+	GetRegistrarWhois(ctx context.Context, query string) (*entities.WhoisRegistrarResponse, error)
 }
@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// ZoneService is the interface for generating TLD zone files
+type ZoneService interface {
+	GenerateZone(ctx context.Context, tldName string) (*entities.Zone, error)
+	BuildZone(ctx context.Context, tldName string) (*entities.Zone, error)
+	PublishZone(ctx context.Context, zone *entities.Zone) error
+	ListZones(ctx context.Context) ([]string, error)
+}
+
+// ZoneSigner is the interface for signing zones with DNSSEC
+type ZoneSigner interface {
+	SignZone(ctx context.Context, zone *entities.Zone) (*entities.Zone, error)
+	// LoadSignatures reuses the signatures of a previously published version of the zone when the zone is signed again
+	LoadSignatures(ctx context.Context, zone *entities.Zone) error
+}
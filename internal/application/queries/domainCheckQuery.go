@@ -13,6 +13,8 @@ type DomainCheckQuery struct {
 	PhaseName  string              // Phase name - if empty the current GA phase is assumed
 	Currency   string              // Currency to use for the price check
 	ClID       entities.ClIDType   // Client ID to use for the price check
+	// AllocationToken is optional, a valid token makes a blocked domain available and its discount is applied to the quote
+	AllocationToken string
 }
 
 // NewDomainCheckQuery creates a new instance of DomainCheckQuery. If the domain name is invalid, an error is returned so we can fail fast.
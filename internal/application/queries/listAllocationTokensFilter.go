@@ -0,0 +1,27 @@
+package queries
+
+// ListAllocationTokensFilter is the struct that contains the filter for the list allocation tokens query
+type ListAllocationTokensFilter struct {
+	TldEquals        string
+	DomainNameEquals string
+	ClIDEquals       string
+	PhaseNameEquals  string
+}
+
+// ToQueryParams converts the Filter to a query string that can be appended to the URL
+func (f ListAllocationTokensFilter) ToQueryParams() string {
+	queryString := ""
+	if f.TldEquals != "" {
+		queryString += "&tld_equals=" + f.TldEquals
+	}
+	if f.DomainNameEquals != "" {
+		queryString += "&domain_name_equals=" + f.DomainNameEquals
+	}
+	if f.ClIDEquals != "" {
+		queryString += "&clid_equals=" + f.ClIDEquals
+	}
+	if f.PhaseNameEquals != "" {
+		queryString += "&phase_name_equals=" + f.PhaseNameEquals
+	}
+	return queryString
+}
@@ -0,0 +1,42 @@
+package queries
+
+import "testing"
+
+func TestListAllocationTokensFilter_ToQueryParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   ListAllocationTokensFilter
+		expected string
+	}{
+		{
+			name:     "all fields empty",
+			filter:   ListAllocationTokensFilter{},
+			expected: "",
+		},
+		{
+			name: "only TldEquals set",
+			filter: ListAllocationTokensFilter{
+				TldEquals: "apex",
+			},
+			expected: "&tld_equals=apex",
+		},
+		{
+			name: "all fields set",
+			filter: ListAllocationTokensFilter{
+				TldEquals:        "apex",
+				DomainNameEquals: "reserved.apex",
+				ClIDEquals:       "ClID-1",
+				PhaseNameEquals:  "GA",
+			},
+			expected: "&tld_equals=apex&domain_name_equals=reserved.apex&clid_equals=ClID-1&phase_name_equals=GA",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.ToQueryParams(); got != tt.expected {
+				t.Errorf("ToQueryParams() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -1,12 +1,20 @@
 package queries
 
+import "time"
+
 // ListContactsFilter is a filter for the ListContacts query
 type ListContactsFilter struct {
 	RoidGreaterThan string
 	RoidLessThan    string
 	IdLike          string
 	EmailLike       string
-	ClidEquals      string
+	// NameMatches does a case insensitive search on the int and loc postal info names with a pattern where * matches any sequence of characters
+	NameMatches string
+	ClidEquals  string
+	// TldEquals only returns contacts that are used by a domain in the TLD
+	TldEquals string
+	// UpdatedAfter does a greater than search on the UpdatedDate
+	UpdatedAfter time.Time
 }
 
 // ToQueryParams converts the Filter to a query string that can be appended to the URL
@@ -29,9 +37,21 @@ func (f ListContactsFilter) ToQueryParams() string {
 		queryParams += "&email_like=" + f.EmailLike
 	}
 
+	if f.NameMatches != "" {
+		queryParams += "&name_matches=" + f.NameMatches
+	}
+
 	if f.ClidEquals != "" {
 		queryParams += "&clid_equals=" + f.ClidEquals
 	}
 
+	if f.TldEquals != "" {
+		queryParams += "&tld_equals=" + f.TldEquals
+	}
+
+	if !f.UpdatedAfter.IsZero() {
+		queryParams += "&updated_after=" + f.UpdatedAfter.Format(time.RFC3339)
+	}
+
 	return queryParams
 }
@@ -1,6 +1,9 @@
 package queries
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestContactsToQueryParams(t *testing.T) {
 	tests := []struct {
@@ -41,6 +44,13 @@ func TestContactsToQueryParams(t *testing.T) {
 			},
 			expected: "&email_like=email@example.com",
 		},
+		{
+			name: "only NameMatches",
+			filter: ListContactsFilter{
+				NameMatches: "John*",
+			},
+			expected: "&name_matches=John*",
+		},
 		{
 			name: "only ClidEquals",
 			filter: ListContactsFilter{
@@ -48,6 +58,14 @@ func TestContactsToQueryParams(t *testing.T) {
 			},
 			expected: "&clid_equals=clidVal",
 		},
+		{
+			name: "only TldEquals and UpdatedAfter",
+			filter: ListContactsFilter{
+				TldEquals:    "apex",
+				UpdatedAfter: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+			expected: "&tld_equals=apex&updated_after=2024-03-01T00:00:00Z",
+		},
 		{
 			name: "multiple fields",
 			filter: ListContactsFilter{
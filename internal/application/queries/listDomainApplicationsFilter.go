@@ -0,0 +1,31 @@
+package queries
+
+// ListDomainApplicationsFilter is the struct that contains the filter for the list domain applications query
+type ListDomainApplicationsFilter struct {
+	DomainNameEquals string
+	DomainNameLike   string
+	ClIDEquals       string
+	PhaseNameEquals  string
+	StatusEquals     string
+}
+
+// ToQueryParams converts the Filter to a query string that can be appended to the URL
+func (f ListDomainApplicationsFilter) ToQueryParams() string {
+	queryString := ""
+	if f.DomainNameEquals != "" {
+		queryString += "&domain_name_equals=" + f.DomainNameEquals
+	}
+	if f.DomainNameLike != "" {
+		queryString += "&domain_name_like=" + f.DomainNameLike
+	}
+	if f.ClIDEquals != "" {
+		queryString += "&clid_equals=" + f.ClIDEquals
+	}
+	if f.PhaseNameEquals != "" {
+		queryString += "&phase_name_equals=" + f.PhaseNameEquals
+	}
+	if f.StatusEquals != "" {
+		queryString += "&status_equals=" + f.StatusEquals
+	}
+	return queryString
+}
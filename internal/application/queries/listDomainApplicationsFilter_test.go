@@ -0,0 +1,43 @@
+package queries
+
+import "testing"
+
+func TestListDomainApplicationsFilter_ToQueryParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   ListDomainApplicationsFilter
+		expected string
+	}{
+		{
+			name:     "all fields empty",
+			filter:   ListDomainApplicationsFilter{},
+			expected: "",
+		},
+		{
+			name: "only StatusEquals set",
+			filter: ListDomainApplicationsFilter{
+				StatusEquals: "validated",
+			},
+			expected: "&status_equals=validated",
+		},
+		{
+			name: "all fields set",
+			filter: ListDomainApplicationsFilter{
+				DomainNameEquals: "example.com",
+				DomainNameLike:   "example",
+				ClIDEquals:       "ClID-1",
+				PhaseNameEquals:  "landrush",
+				StatusEquals:     "pendingValidation",
+			},
+			expected: "&domain_name_equals=example.com&domain_name_like=example&clid_equals=ClID-1&phase_name_equals=landrush&status_equals=pendingValidation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.ToQueryParams(); got != tt.expected {
+				t.Errorf("ToQueryParams() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package queries
+
+import "time"
+
+// ListDomainTransfersFilter is the struct that contains the filter for the list domain transfers query
+type ListDomainTransfersFilter struct {
+	DomainNameLike         string
+	GainingRegistrarEquals string
+	LosingRegistrarEquals  string
+	StatusEquals           string
+	// ExpiresBefore does a less than search on the ExpiryDate of the transfer
+	ExpiresBefore time.Time
+}
+
+// ToQueryParams converts the Filter to a query string that can be appended to the URL
+func (f ListDomainTransfersFilter) ToQueryParams() string {
+	queryString := ""
+	if f.DomainNameLike != "" {
+		queryString += "&domain_name_like=" + f.DomainNameLike
+	}
+	if f.GainingRegistrarEquals != "" {
+		queryString += "&gaining_registrar_equals=" + f.GainingRegistrarEquals
+	}
+	if f.LosingRegistrarEquals != "" {
+		queryString += "&losing_registrar_equals=" + f.LosingRegistrarEquals
+	}
+	if f.StatusEquals != "" {
+		queryString += "&status_equals=" + f.StatusEquals
+	}
+	if !f.ExpiresBefore.IsZero() {
+		queryString += "&expires_before=" + f.ExpiresBefore.Format(time.RFC3339)
+	}
+	return queryString
+}
@@ -0,0 +1,46 @@
+package queries
+
+import (
+	"testing"
+	"time"
+)
+
+func TestListDomainTransfersFilter_ToQueryParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   ListDomainTransfersFilter
+		expected string
+	}{
+		{
+			name:     "all fields empty",
+			filter:   ListDomainTransfersFilter{},
+			expected: "",
+		},
+		{
+			name: "only StatusEquals set",
+			filter: ListDomainTransfersFilter{
+				StatusEquals: "pending",
+			},
+			expected: "&status_equals=pending",
+		},
+		{
+			name: "all fields set",
+			filter: ListDomainTransfersFilter{
+				DomainNameLike:         "example",
+				GainingRegistrarEquals: "ClID-1",
+				LosingRegistrarEquals:  "ClID-2",
+				StatusEquals:           "approved",
+				ExpiresBefore:          time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			expected: "&domain_name_like=example&gaining_registrar_equals=ClID-1&losing_registrar_equals=ClID-2&status_equals=approved&expires_before=2024-01-01T00:00:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.ToQueryParams(); got != tt.expected {
+				t.Errorf("ToQueryParams() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -12,6 +12,10 @@ type ListDomainsFilter struct {
 	NameLike string
 	// NameEquals does an equals search on the Name
 	NameEquals string
+	// NameMatches does a case insensitive search on the Name with a pattern where * matches any sequence of characters, e.g. exa*.apex
+	NameMatches string
+	// NsNameMatches only returns domains with a nameserver whose name matches the pattern, * matches any sequence of characters
+	NsNameMatches string
 	// TldEquals does an equals search on the Tld
 	TldEquals string
 	// ClidEquals does an equals search on the ClID
@@ -24,6 +28,8 @@ type ListDomainsFilter struct {
 	CreatedBefore time.Time
 	// CreatedAfter does a greater than search on the CreatedDate
 	CreatedAfter time.Time
+	// UpdatedAfter does a greater than search on the UpdatedDate
+	UpdatedAfter time.Time
 }
 
 // ToQueryParams converts the Filter to a query string that can be appended to the URL
@@ -41,6 +47,12 @@ func (df ListDomainsFilter) ToQueryParams() string {
 	if df.NameEquals != "" {
 		queryString += "&name_equals=" + df.NameEquals
 	}
+	if df.NameMatches != "" {
+		queryString += "&name_matches=" + df.NameMatches
+	}
+	if df.NsNameMatches != "" {
+		queryString += "&ns_name_matches=" + df.NsNameMatches
+	}
 	if df.TldEquals != "" {
 		queryString += "&tld_equals=" + df.TldEquals
 	}
@@ -59,5 +71,8 @@ func (df ListDomainsFilter) ToQueryParams() string {
 	if !df.CreatedAfter.IsZero() {
 		queryString += "&created_after=" + df.CreatedAfter.Format(time.RFC3339)
 	}
+	if !df.UpdatedAfter.IsZero() {
+		queryString += "&updated_after=" + df.UpdatedAfter.Format(time.RFC3339)
+	}
 	return queryString
 }
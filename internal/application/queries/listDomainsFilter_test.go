@@ -19,29 +19,36 @@ func TestToQueryParams_FullFilter(t *testing.T) {
 	expiresAfter := time.Date(2023, 11, 10, 12, 0, 0, 0, time.UTC)
 	createdBefore := time.Date(2022, 10, 10, 12, 0, 0, 0, time.UTC)
 	createdAfter := time.Date(2022, 11, 10, 12, 0, 0, 0, time.UTC)
+	updatedAfter := time.Date(2022, 12, 10, 12, 0, 0, 0, time.UTC)
 
 	filter := ListDomainsFilter{
 		RoidGreaterThan: "123",
 		NameLike:        "example",
 		NameEquals:      "example.com",
+		NameMatches:     "exa*.com",
+		NsNameMatches:   "ns1.*",
 		TldEquals:       "com",
 		ClidEquals:      "clid_abc",
 		ExpiresBefore:   expiresBefore,
 		ExpiresAfter:    expiresAfter,
 		CreatedBefore:   createdBefore,
 		CreatedAfter:    createdAfter,
+		UpdatedAfter:    updatedAfter,
 	}
 	got := filter.ToQueryParams()
 
 	expected := "&roid_greater_than=123" +
 		"&name_like=example" +
 		"&name_equals=example.com" +
+		"&name_matches=exa*.com" +
+		"&ns_name_matches=ns1.*" +
 		"&tld_equals=com" +
 		"&clid_equals=clid_abc" +
 		"&expires_before=" + expiresBefore.Format(time.RFC3339) +
 		"&expires_after=" + expiresAfter.Format(time.RFC3339) +
 		"&created_before=" + createdBefore.Format(time.RFC3339) +
-		"&created_after=" + createdAfter.Format(time.RFC3339)
+		"&created_after=" + createdAfter.Format(time.RFC3339) +
+		"&updated_after=" + updatedAfter.Format(time.RFC3339)
 
 	if got != expected {
 		t.Errorf("expected query string:\n%q\ngot:\n%q", expected, got)
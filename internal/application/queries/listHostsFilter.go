@@ -1,11 +1,22 @@
 package queries
 
+import "time"
+
 // ListHostsFilter is a filter for the ListHosts query
 type ListHostsFilter struct {
 	RoidGreaterThan string
 	RoidLessThan    string
 	ClidEquals      string
 	NameLike        string
+	NameEquals      string
+	// NameMatches does a case insensitive search on the Name with a pattern where * matches any sequence of characters, e.g. ns1.*
+	NameMatches string
+	// AddressEquals only returns hosts that have the IP address
+	AddressEquals string
+	// TldEquals only returns hosts that are used by a domain in the TLD
+	TldEquals string
+	// UpdatedAfter does a greater than search on the UpdatedDate
+	UpdatedAfter time.Time
 }
 
 // ToQueryParams converts the Filter to a query string that can be appended to the URL
@@ -28,5 +39,25 @@ func (f ListHostsFilter) ToQueryParams() string {
 		queryParams += "&name_like=" + f.NameLike
 	}
 
+	if f.NameEquals != "" {
+		queryParams += "&name_equals=" + f.NameEquals
+	}
+
+	if f.NameMatches != "" {
+		queryParams += "&name_matches=" + f.NameMatches
+	}
+
+	if f.AddressEquals != "" {
+		queryParams += "&address_equals=" + f.AddressEquals
+	}
+
+	if f.TldEquals != "" {
+		queryParams += "&tld_equals=" + f.TldEquals
+	}
+
+	if !f.UpdatedAfter.IsZero() {
+		queryParams += "&updated_after=" + f.UpdatedAfter.Format(time.RFC3339)
+	}
+
 	return queryParams
 }
@@ -1,6 +1,9 @@
 package queries
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestHostToQueryParams(t *testing.T) {
 	tests := []struct {
@@ -41,6 +44,29 @@ func TestHostToQueryParams(t *testing.T) {
 			},
 			expected: "&name_like=host",
 		},
+		{
+			name: "only NameEquals set",
+			filter: ListHostsFilter{
+				NameEquals: "ns1.example.com",
+			},
+			expected: "&name_equals=ns1.example.com",
+		},
+		{
+			name: "only NameMatches and AddressEquals set",
+			filter: ListHostsFilter{
+				NameMatches:   "ns1.*",
+				AddressEquals: "192.0.2.1",
+			},
+			expected: "&name_matches=ns1.*&address_equals=192.0.2.1",
+		},
+		{
+			name: "only TldEquals and UpdatedAfter set",
+			filter: ListHostsFilter{
+				TldEquals:    "apex",
+				UpdatedAfter: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+			expected: "&tld_equals=apex&updated_after=2024-03-01T00:00:00Z",
+		},
 		{
 			name: "multiple fields set",
 			filter: ListHostsFilter{
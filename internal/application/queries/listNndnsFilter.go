@@ -1,11 +1,15 @@
 package queries
 
+import "time"
+
 // ListNndnsFilter is the struct that contains the query for the list nndns query
 type ListNndnsFilter struct {
 	NameLike     string
 	TldEquals    string
 	ReasonEquals string
 	ReasonLike   string
+	// UpdatedAfter does a greater than search on the UpdatedDate
+	UpdatedAfter time.Time
 }
 
 // ToQueryParams converts the Filter to a query string that can be appended to the URL
@@ -23,5 +27,8 @@ func (nf ListNndnsFilter) ToQueryParams() string {
 	if nf.ReasonLike != "" {
 		queryString += "&reason_like=" + nf.ReasonLike
 	}
+	if !nf.UpdatedAfter.IsZero() {
+		queryString += "&updated_after=" + nf.UpdatedAfter.Format(time.RFC3339)
+	}
 	return queryString
 }
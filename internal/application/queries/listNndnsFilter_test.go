@@ -1,6 +1,9 @@
 package queries
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestToNNDNQueryParams(t *testing.T) {
 	tests := []struct {
@@ -41,6 +44,13 @@ func TestToNNDNQueryParams(t *testing.T) {
 			},
 			expected: "&reason_like=match",
 		},
+		{
+			name: "only UpdatedAfter set",
+			filter: ListNndnsFilter{
+				UpdatedAfter: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+			expected: "&updated_after=2024-03-01T00:00:00Z",
+		},
 		{
 			name: "multiple fields set",
 			filter: ListNndnsFilter{
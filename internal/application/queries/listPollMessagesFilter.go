@@ -0,0 +1,23 @@
+package queries
+
+// ListPollMessagesFilter is the struct that contains the filter for the list poll messages query
+type ListPollMessagesFilter struct {
+	ClidEquals            string
+	DomainNameLike        string
+	TransactionTypeEquals string
+}
+
+// ToQueryParams converts the Filter to a query string that can be appended to the URL
+func (f ListPollMessagesFilter) ToQueryParams() string {
+	queryString := ""
+	if f.ClidEquals != "" {
+		queryString += "&clid_equals=" + f.ClidEquals
+	}
+	if f.DomainNameLike != "" {
+		queryString += "&domain_name_like=" + f.DomainNameLike
+	}
+	if f.TransactionTypeEquals != "" {
+		queryString += "&transaction_type_equals=" + f.TransactionTypeEquals
+	}
+	return queryString
+}
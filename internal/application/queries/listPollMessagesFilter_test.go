@@ -0,0 +1,41 @@
+package queries
+
+import "testing"
+
+func TestListPollMessagesFilter_ToQueryParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   ListPollMessagesFilter
+		expected string
+	}{
+		{
+			name:     "all fields empty",
+			filter:   ListPollMessagesFilter{},
+			expected: "",
+		},
+		{
+			name: "only ClidEquals set",
+			filter: ListPollMessagesFilter{
+				ClidEquals: "ClID-1",
+			},
+			expected: "&clid_equals=ClID-1",
+		},
+		{
+			name: "all fields set",
+			filter: ListPollMessagesFilter{
+				ClidEquals:            "ClID-1",
+				DomainNameLike:        "example",
+				TransactionTypeEquals: "purge",
+			},
+			expected: "&clid_equals=ClID-1&domain_name_like=example&transaction_type_equals=purge",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.ToQueryParams(); got != tt.expected {
+				t.Errorf("ToQueryParams() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
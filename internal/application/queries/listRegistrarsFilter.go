@@ -6,8 +6,10 @@ import (
 
 // ListRegistrarsFilter is a filter for the ListRegistrars query
 type ListRegistrarsFilter struct {
-	ClidLike         string
-	NameLike         string
+	ClidLike string
+	NameLike string
+	// NameEquals does a case insensitive equals search on the Name
+	NameEquals       string
 	NickNameLike     string
 	GuridEquals      int
 	EmailLike        string
@@ -25,6 +27,9 @@ func (f ListRegistrarsFilter) ToQueryParams() string {
 	if f.NameLike != "" {
 		queryString += "&name_like=" + f.NameLike
 	}
+	if f.NameEquals != "" {
+		queryString += "&name_equals=" + f.NameEquals
+	}
 	if f.NickNameLike != "" {
 		queryString += "&nick_name_like=" + f.NickNameLike
 	}
@@ -23,6 +23,13 @@ func TestContactToQueryParams(t *testing.T) {
 			},
 			expected: "&clid_like=exampleClid",
 		},
+		{
+			name: "only NameEquals",
+			filter: ListRegistrarsFilter{
+				NameEquals: "Example Registrar, Inc.",
+			},
+			expected: "&name_equals=Example Registrar, Inc.",
+		},
 		{
 			name: "all fields set",
 			filter: ListRegistrarsFilter{
@@ -13,10 +13,11 @@ import (
 type QuoteRequest struct {
 	DomainName      string                   `json:"DomainName" binding:"required" example:"get.busy"`
 	TransactionType entities.TransactionType `json:"TransactionType" binding:"required" example:"registration"`
-	Currency        string                   `json:"Currency" binding:"required"  example:"USD"`
+	Currency        string                   `json:"Currency" binding:"required"  example:"USD"` // If empty the base currency of the phase is assumed
 	Years           int                      `json:"Years" binding:"required" example:"2"`
 	ClID            string                   `json:"ClID" binding:"required"  example:"1290-RiskNames"`
 	PhaseName       string                   `json:"PhaseName" example:"sunrise"` // Phase name - if empty the current GA phase is assumed
+	AllocationToken string                   `json:"AllocationToken"`             // Optional, the discount of the allocation token is applied to registrations
 }
 
 // Validate validates the QuoteRequest.
@@ -27,8 +28,7 @@ func (qr *QuoteRequest) Validate() error {
 	if !slices.Contains(entities.ValidTransactionTypesForQuote, qr.TransactionType) {
 		return errors.Join(entities.ErrInvalidQuoteRequest, entities.ErrInvalidTransactionTypeForQuote)
 	}
-	cur := money.GetCurrency(strings.ToUpper(qr.Currency))
-	if cur == nil {
+	if qr.Currency != "" && money.GetCurrency(strings.ToUpper(qr.Currency)) == nil {
 		return errors.Join(entities.ErrInvalidQuoteRequest, entities.ErrUnknownCurrency)
 	}
 	if qr.Years < 1 || qr.Years > entities.MaxHorizon {
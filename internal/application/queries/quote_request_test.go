@@ -46,6 +46,16 @@ func TestQuoteRequest_Validate(t *testing.T) {
 			},
 			expected: entities.ErrInvalidTransactionTypeForQuote,
 		},
+		{
+			name: "empty Currency defaults to the phase base currency",
+			request: QuoteRequest{
+				DomainName:      "example.com",
+				TransactionType: entities.TransactionTypeRegistration,
+				Years:           1,
+				ClID:            "testRegistrar1",
+			},
+			expected: nil,
+		},
 		{
 			name: "invalid Currency",
 			request: QuoteRequest{
@@ -3,6 +3,7 @@ package queries
 import (
 	"errors"
 	"net"
+	"strconv"
 
 	"github.com/onasunnymorning/domain-os/internal/domain/entities"
 )
@@ -12,7 +13,7 @@ const (
 	WhoisQueryTypeDomainName = "domainName"
 	// WhoisQueryTypeIP means the string in the query is a valid IP address v4 or v6.
 	WhoisQueryTypeIP = "ip"
-	// WhoisQueryTypeRegistrar means the string in the query not an IP address or a domain name, so it must be a registrar.
+	// WhoisQueryTypeRegistrar means the string in the query is a registrar IANA ID or not an IP address or a domain name, so it must be a registrar.
 	WhoisQueryTypeRegistrar = "registrar"
 )
 
@@ -33,6 +34,10 @@ func ClassifyWhoisQuery(rawQuery string) (string, error) {
 	if ip != nil {
 		return WhoisQueryTypeIP, nil
 	}
+	// A number is the IANA ID of a registrar, no TLD is all numeric so it can't be a domain name.
+	if _, err := strconv.Atoi(rawQuery); err == nil {
+		return WhoisQueryTypeRegistrar, nil
+	}
 	// Check if the query is a domain name.
 	_, err := entities.NewDomainName(rawQuery)
 	if err == nil {
@@ -0,0 +1,30 @@
+package queries
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyWhoisQuery(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+		err      error
+	}{
+		{query: "example.apex", expected: WhoisQueryTypeDomainName},
+		{query: "ns1.example.apex", expected: WhoisQueryTypeDomainName},
+		{query: "192.0.2.1", expected: WhoisQueryTypeIP},
+		{query: "2001:db8::1", expected: WhoisQueryTypeIP},
+		{query: "1234", expected: WhoisQueryTypeRegistrar},
+		{query: "Example Registrar, Inc.", expected: WhoisQueryTypeRegistrar},
+		{query: "exämple.apex", err: ErrInvalidWhoisQueryEncoding},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			qt, err := ClassifyWhoisQuery(tt.query)
+			require.ErrorIs(t, err, tt.err)
+			require.Equal(t, tt.expected, qt)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package schedules
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/onasunnymorning/domain-os/internal/application/workflows"
+	"github.com/onasunnymorning/domain-os/internal/infrastructure/temporal"
+	"go.temporal.io/sdk/client"
+)
+
+var (
+	transferAutoApproveScheduleIDPrefix = "transfer_auto_approve_schedule_"
+	transferAutoApproveWorkflowIDPrefix = "transfer_auto_approve_workflow_"
+)
+
+// CreateTransferAutoApproveScheduleHourly creates a schedule that runs the TransferAutoApproveLoop workflow every hour
+func CreateTransferAutoApproveScheduleHourly(cfg temporal.TemporalClientconfig) (string, error) {
+	ctx := context.Background()
+
+	scheduleID := transferAutoApproveScheduleIDPrefix + uuid.NewString()
+	workflowID := transferAutoApproveWorkflowIDPrefix + uuid.NewString()
+
+	// Create a Temporal client
+	temporalClient, err := temporal.GetTemporalClient(cfg)
+	if err != nil {
+		return "", err
+	}
+	defer temporalClient.Close()
+
+	// Create the schedule.
+	scheduleHandle, err := temporalClient.ScheduleClient().Create(ctx, client.ScheduleOptions{
+		ID: scheduleID,
+		Spec: client.ScheduleSpec{
+			Intervals: []client.ScheduleIntervalSpec{
+				{
+					Every:  time.Hour,
+					Offset: time.Minute * 45,
+				},
+			},
+		},
+		Action: &client.ScheduleWorkflowAction{
+			ID:        workflowID,
+			Workflow:  workflows.TransferAutoApproveLoop,
+			TaskQueue: cfg.WorkerQueue,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	return scheduleHandle.GetID(), nil
+}
@@ -0,0 +1,58 @@
+package schedules
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/onasunnymorning/domain-os/internal/application/workflows"
+	"github.com/onasunnymorning/domain-os/internal/infrastructure/temporal"
+	"go.temporal.io/sdk/client"
+)
+
+const (
+	// ZSKLifetime is the time between two ZSK rollovers
+	ZSKLifetime = 90 * 24 * time.Hour
+)
+
+var (
+	zskRolloverScheduleIDPrefix = "zsk_rollover_schedule_"
+	zskRolloverWorkflowIDPrefix = "zsk_rollover_workflow_"
+)
+
+// CreateZSKRolloverSchedule creates a schedule that runs the ZSKRolloverWorkflow for the TLD every ZSKLifetime, using the default intervals of the rollover
+func CreateZSKRolloverSchedule(cfg temporal.TemporalClientconfig, tldName string) (string, error) {
+	ctx := context.Background()
+
+	scheduleID := zskRolloverScheduleIDPrefix + tldName + "_" + uuid.NewString()
+	workflowID := zskRolloverWorkflowIDPrefix + tldName + "_" + uuid.NewString()
+
+	// Create a Temporal client
+	temporalClient, err := temporal.GetTemporalClient(cfg)
+	if err != nil {
+		return "", err
+	}
+	defer temporalClient.Close()
+
+	// Create the schedule.
+	scheduleHandle, err := temporalClient.ScheduleClient().Create(ctx, client.ScheduleOptions{
+		ID: scheduleID,
+		Spec: client.ScheduleSpec{
+			Intervals: []client.ScheduleIntervalSpec{
+				{
+					Every: ZSKLifetime,
+				},
+			},
+		},
+		Action: &client.ScheduleWorkflowAction{
+			ID:        workflowID,
+			Workflow:  workflows.ZSKRolloverWorkflow,
+			Args:      []interface{}{tldName, time.Duration(0), time.Duration(0)},
+			TaskQueue: cfg.WorkerQueue,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	return scheduleHandle.GetID(), nil
+}
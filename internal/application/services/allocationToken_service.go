@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/domain/repositories"
+)
+
+// AllocationTokenService implements the AllocationTokenService interface
+type AllocationTokenService struct {
+	tokenRepo repositories.AllocationTokenRepository
+}
+
+// NewAllocationTokenService returns a new instance of AllocationTokenService
+func NewAllocationTokenService(tokenRepo repositories.AllocationTokenRepository) *AllocationTokenService {
+	return &AllocationTokenService{
+		tokenRepo: tokenRepo,
+	}
+}
+
+// CreateAllocationToken creates a new allocation token. If the command has a domain name the token is bound to the domain and its TLD.
+func (svc *AllocationTokenService) CreateAllocationToken(ctx context.Context, cmd *commands.CreateAllocationTokenCommand) (*entities.AllocationToken, error) {
+	tldName := strings.ToLower(cmd.TLDName)
+	if tldName == "" && cmd.DomainName != "" {
+		dom := entities.DomainName(strings.ToLower(cmd.DomainName))
+		tldName = dom.ParentDomain()
+	}
+	at, err := entities.NewAllocationToken(cmd.Token, tldName)
+	if err != nil {
+		return nil, err
+	}
+	if err := setAllocationTokenBinding(at, &cmd.AllocationTokenBinding); err != nil {
+		return nil, err
+	}
+
+	created, err := svc.tokenRepo.Create(ctx, at)
+	if err != nil {
+		if errors.Is(err, entities.ErrDuplicateAllocationToken) {
+			return nil, errors.Join(entities.ErrInvalidAllocationToken, err)
+		}
+		return nil, err
+	}
+	return created, nil
+}
+
+// UpdateAllocationToken replaces the binding of an existing allocation token. The TLD of a token can't be changed.
+func (svc *AllocationTokenService) UpdateAllocationToken(ctx context.Context, token string, cmd *commands.UpdateAllocationTokenCommand) (*entities.AllocationToken, error) {
+	at, err := svc.tokenRepo.GetByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if cmd.TLDName != "" && strings.ToLower(cmd.TLDName) != at.TLDName.String() {
+		return nil, errors.Join(entities.ErrInvalidAllocationToken, errors.New("the TLD of a token can't be changed"))
+	}
+	if err := setAllocationTokenBinding(at, &cmd.AllocationTokenBinding); err != nil {
+		return nil, err
+	}
+	return svc.tokenRepo.Update(ctx, at)
+}
+
+// GetAllocationToken retrieves an allocation token by its token value
+func (svc *AllocationTokenService) GetAllocationToken(ctx context.Context, token string) (*entities.AllocationToken, error) {
+	return svc.tokenRepo.GetByToken(ctx, token)
+}
+
+// DeleteAllocationToken deletes an allocation token by its token value
+func (svc *AllocationTokenService) DeleteAllocationToken(ctx context.Context, token string) error {
+	return svc.tokenRepo.Delete(ctx, token)
+}
+
+// ListAllocationTokens retrieves a list of allocation tokens with pagination support
+func (svc *AllocationTokenService) ListAllocationTokens(ctx context.Context, params queries.ListItemsQuery) ([]*entities.AllocationToken, string, error) {
+	return svc.tokenRepo.List(ctx, params)
+}
+
+// CountAllocationTokens returns the number of allocation tokens matching the filter
+func (svc *AllocationTokenService) CountAllocationTokens(ctx context.Context, filter queries.ListAllocationTokensFilter) (int64, error) {
+	return svc.tokenRepo.Count(ctx, filter)
+}
+
+// setAllocationTokenBinding sets the domain, registrar, phase and conditions of the binding on the token and validates the result
+func setAllocationTokenBinding(at *entities.AllocationToken, b *commands.AllocationTokenBinding) error {
+	if err := at.SetDomainName(b.DomainName); err != nil {
+		return err
+	}
+	at.ClID = ""
+	if b.ClID != "" {
+		clid, err := entities.NewClIDType(b.ClID)
+		if err != nil {
+			return errors.Join(entities.ErrInvalidAllocationToken, err)
+		}
+		at.ClID = clid
+	}
+	at.PhaseName = ""
+	if b.PhaseName != "" {
+		phaseName, err := entities.NewClIDType(b.PhaseName)
+		if err != nil {
+			return errors.Join(entities.ErrInvalidAllocationToken, err)
+		}
+		at.PhaseName = phaseName
+	}
+	at.ExpiresAt = b.ExpiresAt.UTC()
+	at.MultiUse = b.MultiUse
+	at.DiscountPercentage = b.DiscountPercentage
+	return at.Validate()
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/onasunnymorning/domain-os/internal/application/commands"
 	"github.com/onasunnymorning/domain-os/internal/application/queries"
@@ -65,15 +66,49 @@ func (s *ContactService) BulkCreate(ctx context.Context, cmds []*commands.Create
 	return nil
 }
 
+// GetContactByID retrieves a contact by its ID. The linked status is derived from the domains referencing the contact
 func (s *ContactService) GetContactByID(ctx context.Context, id string) (*entities.Contact, error) {
-	return s.contactRepository.GetContactByID(ctx, id)
+	c, err := s.contactRepository.GetContactByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.contactRepository.GetContactAssociationCount(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	c.Status.Linked = count > 0
+
+	return c, nil
 }
 
+// UpdateContact validates and saves the contact
 func (s *ContactService) UpdateContact(ctx context.Context, c *entities.Contact) (*entities.Contact, error) {
+	if _, err := c.IsValid(); err != nil {
+		return nil, errors.Join(entities.ErrInvalidContact, err)
+	}
 	return s.contactRepository.UpdateContact(ctx, c)
 }
 
+// DeleteContactByID deletes a contact by its ID.
+// Contacts that have a delete prohibition or are linked to a domain can't be deleted
 func (s *ContactService) DeleteContactByID(ctx context.Context, id string) error {
+	c, err := s.contactRepository.GetContactByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !c.CanBeDeleted() {
+		return entities.ErrContactDeleteNotAllowed
+	}
+
+	count, err := s.contactRepository.GetContactAssociationCount(ctx, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.Join(entities.ErrContactIsLinked, fmt.Errorf("contact %s is referenced by %d domain(s)", id, count))
+	}
+
 	return s.contactRepository.DeleteContactByID(ctx, id)
 }
 
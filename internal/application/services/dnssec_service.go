@@ -0,0 +1,303 @@
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/domain/repositories"
+)
+
+const (
+	// Environment variables to configure DNSSEC
+	EnvDNSSECAlgorithm         = "DNSSEC_ALGORITHM"
+	EnvDNSSECDNSKEYTTL         = "DNSSEC_DNSKEY_TTL"
+	EnvDNSSECSignatureValidity = "DNSSEC_SIGNATURE_VALIDITY"
+	EnvDNSSECSignatureRefresh  = "DNSSEC_SIGNATURE_REFRESH"
+	EnvDNSSECNSEC3Iterations   = "DNSSEC_NSEC3_ITERATIONS"
+	EnvDNSSECNSEC3Salt         = "DNSSEC_NSEC3_SALT"
+	EnvDNSSECNSEC3OptOut       = "DNSSEC_NSEC3_OPTOUT"
+
+	// DefaultDNSSECAlgorithm is the algorithm used for new keys
+	// Ref: https://datatracker.ietf.org/doc/html/rfc8624#section-3.1
+	DefaultDNSSECAlgorithm = dns.ECDSAP256SHA256
+	// DefaultDNSKEYTTL is the TTL of the DNSKEY RRset
+	DefaultDNSKEYTTL = 3600
+	// DefaultSignatureValidity is the lifetime of new signatures
+	DefaultSignatureValidity = 14 * 24 * time.Hour
+	// DefaultSignatureRefresh is the time before the expiration at which signatures are renewed
+	DefaultSignatureRefresh = 7 * 24 * time.Hour
+	// SignatureInceptionOffset backdates the inception of signatures to allow for clock skew of validators
+	SignatureInceptionOffset = time.Hour
+)
+
+var (
+	ErrInvalidDNSSECConfig = errors.New("invalid DNSSEC configuration")
+	ErrNoActiveKSK         = errors.New("zone has DNSSEC keys but no active KSK")
+	ErrNotAZSK             = errors.New("key is not a ZSK")
+	ErrZSKNotPublished     = errors.New("ZSK must be published before it is activated")
+)
+
+// DNSSECConfig configures the signing of our zones and the keys we generate
+type DNSSECConfig struct {
+	// Algorithm is used for new keys, zones are signed with the algorithms of their keys
+	Algorithm uint8
+	DNSKEYTTL uint32
+	// New signatures are valid for SignatureValidity, existing signatures are reused until they expire within SignatureRefresh
+	SignatureValidity time.Duration
+	SignatureRefresh  time.Duration
+	// NSEC3 parameters, the salt is hex encoded (empty for no salt)
+	// Ref: https://datatracker.ietf.org/doc/html/rfc9276#section-3.1
+	NSEC3Iterations uint16
+	NSEC3Salt       string
+	// NSEC3OptOut leaves insecure delegations (without DS) out of the NSEC3 chain, which keeps the signed zone small
+	NSEC3OptOut bool
+}
+
+// DefaultDNSSECConfig returns the configuration following current best practices: ECDSA P-256 keys and NSEC3 without salt or additional iterations, with opt-out
+func DefaultDNSSECConfig() DNSSECConfig {
+	return DNSSECConfig{
+		Algorithm:         DefaultDNSSECAlgorithm,
+		DNSKEYTTL:         DefaultDNSKEYTTL,
+		SignatureValidity: DefaultSignatureValidity,
+		SignatureRefresh:  DefaultSignatureRefresh,
+		NSEC3OptOut:       true,
+	}
+}
+
+// ParseDNSSECConfig reads the DNSSEC configuration using getenv (e.g. os.Getenv), unset variables keep their default value
+func ParseDNSSECConfig(getenv func(string) string) (*DNSSECConfig, error) {
+	cfg := DefaultDNSSECConfig()
+	if s := getenv(EnvDNSSECAlgorithm); s != "" {
+		alg, ok := dns.StringToAlgorithm[strings.ToUpper(s)]
+		if !ok || !entities.IsSupportedDNSSECAlgorithm(alg) {
+			return nil, errors.Join(ErrInvalidDNSSECConfig, fmt.Errorf("%w: %s", entities.ErrUnsupportedDNSSECAlgorithm, s))
+		}
+		cfg.Algorithm = alg
+	}
+	if s := getenv(EnvDNSSECDNSKEYTTL); s != "" {
+		ttl, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return nil, errors.Join(ErrInvalidDNSSECConfig, fmt.Errorf("%s: %w", EnvDNSSECDNSKEYTTL, err))
+		}
+		cfg.DNSKEYTTL = uint32(ttl)
+	}
+	for _, d := range []struct {
+		env   string
+		value *time.Duration
+	}{
+		{EnvDNSSECSignatureValidity, &cfg.SignatureValidity},
+		{EnvDNSSECSignatureRefresh, &cfg.SignatureRefresh},
+	} {
+		s := getenv(d.env)
+		if s == "" {
+			continue
+		}
+		v, err := time.ParseDuration(s)
+		if err != nil || v <= 0 {
+			return nil, errors.Join(ErrInvalidDNSSECConfig, fmt.Errorf("%s: invalid duration %q", d.env, s))
+		}
+		*d.value = v
+	}
+	if cfg.SignatureRefresh >= cfg.SignatureValidity {
+		return nil, errors.Join(ErrInvalidDNSSECConfig, fmt.Errorf("%s must be shorter than %s", EnvDNSSECSignatureRefresh, EnvDNSSECSignatureValidity))
+	}
+	if s := getenv(EnvDNSSECNSEC3Iterations); s != "" {
+		iterations, err := strconv.ParseUint(s, 10, 16)
+		if err != nil {
+			return nil, errors.Join(ErrInvalidDNSSECConfig, fmt.Errorf("%s: %w", EnvDNSSECNSEC3Iterations, err))
+		}
+		cfg.NSEC3Iterations = uint16(iterations)
+	}
+	if s := getenv(EnvDNSSECNSEC3Salt); s != "" && s != "-" {
+		salt, err := hex.DecodeString(s)
+		if err != nil || len(salt) > 255 {
+			return nil, errors.Join(ErrInvalidDNSSECConfig, fmt.Errorf("%s: invalid hex salt %q", EnvDNSSECNSEC3Salt, s))
+		}
+		cfg.NSEC3Salt = strings.ToUpper(s)
+	}
+	if s := getenv(EnvDNSSECNSEC3OptOut); s != "" {
+		optOut, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, errors.Join(ErrInvalidDNSSECConfig, fmt.Errorf("%s: %w", EnvDNSSECNSEC3OptOut, err))
+		}
+		cfg.NSEC3OptOut = optOut
+	}
+	return &cfg, nil
+}
+
+// DNSSECService manages the DNSSEC keys of the TLD zones
+type DNSSECService struct {
+	keyRepository repositories.DNSSECKeyRepository
+	tldRepository repositories.TLDRepository
+	cfg           DNSSECConfig
+	now           func() time.Time
+}
+
+// NewDNSSECService returns a new DNSSECService
+func NewDNSSECService(keyRepo repositories.DNSSECKeyRepository, tldRepo repositories.TLDRepository, cfg DNSSECConfig) *DNSSECService {
+	return &DNSSECService{
+		keyRepository: keyRepo,
+		tldRepository: tldRepo,
+		cfg:           cfg,
+		now:           time.Now,
+	}
+}
+
+// ListKeys returns the keys of the TLD zone ordered by type and creation date
+func (s *DNSSECService) ListKeys(ctx context.Context, tldName string) ([]*entities.DNSSECKey, error) {
+	tld, err := s.tldRepository.GetByName(ctx, tldName, false)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := s.keyRepository.ListByZone(ctx, tld.Name.String())
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		if keys[i].Type() != keys[j].Type() {
+			return keys[i].Type() == entities.DNSSECKeyTypeKSK
+		}
+		return keys[i].Created.Before(keys[j].Created)
+	})
+	return keys, nil
+}
+
+// CreateKey generates a new key for the TLD zone that is published and active immediately, use this to sign a zone for the first time
+func (s *DNSSECService) CreateKey(ctx context.Context, tldName string, keyType entities.DNSSECKeyType) (*entities.DNSSECKey, error) {
+	now := s.now().UTC().Truncate(time.Second)
+	key, err := s.newKey(ctx, tldName, keyType, now)
+	if err != nil {
+		return nil, err
+	}
+	key.Publish, key.Activate = now, now
+	if err := s.keyRepository.Create(ctx, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// PrepublishZSK generates a new ZSK for the TLD zone that is published immediately but does not sign the zone until it is activated.
+// This is the first step of a pre-publish ZSK rollover.
+// Ref: https://datatracker.ietf.org/doc/html/rfc6781#section-4.1.1.1
+func (s *DNSSECService) PrepublishZSK(ctx context.Context, tldName string) (*entities.DNSSECKey, error) {
+	now := s.now().UTC().Truncate(time.Second)
+	key, err := s.newKey(ctx, tldName, entities.DNSSECKeyTypeZSK, now)
+	if err != nil {
+		return nil, err
+	}
+	key.Publish = now
+	if err := s.keyRepository.Create(ctx, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// ActivateZSK makes the pre-published ZSK with the key tag sign the zone, the ZSKs that were active until now stop signing but remain published
+func (s *DNSSECService) ActivateZSK(ctx context.Context, tldName string, keyTag uint16) (*entities.DNSSECKey, error) {
+	keys, err := s.ListKeys(ctx, tldName)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now().UTC().Truncate(time.Second)
+
+	var key *entities.DNSSECKey
+	for _, k := range keys {
+		if k.KeyTag() == keyTag && !k.IsRetired(now) {
+			key = k
+		}
+	}
+	if key == nil {
+		return nil, fmt.Errorf("%w: %s key tag %d", entities.ErrDNSSECKeyNotFound, tldName, keyTag)
+	}
+	if key.Type() != entities.DNSSECKeyTypeZSK {
+		return nil, fmt.Errorf("%w: %s key tag %d", ErrNotAZSK, tldName, keyTag)
+	}
+	if !key.IsPublished(now) {
+		return nil, fmt.Errorf("%w: %s key tag %d", ErrZSKNotPublished, tldName, keyTag)
+	}
+	if key.IsActive(now) {
+		return key, nil
+	}
+
+	key.Activate = now
+	if err := s.keyRepository.Update(ctx, key); err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		if k != key && k.Type() == entities.DNSSECKeyTypeZSK && k.IsActive(now) {
+			k.Inactive = now
+			if err := s.keyRepository.Update(ctx, k); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return key, nil
+}
+
+// RemoveRetiredZSKs removes the ZSKs that no longer sign the zone from the DNSKEY RRset and deletes them.
+// Only call this after the signatures of these keys have expired from the caches, this is the last step of a pre-publish ZSK rollover.
+func (s *DNSSECService) RemoveRetiredZSKs(ctx context.Context, tldName string) ([]*entities.DNSSECKey, error) {
+	keys, err := s.ListKeys(ctx, tldName)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now().UTC().Truncate(time.Second)
+
+	removed := []*entities.DNSSECKey{}
+	for _, k := range keys {
+		if k.Type() != entities.DNSSECKeyTypeZSK || !k.IsRetired(now) {
+			continue
+		}
+		if err := s.keyRepository.Delete(ctx, k); err != nil {
+			return nil, err
+		}
+		k.Delete = now
+		removed = append(removed, k)
+	}
+	return removed, nil
+}
+
+// GetDS returns the DS records for the published KSKs of the TLD zone
+func (s *DNSSECService) GetDS(ctx context.Context, tldName string) ([]*dns.DS, error) {
+	keys, err := s.ListKeys(ctx, tldName)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	ds := []*dns.DS{}
+	for _, k := range keys {
+		if k.Type() == entities.DNSSECKeyTypeKSK && k.IsPublished(now) {
+			ds = append(ds, k.DS())
+		}
+	}
+	return ds, nil
+}
+
+// PublishDS stores the DS records for the published KSKs of the TLD zone for submission to the parent (the root zone) and returns them
+func (s *DNSSECService) PublishDS(ctx context.Context, tldName string) ([]*dns.DS, error) {
+	ds, err := s.GetDS(ctx, tldName)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.keyRepository.SaveDS(ctx, dns.Fqdn(strings.ToLower(tldName)), ds); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
+// newKey checks that the TLD exists and generates a new key for its zone
+func (s *DNSSECService) newKey(ctx context.Context, tldName string, keyType entities.DNSSECKeyType, now time.Time) (*entities.DNSSECKey, error) {
+	tld, err := s.tldRepository.GetByName(ctx, tldName, false)
+	if err != nil {
+		return nil, err
+	}
+	return entities.NewDNSSECKey(tld.Name.String(), s.cfg.Algorithm, keyType, s.cfg.DNSKEYTTL, now)
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/require"
+)
+
+// MockDNSSECKeyRepository is an in-memory implementation of the DNSSECKeyRepository interface
+type MockDNSSECKeyRepository struct {
+	Keys []*entities.DNSSECKey
+	DS   map[string][]*dns.DS
+}
+
+// ListByZone returns the keys of the zone
+func (repo *MockDNSSECKeyRepository) ListByZone(ctx context.Context, zone string) ([]*entities.DNSSECKey, error) {
+	keys := []*entities.DNSSECKey{}
+	for _, k := range repo.Keys {
+		if k.Zone() == dns.CanonicalName(zone) {
+			keys = append(keys, k)
+		}
+	}
+	return keys, nil
+}
+
+// Create adds a key
+func (repo *MockDNSSECKeyRepository) Create(ctx context.Context, key *entities.DNSSECKey) error {
+	repo.Keys = append(repo.Keys, key)
+	return nil
+}
+
+// Update is a no-op as the keys are stored by reference
+func (repo *MockDNSSECKeyRepository) Update(ctx context.Context, key *entities.DNSSECKey) error {
+	return nil
+}
+
+// Delete removes a key
+func (repo *MockDNSSECKeyRepository) Delete(ctx context.Context, key *entities.DNSSECKey) error {
+	for i, k := range repo.Keys {
+		if k == key {
+			repo.Keys = append(repo.Keys[:i], repo.Keys[i+1:]...)
+			return nil
+		}
+	}
+	return entities.ErrDNSSECKeyNotFound
+}
+
+// SaveDS stores the DS records of the zone
+func (repo *MockDNSSECKeyRepository) SaveDS(ctx context.Context, zone string, ds []*dns.DS) error {
+	if repo.DS == nil {
+		repo.DS = map[string][]*dns.DS{}
+	}
+	repo.DS[zone] = ds
+	return nil
+}
+
+func TestParseDNSSECConfig(t *testing.T) {
+	cfg, err := ParseDNSSECConfig(func(string) string { return "" })
+	require.NoError(t, err)
+	require.Equal(t, DefaultDNSSECConfig(), *cfg)
+
+	env := map[string]string{
+		EnvDNSSECAlgorithm:         "ed25519",
+		EnvDNSSECDNSKEYTTL:         "7200",
+		EnvDNSSECSignatureValidity: "720h",
+		EnvDNSSECSignatureRefresh:  "240h",
+		EnvDNSSECNSEC3Iterations:   "5",
+		EnvDNSSECNSEC3Salt:         "aabbccdd",
+		EnvDNSSECNSEC3OptOut:       "false",
+	}
+	cfg, err = ParseDNSSECConfig(func(key string) string { return env[key] })
+	require.NoError(t, err)
+	require.Equal(t, DNSSECConfig{
+		Algorithm:         dns.ED25519,
+		DNSKEYTTL:         7200,
+		SignatureValidity: 720 * time.Hour,
+		SignatureRefresh:  240 * time.Hour,
+		NSEC3Iterations:   5,
+		NSEC3Salt:         "AABBCCDD",
+		NSEC3OptOut:       false,
+	}, *cfg)
+
+	for name, env := range map[string]map[string]string{
+		"unsupported algorithm":     {EnvDNSSECAlgorithm: "RSASHA1"},
+		"unknown algorithm":         {EnvDNSSECAlgorithm: "foo"},
+		"invalid TTL":               {EnvDNSSECDNSKEYTTL: "-1"},
+		"invalid validity":          {EnvDNSSECSignatureValidity: "14d"},
+		"refresh longer than valid": {EnvDNSSECSignatureRefresh: "400h"},
+		"invalid iterations":        {EnvDNSSECNSEC3Iterations: "70000"},
+		"invalid salt":              {EnvDNSSECNSEC3Salt: "xyz"},
+		"invalid opt-out":           {EnvDNSSECNSEC3OptOut: "maybe"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseDNSSECConfig(func(key string) string { return env[key] })
+			require.ErrorIs(t, err, ErrInvalidDNSSECConfig)
+		})
+	}
+}
+
+func TestDNSSECService_ZSKRollover(t *testing.T) {
+	ctx := context.Background()
+	tld, err := entities.NewTLD("example", "ry-example")
+	require.NoError(t, err)
+	keyRepo := &MockDNSSECKeyRepository{}
+	svc := NewDNSSECService(keyRepo, &MocktldRepository{Tlds: []*entities.TLD{tld}}, DefaultDNSSECConfig())
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	// Sign the zone for the first time
+	ksk, err := svc.CreateKey(ctx, "example", entities.DNSSECKeyTypeKSK)
+	require.NoError(t, err)
+	require.True(t, ksk.IsActive(now))
+	oldZSK, err := svc.CreateKey(ctx, "example", entities.DNSSECKeyTypeZSK)
+	require.NoError(t, err)
+	require.True(t, oldZSK.IsActive(now))
+
+	// Pre-publish the new ZSK
+	now = now.Add(time.Hour)
+	newZSK, err := svc.PrepublishZSK(ctx, "example")
+	require.NoError(t, err)
+	require.True(t, newZSK.IsPublished(now))
+	require.False(t, newZSK.IsActive(now))
+
+	_, err = svc.ActivateZSK(ctx, "example", ksk.KeyTag())
+	require.ErrorIs(t, err, ErrNotAZSK)
+	_, err = svc.ActivateZSK(ctx, "example", newZSK.KeyTag()+1)
+	require.ErrorIs(t, err, entities.ErrDNSSECKeyNotFound)
+
+	// Nothing to remove yet
+	removed, err := svc.RemoveRetiredZSKs(ctx, "example")
+	require.NoError(t, err)
+	require.Empty(t, removed)
+
+	// Activate the new ZSK, the old one stops signing
+	now = now.Add(24 * time.Hour)
+	activated, err := svc.ActivateZSK(ctx, "example", newZSK.KeyTag())
+	require.NoError(t, err)
+	require.True(t, activated.IsActive(now))
+	require.False(t, oldZSK.IsActive(now))
+	require.True(t, oldZSK.IsPublished(now))
+
+	// Remove the old ZSK
+	now = now.Add(72 * time.Hour)
+	removed, err = svc.RemoveRetiredZSKs(ctx, "example")
+	require.NoError(t, err)
+	require.Len(t, removed, 1)
+	require.Equal(t, oldZSK.KeyTag(), removed[0].KeyTag())
+
+	keys, err := svc.ListKeys(ctx, "example")
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	require.Equal(t, entities.DNSSECKeyTypeKSK, keys[0].Type())
+	require.Equal(t, newZSK.KeyTag(), keys[1].KeyTag())
+
+	// The DS is only published for the KSK
+	ds, err := svc.PublishDS(ctx, "example")
+	require.NoError(t, err)
+	require.Len(t, ds, 1)
+	require.Equal(t, ksk.KeyTag(), ds[0].KeyTag)
+	require.Equal(t, ds, keyRepo.DS["example."])
+}
+
+func TestDNSSECService_ActivateZSK_NotPublished(t *testing.T) {
+	ctx := context.Background()
+	tld, err := entities.NewTLD("example", "ry-example")
+	require.NoError(t, err)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	zsk, err := entities.NewDNSSECKey("example", dns.ECDSAP256SHA256, entities.DNSSECKeyTypeZSK, 3600, now)
+	require.NoError(t, err)
+	zsk.Publish = now.Add(time.Hour)
+
+	svc := NewDNSSECService(&MockDNSSECKeyRepository{Keys: []*entities.DNSSECKey{zsk}}, &MocktldRepository{Tlds: []*entities.TLD{tld}}, DefaultDNSSECConfig())
+	svc.now = func() time.Time { return now }
+	_, err = svc.ActivateZSK(ctx, "example", zsk.KeyTag())
+	require.ErrorIs(t, err, ErrZSKNotPublished)
+}
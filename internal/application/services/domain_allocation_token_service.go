@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// Allocation tokens (RFC 8495) allow a registrar to register a domain that is otherwise not available to it, e.g. a blocked name that is released to a specific registrar.
+// The token is checked with the availability of the domain, its discount is applied to the quote and it is redeemed in the same transaction that creates the domain.
+
+// allocationTokenForDomain retrieves the allocation token and checks it can be used by the registrar to register the domain in the phase.
+// Unknown tokens are reported as a mismatch, like tokens that are bound to another domain, so clients can't tell them apart.
+func (svc *DomainService) allocationTokenForDomain(ctx context.Context, token, domainName, clID, phaseName string) (*entities.AllocationToken, error) {
+	at, err := svc.tokenRepo.GetByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, entities.ErrAllocationTokenNotFound) {
+			return nil, errors.Join(entities.ErrAllocationTokenMismatch, err)
+		}
+		return nil, err
+	}
+	if err := at.CanBeUsed(domainName, clID, phaseName, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	return at, nil
+}
+
+// isAllocationTokenError checks if the error means the allocation token can't be used, as opposed to a failure to check the token
+func isAllocationTokenError(err error) bool {
+	return errors.Is(err, entities.ErrAllocationTokenMismatch) ||
+		errors.Is(err, entities.ErrAllocationTokenExpired) ||
+		errors.Is(err, entities.ErrAllocationTokenRedeemed)
+}
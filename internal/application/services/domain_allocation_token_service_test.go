@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/domain/repositories"
+	"github.com/onasunnymorning/domain-os/internal/infrastructure/snowflakeidgenerator"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newAllocationTokenTestService returns a DomainService for the apex TLD in GA where reserved.apex is blocked and the provided tokens exist
+func newAllocationTokenTestService(t *testing.T, tokens ...*entities.AllocationToken) *DomainService {
+	t.Helper()
+	tld, err := entities.NewTLD("apex", "ry-1")
+	require.NoError(t, err)
+	phase, err := entities.NewPhase("GAPhase", "GA", time.Now().UTC().AddDate(-1, 0, 0))
+	require.NoError(t, err)
+	price, err := entities.NewPrice("USD", 1000, 1000, 500, 2000)
+	require.NoError(t, err)
+	_, err = phase.AddPrice(*price)
+	require.NoError(t, err)
+	require.NoError(t, tld.AddPhase(phase))
+
+	domainRepo := new(repositories.MockDomainRepository)
+	domainRepo.On("GetDomainByName", mock.Anything, mock.Anything, false).Return((*entities.Domain)(nil), entities.ErrDomainNotFound)
+
+	nndn, err := entities.NewNNDN("reserved.apex")
+	require.NoError(t, err)
+	nndnRepo := new(repositories.MockNNDNRepository)
+	nndnRepo.On("GetNNDN", mock.Anything, "reserved.apex").Return(nndn, nil)
+	nndnRepo.On("GetNNDN", mock.Anything, mock.Anything).Return(nil, entities.ErrNNDNNotFound)
+
+	tokenRepo := new(repositories.MockAllocationTokenRepository)
+	for _, at := range tokens {
+		tokenRepo.On("GetByToken", mock.Anything, at.Token).Return(at, nil)
+	}
+	tokenRepo.On("GetByToken", mock.Anything, mock.Anything).Return(nil, entities.ErrAllocationTokenNotFound)
+
+	tldRepo := &MocktldRepository{Tlds: []*entities.TLD{tld}}
+	return NewDomainService(domainRepo, nil, RoidService{}, nndnRepo, tldRepo, nil, nil, nil, nil, nil, nil, nil, nil, tokenRepo)
+}
+
+func TestDomainService_CheckDomainAvailability_AllocationToken(t *testing.T) {
+	tldToken := &entities.AllocationToken{Token: "tld-token", TLDName: "apex"}
+	rarToken := &entities.AllocationToken{Token: "rar-token", TLDName: "apex", DomainName: "reserved.apex", ClID: "rar1"}
+	expiredToken := &entities.AllocationToken{Token: "expired-token", TLDName: "apex", ExpiresAt: time.Now().UTC().Add(-time.Hour)}
+
+	tests := []struct {
+		name          string
+		domain        string
+		clid          string
+		token         string
+		wantAvailable bool
+		wantErr       error
+	}{
+		{"not blocked", "example.apex", "rar1", "", true, nil},
+		{"blocked without token", "reserved.apex", "rar1", "", false, nil},
+		{"blocked with tld token", "reserved.apex", "rar2", "tld-token", true, nil},
+		{"blocked with registrar token", "reserved.apex", "rar1", "rar-token", true, nil},
+		{"token of other registrar", "reserved.apex", "rar2", "rar-token", false, entities.ErrAllocationTokenMismatch},
+		{"token of other domain", "example.apex", "rar1", "rar-token", false, entities.ErrAllocationTokenMismatch},
+		{"unknown token", "reserved.apex", "rar1", "unknown", false, entities.ErrAllocationTokenMismatch},
+		{"expired token", "reserved.apex", "rar1", "expired-token", false, entities.ErrAllocationTokenExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newAllocationTokenTestService(t, tldToken, rarToken, expiredToken)
+
+			result, err := svc.CheckDomainAvailability(context.Background(), tt.domain, "", tt.clid, tt.token)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantAvailable, result.Available)
+			if tt.name == "blocked without token" {
+				require.Equal(t, ErrDomainBlocked.Error(), result.Reason)
+			}
+		})
+	}
+}
+
+func TestAllocationTokenService_CreateAllocationToken(t *testing.T) {
+	var at *entities.AllocationToken
+	tokenRepo := new(repositories.MockAllocationTokenRepository)
+	tokenRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		at = args.Get(1).(*entities.AllocationToken)
+	}).Return(&entities.AllocationToken{}, nil)
+	svc := NewAllocationTokenService(tokenRepo)
+
+	_, err := svc.CreateAllocationToken(context.Background(), &commands.CreateAllocationTokenCommand{
+		AllocationTokenBinding: commands.AllocationTokenBinding{
+			DomainName:         "Reserved.apex",
+			ClID:               "rar1",
+			DiscountPercentage: 50,
+		},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, at.Token)
+	require.Equal(t, entities.DomainName("apex"), at.TLDName)
+	require.Equal(t, entities.DomainName("reserved.apex"), at.DomainName)
+	require.Equal(t, entities.ClIDType("rar1"), at.ClID)
+
+	_, err = svc.CreateAllocationToken(context.Background(), &commands.CreateAllocationTokenCommand{
+		AllocationTokenBinding: commands.AllocationTokenBinding{
+			TLDName:    "other",
+			DomainName: "reserved.apex",
+		},
+	})
+	require.ErrorIs(t, err, entities.ErrInvalidAllocationToken)
+}
+
+func TestAllocationTokenService_UpdateAllocationToken(t *testing.T) {
+	at := &entities.AllocationToken{Token: "abc123", TLDName: "apex", Redemptions: 1}
+	tokenRepo := new(repositories.MockAllocationTokenRepository)
+	tokenRepo.On("GetByToken", mock.Anything, "abc123").Return(at, nil)
+	tokenRepo.On("Update", mock.Anything, at).Return(at, nil)
+	svc := NewAllocationTokenService(tokenRepo)
+
+	_, err := svc.UpdateAllocationToken(context.Background(), "abc123", &commands.UpdateAllocationTokenCommand{
+		AllocationTokenBinding: commands.AllocationTokenBinding{TLDName: "other"},
+	})
+	require.ErrorIs(t, err, entities.ErrInvalidAllocationToken)
+
+	updated, err := svc.UpdateAllocationToken(context.Background(), "abc123", &commands.UpdateAllocationTokenCommand{
+		AllocationTokenBinding: commands.AllocationTokenBinding{DomainName: "reserved.apex", MultiUse: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, at, updated)
+	require.Equal(t, entities.DomainName("reserved.apex"), at.DomainName)
+	require.True(t, at.MultiUse)
+	require.Equal(t, 1, at.Redemptions)
+}
+
+func TestDomainService_RegisterDomain_AllocationToken(t *testing.T) {
+	token := &entities.AllocationToken{Token: "rar-token", TLDName: "apex", DomainName: "reserved.apex", ClID: "rar1"}
+	svc := newAllocationTokenTestService(t, token)
+	idgen, err := snowflakeidgenerator.NewIDGenerator()
+	require.NoError(t, err)
+	svc.roidService = *NewRoidService(idgen)
+	tld, err := svc.tldRepo.GetByName(context.Background(), "apex", true)
+	require.NoError(t, err)
+	svc.phaseRepo = &launchTestPhaseRepository{tld: tld}
+	rarRepo := new(repositories.MockRegistrarRepository)
+	rarRepo.On("IsRegistrarAccreditedForTLD", mock.Anything, "apex", "rar1").Return(true, nil)
+	svc.rarRepo = rarRepo
+	claimsRepo := new(repositories.MockClaimsRepository)
+	claimsRepo.On("GetClaimKey", mock.Anything, mock.Anything).Return("", nil)
+	svc.claimsRepo = claimsRepo
+	domainRepo := svc.domainRepository.(*repositories.MockDomainRepository)
+	domainRepo.On("CreateAndRedeemAllocationToken", mock.Anything, mock.Anything, "rar-token").Return(&entities.Domain{}, nil).Once()
+	domainRepo.On("CreateAndRedeemAllocationToken", mock.Anything, mock.Anything, "rar-token").Return((*entities.Domain)(nil), entities.ErrAllocationTokenRedeemed)
+
+	cmd := &commands.RegisterDomainCommand{
+		Name:            "reserved.apex",
+		ClID:            "rar1",
+		AuthInfo:        "sTr0ngP@ss",
+		RegistrantID:    "reg-1",
+		AdminID:         "reg-1",
+		TechID:          "reg-1",
+		BillingID:       "reg-1",
+		Years:           1,
+		AllocationToken: "rar-token",
+	}
+	_, err = svc.RegisterDomain(context.Background(), cmd)
+	require.NoError(t, err)
+
+	// A concurrent registration redeemed the single use token first, the domain is not created
+	_, err = svc.RegisterDomain(context.Background(), cmd)
+	require.ErrorIs(t, err, entities.ErrAllocationTokenRedeemed)
+	domainRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
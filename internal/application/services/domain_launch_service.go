@@ -0,0 +1,393 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+var (
+	// ErrPhaseNotApplicationBased is returned when applying for a domain in a phase that does not accept applications
+	ErrPhaseNotApplicationBased = errors.New("applications are only accepted in launch phases that require validation")
+)
+
+const (
+	// applicationListPageSize is the page size used to look up the competing applications for a domain
+	applicationListPageSize = 100
+)
+
+// GetClaimKey returns the key to retrieve the trademark claims notice of the domain, or an empty string if the domain does not match a trademark
+func (svc *DomainService) GetClaimKey(ctx context.Context, domainName string) (string, error) {
+	dom, err := entities.NewDomainName(domainName)
+	if err != nil {
+		return "", err
+	}
+	return svc.claimsRepo.GetClaimKey(ctx, dom.Label())
+}
+
+// validateLaunchData checks the launch data of a registration or application against the phase and the TLD:
+//   - registrations in the sunrise phase require a mark
+//   - a claims notice must be valid if it is provided
+//   - while the claims phase of the TLD is active, labels that match a trademark require a claims notice in all phases
+func (svc *DomainService) validateLaunchData(ctx context.Context, tld *entities.TLD, phase *entities.Phase, label, mark string, notice *entities.ClaimsNotice) error {
+	if phase.Name == entities.SunrisePhaseName && mark == "" {
+		return entities.ErrMarkRequired
+	}
+	if notice != nil {
+		return notice.Validate()
+	}
+	for _, launchPhase := range tld.GetCurrentLaunchPhases() {
+		if launchPhase.Name != entities.ClaimsPhaseName {
+			continue
+		}
+		claimKey, err := svc.claimsRepo.GetClaimKey(ctx, label)
+		if err != nil {
+			return err
+		}
+		if claimKey != "" {
+			return entities.ErrClaimsNoticeRequired
+		}
+	}
+	return nil
+}
+
+// CreateDomainApplication applies for a domain in a launch phase that requires validation on behalf of the registrar in the command.
+// The registrar must be accredited, the phase must be active and the domain must be available in the phase, competing applications for the same domain are allowed.
+// The registration data is validated against the phase policy now so the domain can be registered when the application is allocated.
+// The application is pending validation until the registry validates it.
+func (svc *DomainService) CreateDomainApplication(ctx context.Context, cmd *commands.CreateDomainApplicationCommand) (*entities.DomainApplication, error) {
+	years := cmd.Years
+	if years == 0 {
+		years = 1
+	}
+	app, err := entities.NewDomainApplication(cmd.Name, cmd.PhaseName, cmd.ClID, years)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if the registrar is accredited for the TLD
+	isAccredited, err := svc.rarRepo.IsRegistrarAccreditedForTLD(ctx, app.DomainName.ParentDomain(), cmd.ClID)
+	if err != nil {
+		return nil, errors.Join(ErrCouldNotDetermineAccreditation, err)
+	}
+	if !isAccredited {
+		return nil, errors.Join(ErrRegistrarNotAccredited, fmt.Errorf("Registrar.ClID: %s, TLD: %s", cmd.ClID, app.DomainName.ParentDomain()))
+	}
+
+	// Get the phase through the TLD, it must be an active launch phase that requires validation
+	tld, err := svc.tldRepo.GetByName(ctx, app.DomainName.ParentDomain(), true)
+	if err != nil {
+		return nil, err
+	}
+	phase, err := tld.FindPhaseByName(app.PhaseName)
+	if err != nil {
+		return nil, err
+	}
+	if phase.Type != entities.PhaseTypeLaunch || phase.Policy.RequiresValidation == nil || !*phase.Policy.RequiresValidation {
+		return nil, errors.Join(ErrPhaseNotApplicationBased, fmt.Errorf("phase: %s", phase.Name))
+	}
+	if !phase.IsCurrentlyActive() {
+		return nil, errors.Join(entities.ErrNoActivePhase, fmt.Errorf("phase %s is not active", phase.Name))
+	}
+
+	// Check if the domain is available in the phase
+	checkResult, err := svc.CheckDomainAvailability(ctx, app.DomainName.String(), phase.Name.String(), app.ClID.String(), "")
+	if err != nil {
+		return nil, err
+	}
+	if !checkResult.Available {
+		return nil, domainNotAvailableError(checkResult)
+	}
+
+	if err := svc.validateLaunchData(ctx, tld, phase, app.DomainName.Label(), cmd.Mark, cmd.ClaimsNotice); err != nil {
+		return nil, err
+	}
+
+	// Set the registration data of the domain
+	if err := cmd.ApplyContactDataPolicy(phase.Policy.ContactDataPolicy); err != nil {
+		return nil, err
+	}
+	app.AuthInfo, err = entities.NewAuthInfoType(cmd.AuthInfo)
+	if err != nil {
+		return nil, err
+	}
+	app.RegistrantID = entities.ClIDType(cmd.RegistrantID)
+	app.AdminID = entities.ClIDType(cmd.AdminID)
+	app.TechID = entities.ClIDType(cmd.TechID)
+	app.BillingID = entities.ClIDType(cmd.BillingID)
+	for _, h := range cmd.HostNames {
+		app.AddHostName(h)
+	}
+	if err := svc.checkApplicationHosts(ctx, app); err != nil {
+		return nil, err
+	}
+	app.Mark = cmd.Mark
+	if cmd.ClaimsNotice != nil {
+		app.NoticeID = cmd.ClaimsNotice.NoticeID
+	}
+	app.CorrelationID = correlationIDFromContext(ctx)
+
+	createdApp, err := svc.applicationRepo.Create(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+
+	svc.logger.Info(
+		fmt.Sprintf("Application %s for domain %s created by %s in phase %s", createdApp.ID, createdApp.DomainName, createdApp.ClID, createdApp.PhaseName),
+		zap.String("event_type", "domain_application_event"),
+		zap.Any("command", cmd),
+		zap.Any("new_state", createdApp),
+	)
+
+	return createdApp, nil
+}
+
+// GetDomainApplication returns the domain application with the provided ID
+func (svc *DomainService) GetDomainApplication(ctx context.Context, id string) (*entities.DomainApplication, error) {
+	// Application IDs are UUIDs, anything else can't be an application
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.Join(entities.ErrDomainApplicationNotFound, fmt.Errorf("invalid applicationID: %s", id))
+	}
+	return svc.applicationRepo.GetByID(ctx, id)
+}
+
+// ListDomainApplications returns a list of domain applications
+func (svc *DomainService) ListDomainApplications(ctx context.Context, params queries.ListItemsQuery) ([]*entities.DomainApplication, string, error) {
+	return svc.applicationRepo.List(ctx, params)
+}
+
+// CountDomainApplications returns the number of domain applications matching the filter
+func (svc *DomainService) CountDomainApplications(ctx context.Context, filter queries.ListDomainApplicationsFilter) (int64, error) {
+	return svc.applicationRepo.Count(ctx, filter)
+}
+
+// UpdateDomainApplication updates the registration data of an application: the contacts, nameservers and authInfo. Other changes to the application are ignored.
+// Only the registrar that created the application can update it and only as long as it is not allocated, rejected or invalid.
+func (svc *DomainService) UpdateDomainApplication(ctx context.Context, app *entities.DomainApplication) (*entities.DomainApplication, error) {
+	current, err := svc.applicationRepo.GetByID(ctx, app.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	if current.ClID != app.ClID {
+		return nil, entities.ErrInvalidRegistrar
+	}
+	if current.IsFinal() {
+		return nil, entities.ErrDomainApplicationFinal
+	}
+	if err := app.AuthInfo.Validate(); err != nil {
+		return nil, err
+	}
+	if err := svc.checkApplicationHosts(ctx, app); err != nil {
+		return nil, err
+	}
+
+	prevState := *current
+	current.AuthInfo = app.AuthInfo
+	current.RegistrantID = app.RegistrantID
+	current.AdminID = app.AdminID
+	current.TechID = app.TechID
+	current.BillingID = app.BillingID
+	current.HostNames = app.HostNames
+	current.UpdatedAt = time.Now().UTC()
+
+	updatedApp, err := svc.applicationRepo.Update(ctx, current)
+	if err != nil {
+		return nil, err
+	}
+
+	svc.logger.Info(
+		fmt.Sprintf("Application %s for domain %s updated by %s", updatedApp.ID, updatedApp.DomainName, updatedApp.ClID),
+		zap.String("event_type", "domain_application_event"),
+		zap.Any("new_state", updatedApp),
+		zap.Any("previous_state", prevState),
+	)
+
+	return updatedApp, nil
+}
+
+// WithdrawDomainApplication deletes an application as long as it is not allocated, rejected or invalid.
+// Only the registrar that created the application can withdraw it, an empty clid withdraws on behalf of the registry.
+func (svc *DomainService) WithdrawDomainApplication(ctx context.Context, id, clid string) error {
+	app, err := svc.GetDomainApplication(ctx, id)
+	if err != nil {
+		return err
+	}
+	if clid != "" && entities.ClIDType(clid) != app.ClID {
+		return entities.ErrInvalidRegistrar
+	}
+	if app.IsFinal() {
+		return entities.ErrDomainApplicationFinal
+	}
+	if err := svc.applicationRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	svc.logger.Info(
+		fmt.Sprintf("Application %s for domain %s withdrawn", app.ID, app.DomainName),
+		zap.String("event_type", "domain_application_event"),
+		zap.Any("previous_state", app),
+	)
+
+	return nil
+}
+
+// SetDomainApplicationStatus sets the status of an application as the registry, e.g. after validating the mark.
+// Use AllocateDomainApplication to allocate the domain. The registrar is notified through a poll message.
+func (svc *DomainService) SetDomainApplicationStatus(ctx context.Context, id string, status entities.ApplicationStatus, reason string) (*entities.DomainApplication, error) {
+	app, err := svc.GetDomainApplication(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if app.Status == status {
+		return app, nil
+	}
+	if err := app.SetStatus(status, reason); err != nil {
+		return nil, err
+	}
+	app.CorrelationID = correlationIDFromContext(ctx)
+
+	updatedApp, err := svc.applicationRepo.Update(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+	svc.notifyDomainApplication(ctx, updatedApp)
+
+	return updatedApp, nil
+}
+
+// AllocateDomainApplication registers the domain of a validated application to the applicant, who is charged for the registration as if it was registered in the phase of the application.
+// The competing applications for the domain are rejected. All applicants are notified through a poll message.
+func (svc *DomainService) AllocateDomainApplication(ctx context.Context, id string) (*entities.DomainApplication, error) {
+	app, err := svc.GetDomainApplication(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if app.IsFinal() {
+		return nil, entities.ErrDomainApplicationFinal
+	}
+	if !app.CanBeAllocated() {
+		return nil, errors.Join(entities.ErrInvalidApplicationStatus, fmt.Errorf("cannot allocate an application with status %s", app.Status))
+	}
+
+	dom, err := svc.registerDomain(ctx, &commands.RegisterDomainCommand{
+		Name:         app.DomainName.String(),
+		ClID:         app.ClID.String(),
+		AuthInfo:     app.AuthInfo.String(),
+		RegistrantID: app.RegistrantID.String(),
+		AdminID:      app.AdminID.String(),
+		TechID:       app.TechID.String(),
+		BillingID:    app.BillingID.String(),
+		Years:        app.Years,
+		HostNames:    app.HostNames,
+		PhaseName:    app.PhaseName.String(),
+	}, app)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := app.Allocate(dom.RoID); err != nil {
+		return nil, err
+	}
+	app.CorrelationID = correlationIDFromContext(ctx)
+	allocatedApp, err := svc.applicationRepo.Update(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+	svc.notifyDomainApplication(ctx, allocatedApp)
+
+	// The domain is registered now, so the competing applications can't be allocated anymore
+	if err := svc.rejectCompetingApplications(ctx, allocatedApp); err != nil {
+		return nil, err
+	}
+
+	return allocatedApp, nil
+}
+
+// rejectCompetingApplications rejects the applications for the same domain as the allocated application that are not final yet
+func (svc *DomainService) rejectCompetingApplications(ctx context.Context, allocatedApp *entities.DomainApplication) error {
+	var competing []*entities.DomainApplication
+	cursor := ""
+	for {
+		apps, nextCursor, err := svc.applicationRepo.List(ctx, queries.ListItemsQuery{
+			PageSize:   applicationListPageSize,
+			PageCursor: cursor,
+			Filter:     queries.ListDomainApplicationsFilter{DomainNameEquals: allocatedApp.DomainName.String()},
+		})
+		if err != nil {
+			return err
+		}
+		for _, app := range apps {
+			if app.ID != allocatedApp.ID && !app.IsFinal() {
+				competing = append(competing, app)
+			}
+		}
+		if nextCursor == "" {
+			break
+		}
+		cursor = nextCursor
+	}
+
+	for _, app := range competing {
+		if err := app.SetStatus(entities.ApplicationStatusRejected, fmt.Sprintf("domain allocated to application %s", allocatedApp.ID)); err != nil {
+			return err
+		}
+		app.CorrelationID = correlationIDFromContext(ctx)
+		rejectedApp, err := svc.applicationRepo.Update(ctx, app)
+		if err != nil {
+			return err
+		}
+		svc.notifyDomainApplication(ctx, rejectedApp)
+	}
+	return nil
+}
+
+// checkApplicationHosts checks that the nameservers of the application exist and are sponsored by the applicant
+func (svc *DomainService) checkApplicationHosts(ctx context.Context, app *entities.DomainApplication) error {
+	for _, h := range app.HostNames {
+		if _, err := svc.hostRepository.GetHostByNameAndClID(ctx, strings.ToLower(h), app.ClID.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// notifyDomainApplication logs the status change of an application and queues a poll message for the applicant.
+// The change has already been persisted at this point, so failing to queue the message is logged rather than returned.
+func (svc *DomainService) notifyDomainApplication(ctx context.Context, app *entities.DomainApplication) {
+	msg := fmt.Sprintf("Application %s for domain %s is %s", app.ID, app.DomainName, app.Status)
+	if app.Reason != "" {
+		msg += ": " + app.Reason
+	}
+	svc.logger.Info(
+		msg,
+		zap.String("event_type", "domain_application_event"),
+		zap.Any("new_state", app),
+	)
+
+	if svc.pollRepo == nil {
+		return
+	}
+	pm, err := entities.NewPollMessage(app.ClID.String(), msg)
+	if err == nil {
+		pm.DomainName = app.DomainName
+		pm.DomainRoID = app.DomainRoID
+		pm.TraceID = app.CorrelationID
+		_, err = svc.pollRepo.Create(ctx, pm)
+	}
+	if err != nil {
+		svc.logger.Error(
+			"failed to queue poll message",
+			zap.String("clid", app.ClID.String()),
+			zap.String("domain_name", app.DomainName.String()),
+			zap.Error(err),
+		)
+	}
+}
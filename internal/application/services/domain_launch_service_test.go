@@ -0,0 +1,305 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/domain/repositories"
+	"github.com/onasunnymorning/domain-os/internal/infrastructure/snowflakeidgenerator"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// launchTestMocks holds the repositories used by the launch tests
+type launchTestMocks struct {
+	rarRepo    *repositories.MockRegistrarRepository
+	pollRepo   *repositories.MockPollMessageRepository
+	appRepo    *repositories.MockDomainApplicationRepository
+	claimsRepo *repositories.MockClaimsRepository
+}
+
+// newLaunchTestService returns a DomainService for the "apex" TLD with a current GA phase, a current "claims" launch phase,
+// a current "landrush" launch phase that requires validation and a "sunrise" launch phase that does not.
+// The label "brand" matches a trademark.
+func newLaunchTestService(t *testing.T) (*DomainService, *entities.TLD, *launchTestMocks) {
+	t.Helper()
+	tld, err := entities.NewTLD("apex", "ry-1")
+	require.NoError(t, err)
+	start := time.Now().UTC().AddDate(0, -1, 0)
+	ga, err := entities.NewPhase("GAPhase", "GA", start)
+	require.NoError(t, err)
+	require.NoError(t, tld.AddPhase(ga))
+	for _, name := range []string{entities.ClaimsPhaseName, entities.SunrisePhaseName, "landrush"} {
+		phase, err := entities.NewPhase(name, "Launch", start)
+		require.NoError(t, err)
+		if name == "landrush" {
+			requiresValidation := true
+			phase.Policy.RequiresValidation = &requiresValidation
+		}
+		require.NoError(t, tld.AddPhase(phase))
+	}
+
+	m := &launchTestMocks{
+		rarRepo:    new(repositories.MockRegistrarRepository),
+		pollRepo:   new(repositories.MockPollMessageRepository),
+		appRepo:    new(repositories.MockDomainApplicationRepository),
+		claimsRepo: new(repositories.MockClaimsRepository),
+	}
+	m.rarRepo.On("IsRegistrarAccreditedForTLD", mock.Anything, "apex", "ClID-1").Return(true, nil)
+	m.pollRepo.On("Create", mock.Anything, mock.Anything).Return(&entities.PollMessage{ID: 1}, nil)
+	m.claimsRepo.On("GetClaimKey", mock.Anything, "brand").Return("2013041500/2/6/9/rJ1NrDO92vDsAzf7EQzgjX4R0000000001", nil)
+	m.claimsRepo.On("GetClaimKey", mock.Anything, mock.Anything).Return("", nil)
+
+	tldRepo := &MocktldRepository{Tlds: []*entities.TLD{tld}}
+	svc := NewDomainService(nil, nil, RoidService{}, nil, tldRepo, nil, nil, nil, m.rarRepo, m.pollRepo, nil, m.appRepo, m.claimsRepo, nil)
+	return svc, tld, m
+}
+
+// getLaunchTestApplication returns an application for example.apex in the landrush phase by ClID-1 with the provided status
+func getLaunchTestApplication(t *testing.T, status entities.ApplicationStatus) *entities.DomainApplication {
+	t.Helper()
+	app, err := entities.NewDomainApplication("example.apex", "landrush", "ClID-1", 1)
+	require.NoError(t, err)
+	app.Status = status
+	return app
+}
+
+func TestDomainService_ValidateLaunchData(t *testing.T) {
+	svc, tld, _ := newLaunchTestService(t)
+	sunrise, err := tld.FindPhaseByName(entities.SunrisePhaseName)
+	require.NoError(t, err)
+	ga, err := tld.GetCurrentGAPhase()
+	require.NoError(t, err)
+	validNotice := &entities.ClaimsNotice{NoticeID: "abc", NotAfter: time.Now().UTC().Add(time.Hour), AcceptedDate: time.Now().UTC().Add(-time.Hour)}
+
+	tests := []struct {
+		name    string
+		phase   *entities.Phase
+		label   string
+		mark    string
+		notice  *entities.ClaimsNotice
+		wantErr error
+	}{
+		{"sunrise with mark", sunrise, "example", "smd", nil, nil},
+		{"sunrise without mark", sunrise, "example", "", nil, entities.ErrMarkRequired},
+		{"no trademark", ga, "example", "", nil, nil},
+		{"trademark without notice", ga, "brand", "", nil, entities.ErrClaimsNoticeRequired},
+		{"trademark with notice", ga, "brand", "", validNotice, nil},
+		{"invalid notice", ga, "example", "", &entities.ClaimsNotice{NoticeID: "abc"}, entities.ErrInvalidClaimsNotice},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.validateLaunchData(context.TODO(), tld, tt.phase, tt.label, tt.mark, tt.notice)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDomainService_ValidateLaunchData_ClaimsPhaseEnded(t *testing.T) {
+	svc, tld, _ := newLaunchTestService(t)
+	claims, err := tld.FindPhaseByName(entities.ClaimsPhaseName)
+	require.NoError(t, err)
+	ended := time.Now().UTC().Add(-time.Hour)
+	claims.Ends = &ended
+	ga, err := tld.GetCurrentGAPhase()
+	require.NoError(t, err)
+
+	require.NoError(t, svc.validateLaunchData(context.TODO(), tld, ga, "brand", "", nil))
+}
+
+// launchTestPhaseRepository returns the phases of the TLD of the launch tests
+type launchTestPhaseRepository struct {
+	repositories.PhaseRepository
+	tld *entities.TLD
+}
+
+func (r *launchTestPhaseRepository) GetPhaseByTLDAndName(ctx context.Context, tld, name string) (*entities.Phase, error) {
+	return r.tld.FindPhaseByName(entities.ClIDType(name))
+}
+
+func TestDomainService_RegisterDomain_Sunrise(t *testing.T) {
+	svc, tld, _ := newLaunchTestService(t)
+	idgen, err := snowflakeidgenerator.NewIDGenerator()
+	require.NoError(t, err)
+	svc.roidService = *NewRoidService(idgen)
+	svc.phaseRepo = &launchTestPhaseRepository{tld: tld}
+	nndnRepo := new(repositories.MockNNDNRepository)
+	nndnRepo.On("GetNNDN", mock.Anything, mock.Anything).Return(nil, entities.ErrNNDNNotFound)
+	svc.nndnRepo = nndnRepo
+	domainRepo := new(repositories.MockDomainRepository)
+	domainRepo.On("GetDomainByName", mock.Anything, mock.Anything, false).Return((*entities.Domain)(nil), entities.ErrDomainNotFound)
+	var created *entities.Domain
+	domainRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*entities.Domain)
+	}).Return(&entities.Domain{}, nil)
+	svc.domainRepository = domainRepo
+
+	// The mark is not verified, the domain is pending creation until the registry validates it
+	_, err = svc.RegisterDomain(context.TODO(), &commands.RegisterDomainCommand{
+		Name:         "example.apex",
+		ClID:         "ClID-1",
+		AuthInfo:     "sTr0ngP@ss",
+		RegistrantID: "reg-1",
+		AdminID:      "reg-1",
+		TechID:       "reg-1",
+		BillingID:    "reg-1",
+		Years:        1,
+		PhaseName:    entities.SunrisePhaseName,
+		Mark:         "any-mark",
+	})
+	require.NoError(t, err)
+	require.True(t, created.Status.PendingCreate)
+
+	// The policy of the phase itself is unchanged
+	sunrise, err := tld.FindPhaseByName(entities.SunrisePhaseName)
+	require.NoError(t, err)
+	require.False(t, *sunrise.Policy.RequiresValidation)
+
+	_, err = svc.RegisterDomain(context.TODO(), &commands.RegisterDomainCommand{
+		Name:         "other.apex",
+		ClID:         "ClID-1",
+		AuthInfo:     "sTr0ngP@ss",
+		RegistrantID: "reg-1",
+		AdminID:      "reg-1",
+		TechID:       "reg-1",
+		BillingID:    "reg-1",
+		Years:        1,
+	})
+	require.NoError(t, err)
+	require.False(t, created.Status.PendingCreate)
+}
+
+func TestDomainService_GetClaimKey(t *testing.T) {
+	svc, _, _ := newLaunchTestService(t)
+
+	key, err := svc.GetClaimKey(context.TODO(), "brand.apex")
+	require.NoError(t, err)
+	require.NotEmpty(t, key)
+
+	key, err = svc.GetClaimKey(context.TODO(), "example.apex")
+	require.NoError(t, err)
+	require.Empty(t, key)
+
+	_, err = svc.GetClaimKey(context.TODO(), "-brand.apex")
+	require.ErrorIs(t, err, entities.ErrInvalidLabelDash)
+}
+
+func TestDomainService_CreateDomainApplication_Phase(t *testing.T) {
+	svc, _, _ := newLaunchTestService(t)
+
+	tests := []struct {
+		phase   string
+		wantErr error
+	}{
+		{entities.SunrisePhaseName, ErrPhaseNotApplicationBased},
+		{"GAPhase", ErrPhaseNotApplicationBased},
+		{"auction", entities.ErrPhaseNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phase, func(t *testing.T) {
+			_, err := svc.CreateDomainApplication(context.TODO(), &commands.CreateDomainApplicationCommand{Name: "example.apex", ClID: "ClID-1", PhaseName: tt.phase, AuthInfo: "STr0mgP@ZZ"})
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestDomainService_GetDomainApplication_InvalidID(t *testing.T) {
+	svc, _, m := newLaunchTestService(t)
+
+	_, err := svc.GetDomainApplication(context.TODO(), "not-a-uuid")
+	require.ErrorIs(t, err, entities.ErrDomainApplicationNotFound)
+	m.appRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestDomainService_UpdateDomainApplication(t *testing.T) {
+	svc, _, m := newLaunchTestService(t)
+	current := getLaunchTestApplication(t, entities.ApplicationStatusPendingValidation)
+	m.appRepo.On("GetByID", mock.Anything, current.ID.String()).Return(current, nil)
+	m.appRepo.On("Update", mock.Anything, mock.Anything).Return(current, nil)
+
+	// Only the contacts, nameservers and authInfo are changed
+	changes := *current
+	changes.RegistrantID = "reg-2"
+	changes.AuthInfo = "N3wP@ssw0rd"
+	changes.Status = entities.ApplicationStatusValidated
+	changes.Mark = "forged"
+	_, err := svc.UpdateDomainApplication(context.TODO(), &changes)
+	require.NoError(t, err)
+	require.Equal(t, entities.ClIDType("reg-2"), current.RegistrantID)
+	require.Equal(t, entities.AuthInfoType("N3wP@ssw0rd"), current.AuthInfo)
+	require.Equal(t, entities.ApplicationStatusPendingValidation, current.Status)
+	require.Empty(t, current.Mark)
+
+	// Only the applicant can update the application
+	changes.ClID = "ClID-2"
+	_, err = svc.UpdateDomainApplication(context.TODO(), &changes)
+	require.ErrorIs(t, err, entities.ErrInvalidRegistrar)
+}
+
+func TestDomainService_WithdrawDomainApplication(t *testing.T) {
+	svc, _, m := newLaunchTestService(t)
+	app := getLaunchTestApplication(t, entities.ApplicationStatusValidated)
+	final := getLaunchTestApplication(t, entities.ApplicationStatusRejected)
+	m.appRepo.On("GetByID", mock.Anything, app.ID.String()).Return(app, nil)
+	m.appRepo.On("GetByID", mock.Anything, final.ID.String()).Return(final, nil)
+	m.appRepo.On("Delete", mock.Anything, app.ID.String()).Return(nil)
+
+	require.ErrorIs(t, svc.WithdrawDomainApplication(context.TODO(), app.ID.String(), "ClID-2"), entities.ErrInvalidRegistrar)
+	require.ErrorIs(t, svc.WithdrawDomainApplication(context.TODO(), final.ID.String(), "ClID-1"), entities.ErrDomainApplicationFinal)
+	require.NoError(t, svc.WithdrawDomainApplication(context.TODO(), app.ID.String(), "ClID-1"))
+	m.appRepo.AssertCalled(t, "Delete", mock.Anything, app.ID.String())
+}
+
+func TestDomainService_SetDomainApplicationStatus(t *testing.T) {
+	svc, _, m := newLaunchTestService(t)
+	app := getLaunchTestApplication(t, entities.ApplicationStatusPendingValidation)
+	m.appRepo.On("GetByID", mock.Anything, app.ID.String()).Return(app, nil)
+	m.appRepo.On("Update", mock.Anything, mock.Anything).Return(app, nil)
+
+	updated, err := svc.SetDomainApplicationStatus(context.TODO(), app.ID.String(), entities.ApplicationStatusInvalid, "the mark does not match the label")
+	require.NoError(t, err)
+	require.Equal(t, entities.ApplicationStatusInvalid, updated.Status)
+	m.pollRepo.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(pm *entities.PollMessage) bool {
+		return pm.ClID == "ClID-1" && pm.DomainName == "example.apex"
+	}))
+
+	_, err = svc.SetDomainApplicationStatus(context.TODO(), app.ID.String(), entities.ApplicationStatusValidated, "")
+	require.ErrorIs(t, err, entities.ErrDomainApplicationFinal)
+}
+
+func TestDomainService_AllocateDomainApplication_NotValidated(t *testing.T) {
+	svc, _, m := newLaunchTestService(t)
+	app := getLaunchTestApplication(t, entities.ApplicationStatusPendingValidation)
+	m.appRepo.On("GetByID", mock.Anything, app.ID.String()).Return(app, nil)
+
+	_, err := svc.AllocateDomainApplication(context.TODO(), app.ID.String())
+	require.ErrorIs(t, err, entities.ErrInvalidApplicationStatus)
+	m.appRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestDomainService_RejectCompetingApplications(t *testing.T) {
+	svc, _, m := newLaunchTestService(t)
+	allocated := getLaunchTestApplication(t, entities.ApplicationStatusAllocated)
+	competing := getLaunchTestApplication(t, entities.ApplicationStatusValidated)
+	invalid := getLaunchTestApplication(t, entities.ApplicationStatusInvalid)
+	m.appRepo.On("List", mock.Anything, mock.MatchedBy(func(q queries.ListItemsQuery) bool {
+		return q.Filter == queries.ListDomainApplicationsFilter{DomainNameEquals: "example.apex"}
+	})).Return([]*entities.DomainApplication{allocated, competing, invalid}, "", nil)
+	m.appRepo.On("Update", mock.Anything, mock.Anything).Return(competing, nil)
+
+	require.NoError(t, svc.rejectCompetingApplications(context.TODO(), allocated))
+	require.Equal(t, entities.ApplicationStatusRejected, competing.Status)
+	require.Equal(t, entities.ApplicationStatusInvalid, invalid.Status)
+	require.Equal(t, entities.ApplicationStatusAllocated, allocated.Status)
+	m.appRepo.AssertNumberOfCalls(t, "Update", 1)
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// A restore is a two step process (RFC 3915). RestoreDomain puts the domain in pendingRestore status, after which the registrar must submit a restore report within the pendingRestore period.
+// The restore workflow completes the restore of the domains for which a report was received and returns the others to the redemption period once the pendingRestore period has ended (see CompleteDomainRestore).
+
+// ReportDomainRestore records the restore report of a domain in pendingRestore status.
+// Only the sponsoring registrar can submit the report, an empty ClID submits the report on behalf of the registry.
+func (svc *DomainService) ReportDomainRestore(ctx context.Context, cmd *commands.RestoreReportCommand) (*entities.Domain, error) {
+	dom, err := svc.GetDomainByName(ctx, cmd.Name, false)
+	if err != nil {
+		return nil, err
+	}
+	if cmd.ClID != "" && dom.ClID.String() != cmd.ClID {
+		return nil, entities.ErrInvalidRegistrar
+	}
+
+	prevState := dom.DeepCopy()
+
+	if err := dom.SubmitRestoreReport(cmd.ToEntity()); err != nil {
+		return nil, err
+	}
+
+	updatedDomain, err := svc.domainRepository.UpdateDomain(ctx, dom)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := svc.restoreLifecycleEvent(updatedDomain)
+	if err != nil {
+		return nil, err
+	}
+	msg := fmt.Sprintf("Restore report for domain %s received from %s", updatedDomain.Name, updatedDomain.ClID)
+	svc.logDomainLifecycleEvent(ctx, msg, event, cmd, updatedDomain, prevState)
+
+	return updatedDomain, nil
+}
+
+// CompleteDomainRestore processes a domain in pendingRestore status and returns it in its resulting state:
+//   - if the restore report was received, the pendingRestore status is unset. The calling code is expected to renew the domain to charge the restore.
+//   - if no report was received within the pendingRestore period, the domain is returned to the redemption period (pendingDelete status).
+//   - if the pendingRestore period has not ended yet, the domain is returned unchanged.
+//
+// The registrar is notified through a poll message when the restore is completed or cancelled.
+func (svc *DomainService) CompleteDomainRestore(ctx context.Context, domainName string) (*entities.Domain, error) {
+	dom, err := svc.GetDomainByName(ctx, domainName, false)
+	if err != nil {
+		return nil, err
+	}
+
+	prevState := dom.DeepCopy()
+
+	var msg string
+	if dom.RestoreReport.IsNil() {
+		err := dom.CancelRestore()
+		if errors.Is(err, entities.ErrRestoreReportPending) {
+			return dom, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		msg = fmt.Sprintf("Restore of domain %s cancelled, no restore report was received before %s", dom.Name, dom.RGPStatus.PendingRestorePeriodEnd.Format(time.RFC3339))
+	} else {
+		if err := dom.CompleteRestore(); err != nil {
+			return nil, err
+		}
+		msg = fmt.Sprintf("Restore of domain %s completed", dom.Name)
+	}
+
+	updatedDomain, err := svc.domainRepository.UpdateDomain(ctx, dom)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := svc.restoreLifecycleEvent(updatedDomain)
+	if err != nil {
+		return nil, err
+	}
+	svc.logDomainLifecycleEvent(ctx, msg, event, nil, updatedDomain, prevState)
+	svc.queuePollMessage(ctx, msg, event)
+
+	return updatedDomain, nil
+}
+
+// restoreLifecycleEvent creates the lifecycle event for the steps of a restore that follow the restore request. These are not charged.
+func (svc *DomainService) restoreLifecycleEvent(dom *entities.Domain) (*entities.DomainLifeCycleEvent, error) {
+	event, err := entities.NewDomainLifeCycleEvent(
+		dom.ClID.String(),
+		"",
+		dom.Name.ParentDomain(),
+		dom.Name.String(),
+		0,
+		entities.TransactionTypeUpdate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	event.DomainRoID = dom.RoID.String()
+	return event, nil
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/domain/repositories"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// newRestoreTestService returns a DomainService whose domain repository returns the provided domain and a poll repository to inspect the poll messages
+func newRestoreTestService(dom *entities.Domain) (*DomainService, *repositories.MockPollMessageRepository) {
+	domainRepo := new(repositories.MockDomainRepository)
+	domainRepo.On("GetDomainByName", mock.Anything, "example.apex", false).Return(dom, nil)
+	domainRepo.On("UpdateDomain", mock.Anything, mock.Anything).Return(dom, nil)
+	pollRepo := new(repositories.MockPollMessageRepository)
+	pollRepo.On("Create", mock.Anything, mock.Anything).Return(&entities.PollMessage{ID: 1}, nil)
+	svc := NewDomainService(domainRepo, nil, RoidService{}, nil, nil, nil, nil, nil, nil, pollRepo, nil, nil, nil, nil)
+	return svc, pollRepo
+}
+
+// getPendingRestoreTestDomain returns a domain in pendingRestore status that was restored the provided number of days ago
+func getPendingRestoreTestDomain(daysAgo int) *entities.Domain {
+	return &entities.Domain{
+		RoID:       "1234_DOM-APEX",
+		Name:       "example.apex",
+		ClID:       "GoMamma",
+		ExpiryDate: time.Now().UTC().AddDate(0, 0, -10),
+		Status:     entities.DomainStatus{PendingRestore: true},
+		RGPStatus: entities.DomainRGPStatus{
+			RedemptionPeriodEnd:     time.Now().UTC().AddDate(0, 0, 20),
+			PendingRestorePeriodEnd: time.Now().UTC().AddDate(0, 0, entities.PendingRestorePeriod-daysAgo),
+		},
+	}
+}
+
+func getTestRestoreReportCommand(clid string) *commands.RestoreReportCommand {
+	return &commands.RestoreReportCommand{
+		Name:       "example.apex",
+		ClID:       clid,
+		PreData:    "pre",
+		PostData:   "post",
+		DelTime:    time.Now().UTC().AddDate(0, 0, -10),
+		ResTime:    time.Now().UTC().AddDate(0, 0, -1),
+		ResReason:  "deleted by mistake",
+		Statements: []string{"statement 1", "statement 2"},
+	}
+}
+
+func TestDomainService_ReportDomainRestore(t *testing.T) {
+	dom := getPendingRestoreTestDomain(1)
+	svc, _ := newRestoreTestService(dom)
+
+	_, err := svc.ReportDomainRestore(context.Background(), getTestRestoreReportCommand("otherRar"))
+	require.ErrorIs(t, err, entities.ErrInvalidRegistrar)
+
+	cmd := getTestRestoreReportCommand("GoMamma")
+	cmd.Statements = nil
+	_, err = svc.ReportDomainRestore(context.Background(), cmd)
+	require.ErrorIs(t, err, entities.ErrInvalidRestoreReport)
+
+	restored, err := svc.ReportDomainRestore(context.Background(), getTestRestoreReportCommand("GoMamma"))
+	require.NoError(t, err)
+	require.False(t, restored.RestoreReport.IsNil())
+	require.Equal(t, "deleted by mistake", restored.RestoreReport.ResReason)
+	require.True(t, restored.Status.PendingRestore)
+
+	// Only one report is accepted
+	_, err = svc.ReportDomainRestore(context.Background(), getTestRestoreReportCommand(""))
+	require.ErrorIs(t, err, entities.ErrRestoreReportNotAllowed)
+}
+
+func TestDomainService_CompleteDomainRestore(t *testing.T) {
+	t.Run("report received", func(t *testing.T) {
+		dom := getPendingRestoreTestDomain(1)
+		dom.RestoreReport.ReportedAt = time.Now().UTC()
+		svc, pollRepo := newRestoreTestService(dom)
+
+		completed, err := svc.CompleteDomainRestore(context.Background(), "example.apex")
+		require.NoError(t, err)
+		require.False(t, completed.Status.PendingRestore)
+		require.False(t, completed.Status.PendingDelete)
+		pollRepo.AssertNumberOfCalls(t, "Create", 1)
+	})
+
+	t.Run("report pending", func(t *testing.T) {
+		svc, pollRepo := newRestoreTestService(getPendingRestoreTestDomain(1))
+
+		dom, err := svc.CompleteDomainRestore(context.Background(), "example.apex")
+		require.NoError(t, err)
+		require.True(t, dom.Status.PendingRestore)
+		pollRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("report overdue", func(t *testing.T) {
+		svc, pollRepo := newRestoreTestService(getPendingRestoreTestDomain(entities.PendingRestorePeriod + 1))
+
+		dom, err := svc.CompleteDomainRestore(context.Background(), "example.apex")
+		require.NoError(t, err)
+		require.False(t, dom.Status.PendingRestore)
+		require.True(t, dom.Status.PendingDelete)
+		require.Equal(t, []string{entities.RGPStatusRedemptionPeriod}, dom.RGPStatuses(time.Now().UTC()))
+		pollRepo.AssertNumberOfCalls(t, "Create", 1)
+	})
+}
+
+func TestDomainService_RestoreDomain_Quote(t *testing.T) {
+	tld, err := entities.NewTLD("apex", "ry-1")
+	require.NoError(t, err)
+	phase, err := entities.NewPhase("GAPhase", "GA", time.Now().UTC().AddDate(-1, 0, 0))
+	require.NoError(t, err)
+	price, err := entities.NewPrice("USD", 1000, 1000, 500, 4000)
+	require.NoError(t, err)
+	_, err = phase.AddPrice(*price)
+	require.NoError(t, err)
+	require.NoError(t, tld.AddPhase(phase))
+
+	dom := &entities.Domain{
+		RoID:       "1234_DOM-APEX",
+		Name:       "example.apex",
+		ClID:       "GoMamma",
+		ExpiryDate: time.Now().UTC().AddDate(0, 0, -10),
+		Status:     entities.DomainStatus{PendingDelete: true},
+		RGPStatus:  entities.DomainRGPStatus{RedemptionPeriodEnd: time.Now().UTC().AddDate(0, 0, 20)},
+	}
+	svc, _ := newRestoreTestService(dom)
+	svc.tldRepo = &MocktldRepository{Tlds: []*entities.TLD{tld}}
+	core, logs := observer.New(zap.InfoLevel)
+	svc.logger = zap.New(core)
+
+	// A fee that does not match the restore price is refused
+	_, err = svc.RestoreDomain(context.Background(), "example.apex", commands.FeeExtension{Currency: "USD", Amount: 1000, Provided: true})
+	require.ErrorIs(t, err, entities.ErrFeeMismatch)
+	require.True(t, dom.Status.PendingDelete)
+
+	// The restore is charged the restore price the client agreed to
+	_, err = svc.RestoreDomain(context.Background(), "example.apex", commands.FeeExtension{Currency: "USD", Amount: 4000, Provided: true})
+	require.NoError(t, err)
+	require.True(t, dom.Status.PendingRestore)
+	entries := logs.FilterField(zap.String("event_type", "domain_lifecycle_event")).All()
+	require.Len(t, entries, 1)
+	event, ok := entries[0].ContextMap()["domain_lifecycle_event"].(*entities.DomainLifeCycleEvent)
+	require.True(t, ok)
+	require.Equal(t, entities.TransactionTypeRestore, event.Quote.TransactionType)
+	require.Equal(t, int64(4000), event.Quote.Price.Amount())
+}
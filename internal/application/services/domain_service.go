@@ -52,6 +52,11 @@ type DomainService struct {
 	premiumLabelRepo repositories.PremiumLabelRepository
 	fxRepo           repositories.FXRepository
 	rarRepo          repositories.RegistrarRepository
+	pollRepo         repositories.PollMessageRepository
+	transferRepo     repositories.DomainTransferRepository
+	applicationRepo  repositories.DomainApplicationRepository
+	claimsRepo       repositories.ClaimsRepository
+	tokenRepo        repositories.AllocationTokenRepository
 	logger           *zap.Logger
 }
 
@@ -66,6 +71,11 @@ func NewDomainService(
 	plr repositories.PremiumLabelRepository,
 	fxr repositories.FXRepository,
 	rRepo repositories.RegistrarRepository,
+	pollRepo repositories.PollMessageRepository,
+	transferRepo repositories.DomainTransferRepository,
+	applicationRepo repositories.DomainApplicationRepository,
+	claimsRepo repositories.ClaimsRepository,
+	tokenRepo repositories.AllocationTokenRepository,
 ) *DomainService {
 	logger, _ := zap.NewProduction()
 	return &DomainService{
@@ -78,6 +88,11 @@ func NewDomainService(
 		premiumLabelRepo: plr,
 		fxRepo:           fxr,
 		rarRepo:          rRepo,
+		pollRepo:         pollRepo,
+		transferRepo:     transferRepo,
+		applicationRepo:  applicationRepo,
+		claimsRepo:       claimsRepo,
+		tokenRepo:        tokenRepo,
 		logger:           logger,
 	}
 }
@@ -145,6 +160,7 @@ func (s *DomainService) Create(ctx context.Context, cmd *commands.CreateDomainCo
 	}
 	d.GrandFathering = cmd.GrandFathering
 	d.RenewedYears = cmd.RenewedYears
+	d.SecDNS = cmd.SecDNS
 
 	// Check if the domain is valid
 	if err := d.Validate(); err != nil {
@@ -257,6 +273,7 @@ func (s *DomainService) UpdateDomain(ctx context.Context, name string, upDom *co
 	dom.Status = upDom.Status
 	dom.RGPStatus = upDom.RGPStatus
 	dom.GrandFathering = upDom.GrandFathering
+	dom.SecDNS = upDom.SecDNS
 
 	// Validate the domain
 	if err := dom.Validate(); err != nil {
@@ -265,22 +282,9 @@ func (s *DomainService) UpdateDomain(ctx context.Context, name string, upDom *co
 
 	// If the phase is provided, check if the domain contacts are valid in the phase
 	if upDom.EnforcePhasePolicy {
-		// Get the phase
-		tld, err := s.tldRepo.GetByName(ctx, dom.Name.ParentDomain(), true)
-		if err != nil {
-			return nil, err
-		}
-		phase, err := tld.GetCurrentGAPhase()
-		if err != nil {
+		if err := s.applyGAPhaseContactDataPolicy(ctx, dom); err != nil {
 			return nil, err
 		}
-		// Since this is an update we don't check the validity of the label as it already exists and doesn't change
-
-		// Apply the contact data policy
-		err = dom.ApplyContactDataPolicy(phase.Policy.ContactDataPolicy)
-		if err != nil {
-			return nil, errors.Join(entities.ErrInvalidDomain, err)
-		}
 	}
 
 	// Save and return
@@ -307,6 +311,201 @@ func (s *DomainService) UpdateDomain(ctx context.Context, name string, upDom *co
 	return updatedDomain, nil
 }
 
+// applyGAPhaseContactDataPolicy applies the contact data policy of the current GA phase of the TLD to the domain.
+// Since this is used for updates we don't check the validity of the label as it already exists and doesn't change
+func (s *DomainService) applyGAPhaseContactDataPolicy(ctx context.Context, dom *entities.Domain) error {
+	tld, err := s.tldRepo.GetByName(ctx, dom.Name.ParentDomain(), true)
+	if err != nil {
+		return err
+	}
+	phase, err := tld.GetCurrentGAPhase()
+	if err != nil {
+		return err
+	}
+	err = dom.ApplyContactDataPolicy(phase.Policy.ContactDataPolicy)
+	if err != nil {
+		return errors.Join(entities.ErrInvalidDomain, err)
+	}
+	return nil
+}
+
+// ApplyDomainUpdate applies all changes of a single registrar update to the domain and persists them in one transaction, either all changes are saved or none are.
+// The changes are applied to the domain as it is read for update by the repository, so concurrent updates of the same domain are not lost.
+// Every change is validated against the domain before anything is saved. The changes are applied in the following order:
+//  1. remove statuses, this allows a client to remove clientUpdateProhibited and make other changes in the same command
+//  2. contacts, authInfo and secDNS changes
+//  3. remove and add nameservers
+//  4. add statuses, this allows a client to make changes and set clientUpdateProhibited in the same command
+func (s *DomainService) ApplyDomainUpdate(ctx context.Context, cmd *commands.ApplyDomainUpdateCommand) (*entities.Domain, error) {
+	var prevDom *entities.Domain
+	updatedDomain, err := s.domainRepository.UpdateDomainAndHosts(ctx, cmd.Name, func(dom *entities.Domain) ([]*entities.Host, error) {
+		// Make a copy of the original domain
+		prevDom = dom.DeepCopy()
+		return s.applyDomainUpdate(ctx, dom, cmd)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Log a lifecycle event
+	event, err := entities.NewDomainLifeCycleEvent(
+		updatedDomain.ClID.String(),
+		"",
+		updatedDomain.Name.ParentDomain(),
+		updatedDomain.Name.String(),
+		0,
+		entities.TransactionTypeUpdate,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logDomainLifecycleEvent(ctx, fmt.Sprintf("Domain %s updated", updatedDomain.Name), event, cmd, updatedDomain, prevDom)
+
+	return updatedDomain, nil
+}
+
+// applyDomainUpdate applies the changes of the command to the domain and validates the result. It returns the hosts that were removed from the domain.
+func (s *DomainService) applyDomainUpdate(ctx context.Context, dom *entities.Domain, cmd *commands.ApplyDomainUpdateCommand) ([]*entities.Host, error) {
+	if cmd.ClID != "" && dom.ClID.String() != cmd.ClID {
+		return nil, entities.ErrInvalidRegistrar
+	}
+
+	// 1. Remove statuses
+	for _, status := range cmd.RemStatuses {
+		if err := dom.UnSetStatus(status); err != nil {
+			return nil, errors.Join(ErrCannotSetDomainStatus, err)
+		}
+	}
+
+	// If the domain still can't be updated, only status changes are allowed (which are validated by the domain entity)
+	if cmd.HasObjectChanges() && !dom.CanBeUpdated() {
+		return nil, entities.ErrDomainUpdateNotAllowed
+	}
+
+	// 2. Contacts, authInfo and secDNS
+	if cmd.HasContactChanges() || cmd.SecDNS != nil {
+		if err := applyDomainContactChanges(dom, cmd); err != nil {
+			return nil, err
+		}
+		if cmd.SecDNS != nil {
+			if err := applyDomainSecDNSChanges(dom, cmd.SecDNS); err != nil {
+				return nil, err
+			}
+		}
+		if cmd.ClID != "" {
+			dom.UpRr = entities.ClIDType(cmd.ClID)
+		}
+		if cmd.EnforcePhasePolicy {
+			if err := s.applyGAPhaseContactDataPolicy(ctx, dom); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// 3. Remove and add nameservers
+	var removedHosts []*entities.Host
+	for _, hostName := range cmd.RemHosts {
+		host, err := s.hostRepository.GetHostByNameAndClID(ctx, strings.ToLower(hostName), dom.ClID.String())
+		if err != nil {
+			return nil, err
+		}
+		if err := dom.RemoveHost(host); err != nil {
+			return nil, err
+		}
+		removedHosts = append(removedHosts, host)
+	}
+	for _, hostName := range cmd.AddHosts {
+		host, err := s.hostRepository.GetHostByNameAndClID(ctx, strings.ToLower(hostName), dom.ClID.String())
+		if err != nil {
+			return nil, err
+		}
+		if _, err := dom.AddHost(host, false); err != nil && !errors.Is(err, entities.ErrDuplicateHost) {
+			return nil, err
+		}
+	}
+
+	// 4. Add statuses
+	for _, status := range cmd.AddStatuses {
+		if err := dom.SetStatus(status); err != nil {
+			return nil, errors.Join(ErrCannotSetDomainStatus, err)
+		}
+	}
+
+	// Validate the end result before saving anything
+	if err := dom.Validate(); err != nil {
+		return nil, errors.Join(entities.ErrInvalidDomain, err)
+	}
+
+	return removedHosts, nil
+}
+
+// applyDomainContactChanges applies the contact and authInfo changes of the command to the domain.
+// Contacts are removed before they are added so a contact can be replaced in a single command.
+func applyDomainContactChanges(dom *entities.Domain, cmd *commands.ApplyDomainUpdateCommand) error {
+	for _, c := range cmd.RemContacts {
+		field, err := domainContactField(dom, c.Type)
+		if err != nil {
+			return err
+		}
+		if field.String() != c.ID {
+			return errors.Join(entities.ErrContactNotFound, fmt.Errorf("%s is not the %s contact of %s", c.ID, c.Type, dom.Name))
+		}
+		*field = ""
+	}
+	for _, c := range cmd.AddContacts {
+		field, err := domainContactField(dom, c.Type)
+		if err != nil {
+			return err
+		}
+		*field = entities.ClIDType(c.ID)
+	}
+	if cmd.RegistrantID != nil {
+		dom.RegistrantID = entities.ClIDType(*cmd.RegistrantID)
+	}
+	if cmd.AuthInfo != nil {
+		dom.AuthInfo = entities.AuthInfoType(*cmd.AuthInfo)
+	}
+	return nil
+}
+
+// domainContactField returns a pointer to the contact field of the domain for the provided contact type
+func domainContactField(dom *entities.Domain, contactType string) (*entities.ClIDType, error) {
+	switch contactType {
+	case "admin":
+		return &dom.AdminID, nil
+	case "tech":
+		return &dom.TechID, nil
+	case "billing":
+		return &dom.BillingID, nil
+	default:
+		return nil, errors.Join(entities.ErrInvalidContact, fmt.Errorf("unknown contact type: %s", contactType))
+	}
+}
+
+// applyDomainSecDNSChanges applies the DNSSEC delegation data changes to the domain, DS records are removed before they are added
+func applyDomainSecDNSChanges(dom *entities.Domain, chg *commands.DomainSecDNSChanges) error {
+	if chg.RemAll {
+		dom.RemoveAllDSData()
+	}
+	for _, ds := range chg.RemDSData {
+		if err := dom.RemoveDSData(ds); err != nil {
+			return err
+		}
+	}
+	for _, ds := range chg.AddDSData {
+		if err := dom.AddDSData(ds); err != nil {
+			return err
+		}
+	}
+	if chg.MaxSigLife != nil {
+		if err := dom.SetMaxSigLife(*chg.MaxSigLife); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetDomainByName retrieves a domain by its name from the repository
 func (s *DomainService) GetDomainByName(ctx context.Context, name string, preloadHosts bool) (*entities.Domain, error) {
 	return s.domainRepository.GetDomainByName(ctx, name, preloadHosts)
@@ -348,6 +547,10 @@ func (s *DomainService) DeleteDomainByName(ctx context.Context, name string) err
 
 	msg := fmt.Sprintf("Domain %s ADMIN deleted", name)
 	s.logDomainLifecycleEvent(ctx, msg, event, nil, nil, prevState)
+	if prevState != nil {
+		event.DomainRoID = prevState.RoID.String()
+		s.queuePollMessage(ctx, msg, event)
+	}
 
 	return nil
 }
@@ -426,6 +629,7 @@ func (s *DomainService) PurgeDomain(ctx context.Context, name string) error {
 
 	msg := fmt.Sprintf("Domain %s purged", name)
 	s.logDomainLifecycleEvent(ctx, msg, event, nil, createdNNDN, dom)
+	s.queuePollMessage(ctx, msg, event)
 
 	return nil
 
@@ -815,10 +1019,11 @@ func (svc *DomainService) CheckDomainIsBlocked(ctx context.Context, domainName s
 // It performs the following checks:
 // 1. Validates the domain name against the RFCs.
 // 2. Checks if the domain already exists.
-// 3. Checks if the domain is blocked.
+// 3. Checks if the domain is blocked, unless an allocation token is provided.
 // 4. Retrieves the phase by name if provided, otherwise gets the current GA phase.
 // 5. Checks if the domain label is valid in the current phase.
-func (svc *DomainService) CheckDomainAvailability(ctx context.Context, domainName, phaseName string) (*queries.DomainCheckResult, error) {
+// 6. Checks if the allocation token, if provided, can be used by the registrar for the domain in the phase. A valid token makes a blocked domain available.
+func (svc *DomainService) CheckDomainAvailability(ctx context.Context, domainName, phaseName, clID, allocationToken string) (*queries.DomainCheckResult, error) {
 	response := &queries.DomainCheckResult{
 		TimeStamp:  time.Now().UTC(),
 		Available:  false,
@@ -844,13 +1049,13 @@ func (svc *DomainService) CheckDomainAvailability(ctx context.Context, domainNam
 		return response, err
 	}
 
-	// Check if the domain is blocked
+	// Check if the domain is blocked, an allocation token can release a blocked domain
 	blocked, err := svc.CheckDomainIsBlocked(ctx, domainName)
 	if err != nil {
 		response.Reason = err.Error()
 		return response, err
 	}
-	if blocked {
+	if blocked && allocationToken == "" {
 		response.Reason = ErrDomainBlocked.Error()
 		return response, err
 	}
@@ -888,11 +1093,31 @@ func (svc *DomainService) CheckDomainAvailability(ctx context.Context, domainNam
 		return response, errors.Join(entities.ErrInvalidDomain, entities.ErrLabelNotValidInPhase)
 	}
 
+	// Check if the allocation token can be used for the domain
+	if allocationToken != "" {
+		if _, err := svc.allocationTokenForDomain(ctx, allocationToken, domainName, clID, phase.Name.String()); err != nil {
+			response.Reason = err.Error()
+			return response, err
+		}
+	}
+
 	// If all checks pass, the domain is available
 	response.Available = true
 	return response, nil
 }
 
+// domainNotAvailableError returns the error for a domain that is not available according to the check result.
+// It keeps the sentinel errors where it can so callers (e.g. EPP) can map them to the correct result code.
+func domainNotAvailableError(checkResult *queries.DomainCheckResult) error {
+	switch checkResult.Reason {
+	case ErrDomainExists.Error():
+		return errors.Join(entities.ErrInvalidDomain, ErrDomainExists)
+	case ErrDomainBlocked.Error():
+		return errors.Join(entities.ErrInvalidDomain, ErrDomainBlocked)
+	}
+	return errors.Join(entities.ErrInvalidDomain, errors.New(checkResult.Reason))
+}
+
 // CheckDomain checks the availability of a domain name
 // This was intended to mimic the EPP check command, but needs to be re-evaluated if that is the best approach
 func (svc *DomainService) CheckDomain(ctx context.Context, q *queries.DomainCheckQuery) (*queries.DomainCheckResult, error) {
@@ -900,8 +1125,8 @@ func (svc *DomainService) CheckDomain(ctx context.Context, q *queries.DomainChec
 	q.Currency = strings.ToUpper(q.Currency)
 
 	// Check the availability of the domain in the phase or the current GA phase
-	availability, err := svc.CheckDomainAvailability(ctx, q.DomainName.String(), q.PhaseName)
-	if err != nil && !errors.Is(err, ErrDomainExists) && !errors.Is(err, ErrDomainBlocked) && !errors.Is(err, entities.ErrLabelNotValidInPhase) {
+	availability, err := svc.CheckDomainAvailability(ctx, q.DomainName.String(), q.PhaseName, q.ClID.String(), q.AllocationToken)
+	if err != nil && !errors.Is(err, ErrDomainExists) && !errors.Is(err, ErrDomainBlocked) && !errors.Is(err, entities.ErrLabelNotValidInPhase) && !isAllocationTokenError(err) {
 		return nil, err
 	}
 	// Create the result object
@@ -928,6 +1153,7 @@ func (svc *DomainService) CheckDomain(ctx context.Context, q *queries.DomainChec
 		Currency:        q.Currency,
 		Years:           1,
 		PhaseName:       q.PhaseName,
+		AllocationToken: q.AllocationToken,
 	})
 	if err != nil {
 		return nil, err
@@ -939,11 +1165,19 @@ func (svc *DomainService) CheckDomain(ctx context.Context, q *queries.DomainChec
 }
 
 // RegisterDomain registers a new domain based on the provided command parameters.
-// It checks if the registrar is accredited for the TLD and the domain's availability, optionally validates fees (pending implementation), determines the
+// It checks if the registrar is accredited for the TLD and the domain's availability, optionally validates the fee extension against the quote, determines the
 // relevant TLD and phase information, generates a unique ROID, creates the domain
 // entity, attaches any specified hosts, and persists the resulting domain in the
 // repository. It returns the created domain or an error if any step fails.
+// The phase must be active and the launch data (mark and claims notice) must satisfy the phase, see validateLaunchData.
+// Sunrise registrations are pendingCreate until the registry validated the mark.
 func (svc *DomainService) RegisterDomain(ctx context.Context, cmd *commands.RegisterDomainCommand) (*entities.Domain, error) {
+	return svc.registerDomain(ctx, cmd, nil)
+}
+
+// registerDomain registers the domain in the command. If an application is provided the domain is allocated to it:
+// the application was validated when it was created, so the phase may have ended since and the domain does not need validation anymore.
+func (svc *DomainService) registerDomain(ctx context.Context, cmd *commands.RegisterDomainCommand, app *entities.DomainApplication) (*entities.Domain, error) {
 	// Check if the registrar is accredited for the TLD
 	domName := entities.DomainName(cmd.Name)
 	isAccredited, err := svc.rarRepo.IsRegistrarAccreditedForTLD(ctx, domName.ParentDomain(), cmd.ClID)
@@ -968,14 +1202,14 @@ func (svc *DomainService) RegisterDomain(ctx context.Context, cmd *commands.Regi
 	if includeFees {
 		q.Currency = cmd.Fee.Currency
 	}
-	checkResult, err := svc.CheckDomainAvailability(ctx, cmd.Name, cmd.PhaseName)
+	checkResult, err := svc.CheckDomainAvailability(ctx, cmd.Name, cmd.PhaseName, cmd.ClID, cmd.AllocationToken)
 	if err != nil {
 		return nil, err
 	}
 
 	// If the domain is not available, return now
 	if !checkResult.Available {
-		return nil, errors.Join(entities.ErrInvalidDomain, errors.New(checkResult.Reason))
+		return nil, domainNotAvailableError(checkResult)
 	}
 
 	// Create a lifecycle event for logging
@@ -1008,6 +1242,27 @@ func (svc *DomainService) RegisterDomain(ctx context.Context, cmd *commands.Regi
 	if err != nil {
 		return nil, err
 	}
+	if app == nil {
+		if !phase.IsCurrentlyActive() {
+			return nil, errors.Join(entities.ErrNoActivePhase, fmt.Errorf("phase %s is not active", phase.Name))
+		}
+		if err := svc.validateLaunchData(ctx, tld, phase, domainName.Label(), cmd.Mark, cmd.ClaimsNotice); err != nil {
+			return nil, err
+		}
+		// We don't verify the mark of a sunrise registration, the domain is pending creation until the registry validates the mark
+		if phase.Name == entities.SunrisePhaseName {
+			sunrisePhase := *phase
+			requiresValidation := true
+			sunrisePhase.Policy.RequiresValidation = &requiresValidation
+			phase = &sunrisePhase
+		}
+	} else {
+		// Allocated applications don't need to be validated again
+		allocationPhase := *phase
+		requiresValidation := false
+		allocationPhase.Policy.RequiresValidation = &requiresValidation
+		phase = &allocationPhase
+	}
 
 	// Get a quote
 	var cur string
@@ -1024,6 +1279,7 @@ func (svc *DomainService) RegisterDomain(ctx context.Context, cmd *commands.Regi
 		Currency:        cur,
 		Years:           cmd.Years,
 		PhaseName:       cmd.PhaseName,
+		AllocationToken: cmd.AllocationToken,
 	})
 	if err != nil {
 		return nil, err
@@ -1031,14 +1287,9 @@ func (svc *DomainService) RegisterDomain(ctx context.Context, cmd *commands.Regi
 	event.Quote = *quote
 	checkResult.Quote = quote
 
-	// Check the fee extension against the quote ONLY if it is provided
-	if cmd.Fee.Currency != "" && cmd.Fee.Amount != 0 {
-		if strings.ToUpper(cmd.Fee.Currency) != quote.Price.Currency().Code {
-			return nil, errors.Join(entities.ErrInvalidRenewal, fmt.Errorf("currency mismatch: requested %s, got %s", strings.ToUpper(cmd.Fee.Currency), quote.Price.Currency().Code))
-		}
-		if cmd.Fee.Amount != quote.Price.Amount() {
-			return nil, errors.Join(entities.ErrInvalidRenewal, fmt.Errorf("amount mismatch: requested %d, got %d", cmd.Fee.Amount, quote.Price.Amount()))
-		}
+	// Check the fee extension against the quote, premium domains can only be registered if the client agreed to the fee
+	if err := validateFee(cmd.Fee, quote); err != nil {
+		return nil, err
 	}
 
 	// Generate a RoID for our new domain
@@ -1068,8 +1319,23 @@ func (svc *DomainService) RegisterDomain(ctx context.Context, cmd *commands.Regi
 		}
 	}
 
-	// Save the domain including optional host associations
-	createdDomain, err := svc.domainRepository.Create(ctx, dom)
+	// Add the DNSSEC delegation data if there is any
+	for _, ds := range cmd.SecDNS.DSData {
+		if err := dom.AddDSData(ds); err != nil {
+			return nil, err
+		}
+	}
+	if err := dom.SetMaxSigLife(cmd.SecDNS.MaxSigLife); err != nil {
+		return nil, err
+	}
+
+	// Save the domain including optional host associations, the allocation token is redeemed in the same transaction so it can't be redeemed twice
+	var createdDomain *entities.Domain
+	if cmd.AllocationToken != "" {
+		createdDomain, err = svc.domainRepository.CreateAndRedeemAllocationToken(ctx, dom, cmd.AllocationToken)
+	} else {
+		createdDomain, err = svc.domainRepository.Create(ctx, dom)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -1143,13 +1409,11 @@ func (svc *DomainService) RenewDomain(ctx context.Context, cmd *commands.RenewDo
 	}
 	event.Quote = *quote
 
-	// Check the fee extension against the quote ONLY if it is provided
-	if cmd.Fee.Currency != "" && cmd.Fee.Amount != 0 {
-		if strings.ToUpper(cmd.Fee.Currency) != quote.Price.Currency().Code {
-			return nil, errors.Join(entities.ErrInvalidRenewal, fmt.Errorf("currency mismatch: requested %s, got %s", strings.ToUpper(cmd.Fee.Currency), quote.Price.Currency().Code))
-		}
-		if cmd.Fee.Amount != quote.Price.Amount() {
-			return nil, errors.Join(entities.ErrInvalidRenewal, fmt.Errorf("amount mismatch: requested %d, got %d", cmd.Fee.Amount, quote.Price.Amount()))
+	// Check the fee extension against the quote, premium domains can only be renewed if the client agreed to the fee.
+	// Forced renewals (e.g. auto-renewals by the lifecycle) are initiated by the registry and charged the quote.
+	if !force || cmd.Fee.Provided {
+		if err := validateFee(cmd.Fee, quote); err != nil {
+			return nil, errors.Join(entities.ErrInvalidRenewal, err)
 		}
 	}
 
@@ -1316,6 +1580,7 @@ func (svc *DomainService) AutoRenewDomain(ctx context.Context, name string, year
 	// Log the domain auto renewal
 	msg := fmt.Sprintf("Domain %s auto-renewed for %d years", name, years)
 	svc.logDomainLifecycleEvent(ctx, msg, event, nil, updatedDomain, prevState)
+	svc.queuePollMessage(ctx, msg, event)
 
 	return updatedDomain, nil
 }
@@ -1434,12 +1699,14 @@ func (svc *DomainService) ExpireDomain(ctx context.Context, domainName string) (
 	// Log the domain expiration
 	msg := fmt.Sprintf("Domain %s expired", domainName)
 	svc.logDomainLifecycleEvent(ctx, msg, event, nil, updatedDomain, prevState)
+	svc.queuePollMessage(ctx, msg, event)
 
 	return updatedDomain, nil
 }
 
-// RestoreDomain restores a domain. It does a soft restore by setting the status tu pendingRestore. Another process will pick this up and complete the restore.
-func (svc *DomainService) RestoreDomain(ctx context.Context, domainName string) (*entities.Domain, error) {
+// RestoreDomain restores a domain. It does a soft restore by setting the status tu pendingRestore. Another process will pick this up and complete the restore once the restore report is received (see ReportDomainRestore).
+// The restore is charged using a restore quote in the currency of the fee, if the client provided the fee it must match the quote.
+func (svc *DomainService) RestoreDomain(ctx context.Context, domainName string, fee commands.FeeExtension) (*entities.Domain, error) {
 	// Get the domain
 	dom, err := svc.GetDomainByName(ctx, domainName, false)
 	if err != nil {
@@ -1470,18 +1737,27 @@ func (svc *DomainService) RestoreDomain(ctx context.Context, domainName string)
 		return nil, err
 	}
 
-	// Get a quote
+	// Get a quote, if the currency is not specified use the base currency of the phase
+	cur := fee.Currency
+	if cur == "" {
+		cur = currentPhase.Policy.BaseCurrency
+	}
 	quote, err := svc.GetQuote(ctx, &queries.QuoteRequest{
 		DomainName:      dom.Name.String(),
 		ClID:            dom.ClID.String(),
-		TransactionType: entities.TransactionTypeAutoRenewal,
-		Currency:        currentPhase.Policy.BaseCurrency,
+		TransactionType: entities.TransactionTypeRestore,
+		Currency:        cur,
 		Years:           1,
 		PhaseName:       currentPhase.Name.String(),
 	})
 	if err != nil {
 		return nil, err
 	}
+	if fee.Provided {
+		if err := fee.Validate(quote); err != nil {
+			return nil, err
+		}
+	}
 	event.Quote = *quote
 
 	// Save the previous state
@@ -1549,7 +1825,7 @@ func (svc *DomainService) DropCatchDomain(ctx context.Context, domainName string
 	return nil
 }
 
-// GetNSRecordsPerTLD gets NS records for a TLD
+// GetNSRecordsPerTLD gets NS records for a TLD, followed by the DS records of the signed delegations
 func (s *DomainService) GetNSRecordsPerTLD(ctx context.Context, params queries.ActiveDomainsWithHostsQuery) ([]dns.RR, error) {
 	response, err := s.domainRepository.GetActiveDomainsWithHosts(ctx, params)
 	if err != nil {
@@ -1602,9 +1878,22 @@ func (s *DomainService) CountRestoredDomains(ctx context.Context, q *queries.Res
 	return s.domainRepository.CountRestoredDomains(ctx, q.ClID.String(), q.TLD.String())
 }
 
+// validateFee checks the fee the client agreed to pay against the quote if it is provided.
+// The fee is required for premium domains so a client is never charged a premium price it did not agree to (RFC 8748 section 3.8).
+func validateFee(fee commands.FeeExtension, quote *entities.Quote) error {
+	if fee.Provided {
+		return fee.Validate(quote)
+	}
+	if quote.IsPremium() {
+		return errors.Join(entities.ErrFeeMismatch, entities.ErrFeeRequired, fmt.Errorf("class: %s", quote.Class))
+	}
+	return nil
+}
+
 // GetQuote retrieves a quote for a domain based on the provided QuoteRequest.
 // It validates the request, retrieves the appropriate TLD and phase, and calculates
-// the quote using the PriceEngine. It returns the quote or an error if any step fails.
+// the quote using the PriceEngine. If no currency is requested, the quote is in the base currency of the phase.
+// It returns the quote or an error if any step fails.
 func (s *DomainService) GetQuote(ctx context.Context, q *queries.QuoteRequest) (*entities.Quote, error) {
 	// Validate the request and
 	if err := q.Validate(); err != nil {
@@ -1636,6 +1925,10 @@ func (s *DomainService) GetQuote(ctx context.Context, q *queries.QuoteRequest) (
 	if phase == nil {
 		return nil, entities.ErrPhaseNotFound
 	}
+	// If no currency is provided, default to the base currency of the phase
+	if q.Currency == "" {
+		q.Currency = phase.Policy.BaseCurrency
+	}
 
 	domain, err := s.domainRepository.GetDomainByName(ctx, domainName.String(), false)
 	// Get the domain
@@ -1684,8 +1977,24 @@ func (s *DomainService) GetQuote(ctx context.Context, q *queries.QuoteRequest) (
 	// Instantiate a PriceEngine
 	calc := entities.NewPriceEngine(*phase, *domain, *fx, pe)
 
-	// Get/Return the quote
-	return calc.GetQuote(*q.ToEntity())
+	// Get the quote
+	quote, err := calc.GetQuote(*q.ToEntity())
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply the discount of the allocation token to registrations
+	if q.AllocationToken != "" && q.TransactionType == entities.TransactionTypeRegistration {
+		token, err := s.allocationTokenForDomain(ctx, q.AllocationToken, domainName.String(), q.ClID, phase.Name.String())
+		if err != nil {
+			return nil, err
+		}
+		if err := token.ApplyDiscount(quote); err != nil {
+			return nil, err
+		}
+	}
+
+	return quote, nil
 }
 
 // logDomainLifecycleEvent logs a domain lifecycle event with the provided context, event, command, and result.
@@ -1717,6 +2026,26 @@ func (s *DomainService) logDomainLifecycleEvent(
 	)
 }
 
+// queuePollMessage queues a poll message for the registrar in the DomainLifeCycleEvent so it learns about actions it did not initiate itself.
+// The action has already been persisted at this point, so failing to queue the message is logged rather than returned.
+func (s *DomainService) queuePollMessage(ctx context.Context, msg string, event *entities.DomainLifeCycleEvent) {
+	if s.pollRepo == nil {
+		return
+	}
+	pm, err := entities.NewPollMessageFromDomainLifeCycleEvent(event, msg)
+	if err == nil {
+		_, err = s.pollRepo.Create(ctx, pm)
+	}
+	if err != nil {
+		s.logger.Error(
+			"failed to queue poll message",
+			zap.String("clid", event.ClientID),
+			zap.String("domain_name", event.DomainName),
+			zap.Error(err),
+		)
+	}
+}
+
 // SetStatus sets the provided status value on the Domain.Status struct to true
 func (s *DomainService) SetStatus(ctx context.Context, domainName, status string) (*entities.Domain, error) {
 	// Fail early before making any DB calls
@@ -1757,7 +2086,13 @@ func (s *DomainService) SetStatus(ctx context.Context, domainName, status string
 		return nil, err
 	}
 
-	s.logDomainLifecycleEvent(ctx, fmt.Sprintf("Domain status %s set to true", status), event, nil, updatedDomain, previousDom)
+	msg := fmt.Sprintf("Domain %s status %s set to true", dom.Name, status)
+	s.logDomainLifecycleEvent(ctx, msg, event, nil, updatedDomain, previousDom)
+	// Registrars set client statuses themselves, only notify them of changes to the other statuses
+	if !strings.HasPrefix(status, "client") {
+		event.DomainRoID = updatedDomain.RoID.String()
+		s.queuePollMessage(ctx, msg, event)
+	}
 
 	return updatedDomain, nil
 }
@@ -1802,7 +2137,13 @@ func (s *DomainService) UnSetStatus(ctx context.Context, domainName, status stri
 		return nil, err
 	}
 
-	s.logDomainLifecycleEvent(ctx, fmt.Sprintf("Domain status %s set to false", status), event, nil, updatedDomain, previousDom)
+	msg := fmt.Sprintf("Domain %s status %s set to false", dom.Name, status)
+	s.logDomainLifecycleEvent(ctx, msg, event, nil, updatedDomain, previousDom)
+	// Registrars set client statuses themselves, only notify them of changes to the other statuses
+	if !strings.HasPrefix(status, "client") {
+		event.DomainRoID = updatedDomain.RoID.String()
+		s.queuePollMessage(ctx, msg, event)
+	}
 
 	return updatedDomain, nil
 }
@@ -1866,6 +2207,7 @@ func (s *DomainService) domainFromCreateDomainCommand(cmd *commands.CreateDomain
 	}
 	d.GrandFathering = cmd.GrandFathering
 	d.RenewedYears = cmd.RenewedYears
+	d.SecDNS = cmd.SecDNS
 
 	// Check if the domain is valid
 	if err := d.Validate(); err != nil {
@@ -4,10 +4,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Rhymond/go-money"
 	"github.com/onasunnymorning/domain-os/internal/application/commands"
 	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/domain/repositories"
 	"github.com/onasunnymorning/domain-os/internal/infrastructure/snowflakeidgenerator"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/net/context"
 )
 
 func TestDomainFromCreateDomainCommand(t *testing.T) {
@@ -71,6 +75,33 @@ func TestDomainFromCreateDomainCommand(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Valid command with DS data",
+			cmd: &commands.CreateDomainCommand{
+				Name:       "example.com",
+				ClID:       "client123",
+				AuthInfo:   "sTr0N5p@zzWqRD",
+				ExpiryDate: time.Now().AddDate(1, 0, 0),
+				SecDNS: entities.DomainSecDNS{
+					MaxSigLife: 3600,
+					DSData:     []entities.DomainDSData{{KeyTag: 12345, Alg: 13, DigestType: 2, Digest: "E2D3C916F6DEEAC73294E8268FB5885044A833FC5459588F4A9184CFC41A5766"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid command with invalid DS data",
+			cmd: &commands.CreateDomainCommand{
+				Name:       "example.com",
+				ClID:       "client123",
+				AuthInfo:   "sTr0N5p@zzWqRD",
+				ExpiryDate: time.Now().AddDate(1, 0, 0),
+				SecDNS: entities.DomainSecDNS{
+					DSData: []entities.DomainDSData{{KeyTag: 12345, Alg: 13, DigestType: 2, Digest: "ABCDEF"}},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "Invalid command with empty name",
 			cmd: &commands.CreateDomainCommand{
@@ -94,6 +125,7 @@ func TestDomainFromCreateDomainCommand(t *testing.T) {
 				assert.Equal(t, tt.cmd.Name, domain.Name.String())
 				assert.Equal(t, tt.cmd.ClID, domain.ClID.String())
 				assert.Equal(t, tt.cmd.AuthInfo, domain.AuthInfo.String())
+				assert.Equal(t, tt.cmd.SecDNS, domain.SecDNS)
 				if tt.cmd.RoID == "" {
 					assert.Contains(t, domain.RoID.String(), "DOM-APEX")
 				} else {
@@ -199,3 +231,226 @@ func TestBulkDomainFromCreateDomainCommands(t *testing.T) {
 		})
 	}
 }
+
+func TestDomainService_SetStatus_QueuesPollMessage(t *testing.T) {
+	testcases := []struct {
+		name      string
+		status    string
+		wantQueue bool
+	}{
+		{"server status is queued", "serverHold", true},
+		{"client status is not queued", "clientHold", false},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDomainRepo := new(repositories.MockDomainRepository)
+			mockPollRepo := new(repositories.MockPollMessageRepository)
+			domainService := NewDomainService(mockDomainRepo, nil, RoidService{}, nil, nil, nil, nil, nil, nil, mockPollRepo, nil, nil, nil, nil)
+
+			dom := &entities.Domain{RoID: "1234_DOM-APEX", Name: "example.com", ClID: "testClID"}
+			mockDomainRepo.On("GetDomainByName", mock.Anything, "example.com", false).Return(dom, nil)
+			mockDomainRepo.On("UpdateDomain", mock.Anything, mock.Anything).Return(dom, nil)
+			mockPollRepo.On("Create", mock.Anything, mock.MatchedBy(func(pm *entities.PollMessage) bool {
+				return pm.ClID == "testClID" && pm.DomainRoID == "1234_DOM-APEX" && pm.TransactionType == entities.TransactionTypeUpdate
+			})).Return(&entities.PollMessage{ID: 1}, nil)
+
+			_, err := domainService.SetStatus(context.TODO(), "example.com", tc.status)
+			assert.NoError(t, err)
+			if tc.wantQueue {
+				mockPollRepo.AssertNumberOfCalls(t, "Create", 1)
+			} else {
+				mockPollRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestDomainService_ApplyDomainUpdate(t *testing.T) {
+	ds1 := entities.DomainDSData{KeyTag: 12345, Alg: 13, DigestType: 2, Digest: "E2D3C916F6DEEAC73294E8268FB5885044A833FC5459588F4A9184CFC41A5766"}
+	ds2 := entities.DomainDSData{KeyTag: 54321, Alg: 13, DigestType: 2, Digest: "E2D3C916F6DEEAC73294E8268FB5885044A833FC5459588F4A9184CFC41A5766"}
+	newTestDomain := func() *entities.Domain {
+		ns1 := &entities.Host{RoID: "1_HOST-APEX", Name: "ns1.example.net", ClID: "testClID", Status: entities.HostStatus{Linked: true}}
+		return &entities.Domain{
+			RoID:         "1234_DOM-APEX",
+			Name:         "example.com",
+			ClID:         "testClID",
+			AuthInfo:     "sTr0N5p@zzWqRD",
+			RegistrantID: "reg-1",
+			TechID:       "tech-1",
+			Status:       entities.DomainStatus{ClientUpdateProhibited: true},
+			Hosts:        []*entities.Host{ns1},
+			SecDNS:       entities.DomainSecDNS{DSData: []entities.DomainDSData{ds1}},
+		}
+	}
+	registrant, authInfo := "reg-2", "n3wP@ssw0rdX"
+
+	testcases := []struct {
+		name             string
+		cmd              *commands.ApplyDomainUpdateCommand
+		wantErr          error
+		wantRemovedHosts int
+	}{
+		{
+			name: "all changes",
+			cmd: &commands.ApplyDomainUpdateCommand{
+				Name:         "example.com",
+				ClID:         "testClID",
+				RemStatuses:  []string{"clientUpdateProhibited"},
+				AddStatuses:  []string{"clientHold"},
+				RemContacts:  []commands.DomainContactChange{{Type: "tech", ID: "tech-1"}},
+				AddContacts:  []commands.DomainContactChange{{Type: "tech", ID: "tech-2"}},
+				RegistrantID: &registrant,
+				AuthInfo:     &authInfo,
+				SecDNS:       &commands.DomainSecDNSChanges{RemDSData: []entities.DomainDSData{ds1}, AddDSData: []entities.DomainDSData{ds2}},
+				RemHosts:     []string{"ns1.example.net"},
+				AddHosts:     []string{"NS2.example.net"},
+			},
+			wantRemovedHosts: 1,
+		},
+		{
+			name: "update prohibited",
+			cmd: &commands.ApplyDomainUpdateCommand{
+				Name:     "example.com",
+				ClID:     "testClID",
+				AddHosts: []string{"ns2.example.net"},
+			},
+			wantErr: entities.ErrDomainUpdateNotAllowed,
+		},
+		{
+			name: "not sponsor",
+			cmd: &commands.ApplyDomainUpdateCommand{
+				Name:        "example.com",
+				ClID:        "otherClID",
+				RemStatuses: []string{"clientUpdateProhibited"},
+			},
+			wantErr: entities.ErrInvalidRegistrar,
+		},
+		{
+			name: "remove wrong contact",
+			cmd: &commands.ApplyDomainUpdateCommand{
+				Name:        "example.com",
+				ClID:        "testClID",
+				RemStatuses: []string{"clientUpdateProhibited"},
+				RemContacts: []commands.DomainContactChange{{Type: "tech", ID: "someone-else"}},
+			},
+			wantErr: entities.ErrContactNotFound,
+		},
+		{
+			name: "unknown contact type",
+			cmd: &commands.ApplyDomainUpdateCommand{
+				Name:        "example.com",
+				ClID:        "testClID",
+				RemStatuses: []string{"clientUpdateProhibited"},
+				AddContacts: []commands.DomainContactChange{{Type: "owner", ID: "tech-2"}},
+			},
+			wantErr: entities.ErrInvalidContact,
+		},
+		{
+			name: "add existing DS record",
+			cmd: &commands.ApplyDomainUpdateCommand{
+				Name:        "example.com",
+				ClID:        "testClID",
+				RemStatuses: []string{"clientUpdateProhibited"},
+				SecDNS:      &commands.DomainSecDNSChanges{AddDSData: []entities.DomainDSData{ds1}},
+			},
+			wantErr: entities.ErrDuplicateDSData,
+		},
+		{
+			name: "unknown host fails the whole update",
+			cmd: &commands.ApplyDomainUpdateCommand{
+				Name:        "example.com",
+				ClID:        "testClID",
+				RemStatuses: []string{"clientUpdateProhibited"},
+				RemHosts:    []string{"ns1.example.net"},
+				AddHosts:    []string{"ns3.example.net"},
+			},
+			wantErr: entities.ErrHostNotFound,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			hosts := map[string]*entities.Host{
+				"ns1.example.net": {RoID: "1_HOST-APEX", Name: "ns1.example.net", ClID: "testClID", Status: entities.HostStatus{Linked: true}},
+				"ns2.example.net": {RoID: "2_HOST-APEX", Name: "ns2.example.net", ClID: "testClID", Status: entities.HostStatus{OK: true}},
+			}
+			mockDomainRepo := new(repositories.MockDomainRepository)
+			mockHostRepo := &repositories.MockHostRepository{
+				GetHostByNameAndClIDFunc: func(ctx context.Context, name, clid string) (*entities.Host, error) {
+					if h, ok := hosts[name]; ok && clid == "testClID" {
+						return h, nil
+					}
+					return nil, entities.ErrHostNotFound
+				},
+			}
+			domainService := NewDomainService(mockDomainRepo, mockHostRepo, RoidService{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			// The mock applies the update to the domain as it is stored, like the repository does after locking the domain
+			var update func(d *entities.Domain) ([]*entities.Host, error)
+			mockDomainRepo.On("UpdateDomainAndHosts", mock.Anything, "example.com", mock.Anything).Run(func(args mock.Arguments) {
+				update = args.Get(2).(func(d *entities.Domain) ([]*entities.Host, error))
+			}).Return(newTestDomain(), nil)
+
+			d, err := domainService.ApplyDomainUpdate(context.TODO(), tc.cmd)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, entities.ClIDType("reg-2"), d.RegistrantID)
+			assert.Equal(t, entities.ClIDType("tech-2"), d.TechID)
+			assert.Equal(t, entities.AuthInfoType("n3wP@ssw0rdX"), d.AuthInfo)
+			assert.Equal(t, entities.ClIDType("testClID"), d.UpRr)
+			assert.Equal(t, []entities.DomainDSData{ds2}, d.SecDNS.DSData)
+			assert.Len(t, d.Hosts, 1)
+			assert.Equal(t, "ns2.example.net", d.Hosts[0].Name.String())
+			assert.True(t, d.Hosts[0].Status.Linked)
+			assert.True(t, d.Status.ClientHold)
+			assert.False(t, d.Status.ClientUpdateProhibited)
+
+			removedHosts, err := update(newTestDomain())
+			assert.NoError(t, err)
+			assert.Len(t, removedHosts, tc.wantRemovedHosts)
+		})
+	}
+}
+
+func TestValidateFee(t *testing.T) {
+	standard := entities.NewQuote("USD")
+	standard.Class = "standard"
+	standard.Price = money.New(1000, "USD")
+	premium := entities.NewQuote("USD")
+	premium.Class = "premium"
+	premium.Price = money.New(50000, "USD")
+	released := entities.NewQuote("USD")
+	released.Class = "premium"
+	released.Price = money.New(0, "USD")
+
+	tests := []struct {
+		name    string
+		fee     commands.FeeExtension
+		quote   *entities.Quote
+		wantErr error
+	}{
+		{"standard without fee", commands.FeeExtension{}, standard, nil},
+		{"standard with matching fee", commands.FeeExtension{Provided: true, Currency: "USD", Amount: 1000}, standard, nil},
+		{"standard with wrong fee", commands.FeeExtension{Provided: true, Currency: "USD", Amount: 500}, standard, entities.ErrFeeMismatch},
+		{"premium without fee", commands.FeeExtension{}, premium, entities.ErrFeeRequired},
+		{"premium with standard fee", commands.FeeExtension{Provided: true, Currency: "USD", Amount: 1000}, premium, entities.ErrFeeMismatch},
+		{"premium with matching fee", commands.FeeExtension{Provided: true, Currency: "USD", Amount: 50000}, premium, nil},
+		{"premium with zero fee", commands.FeeExtension{Provided: true, Currency: "USD"}, released, nil},
+		{"premium with zero fee not provided", commands.FeeExtension{Currency: "USD"}, released, entities.ErrFeeRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFee(tt.fee, tt.quote)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.ErrorIs(t, err, entities.ErrFeeMismatch)
+		})
+	}
+}
@@ -0,0 +1,295 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// RequestDomainTransfer starts a transfer of a domain to the gaining registrar in the command.
+// It validates the registrar's accreditation and the domain's AuthInfo, status and transfer lock period, sets the domain to pendingTransfer
+// and stores a pending DomainTransfer that expires after the TransferGP of the current GA phase.
+// The losing registrar is notified through a poll message.
+func (svc *DomainService) RequestDomainTransfer(ctx context.Context, cmd *entities.RequestTransferCommand) (*entities.DomainTransfer, error) {
+	years := cmd.Years
+	if years == 0 {
+		years = entities.TRANSFER_YEARS
+	}
+	if years != entities.TRANSFER_YEARS {
+		return nil, entities.ErrInvalidTransferPeriod
+	}
+
+	// Get the domain wihtout the hosts
+	dom, err := svc.GetDomainByName(ctx, cmd.DomainName, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if the gaining registrar is accredited for the TLD
+	isAccredited, err := svc.rarRepo.IsRegistrarAccreditedForTLD(ctx, dom.Name.ParentDomain(), cmd.GainingRegistrar)
+	if err != nil {
+		return nil, errors.Join(ErrCouldNotDetermineAccreditation, err)
+	}
+	if !isAccredited {
+		return nil, errors.Join(ErrRegistrarNotAccredited, fmt.Errorf("Registrar.ClID: %s, TLD: %s", cmd.GainingRegistrar, dom.Name.ParentDomain()))
+	}
+
+	// Get the TLD including the phases
+	tld, err := svc.tldRepo.GetByName(ctx, dom.Name.ParentDomain(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Always use the current GA phase policy for transfers
+	phase, err := tld.GetCurrentGAPhase()
+	if err != nil {
+		return nil, err
+	}
+
+	// Save the previous state
+	prevState := dom.DeepCopy()
+
+	// Validate the request and set the domain to pendingTransfer using our entity
+	err = dom.RequestTransfer(cmd.GainingRegistrar, cmd.AuthInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer := entities.NewDomainTransfer(phase.Policy.TransferGP)
+	transfer.DomainRoiD = dom.RoID
+	transfer.DomainName = dom.Name
+	transfer.GainingRegistrar = entities.ClIDType(cmd.GainingRegistrar)
+	transfer.LosingRegistrar = dom.ClID
+	transfer.Years = years
+	transfer.CorrelationID = correlationIDFromContext(ctx)
+
+	// Save the domain and the transfer in a single transaction
+	updatedDomain, createdTransfer, err := svc.domainRepository.UpdateDomainAndCreateTransfer(ctx, dom, &transfer)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a lifecycle event for logging, transfer requests are not billable so no quote is attached
+	event, err := entities.NewDomainLifeCycleEvent(
+		prevState.ClID.String(),
+		"",
+		dom.Name.ParentDomain(),
+		dom.Name.String(),
+		years,
+		entities.TransactionTypeTransfer,
+	)
+	if err != nil {
+		return nil, err
+	}
+	event.DomainRoID = updatedDomain.RoID.String()
+
+	msg := fmt.Sprintf("Transfer of domain %s requested by %s", dom.Name, cmd.GainingRegistrar)
+	svc.logDomainLifecycleEvent(ctx, msg, event, cmd, updatedDomain, prevState)
+	svc.queuePollMessage(ctx, msg, event)
+
+	return createdTransfer, nil
+}
+
+// ApproveDomainTransfer approves the pending transfer of a domain.
+// Only the losing registrar can approve a transfer, an empty clid approves on behalf of the registry (e.g. when the transfer is auto-approved).
+// The domain moves to the gaining registrar and is extended with the years of the transfer, which are charged to the gaining registrar using a transfer quote.
+// A lifecycle event is logged and a poll message is queued for both registrars.
+func (svc *DomainService) ApproveDomainTransfer(ctx context.Context, domainName, clid string) (*entities.DomainTransfer, error) {
+	dom, transfer, err := svc.getPendingDomainTransfer(ctx, domainName)
+	if err != nil {
+		return nil, err
+	}
+	if clid != "" && entities.ClIDType(clid) != transfer.LosingRegistrar {
+		return nil, entities.ErrInvalidRegistrar
+	}
+
+	// Get the TLD including the phases
+	tld, err := svc.tldRepo.GetByName(ctx, dom.Name.ParentDomain(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Always use the current GA phase policy for transfers
+	phase, err := tld.GetCurrentGAPhase()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a lifecycle event for logging
+	event, err := entities.NewDomainLifeCycleEvent(
+		transfer.GainingRegistrar.String(),
+		"",
+		dom.Name.ParentDomain(),
+		dom.Name.String(),
+		transfer.Years,
+		entities.TransactionTypeTransfer,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get a quote for the gaining registrar. This needs to happen before completing the transfer as grandfathering may be voided by it.
+	quote, err := svc.GetQuote(ctx, &queries.QuoteRequest{
+		DomainName:      dom.Name.String(),
+		ClID:            transfer.GainingRegistrar.String(),
+		TransactionType: entities.TransactionTypeTransfer,
+		Currency:        phase.Policy.BaseCurrency,
+		Years:           transfer.Years,
+		PhaseName:       phase.Name.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	event.Quote = *quote
+
+	// Save the previous state
+	prevState := dom.DeepCopy()
+
+	// Move the domain to the gaining registrar using our entity
+	err = dom.CompleteTransfer(transfer.GainingRegistrar.String(), transfer.Years, phase)
+	if err != nil {
+		return nil, err
+	}
+	err = transfer.Finalize(entities.FinalizeTransferCommand{Status: entities.TransferStatusApproved, CorrelationID: correlationIDFromContext(ctx)})
+	if err != nil {
+		return nil, err
+	}
+
+	// Save the domain and the transfer in a single transaction
+	updatedDomain, updatedTransfer, err := svc.domainRepository.UpdateDomainAndTransfer(ctx, dom, transfer)
+	if err != nil {
+		return nil, err
+	}
+	event.DomainRoID = updatedDomain.RoID.String()
+
+	// Log the domain transfer and notify both registrars
+	msg := fmt.Sprintf("Domain %s transferred from %s to %s for %d years", dom.Name, transfer.LosingRegistrar, transfer.GainingRegistrar, transfer.Years)
+	svc.logDomainLifecycleEvent(ctx, msg, event, transfer, updatedDomain, prevState)
+	svc.queuePollMessage(ctx, fmt.Sprintf("Transfer of domain %s approved", dom.Name), event)
+	svc.notifyDomainTransfer(ctx, updatedDomain, transfer.LosingRegistrar, fmt.Sprintf("Domain %s transferred to %s", dom.Name, transfer.GainingRegistrar))
+
+	return updatedTransfer, nil
+}
+
+// RejectDomainTransfer rejects the pending transfer of a domain.
+// Only the losing registrar can reject a transfer, an empty clid rejects on behalf of the registry.
+// The gaining registrar is notified through a poll message.
+func (svc *DomainService) RejectDomainTransfer(ctx context.Context, domainName, clid, reason string) (*entities.DomainTransfer, error) {
+	dom, transfer, err := svc.getPendingDomainTransfer(ctx, domainName)
+	if err != nil {
+		return nil, err
+	}
+	if clid != "" && entities.ClIDType(clid) != transfer.LosingRegistrar {
+		return nil, entities.ErrInvalidRegistrar
+	}
+
+	updatedTransfer, err := svc.finalizeDomainTransfer(ctx, dom, transfer, entities.TransferStatusDenied, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	svc.notifyDomainTransfer(ctx, dom, transfer.GainingRegistrar, fmt.Sprintf("Transfer of domain %s rejected by %s", dom.Name, transfer.LosingRegistrar))
+
+	return updatedTransfer, nil
+}
+
+// CancelDomainTransfer withdraws the pending transfer of a domain.
+// Only the gaining registrar can cancel a transfer, an empty clid cancels on behalf of the registry.
+// The losing registrar is notified through a poll message.
+func (svc *DomainService) CancelDomainTransfer(ctx context.Context, domainName, clid string) (*entities.DomainTransfer, error) {
+	dom, transfer, err := svc.getPendingDomainTransfer(ctx, domainName)
+	if err != nil {
+		return nil, err
+	}
+	if clid != "" && entities.ClIDType(clid) != transfer.GainingRegistrar {
+		return nil, entities.ErrInvalidRegistrar
+	}
+
+	updatedTransfer, err := svc.finalizeDomainTransfer(ctx, dom, transfer, entities.TransferStatusCancelled, "")
+	if err != nil {
+		return nil, err
+	}
+
+	svc.notifyDomainTransfer(ctx, dom, transfer.LosingRegistrar, fmt.Sprintf("Transfer of domain %s cancelled by %s", dom.Name, transfer.GainingRegistrar))
+
+	return updatedTransfer, nil
+}
+
+// GetDomainTransfer returns the most recent transfer of a domain
+func (svc *DomainService) GetDomainTransfer(ctx context.Context, domainName string) (*entities.DomainTransfer, error) {
+	return svc.transferRepo.GetLatestByDomainName(ctx, domainName)
+}
+
+// ListDomainTransfers returns a list of domain transfers
+func (svc *DomainService) ListDomainTransfers(ctx context.Context, params queries.ListItemsQuery) ([]*entities.DomainTransfer, string, error) {
+	return svc.transferRepo.List(ctx, params)
+}
+
+// CountDomainTransfers returns the number of domain transfers matching the filter
+func (svc *DomainService) CountDomainTransfers(ctx context.Context, filter queries.ListDomainTransfersFilter) (int64, error) {
+	return svc.transferRepo.Count(ctx, filter)
+}
+
+// getPendingDomainTransfer returns the domain and its pending transfer. It returns ErrNoPendingTransfer if the domain is not pending transfer.
+func (svc *DomainService) getPendingDomainTransfer(ctx context.Context, domainName string) (*entities.Domain, *entities.DomainTransfer, error) {
+	dom, err := svc.GetDomainByName(ctx, domainName, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !dom.Status.PendingTransfer {
+		return nil, nil, entities.ErrNoPendingTransfer
+	}
+	transfer, err := svc.transferRepo.GetLatestByDomainName(ctx, domainName)
+	if err != nil {
+		if errors.Is(err, entities.ErrTransferNotFound) {
+			return nil, nil, entities.ErrNoPendingTransfer
+		}
+		return nil, nil, err
+	}
+	if !transfer.IsPending() {
+		return nil, nil, entities.ErrNoPendingTransfer
+	}
+	return dom, transfer, nil
+}
+
+// finalizeDomainTransfer clears the pendingTransfer status of the domain and sets the final status on the transfer without changing the sponsor of the domain.
+func (svc *DomainService) finalizeDomainTransfer(ctx context.Context, dom *entities.Domain, transfer *entities.DomainTransfer, status entities.TransferStatus, reason string) (*entities.DomainTransfer, error) {
+	err := dom.ClearPendingTransfer()
+	if err != nil {
+		return nil, err
+	}
+	err = transfer.Finalize(entities.FinalizeTransferCommand{Status: status, Reason: reason, CorrelationID: correlationIDFromContext(ctx)})
+	if err != nil {
+		return nil, err
+	}
+
+	_, updatedTransfer, err := svc.domainRepository.UpdateDomainAndTransfer(ctx, dom, transfer)
+	if err != nil {
+		return nil, err
+	}
+	return updatedTransfer, nil
+}
+
+// notifyDomainTransfer logs a non-billable transfer event and queues a poll message for the registrar with the provided ClID
+func (svc *DomainService) notifyDomainTransfer(ctx context.Context, dom *entities.Domain, clid entities.ClIDType, msg string) {
+	event, err := entities.NewDomainLifeCycleEvent(clid.String(), "", dom.Name.ParentDomain(), dom.Name.String(), 0, entities.TransactionTypeTransfer)
+	if err != nil {
+		svc.logger.Error("failed to create transfer event", zap.String("domain_name", dom.Name.String()), zap.Error(err))
+		return
+	}
+	event.DomainRoID = dom.RoID.String()
+	svc.logDomainLifecycleEvent(ctx, msg, event, nil, dom, nil)
+	svc.queuePollMessage(ctx, msg, event)
+}
+
+// correlationIDFromContext returns the correlation_id set on the context, if any
+func correlationIDFromContext(ctx context.Context) string {
+	if correlation_id, ok := ctx.Value("correlation_id").(string); ok {
+		return correlation_id
+	}
+	return ""
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/domain/repositories"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// transferTestMocks holds the repositories used by the transfer tests
+type transferTestMocks struct {
+	domainRepo   *repositories.MockDomainRepository
+	rarRepo      *repositories.MockRegistrarRepository
+	pollRepo     *repositories.MockPollMessageRepository
+	transferRepo *repositories.MockDomainTransferRepository
+}
+
+// newTransferTestService returns a DomainService for the "apex" TLD with a current GA phase that has a transfer price.
+// The repositories return the provided domain and transfer, so the tests can inspect the changes the service made to them.
+func newTransferTestService(t *testing.T, dom *entities.Domain, transfer *entities.DomainTransfer) (*DomainService, *transferTestMocks) {
+	t.Helper()
+	tld, err := entities.NewTLD("apex", "ry-1")
+	require.NoError(t, err)
+	phase, err := entities.NewPhase("GAPhase", "GA", time.Now().UTC().AddDate(-1, 0, 0))
+	require.NoError(t, err)
+	price, err := entities.NewPrice("USD", 1000, 1000, 500, 2000)
+	require.NoError(t, err)
+	_, err = phase.AddPrice(*price)
+	require.NoError(t, err)
+	require.NoError(t, tld.AddPhase(phase))
+
+	m := &transferTestMocks{
+		domainRepo:   new(repositories.MockDomainRepository),
+		rarRepo:      new(repositories.MockRegistrarRepository),
+		pollRepo:     new(repositories.MockPollMessageRepository),
+		transferRepo: new(repositories.MockDomainTransferRepository),
+	}
+	m.domainRepo.On("GetDomainByName", mock.Anything, "example.apex", false).Return(dom, nil)
+	m.domainRepo.On("UpdateDomainAndCreateTransfer", mock.Anything, mock.Anything, mock.Anything).Return(dom, transfer, nil)
+	m.domainRepo.On("UpdateDomainAndTransfer", mock.Anything, mock.Anything, mock.Anything).Return(dom, transfer, nil)
+	m.pollRepo.On("Create", mock.Anything, mock.Anything).Return(&entities.PollMessage{ID: 1}, nil)
+	m.transferRepo.On("GetLatestByDomainName", mock.Anything, "example.apex").Return(transfer, nil)
+
+	tldRepo := &MocktldRepository{Tlds: []*entities.TLD{tld}}
+	svc := NewDomainService(m.domainRepo, nil, RoidService{}, nil, tldRepo, nil, nil, nil, m.rarRepo, m.pollRepo, m.transferRepo, nil, nil, nil)
+	return svc, m
+}
+
+// getTransferTestDomain returns a domain sponsored by losingRar that can be transferred
+func getTransferTestDomain() *entities.Domain {
+	return &entities.Domain{
+		RoID:       "1234_DOM-APEX",
+		Name:       "example.apex",
+		ClID:       "losingRar",
+		AuthInfo:   "STr0mgP@ZZ",
+		ExpiryDate: time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Status:     entities.DomainStatus{OK: true},
+	}
+}
+
+// getPendingTestTransfer returns a pending transfer of the test domain from losingRar to gainingRar
+func getPendingTestTransfer() *entities.DomainTransfer {
+	transfer := entities.NewDomainTransfer(5)
+	transfer.DomainRoiD = "1234_DOM-APEX"
+	transfer.DomainName = "example.apex"
+	transfer.GainingRegistrar = "gainingRar"
+	transfer.LosingRegistrar = "losingRar"
+	return &transfer
+}
+
+func TestDomainService_RequestDomainTransfer(t *testing.T) {
+	dom := getTransferTestDomain()
+	svc, m := newTransferTestService(t, dom, getPendingTestTransfer())
+	m.rarRepo.On("IsRegistrarAccreditedForTLD", mock.Anything, "apex", "gainingRar").Return(true, nil)
+	m.rarRepo.On("IsRegistrarAccreditedForTLD", mock.Anything, "apex", "otherRar").Return(false, nil)
+
+	_, err := svc.RequestDomainTransfer(context.TODO(), &entities.RequestTransferCommand{DomainName: "example.apex", GainingRegistrar: "gainingRar", AuthInfo: "STr0mgP@ZZ"})
+	require.NoError(t, err)
+	require.True(t, dom.Status.PendingTransfer)
+	m.domainRepo.AssertCalled(t, "UpdateDomainAndCreateTransfer", mock.Anything, dom, mock.MatchedBy(func(tr *entities.DomainTransfer) bool {
+		return tr.Status == entities.TransferStatusPending && tr.LosingRegistrar == "losingRar" && tr.GainingRegistrar == "gainingRar" && tr.Years == entities.TRANSFER_YEARS
+	}))
+	m.pollRepo.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(pm *entities.PollMessage) bool {
+		return pm.ClID == "losingRar" && pm.TransactionType == entities.TransactionTypeTransfer
+	}))
+
+	// The domain is now pending transfer
+	_, err = svc.RequestDomainTransfer(context.TODO(), &entities.RequestTransferCommand{DomainName: "example.apex", GainingRegistrar: "gainingRar", AuthInfo: "STr0mgP@ZZ"})
+	require.ErrorIs(t, err, entities.ErrDomainPendingTransfer)
+
+	_, err = svc.RequestDomainTransfer(context.TODO(), &entities.RequestTransferCommand{DomainName: "example.apex", GainingRegistrar: "otherRar", AuthInfo: "STr0mgP@ZZ"})
+	require.ErrorIs(t, err, ErrRegistrarNotAccredited)
+
+	_, err = svc.RequestDomainTransfer(context.TODO(), &entities.RequestTransferCommand{DomainName: "example.apex", GainingRegistrar: "gainingRar", AuthInfo: "STr0mgP@ZZ", Years: 2})
+	require.ErrorIs(t, err, entities.ErrInvalidTransferPeriod)
+}
+
+func TestDomainService_ApproveDomainTransfer(t *testing.T) {
+	dom := getTransferTestDomain()
+	dom.Status = entities.DomainStatus{PendingTransfer: true}
+	dom.GrandFathering = entities.DomainGrandFathering{GFAmount: 100, GFCurrency: "USD", GFExpiryCondition: entities.GFConditionTransfer}
+	svc, m := newTransferTestService(t, dom, getPendingTestTransfer())
+
+	// Only the losing registrar can approve
+	_, err := svc.ApproveDomainTransfer(context.TODO(), "example.apex", "gainingRar")
+	require.ErrorIs(t, err, entities.ErrInvalidRegistrar)
+
+	approved, err := svc.ApproveDomainTransfer(context.TODO(), "example.apex", "losingRar")
+	require.NoError(t, err)
+	require.Equal(t, entities.TransferStatusApproved, approved.Status)
+	require.Equal(t, entities.ClIDType("gainingRar"), dom.ClID)
+	require.Equal(t, time.Date(2031, time.January, 1, 0, 0, 0, 0, time.UTC), dom.ExpiryDate)
+	require.False(t, dom.Status.PendingTransfer)
+	require.False(t, dom.IsGrandFathered())
+
+	// Both registrars are notified
+	m.pollRepo.AssertNumberOfCalls(t, "Create", 2)
+	m.pollRepo.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(pm *entities.PollMessage) bool { return pm.ClID == "gainingRar" }))
+	m.pollRepo.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(pm *entities.PollMessage) bool { return pm.ClID == "losingRar" }))
+
+	// The domain is no longer pending transfer
+	_, err = svc.ApproveDomainTransfer(context.TODO(), "example.apex", "losingRar")
+	require.ErrorIs(t, err, entities.ErrNoPendingTransfer)
+}
+
+func TestDomainService_RejectCancelDomainTransfer(t *testing.T) {
+	testcases := []struct {
+		name       string
+		action     func(svc *DomainService, clid string) (*entities.DomainTransfer, error)
+		clid       string
+		wantErr    error
+		wantStatus entities.TransferStatus
+		notify     entities.ClIDType
+	}{
+		{
+			name: "reject by losing registrar",
+			action: func(svc *DomainService, clid string) (*entities.DomainTransfer, error) {
+				return svc.RejectDomainTransfer(context.TODO(), "example.apex", clid, "no")
+			},
+			clid:       "losingRar",
+			wantStatus: entities.TransferStatusDenied,
+			notify:     "gainingRar",
+		},
+		{
+			name: "reject by gaining registrar",
+			action: func(svc *DomainService, clid string) (*entities.DomainTransfer, error) {
+				return svc.RejectDomainTransfer(context.TODO(), "example.apex", clid, "no")
+			},
+			clid:    "gainingRar",
+			wantErr: entities.ErrInvalidRegistrar,
+		},
+		{
+			name: "cancel by gaining registrar",
+			action: func(svc *DomainService, clid string) (*entities.DomainTransfer, error) {
+				return svc.CancelDomainTransfer(context.TODO(), "example.apex", clid)
+			},
+			clid:       "gainingRar",
+			wantStatus: entities.TransferStatusCancelled,
+			notify:     "losingRar",
+		},
+		{
+			name: "cancel by losing registrar",
+			action: func(svc *DomainService, clid string) (*entities.DomainTransfer, error) {
+				return svc.CancelDomainTransfer(context.TODO(), "example.apex", clid)
+			},
+			clid:    "losingRar",
+			wantErr: entities.ErrInvalidRegistrar,
+		},
+		{
+			name: "cancel by the registry",
+			action: func(svc *DomainService, clid string) (*entities.DomainTransfer, error) {
+				return svc.CancelDomainTransfer(context.TODO(), "example.apex", clid)
+			},
+			clid:       "",
+			wantStatus: entities.TransferStatusCancelled,
+			notify:     "losingRar",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			dom := getTransferTestDomain()
+			dom.Status = entities.DomainStatus{PendingTransfer: true}
+			svc, m := newTransferTestService(t, dom, getPendingTestTransfer())
+
+			transfer, err := tc.action(svc, tc.clid)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				require.True(t, dom.Status.PendingTransfer)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantStatus, transfer.Status)
+			require.Equal(t, entities.ClIDType("losingRar"), dom.ClID)
+			require.False(t, dom.Status.PendingTransfer)
+			require.True(t, dom.Status.OK)
+			m.pollRepo.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(pm *entities.PollMessage) bool { return pm.ClID == tc.notify }))
+		})
+	}
+}
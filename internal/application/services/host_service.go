@@ -118,9 +118,39 @@ func (s *HostService) DeleteHostByRoID(ctx context.Context, roidString string) e
 	if err != nil {
 		return err
 	}
+
+	host, err := s.hostRepository.GetHostByRoid(ctx, roidInt)
+	if err != nil {
+		// Deleting a host that doesn't exist is idempotent
+		if errors.Is(err, entities.ErrHostNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !host.CanBeDeleted() {
+		return entities.ErrHostDeleteProhibited
+	}
+
+	// Hosts that are in use by domains can't be deleted
+	count, err := s.hostRepository.GetHostAssociationCount(ctx, roidInt)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.Join(entities.ErrHostIsLinked, fmt.Errorf("host %s is associated with %d domain(s)", host.Name, count))
+	}
+
 	return s.hostRepository.DeleteHostByRoid(ctx, roidInt)
 }
 
+// UpdateHost validates and saves the host. Addresses are not updated, use AddHostAddress and RemoveHostAddress instead
+func (s *HostService) UpdateHost(ctx context.Context, h *entities.Host) (*entities.Host, error) {
+	if err := h.Validate(); err != nil {
+		return nil, errors.Join(entities.ErrInvalidHost, err)
+	}
+	return s.hostRepository.UpdateHost(ctx, h)
+}
+
 // ListHosts lists hosts
 func (s *HostService) ListHosts(ctx context.Context, params queries.ListItemsQuery) ([]*entities.Host, string, error) {
 	return s.hostRepository.ListHosts(ctx, params)
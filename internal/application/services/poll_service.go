@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/domain/repositories"
+)
+
+// PollService implements the PollService interface
+type PollService struct {
+	pollRepo repositories.PollMessageRepository
+}
+
+// NewPollService returns a new instance of PollService
+func NewPollService(pollRepo repositories.PollMessageRepository) *PollService {
+	return &PollService{
+		pollRepo: pollRepo,
+	}
+}
+
+// Enqueue adds a message to the queue of the registrar in PollMessage.ClID
+func (s *PollService) Enqueue(ctx context.Context, msg *entities.PollMessage) (*entities.PollMessage, error) {
+	return s.pollRepo.Create(ctx, msg)
+}
+
+// Peek returns the oldest message in the queue of the registrar and the number of messages in the queue.
+// If the queue is empty, a nil message and a count of 0 are returned.
+func (s *PollService) Peek(ctx context.Context, clid string) (*entities.PollMessage, int64, error) {
+	count, err := s.pollRepo.Count(ctx, queries.ListPollMessagesFilter{ClidEquals: clid})
+	if err != nil {
+		return nil, 0, err
+	}
+	if count == 0 {
+		return nil, 0, nil
+	}
+	msg, err := s.pollRepo.GetOldest(ctx, clid)
+	if err != nil {
+		// The queue may have been emptied in between our calls
+		if errors.Is(err, entities.ErrPollMessageNotFound) {
+			return nil, 0, nil
+		}
+		return nil, 0, err
+	}
+	return msg, count, nil
+}
+
+// Ack removes the message with the provided ID from the queue of the registrar and returns the next message and the number of messages remaining.
+// Registrars can only acknowledge their own messages, other messages are reported as not found.
+func (s *PollService) Ack(ctx context.Context, clid string, id int64) (*entities.PollMessage, int64, error) {
+	msg, err := s.pollRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, 0, err
+	}
+	if msg.ClID.String() != clid {
+		return nil, 0, errors.Join(entities.ErrPollMessageNotFound, fmt.Errorf("msgID: %d", id))
+	}
+	if err := s.pollRepo.Delete(ctx, id); err != nil {
+		return nil, 0, err
+	}
+	return s.Peek(ctx, clid)
+}
+
+// GetByID retrieves a poll message by its ID
+func (s *PollService) GetByID(ctx context.Context, id int64) (*entities.PollMessage, error) {
+	return s.pollRepo.GetByID(ctx, id)
+}
+
+// Delete removes a poll message from the queue regardless of the registrar it belongs to
+func (s *PollService) Delete(ctx context.Context, id int64) error {
+	return s.pollRepo.Delete(ctx, id)
+}
+
+// List returns a list of poll messages
+func (s *PollService) List(ctx context.Context, params queries.ListItemsQuery) ([]*entities.PollMessage, string, error) {
+	return s.pollRepo.List(ctx, params)
+}
+
+// Count returns the number of poll messages matching the filter
+func (s *PollService) Count(ctx context.Context, filter queries.ListPollMessagesFilter) (int64, error) {
+	return s.pollRepo.Count(ctx, filter)
+}
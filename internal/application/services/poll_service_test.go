@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPollService_Peek(t *testing.T) {
+	mockRepo := new(repositories.MockPollMessageRepository)
+	service := NewPollService(mockRepo)
+
+	msg := &entities.PollMessage{ID: 1, ClID: "testClID", Msg: "first message"}
+	mockRepo.On("Count", mock.Anything, queries.ListPollMessagesFilter{ClidEquals: "testClID"}).Return(int64(2), nil)
+	mockRepo.On("GetOldest", mock.Anything, "testClID").Return(msg, nil)
+	mockRepo.On("Count", mock.Anything, queries.ListPollMessagesFilter{ClidEquals: "emptyClID"}).Return(int64(0), nil)
+
+	result, count, err := service.Peek(context.TODO(), "testClID")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.Equal(t, msg, result)
+
+	// An empty queue is not an error
+	result, count, err = service.Peek(context.TODO(), "emptyClID")
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+	assert.Nil(t, result)
+	mockRepo.AssertNotCalled(t, "GetOldest", mock.Anything, "emptyClID")
+}
+
+func TestPollService_Ack(t *testing.T) {
+	first := &entities.PollMessage{ID: 1, ClID: "testClID", Msg: "first message"}
+	second := &entities.PollMessage{ID: 2, ClID: "testClID", Msg: "second message"}
+
+	testcases := []struct {
+		name      string
+		clid      string
+		id        int64
+		wantErr   error
+		wantNext  *entities.PollMessage
+		wantCount int64
+	}{
+		{"ack own message", "testClID", 1, nil, second, 1},
+		{"ack message of other registrar", "otherClID", 1, entities.ErrPollMessageNotFound, nil, 0},
+		{"ack unknown message", "testClID", 3, entities.ErrPollMessageNotFound, nil, 0},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(repositories.MockPollMessageRepository)
+			service := NewPollService(mockRepo)
+			mockRepo.On("GetByID", mock.Anything, int64(1)).Return(first, nil)
+			mockRepo.On("GetByID", mock.Anything, int64(3)).Return(nil, entities.ErrPollMessageNotFound)
+			mockRepo.On("Delete", mock.Anything, int64(1)).Return(nil)
+			mockRepo.On("Count", mock.Anything, queries.ListPollMessagesFilter{ClidEquals: "testClID"}).Return(int64(1), nil)
+			mockRepo.On("GetOldest", mock.Anything, "testClID").Return(second, nil)
+
+			next, count, err := service.Ack(context.TODO(), tc.clid, tc.id)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantNext, next)
+			assert.Equal(t, tc.wantCount, count)
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+const (
+	// RDAP_DEFAULT_SEARCH_PAGE_SIZE is the number of results per page of a search if no page size is configured
+	RDAP_DEFAULT_SEARCH_PAGE_SIZE = 25
+	// RDAP_DEFAULT_TRUNCATION_NOTICE is the description of the notice that is added when there are more search results than fit on a page
+	RDAP_DEFAULT_TRUNCATION_NOTICE = "Search results are limited per page, follow the next link in the paging metadata to get the next page."
+)
+
+var (
+	ErrInvalidRDAPSearchPattern = errors.New("a search pattern must start with a character other than * and may only contain letters, digits, hyphens, dots and *")
+	ErrInvalidRDAPSearchCursor  = errors.New("invalid search cursor")
+	ErrInvalidRDAPSearchType    = errors.New("exactly one search parameter must be provided")
+)
+
+// SearchDomains searches the domains by name (e.g. exa*.apex) or by the name of one of their nameservers (e.g. ns1.*).
+// Exactly one of name and nsLdhName must be provided. Search results do not contain contacts.
+func (s *RDAPService) SearchDomains(ctx context.Context, name, nsLdhName, cursor string) (*entities.RDAPDomainSearchResults, error) {
+	filter := queries.ListDomainsFilter{}
+	query := url.Values{}
+	var err error
+	switch {
+	case name != "" && nsLdhName == "":
+		filter.NameMatches, err = newRDAPSearchPattern(name)
+		query.Set("name", name)
+	case nsLdhName != "" && name == "":
+		filter.NsNameMatches, err = newRDAPSearchPattern(nsLdhName)
+		query.Set("nsLdhName", nsLdhName)
+	default:
+		err = errors.Join(entities.ErrInvalidRDAPQuery, ErrInvalidRDAPSearchType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRDAPSearchCursor(cursor, entities.DOMAIN_ROID_ID); err != nil {
+		return nil, err
+	}
+
+	doms, next, err := s.domRepo.ListDomains(ctx, queries.ListItemsQuery{PageSize: s.searchPageSize(), PageCursor: cursor, Filter: filter})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	// Domains of the same registrar share the registrar entity
+	rars := map[string]*entities.Registrar{}
+	results := &entities.RDAPDomainSearchResults{DomainSearchResults: make([]entities.RDAPDomain, 0, len(doms))}
+	for _, dom := range doms {
+		rar, ok := rars[dom.ClID.String()]
+		if !ok {
+			rar, err = s.rarRepo.GetByClID(ctx, dom.ClID.String(), false)
+			if err != nil {
+				return nil, err
+			}
+			rars[dom.ClID.String()] = rar
+		}
+		rd := entities.NewRDAPDomain(dom, rar, now)
+		rd.Links = []entities.RDAPLink{entities.NewRDAPSelfLink(s.BaseURL, entities.RDAPObjectClassDomain, rd.LdhName)}
+		results.DomainSearchResults = append(results.DomainSearchResults, *rd)
+	}
+
+	results.RDAPConformance = entities.NewRDAPSearchConformance()
+	results.Notices = s.searchNotices(next)
+	results.PagingMetadata = entities.NewRDAPPagingMetadata(s.BaseURL, "domains", query, s.searchPageSize(), next)
+	return results, nil
+}
+
+// SearchNameservers searches the nameservers by name (e.g. ns1.*) or by IP address. Exactly one of name and ip must be provided.
+func (s *RDAPService) SearchNameservers(ctx context.Context, name, ip, cursor string) (*entities.RDAPNameserverSearchResults, error) {
+	filter := queries.ListHostsFilter{}
+	query := url.Values{}
+	var err error
+	switch {
+	case name != "" && ip == "":
+		filter.NameMatches, err = newRDAPSearchPattern(name)
+		query.Set("name", name)
+	case ip != "" && name == "":
+		var addr netip.Addr
+		addr, err = netip.ParseAddr(ip)
+		if err != nil {
+			err = errors.Join(entities.ErrInvalidRDAPQuery, err)
+		}
+		filter.AddressEquals = addr.String()
+		query.Set("ip", ip)
+	default:
+		err = errors.Join(entities.ErrInvalidRDAPQuery, ErrInvalidRDAPSearchType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRDAPSearchCursor(cursor, entities.HOST_ROID_ID); err != nil {
+		return nil, err
+	}
+
+	hosts, next, err := s.hostRepo.ListHosts(ctx, queries.ListItemsQuery{PageSize: s.searchPageSize(), PageCursor: cursor, Filter: filter})
+	if err != nil {
+		return nil, err
+	}
+
+	results := &entities.RDAPNameserverSearchResults{NameserverSearchResults: make([]entities.RDAPNameserver, 0, len(hosts))}
+	for _, h := range hosts {
+		ns := entities.NewRDAPNameserver(h)
+		ns.Links = []entities.RDAPLink{entities.NewRDAPSelfLink(s.BaseURL, entities.RDAPObjectClassNameserver, ns.LdhName)}
+		results.NameserverSearchResults = append(results.NameserverSearchResults, ns)
+	}
+
+	results.RDAPConformance = entities.NewRDAPSearchConformance()
+	results.Notices = s.searchNotices(next)
+	results.PagingMetadata = entities.NewRDAPPagingMetadata(s.BaseURL, "nameservers", query, s.searchPageSize(), next)
+	return results, nil
+}
+
+// SearchEntities searches the contacts by name (e.g. John*).
+// A search by name would reveal the names that are redacted from the public output, so only requesters with a token
+// that grants full access to all TLDs get results. Other requesters get an empty result set with a truncation notice.
+func (s *RDAPService) SearchEntities(ctx context.Context, fn, cursor, token string) (*entities.RDAPEntitySearchResults, error) {
+	fn = strings.TrimSpace(fn)
+	if fn == "" || strings.HasPrefix(fn, "*") {
+		return nil, errors.Join(entities.ErrInvalidRDAPQuery, ErrInvalidRDAPSearchPattern)
+	}
+	if err := validateRDAPSearchCursor(cursor, entities.CONTACT_ROID_ID); err != nil {
+		return nil, err
+	}
+	query := url.Values{"fn": {fn}}
+
+	results := &entities.RDAPEntitySearchResults{
+		RDAPConformance:     entities.NewRDAPSearchConformance(),
+		EntitySearchResults: []entities.RDAPEntity{},
+	}
+	if !s.access.HasFullAccess(RDDS_ALL_TLDS, token) {
+		results.Notices = append(entities.NewRDAPNotices(s.TermsOfServiceURL), entities.NewRDAPTruncationNotice(
+			entities.RDAPNoticeTypeResultSetTruncatedAuthorization,
+			"Entity search is only available to authenticated requesters with access to the unredacted registration data.",
+		))
+		results.PagingMetadata = entities.NewRDAPPagingMetadata(s.BaseURL, "entities", query, s.searchPageSize(), "")
+		return results, nil
+	}
+
+	contacts, next, err := s.contactRepo.ListContacts(ctx, queries.ListItemsQuery{
+		PageSize:   s.searchPageSize(),
+		PageCursor: cursor,
+		Filter:     queries.ListContactsFilter{NameMatches: fn},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range contacts {
+		e := entities.NewRDAPContactEntity(c, entities.ContactRedaction{})
+		e.Links = []entities.RDAPLink{entities.NewRDAPSelfLink(s.BaseURL, entities.RDAPObjectClassEntity, e.Handle)}
+		results.EntitySearchResults = append(results.EntitySearchResults, e)
+	}
+
+	results.Notices = s.searchNotices(next)
+	results.PagingMetadata = entities.NewRDAPPagingMetadata(s.BaseURL, "entities", query, s.searchPageSize(), next)
+	return results, nil
+}
+
+// searchPageSize returns the configured number of results per page of a search
+func (s *RDAPService) searchPageSize() int {
+	if s.SearchPageSize <= 0 {
+		return RDAP_DEFAULT_SEARCH_PAGE_SIZE
+	}
+	return s.SearchPageSize
+}
+
+// searchNotices returns the notices of a search response, the truncation notice is added if there is a next page
+func (s *RDAPService) searchNotices(nextCursor string) []entities.RDAPNotice {
+	notices := entities.NewRDAPNotices(s.TermsOfServiceURL)
+	if nextCursor != "" {
+		notices = append(notices, entities.NewRDAPTruncationNotice(entities.RDAPNoticeTypeResultSetTruncatedLoad, s.TruncationNotice))
+	}
+	return notices
+}
+
+// newRDAPSearchPattern validates and normalizes the domain or nameserver name pattern of a search where * matches any sequence of characters.
+// A pattern without a wildcard must be a valid name. The pattern must not start with a wildcard so searches can use the index on the name.
+func newRDAPSearchPattern(pattern string) (string, error) {
+	p := strings.ToLower(strings.TrimSuffix(strings.TrimSpace(pattern), "."))
+	if !strings.Contains(p, "*") {
+		return newRDAPQueryName(p)
+	}
+	if strings.HasPrefix(p, "*") {
+		return "", errors.Join(entities.ErrInvalidRDAPQuery, ErrInvalidRDAPSearchPattern)
+	}
+	for _, r := range p {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '.' && r != '*' {
+			return "", errors.Join(entities.ErrInvalidRDAPQuery, ErrInvalidRDAPSearchPattern)
+		}
+	}
+	return p, nil
+}
+
+// validateRDAPSearchCursor checks that the cursor is the RoID of the searched object type
+func validateRDAPSearchCursor(cursor, objectIdentifier string) error {
+	if cursor == "" {
+		return nil
+	}
+	roid := entities.RoidType(cursor)
+	if roid.Validate() != nil || roid.ObjectIdentifier() != objectIdentifier {
+		return errors.Join(entities.ErrInvalidRDAPQuery, ErrInvalidRDAPSearchCursor)
+	}
+	if _, err := roid.Int64(); err != nil {
+		return errors.Join(entities.ErrInvalidRDAPQuery, fmt.Errorf("%w: %w", ErrInvalidRDAPSearchCursor, err))
+	}
+	return nil
+}
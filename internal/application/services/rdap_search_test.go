@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/domain/repositories"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRDAPSearchPattern(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		expected string
+		wantErr  bool
+	}{
+		{pattern: "exa*.apex", expected: "exa*.apex"},
+		{pattern: "NS1.*", expected: "ns1.*"},
+		{pattern: "Example.Apex.", expected: "example.apex"},
+		{pattern: "*.apex", wantErr: true},
+		{pattern: "exa%*.apex", wantErr: true},
+		{pattern: "-invalid.apex", wantErr: true},
+		{pattern: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			p, err := newRDAPSearchPattern(tt.pattern)
+			if tt.wantErr {
+				require.ErrorIs(t, err, entities.ErrInvalidRDAPQuery)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, p)
+		})
+	}
+}
+
+func TestValidateRDAPSearchCursor(t *testing.T) {
+	require.NoError(t, validateRDAPSearchCursor("", entities.DOMAIN_ROID_ID))
+	require.NoError(t, validateRDAPSearchCursor("123_DOM-APEX", entities.DOMAIN_ROID_ID))
+	require.ErrorIs(t, validateRDAPSearchCursor("123_HOST-APEX", entities.DOMAIN_ROID_ID), entities.ErrInvalidRDAPQuery)
+	require.ErrorIs(t, validateRDAPSearchCursor("abc_DOM-APEX", entities.DOMAIN_ROID_ID), entities.ErrInvalidRDAPQuery)
+	require.ErrorIs(t, validateRDAPSearchCursor("garbage", entities.DOMAIN_ROID_ID), entities.ErrInvalidRDAPQuery)
+}
+
+func TestRDAPService_SearchDomains(t *testing.T) {
+	s, domRepo, rarRepo := getTestRDAPService(t)
+	s.SearchPageSize = 10
+	d1, err := entities.NewDomain("1_DOM-APEX", "apex.domains", "rar1", "str0NGP@ZZw0rd")
+	require.NoError(t, err)
+	d2, err := entities.NewDomain("2_DOM-APEX", "apex2.domains", "rar1", "str0NGP@ZZw0rd")
+	require.NoError(t, err)
+	domRepo.On("ListDomains", mock.Anything, queries.ListItemsQuery{PageSize: 10, Filter: queries.ListDomainsFilter{NameMatches: "apex*.domains"}}).Return([]*entities.Domain{d1, d2}, nil)
+	domRepo.On("ListDomains", mock.Anything, queries.ListItemsQuery{PageSize: 10, PageCursor: "2_DOM-APEX", Filter: queries.ListDomainsFilter{NsNameMatches: "ns1.*"}}).Return([]*entities.Domain{}, nil)
+	rarRepo.On("GetByClID", mock.Anything, "rar1", false).Return(getTestRDAPRegistrar(t), nil).Once()
+
+	res, err := s.SearchDomains(context.Background(), "APEX*.domains", "", "")
+	require.NoError(t, err)
+	require.Len(t, res.DomainSearchResults, 2)
+	require.Equal(t, "https://rdap.apex.domains/domain/apex2.domains", res.DomainSearchResults[1].Links[0].Href)
+	require.Contains(t, res.RDAPConformance, entities.RDAPConformancePaging)
+	require.Equal(t, 10, res.PagingMetadata.PageSize)
+	require.Empty(t, res.PagingMetadata.Links)
+	require.Len(t, res.Notices, 3)
+
+	res, err = s.SearchDomains(context.Background(), "", "ns1.*", "2_DOM-APEX")
+	require.NoError(t, err)
+	require.NotNil(t, res.DomainSearchResults)
+	require.Empty(t, res.DomainSearchResults)
+
+	// Exactly one search parameter
+	_, err = s.SearchDomains(context.Background(), "apex*.domains", "ns1.*", "")
+	require.ErrorIs(t, err, ErrInvalidRDAPSearchType)
+	_, err = s.SearchDomains(context.Background(), "", "", "")
+	require.ErrorIs(t, err, entities.ErrInvalidRDAPQuery)
+
+	// A cursor of another object type
+	_, err = s.SearchDomains(context.Background(), "apex*.domains", "", "1_HOST-APEX")
+	require.ErrorIs(t, err, ErrInvalidRDAPSearchCursor)
+
+	domRepo.AssertExpectations(t)
+	rarRepo.AssertExpectations(t)
+}
+
+func TestRDAPService_SearchNameservers(t *testing.T) {
+	s, _, _ := getTestRDAPService(t)
+	s.SearchPageSize = 1
+	s.TruncationNotice = "Only one nameserver per page"
+	h, err := entities.NewHost("ns1.apex.domains", "1_HOST-APEX", "rar1")
+	require.NoError(t, err)
+	var filter queries.ListHostsFilter
+	s.hostRepo = &repositories.MockHostRepository{
+		ListHostsFunc: func(ctx context.Context, params queries.ListItemsQuery) ([]*entities.Host, string, error) {
+			filter = params.Filter.(queries.ListHostsFilter)
+			return []*entities.Host{h}, h.RoID.String(), nil
+		},
+	}
+
+	res, err := s.SearchNameservers(context.Background(), "", "2001:DB8::1", "")
+	require.NoError(t, err)
+	require.Equal(t, "2001:db8::1", filter.AddressEquals)
+	require.Len(t, res.NameserverSearchResults, 1)
+	require.Equal(t, "https://rdap.apex.domains/nameserver/ns1.apex.domains", res.NameserverSearchResults[0].Links[0].Href)
+
+	// There is a next page
+	require.Len(t, res.PagingMetadata.Links, 1)
+	require.Equal(t, "https://rdap.apex.domains/nameservers?cursor=MV9IT1NULUFQRVg%3D&ip=2001%3ADB8%3A%3A1", res.PagingMetadata.Links[0].Href)
+	require.Len(t, res.Notices, 4)
+	require.Equal(t, entities.RDAPNoticeTypeResultSetTruncatedLoad, res.Notices[3].Type)
+	require.Equal(t, []string{"Only one nameserver per page"}, res.Notices[3].Description)
+
+	_, err = s.SearchNameservers(context.Background(), "ns1.*", "", "")
+	require.NoError(t, err)
+	require.Equal(t, "ns1.*", filter.NameMatches)
+
+	_, err = s.SearchNameservers(context.Background(), "", "not-an-ip", "")
+	require.ErrorIs(t, err, entities.ErrInvalidRDAPQuery)
+}
+
+func TestRDAPService_SearchEntities(t *testing.T) {
+	s, _, _ := getTestRDAPService(t)
+
+	// Anonymous requesters do not get results
+	res, err := s.SearchEntities(context.Background(), "John*", "", "")
+	require.NoError(t, err)
+	require.Empty(t, res.EntitySearchResults)
+	require.Equal(t, entities.RDAPNoticeTypeResultSetTruncatedAuthorization, res.Notices[len(res.Notices)-1].Type)
+
+	// A token for a single TLD does not grant access
+	res, err = s.SearchEntities(context.Background(), "John*", "", "apex-token")
+	require.NoError(t, err)
+	require.Empty(t, res.EntitySearchResults)
+
+	res, err = s.SearchEntities(context.Background(), "John*", "", "admin-token")
+	require.NoError(t, err)
+	require.Len(t, res.EntitySearchResults, 1)
+	require.Equal(t, "cont1", res.EntitySearchResults[0].Handle)
+	require.Empty(t, res.EntitySearchResults[0].Redacted)
+	require.Equal(t, "https://rdap.apex.domains/entity/cont1", res.EntitySearchResults[0].Links[0].Href)
+
+	_, err = s.SearchEntities(context.Background(), "*Doe", "", "admin-token")
+	require.ErrorIs(t, err, ErrInvalidRDAPSearchPattern)
+}
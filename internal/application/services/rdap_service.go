@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/domain/repositories"
+)
+
+// RDAPService implements the RDAPService interface. It builds the RDAP (RFC 9082/9083) responses for domains, nameservers and entities
+// following the ICANN RDAP Response Profile.
+type RDAPService struct {
+	domRepo     repositories.DomainRepository
+	hostRepo    repositories.HostRepository
+	contactRepo repositories.ContactRepository
+	rarRepo     repositories.RegistrarRepository
+	tldRepo     repositories.TLDRepository
+	// access decides which requesters get the unredacted contact data
+	access *RDDSAccessPolicy
+	// BaseURL is the public URL of the RDAP service, it is used to create the self links
+	BaseURL string
+	// TermsOfServiceURL is linked from the Terms of Use notice
+	TermsOfServiceURL string
+	// SearchPageSize is the maximum number of results in a page of search results
+	SearchPageSize int
+	// TruncationNotice is the description of the notice that is added when there are more search results than fit on a page
+	TruncationNotice string
+}
+
+// NewRDAPService creates a new instance of RDAPService
+func NewRDAPService(domRepo repositories.DomainRepository, hostRepo repositories.HostRepository, contactRepo repositories.ContactRepository, rarRepo repositories.RegistrarRepository, tldRepo repositories.TLDRepository, access *RDDSAccessPolicy, baseURL, termsOfServiceURL string) *RDAPService {
+	return &RDAPService{
+		domRepo:           domRepo,
+		hostRepo:          hostRepo,
+		contactRepo:       contactRepo,
+		rarRepo:           rarRepo,
+		tldRepo:           tldRepo,
+		access:            access,
+		BaseURL:           baseURL,
+		TermsOfServiceURL: termsOfServiceURL,
+		SearchPageSize:    RDAP_DEFAULT_SEARCH_PAGE_SIZE,
+		TruncationNotice:  RDAP_DEFAULT_TRUNCATION_NOTICE,
+	}
+}
+
+// GetDomain returns the RDAP response for a domain including its nameservers, sponsoring registrar and contacts.
+// The contacts are redacted according to their disclose flags unless the token grants full access to the TLD, roles that are prohibited by the contact data policy of the TLD are left out.
+func (s *RDAPService) GetDomain(ctx context.Context, name, token string) (*entities.RDAPDomain, error) {
+	dn, err := newRDAPQueryName(name)
+	if err != nil {
+		return nil, err
+	}
+	dom, err := s.domRepo.GetDomainByName(ctx, dn, true)
+	if err != nil {
+		return nil, err
+	}
+
+	rar, err := s.rarRepo.GetByClID(ctx, dom.ClID.String(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	rd := entities.NewRDAPDomain(dom, rar, now)
+
+	policy, err := getContactDataPolicy(ctx, s.tldRepo, dom.TLDName.String())
+	if err != nil {
+		return nil, err
+	}
+	contacts, err := getRDDSContacts(ctx, s.contactRepo, dom, policy)
+	if err != nil {
+		return nil, err
+	}
+	fullAccess := s.access.HasFullAccess(dom.TLDName.String(), token)
+	for _, c := range contacts {
+		redaction := entities.ContactRedaction{}
+		if !fullAccess {
+			redaction = entities.NewContactRedaction(c.contact)
+		}
+		rd.Entities = append(rd.Entities, entities.NewRDAPContactEntity(c.contact, redaction, c.roles...))
+		path := fmt.Sprintf("$.entities[?(@.roles[0]=='%s')]", c.roles[0])
+		rd.Redacted = append(rd.Redacted, entities.NewRDAPContactRedactions(c.contact, redaction, path, c.roles...)...)
+	}
+
+	rd.RDAPConformance = entities.RDAPConformanceWithRedactions(rd.Redacted)
+	rd.Notices = entities.NewRDAPNotices(s.TermsOfServiceURL)
+	rd.Links = []entities.RDAPLink{entities.NewRDAPSelfLink(s.BaseURL, entities.RDAPObjectClassDomain, rd.LdhName)}
+	rd.Events = append(rd.Events, entities.RDAPEvent{EventAction: entities.RDAPEventLastRDAPDBUpdate, EventDate: now})
+	return rd, nil
+}
+
+// GetNameserver returns the RDAP response for a nameserver
+func (s *RDAPService) GetNameserver(ctx context.Context, name string) (*entities.RDAPNameserver, error) {
+	hn, err := newRDAPQueryName(name)
+	if err != nil {
+		return nil, err
+	}
+	hosts, _, err := s.hostRepo.ListHosts(ctx, queries.ListItemsQuery{
+		PageSize: 1,
+		Filter:   queries.ListHostsFilter{NameEquals: hn},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(hosts) == 0 {
+		return nil, entities.ErrHostNotFound
+	}
+
+	ns := entities.NewRDAPNameserver(hosts[0])
+	ns.RDAPConformance = entities.RDAPConformance
+	ns.Notices = entities.NewRDAPNotices(s.TermsOfServiceURL)
+	ns.Links = []entities.RDAPLink{entities.NewRDAPSelfLink(s.BaseURL, entities.RDAPObjectClassNameserver, ns.LdhName)}
+	return &ns, nil
+}
+
+// GetEntity returns the RDAP response for an entity. A numeric handle is looked up as the IANA ID of a registrar first,
+// other handles are looked up as a contact ID and then as the ClID of a registrar.
+// Contacts are not tied to a TLD, so they are only shown unredacted if the token grants full access to all TLDs.
+func (s *RDAPService) GetEntity(ctx context.Context, handle, token string) (*entities.RDAPEntity, error) {
+	entity, err := s.getEntity(ctx, handle, token)
+	if err != nil {
+		return nil, err
+	}
+	entity.RDAPConformance = entities.RDAPConformanceWithRedactions(entity.Redacted)
+	entity.Notices = entities.NewRDAPNotices(s.TermsOfServiceURL)
+	entity.Links = append(entity.Links, entities.NewRDAPSelfLink(s.BaseURL, entities.RDAPObjectClassEntity, entity.Handle))
+	return entity, nil
+}
+
+// getEntity looks up the entity by its handle
+func (s *RDAPService) getEntity(ctx context.Context, handle, token string) (*entities.RDAPEntity, error) {
+	if gurID, err := strconv.Atoi(handle); err == nil {
+		rar, err := s.rarRepo.GetByGurID(ctx, gurID)
+		if err == nil {
+			e := entities.NewRDAPRegistrarEntity(rar)
+			return &e, nil
+		}
+		if !errors.Is(err, entities.ErrRegistrarNotFound) {
+			return nil, err
+		}
+	}
+
+	c, err := s.contactRepo.GetContactByID(ctx, handle)
+	if err == nil {
+		redaction := entities.ContactRedaction{}
+		if !s.access.HasFullAccess(RDDS_ALL_TLDS, token) {
+			redaction = entities.NewContactRedaction(c)
+		}
+		e := entities.NewRDAPContactEntity(c, redaction)
+		// The handle is what was queried, so it is not redacted
+		e.Handle = c.ID.String()
+		redaction.ID = false
+		e.Redacted = entities.NewRDAPContactRedactions(c, redaction, "$")
+		return &e, nil
+	}
+	if !errors.Is(err, entities.ErrContactNotFound) {
+		return nil, err
+	}
+
+	rar, err := s.rarRepo.GetByClID(ctx, handle, false)
+	if err != nil {
+		return nil, err
+	}
+	e := entities.NewRDAPRegistrarEntity(rar)
+	return &e, nil
+}
+
+// Help returns the RDAP response to a help query
+func (s *RDAPService) Help(ctx context.Context) *entities.RDAPHelp {
+	notices := []entities.RDAPNotice{
+		{
+			Title: "RDAP Help",
+			Description: []string{
+				"This service supports the following queries:",
+				"domain/<domain name>",
+				"nameserver/<nameserver name>",
+				"entity/<IANA registrar ID, registrar ClID or contact ID>",
+				"domains?name=<domain name pattern>, e.g. exa*.apex",
+				"domains?nsLdhName=<nameserver name pattern>, e.g. ns1.*",
+				"nameservers?name=<nameserver name pattern>",
+				"nameservers?ip=<IP address>",
+				"entities?fn=<contact name pattern>, only for authenticated requesters",
+				"help",
+			},
+		},
+	}
+	return &entities.RDAPHelp{
+		RDAPConformance: entities.RDAPConformance,
+		Notices:         append(notices, entities.NewRDAPNotices(s.TermsOfServiceURL)...),
+	}
+}
+
+// newRDAPQueryName validates and normalizes the domain or nameserver name of a query
+func newRDAPQueryName(name string) (string, error) {
+	dn, err := entities.NewDomainName(name)
+	if err != nil {
+		return "", errors.Join(entities.ErrInvalidRDAPQuery, err)
+	}
+	return dn.String(), nil
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/domain/repositories"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestRDAPRegistrar(t *testing.T) *entities.Registrar {
+	t.Helper()
+	a, err := entities.NewAddress("Brussels", "BE")
+	require.NoError(t, err)
+	pi, err := entities.NewRegistrarPostalInfo("int", a)
+	require.NoError(t, err)
+	rar, err := entities.NewRegistrar("rar1", "Registrar One", "abuse@rar1.com", 1234, [2]*entities.RegistrarPostalInfo{pi, nil})
+	require.NoError(t, err)
+	return rar
+}
+
+func getTestRDAPService(t *testing.T) (*RDAPService, *repositories.MockDomainRepository, *repositories.MockRegistrarRepository) {
+	t.Helper()
+	h, err := entities.NewHost("ns1.apex.domains", "1_HOST-APEX", "rar1")
+	require.NoError(t, err)
+
+	domRepo := new(repositories.MockDomainRepository)
+	rarRepo := new(repositories.MockRegistrarRepository)
+	hostRepo := &repositories.MockHostRepository{
+		ListHostsFunc: func(ctx context.Context, params queries.ListItemsQuery) ([]*entities.Host, string, error) {
+			if params.Filter.(queries.ListHostsFilter).NameEquals == h.Name.String() {
+				return []*entities.Host{h}, "", nil
+			}
+			return nil, "", nil
+		},
+	}
+	access := NewRDDSAccessPolicy(map[string][]string{"apex": {"apex-token"}, RDDS_ALL_TLDS: {"admin-token"}})
+
+	return NewRDAPService(domRepo, hostRepo, getTestRDDSContactRepository(t), rarRepo, &fakeRDDSTLDRepository{}, access, "https://rdap.apex.domains", "https://apex.domains/tos"), domRepo, rarRepo
+}
+
+func TestRDAPService_GetDomain(t *testing.T) {
+	s, domRepo, rarRepo := getTestRDAPService(t)
+	d, err := entities.NewDomain("1_DOM-APEX", "apex.domains", "rar1", "str0NGP@ZZw0rd")
+	require.NoError(t, err)
+	d.CreatedAt = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	d.TLDName = "domains"
+	d.RegistrantID = "cont1"
+	d.AdminID = "cont1"
+	d.TechID = "missing"
+	domRepo.On("GetDomainByName", mock.Anything, "apex.domains", true).Return(d, nil)
+	rarRepo.On("GetByClID", mock.Anything, "rar1", false).Return(getTestRDAPRegistrar(t), nil)
+
+	rd, err := s.GetDomain(context.Background(), "APEX.domains.", "")
+	require.NoError(t, err)
+	require.Contains(t, rd.RDAPConformance, entities.RDAPConformanceRedacted)
+	require.Len(t, rd.Notices, 3)
+	require.Equal(t, "https://rdap.apex.domains/domain/apex.domains", rd.Links[0].Href)
+	require.Equal(t, entities.RDAPEventLastRDAPDBUpdate, rd.Events[len(rd.Events)-1].EventAction)
+
+	// The registrar and a single entity for the contact in both roles, the missing contact is left out
+	require.Len(t, rd.Entities, 2)
+	require.Equal(t, []string{entities.RDAPRoleRegistrar}, rd.Entities[0].Roles)
+	require.Equal(t, []string{entities.RDAPRoleRegistrant, entities.RDAPRoleAdministrative}, rd.Entities[1].Roles)
+
+	// Anonymous requests get the redacted contact
+	require.Empty(t, rd.Entities[1].Handle)
+	require.NotEmpty(t, rd.Redacted)
+	require.Equal(t, "Registry Registrant ID", rd.Redacted[0].Name.Type)
+	require.Equal(t, "$.entities[?(@.roles[0]=='registrant')].handle", rd.Redacted[0].PrePath)
+
+	// A token for another TLD does not grant access
+	rd, err = s.GetDomain(context.Background(), "apex.domains", "other-token")
+	require.NoError(t, err)
+	require.NotEmpty(t, rd.Redacted)
+
+	// A privileged requester gets the full contact
+	rd, err = s.GetDomain(context.Background(), "apex.domains", "admin-token")
+	require.NoError(t, err)
+	require.Equal(t, entities.RDAPConformance, rd.RDAPConformance)
+	require.Equal(t, "cont1", rd.Entities[1].Handle)
+	require.Empty(t, rd.Redacted)
+}
+
+func TestRDAPService_GetDomain_NotFound(t *testing.T) {
+	s, domRepo, _ := getTestRDAPService(t)
+	domRepo.On("GetDomainByName", mock.Anything, "missing.domains", true).Return((*entities.Domain)(nil), entities.ErrDomainNotFound)
+
+	_, err := s.GetDomain(context.Background(), "missing.domains", "")
+	require.ErrorIs(t, err, entities.ErrDomainNotFound)
+
+	_, err = s.GetDomain(context.Background(), "-invalid.domains", "")
+	require.ErrorIs(t, err, entities.ErrInvalidRDAPQuery)
+}
+
+func TestRDAPService_GetNameserver(t *testing.T) {
+	s, _, _ := getTestRDAPService(t)
+
+	ns, err := s.GetNameserver(context.Background(), "ns1.apex.domains")
+	require.NoError(t, err)
+	require.Equal(t, "ns1.apex.domains", ns.LdhName)
+	require.Equal(t, "https://rdap.apex.domains/nameserver/ns1.apex.domains", ns.Links[0].Href)
+
+	_, err = s.GetNameserver(context.Background(), "ns2.apex.domains")
+	require.ErrorIs(t, err, entities.ErrHostNotFound)
+}
+
+func TestRDAPService_GetEntity(t *testing.T) {
+	s, _, rarRepo := getTestRDAPService(t)
+	rarRepo.On("GetByGurID", mock.Anything, 1234).Return(getTestRDAPRegistrar(t), nil)
+	rarRepo.On("GetByClID", mock.Anything, "rar1", false).Return(getTestRDAPRegistrar(t), nil)
+	rarRepo.On("GetByClID", mock.Anything, "missing", false).Return((*entities.Registrar)(nil), entities.ErrRegistrarNotFound)
+
+	e, err := s.GetEntity(context.Background(), "1234", "")
+	require.NoError(t, err)
+	require.Equal(t, []string{entities.RDAPRoleRegistrar}, e.Roles)
+	require.Equal(t, "https://rdap.apex.domains/entity/1234", e.Links[len(e.Links)-1].Href)
+
+	e, err = s.GetEntity(context.Background(), "cont1", "")
+	require.NoError(t, err)
+	require.Equal(t, "cont1", e.Handle)
+	require.Equal(t, "Contact Name", e.Redacted[0].Name.Type)
+	require.Contains(t, e.RDAPConformance, entities.RDAPConformanceRedacted)
+
+	// Contacts are not tied to a TLD, so only a token for all TLDs grants access
+	e, err = s.GetEntity(context.Background(), "cont1", "apex-token")
+	require.NoError(t, err)
+	require.NotEmpty(t, e.Redacted)
+	e, err = s.GetEntity(context.Background(), "cont1", "admin-token")
+	require.NoError(t, err)
+	require.Empty(t, e.Redacted)
+
+	e, err = s.GetEntity(context.Background(), "rar1", "")
+	require.NoError(t, err)
+	require.Equal(t, "1234", e.Handle)
+
+	_, err = s.GetEntity(context.Background(), "missing", "")
+	require.ErrorIs(t, err, entities.ErrRegistrarNotFound)
+}
+
+func TestRDAPService_Help(t *testing.T) {
+	s, _, _ := getTestRDAPService(t)
+	h := s.Help(context.Background())
+	require.Equal(t, entities.RDAPConformance, h.RDAPConformance)
+	require.Len(t, h.Notices, 4)
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/domain/repositories"
+)
+
+const (
+	// RDDS_ALL_TLDS configures tokens that have full access to the registration data of all TLDs
+	RDDS_ALL_TLDS = "*"
+)
+
+var (
+	ErrInvalidRDDSAccessPolicy = errors.New("invalid RDDS access policy, expected a JSON object of TLD names to lists of tokens")
+)
+
+// RDDSAccessPolicy decides which requesters get the full, unredacted registration data of a TLD. Privileged requesters
+// (e.g. law enforcement or the registry operator) authenticate with a bearer token that is configured per TLD.
+// Only the SHA256 hashes of the tokens are kept in memory.
+type RDDSAccessPolicy struct {
+	tokens map[string][][sha256.Size]byte
+}
+
+// NewRDDSAccessPolicy creates a new RDDSAccessPolicy from a map of TLD names to tokens. Tokens configured for RDDS_ALL_TLDS have access to all TLDs.
+func NewRDDSAccessPolicy(tokens map[string][]string) *RDDSAccessPolicy {
+	p := &RDDSAccessPolicy{tokens: map[string][][sha256.Size]byte{}}
+	for tld, list := range tokens {
+		for _, token := range list {
+			if token == "" {
+				continue
+			}
+			p.tokens[tld] = append(p.tokens[tld], sha256.Sum256([]byte(token)))
+		}
+	}
+	return p
+}
+
+// ParseRDDSAccessPolicy creates a new RDDSAccessPolicy from its JSON representation, e.g. {"apex": ["token1"], "*": ["token2"]}.
+// An empty string returns a policy without privileged requesters.
+func ParseRDDSAccessPolicy(s string) (*RDDSAccessPolicy, error) {
+	tokens := map[string][]string{}
+	if s != "" {
+		if err := json.Unmarshal([]byte(s), &tokens); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidRDDSAccessPolicy, err)
+		}
+	}
+	return NewRDDSAccessPolicy(tokens), nil
+}
+
+// HasFullAccess returns true if the token grants access to the unredacted registration data of the TLD.
+// Use RDDS_ALL_TLDS as the TLD for data that is not tied to a TLD, such as contacts.
+func (p *RDDSAccessPolicy) HasFullAccess(tld, token string) bool {
+	if p == nil || token == "" {
+		return false
+	}
+	hash := sha256.Sum256([]byte(token))
+	for _, key := range []string{tld, RDDS_ALL_TLDS} {
+		for _, h := range p.tokens[key] {
+			if subtle.ConstantTimeCompare(h[:], hash[:]) == 1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getContactDataPolicy returns the contact data policy of the current GA phase of the TLD.
+// If the TLD has no current GA phase, the default policy is returned.
+func getContactDataPolicy(ctx context.Context, tldRepo repositories.TLDRepository, tldName string) (entities.ContactDataPolicy, error) {
+	tld, err := tldRepo.GetByName(ctx, tldName, true)
+	if err != nil {
+		return entities.ContactDataPolicy{}, err
+	}
+	phase, err := tld.GetCurrentGAPhase()
+	if err != nil {
+		return entities.NewContactDataPolicy(), nil
+	}
+	return phase.Policy.ContactDataPolicy, nil
+}
+
+// rddsContact is a contact of a domain with the roles it has on the domain
+type rddsContact struct {
+	contact *entities.Contact
+	roles   []string
+}
+
+// getRDDSContacts returns the contacts of the domain that may be published according to the contact data policy.
+// A contact that is used in several roles is only returned once with all its roles. Missing contacts are skipped.
+func getRDDSContacts(ctx context.Context, contactRepo repositories.ContactRepository, dom *entities.Domain, policy entities.ContactDataPolicy) ([]rddsContact, error) {
+	var result []rddsContact
+	index := map[string]int{}
+	for _, c := range []struct {
+		id   entities.ClIDType
+		role string
+	}{
+		{dom.RegistrantID, entities.RDAPRoleRegistrant},
+		{dom.AdminID, entities.RDAPRoleAdministrative},
+		{dom.TechID, entities.RDAPRoleTechnical},
+		{dom.BillingID, entities.RDAPRoleBilling},
+	} {
+		id := c.id.String()
+		if id == "" || policy.ForRole(c.role) == entities.ContactDataPolicyTypeProhibited {
+			continue
+		}
+		if i, ok := index[id]; ok {
+			result[i].roles = append(result[i].roles, c.role)
+			continue
+		}
+		contact, err := contactRepo.GetContactByID(ctx, id)
+		if err != nil {
+			// A missing contact should not prevent the domain from being served
+			if errors.Is(err, entities.ErrContactNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		index[id] = len(result)
+		result = append(result, rddsContact{contact: contact, roles: []string{c.role}})
+	}
+	return result, nil
+}
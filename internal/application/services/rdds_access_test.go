@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/domain/repositories"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRDDSContactRepository only implements the methods used by the RDDS services
+type fakeRDDSContactRepository struct {
+	repositories.ContactRepository
+	contacts map[string]*entities.Contact
+}
+
+func (r *fakeRDDSContactRepository) GetContactByID(ctx context.Context, id string) (*entities.Contact, error) {
+	c, ok := r.contacts[id]
+	if !ok {
+		return nil, entities.ErrContactNotFound
+	}
+	return c, nil
+}
+
+// ListContacts returns all contacts on a single page
+func (r *fakeRDDSContactRepository) ListContacts(ctx context.Context, params queries.ListItemsQuery) ([]*entities.Contact, string, error) {
+	var contacts []*entities.Contact
+	for _, c := range r.contacts {
+		contacts = append(contacts, c)
+	}
+	return contacts, "", nil
+}
+
+// getTestRDDSContactRepository returns a contact repository with the contact "cont1" that has a postal info and does not disclose anything
+func getTestRDDSContactRepository(t *testing.T) *fakeRDDSContactRepository {
+	t.Helper()
+	c, err := entities.NewContact("cont1", "1_CONT-APEX", "cont1@apex.domains", "str0NGP@ZZw0rd", "rar1")
+	require.NoError(t, err)
+	a, err := entities.NewAddress("Brussels", "BE")
+	require.NoError(t, err)
+	pi, err := entities.NewContactPostalInfo("int", "John Doe", a)
+	require.NoError(t, err)
+	require.NoError(t, c.AddPostalInfo(pi))
+	return &fakeRDDSContactRepository{contacts: map[string]*entities.Contact{"cont1": c}}
+}
+
+// fakeRDDSTLDRepository only implements the methods used by the RDDS services. It returns a TLD with a GA phase that has the policy, or no phases if policy is nil.
+type fakeRDDSTLDRepository struct {
+	repositories.TLDRepository
+	policy *entities.ContactDataPolicy
+}
+
+func (r *fakeRDDSTLDRepository) GetByName(ctx context.Context, name string, preloadAll bool) (*entities.TLD, error) {
+	tld := &entities.TLD{Name: entities.DomainName(name)}
+	if r.policy != nil {
+		phase, err := entities.NewPhase("GAPhase", "GA", time.Now().UTC().AddDate(0, -1, 0))
+		if err != nil {
+			return nil, err
+		}
+		phase.Policy.ContactDataPolicy = *r.policy
+		tld.Phases = append(tld.Phases, *phase)
+	}
+	return tld, nil
+}
+
+func TestRDDSAccessPolicy_HasFullAccess(t *testing.T) {
+	p, err := ParseRDDSAccessPolicy(`{"apex": ["apex-token"], "*": ["admin-token"]}`)
+	require.NoError(t, err)
+
+	require.True(t, p.HasFullAccess("apex", "apex-token"))
+	require.False(t, p.HasFullAccess("other", "apex-token"))
+	require.True(t, p.HasFullAccess("other", "admin-token"))
+	require.True(t, p.HasFullAccess(RDDS_ALL_TLDS, "admin-token"))
+	require.False(t, p.HasFullAccess(RDDS_ALL_TLDS, "apex-token"))
+	require.False(t, p.HasFullAccess("apex", ""))
+	require.False(t, p.HasFullAccess("apex", "wrong"))
+
+	// Without a policy nobody has full access
+	var nilPolicy *RDDSAccessPolicy
+	require.False(t, nilPolicy.HasFullAccess("apex", "apex-token"))
+	p, err = ParseRDDSAccessPolicy("")
+	require.NoError(t, err)
+	require.False(t, p.HasFullAccess("apex", "apex-token"))
+
+	_, err = ParseRDDSAccessPolicy("apex=token")
+	require.ErrorIs(t, err, ErrInvalidRDDSAccessPolicy)
+}
+
+func TestGetRDDSContacts(t *testing.T) {
+	contactRepo := getTestRDDSContactRepository(t)
+	dom := &entities.Domain{RegistrantID: "cont1", AdminID: "cont1", TechID: "missing", BillingID: "cont1"}
+
+	// The default policy publishes all roles
+	policy, err := getContactDataPolicy(context.Background(), &fakeRDDSTLDRepository{}, "apex")
+	require.NoError(t, err)
+	contacts, err := getRDDSContacts(context.Background(), contactRepo, dom, policy)
+	require.NoError(t, err)
+	require.Len(t, contacts, 1)
+	require.Equal(t, []string{entities.RDAPRoleRegistrant, entities.RDAPRoleAdministrative, entities.RDAPRoleBilling}, contacts[0].roles)
+
+	// Prohibited roles are left out
+	prohibited := entities.NewContactDataPolicy()
+	prohibited.AdminContactDataPolicy = entities.ContactDataPolicyTypeProhibited
+	prohibited.BillingContactDataPolicy = entities.ContactDataPolicyTypeProhibited
+	policy, err = getContactDataPolicy(context.Background(), &fakeRDDSTLDRepository{policy: &prohibited}, "apex")
+	require.NoError(t, err)
+	require.Equal(t, prohibited, policy)
+	contacts, err = getRDDSContacts(context.Background(), contactRepo, dom, policy)
+	require.NoError(t, err)
+	require.Len(t, contacts, 1)
+	require.Equal(t, []string{entities.RDAPRoleRegistrant}, contacts[0].roles)
+}
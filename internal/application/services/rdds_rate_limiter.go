@@ -0,0 +1,221 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.uber.org/zap"
+)
+
+const (
+	// The RDDS services as they are reported in the monthly registry activity report
+	RDDS_SERVICE_WHOIS     = "whois-43"
+	RDDS_SERVICE_WEB_WHOIS = "web-whois"
+	RDDS_SERVICE_RDAP      = "rdap"
+
+	// The results of an RDDS query
+	RDDS_QUERY_RESULT_ALLOWED      = "allowed"
+	RDDS_QUERY_RESULT_RATE_LIMITED = "rate_limited"
+
+	// RDDS_RATE_LIMIT_DEFAULT_IPV4_PREFIX_LENGTH limits every IPv4 address separately
+	RDDS_RATE_LIMIT_DEFAULT_IPV4_PREFIX_LENGTH = 32
+	// RDDS_RATE_LIMIT_DEFAULT_IPV6_PREFIX_LENGTH limits IPv6 sources per /64, as a single host usually has a whole /64
+	RDDS_RATE_LIMIT_DEFAULT_IPV6_PREFIX_LENGTH = 64
+	// RDDS_RATE_LIMIT_SWEEP_INTERVAL is how often the buckets of sources that have not queried for a while are removed
+	RDDS_RATE_LIMIT_SWEEP_INTERVAL = time.Minute
+)
+
+var (
+	ErrRDDSQueryLimitExceeded    = errors.New("query limit exceeded")
+	ErrInvalidRDDSRateLimit      = errors.New("invalid RDDS rate limit, expected a positive number of queries per second")
+	ErrInvalidRDDSRateLimitBurst = errors.New("invalid RDDS rate limit burst, expected a positive number of queries")
+	ErrInvalidRDDSAllowlist      = errors.New("invalid RDDS allowlist, expected a comma separated list of IP addresses and prefixes")
+)
+
+// RDDSRateLimitConfig configures the number of queries a source can make to the RDDS services
+type RDDSRateLimitConfig struct {
+	// Rate is the number of queries per second a source can sustain, zero disables the limit
+	Rate float64
+	// Burst is the number of queries a source can make at once
+	Burst int
+	// IPv4PrefixLength and IPv6PrefixLength group the addresses of a source, all addresses in the prefix share a limit
+	IPv4PrefixLength int
+	IPv6PrefixLength int
+	// Allowlist holds the prefixes that are never limited, e.g. the monitoring of ICANN and the registry operator
+	Allowlist []netip.Prefix
+}
+
+// ParseRDDSRateLimitConfig creates a new RDDSRateLimitConfig from its string representation, e.g. the rate "5", the burst "20"
+// and the allowlist "192.0.2.1, 2001:db8::/32". An empty rate disables the limit, an empty burst allows a second worth of queries at once.
+func ParseRDDSRateLimitConfig(rate, burst, allowlist string) (*RDDSRateLimitConfig, error) {
+	cfg := &RDDSRateLimitConfig{
+		IPv4PrefixLength: RDDS_RATE_LIMIT_DEFAULT_IPV4_PREFIX_LENGTH,
+		IPv6PrefixLength: RDDS_RATE_LIMIT_DEFAULT_IPV6_PREFIX_LENGTH,
+	}
+	var err error
+	if rate != "" {
+		cfg.Rate, err = strconv.ParseFloat(rate, 64)
+		if err != nil || cfg.Rate <= 0 || math.IsInf(cfg.Rate, 0) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRDDSRateLimit, rate)
+		}
+		cfg.Burst = int(math.Ceil(cfg.Rate))
+	}
+	if burst != "" {
+		cfg.Burst, err = strconv.Atoi(burst)
+		if err != nil || cfg.Burst < 1 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRDDSRateLimitBurst, burst)
+		}
+	}
+	for _, s := range strings.Split(allowlist, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(s)
+			if addrErr != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidRDDSAllowlist, err)
+			}
+			prefix = netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen())
+		}
+		cfg.Allowlist = append(cfg.Allowlist, prefix.Masked())
+	}
+	return cfg, nil
+}
+
+// rddsTokenBucket holds the tokens of a source, every query takes a token and the tokens are refilled at the configured rate
+type rddsTokenBucket struct {
+	tokens  float64
+	updated time.Time
+	limited bool
+}
+
+// TokenBucketRDDSRateLimiter implements the RDDSRateLimiter interface with a token bucket per source prefix.
+// All queries are counted in the rdds_queries_total metric by service and result, which provides the RDDS query counts of the monthly registry activity report.
+type TokenBucketRDDSRateLimiter struct {
+	cfg       RDDSRateLimitConfig
+	mu        sync.Mutex
+	buckets   map[netip.Prefix]*rddsTokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+	queries   *prometheus.CounterVec
+	logger    *zap.Logger
+}
+
+// NewTokenBucketRDDSRateLimiter creates a new instance of TokenBucketRDDSRateLimiter and registers its metrics with the registerer, if one is provided
+func NewTokenBucketRDDSRateLimiter(cfg RDDSRateLimitConfig, reg prometheus.Registerer) *TokenBucketRDDSRateLimiter {
+	logger, _ := zap.NewProduction()
+	if cfg.IPv4PrefixLength <= 0 || cfg.IPv4PrefixLength > 32 {
+		cfg.IPv4PrefixLength = RDDS_RATE_LIMIT_DEFAULT_IPV4_PREFIX_LENGTH
+	}
+	if cfg.IPv6PrefixLength <= 0 || cfg.IPv6PrefixLength > 128 {
+		cfg.IPv6PrefixLength = RDDS_RATE_LIMIT_DEFAULT_IPV6_PREFIX_LENGTH
+	}
+	if cfg.Burst < 1 {
+		cfg.Burst = 1
+	}
+	l := &TokenBucketRDDSRateLimiter{
+		cfg:     cfg,
+		buckets: map[netip.Prefix]*rddsTokenBucket{},
+		now:     time.Now,
+		queries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "rdds_queries_total",
+			Help: "The number of RDDS queries by service and result",
+		}, []string{"service", "result"}),
+		logger: logger,
+	}
+	if reg != nil {
+		reg.MustRegister(l.queries)
+	}
+	return l
+}
+
+// Allow counts the query of the service and reports whether a query from the address may be answered.
+// Queries from an unknown address (e.g. a unix socket) and from the allowlist are always answered.
+func (l *TokenBucketRDDSRateLimiter) Allow(service string, addr netip.Addr) bool {
+	allowed := l.allow(addr)
+	result := RDDS_QUERY_RESULT_ALLOWED
+	if !allowed {
+		result = RDDS_QUERY_RESULT_RATE_LIMITED
+	}
+	l.queries.WithLabelValues(service, result).Inc()
+	return allowed
+}
+
+// QueryCount returns the number of queries of the service with the result, e.g. RDDS_QUERY_RESULT_ALLOWED
+func (l *TokenBucketRDDSRateLimiter) QueryCount(service, result string) (uint64, error) {
+	c, err := l.queries.GetMetricWithLabelValues(service, result)
+	if err != nil {
+		return 0, err
+	}
+	m := &dto.Metric{}
+	if err := c.Write(m); err != nil {
+		return 0, err
+	}
+	return uint64(m.GetCounter().GetValue()), nil
+}
+
+// allow takes a token from the bucket of the source
+func (l *TokenBucketRDDSRateLimiter) allow(addr netip.Addr) bool {
+	if l.cfg.Rate <= 0 || !addr.IsValid() {
+		return true
+	}
+	addr = addr.Unmap()
+	for _, p := range l.cfg.Allowlist {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	bits := l.cfg.IPv6PrefixLength
+	if addr.Is4() {
+		bits = l.cfg.IPv4PrefixLength
+	}
+	source, err := addr.Prefix(bits)
+	if err != nil {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.sweep(now)
+	b, ok := l.buckets[source]
+	if !ok {
+		b = &rddsTokenBucket{tokens: float64(l.cfg.Burst), updated: now}
+		l.buckets[source] = b
+	}
+	b.tokens = math.Min(float64(l.cfg.Burst), b.tokens+now.Sub(b.updated).Seconds()*l.cfg.Rate)
+	b.updated = now
+	if b.tokens < 1 {
+		if !b.limited {
+			b.limited = true
+			l.logger.Warn("RDDS query limit exceeded", zap.String("source", source.String()))
+		}
+		return false
+	}
+	b.tokens--
+	b.limited = false
+	return true
+}
+
+// sweep removes the buckets that have been refilled completely, a new bucket is full as well so this does not change the limits
+func (l *TokenBucketRDDSRateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < RDDS_RATE_LIMIT_SWEEP_INTERVAL {
+		return
+	}
+	l.lastSweep = now
+	for source, b := range l.buckets {
+		if b.tokens+now.Sub(b.updated).Seconds()*l.cfg.Rate >= float64(l.cfg.Burst) {
+			delete(l.buckets, source)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRDDSRateLimitConfig(t *testing.T) {
+	cfg, err := ParseRDDSRateLimitConfig("", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, float64(0), cfg.Rate)
+	assert.Empty(t, cfg.Allowlist)
+
+	cfg, err = ParseRDDSRateLimitConfig("2.5", "", "192.0.2.1, 2001:db8::/32,")
+	require.NoError(t, err)
+	assert.Equal(t, 2.5, cfg.Rate)
+	assert.Equal(t, 3, cfg.Burst)
+	assert.Equal(t, []netip.Prefix{netip.MustParsePrefix("192.0.2.1/32"), netip.MustParsePrefix("2001:db8::/32")}, cfg.Allowlist)
+	assert.Equal(t, RDDS_RATE_LIMIT_DEFAULT_IPV4_PREFIX_LENGTH, cfg.IPv4PrefixLength)
+	assert.Equal(t, RDDS_RATE_LIMIT_DEFAULT_IPV6_PREFIX_LENGTH, cfg.IPv6PrefixLength)
+
+	cfg, err = ParseRDDSRateLimitConfig("5", "20", "")
+	require.NoError(t, err)
+	assert.Equal(t, 20, cfg.Burst)
+
+	_, err = ParseRDDSRateLimitConfig("-1", "", "")
+	assert.ErrorIs(t, err, ErrInvalidRDDSRateLimit)
+	_, err = ParseRDDSRateLimitConfig("5", "none", "")
+	assert.ErrorIs(t, err, ErrInvalidRDDSRateLimitBurst)
+	_, err = ParseRDDSRateLimitConfig("5", "", "not-an-ip")
+	assert.ErrorIs(t, err, ErrInvalidRDDSAllowlist)
+}
+
+func TestTokenBucketRDDSRateLimiter_Allow(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewTokenBucketRDDSRateLimiter(RDDSRateLimitConfig{
+		Rate:      1,
+		Burst:     2,
+		Allowlist: []netip.Prefix{netip.MustParsePrefix("198.51.100.0/24")},
+	}, prometheus.NewRegistry())
+	l.now = func() time.Time { return now }
+
+	source := netip.MustParseAddr("192.0.2.1")
+	assert.True(t, l.Allow(RDDS_SERVICE_WHOIS, source))
+	assert.True(t, l.Allow(RDDS_SERVICE_RDAP, source))
+	assert.False(t, l.Allow(RDDS_SERVICE_WHOIS, source), "the burst is used up")
+
+	// Other sources have their own bucket
+	assert.True(t, l.Allow(RDDS_SERVICE_WHOIS, netip.MustParseAddr("192.0.2.2")))
+
+	// IPv6 addresses in the same /64 share a bucket
+	assert.True(t, l.Allow(RDDS_SERVICE_RDAP, netip.MustParseAddr("2001:db8::1")))
+	assert.True(t, l.Allow(RDDS_SERVICE_RDAP, netip.MustParseAddr("2001:db8::2")))
+	assert.False(t, l.Allow(RDDS_SERVICE_RDAP, netip.MustParseAddr("2001:db8::3")))
+
+	// The allowlist and unknown sources are never limited
+	for i := 0; i < 5; i++ {
+		assert.True(t, l.Allow(RDDS_SERVICE_WHOIS, netip.MustParseAddr("198.51.100.7")))
+		assert.True(t, l.Allow(RDDS_SERVICE_WHOIS, netip.Addr{}))
+	}
+
+	// The bucket is refilled at the configured rate
+	now = now.Add(time.Second)
+	assert.True(t, l.Allow(RDDS_SERVICE_WHOIS, source))
+	assert.False(t, l.Allow(RDDS_SERVICE_WHOIS, source))
+
+	// Sources that have not queried for a while are removed
+	now = now.Add(RDDS_RATE_LIMIT_SWEEP_INTERVAL)
+	assert.True(t, l.Allow(RDDS_SERVICE_WHOIS, netip.MustParseAddr("192.0.2.3")))
+	assert.Len(t, l.buckets, 1)
+
+	// All queries are counted
+	count, err := l.QueryCount(RDDS_SERVICE_WHOIS, RDDS_QUERY_RESULT_ALLOWED)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(14), count)
+	count, err = l.QueryCount(RDDS_SERVICE_WHOIS, RDDS_QUERY_RESULT_RATE_LIMITED)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+	count, err = l.QueryCount(RDDS_SERVICE_RDAP, RDDS_QUERY_RESULT_RATE_LIMITED)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+}
+
+func TestTokenBucketRDDSRateLimiter_Disabled(t *testing.T) {
+	l := NewTokenBucketRDDSRateLimiter(RDDSRateLimitConfig{}, nil)
+	for i := 0; i < 100; i++ {
+		assert.True(t, l.Allow(RDDS_SERVICE_WEB_WHOIS, netip.MustParseAddr("192.0.2.1")))
+	}
+	count, err := l.QueryCount(RDDS_SERVICE_WEB_WHOIS, RDDS_QUERY_RESULT_ALLOWED)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), count)
+}
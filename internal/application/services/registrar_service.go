@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/onasunnymorning/domain-os/internal/application/commands"
 	"github.com/onasunnymorning/domain-os/internal/application/queries"
@@ -91,6 +92,10 @@ func (s *RegistrarService) Update(ctx context.Context, rar *entities.Registrar)
 	// make a copy of the original
 	previousRar := registrar.DeepCopy()
 
+	// the EPP password is managed through SetEPPPassword and the login state through LoginEPP, so we keep the stored values
+	rar.EPPPasswordHash = registrar.EPPPasswordHash
+	rar.EPPLogin = registrar.EPPLogin
+
 	// update the registrar
 	updatedRar, err := s.registrarRepository.Update(ctx, rar)
 	if err != nil {
@@ -160,6 +165,99 @@ func (s *RegistrarService) SetStatus(ctx context.Context, clid string, status en
 	return nil
 }
 
+// SetEPPPassword sets the password the registrar uses to login to the EPP server. Only the hash of the password is stored
+func (s *RegistrarService) SetEPPPassword(ctx context.Context, clid, password string) error {
+	// get the registrar
+	registrar, err := s.registrarRepository.GetByClID(ctx, clid, false)
+	if err != nil {
+		return err
+	}
+
+	// set the password using domain logic
+	err = registrar.SetEPPPassword(password)
+	if err != nil {
+		return err
+	}
+
+	// save the registrar
+	_, err = s.registrarRepository.Update(ctx, registrar)
+	if err != nil {
+		return err
+	}
+
+	// Log the registrar lifecycle event, the registrar itself is omitted as there is nothing else to see
+	event := entities.NewRegistrarLifecycleEvent(clid, entities.RegistrarEventTypeUpdate)
+	s.logLifecycleEvent(ctx, fmt.Sprintf("registrar %s EPP password changed", clid), event, nil, nil, nil)
+
+	return nil
+}
+
+// LoginEPP authenticates a registrar at the EPP server and records the login.
+// An unknown ClID results in ErrEPPAuthenticationFailed so we don't disclose which registrars exist.
+// Failed attempts are counted and lock out the registrar once EPP_MAX_FAILED_LOGINS is reached, no credentials are verified while the registrar is locked out.
+// An expired password can only be used to login if a new password is provided at the same time.
+func (s *RegistrarService) LoginEPP(ctx context.Context, cmd *commands.EPPLoginCommand) (*commands.EPPLoginResult, error) {
+	registrar, err := s.registrarRepository.GetByClID(ctx, cmd.ClID, false)
+	if err != nil {
+		if errors.Is(err, entities.ErrRegistrarNotFound) {
+			return nil, entities.ErrEPPAuthenticationFailed
+		}
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if err := registrar.CheckEPPLoginLock(now); err != nil {
+		return nil, err
+	}
+
+	if err := registrar.VerifyEPPPassword(cmd.Password); err != nil {
+		s.recordFailedEPPLogin(ctx, registrar.ClID.String(), now)
+		return nil, err
+	}
+
+	result := &commands.EPPLoginResult{
+		FailedLogins: registrar.EPPLogin.FailedLogins,
+	}
+
+	if cmd.NewPassword != "" {
+		if err := registrar.SetEPPPassword(cmd.NewPassword); err != nil {
+			return nil, err
+		}
+		result.PasswordChanged = true
+	} else if registrar.EPPPasswordExpired(now) {
+		return nil, entities.ErrEPPPasswordExpired
+	}
+
+	registrar.RecordEPPLogin(now, cmd.UserAgent)
+
+	result.Registrar, err = s.registrarRepository.Update(ctx, registrar)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.PasswordChanged {
+		// Log the registrar lifecycle event, the registrar itself is omitted as there is nothing else to see
+		event := entities.NewRegistrarLifecycleEvent(cmd.ClID, entities.RegistrarEventTypeUpdate)
+		s.logLifecycleEvent(ctx, fmt.Sprintf("registrar %s EPP password changed at login", cmd.ClID), event, nil, nil, nil)
+	}
+
+	return result, nil
+}
+
+// recordFailedEPPLogin counts a failed login attempt of the registrar. The attempt is counted by the repository in a single update so concurrent failed logins can't overwrite each other.
+// Failing to store the attempt is logged, the login fails regardless.
+func (s *RegistrarService) recordFailedEPPLogin(ctx context.Context, clid string, t time.Time) {
+	failedLogins, lockedUntil, err := s.registrarRepository.RecordFailedEPPLogin(ctx, clid, t)
+	if err != nil {
+		s.logger.Error("failed to record failed EPP login", zap.String("clid", clid), zap.Error(err))
+		return
+	}
+	if t.Before(lockedUntil) {
+		event := entities.NewRegistrarLifecycleEvent(clid, entities.RegistrarEventTypeUpdate)
+		s.logLifecycleEvent(ctx, fmt.Sprintf("registrar %s EPP login locked after %d failed attempts", clid, failedLogins), event, nil, nil, nil)
+	}
+}
+
 // bulkRarFromCmd creates a slice of registrars from a slice of Create Registrar Commands
 func bulkRarFromCmd(cmds []*commands.CreateRegistrarCommand) ([]*entities.Registrar, error) {
 	var rars []*entities.Registrar
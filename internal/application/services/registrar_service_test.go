@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistrarService_SetEPPPassword(t *testing.T) {
+	mockRarRepo := new(repositories.MockRegistrarRepository)
+	service := NewRegistrarService(mockRarRepo)
+
+	rar := &entities.Registrar{ClID: "testClID", Status: entities.RegistrarStatusOK}
+	mockRarRepo.On("GetByClID", mock.Anything, "testClID", false).Return(rar, nil)
+	mockRarRepo.On("Update", mock.Anything, mock.MatchedBy(func(r *entities.Registrar) bool {
+		return r.VerifyEPPPassword("s3cr3tPW-2024!") == nil
+	})).Return(rar, nil)
+
+	// An invalid password is not saved
+	err := service.SetEPPPassword(context.TODO(), "testClID", "short")
+	assert.ErrorIs(t, err, entities.ErrInvalidEPPPassword)
+	mockRarRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+
+	err = service.SetEPPPassword(context.TODO(), "testClID", "s3cr3tPW-2024!")
+	assert.NoError(t, err)
+	mockRarRepo.AssertExpectations(t)
+}
+
+// getLoginTestRegistrar returns a registrar with the EPP password s3cr3tPW-2024! that was set at the provided time
+func getLoginTestRegistrar(t *testing.T, pwChangedAt time.Time) *entities.Registrar {
+	rar := &entities.Registrar{ClID: "testClID", Status: entities.RegistrarStatusOK}
+	require.NoError(t, rar.SetEPPPassword("s3cr3tPW-2024!"))
+	rar.EPPLogin.PasswordChangedAt = pwChangedAt
+	return rar
+}
+
+func TestRegistrarService_LoginEPP(t *testing.T) {
+	now := time.Now().UTC()
+	ua := entities.EPPUserAgent{App: "EPP SDK 1.0.0", Tech: "Go 1.22", OS: "x86_64 Linux"}
+
+	testcases := []struct {
+		name        string
+		rar         func() *entities.Registrar
+		cmd         commands.EPPLoginCommand
+		wantErr     error
+		wantUpdate  bool
+		wantRecord  bool
+		wantFailed  int
+		wantChanged bool
+	}{
+		{
+			name:       "valid credentials",
+			rar:        func() *entities.Registrar { return getLoginTestRegistrar(t, now) },
+			cmd:        commands.EPPLoginCommand{ClID: "testClID", Password: "s3cr3tPW-2024!", UserAgent: ua},
+			wantUpdate: true,
+		},
+		{
+			name: "previous failed logins are reported",
+			rar: func() *entities.Registrar {
+				rar := getLoginTestRegistrar(t, now)
+				rar.EPPLogin.FailedLogins = 2
+				return rar
+			},
+			cmd:        commands.EPPLoginCommand{ClID: "testClID", Password: "s3cr3tPW-2024!"},
+			wantUpdate: true,
+			wantFailed: 2,
+		},
+		{
+			name:       "wrong password is recorded",
+			rar:        func() *entities.Registrar { return getLoginTestRegistrar(t, now) },
+			cmd:        commands.EPPLoginCommand{ClID: "testClID", Password: "wr0ngPW!"},
+			wantErr:    entities.ErrEPPAuthenticationFailed,
+			wantRecord: true,
+		},
+		{
+			name:    "unknown registrar",
+			rar:     func() *entities.Registrar { return getLoginTestRegistrar(t, now) },
+			cmd:     commands.EPPLoginCommand{ClID: "unknown", Password: "s3cr3tPW-2024!"},
+			wantErr: entities.ErrEPPAuthenticationFailed,
+		},
+		{
+			name: "locked out",
+			rar: func() *entities.Registrar {
+				rar := getLoginTestRegistrar(t, now)
+				rar.EPPLogin.FailedLogins = entities.EPP_MAX_FAILED_LOGINS
+				rar.EPPLogin.LockedUntil = now.Add(time.Minute)
+				return rar
+			},
+			cmd:     commands.EPPLoginCommand{ClID: "testClID", Password: "s3cr3tPW-2024!"},
+			wantErr: entities.ErrEPPLoginLocked,
+		},
+		{
+			name:    "expired password",
+			rar:     func() *entities.Registrar { return getLoginTestRegistrar(t, now.Add(-entities.EPP_PASSWORD_MAX_AGE)) },
+			cmd:     commands.EPPLoginCommand{ClID: "testClID", Password: "s3cr3tPW-2024!"},
+			wantErr: entities.ErrEPPPasswordExpired,
+		},
+		{
+			name:        "expired password with new password",
+			rar:         func() *entities.Registrar { return getLoginTestRegistrar(t, now.Add(-entities.EPP_PASSWORD_MAX_AGE)) },
+			cmd:         commands.EPPLoginCommand{ClID: "testClID", Password: "s3cr3tPW-2024!", NewPassword: "n3wS3cr3t-2025!"},
+			wantUpdate:  true,
+			wantChanged: true,
+		},
+		{
+			name:    "new password does not meet the policy",
+			rar:     func() *entities.Registrar { return getLoginTestRegistrar(t, now) },
+			cmd:     commands.EPPLoginCommand{ClID: "testClID", Password: "s3cr3tPW-2024!", NewPassword: "n3wS3cr3t"},
+			wantErr: entities.ErrInvalidEPPPassword,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			rar := tc.rar()
+			mockRarRepo := new(repositories.MockRegistrarRepository)
+			service := NewRegistrarService(mockRarRepo)
+			mockRarRepo.On("GetByClID", mock.Anything, "testClID", false).Return(rar, nil)
+			mockRarRepo.On("GetByClID", mock.Anything, "unknown", false).Return((*entities.Registrar)(nil), entities.ErrRegistrarNotFound)
+			mockRarRepo.On("Update", mock.Anything, rar).Return(rar, nil)
+			mockRarRepo.On("RecordFailedEPPLogin", mock.Anything, "testClID", mock.Anything).Return(1, time.Time{}, nil)
+
+			result, err := service.LoginEPP(context.TODO(), &tc.cmd)
+			if tc.wantUpdate {
+				mockRarRepo.AssertCalled(t, "Update", mock.Anything, rar)
+			} else {
+				mockRarRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			}
+			if tc.wantRecord {
+				mockRarRepo.AssertCalled(t, "RecordFailedEPPLogin", mock.Anything, "testClID", mock.Anything)
+			} else {
+				mockRarRepo.AssertNotCalled(t, "RecordFailedEPPLogin", mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, rar, result.Registrar)
+			assert.Equal(t, tc.wantFailed, result.FailedLogins)
+			assert.Equal(t, tc.wantChanged, result.PasswordChanged)
+			assert.Zero(t, rar.EPPLogin.FailedLogins)
+			assert.False(t, rar.EPPLogin.LastLoginAt.IsZero())
+			assert.Equal(t, tc.cmd.UserAgent, rar.EPPLogin.UserAgent)
+			if tc.wantChanged {
+				assert.NoError(t, rar.VerifyEPPPassword(tc.cmd.NewPassword))
+				assert.False(t, rar.EPPPasswordExpired(now))
+			}
+		})
+	}
+}
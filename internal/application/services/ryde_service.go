@@ -0,0 +1,258 @@
+package services
+
+import (
+	"bytes"
+	"crypto"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+)
+
+const (
+	RYDE_EXTENSION      = ".ryde"
+	RYDE_SIG_EXTENSION  = ".sig"
+	RYDE_XML_EXTENSION  = ".xml"
+	RYDE_ARMOR_PREAMBLE = "-----BEGIN PGP"
+)
+
+var (
+	ErrMissingRyDEKey      = errors.New("missing OpenPGP key")
+	ErrRyDEKeyLocked       = errors.New("the OpenPGP private key is protected by a passphrase")
+	ErrRyDENotEncrypted    = errors.New("the RyDE file is not encrypted")
+	ErrRyDEInvalidFileName = errors.New("invalid file name, expected an .xml deposit or a .ryde file")
+)
+
+// RyDEKeys holds the OpenPGP keys used to package and unpack RyDE escrow deposits
+type RyDEKeys struct {
+	// EncryptTo are the public keys of the escrow agent the deposit is encrypted to
+	EncryptTo openpgp.EntityList
+	// SignWith is the private key of the registry used to sign the deposit
+	SignWith *openpgp.Entity
+	// DecryptWith are the private keys used to decrypt a received deposit
+	DecryptWith openpgp.EntityList
+	// VerifyWith are the public keys of the depositor used to verify the signature of a received deposit
+	VerifyWith openpgp.EntityList
+}
+
+// RyDEService packages escrow deposits in the RyDE format as required by ICANN: the deposit is compressed and encrypted
+// to the key of the escrow agent in a single OpenPGP message (.ryde) which is signed with a detached signature (.sig).
+// It also does the reverse, verifying and decrypting a received deposit so it can be analyzed by the XMLEscrowService.
+type RyDEService struct {
+	Keys   RyDEKeys
+	config *packet.Config
+}
+
+// NewRyDEService creates a new instance of RyDEService
+func NewRyDEService(keys RyDEKeys) *RyDEService {
+	return &RyDEService{
+		Keys: keys,
+		config: &packet.Config{
+			DefaultHash:            crypto.SHA256,
+			DefaultCipher:          packet.CipherAES256,
+			DefaultCompressionAlgo: packet.CompressionZIP,
+		},
+	}
+}
+
+// Package encrypts and signs the deposit file and returns the names of the .ryde and .sig files, which are written next to the deposit
+func (s *RyDEService) Package(depositFile string) (string, string, error) {
+	if len(s.Keys.EncryptTo) == 0 {
+		return "", "", fmt.Errorf("%w: no encryption key for the escrow agent", ErrMissingRyDEKey)
+	}
+	if s.Keys.SignWith == nil {
+		return "", "", fmt.Errorf("%w: no signing key", ErrMissingRyDEKey)
+	}
+	if filepath.Ext(depositFile) != RYDE_XML_EXTENSION {
+		return "", "", ErrRyDEInvalidFileName
+	}
+	base := strings.TrimSuffix(depositFile, RYDE_XML_EXTENSION)
+	rydeFile := base + RYDE_EXTENSION
+	sigFile := base + RYDE_SIG_EXTENSION
+
+	if err := s.encrypt(depositFile, rydeFile); err != nil {
+		os.Remove(rydeFile)
+		return "", "", err
+	}
+	if err := s.sign(rydeFile, sigFile); err != nil {
+		os.Remove(sigFile)
+		return "", "", err
+	}
+	return rydeFile, sigFile, nil
+}
+
+// encrypt writes the deposit as a compressed and encrypted OpenPGP message to rydeFile
+func (s *RyDEService) encrypt(depositFile, rydeFile string) error {
+	in, err := os.Open(depositFile)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	fi, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := os.Create(rydeFile)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	hints := &openpgp.FileHints{IsBinary: true, FileName: filepath.Base(depositFile), ModTime: fi.ModTime()}
+	w, err := openpgp.Encrypt(out, s.Keys.EncryptTo, nil, hints, s.config)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, in); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// sign writes a detached signature of rydeFile to sigFile
+func (s *RyDEService) sign(rydeFile, sigFile string) error {
+	in, err := os.Open(rydeFile)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(sigFile)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if err := openpgp.DetachSign(out, s.Keys.SignWith, in, s.config); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// Unpack verifies the signature of the .ryde file and decrypts it. The deposit is written next to the .ryde file with an .xml extension
+// and its name is returned. If sigFile is empty the signature is expected next to the .ryde file with a .sig extension.
+func (s *RyDEService) Unpack(rydeFile, sigFile string) (string, error) {
+	if len(s.Keys.DecryptWith) == 0 {
+		return "", fmt.Errorf("%w: no decryption key", ErrMissingRyDEKey)
+	}
+	if len(s.Keys.VerifyWith) == 0 {
+		return "", fmt.Errorf("%w: no key to verify the signature", ErrMissingRyDEKey)
+	}
+	if filepath.Ext(rydeFile) != RYDE_EXTENSION {
+		return "", ErrRyDEInvalidFileName
+	}
+	base := strings.TrimSuffix(rydeFile, RYDE_EXTENSION)
+	if sigFile == "" {
+		sigFile = base + RYDE_SIG_EXTENSION
+	}
+
+	if err := s.Verify(rydeFile, sigFile); err != nil {
+		return "", err
+	}
+
+	depositFile := base + RYDE_XML_EXTENSION
+	if err := s.decrypt(rydeFile, depositFile); err != nil {
+		os.Remove(depositFile)
+		return "", err
+	}
+	return depositFile, nil
+}
+
+// Verify checks the detached signature in sigFile of the .ryde file against the keys of the depositor
+func (s *RyDEService) Verify(rydeFile, sigFile string) error {
+	in, err := os.Open(rydeFile)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	sig, err := os.Open(sigFile)
+	if err != nil {
+		return err
+	}
+	defer sig.Close()
+
+	if _, err := openpgp.CheckDetachedSignature(s.Keys.VerifyWith, in, sig, s.config); err != nil {
+		return fmt.Errorf("invalid signature for %s: %w", rydeFile, err)
+	}
+	return nil
+}
+
+// decrypt decrypts and decompresses the OpenPGP message in rydeFile to depositFile
+func (s *RyDEService) decrypt(rydeFile, depositFile string) error {
+	in, err := os.Open(rydeFile)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	md, err := openpgp.ReadMessage(in, s.Keys.DecryptWith, nil, s.config)
+	if err != nil {
+		return err
+	}
+	if !md.IsEncrypted {
+		return ErrRyDENotEncrypted
+	}
+
+	out, err := os.Create(depositFile)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, md.UnverifiedBody); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// ReadRyDEKeyRing reads an armored or binary OpenPGP key ring from a file.
+// If the key ring contains private keys that are protected by a passphrase, they are decrypted using the provided passphrase.
+func ReadRyDEKeyRing(filename, passphrase string) (openpgp.EntityList, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys openpgp.EntityList
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte(RYDE_ARMOR_PREAMBLE)) {
+		keys, err = openpgp.ReadArmoredKeyRing(bytes.NewReader(b))
+	} else {
+		keys, err = openpgp.ReadKeyRing(bytes.NewReader(b))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading key ring %s: %w", filename, err)
+	}
+
+	for _, e := range keys {
+		if err := unlockPrivateKey(e.PrivateKey, passphrase); err != nil {
+			return nil, err
+		}
+		for _, sub := range e.Subkeys {
+			if err := unlockPrivateKey(sub.PrivateKey, passphrase); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return keys, nil
+}
+
+// unlockPrivateKey decrypts the private key if it is protected by a passphrase
+func unlockPrivateKey(key *packet.PrivateKey, passphrase string) error {
+	if key == nil || !key.Encrypted {
+		return nil
+	}
+	if passphrase == "" {
+		return ErrRyDEKeyLocked
+	}
+	return key.Decrypt([]byte(passphrase))
+}
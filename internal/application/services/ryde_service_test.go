@@ -0,0 +1,125 @@
+package services
+
+import (
+	"crypto"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/stretchr/testify/require"
+)
+
+// newRyDETestKey generates a new OpenPGP key for the tests. Like keys generated by gpg, it lists SHA256 as a preferred hash.
+func newRyDETestKey(t *testing.T, name string) *openpgp.Entity {
+	t.Helper()
+	e, err := openpgp.NewEntity(name, "", name+"@apex.domains", &packet.Config{DefaultHash: crypto.SHA256})
+	require.NoError(t, err)
+	// The preferences are only included in the self signature when the identities are signed again
+	require.NoError(t, e.SerializePrivate(io.Discard, nil))
+	return e
+}
+
+// writeRyDETestKey writes the armored private or public key to a file and returns its name
+func writeRyDETestKey(t *testing.T, e *openpgp.Entity, private bool) string {
+	t.Helper()
+	filename := filepath.Join(t.TempDir(), e.PrimaryKey.KeyIdShortString()+".asc")
+	f, err := os.Create(filename)
+	require.NoError(t, err)
+	defer f.Close()
+
+	blockType := openpgp.PublicKeyType
+	if private {
+		blockType = openpgp.PrivateKeyType
+	}
+	w, err := armor.Encode(f, blockType, nil)
+	require.NoError(t, err)
+	if private {
+		require.NoError(t, e.SerializePrivate(w, nil))
+	} else {
+		require.NoError(t, e.Serialize(w))
+	}
+	require.NoError(t, w.Close())
+	return filename
+}
+
+func TestRyDEService_PackageUnpack(t *testing.T) {
+	registry := newRyDETestKey(t, "registry")
+	agent := newRyDETestKey(t, "agent")
+
+	// Read the keys back from files as they would be configured
+	signWith, err := ReadRyDEKeyRing(writeRyDETestKey(t, registry, true), "")
+	require.NoError(t, err)
+	encryptTo, err := ReadRyDEKeyRing(writeRyDETestKey(t, agent, false), "")
+	require.NoError(t, err)
+	decryptWith, err := ReadRyDEKeyRing(writeRyDETestKey(t, agent, true), "")
+	require.NoError(t, err)
+	verifyWith, err := ReadRyDEKeyRing(writeRyDETestKey(t, registry, false), "")
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	depositFile := filepath.Join(dir, "apex_2024-03-01_full_S1_R0.xml")
+	deposit := []byte(`<?xml version="1.0" encoding="UTF-8"?><rde:deposit type="FULL" id="202403010000" xmlns:rde="urn:ietf:params:xml:ns:rde-1.0"></rde:deposit>`)
+	require.NoError(t, os.WriteFile(depositFile, deposit, 0644))
+
+	packager := NewRyDEService(RyDEKeys{EncryptTo: encryptTo, SignWith: signWith[0]})
+	rydeFile, sigFile, err := packager.Package(depositFile)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "apex_2024-03-01_full_S1_R0.ryde"), rydeFile)
+	require.Equal(t, filepath.Join(dir, "apex_2024-03-01_full_S1_R0.sig"), sigFile)
+
+	// The agent receives the files without the original deposit
+	require.NoError(t, os.Remove(depositFile))
+	receiver := NewRyDEService(RyDEKeys{DecryptWith: decryptWith, VerifyWith: verifyWith})
+	unpacked, err := receiver.Unpack(rydeFile, "")
+	require.NoError(t, err)
+	require.Equal(t, depositFile, unpacked)
+	b, err := os.ReadFile(unpacked)
+	require.NoError(t, err)
+	require.Equal(t, deposit, b)
+
+	// A signature by another key is rejected
+	other := NewRyDEService(RyDEKeys{DecryptWith: decryptWith, VerifyWith: openpgp.EntityList{newRyDETestKey(t, "other")}})
+	_, err = other.Unpack(rydeFile, sigFile)
+	require.ErrorContains(t, err, "invalid signature")
+
+	// A tampered file is rejected
+	ryde, err := os.ReadFile(rydeFile)
+	require.NoError(t, err)
+	ryde[len(ryde)-1] ^= 0xff
+	require.NoError(t, os.WriteFile(rydeFile, ryde, 0644))
+	require.Error(t, receiver.Verify(rydeFile, sigFile))
+}
+
+func TestRyDEService_MissingKeys(t *testing.T) {
+	s := NewRyDEService(RyDEKeys{})
+	_, _, err := s.Package("apex.xml")
+	require.ErrorIs(t, err, ErrMissingRyDEKey)
+	_, err = s.Unpack("apex.ryde", "")
+	require.ErrorIs(t, err, ErrMissingRyDEKey)
+
+	key := newRyDETestKey(t, "registry")
+	s = NewRyDEService(RyDEKeys{EncryptTo: openpgp.EntityList{key}, SignWith: key, DecryptWith: openpgp.EntityList{key}, VerifyWith: openpgp.EntityList{key}})
+	_, _, err = s.Package("apex.txt")
+	require.ErrorIs(t, err, ErrRyDEInvalidFileName)
+	_, err = s.Unpack("apex.xml", "")
+	require.ErrorIs(t, err, ErrRyDEInvalidFileName)
+}
+
+func TestReadRyDEKeyRing(t *testing.T) {
+	key := newRyDETestKey(t, "registry")
+	keys, err := ReadRyDEKeyRing(writeRyDETestKey(t, key, false), "")
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.Equal(t, key.PrimaryKey.KeyId, keys[0].PrimaryKey.KeyId)
+
+	_, err = ReadRyDEKeyRing(filepath.Join(t.TempDir(), "missing.asc"), "")
+	require.Error(t, err)
+
+	// A locked private key needs a passphrase
+	key.PrivateKey.Encrypted = true
+	require.ErrorIs(t, unlockPrivateKey(key.PrivateKey, ""), ErrRyDEKeyLocked)
+}
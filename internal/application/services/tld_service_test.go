@@ -11,12 +11,26 @@ import (
 )
 
 type MockDNSRecordRepository struct {
-	Header *entities.TLDHeader
+	Header  *entities.TLDHeader
+	Records []*postgres.TLDDNSRecord
+	Updated []*postgres.TLDDNSRecord
 }
 
 // GetByZone returns a list of DNSRecords by zone
 func (repo *MockDNSRecordRepository) GetByZone(ctx context.Context, zone string) ([]*postgres.TLDDNSRecord, error) {
-	return nil, nil
+	var records []*postgres.TLDDNSRecord
+	for _, r := range repo.Records {
+		if r.Zone == zone {
+			records = append(records, r)
+		}
+	}
+	return records, nil
+}
+
+// Update updates a DNSRecord
+func (repo *MockDNSRecordRepository) Update(ctx context.Context, record *postgres.TLDDNSRecord) (*postgres.TLDDNSRecord, error) {
+	repo.Updated = append(repo.Updated, record)
+	return record, nil
 }
 
 // Create creates a DNSRecord
@@ -1,22 +1,43 @@
 package services
 
 import (
+	"errors"
+	"net/netip"
+	"slices"
+	"strconv"
+
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
 	"github.com/onasunnymorning/domain-os/internal/domain/entities"
 	"github.com/onasunnymorning/domain-os/internal/domain/repositories"
 	"golang.org/x/net/context"
 )
 
+const (
+	// WHOIS_MAX_NAMESERVERS_PER_IP is the maximum number of nameservers returned for a WHOIS query by IP address
+	WHOIS_MAX_NAMESERVERS_PER_IP = 10
+)
+
+var (
+	ErrInvalidWhoisIPAddress = errors.New("invalid IP address")
+)
+
 // WhoisService implements the whois service interface
 type WhoisService struct {
-	domRepo repositories.DomainRepository
-	rarRepo repositories.RegistrarRepository
+	domRepo     repositories.DomainRepository
+	hostRepo    repositories.HostRepository
+	rarRepo     repositories.RegistrarRepository
+	contactRepo repositories.ContactRepository
+	tldRepo     repositories.TLDRepository
 }
 
 // NewWhoisService creates a new instance of WhoisService
-func NewWhoisService(domRepo repositories.DomainRepository, rarRepo repositories.RegistrarRepository) *WhoisService {
+func NewWhoisService(domRepo repositories.DomainRepository, hostRepo repositories.HostRepository, rarRepo repositories.RegistrarRepository, contactRepo repositories.ContactRepository, tldRepo repositories.TLDRepository) *WhoisService {
 	return &WhoisService{
-		domRepo: domRepo,
-		rarRepo: rarRepo,
+		domRepo:     domRepo,
+		hostRepo:    hostRepo,
+		rarRepo:     rarRepo,
+		contactRepo: contactRepo,
+		tldRepo:     tldRepo,
 	}
 }
 
@@ -34,11 +55,115 @@ func (s *WhoisService) GetDomainWhois(ctx context.Context, dn string) (*entities
 		return nil, err
 	}
 
+	// Look up the contacts allowed by the contact data policy of the TLD
+	policy, err := getContactDataPolicy(ctx, s.tldRepo, dom.TLDName.String())
+	if err != nil {
+		return nil, err
+	}
+	contacts, err := getRDDSContacts(ctx, s.contactRepo, dom, policy)
+	if err != nil {
+		return nil, err
+	}
+	wc := entities.WhoisDomainContacts{}
+	for _, c := range contacts {
+		if slices.Contains(c.roles, entities.RDAPRoleRegistrant) {
+			wc.Registrant = c.contact
+		}
+		if slices.Contains(c.roles, entities.RDAPRoleAdministrative) {
+			wc.Admin = c.contact
+		}
+		if slices.Contains(c.roles, entities.RDAPRoleTechnical) {
+			wc.Tech = c.contact
+		}
+	}
+
 	// Populate the whois response
-	wr, err := entities.NewWhoisResponse(dom, rar)
+	wr, err := entities.NewWhoisResponse(dom, rar, wc)
 	if err != nil {
 		return nil, err
 	}
 
 	return wr, nil
 }
+
+// GetNameserverWhois returns the whois information of a nameserver
+func (s *WhoisService) GetNameserverWhois(ctx context.Context, name string) (*entities.WhoisNameserverResponse, error) {
+	hn, err := entities.NewDomainName(name)
+	if err != nil {
+		return nil, err
+	}
+	hosts, _, err := s.hostRepo.ListHosts(ctx, queries.ListItemsQuery{
+		PageSize: 1,
+		Filter:   queries.ListHostsFilter{NameEquals: hn.String()},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(hosts) == 0 {
+		return nil, entities.ErrHostNotFound
+	}
+	return s.newNameserverWhois(ctx, hosts[0])
+}
+
+// GetNameserverWhoisByIP returns the whois information of the nameservers that have the IP address, up to WHOIS_MAX_NAMESERVERS_PER_IP
+func (s *WhoisService) GetNameserverWhoisByIP(ctx context.Context, ip string) ([]*entities.WhoisNameserverResponse, error) {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidWhoisIPAddress, err)
+	}
+	hosts, _, err := s.hostRepo.ListHosts(ctx, queries.ListItemsQuery{
+		PageSize: WHOIS_MAX_NAMESERVERS_PER_IP,
+		Filter:   queries.ListHostsFilter{AddressEquals: addr.String()},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(hosts) == 0 {
+		return nil, entities.ErrHostNotFound
+	}
+	responses := make([]*entities.WhoisNameserverResponse, len(hosts))
+	for i, h := range hosts {
+		responses[i], err = s.newNameserverWhois(ctx, h)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return responses, nil
+}
+
+// newNameserverWhois looks up the sponsoring registrar of the host and populates the whois response
+func (s *WhoisService) newNameserverWhois(ctx context.Context, h *entities.Host) (*entities.WhoisNameserverResponse, error) {
+	rar, err := s.rarRepo.GetByClID(ctx, h.ClID.String(), false)
+	if err != nil {
+		return nil, err
+	}
+	return entities.NewWhoisNameserverResponse(h, rar), nil
+}
+
+// GetRegistrarWhois returns the whois information of a registrar. A numeric query is looked up as the IANA ID of the registrar,
+// other queries are looked up as the name of the registrar (case insensitive).
+func (s *WhoisService) GetRegistrarWhois(ctx context.Context, query string) (*entities.WhoisRegistrarResponse, error) {
+	if gurID, err := strconv.Atoi(query); err == nil {
+		rar, err := s.rarRepo.GetByGurID(ctx, gurID)
+		if err != nil {
+			return nil, err
+		}
+		return entities.NewWhoisRegistrarResponse(rar), nil
+	}
+
+	rars, _, err := s.rarRepo.List(ctx, queries.ListItemsQuery{
+		PageSize: 1,
+		Filter:   queries.ListRegistrarsFilter{NameEquals: query},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rars) == 0 {
+		return nil, entities.ErrRegistrarNotFound
+	}
+	rar, err := s.rarRepo.GetByClID(ctx, rars[0].ClID.String(), false)
+	if err != nil {
+		return nil, err
+	}
+	return entities.NewWhoisRegistrarResponse(rar), nil
+}
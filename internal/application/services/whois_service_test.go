@@ -5,9 +5,11 @@ package services
 import (
 	"context"
 	"errors"
+	"net/netip"
 	"testing"
 	"time"
 
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
 	"github.com/onasunnymorning/domain-os/internal/domain/entities"
 	"github.com/onasunnymorning/domain-os/internal/domain/repositories"
 	"github.com/stretchr/testify/assert"
@@ -20,7 +22,7 @@ func TestGetDomainWhois(t *testing.T) {
 	mockRarRepo := new(repositories.MockRegistrarRepository)
 
 	// Create a WhoisService with the mock repositories
-	service := NewWhoisService(mockDomRepo, mockRarRepo)
+	service := NewWhoisService(mockDomRepo, &repositories.MockHostRepository{}, mockRarRepo, getTestRDDSContactRepository(t), &fakeRDDSTLDRepository{})
 
 	// Define test data
 	domainName := "example.com"
@@ -34,6 +36,8 @@ func TestGetDomainWhois(t *testing.T) {
 		Status: entities.DomainStatus{
 			OK: true,
 		},
+		RegistrantID: "cont1",
+		TechID:       "cont1",
 		Hosts: []*entities.Host{
 			{
 				Name: "ns1.example.com",
@@ -81,10 +85,17 @@ func TestGetDomainWhois(t *testing.T) {
 	assert.Equal(t, "+1.5555555555", result.RegistrarAbuseContactPhone)
 	assert.Equal(t, []string{"ok"}, result.DomainStatus)
 	assert.Equal(t, []string{"ns1.example.com", "ns2.example.com"}, result.NameServers)
-	assert.Equal(t, "unsigned", result.DNSSEC)
-	assert.Equal(t, "URL of the ICANN Whois Inaccuracy Complaint Form: https://www.icann.org/wicf/", result.ICANNComplaintURL)
+	assert.Equal(t, entities.WhoisDNSSECUnsigned, result.DNSSEC)
+	assert.Equal(t, entities.WHOIS_INACCURACY_COMPLAINT_URL, result.ICANNComplaintURL)
 	assert.Equal(t, time.Now().Format(time.RFC3339), result.LastWhoisUpdate.Format(time.RFC3339))
 
+	// The contacts are always redacted
+	assert.Len(t, result.Contacts, 2)
+	assert.Equal(t, "Registrant", result.Contacts[0].Label)
+	assert.Equal(t, "Tech", result.Contacts[1].Label)
+	assert.Equal(t, entities.RDDS_REDACTED_FOR_PRIVACY, result.Contacts[0].Name)
+	assert.Equal(t, entities.RDDS_REDACTED_EMAIL, result.Contacts[0].Email)
+
 	// Verify that the expectations were met
 	mockDomRepo.AssertExpectations(t)
 	mockRarRepo.AssertExpectations(t)
@@ -96,7 +107,7 @@ func TestGetDomainWhois_RegistrarRepoError(t *testing.T) {
 	mockRarRepo := new(repositories.MockRegistrarRepository)
 
 	// Create the service with the mock repositories
-	service := NewWhoisService(mockDomRepo, mockRarRepo)
+	service := NewWhoisService(mockDomRepo, &repositories.MockHostRepository{}, mockRarRepo, getTestRDDSContactRepository(t), &fakeRDDSTLDRepository{})
 
 	// Define test data
 	domainName := "example.com"
@@ -130,7 +141,7 @@ func TestGetDomainWhois_DomainRepoError(t *testing.T) {
 	mockRarRepo := new(repositories.MockRegistrarRepository)
 
 	// Create the service with the mock repositories
-	service := NewWhoisService(mockDomRepo, mockRarRepo)
+	service := NewWhoisService(mockDomRepo, &repositories.MockHostRepository{}, mockRarRepo, getTestRDDSContactRepository(t), &fakeRDDSTLDRepository{})
 
 	// Define test data
 	domainName := "example.com"
@@ -150,3 +161,75 @@ func TestGetDomainWhois_DomainRepoError(t *testing.T) {
 	mockDomRepo.AssertExpectations(t)
 	mockRarRepo.AssertExpectations(t)
 }
+
+func TestGetNameserverWhois(t *testing.T) {
+	h, err := entities.NewHost("ns1.example.com", "1_HOST-APEX", "testClID")
+	assert.NoError(t, err)
+	h.Addresses = []netip.Addr{netip.MustParseAddr("192.0.2.1")}
+	var filter queries.ListHostsFilter
+	hostRepo := &repositories.MockHostRepository{
+		ListHostsFunc: func(ctx context.Context, params queries.ListItemsQuery) ([]*entities.Host, string, error) {
+			filter = params.Filter.(queries.ListHostsFilter)
+			if filter.NameEquals == h.Name.String() || filter.AddressEquals == "192.0.2.1" {
+				return []*entities.Host{h}, "", nil
+			}
+			return nil, "", nil
+		},
+	}
+	mockRarRepo := new(repositories.MockRegistrarRepository)
+	mockRarRepo.On("GetByClID", mock.Anything, "testClID", false).Return(&entities.Registrar{Name: "Test Registrar"}, nil)
+	service := NewWhoisService(new(repositories.MockDomainRepository), hostRepo, mockRarRepo, getTestRDDSContactRepository(t), &fakeRDDSTLDRepository{})
+
+	// By name, the name is normalized
+	ns, err := service.GetNameserverWhois(context.TODO(), "NS1.example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "ns1.example.com", ns.ServerName)
+	assert.Equal(t, []string{"192.0.2.1"}, ns.IPAddresses)
+	assert.Equal(t, "Test Registrar", ns.Registrar)
+
+	_, err = service.GetNameserverWhois(context.TODO(), "ns2.example.com")
+	assert.ErrorIs(t, err, entities.ErrHostNotFound)
+
+	// By IP address
+	nameservers, err := service.GetNameserverWhoisByIP(context.TODO(), "192.0.2.1")
+	assert.NoError(t, err)
+	assert.Len(t, nameservers, 1)
+	assert.Equal(t, "ns1.example.com", nameservers[0].ServerName)
+
+	_, err = service.GetNameserverWhoisByIP(context.TODO(), "2001:DB8::1")
+	assert.ErrorIs(t, err, entities.ErrHostNotFound)
+	assert.Equal(t, "2001:db8::1", filter.AddressEquals)
+
+	_, err = service.GetNameserverWhoisByIP(context.TODO(), "not-an-ip")
+	assert.ErrorIs(t, err, ErrInvalidWhoisIPAddress)
+}
+
+func TestGetRegistrarWhois(t *testing.T) {
+	rar := &entities.Registrar{ClID: "testClID", Name: "Test Registrar", GurID: 2222, Email: "me@registrar.com"}
+	mockRarRepo := new(repositories.MockRegistrarRepository)
+	mockRarRepo.On("GetByGurID", mock.Anything, 2222).Return(rar, nil)
+	mockRarRepo.On("GetByGurID", mock.Anything, 3333).Return((*entities.Registrar)(nil), entities.ErrRegistrarNotFound)
+	mockRarRepo.On("List", mock.Anything, queries.ListItemsQuery{PageSize: 1, Filter: queries.ListRegistrarsFilter{NameEquals: "test registrar"}}).Return([]*entities.RegistrarListItem{{ClID: "testClID", Name: "Test Registrar"}}, nil)
+	mockRarRepo.On("List", mock.Anything, queries.ListItemsQuery{PageSize: 1, Filter: queries.ListRegistrarsFilter{NameEquals: "Unknown Registrar"}}).Return([]*entities.RegistrarListItem{}, nil)
+	mockRarRepo.On("GetByClID", mock.Anything, "testClID", false).Return(rar, nil)
+	service := NewWhoisService(new(repositories.MockDomainRepository), &repositories.MockHostRepository{}, mockRarRepo, getTestRDDSContactRepository(t), &fakeRDDSTLDRepository{})
+
+	// By IANA ID
+	resp, err := service.GetRegistrarWhois(context.TODO(), "2222")
+	assert.NoError(t, err)
+	assert.Equal(t, "Test Registrar", resp.Registrar)
+	assert.Equal(t, "me@registrar.com", resp.Email)
+
+	_, err = service.GetRegistrarWhois(context.TODO(), "3333")
+	assert.ErrorIs(t, err, entities.ErrRegistrarNotFound)
+
+	// By name
+	resp, err = service.GetRegistrarWhois(context.TODO(), "test registrar")
+	assert.NoError(t, err)
+	assert.Equal(t, "Test Registrar", resp.Registrar)
+
+	_, err = service.GetRegistrarWhois(context.TODO(), "Unknown Registrar")
+	assert.ErrorIs(t, err, entities.ErrRegistrarNotFound)
+
+	mockRarRepo.AssertExpectations(t)
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/miekg/dns"
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/domain/repositories"
+	"github.com/onasunnymorning/domain-os/internal/infrastructure/db/postgres"
+)
+
+var (
+	ErrTLDSOANotFound = errors.New("no SOA record found for the TLD, add one to the TLD DNS records")
+)
+
+// ZoneService implements the ZoneService interface
+type ZoneService struct {
+	tldRepository    repositories.TLDRepository
+	dnsRecRepo       repositories.TLDDNSRecordRepository
+	domainRepository repositories.DomainRepository
+}
+
+// NewZoneService returns a new ZoneService
+func NewZoneService(tldRepo repositories.TLDRepository, dnsRecRepo repositories.TLDDNSRecordRepository, domRepo repositories.DomainRepository) *ZoneService {
+	return &ZoneService{
+		tldRepository:    tldRepo,
+		dnsRecRepo:       dnsRecRepo,
+		domainRepository: domRepo,
+	}
+}
+
+// GenerateZone builds the complete zone for a TLD and publishes it with a new SOA serial (see BuildZone and PublishZone).
+func (s *ZoneService) GenerateZone(ctx context.Context, tldName string) (*entities.Zone, error) {
+	zone, err := s.BuildZone(ctx, tldName)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.PublishZone(ctx, zone); err != nil {
+		return nil, err
+	}
+	return zone, nil
+}
+
+// BuildZone builds the complete zone for a TLD using the current SOA serial. It merges the TLD DNS records (SOA and other apex records, see GetTLDHeader),
+// the NS and DS records of the delegated domains and the glue records of the hosts within the TLD.
+// Domains that are inactive, pending delete or on hold are not published.
+func (s *ZoneService) BuildZone(ctx context.Context, tldName string) (*entities.Zone, error) {
+	tld, err := s.tldRepository.GetByName(ctx, tldName, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect the apex records
+	soaRecord, records, err := s.getZoneRecords(ctx, tld.Name.String())
+	if err != nil {
+		return nil, err
+	}
+	soa, err := soaRecord.ToRR()
+	if err != nil {
+		return nil, err
+	}
+
+	zone, err := entities.NewZone(tld.Name.String(), soa.(*dns.SOA))
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range records {
+		rr, err := r.ToRR()
+		if err != nil {
+			return nil, err
+		}
+		if err := zone.AddRecords(rr); err != nil {
+			return nil, err
+		}
+	}
+
+	// Add the delegations (NS and DS records)
+	delegations, err := s.domainRepository.GetActiveDomainsWithHosts(ctx, queries.ActiveDomainsWithHostsQuery{TldName: tld.Name.String()})
+	if err != nil {
+		return nil, err
+	}
+	if err := zone.AddRecords(delegations...); err != nil {
+		return nil, err
+	}
+
+	// Add the glue, addresses of hosts in other TLDs are published in their own zone
+	glue, err := s.domainRepository.GetActiveDomainGlue(ctx, tld.Name.String())
+	if err != nil {
+		return nil, err
+	}
+	for _, rr := range glue {
+		if !zone.IsInZone(rr.Header().Name) {
+			continue
+		}
+		if err := zone.AddRecords(rr); err != nil {
+			return nil, err
+		}
+	}
+
+	return zone, nil
+}
+
+// PublishZone increments the SOA serial of the zone and stores it after the zone passes validation (see entities.CheckZone).
+// The serial of the zone is left unchanged if it fails validation.
+func (s *ZoneService) PublishZone(ctx context.Context, zone *entities.Zone) error {
+	soaRecord, _, err := s.getZoneRecords(ctx, strings.TrimSuffix(zone.Origin, "."))
+	if err != nil {
+		return err
+	}
+
+	serial := zone.SOA.Serial
+	zone.IncrementSerial()
+	if err := zone.Validate(); err != nil {
+		zone.SOA.Serial = serial
+		return err
+	}
+
+	// Store the new serial
+	var soaData postgres.SOARecordData
+	if err := json.Unmarshal([]byte(soaRecord.Data), &soaData); err != nil {
+		zone.SOA.Serial = serial
+		return err
+	}
+	soaData.Serial = zone.SOA.Serial
+	data, err := json.Marshal(soaData)
+	if err != nil {
+		zone.SOA.Serial = serial
+		return err
+	}
+	soaRecord.Data = string(data)
+	if _, err := s.dnsRecRepo.Update(ctx, soaRecord); err != nil {
+		zone.SOA.Serial = serial
+		return err
+	}
+
+	return nil
+}
+
+// ListZones returns the names of the TLDs that have DNS enabled, these are the zones that are published
+func (s *ZoneService) ListZones(ctx context.Context) ([]string, error) {
+	var zones []string
+	query := queries.ListItemsQuery{PageSize: 1000}
+	for {
+		tlds, cursor, err := s.tldRepository.List(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		for _, tld := range tlds {
+			if tld.EnableDNS {
+				zones = append(zones, tld.Name.String())
+			}
+		}
+		if cursor == "" {
+			return zones, nil
+		}
+		query.PageCursor = cursor
+	}
+}
+
+// getZoneRecords returns the SOA record and the other TLD DNS records of a zone
+func (s *ZoneService) getZoneRecords(ctx context.Context, zoneName string) (*postgres.TLDDNSRecord, []*postgres.TLDDNSRecord, error) {
+	records, err := s.dnsRecRepo.GetByZone(ctx, zoneName)
+	if err != nil {
+		return nil, nil, err
+	}
+	var soaRecord *postgres.TLDDNSRecord
+	var other []*postgres.TLDDNSRecord
+	for _, r := range records {
+		if r.Type != "SOA" {
+			other = append(other, r)
+			continue
+		}
+		if soaRecord != nil {
+			return nil, nil, errors.Join(entities.ErrInvalidZone, entities.ErrZoneMultipleSOA)
+		}
+		soaRecord = r
+	}
+	if soaRecord == nil {
+		return nil, nil, ErrTLDSOANotFound
+	}
+	return soaRecord, other, nil
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/domain/repositories"
+	"github.com/onasunnymorning/domain-os/internal/infrastructure/db/postgres"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newZoneServiceTestRRs(t *testing.T, records ...string) []dns.RR {
+	t.Helper()
+	rrs := make([]dns.RR, len(records))
+	for i, r := range records {
+		rr, err := dns.NewRR(r)
+		require.NoError(t, err)
+		rrs[i] = rr
+	}
+	return rrs
+}
+
+func TestZoneService_GenerateZone(t *testing.T) {
+	tld, err := entities.NewTLD("example", "ry-example")
+	require.NoError(t, err)
+	nsTarget := "ns1.nic.example"
+
+	newDNSRecRepo := func() *MockDNSRecordRepository {
+		return &MockDNSRecordRepository{
+			Records: []*postgres.TLDDNSRecord{
+				{ID: 1, Zone: "example", Name: "example", Type: "SOA", TTL: 3600, Data: `{"ns":"ns1.nic.example.","mbox":"hostmaster.nic.example.","serial":41,"refresh":1800,"retry":900,"expire":604800,"minttl":86400}`},
+				{ID: 2, Zone: "example", Name: "example", Type: "NS", TTL: 3600, Target: &nsTarget},
+				{ID: 3, Zone: "example", Name: "ns1.nic.example", Type: "A", TTL: 3600, Data: `{"address":"192.0.2.1"}`},
+			},
+		}
+	}
+
+	delegations := newZoneServiceTestRRs(t,
+		"a.example. 3600 IN NS ns1.a.example.",
+		"a.example. 3600 IN NS ns.other.net.",
+		"b.example. 3600 IN NS ns1.a.example.",
+		"a.example. 3600 IN DS 12345 13 2 E2D3C916F6DEEAC73294E8268FB5885044A833FC5459588F4A9184CFC41A5766",
+	)
+
+	t.Run("builds, validates and increments the serial", func(t *testing.T) {
+		dnsRecRepo := newDNSRecRepo()
+		domRepo := new(repositories.MockDomainRepository)
+		domRepo.On("GetActiveDomainsWithHosts", mock.Anything, queries.ActiveDomainsWithHostsQuery{TldName: "example"}).Return(delegations, nil)
+		// Duplicate glue and glue of other TLDs are dropped
+		domRepo.On("GetActiveDomainGlue", mock.Anything, "example").Return(newZoneServiceTestRRs(t,
+			"ns1.a.example. 3600 IN A 192.0.2.2",
+			"ns1.a.example. 3600 IN A 192.0.2.2",
+			"ns.other.net. 3600 IN A 192.0.2.3",
+		), nil)
+
+		svc := NewZoneService(&MocktldRepository{Tlds: []*entities.TLD{tld}}, dnsRecRepo, domRepo)
+		zone, err := svc.GenerateZone(context.Background(), "example")
+		require.NoError(t, err)
+
+		require.Equal(t, uint32(42), zone.SOA.Serial)
+		require.Len(t, zone.Records(), 8)
+		require.NoError(t, entities.CheckZone("example.", strings.NewReader(zone.String())))
+		require.NotContains(t, zone.String(), "ns.other.net.\t3600\tIN\tA")
+
+		// The serial is stored
+		require.Len(t, dnsRecRepo.Updated, 1)
+		rr, err := dnsRecRepo.Updated[0].ToRR()
+		require.NoError(t, err)
+		require.Equal(t, uint32(42), rr.(*dns.SOA).Serial)
+		domRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid zone does not store the serial", func(t *testing.T) {
+		dnsRecRepo := newDNSRecRepo()
+		domRepo := new(repositories.MockDomainRepository)
+		domRepo.On("GetActiveDomainsWithHosts", mock.Anything, mock.Anything).Return(delegations, nil)
+		domRepo.On("GetActiveDomainGlue", mock.Anything, "example").Return([]dns.RR{}, nil)
+
+		svc := NewZoneService(&MocktldRepository{Tlds: []*entities.TLD{tld}}, dnsRecRepo, domRepo)
+		_, err := svc.GenerateZone(context.Background(), "example")
+		require.ErrorIs(t, err, entities.ErrZoneNSMissingAddress)
+		require.Empty(t, dnsRecRepo.Updated)
+	})
+
+	t.Run("no SOA", func(t *testing.T) {
+		dnsRecRepo := newDNSRecRepo()
+		dnsRecRepo.Records = dnsRecRepo.Records[1:]
+
+		svc := NewZoneService(&MocktldRepository{Tlds: []*entities.TLD{tld}}, dnsRecRepo, new(repositories.MockDomainRepository))
+		_, err := svc.GenerateZone(context.Background(), "example")
+		require.ErrorIs(t, err, ErrTLDSOANotFound)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		domRepo := new(repositories.MockDomainRepository)
+		domRepo.On("GetActiveDomainsWithHosts", mock.Anything, mock.Anything).Return([]dns.RR(nil), errors.New("db error"))
+
+		svc := NewZoneService(&MocktldRepository{Tlds: []*entities.TLD{tld}}, newDNSRecRepo(), domRepo)
+		_, err := svc.GenerateZone(context.Background(), "example")
+		require.EqualError(t, err, "db error")
+	})
+}
+
+func TestZoneService_BuildZone_KeepsSerial(t *testing.T) {
+	tld, err := entities.NewTLD("example", "ry-example")
+	require.NoError(t, err)
+	nsTarget := "ns.other.net"
+	dnsRecRepo := &MockDNSRecordRepository{
+		Records: []*postgres.TLDDNSRecord{
+			{ID: 1, Zone: "example", Name: "example", Type: "SOA", TTL: 3600, Data: `{"ns":"ns1.nic.example.","mbox":"hostmaster.nic.example.","serial":41,"refresh":1800,"retry":900,"expire":604800,"minttl":86400}`},
+			{ID: 2, Zone: "example", Name: "example", Type: "NS", TTL: 3600, Target: &nsTarget},
+		},
+	}
+	domRepo := new(repositories.MockDomainRepository)
+	domRepo.On("GetActiveDomainsWithHosts", mock.Anything, mock.Anything).Return([]dns.RR{}, nil)
+	domRepo.On("GetActiveDomainGlue", mock.Anything, "example").Return([]dns.RR{}, nil)
+
+	svc := NewZoneService(&MocktldRepository{Tlds: []*entities.TLD{tld}}, dnsRecRepo, domRepo)
+	zone, err := svc.BuildZone(context.Background(), "example")
+	require.NoError(t, err)
+	require.Equal(t, uint32(41), zone.SOA.Serial)
+	require.Empty(t, dnsRecRepo.Updated)
+
+	require.NoError(t, svc.PublishZone(context.Background(), zone))
+	require.Equal(t, uint32(42), zone.SOA.Serial)
+	require.Len(t, dnsRecRepo.Updated, 1)
+}
+
+func TestZoneService_ListZones(t *testing.T) {
+	enabled, err := entities.NewTLD("enabled", "ry-example")
+	require.NoError(t, err)
+	enabled.ToggleEnableDNS(true)
+	disabled, err := entities.NewTLD("disabled", "ry-example")
+	require.NoError(t, err)
+
+	svc := NewZoneService(&MocktldRepository{Tlds: []*entities.TLD{enabled, disabled}}, &MockDNSRecordRepository{}, new(repositories.MockDomainRepository))
+	zones, err := svc.ListZones(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"enabled"}, zones)
+}
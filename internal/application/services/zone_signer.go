@@ -0,0 +1,324 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/domain/repositories"
+)
+
+// ZoneSigner signs TLD zones with DNSSEC using the keys of the zone and authenticated denial of existence with NSEC3.
+// Signatures are reused as long as the RRset does not change and the signature does not expire within the refresh window,
+// so signing an unchanged zone again yields the same zone until its signatures are due for a refresh.
+// Ref: https://datatracker.ietf.org/doc/html/rfc4035#section-2
+type ZoneSigner struct {
+	keyRepository repositories.DNSSECKeyRepository
+	cfg           DNSSECConfig
+	now           func() time.Time
+
+	mu         sync.Mutex
+	signatures map[string]map[string]*dns.RRSIG // by zone and signatureCacheKey
+}
+
+// NewZoneSigner returns a new ZoneSigner
+func NewZoneSigner(keyRepo repositories.DNSSECKeyRepository, cfg DNSSECConfig) *ZoneSigner {
+	return &ZoneSigner{
+		keyRepository: keyRepo,
+		cfg:           cfg,
+		now:           time.Now,
+		signatures:    map[string]map[string]*dns.RRSIG{},
+	}
+}
+
+// rrsetKey identifies an RRset in the zone
+type rrsetKey struct {
+	name   string
+	rrtype uint16
+}
+
+// SignZone returns a signed copy of the zone. Zones without keys are not signed and returned as is.
+// The published keys are added to the DNSKEY RRset, which is signed by the active KSKs. All other authoritative RRsets are signed by the active ZSKs,
+// or by the KSKs if there is no active ZSK (a combined signing key). Delegations and glue are not signed, only the DS records at the delegations are.
+func (s *ZoneSigner) SignZone(ctx context.Context, zone *entities.Zone) (*entities.Zone, error) {
+	keys, err := s.keyRepository.ListByZone(ctx, zone.Origin)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return zone, nil
+	}
+
+	now := s.now()
+	var dnskeys []dns.RR
+	var ksks, zsks []*entities.DNSSECKey
+	for _, key := range keys {
+		if !key.IsPublished(now) {
+			continue
+		}
+		dnskey := dns.Copy(key.DNSKEY).(*dns.DNSKEY)
+		dnskey.Hdr.Name, dnskey.Hdr.Ttl = zone.Origin, s.cfg.DNSKEYTTL
+		dnskeys = append(dnskeys, dnskey)
+		if !key.IsActive(now) {
+			continue
+		}
+		if key.Type() == entities.DNSSECKeyTypeKSK {
+			ksks = append(ksks, key)
+		} else {
+			zsks = append(zsks, key)
+		}
+	}
+	if len(ksks) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoActiveKSK, zone.Origin)
+	}
+	if len(zsks) == 0 {
+		zsks = ksks
+	}
+
+	signed, err := entities.NewZone(zone.Origin, dns.Copy(zone.SOA).(*dns.SOA))
+	if err != nil {
+		return nil, err
+	}
+	if err := signed.AddRecords(zone.Records()[1:]...); err != nil {
+		return nil, err
+	}
+	if err := signed.AddRecords(dnskeys...); err != nil {
+		return nil, err
+	}
+	if err := signed.AddRecords(&dns.NSEC3PARAM{
+		Hdr:        dns.RR_Header{Name: zone.Origin, Rrtype: dns.TypeNSEC3PARAM, Class: dns.ClassINET},
+		Hash:       dns.SHA1,
+		Iterations: s.cfg.NSEC3Iterations,
+		SaltLength: uint8(len(s.cfg.NSEC3Salt) / 2),
+		Salt:       s.cfg.NSEC3Salt,
+	}); err != nil {
+		return nil, err
+	}
+
+	// Collect the RRsets and the delegations
+	rrsets := map[rrsetKey][]dns.RR{}
+	delegations := map[string]bool{}
+	for _, rr := range signed.Records() {
+		k := rrsetKey{name: dns.CanonicalName(rr.Header().Name), rrtype: rr.Header().Rrtype}
+		rrsets[k] = append(rrsets[k], rr)
+		if k.rrtype == dns.TypeNS && k.name != zone.Origin {
+			delegations[k.name] = true
+		}
+	}
+
+	nsec3s := s.nsec3Chain(signed, rrsets, delegations)
+	if err := signed.AddRecords(nsec3s...); err != nil {
+		return nil, err
+	}
+	for _, rr := range nsec3s {
+		rrsets[rrsetKey{name: dns.CanonicalName(rr.Header().Name), rrtype: dns.TypeNSEC3}] = []dns.RR{rr}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cache := s.signatures[zone.Origin]
+	next := make(map[string]*dns.RRSIG, len(cache))
+	for k, rrset := range rrsets {
+		if !isAuthoritative(zone.Origin, k, delegations) {
+			continue
+		}
+		signers := zsks
+		if k.rrtype == dns.TypeDNSKEY {
+			signers = ksks
+		}
+		for _, key := range signers {
+			sig, err := s.signRRset(key, rrset, now, cache, next)
+			if err != nil {
+				return nil, fmt.Errorf("signing %s %s: %w", k.name, dns.TypeToString[k.rrtype], err)
+			}
+			if err := signed.AddRecords(sig); err != nil {
+				return nil, err
+			}
+		}
+	}
+	// Only keep the signatures of the current version of the zone
+	s.signatures[zone.Origin] = next
+
+	return signed, nil
+}
+
+// LoadSignatures reuses the signatures of a signed version of the zone that was published before (e.g. before a restart),
+// so signing the same content again yields the same zone until its signatures are due for a refresh. Signatures by keys the zone no longer has are dropped.
+func (s *ZoneSigner) LoadSignatures(ctx context.Context, zone *entities.Zone) error {
+	keys, err := s.keyRepository.ListByZone(ctx, zone.Origin)
+	if err != nil {
+		return err
+	}
+
+	rrsets := map[rrsetKey][]dns.RR{}
+	var sigs []*dns.RRSIG
+	for _, rr := range zone.Records() {
+		if sig, ok := rr.(*dns.RRSIG); ok {
+			sigs = append(sigs, sig)
+			continue
+		}
+		k := rrsetKey{name: dns.CanonicalName(rr.Header().Name), rrtype: rr.Header().Rrtype}
+		rrsets[k] = append(rrsets[k], rr)
+	}
+
+	cache := make(map[string]*dns.RRSIG, len(sigs))
+	for _, sig := range sigs {
+		rrset := rrsets[rrsetKey{name: dns.CanonicalName(sig.Hdr.Name), rrtype: sig.TypeCovered}]
+		if len(rrset) == 0 {
+			continue
+		}
+		for _, key := range keys {
+			if key.KeyTag() == sig.KeyTag && key.DNSKEY.Algorithm == sig.Algorithm {
+				cache[signatureCacheKey(key, rrset)] = sig
+			}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.signatures[zone.Origin] = cache
+	return nil
+}
+
+// signRRset returns the signature of the RRset by the key, reusing the signature from the cache if it does not expire within the refresh window
+func (s *ZoneSigner) signRRset(key *entities.DNSSECKey, rrset []dns.RR, now time.Time, cache, next map[string]*dns.RRSIG) (*dns.RRSIG, error) {
+	cacheKey := signatureCacheKey(key, rrset)
+	if sig, ok := cache[cacheKey]; ok && time.Unix(int64(sig.Expiration), 0).Sub(now) > s.cfg.SignatureRefresh {
+		next[cacheKey] = sig
+		return sig, nil
+	}
+
+	sig := &dns.RRSIG{
+		Hdr:        dns.RR_Header{Ttl: rrset[0].Header().Ttl},
+		Algorithm:  key.DNSKEY.Algorithm,
+		KeyTag:     key.KeyTag(),
+		SignerName: key.Zone(),
+		Inception:  uint32(now.Add(-SignatureInceptionOffset).Unix()),
+		Expiration: uint32(now.Add(s.cfg.SignatureValidity).Unix()),
+	}
+	if err := sig.Sign(key.PrivateKey, rrset); err != nil {
+		return nil, err
+	}
+	next[cacheKey] = sig
+	return sig, nil
+}
+
+// nsec3Chain creates the NSEC3 records for the zone. With opt-out, delegations without DS are left out of the chain.
+// Glue is never part of the chain, empty non-terminals are.
+// Ref: https://datatracker.ietf.org/doc/html/rfc5155#section-7.1
+func (s *ZoneSigner) nsec3Chain(zone *entities.Zone, rrsets map[rrsetKey][]dns.RR, delegations map[string]bool) []dns.RR {
+	origin := zone.Origin
+	types := map[string][]uint16{}
+	for k := range rrsets {
+		types[k.name] = append(types[k.name], k.rrtype)
+	}
+
+	bitmaps := map[string][]uint16{}
+	for name, present := range types {
+		if cut := closestDelegationAbove(origin, name, delegations); cut != "" {
+			continue
+		}
+		var bitmap []uint16
+		if delegations[name] {
+			// Only the delegation itself is present at the cut, the other records are glue
+			secure := len(rrsets[rrsetKey{name: name, rrtype: dns.TypeDS}]) > 0
+			if !secure && s.cfg.NSEC3OptOut {
+				continue
+			}
+			bitmap = []uint16{dns.TypeNS}
+			if secure {
+				bitmap = append(bitmap, dns.TypeDS, dns.TypeRRSIG)
+			}
+		} else {
+			bitmap = append(present, dns.TypeRRSIG)
+		}
+		sort.Slice(bitmap, func(i, j int) bool { return bitmap[i] < bitmap[j] })
+		bitmaps[name] = bitmap
+
+		// Add the empty non-terminals between the name and the apex
+		for n := parentName(name); n != origin && dns.IsSubDomain(origin, n); n = parentName(n) {
+			if _, ok := types[n]; ok {
+				break
+			}
+			if _, ok := bitmaps[n]; !ok {
+				bitmaps[n] = nil
+			}
+		}
+	}
+
+	ttl := zone.SOA.Minttl
+	if zone.SOA.Hdr.Ttl < ttl {
+		ttl = zone.SOA.Hdr.Ttl
+	}
+	var flags uint8
+	if s.cfg.NSEC3OptOut {
+		flags = 1
+	}
+
+	hashes := make([]string, 0, len(bitmaps))
+	byHash := make(map[string]string, len(bitmaps))
+	for name := range bitmaps {
+		hash := dns.HashName(name, dns.SHA1, s.cfg.NSEC3Iterations, s.cfg.NSEC3Salt)
+		hashes = append(hashes, hash)
+		byHash[hash] = name
+	}
+	sort.Strings(hashes)
+
+	rrs := make([]dns.RR, 0, len(hashes))
+	for i, hash := range hashes {
+		rrs = append(rrs, &dns.NSEC3{
+			Hdr:        dns.RR_Header{Name: strings.ToLower(hash) + "." + origin, Rrtype: dns.TypeNSEC3, Class: dns.ClassINET, Ttl: ttl},
+			Hash:       dns.SHA1,
+			Flags:      flags,
+			Iterations: s.cfg.NSEC3Iterations,
+			SaltLength: uint8(len(s.cfg.NSEC3Salt) / 2),
+			Salt:       s.cfg.NSEC3Salt,
+			HashLength: 20,
+			NextDomain: hashes[(i+1)%len(hashes)],
+			TypeBitMap: bitmaps[byHash[hash]],
+		})
+	}
+	return rrs
+}
+
+// isAuthoritative checks if the RRset is signed: glue is not signed and at a delegation only the DS RRset is
+func isAuthoritative(origin string, k rrsetKey, delegations map[string]bool) bool {
+	if delegations[k.name] {
+		return k.rrtype == dns.TypeDS
+	}
+	return closestDelegationAbove(origin, k.name, delegations) == ""
+}
+
+// closestDelegationAbove returns the delegation point above the name, or an empty string if the name is not below a delegation
+func closestDelegationAbove(origin, name string, delegations map[string]bool) string {
+	for n := parentName(name); n != origin && dns.IsSubDomain(origin, n); n = parentName(n) {
+		if delegations[n] {
+			return n
+		}
+	}
+	return ""
+}
+
+// parentName returns the name without its first label
+func parentName(name string) string {
+	off, end := dns.NextLabel(name, 0)
+	if end {
+		return "."
+	}
+	return name[off:]
+}
+
+// signatureCacheKey identifies a signature by the key and the content of the RRset
+func signatureCacheKey(key *entities.DNSSECKey, rrset []dns.RR) string {
+	rrs := make([]string, len(rrset))
+	for i, rr := range rrset {
+		rrs[i] = rr.String()
+	}
+	sort.Strings(rrs)
+	return fmt.Sprintf("%d/%d\n%s", key.DNSKEY.Algorithm, key.KeyTag(), strings.Join(rrs, "\n"))
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/require"
+)
+
+// newZoneSignerTestZone returns an unsigned zone with a secure delegation (a), an insecure delegation (b) and glue
+func newZoneSignerTestZone(t *testing.T) *entities.Zone {
+	t.Helper()
+	soa, err := dns.NewRR("example. 3600 IN SOA ns1.nic.example. hostmaster.nic.example. 42 1800 900 604800 86400")
+	require.NoError(t, err)
+	zone, err := entities.NewZone("example.", soa.(*dns.SOA))
+	require.NoError(t, err)
+	require.NoError(t, zone.AddRecords(newZoneServiceTestRRs(t,
+		"example. 3600 IN NS ns1.nic.example.",
+		"ns1.nic.example. 3600 IN A 192.0.2.1",
+		"a.example. 3600 IN NS ns1.a.example.",
+		"a.example. 3600 IN DS 12345 13 2 E2D3C916F6DEEAC73294E8268FB5885044A833FC5459588F4A9184CFC41A5766",
+		"ns1.a.example. 3600 IN A 192.0.2.2",
+		"b.example. 3600 IN NS ns.other.net.",
+	)...))
+	return zone
+}
+
+// newZoneSignerTestKey returns a key that is published and active at now, or only published if active is false
+func newZoneSignerTestKey(t *testing.T, keyType entities.DNSSECKeyType, now time.Time, active bool) *entities.DNSSECKey {
+	t.Helper()
+	key, err := entities.NewDNSSECKey("example", dns.ECDSAP256SHA256, keyType, 3600, now)
+	require.NoError(t, err)
+	key.Publish = now
+	if active {
+		key.Activate = now
+	}
+	return key
+}
+
+// signedRRsets indexes the records of the signed zone by owner and type, RRSIGs by owner and covered type
+func signedRRsets(zone *entities.Zone) (map[rrsetKey][]dns.RR, map[rrsetKey][]*dns.RRSIG) {
+	rrsets := map[rrsetKey][]dns.RR{}
+	sigs := map[rrsetKey][]*dns.RRSIG{}
+	for _, rr := range zone.Records() {
+		name := dns.CanonicalName(rr.Header().Name)
+		if sig, ok := rr.(*dns.RRSIG); ok {
+			k := rrsetKey{name: name, rrtype: sig.TypeCovered}
+			sigs[k] = append(sigs[k], sig)
+			continue
+		}
+		k := rrsetKey{name: name, rrtype: rr.Header().Rrtype}
+		rrsets[k] = append(rrsets[k], rr)
+	}
+	return rrsets, sigs
+}
+
+func nsec3Owner(name string) string {
+	return strings.ToLower(dns.HashName(name, dns.SHA1, 0, "")) + ".example."
+}
+
+func TestZoneSigner_SignZone(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	ksk := newZoneSignerTestKey(t, entities.DNSSECKeyTypeKSK, now, true)
+	zsk := newZoneSignerTestKey(t, entities.DNSSECKeyTypeZSK, now, true)
+	prepublished := newZoneSignerTestKey(t, entities.DNSSECKeyTypeZSK, now, false)
+	keys := map[uint16]*entities.DNSSECKey{ksk.KeyTag(): ksk, zsk.KeyTag(): zsk, prepublished.KeyTag(): prepublished}
+
+	signer := NewZoneSigner(&MockDNSSECKeyRepository{Keys: []*entities.DNSSECKey{ksk, zsk, prepublished}}, DefaultDNSSECConfig())
+	signer.now = func() time.Time { return now }
+
+	zone := newZoneSignerTestZone(t)
+	signed, err := signer.SignZone(ctx, zone)
+	require.NoError(t, err)
+	require.NoError(t, signed.Validate())
+	require.Equal(t, uint32(42), signed.SOA.Serial)
+
+	rrsets, sigs := signedRRsets(signed)
+	require.Len(t, rrsets[rrsetKey{name: "example.", rrtype: dns.TypeDNSKEY}], 3)
+	require.Len(t, rrsets[rrsetKey{name: "example.", rrtype: dns.TypeNSEC3PARAM}], 1)
+
+	// All signatures are valid and made by the right key
+	for k, rrsetSigs := range sigs {
+		for _, sig := range rrsetSigs {
+			key := keys[sig.KeyTag]
+			require.NotNil(t, key)
+			require.NotEqual(t, prepublished.KeyTag(), sig.KeyTag)
+			if k.rrtype == dns.TypeDNSKEY {
+				require.Equal(t, ksk.KeyTag(), sig.KeyTag)
+			} else {
+				require.Equal(t, zsk.KeyTag(), sig.KeyTag)
+			}
+			rrset := rrsets[k]
+			if k.rrtype == dns.TypeSOA {
+				rrset = []dns.RR{signed.SOA}
+			}
+			require.NoError(t, sig.Verify(key.DNSKEY, rrset), "%s %s", k.name, dns.TypeToString[k.rrtype])
+			require.True(t, sig.ValidityPeriod(now))
+		}
+	}
+	for _, k := range []rrsetKey{
+		{name: "example.", rrtype: dns.TypeSOA},
+		{name: "example.", rrtype: dns.TypeNS},
+		{name: "example.", rrtype: dns.TypeDNSKEY},
+		{name: "example.", rrtype: dns.TypeNSEC3PARAM},
+		{name: "ns1.nic.example.", rrtype: dns.TypeA},
+		{name: "a.example.", rrtype: dns.TypeDS},
+	} {
+		require.Len(t, sigs[k], 1, "%s %s", k.name, dns.TypeToString[k.rrtype])
+	}
+	// Delegations and glue are not signed
+	for _, k := range []rrsetKey{
+		{name: "a.example.", rrtype: dns.TypeNS},
+		{name: "ns1.a.example.", rrtype: dns.TypeA},
+		{name: "b.example.", rrtype: dns.TypeNS},
+	} {
+		require.Empty(t, sigs[k], "%s %s", k.name, dns.TypeToString[k.rrtype])
+	}
+
+	// The NSEC3 chain covers the apex, the empty non-terminal, the authoritative data and the secure delegation, but not the insecure delegation and glue
+	var nsec3s []*dns.NSEC3
+	for k, rrset := range rrsets {
+		if k.rrtype == dns.TypeNSEC3 {
+			nsec3s = append(nsec3s, rrset[0].(*dns.NSEC3))
+			require.Len(t, sigs[k], 1)
+		}
+	}
+	require.Len(t, nsec3s, 4)
+	bitmaps := map[string][]uint16{}
+	next := map[string]string{}
+	for _, n := range nsec3s {
+		require.Equal(t, uint8(1), n.Flags)
+		require.Equal(t, uint32(3600), n.Hdr.Ttl)
+		bitmaps[dns.CanonicalName(n.Hdr.Name)] = n.TypeBitMap
+		next[dns.CanonicalName(n.Hdr.Name)] = strings.ToLower(n.NextDomain) + ".example."
+	}
+	require.Equal(t, []uint16{dns.TypeNS, dns.TypeSOA, dns.TypeRRSIG, dns.TypeDNSKEY, dns.TypeNSEC3PARAM}, bitmaps[nsec3Owner("example.")])
+	require.Equal(t, []uint16{dns.TypeNS, dns.TypeDS, dns.TypeRRSIG}, bitmaps[nsec3Owner("a.example.")])
+	require.Equal(t, []uint16{dns.TypeA, dns.TypeRRSIG}, bitmaps[nsec3Owner("ns1.nic.example.")])
+	require.Contains(t, bitmaps, nsec3Owner("nic.example."))
+	require.Empty(t, bitmaps[nsec3Owner("nic.example.")])
+	require.NotContains(t, bitmaps, nsec3Owner("b.example."))
+	require.NotContains(t, bitmaps, nsec3Owner("ns1.a.example."))
+	// The chain is closed
+	owner := nsec3Owner("example.")
+	for range nsec3s {
+		owner = next[owner]
+	}
+	require.Equal(t, nsec3Owner("example."), owner)
+
+	// The input zone is not modified
+	require.Len(t, zone.Records(), 7)
+
+	t.Run("reuses signatures", func(t *testing.T) {
+		now = now.Add(time.Hour)
+		again, err := signer.SignZone(ctx, newZoneSignerTestZone(t))
+		require.NoError(t, err)
+		require.Equal(t, signed.String(), again.String())
+	})
+
+	t.Run("reuses loaded signatures", func(t *testing.T) {
+		// A new signer, e.g. after a restart, signs the zone the same way as the published version
+		restarted := NewZoneSigner(&MockDNSSECKeyRepository{Keys: []*entities.DNSSECKey{ksk, zsk, prepublished}}, DefaultDNSSECConfig())
+		restarted.now = func() time.Time { return now }
+		require.NoError(t, restarted.LoadSignatures(ctx, signed))
+		again, err := restarted.SignZone(ctx, newZoneSignerTestZone(t))
+		require.NoError(t, err)
+		require.Equal(t, signed.String(), again.String())
+
+		// Signatures by keys the zone no longer has are not reused
+		withoutZSK := NewZoneSigner(&MockDNSSECKeyRepository{Keys: []*entities.DNSSECKey{ksk}}, DefaultDNSSECConfig())
+		withoutZSK.now = func() time.Time { return now }
+		require.NoError(t, withoutZSK.LoadSignatures(ctx, signed))
+		resigned, err := withoutZSK.SignZone(ctx, newZoneSignerTestZone(t))
+		require.NoError(t, err)
+		_, sigs := signedRRsets(resigned)
+		require.Equal(t, ksk.KeyTag(), sigs[rrsetKey{name: "example.", rrtype: dns.TypeSOA}][0].KeyTag)
+	})
+
+	t.Run("refreshes signatures", func(t *testing.T) {
+		now = now.Add(DefaultSignatureValidity - DefaultSignatureRefresh)
+		refreshed, err := signer.SignZone(ctx, newZoneSignerTestZone(t))
+		require.NoError(t, err)
+		_, sigs := signedRRsets(refreshed)
+		sig := sigs[rrsetKey{name: "example.", rrtype: dns.TypeSOA}][0]
+		require.Equal(t, uint32(now.Add(DefaultSignatureValidity).Unix()), sig.Expiration)
+		require.Equal(t, uint32(now.Add(-SignatureInceptionOffset).Unix()), sig.Inception)
+	})
+}
+
+func TestZoneSigner_SignZone_NoOptOut(t *testing.T) {
+	now := time.Now()
+	ksk := newZoneSignerTestKey(t, entities.DNSSECKeyTypeKSK, now, true)
+	cfg := DefaultDNSSECConfig()
+	cfg.NSEC3OptOut = false
+	signer := NewZoneSigner(&MockDNSSECKeyRepository{Keys: []*entities.DNSSECKey{ksk}}, cfg)
+
+	signed, err := signer.SignZone(context.Background(), newZoneSignerTestZone(t))
+	require.NoError(t, err)
+	rrsets, sigs := signedRRsets(signed)
+
+	// The insecure delegation is part of the chain, without signatures
+	nsec3 := rrsets[rrsetKey{name: nsec3Owner("b.example."), rrtype: dns.TypeNSEC3}]
+	require.Len(t, nsec3, 1)
+	require.Equal(t, uint8(0), nsec3[0].(*dns.NSEC3).Flags)
+	require.Equal(t, []uint16{dns.TypeNS}, nsec3[0].(*dns.NSEC3).TypeBitMap)
+
+	// Without a ZSK the KSK signs the whole zone
+	require.Equal(t, ksk.KeyTag(), sigs[rrsetKey{name: "example.", rrtype: dns.TypeSOA}][0].KeyTag)
+}
+
+func TestZoneSigner_SignZone_Keys(t *testing.T) {
+	now := time.Now()
+	zone := newZoneSignerTestZone(t)
+
+	// Zones without keys are not signed
+	signer := NewZoneSigner(&MockDNSSECKeyRepository{}, DefaultDNSSECConfig())
+	signed, err := signer.SignZone(context.Background(), zone)
+	require.NoError(t, err)
+	require.Same(t, zone, signed)
+
+	// A zone needs an active KSK
+	zsk := newZoneSignerTestKey(t, entities.DNSSECKeyTypeZSK, now, true)
+	ksk := newZoneSignerTestKey(t, entities.DNSSECKeyTypeKSK, now, false)
+	signer = NewZoneSigner(&MockDNSSECKeyRepository{Keys: []*entities.DNSSECKey{zsk, ksk}}, DefaultDNSSECConfig())
+	_, err = signer.SignZone(context.Background(), zone)
+	require.ErrorIs(t, err, ErrNoActiveKSK)
+}
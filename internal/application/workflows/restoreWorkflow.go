@@ -81,20 +81,40 @@ func RestoreWorkflow(ctx workflow.Context) error {
 			zap.Any("RenewDomainCommand", cmd),
 		)
 
-		// Unset the PendingRestore status
-		unsetStatusCommand := commands.ToggleDomainStatusCommand{
-			DomainName:    cmd.Name,
-			Status:        entities.DomainStatusPendingRestore,
-			CorrelationID: workflowID,
+		// Complete the restore, this unsets the PendingRestore status if the restore report was received
+		dom := &entities.Domain{}
+		completeErr := workflow.ExecuteActivity(ctx, activities.CompleteDomainRestore, workflowID, cmd.Name).Get(ctx, dom)
+		if completeErr != nil {
+			logger.Error(
+				"failed to complete restore",
+				zap.String("domain_name", cmd.Name),
+				zap.String("workflow_id", workflowID),
+				zap.Error(completeErr),
+			)
+			continue
+		}
+		if dom.Status.PendingRestore {
+			logger.Debug(
+				"restore report not received yet, skipping",
+				zap.String("domain_name", cmd.Name),
+				zap.String("workflow_id", workflowID),
+			)
+			continue
 		}
-		unSetStatusErr := workflow.ExecuteActivity(ctx, activities.UnSetDomainStatus, unsetStatusCommand).Get(ctx, nil)
-		if unSetStatusErr != nil {
-			logger.Warn(
-				"failed to unset PendingRestore status",
+		if dom.Status.PendingDelete {
+			logger.Info(
+				"restore report not received within the pendingRestore period, domain returned to redemption period",
 				zap.String("domain_name", cmd.Name),
 				zap.String("workflow_id", workflowID),
-				zap.Error(unSetStatusErr),
 			)
+			continue
+		}
+
+		// Should the renew fail, the PendingRestore status is set again so we can try again later
+		pendingRestoreCommand := commands.ToggleDomainStatusCommand{
+			DomainName:    cmd.Name,
+			Status:        entities.DomainStatusPendingRestore,
+			CorrelationID: workflowID,
 		}
 
 		// Force-Renew the domain
@@ -108,7 +128,7 @@ func RestoreWorkflow(ctx workflow.Context) error {
 			)
 
 			// if the renew fails, set the domain status to PendingRestore again so we can try again later
-			setStatusErr := workflow.ExecuteActivity(ctx, activities.SetDomainStatus, unsetStatusCommand).Get(ctx, nil)
+			setStatusErr := workflow.ExecuteActivity(ctx, activities.SetDomainStatus, pendingRestoreCommand).Get(ctx, nil)
 			if setStatusErr != nil {
 				logger.Error(
 					"failed to re-set PendingRestore status after failed renew as part of the restore process",
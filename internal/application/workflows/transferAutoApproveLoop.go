@@ -0,0 +1,70 @@
+package workflows
+
+import (
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/application/activities"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+	"go.uber.org/zap"
+)
+
+// TransferAutoApproveLoop approves pending transfers the losing registrar did not act on within the TransferGP of the phase policy.
+// Approving a transfer moves the domain to the gaining registrar and notifies both registrars.
+func TransferAutoApproveLoop(ctx workflow.Context) error {
+	// set up our logger
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	// Get the workflow ID
+	workflowID := getWorkflowID(ctx)
+
+	// RetryPolicy specifies how to automatically handle retries if an Activity fails.
+	retrypolicy := &temporal.RetryPolicy{
+		InitialInterval:        time.Second,
+		BackoffCoefficient:     2.0,
+		MaximumInterval:        10 * time.Minute,
+		MaximumAttempts:        3, // 0 is unlimited retries
+		NonRetryableErrorTypes: []string{"none"},
+	}
+
+	options := workflow.ActivityOptions{
+		// Timeout options specify when to automatically timeout Activity functions.
+		StartToCloseTimeout: time.Minute,
+		// Optionally provide a customized RetryPolicy.
+		// Temporal retries failed Activities by default.
+		RetryPolicy: retrypolicy,
+	}
+
+	// Apply the options.
+	ctx = workflow.WithActivityOptions(ctx, options)
+
+	// Get the list of pending transfers that have expired
+	transfers := []entities.DomainTransfer{}
+	expiredTransfersError := workflow.ExecuteActivity(ctx, activities.ListExpiredTransfers, workflowID).Get(ctx, &transfers)
+	if expiredTransfersError != nil {
+		logger.Error(
+			"Error getting expired transfers",
+			zap.Error(expiredTransfersError),
+		)
+		return expiredTransfersError
+	}
+
+	// Process the list of expired transfers
+	for _, transfer := range transfers {
+		// Approve the transfer on behalf of the registry
+		approveActivityErr := workflow.ExecuteActivity(ctx, activities.ApproveDomainTransfer, workflowID, transfer.DomainName.String()).Get(ctx, nil)
+		if approveActivityErr != nil {
+			logger.Error(
+				"Error auto-approving domain transfer",
+				zap.String("domain_name", transfer.DomainName.String()),
+				zap.Error(approveActivityErr),
+				zap.Any("transfer", transfer),
+			)
+		}
+	}
+
+	return nil
+
+}
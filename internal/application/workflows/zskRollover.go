@@ -0,0 +1,103 @@
+package workflows
+
+import (
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/application/activities"
+	"github.com/onasunnymorning/domain-os/internal/interface/rest/response"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultZSKPrepublishInterval is the time the new ZSK is published before it signs the zone. It must exceed the DNSKEY TTL plus the time it takes to publish the zone on all secondaries.
+	DefaultZSKPrepublishInterval = 24 * time.Hour
+	// DefaultZSKRetireInterval is the time the old ZSK stays published after it stopped signing. It must exceed the largest TTL in the zone plus the time it takes to publish the zone on all secondaries.
+	DefaultZSKRetireInterval = 72 * time.Hour
+)
+
+// ZSKRolloverWorkflow replaces the ZSK of a TLD zone using the pre-publish method: the new ZSK is published, then it takes over the signing
+// and finally the old ZSK is removed once its signatures have expired from the caches. The DS for the parent is published at the end.
+// A zero interval uses the default.
+// Ref: https://datatracker.ietf.org/doc/html/rfc7583#section-3.2.1
+func ZSKRolloverWorkflow(ctx workflow.Context, tldName string, prepublishInterval, retireInterval time.Duration) error {
+	// set up our logger
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	// Get the workflow ID
+	workflowID := getWorkflowID(ctx)
+
+	if prepublishInterval == 0 {
+		prepublishInterval = DefaultZSKPrepublishInterval
+	}
+	if retireInterval == 0 {
+		retireInterval = DefaultZSKRetireInterval
+	}
+
+	// RetryPolicy specifies how to automatically handle retries if an Activity fails.
+	retrypolicy := &temporal.RetryPolicy{
+		InitialInterval:        time.Second,
+		BackoffCoefficient:     2.0,
+		MaximumInterval:        10 * time.Minute,
+		MaximumAttempts:        10, // 0 is unlimited retries
+		NonRetryableErrorTypes: []string{"none"},
+	}
+
+	options := workflow.ActivityOptions{
+		// Timeout options specify when to automatically timeout Activity functions.
+		StartToCloseTimeout: time.Minute,
+		// Optionally provide a customized RetryPolicy.
+		// Temporal retries failed Activities by default.
+		RetryPolicy: retrypolicy,
+	}
+
+	// Apply the options.
+	ctx = workflow.WithActivityOptions(ctx, options)
+
+	// Publish the new ZSK
+	var key response.DNSSECKey
+	err := workflow.ExecuteActivity(ctx, activities.PrepublishZSK, workflowID, tldName).Get(ctx, &key)
+	if err != nil {
+		logger.Error("Error pre-publishing ZSK", zap.String("tld", tldName), zap.Error(err))
+		return err
+	}
+	logger.Info("Pre-published ZSK", zap.String("tld", tldName), zap.Uint16("key_tag", key.KeyTag))
+
+	// Wait for the new DNSKEY RRset to reach the caches before signing with the new ZSK
+	if err := workflow.Sleep(ctx, prepublishInterval); err != nil {
+		return err
+	}
+	err = workflow.ExecuteActivity(ctx, activities.ActivateZSK, workflowID, tldName, key.KeyTag).Get(ctx, nil)
+	if err != nil {
+		logger.Error("Error activating ZSK", zap.String("tld", tldName), zap.Uint16("key_tag", key.KeyTag), zap.Error(err))
+		return err
+	}
+	logger.Info("Activated ZSK", zap.String("tld", tldName), zap.Uint16("key_tag", key.KeyTag))
+
+	// Wait for the signatures of the old ZSK to expire from the caches before removing it
+	if err := workflow.Sleep(ctx, retireInterval); err != nil {
+		return err
+	}
+	var removed []response.DNSSECKey
+	err = workflow.ExecuteActivity(ctx, activities.RemoveRetiredZSKs, workflowID, tldName).Get(ctx, &removed)
+	if err != nil {
+		logger.Error("Error removing retired ZSKs", zap.String("tld", tldName), zap.Error(err))
+		return err
+	}
+	for _, k := range removed {
+		logger.Info("Removed retired ZSK", zap.String("tld", tldName), zap.Uint16("key_tag", k.KeyTag))
+	}
+
+	// Keep the DS for the parent up to date
+	var ds response.DSResponse
+	err = workflow.ExecuteActivity(ctx, activities.PublishDS, workflowID, tldName).Get(ctx, &ds)
+	if err != nil {
+		logger.Error("Error publishing DS", zap.String("tld", tldName), zap.Error(err))
+		return err
+	}
+	logger.Info("Published DS", zap.String("tld", tldName), zap.Strings("ds", ds.DS))
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxAllocationTokenLength is the maximum length of an allocation token
+	MaxAllocationTokenLength = 255
+)
+
+var (
+	// ErrAllocationTokenNotFound is returned when an allocation token does not exist
+	ErrAllocationTokenNotFound = errors.New("allocation token not found")
+	// ErrInvalidAllocationToken is returned when an allocation token can't be created or updated
+	ErrInvalidAllocationToken = errors.New("invalid allocation token")
+	// ErrDuplicateAllocationToken is returned when an allocation token already exists
+	ErrDuplicateAllocationToken = errors.New("duplicate allocation token")
+	// ErrAllocationTokenMismatch is returned when an allocation token can't be used for the domain, registrar or phase (RFC 8495 'Allocation Token mismatch')
+	ErrAllocationTokenMismatch = errors.New("allocation token mismatch")
+	// ErrAllocationTokenExpired is returned when an allocation token is used after it expired
+	ErrAllocationTokenExpired = errors.New("allocation token expired")
+	// ErrAllocationTokenRedeemed is returned when a single use allocation token is used for the second time
+	ErrAllocationTokenRedeemed = errors.New("allocation token was already redeemed")
+)
+
+// AllocationToken authorizes a registrar to register a domain that is otherwise not available to it, e.g. a blocked name or a premium label that is released to a specific registrar.
+// A token is bound to a TLD or to a single domain name in that TLD and can optionally be restricted to a registrar and a phase.
+// A token can optionally give a discount on the registration price.
+// Ref: https://datatracker.ietf.org/doc/html/rfc8495
+type AllocationToken struct {
+	// Token is the opaque value the registrar provides with the command
+	Token string
+	// TLDName is the TLD the token can be used in
+	TLDName DomainName
+	// DomainName is the domain the token can be used for, if empty the token can be used for any domain in the TLD
+	DomainName DomainName
+	// ClID is the registrar that can use the token, if empty any registrar can use it
+	ClID ClIDType
+	// PhaseName is the phase the token can be used in, if empty it can be used in any phase
+	PhaseName ClIDType
+	// ExpiresAt is the time after which the token can't be used anymore, if zero the token does not expire
+	ExpiresAt time.Time
+	// MultiUse indicates the token can be redeemed more than once, by default a token can only be redeemed once
+	MultiUse bool
+	// DiscountPercentage is the discount on the registration price in percent (0-100)
+	DiscountPercentage int
+	// Redemptions is the number of times the token was redeemed
+	Redemptions int
+	// RedeemedAt is the time the token was last redeemed
+	RedeemedAt time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// NewAllocationToken creates a new allocation token for a TLD. If the token is empty a random token is generated.
+func NewAllocationToken(token, tldName string) (*AllocationToken, error) {
+	if token == "" {
+		token = uuid.NewString()
+	}
+	tld, err := NewDomainName(tldName)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidAllocationToken, err)
+	}
+	at := &AllocationToken{
+		Token:     strings.TrimSpace(token),
+		TLDName:   *tld,
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+	if err := at.Validate(); err != nil {
+		return nil, err
+	}
+	return at, nil
+}
+
+// SetDomainName binds the token to a domain name, the domain must be in the TLD of the token
+func (at *AllocationToken) SetDomainName(name string) error {
+	if name == "" {
+		at.DomainName = ""
+		return nil
+	}
+	dom, err := NewDomainName(name)
+	if err != nil {
+		return errors.Join(ErrInvalidAllocationToken, err)
+	}
+	if dom.ParentDomain() != at.TLDName.String() {
+		return errors.Join(ErrInvalidAllocationToken, fmt.Errorf("domain %s is not in TLD %s", dom, at.TLDName))
+	}
+	at.DomainName = *dom
+	return nil
+}
+
+// Validate checks if the allocation token is valid
+func (at *AllocationToken) Validate() error {
+	if at.Token == "" || len(at.Token) > MaxAllocationTokenLength || strings.ContainsAny(at.Token, " \t\r\n") {
+		return errors.Join(ErrInvalidAllocationToken, fmt.Errorf("token must be 1-%d characters without whitespace", MaxAllocationTokenLength))
+	}
+	if err := at.TLDName.Validate(); err != nil {
+		return errors.Join(ErrInvalidAllocationToken, err)
+	}
+	if at.DomainName != "" && at.DomainName.ParentDomain() != at.TLDName.String() {
+		return errors.Join(ErrInvalidAllocationToken, fmt.Errorf("domain %s is not in TLD %s", at.DomainName, at.TLDName))
+	}
+	if at.DiscountPercentage < 0 || at.DiscountPercentage > 100 {
+		return errors.Join(ErrInvalidAllocationToken, errors.New("discount percentage must be between 0 and 100"))
+	}
+	return nil
+}
+
+// CanBeUsed checks if the token can be used by the registrar to register the domain in the phase at the provided time.
+// An empty phase name skips the phase check, this is used when the phase is not known yet.
+func (at *AllocationToken) CanBeUsed(domainName, clID, phaseName string, t time.Time) error {
+	if !at.ExpiresAt.IsZero() && !t.Before(at.ExpiresAt) {
+		return errors.Join(ErrAllocationTokenExpired, fmt.Errorf("token expired at %s", at.ExpiresAt.Format(time.RFC3339)))
+	}
+	if !at.MultiUse && at.Redemptions > 0 {
+		return ErrAllocationTokenRedeemed
+	}
+	dom := DomainName(strings.ToLower(domainName))
+	if dom.ParentDomain() != at.TLDName.String() {
+		return errors.Join(ErrAllocationTokenMismatch, fmt.Errorf("token is not valid for TLD %s", dom.ParentDomain()))
+	}
+	if at.DomainName != "" && at.DomainName != dom {
+		return errors.Join(ErrAllocationTokenMismatch, fmt.Errorf("token is not valid for domain %s", dom))
+	}
+	if at.ClID != "" && at.ClID.String() != clID {
+		return errors.Join(ErrAllocationTokenMismatch, fmt.Errorf("token is not valid for registrar %s", clID))
+	}
+	if at.PhaseName != "" && phaseName != "" && at.PhaseName.String() != phaseName {
+		return errors.Join(ErrAllocationTokenMismatch, fmt.Errorf("token is not valid in phase %s", phaseName))
+	}
+	return nil
+}
+
+// Redeem records the use of the token to register the domain
+func (at *AllocationToken) Redeem(domainName, clID, phaseName string) error {
+	now := time.Now().UTC()
+	if err := at.CanBeUsed(domainName, clID, phaseName, now); err != nil {
+		return err
+	}
+	at.Redemptions++
+	at.RedeemedAt = now
+	return nil
+}
+
+// ApplyDiscount applies the discount of the token to the price of the quote. The discount is added to the quote so it can be reported separately.
+func (at *AllocationToken) ApplyDiscount(q *Quote) error {
+	if at.DiscountPercentage == 0 {
+		return nil
+	}
+	// Allocate splits the price without losing any cents to rounding
+	parts, err := q.Price.Allocate(100-at.DiscountPercentage, at.DiscountPercentage)
+	if err != nil {
+		return err
+	}
+	q.Price = parts[0]
+	if q.Discount == nil {
+		q.Discount = money.New(0, q.Price.Currency().Code)
+	}
+	q.Discount, err = q.Discount.Add(parts[1])
+	return err
+}
@@ -0,0 +1,137 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAllocationToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		tld     string
+		wantErr error
+	}{
+		{"valid", "abc123", "apex", nil},
+		{"generated", "", "apex", nil},
+		{"whitespace", "abc 123", "apex", ErrInvalidAllocationToken},
+		{"invalid tld", "abc123", "-apex", ErrInvalidAllocationToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at, err := NewAllocationToken(tt.token, tt.tld)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, at)
+				return
+			}
+			require.NoError(t, err)
+			require.NotEmpty(t, at.Token)
+			require.Equal(t, DomainName("apex"), at.TLDName)
+			require.False(t, at.MultiUse)
+		})
+	}
+}
+
+func TestAllocationToken_SetDomainName(t *testing.T) {
+	at, err := NewAllocationToken("abc123", "apex")
+	require.NoError(t, err)
+
+	require.NoError(t, at.SetDomainName("Reserved.apex"))
+	require.Equal(t, DomainName("reserved.apex"), at.DomainName)
+
+	require.ErrorIs(t, at.SetDomainName("reserved.other"), ErrInvalidAllocationToken)
+	require.ErrorIs(t, at.SetDomainName("-reserved.apex"), ErrInvalidAllocationToken)
+
+	require.NoError(t, at.SetDomainName(""))
+	require.Empty(t, at.DomainName)
+}
+
+func TestAllocationToken_Validate(t *testing.T) {
+	at, err := NewAllocationToken("abc123", "apex")
+	require.NoError(t, err)
+
+	at.DiscountPercentage = 101
+	require.ErrorIs(t, at.Validate(), ErrInvalidAllocationToken)
+
+	at.DiscountPercentage = 100
+	require.NoError(t, at.Validate())
+
+	at.DomainName = "reserved.other"
+	require.ErrorIs(t, at.Validate(), ErrInvalidAllocationToken)
+}
+
+func TestAllocationToken_CanBeUsed(t *testing.T) {
+	now := time.Now().UTC()
+	tests := []struct {
+		name    string
+		token   AllocationToken
+		domain  string
+		clid    string
+		phase   string
+		wantErr error
+	}{
+		{"tld token", AllocationToken{TLDName: "apex"}, "reserved.apex", "rar1", "GA", nil},
+		{"domain token", AllocationToken{TLDName: "apex", DomainName: "reserved.apex"}, "Reserved.apex", "rar1", "GA", nil},
+		{"registrar and phase", AllocationToken{TLDName: "apex", ClID: "rar1", PhaseName: "GA"}, "reserved.apex", "rar1", "GA", nil},
+		{"unknown phase", AllocationToken{TLDName: "apex", PhaseName: "GA"}, "reserved.apex", "rar1", "", nil},
+		{"other tld", AllocationToken{TLDName: "apex"}, "reserved.other", "rar1", "GA", ErrAllocationTokenMismatch},
+		{"other domain", AllocationToken{TLDName: "apex", DomainName: "reserved.apex"}, "other.apex", "rar1", "GA", ErrAllocationTokenMismatch},
+		{"other registrar", AllocationToken{TLDName: "apex", ClID: "rar1"}, "reserved.apex", "rar2", "GA", ErrAllocationTokenMismatch},
+		{"other phase", AllocationToken{TLDName: "apex", PhaseName: "sunrise"}, "reserved.apex", "rar1", "GA", ErrAllocationTokenMismatch},
+		{"expired", AllocationToken{TLDName: "apex", ExpiresAt: now}, "reserved.apex", "rar1", "GA", ErrAllocationTokenExpired},
+		{"not expired", AllocationToken{TLDName: "apex", ExpiresAt: now.Add(time.Hour)}, "reserved.apex", "rar1", "GA", nil},
+		{"redeemed", AllocationToken{TLDName: "apex", Redemptions: 1}, "reserved.apex", "rar1", "GA", ErrAllocationTokenRedeemed},
+		{"multi use", AllocationToken{TLDName: "apex", MultiUse: true, Redemptions: 5}, "reserved.apex", "rar1", "GA", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.token.CanBeUsed(tt.domain, tt.clid, tt.phase, now)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAllocationToken_Redeem(t *testing.T) {
+	at := &AllocationToken{TLDName: "apex", DomainName: "reserved.apex"}
+
+	require.NoError(t, at.Redeem("reserved.apex", "rar1", "GA"))
+	require.Equal(t, 1, at.Redemptions)
+	require.False(t, at.RedeemedAt.IsZero())
+
+	require.ErrorIs(t, at.Redeem("reserved.apex", "rar1", "GA"), ErrAllocationTokenRedeemed)
+	require.Equal(t, 1, at.Redemptions)
+}
+
+func TestAllocationToken_ApplyDiscount(t *testing.T) {
+	tests := []struct {
+		name         string
+		discount     int
+		price        int64
+		wantPrice    int64
+		wantDiscount *money.Money
+	}{
+		{"no discount", 0, 1000, 1000, nil},
+		{"half", 50, 1001, 501, money.New(500, "USD")},
+		{"free", 100, 1000, 0, money.New(1000, "USD")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at := &AllocationToken{TLDName: "apex", DiscountPercentage: tt.discount}
+			q := &Quote{Price: money.New(tt.price, "USD")}
+			require.NoError(t, at.ApplyDiscount(q))
+			require.Equal(t, tt.wantPrice, q.Price.Amount())
+			require.Equal(t, tt.wantDiscount, q.Discount)
+		})
+	}
+}
@@ -51,6 +51,8 @@ var (
 	ErrContactAlreadyExists            = errors.New("contact already exists")
 	ErrInvalidContactStatusCombination = errors.New("invalid combination of contact statuses")
 	ErrContactUpdateNotAllowed         = errors.New("contact status prohibits update")
+	ErrContactDeleteNotAllowed         = errors.New("contact status prohibits delete")
+	ErrContactIsLinked                 = errors.New("contact is linked to one or more domains")
 	ErrPostalInfoTypeExistsAlready     = errors.New("postalinfo of this type already exists")
 	ErrInvalidContactRoID              = fmt.Errorf("invalid Contact.RoID.ObjectIdentifier(), expecting '%s'", CONTACT_ROID_ID)
 )
@@ -95,6 +97,48 @@ func (s *ContactStatus) IsNil() bool {
 	return !s.ClientDeleteProhibited && !s.ClientTransferProhibited && !s.ClientUpdateProhibited && !s.ServerDeleteProhibited && !s.ServerTransferProhibited && !s.ServerUpdateProhibited && !s.PendingCreate && !s.PendingDelete && !s.PendingTransfer && !s.PendingUpdate && !s.OK
 }
 
+// StringSlice returns a slice of strings representing the ContactStatus. This is useful for building EPP and WHOIS responses
+func (s *ContactStatus) StringSlice() []string {
+	var status []string
+	if s.OK {
+		status = append(status, ContactStatusOK.String())
+	}
+	if s.Linked {
+		status = append(status, ContactStatusLinked.String())
+	}
+	if s.ClientDeleteProhibited {
+		status = append(status, ContactStatusClientDeleteProhibited.String())
+	}
+	if s.ClientUpdateProhibited {
+		status = append(status, ContactStatusClientUpdateProhibited.String())
+	}
+	if s.ClientTransferProhibited {
+		status = append(status, ContactStatusClientTransferProhibited.String())
+	}
+	if s.ServerDeleteProhibited {
+		status = append(status, ContactStatusServerDeleteProhibited.String())
+	}
+	if s.ServerUpdateProhibited {
+		status = append(status, ContactStatusServerUpdateProhibited.String())
+	}
+	if s.ServerTransferProhibited {
+		status = append(status, ContactStatusServerTransferProhibited.String())
+	}
+	if s.PendingCreate {
+		status = append(status, ContactStatusPendingCreate.String())
+	}
+	if s.PendingUpdate {
+		status = append(status, ContactStatusPendingUpdate.String())
+	}
+	if s.PendingTransfer {
+		status = append(status, ContactStatusPendingTransfer.String())
+	}
+	if s.PendingDelete {
+		status = append(status, ContactStatusPendingDelete.String())
+	}
+	return status
+}
+
 // SetFullStatus sets the ContactStatus equal to the received ContactStatus and returns an error if the status is invalid
 func (c *Contact) SetFullStatus(status ContactStatus) error {
 	if !status.IsNil() && !status.IsValidContactStatus() {
@@ -0,0 +1,92 @@
+package entities
+
+const (
+	// RDDS_REDACTED_FOR_PRIVACY replaces redacted values in WHOIS output as required by the Registration Data Policy
+	RDDS_REDACTED_FOR_PRIVACY = "REDACTED FOR PRIVACY"
+	// RDDS_REDACTED_EMAIL replaces a redacted email in WHOIS output and is added as a remark to RDAP entities with a redacted email
+	RDDS_REDACTED_EMAIL = "Please query the RDDS service of the Registrar of Record identified in this output for information on how to contact the Registrant, Admin, or Tech contact of the queried domain name."
+)
+
+// ContactRedaction lists the fields of a contact that are withheld from public RDDS (WHOIS and RDAP) output.
+// The zero value redacts nothing, which is the view of a privileged requester.
+type ContactRedaction struct {
+	ID         bool
+	Name       bool
+	Org        bool
+	Street     bool
+	City       bool
+	PostalCode bool
+	Voice      bool
+	Fax        bool
+	Email      bool
+}
+
+// NewContactRedaction returns the redaction of a contact based on its disclose flags.
+// The flags of the postal info that is published (int is preferred over loc) apply to the name, organization and address.
+// The state/province and country are always published as allowed by the Registration Data Policy, the contact ID is redacted together with the name.
+func NewContactRedaction(c *Contact) ContactRedaction {
+	name, org, addr := c.Disclose.NameInt, c.Disclose.OrgInt, c.Disclose.AddrInt
+	if pi := c.RDDSPostalInfo(); pi != nil && pi.Type == "loc" {
+		name, org, addr = c.Disclose.NameLoc, c.Disclose.OrgLoc, c.Disclose.AddrLoc
+	}
+	return ContactRedaction{
+		ID:         !name,
+		Name:       !name,
+		Org:        !org,
+		Street:     !addr,
+		City:       !addr,
+		PostalCode: !addr,
+		Voice:      !c.Disclose.Voice,
+		Fax:        !c.Disclose.Fax,
+		Email:      !c.Disclose.Email,
+	}
+}
+
+// IsEmpty returns true if no fields are redacted
+func (r ContactRedaction) IsEmpty() bool {
+	return r == ContactRedaction{}
+}
+
+// applyTo only keeps the redactions of the fields that hold data, there is nothing to redact in an empty field
+func (r ContactRedaction) applyTo(c *Contact) ContactRedaction {
+	pi := c.RDDSPostalInfo()
+	var a *Address
+	if pi != nil {
+		a = pi.Address
+	}
+	r.Name = r.Name && pi != nil
+	r.Org = r.Org && pi != nil && pi.Org != ""
+	r.Street = r.Street && a != nil && a.Street1 != ""
+	r.City = r.City && a != nil && a.City != ""
+	r.PostalCode = r.PostalCode && a != nil && a.PostalCode != ""
+	r.Voice = r.Voice && c.Voice != ""
+	r.Fax = r.Fax && c.Fax != ""
+	r.Email = r.Email && c.Email != ""
+	return r
+}
+
+// RDDSPostalInfo returns the postal info that is published in RDDS output, the int postal info is preferred over the loc postal info
+func (c *Contact) RDDSPostalInfo() *ContactPostalInfo {
+	for _, pi := range c.PostalInfo {
+		if pi != nil {
+			return pi
+		}
+	}
+	return nil
+}
+
+// ForRole returns the contact data policy for a role (registrant, administrative, technical or billing).
+// An unknown role returns ContactDataPolicyTypeProhibited so its data is never published.
+func (p ContactDataPolicy) ForRole(role string) ContactDataPolicyType {
+	switch role {
+	case RDAPRoleRegistrant:
+		return p.RegistrantContactDataPolicy
+	case RDAPRoleAdministrative:
+		return p.AdminContactDataPolicy
+	case RDAPRoleTechnical:
+		return p.TechContactDataPolicy
+	case RDAPRoleBilling:
+		return p.BillingContactDataPolicy
+	}
+	return ContactDataPolicyTypeProhibited
+}
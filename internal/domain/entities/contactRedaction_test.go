@@ -0,0 +1,45 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewContactRedaction(t *testing.T) {
+	c, err := NewContact("myid", "1234_CONT-APEX", "me@my.com", "str0NGP@ZZw0rd", "rar1")
+	require.NoError(t, err)
+
+	// Nothing is disclosed by default
+	r := NewContactRedaction(c)
+	require.Equal(t, ContactRedaction{ID: true, Name: true, Org: true, Street: true, City: true, PostalCode: true, Voice: true, Fax: true, Email: true}, r)
+	require.False(t, r.IsEmpty())
+
+	// Without postal info the int flags apply
+	c.Disclose = *NewDiscloseStruct(true)
+	require.True(t, NewContactRedaction(c).IsEmpty())
+
+	// The flags of the published postal info apply
+	a, err := NewAddress("Brussels", "BE")
+	require.NoError(t, err)
+	pi, err := NewContactPostalInfo("loc", "Jan Janssens", a)
+	require.NoError(t, err)
+	require.NoError(t, c.AddPostalInfo(pi))
+	c.Disclose.NameLoc = false
+	r = NewContactRedaction(c)
+	require.True(t, r.Name)
+	require.True(t, r.ID)
+	require.False(t, r.Street)
+	require.False(t, r.Email)
+}
+
+func TestContactDataPolicy_ForRole(t *testing.T) {
+	p := NewContactDataPolicy()
+	p.BillingContactDataPolicy = ContactDataPolicyTypeProhibited
+
+	require.Equal(t, ContactDataPolicyTypeMandatory, p.ForRole(RDAPRoleRegistrant))
+	require.Equal(t, ContactDataPolicyTypeMandatory, p.ForRole(RDAPRoleTechnical))
+	require.Equal(t, ContactDataPolicyTypeOptional, p.ForRole(RDAPRoleAdministrative))
+	require.Equal(t, ContactDataPolicyTypeProhibited, p.ForRole(RDAPRoleBilling))
+	require.Equal(t, ContactDataPolicyTypeProhibited, p.ForRole("unknown"))
+}
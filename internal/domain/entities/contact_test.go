@@ -773,3 +773,33 @@ func TestContact_Disclose_IsNil(t *testing.T) {
 
 	require.False(t, cd.IsNil())
 }
+
+func TestContactStatus_StringSlice(t *testing.T) {
+	testcases := []struct {
+		name string
+		cs   ContactStatus
+		want []string
+	}{
+		{
+			name: "nil",
+			cs:   ContactStatus{},
+			want: nil,
+		},
+		{
+			name: "ok linked",
+			cs:   ContactStatus{OK: true, Linked: true},
+			want: []string{"ok", "linked"},
+		},
+		{
+			name: "prohibitions",
+			cs:   ContactStatus{ClientDeleteProhibited: true, ServerTransferProhibited: true, Linked: true},
+			want: []string{"linked", "clientDeleteProhibited", "serverTransferProhibited"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.cs.StringSlice())
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package entities
+
+import "time"
+
+// DeletedObject records the deletion of a domain, host, contact or NNDN so it can be included in the deletes of the next DIFF or INCR escrow deposit
+type DeletedObject struct {
+	ID int64
+	// ObjectURI is the RDE URI of the type of object that was deleted e.g. DOMAIN_URI
+	ObjectURI string
+	// TLDName is empty for hosts and contacts, these are not bound to a TLD
+	TLDName string
+	// Name is the domain name, host name, contact ID or the A-label of the NNDN
+	Name string
+	// RoID is used to identify the host, host names are not unique
+	RoID      string
+	DeletedAt time.Time
+}
+
+// NewDeletedObject creates a new DeletedObject that was deleted now
+func NewDeletedObject(objectURI, tld, name, roid string) *DeletedObject {
+	return &DeletedObject{
+		ObjectURI: objectURI,
+		TLDName:   tld,
+		Name:      name,
+		RoID:      roid,
+		DeletedAt: time.Now().UTC(),
+	}
+}
@@ -0,0 +1,126 @@
+package entities
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+var (
+	ErrInvalidDNSSECKey           = errors.New("invalid DNSSEC key")
+	ErrDNSSECKeyNotFound          = errors.New("DNSSEC key not found")
+	ErrUnsupportedDNSSECAlgorithm = errors.New("unsupported DNSSEC algorithm")
+	ErrInvalidDNSSECKeyType       = errors.New("invalid DNSSEC key type, must be KSK or ZSK")
+)
+
+// DNSSECKeyType distinguishes key signing keys (sign the DNSKEY RRset, referenced by the DS in the parent) from zone signing keys (sign all other RRsets)
+type DNSSECKeyType string
+
+const (
+	DNSSECKeyTypeKSK DNSSECKeyType = "KSK"
+	DNSSECKeyTypeZSK DNSSECKeyType = "ZSK"
+)
+
+// dnssecKeyBits holds the key size used when generating keys for the supported signing algorithms. Algorithms that are not recommended for signing are not supported.
+// Ref: https://datatracker.ietf.org/doc/html/rfc8624#section-3.1
+var dnssecKeyBits = map[uint8]int{
+	dns.RSASHA256:       2048,
+	dns.RSASHA512:       2048,
+	dns.ECDSAP256SHA256: 256,
+	dns.ECDSAP384SHA384: 384,
+	dns.ED25519:         256,
+}
+
+// DNSSECKey is a key pair used to sign a zone, with its timing metadata as used by BIND. A zero time means the event is not scheduled.
+// A key is included in the DNSKEY RRset from Publish until Delete and signs the zone from Activate until Inactive.
+// Ref: https://datatracker.ietf.org/doc/html/rfc7583#section-3.1
+type DNSSECKey struct {
+	DNSKEY     *dns.DNSKEY
+	PrivateKey crypto.Signer
+	Created    time.Time
+	Publish    time.Time
+	Activate   time.Time
+	Inactive   time.Time
+	Delete     time.Time
+}
+
+// IsSupportedDNSSECAlgorithm checks if we can generate keys and sign with the algorithm
+func IsSupportedDNSSECAlgorithm(alg uint8) bool {
+	_, ok := dnssecKeyBits[alg]
+	return ok
+}
+
+// NewDNSSECKey generates a new key pair for the zone. The key is neither published nor active, set the timing metadata to use it.
+func NewDNSSECKey(zone string, alg uint8, keyType DNSSECKeyType, ttl uint32, now time.Time) (*DNSSECKey, error) {
+	bits, ok := dnssecKeyBits[alg]
+	if !ok {
+		return nil, errors.Join(ErrInvalidDNSSECKey, fmt.Errorf("%w: %d", ErrUnsupportedDNSSECAlgorithm, alg))
+	}
+	var flags uint16
+	switch keyType {
+	case DNSSECKeyTypeKSK:
+		flags = dns.ZONE | dns.SEP
+	case DNSSECKeyTypeZSK:
+		flags = dns.ZONE
+	default:
+		return nil, errors.Join(ErrInvalidDNSSECKey, ErrInvalidDNSSECKeyType)
+	}
+
+	key := &dns.DNSKEY{
+		Hdr:       dns.RR_Header{Name: dns.CanonicalName(zone), Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET, Ttl: ttl},
+		Flags:     flags,
+		Protocol:  3,
+		Algorithm: alg,
+	}
+	priv, err := key.Generate(bits)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidDNSSECKey, err)
+	}
+	signer, ok := priv.(crypto.Signer)
+	if !ok {
+		return nil, errors.Join(ErrInvalidDNSSECKey, ErrUnsupportedDNSSECAlgorithm)
+	}
+	return &DNSSECKey{DNSKEY: key, PrivateKey: signer, Created: now.UTC().Truncate(time.Second)}, nil
+}
+
+// Zone returns the name of the zone the key belongs to
+func (k *DNSSECKey) Zone() string {
+	return dns.CanonicalName(k.DNSKEY.Hdr.Name)
+}
+
+// KeyTag returns the key tag of the DNSKEY
+func (k *DNSSECKey) KeyTag() uint16 {
+	return k.DNSKEY.KeyTag()
+}
+
+// Type returns KSK if the SEP flag is set and ZSK otherwise
+func (k *DNSSECKey) Type() DNSSECKeyType {
+	if k.DNSKEY.Flags&dns.SEP != 0 {
+		return DNSSECKeyTypeKSK
+	}
+	return DNSSECKeyTypeZSK
+}
+
+// IsPublished checks if the key is part of the DNSKEY RRset at time t
+func (k *DNSSECKey) IsPublished(t time.Time) bool {
+	return !k.Publish.IsZero() && !t.Before(k.Publish) && (k.Delete.IsZero() || t.Before(k.Delete))
+}
+
+// IsActive checks if the key is used to sign the zone at time t. A key is only active while it is published.
+func (k *DNSSECKey) IsActive(t time.Time) bool {
+	return k.IsPublished(t) && !k.Activate.IsZero() && !t.Before(k.Activate) && (k.Inactive.IsZero() || t.Before(k.Inactive))
+}
+
+// IsRetired checks if the key no longer signs the zone at time t after having been active
+func (k *DNSSECKey) IsRetired(t time.Time) bool {
+	return !k.Inactive.IsZero() && !t.Before(k.Inactive)
+}
+
+// DS returns the DS record for the key using SHA-256, to be published in the parent zone
+// Ref: https://datatracker.ietf.org/doc/html/rfc8624#section-3.3
+func (k *DNSSECKey) DS() *dns.DS {
+	return k.DNSKEY.ToDS(dns.SHA256)
+}
@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDNSSECKey(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 500, time.UTC)
+	for _, alg := range []uint8{dns.RSASHA256, dns.ECDSAP256SHA256, dns.ECDSAP384SHA384, dns.ED25519} {
+		t.Run(dns.AlgorithmToString[alg], func(t *testing.T) {
+			key, err := NewDNSSECKey("Example", alg, DNSSECKeyTypeKSK, 3600, now)
+			require.NoError(t, err)
+			require.Equal(t, "example.", key.Zone())
+			require.Equal(t, DNSSECKeyTypeKSK, key.Type())
+			require.Equal(t, uint16(257), key.DNSKEY.Flags)
+			require.Equal(t, alg, key.DNSKEY.Algorithm)
+			require.Equal(t, now.Truncate(time.Second), key.Created)
+			require.False(t, key.IsPublished(now))
+
+			// The key can sign
+			rr, err := dns.NewRR("example. 3600 IN NS ns1.example.")
+			require.NoError(t, err)
+			sig := &dns.RRSIG{Algorithm: alg, KeyTag: key.KeyTag(), SignerName: key.Zone(), Inception: uint32(now.Unix()), Expiration: uint32(now.Add(time.Hour).Unix())}
+			require.NoError(t, sig.Sign(key.PrivateKey, []dns.RR{rr}))
+			require.NoError(t, sig.Verify(key.DNSKEY, []dns.RR{rr}))
+		})
+	}
+
+	zsk, err := NewDNSSECKey("example", dns.ECDSAP256SHA256, DNSSECKeyTypeZSK, 3600, now)
+	require.NoError(t, err)
+	require.Equal(t, DNSSECKeyTypeZSK, zsk.Type())
+	require.Equal(t, uint16(256), zsk.DNSKEY.Flags)
+
+	_, err = NewDNSSECKey("example", dns.RSASHA1, DNSSECKeyTypeZSK, 3600, now)
+	require.ErrorIs(t, err, ErrUnsupportedDNSSECAlgorithm)
+	_, err = NewDNSSECKey("example", dns.ECDSAP256SHA256, DNSSECKeyType("CSK"), 3600, now)
+	require.ErrorIs(t, err, ErrInvalidDNSSECKeyType)
+}
+
+func TestDNSSECKey_Timing(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	key := &DNSSECKey{
+		DNSKEY:   &dns.DNSKEY{Flags: dns.ZONE},
+		Publish:  t0,
+		Activate: t0.Add(time.Hour),
+		Inactive: t0.Add(2 * time.Hour),
+		Delete:   t0.Add(3 * time.Hour),
+	}
+
+	tests := []struct {
+		at        time.Time
+		published bool
+		active    bool
+		retired   bool
+	}{
+		{at: t0.Add(-time.Second)},
+		{at: t0, published: true},
+		{at: t0.Add(time.Hour), published: true, active: true},
+		{at: t0.Add(2 * time.Hour), published: true, retired: true},
+		{at: t0.Add(3 * time.Hour), retired: true},
+	}
+	for _, tc := range tests {
+		require.Equal(t, tc.published, key.IsPublished(tc.at), tc.at)
+		require.Equal(t, tc.active, key.IsActive(tc.at), tc.at)
+		require.Equal(t, tc.retired, key.IsRetired(tc.at), tc.at)
+	}
+
+	// Unscheduled events never happen
+	key.Inactive, key.Delete = time.Time{}, time.Time{}
+	require.True(t, key.IsActive(t0.Add(24*365*time.Hour)))
+}
+
+func TestDNSSECKey_DS(t *testing.T) {
+	rr, err := dns.NewRR("example. 3600 IN DNSKEY 257 3 13 kXKkvWU3vGYfTJGl3qBd4qhiWp5aRs7YtkCJxD2d+t7KXqwahww5IgJtxJT2yFItlggazyfXqJEVOmMJ3qT0tQ==")
+	require.NoError(t, err)
+	key := &DNSSECKey{DNSKEY: rr.(*dns.DNSKEY)}
+	ds := key.DS()
+	require.Equal(t, key.KeyTag(), ds.KeyTag)
+	require.Equal(t, uint8(dns.ECDSAP256SHA256), ds.Algorithm)
+	require.Equal(t, uint8(dns.SHA256), ds.DigestType)
+	require.Len(t, ds.Digest, 64)
+}
@@ -69,6 +69,8 @@ type Domain struct {
 	Status         DomainStatus         `json:"Status"`
 	RGPStatus      DomainRGPStatus      `json:"RGPStatus"`
 	GrandFathering DomainGrandFathering `json:"GrandFathering"`
+	SecDNS         DomainSecDNS         `json:"SecDNS"`
+	RestoreReport  DomainRestoreReport  `json:"RestoreReport"`
 	Hosts          []*Host              `json:"Hosts"`
 }
 
@@ -190,6 +192,9 @@ func (d *Domain) Validate() error {
 	if err := d.Status.Validate(); err != nil {
 		return err
 	}
+	if err := d.SecDNS.Validate(); err != nil {
+		return err
+	}
 	if isIDN, _ := d.Name.IsIDN(); !isIDN {
 		// if the domain is not an IDN domain, the OriginalName and UName fields must be empty
 		if d.OriginalName != "" {
@@ -436,6 +441,73 @@ func (d *Domain) ForceRenew(years int, isAutoRenew bool, phase *Phase) error {
 	return nil
 }
 
+// RequestTransfer validates that the domain can be transferred to the registrar with the provided ClID using the provided authInfo and sets the domain status to PendingTransfer.
+// Transfer prohibitions, pending actions and the transfer lock period are checked through CanBeTransferred.
+// Update prohibitions do not prevent a transfer, so the PendingTransfer status is set directly rather than through SetStatus.
+func (d *Domain) RequestTransfer(gainingClID, authInfo string) error {
+	if d.ClID.String() == gainingClID {
+		return ErrTransferToSponsor
+	}
+	if d.AuthInfo.String() != authInfo {
+		return ErrTransferAuthInfoMismatch
+	}
+	if d.Status.PendingTransfer {
+		return ErrDomainPendingTransfer
+	}
+	if d.Status.ClientTransferProhibited || d.Status.ServerTransferProhibited || d.Status.HasPendings() {
+		return ErrDomainTransferNotAllowed
+	}
+	if !d.CanBeTransferred() {
+		return errors.Join(ErrDomainTransferLocked, fmt.Errorf("transfer lock period ends %s", d.RGPStatus.TransferLockPeriodEnd.Format(time.RFC3339)))
+	}
+
+	d.Status.PendingTransfer = true
+	d.UnSetOKStatusIfNeeded()
+
+	return nil
+}
+
+// ClearPendingTransfer removes the PendingTransfer status from the domain without changing its sponsor. Use this when a transfer is rejected or cancelled.
+func (d *Domain) ClearPendingTransfer() error {
+	if !d.Status.PendingTransfer {
+		return ErrNoPendingTransfer
+	}
+	d.Status.PendingTransfer = false
+	d.SetOKStatusIfNeeded()
+
+	return nil
+}
+
+// CompleteTransfer moves the domain to the gaining registrar once a transfer has been approved.
+// The registration is extended with the provided number of years and the transfer lock period restarts.
+// Grandfathering with a transfer expiry condition is voided.
+func (d *Domain) CompleteTransfer(gainingClID string, years int, phase *Phase) error {
+	if phase == nil {
+		return errors.Join(ErrInvalidRenewal, ErrPhaseNotProvided)
+	}
+	if !d.Status.PendingTransfer {
+		return ErrNoPendingTransfer
+	}
+	if years != TRANSFER_YEARS {
+		return ErrInvalidTransferPeriod
+	}
+
+	d.ClID = ClIDType(gainingClID)
+	d.UpRr = ClIDType(gainingClID)
+	d.ExpiryDate = d.ExpiryDate.AddDate(years, 0, 0)
+	d.RenewedYears += years
+	d.RGPStatus.TransferLockPeriodEnd = time.Now().UTC().AddDate(0, 0, phase.Policy.TransferLockPeriod)
+
+	if d.GrandFathering.GFExpiryCondition == GFConditionTransfer {
+		d.GrandFathering = DomainGrandFathering{}
+	}
+
+	d.Status.PendingTransfer = false
+	d.SetOKStatusIfNeeded()
+
+	return nil
+}
+
 // MarkForDeletion ititiates the end-of-life lifecycle for a domain when a delete command is received form the user. Use this to process user delete commands. It sets the domain status to PendingDelete and sets the appropriate RGP statuses depending on the phase policy.
 // If the domain is still in AddGracePeriod, the domain does not go through an EOL process and RGP Statuses are set to it can be deleted immediately.
 // This funciton depends on downstream logic to purge the domain from the repository, we just set the RGP time parameters here.
@@ -533,12 +605,65 @@ func (d *Domain) Restore() error {
 	if err != nil {
 		return errors.Join(ErrDomainRestoreNotAllowed, err)
 	}
+	// The registrar has to submit a restore report within the pendingRestore period, reports of previous restores are discarded
+	d.RGPStatus.PendingRestorePeriodEnd = time.Now().UTC().AddDate(0, 0, PendingRestorePeriod)
+	d.RestoreReport = DomainRestoreReport{}
 	// Set the registrar who made the update
 	d.UpRr = d.ClID
 
 	return nil
 }
 
+// SubmitRestoreReport records the restore report of a domain in pendingRestore status.
+// Only one report is accepted per restore and it must be received before the pendingRestore period ends.
+func (d *Domain) SubmitRestoreReport(report DomainRestoreReport) error {
+	if !d.Status.PendingRestore {
+		return errors.Join(ErrRestoreReportNotAllowed, fmt.Errorf("domain is not in %s status", DomainStatusPendingRestore))
+	}
+	if !d.RestoreReport.IsNil() {
+		return errors.Join(ErrRestoreReportNotAllowed, fmt.Errorf("a restore report was already received %s", d.RestoreReport.ReportedAt.Format(time.RFC3339)))
+	}
+	if !time.Now().UTC().Before(d.RGPStatus.PendingRestorePeriodEnd) {
+		return errors.Join(ErrRestoreReportNotAllowed, ErrRestoreReportOverdue)
+	}
+	if err := report.Validate(); err != nil {
+		return err
+	}
+	report.ReportedAt = time.Now().UTC()
+	d.RestoreReport = report
+	d.UpRr = d.ClID
+	return nil
+}
+
+// CompleteRestore unsets the pendingRestore status of a domain once the restore report was received.
+// This relies on the calling code to renew the domain, which is how the restore is charged.
+func (d *Domain) CompleteRestore() error {
+	if !d.Status.PendingRestore {
+		return errors.Join(ErrDomainRestoreNotAllowed, fmt.Errorf("domain is not in %s status", DomainStatusPendingRestore))
+	}
+	if d.RestoreReport.IsNil() {
+		return ErrRestoreReportPending
+	}
+	return d.UnSetStatus(DomainStatusPendingRestore)
+}
+
+// CancelRestore returns a domain to the redemption period if its restore report was not received within the pendingRestore period (RFC 3915)
+func (d *Domain) CancelRestore() error {
+	if !d.Status.PendingRestore {
+		return errors.Join(ErrDomainRestoreNotAllowed, fmt.Errorf("domain is not in %s status", DomainStatusPendingRestore))
+	}
+	if !d.RestoreReport.IsNil() {
+		return errors.Join(ErrDomainRestoreNotAllowed, errors.New("the restore report was received"))
+	}
+	if time.Now().UTC().Before(d.RGPStatus.PendingRestorePeriodEnd) {
+		return ErrRestoreReportPending
+	}
+	if err := d.UnSetStatus(DomainStatusPendingRestore); err != nil {
+		return err
+	}
+	return d.SetStatus(DomainStatusPendingDelete)
+}
+
 // IsGrandFathered checks if the domain is grand fathered
 func (d *Domain) IsGrandFathered() bool {
 	if d.GrandFathering.GFAmount == 0 && d.GrandFathering.GFCurrency == "" {
@@ -616,9 +741,23 @@ func (d *Domain) DeepCopy() *Domain {
 		Status:         d.Status,         // Struct copied by value
 		RGPStatus:      d.RGPStatus,      // Struct copied by value
 		GrandFathering: d.GrandFathering, // Struct copied by value
+		SecDNS:         DomainSecDNS{MaxSigLife: d.SecDNS.MaxSigLife},
+		RestoreReport:  d.RestoreReport, // Statements handled below
 		// Hosts handled below
 	}
 
+	// Copy the DS data so changes to the copy don't affect the original.
+	if d.SecDNS.DSData != nil {
+		newDomain.SecDNS.DSData = make([]DomainDSData, len(d.SecDNS.DSData))
+		copy(newDomain.SecDNS.DSData, d.SecDNS.DSData)
+	}
+
+	// Copy the restore report statements
+	if d.RestoreReport.Statements != nil {
+		newDomain.RestoreReport.Statements = make([]string, len(d.RestoreReport.Statements))
+		copy(newDomain.RestoreReport.Statements, d.RestoreReport.Statements)
+	}
+
 	// Deep-copy the Hosts slice, calling Host.DeepCopy() on each.
 	if d.Hosts != nil {
 		newDomain.Hosts = make([]*Host, len(d.Hosts))
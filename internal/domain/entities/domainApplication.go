@@ -0,0 +1,174 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ApplicationStatus is the status of a domain application as defined in RFC 8334
+// Ref: https://datatracker.ietf.org/doc/html/rfc8334#section-2.3
+type ApplicationStatus string
+
+const (
+	// ApplicationStatusPendingValidation means the application was received and waits for the registry to validate it (e.g. the mark)
+	ApplicationStatusPendingValidation ApplicationStatus = "pendingValidation"
+	// ApplicationStatusValidated means the application was validated by the registry
+	ApplicationStatusValidated ApplicationStatus = "validated"
+	// ApplicationStatusInvalid means the registry could not validate the application
+	ApplicationStatusInvalid ApplicationStatus = "invalid"
+	// ApplicationStatusPendingAllocation means the application is valid and waits for allocation (e.g. in an auction of competing applications)
+	ApplicationStatusPendingAllocation ApplicationStatus = "pendingAllocation"
+	// ApplicationStatusAllocated means the domain was registered to the applicant
+	ApplicationStatusAllocated ApplicationStatus = "allocated"
+	// ApplicationStatusRejected means the application was not allocated (e.g. the domain was allocated to another applicant)
+	ApplicationStatusRejected ApplicationStatus = "rejected"
+)
+
+var (
+	// ErrDomainApplicationNotFound is returned when a domain application does not exist
+	ErrDomainApplicationNotFound = errors.New("domain application not found")
+	// ErrInvalidDomainApplication is returned when a domain application can't be created
+	ErrInvalidDomainApplication = errors.New("invalid domain application")
+	// ErrInvalidApplicationStatus is returned when the status of an application can't be set
+	ErrInvalidApplicationStatus = errors.New("invalid application status")
+	// ErrDomainApplicationFinal is returned when trying to change an application that is allocated, rejected or invalid
+	ErrDomainApplicationFinal = errors.New("domain application is allocated, rejected or invalid and cannot be changed")
+
+	// applicationStatusTransitions lists the statuses an application can move to from each status. Final statuses can't be changed.
+	applicationStatusTransitions = map[ApplicationStatus][]ApplicationStatus{
+		ApplicationStatusPendingValidation: {ApplicationStatusValidated, ApplicationStatusInvalid, ApplicationStatusRejected},
+		ApplicationStatusValidated:         {ApplicationStatusPendingAllocation, ApplicationStatusAllocated, ApplicationStatusRejected},
+		ApplicationStatusPendingAllocation: {ApplicationStatusAllocated, ApplicationStatusRejected},
+	}
+)
+
+// DomainApplication is an application for a domain in a launch phase that requires validation. Competing applications for the same domain can exist,
+// the registry validates them and allocates the domain to one of the applicants. The applicant is charged when the domain is allocated.
+// Ref: https://datatracker.ietf.org/doc/html/rfc8334#section-3.3
+type DomainApplication struct {
+	// ID is the applicationID that is returned to the registrar
+	ID uuid.UUID
+	// DomainName is the name of the domain applied for
+	DomainName DomainName
+	// PhaseName is the name of the launch phase the application was created in
+	PhaseName ClIDType
+	// ClID is the registrar that created the application
+	ClID ClIDType
+	// Status is the status of the application
+	Status ApplicationStatus
+	// The registration data of the domain, it is used when the domain is allocated
+	AuthInfo     AuthInfoType
+	RegistrantID ClIDType
+	AdminID      ClIDType
+	TechID       ClIDType
+	BillingID    ClIDType
+	HostNames    []string
+	Years        int
+	// Mark is the mark provided by the applicant (e.g. an encoded signed mark), it is validated by the registry
+	Mark string
+	// NoticeID is the ID of the claims notice accepted by the registrant, if any
+	NoticeID string
+	// DomainRoID is the RoID of the domain once the application is allocated
+	DomainRoID RoidType
+	// Reason is an optional reason for the current status (e.g. why the application is invalid)
+	Reason string
+	// CorrelationID of the request that last changed the status of the application
+	CorrelationID string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// NewDomainApplication creates a new DomainApplication pending validation for the domain in the launch phase on behalf of the registrar
+func NewDomainApplication(name, phaseName, clid string, years int) (*DomainApplication, error) {
+	domainName, err := NewDomainName(name)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidDomainApplication, err)
+	}
+	if phaseName == "" {
+		return nil, errors.Join(ErrInvalidDomainApplication, ErrPhaseNotProvided)
+	}
+	if clid == "" {
+		return nil, errors.Join(ErrInvalidDomainApplication, ErrEmptyClientID)
+	}
+	if years < 1 {
+		return nil, errors.Join(ErrInvalidDomainApplication, ErrZeroRenewalPeriod)
+	}
+	now := time.Now().UTC()
+	return &DomainApplication{
+		ID:         uuid.New(),
+		DomainName: *domainName,
+		PhaseName:  ClIDType(phaseName),
+		ClID:       ClIDType(clid),
+		Status:     ApplicationStatusPendingValidation,
+		Years:      years,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}, nil
+}
+
+// IsFinal returns true if the application is allocated, rejected or invalid. A final application can't be updated.
+func (a *DomainApplication) IsFinal() bool {
+	_, ok := applicationStatusTransitions[a.Status]
+	return !ok
+}
+
+// SetStatus moves the application to the provided status with an optional reason.
+// Setting the current status again is idempotent. Use Allocate to allocate the application.
+func (a *DomainApplication) SetStatus(status ApplicationStatus, reason string) error {
+	if status == a.Status {
+		return nil
+	}
+	if a.IsFinal() {
+		return ErrDomainApplicationFinal
+	}
+	if status == ApplicationStatusAllocated || !slices.Contains(applicationStatusTransitions[a.Status], status) {
+		return errors.Join(ErrInvalidApplicationStatus, fmt.Errorf("cannot change status from %s to %s", a.Status, status))
+	}
+	a.Status = status
+	a.Reason = reason
+	a.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// CanBeAllocated returns true if the application is validated or pending allocation
+func (a *DomainApplication) CanBeAllocated() bool {
+	return slices.Contains(applicationStatusTransitions[a.Status], ApplicationStatusAllocated)
+}
+
+// Allocate marks the application as allocated to the domain with the provided RoID. Only validated applications can be allocated.
+func (a *DomainApplication) Allocate(domainRoID RoidType) error {
+	if a.IsFinal() {
+		return ErrDomainApplicationFinal
+	}
+	if !a.CanBeAllocated() {
+		return errors.Join(ErrInvalidApplicationStatus, fmt.Errorf("cannot allocate an application with status %s", a.Status))
+	}
+	a.Status = ApplicationStatusAllocated
+	a.DomainRoID = domainRoID
+	a.Reason = ""
+	a.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// AddHostName adds a nameserver to the application, adding a nameserver twice is idempotent
+func (a *DomainApplication) AddHostName(name string) {
+	name = strings.ToLower(name)
+	if !slices.Contains(a.HostNames, name) {
+		a.HostNames = append(a.HostNames, name)
+	}
+}
+
+// RemoveHostName removes a nameserver from the application. It returns ErrHostNotFound if the application does not use the nameserver.
+func (a *DomainApplication) RemoveHostName(name string) error {
+	i := slices.Index(a.HostNames, strings.ToLower(name))
+	if i < 0 {
+		return errors.Join(ErrHostNotFound, fmt.Errorf("%s is not a nameserver of the application", name))
+	}
+	a.HostNames = slices.Delete(a.HostNames, i, i+1)
+	return nil
+}
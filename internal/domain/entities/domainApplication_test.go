@@ -0,0 +1,101 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDomainApplication(t *testing.T) {
+	tests := []struct {
+		name      string
+		domain    string
+		phaseName string
+		clid      string
+		years     int
+		wantErr   error
+	}{
+		{"valid", "Example.com", "landrush", "ClID-1", 1, nil},
+		{"invalid name", "-example.com", "landrush", "ClID-1", 1, ErrInvalidDomainApplication},
+		{"missing phase", "example.com", "", "ClID-1", 1, ErrPhaseNotProvided},
+		{"missing clid", "example.com", "landrush", "", 1, ErrEmptyClientID},
+		{"zero years", "example.com", "landrush", "ClID-1", 0, ErrZeroRenewalPeriod},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, err := NewDomainApplication(tt.domain, tt.phaseName, tt.clid, tt.years)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, app)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, DomainName("example.com"), app.DomainName)
+			require.Equal(t, ApplicationStatusPendingValidation, app.Status)
+			require.False(t, app.IsFinal())
+			require.False(t, app.CreatedAt.IsZero())
+		})
+	}
+}
+
+func TestDomainApplication_SetStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    ApplicationStatus
+		to      ApplicationStatus
+		wantErr error
+	}{
+		{"validate", ApplicationStatusPendingValidation, ApplicationStatusValidated, nil},
+		{"invalidate", ApplicationStatusPendingValidation, ApplicationStatusInvalid, nil},
+		{"reject pending validation", ApplicationStatusPendingValidation, ApplicationStatusRejected, nil},
+		{"pending allocation", ApplicationStatusValidated, ApplicationStatusPendingAllocation, nil},
+		{"reject pending allocation", ApplicationStatusPendingAllocation, ApplicationStatusRejected, nil},
+		{"idempotent", ApplicationStatusValidated, ApplicationStatusValidated, nil},
+		{"skip validation", ApplicationStatusPendingValidation, ApplicationStatusPendingAllocation, ErrInvalidApplicationStatus},
+		{"allocate through status", ApplicationStatusValidated, ApplicationStatusAllocated, ErrInvalidApplicationStatus},
+		{"unknown status", ApplicationStatusValidated, "won", ErrInvalidApplicationStatus},
+		{"final", ApplicationStatusInvalid, ApplicationStatusValidated, ErrDomainApplicationFinal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &DomainApplication{Status: tt.from}
+			err := app.SetStatus(tt.to, "reason")
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Equal(t, tt.from, app.Status)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.to, app.Status)
+		})
+	}
+}
+
+func TestDomainApplication_Allocate(t *testing.T) {
+	app := &DomainApplication{Status: ApplicationStatusPendingValidation}
+	require.ErrorIs(t, app.Allocate("1234_DOM-APEX"), ErrInvalidApplicationStatus)
+
+	app.Status = ApplicationStatusPendingAllocation
+	app.Reason = "auction"
+	require.NoError(t, app.Allocate("1234_DOM-APEX"))
+	require.Equal(t, ApplicationStatusAllocated, app.Status)
+	require.Equal(t, RoidType("1234_DOM-APEX"), app.DomainRoID)
+	require.Empty(t, app.Reason)
+	require.True(t, app.IsFinal())
+
+	require.ErrorIs(t, app.Allocate("1234_DOM-APEX"), ErrDomainApplicationFinal)
+}
+
+func TestDomainApplication_HostNames(t *testing.T) {
+	app := &DomainApplication{}
+	app.AddHostName("NS1.example.net")
+	app.AddHostName("ns1.example.net")
+	app.AddHostName("ns2.example.net")
+	require.Equal(t, []string{"ns1.example.net", "ns2.example.net"}, app.HostNames)
+
+	require.NoError(t, app.RemoveHostName("NS1.example.net"))
+	require.Equal(t, []string{"ns2.example.net"}, app.HostNames)
+	require.ErrorIs(t, app.RemoveHostName("ns1.example.net"), ErrHostNotFound)
+}
@@ -14,11 +14,47 @@ type DomainRGPStatus struct {
 	TransferLockPeriodEnd time.Time `json:"transferLockPeriodEnd"`
 	// RedemptionPeriodEnd is the date after which the domain will no longer be restorable it will remain in the repository until the purge date.
 	RedemptionPeriodEnd time.Time `json:"redemptionPeriodEnd"`
+	// PendingRestorePeriodEnd is the end of the period in which the registrar must submit the restore report after a restore request, after which the domain returns to the redemption period.
+	PendingRestorePeriodEnd time.Time `json:"pendingRestorePeriodEnd"`
 	// PurgeDate is date after which the domain should be purged and become available for registration again.
 	PurgeDate time.Time `json:"purgeDate" gorm:"index"`
 }
 
 // IsNil checks if the DomainRGPStatus object is nil
 func (d *DomainRGPStatus) IsNil() bool {
-	return d.AddPeriodEnd.IsZero() && d.RenewPeriodEnd.IsZero() && d.AutoRenewPeriodEnd.IsZero() && d.TransferLockPeriodEnd.IsZero() && d.RedemptionPeriodEnd.IsZero() && d.PurgeDate.IsZero()
+	return d.AddPeriodEnd.IsZero() && d.RenewPeriodEnd.IsZero() && d.AutoRenewPeriodEnd.IsZero() && d.TransferLockPeriodEnd.IsZero() && d.RedemptionPeriodEnd.IsZero() && d.PendingRestorePeriodEnd.IsZero() && d.PurgeDate.IsZero()
+}
+
+// RGP status values as defined in RFC 3915
+const (
+	RGPStatusAddPeriod        = "addPeriod"
+	RGPStatusAutoRenewPeriod  = "autoRenewPeriod"
+	RGPStatusRenewPeriod      = "renewPeriod"
+	RGPStatusRedemptionPeriod = "redemptionPeriod"
+	RGPStatusPendingRestore   = "pendingRestore"
+	RGPStatusPendingDelete    = "pendingDelete"
+)
+
+// RGPStatuses returns the RGP status values (RFC 3915) that apply to the domain at the provided time
+func (d *Domain) RGPStatuses(at time.Time) []string {
+	if d.Status.PendingRestore {
+		return []string{RGPStatusPendingRestore}
+	}
+	if d.Status.PendingDelete {
+		if at.Before(d.RGPStatus.RedemptionPeriodEnd) {
+			return []string{RGPStatusRedemptionPeriod}
+		}
+		return []string{RGPStatusPendingDelete}
+	}
+	var statuses []string
+	if at.Before(d.RGPStatus.AddPeriodEnd) {
+		statuses = append(statuses, RGPStatusAddPeriod)
+	}
+	if at.Before(d.RGPStatus.RenewPeriodEnd) {
+		statuses = append(statuses, RGPStatusRenewPeriod)
+	}
+	if at.Before(d.RGPStatus.AutoRenewPeriodEnd) {
+		statuses = append(statuses, RGPStatusAutoRenewPeriod)
+	}
+	return statuses
 }
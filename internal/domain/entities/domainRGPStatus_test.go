@@ -16,3 +16,28 @@ func TestDomainRGPStatus_IsNil(t *testing.T) {
 	}
 	require.False(t, rgp.IsNil())
 }
+
+func TestDomain_RGPStatuses(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		status DomainStatus
+		rgp    DomainRGPStatus
+		want   []string
+	}{
+		{"no grace periods", DomainStatus{OK: true}, DomainRGPStatus{}, nil},
+		{"add period", DomainStatus{OK: true}, DomainRGPStatus{AddPeriodEnd: now.AddDate(0, 0, 5)}, []string{RGPStatusAddPeriod}},
+		{"expired add period", DomainStatus{OK: true}, DomainRGPStatus{AddPeriodEnd: now.AddDate(0, 0, -1)}, nil},
+		{"renew and auto renew period", DomainStatus{OK: true}, DomainRGPStatus{RenewPeriodEnd: now.AddDate(0, 0, 5), AutoRenewPeriodEnd: now.AddDate(0, 0, 45)}, []string{RGPStatusRenewPeriod, RGPStatusAutoRenewPeriod}},
+		{"redemption period", DomainStatus{PendingDelete: true}, DomainRGPStatus{RedemptionPeriodEnd: now.AddDate(0, 0, 30)}, []string{RGPStatusRedemptionPeriod}},
+		{"pending delete", DomainStatus{PendingDelete: true}, DomainRGPStatus{RedemptionPeriodEnd: now.AddDate(0, 0, -1)}, []string{RGPStatusPendingDelete}},
+		{"pending restore", DomainStatus{PendingRestore: true}, DomainRGPStatus{RedemptionPeriodEnd: now.AddDate(0, 0, 30)}, []string{RGPStatusPendingRestore}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Domain{Status: tt.status, RGPStatus: tt.rgp}
+			require.Equal(t, tt.want, d.RGPStatuses(now))
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// PendingRestorePeriod is the number of days the registry waits for the restore report after a restore request (RFC 3915)
+	PendingRestorePeriod = 7
+	// RestoreReportStatements is the number of statements a restore report must contain (RFC 3915)
+	RestoreReportStatements = 2
+)
+
+var (
+	ErrInvalidRestoreReport    = errors.New("invalid restore report")
+	ErrRestoreReportNotAllowed = errors.New("restore report not allowed")
+	ErrRestoreReportPending    = errors.New("restore report has not been received")
+	ErrRestoreReportOverdue    = errors.New("restore report was not received within the pendingRestore period")
+)
+
+// DomainRestoreReport value object is the report a registrar submits to complete the restore of a domain.
+// It documents the registration data before the deletion and after the restore, and the reason for the restore.
+// Ref: https://datatracker.ietf.org/doc/html/rfc3915#section-4.2.5
+type DomainRestoreReport struct {
+	// PreData is a copy of the registration data that existed for the domain prior to the domain being deleted
+	PreData string `json:"preData"`
+	// PostData is a copy of the registration data that exists for the domain at the time the restore report is submitted
+	PostData string `json:"postData"`
+	// DelTime is the date and time when the domain delete request was sent to the registry
+	DelTime time.Time `json:"delTime"`
+	// ResTime is the date and time when the original restore request was sent to the registry
+	ResTime time.Time `json:"resTime"`
+	// ResReason is a brief explanation of the reason for restoring the domain
+	ResReason string `json:"resReason"`
+	// Statements are the text statements the registrar has not restored the domain to assume its rights for use or sale, and that the information in the report is factual
+	Statements []string `json:"statements" gorm:"serializer:json"`
+	// Other is any information needed to support the statements provided by the registrar
+	Other string `json:"other"`
+	// ReportedAt is the date and time the report was received by the registry, it is zero if no report was received
+	ReportedAt time.Time `json:"reportedAt"`
+}
+
+// IsNil checks if a restore report was received
+func (r *DomainRestoreReport) IsNil() bool {
+	return r.ReportedAt.IsZero()
+}
+
+// Validate checks that all mandatory fields of the report are set and that the restore request was made after the delete request
+func (r *DomainRestoreReport) Validate() error {
+	if strings.TrimSpace(r.PreData) == "" {
+		return errors.Join(ErrInvalidRestoreReport, errors.New("preData is required"))
+	}
+	if strings.TrimSpace(r.PostData) == "" {
+		return errors.Join(ErrInvalidRestoreReport, errors.New("postData is required"))
+	}
+	if r.DelTime.IsZero() || r.ResTime.IsZero() {
+		return errors.Join(ErrInvalidRestoreReport, errors.New("delTime and resTime are required"))
+	}
+	if r.ResTime.Before(r.DelTime) {
+		return errors.Join(ErrInvalidRestoreReport, errors.New("resTime must be after delTime"))
+	}
+	if strings.TrimSpace(r.ResReason) == "" {
+		return errors.Join(ErrInvalidRestoreReport, errors.New("resReason is required"))
+	}
+	if len(r.Statements) != RestoreReportStatements || slices.ContainsFunc(r.Statements, func(s string) bool { return strings.TrimSpace(s) == "" }) {
+		return errors.Join(ErrInvalidRestoreReport, fmt.Errorf("exactly %d statements are required", RestoreReportStatements))
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func getValidRestoreReport() DomainRestoreReport {
+	delTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return DomainRestoreReport{
+		PreData:    "Pre-delete registration data goes here.",
+		PostData:   "Post-restore registration data goes here.",
+		DelTime:    delTime,
+		ResTime:    delTime.AddDate(0, 0, 2),
+		ResReason:  "deleted by mistake",
+		Statements: []string{"This registrar has not restored the Registered Name in order to assume the rights to use or sell the Registered Name for itself or for any third party.", "The information in this report is true to best of this registrar's knowledge, and this registrar acknowledges that intentionally supplying false information in this report shall constitute an incurable material breach of the Registry-Registrar Agreement."},
+		Other:      "Supporting information goes here.",
+	}
+}
+
+func TestDomainRestoreReport_IsNil(t *testing.T) {
+	report := getValidRestoreReport()
+	require.True(t, report.IsNil())
+
+	report.ReportedAt = time.Now().UTC()
+	require.False(t, report.IsNil())
+}
+
+func TestDomainRestoreReport_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(r *DomainRestoreReport)
+		wantErr error
+	}{
+		{"valid", func(r *DomainRestoreReport) {}, nil},
+		{"other is optional", func(r *DomainRestoreReport) { r.Other = "" }, nil},
+		{"missing preData", func(r *DomainRestoreReport) { r.PreData = " " }, ErrInvalidRestoreReport},
+		{"missing postData", func(r *DomainRestoreReport) { r.PostData = "" }, ErrInvalidRestoreReport},
+		{"missing delTime", func(r *DomainRestoreReport) { r.DelTime = time.Time{} }, ErrInvalidRestoreReport},
+		{"resTime before delTime", func(r *DomainRestoreReport) { r.ResTime = r.DelTime.Add(-time.Hour) }, ErrInvalidRestoreReport},
+		{"missing resReason", func(r *DomainRestoreReport) { r.ResReason = "" }, ErrInvalidRestoreReport},
+		{"one statement", func(r *DomainRestoreReport) { r.Statements = r.Statements[:1] }, ErrInvalidRestoreReport},
+		{"empty statement", func(r *DomainRestoreReport) { r.Statements[1] = "" }, ErrInvalidRestoreReport},
+		{"three statements", func(r *DomainRestoreReport) { r.Statements = append(r.Statements, "three") }, ErrInvalidRestoreReport},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := getValidRestoreReport()
+			tt.modify(&report)
+			require.ErrorIs(t, report.Validate(), tt.wantErr)
+		})
+	}
+}
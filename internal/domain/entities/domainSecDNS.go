@@ -0,0 +1,176 @@
+package entities
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	MaxDSDataPerDomain = 8
+
+	// DS digest types as defined in the IANA "Delegation Signer (DS) Resource Record (RR) Type Digest Algorithms" registry
+	DSDigestTypeSHA1   = 1
+	DSDigestTypeSHA256 = 2
+	DSDigestTypeGOST   = 3
+	DSDigestTypeSHA384 = 4
+)
+
+var (
+	ErrInvalidDSData              = errors.New("invalid DS data")
+	ErrInvalidDSKeyTag            = errors.New("DS keyTag must be between 0 and 65535")
+	ErrInvalidDSAlgorithm         = errors.New("DS algorithm must be between 1 and 255")
+	ErrUnsupportedDSDigestType    = errors.New("unsupported DS digest type")
+	ErrInvalidDSDigest            = errors.New("DS digest must be a hexadecimal string matching the length of its digest type")
+	ErrInvalidMaxSigLife          = fmt.Errorf("maxSigLife must be between 1 and %d seconds", math.MaxInt32)
+	ErrDuplicateDSData            = errors.New("the DS data is already associated with the domain")
+	ErrDSDataNotFound             = errors.New("the DS data is not associated with the domain")
+	ErrMaxDSDataPerDomainExceeded = fmt.Errorf("domain can contain %d DS records at most", MaxDSDataPerDomain)
+
+	// dsDigestLengths maps the supported digest types to the length of their hexadecimal digest
+	dsDigestLengths = map[uint8]int{
+		DSDigestTypeSHA1:   40,
+		DSDigestTypeSHA256: 64,
+		DSDigestTypeGOST:   64,
+		DSDigestTypeSHA384: 96,
+	}
+)
+
+// DomainDSData holds a Delegation Signer record of a domain as provisioned through the EPP secDNS extension (dsData interface).
+// Ref: https://datatracker.ietf.org/doc/html/rfc5910
+type DomainDSData struct {
+	KeyTag     uint16 `json:"KeyTag"`
+	Alg        uint8  `json:"Alg"`
+	DigestType uint8  `json:"DigestType"`
+	Digest     string `json:"Digest"` // uppercase hexadecimal, as it is presented in the DS RR
+}
+
+// NewDomainDSData creates a new instance of DomainDSData. The digest is normalized to uppercase hexadecimal without whitespace.
+func NewDomainDSData(keyTag, alg, digestType int, digest string) (*DomainDSData, error) {
+	if keyTag < 0 || keyTag > math.MaxUint16 {
+		return nil, errors.Join(ErrInvalidDSData, ErrInvalidDSKeyTag)
+	}
+	if alg < 1 || alg > math.MaxUint8 {
+		return nil, errors.Join(ErrInvalidDSData, ErrInvalidDSAlgorithm)
+	}
+	if digestType < 0 || digestType > math.MaxUint8 {
+		return nil, errors.Join(ErrInvalidDSData, ErrUnsupportedDSDigestType)
+	}
+	ds := &DomainDSData{
+		KeyTag:     uint16(keyTag),
+		Alg:        uint8(alg),
+		DigestType: uint8(digestType),
+		Digest:     strings.ToUpper(strings.Join(strings.Fields(digest), "")),
+	}
+	if err := ds.Validate(); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
+// Validate checks that the algorithm is set, the digest type is supported and the digest is hexadecimal with the length of its digest type
+func (ds DomainDSData) Validate() error {
+	if ds.Alg == 0 {
+		return errors.Join(ErrInvalidDSData, ErrInvalidDSAlgorithm)
+	}
+	length, ok := dsDigestLengths[ds.DigestType]
+	if !ok {
+		return errors.Join(ErrInvalidDSData, ErrUnsupportedDSDigestType)
+	}
+	if len(ds.Digest) != length {
+		return errors.Join(ErrInvalidDSData, ErrInvalidDSDigest)
+	}
+	if _, err := hex.DecodeString(ds.Digest); err != nil {
+		return errors.Join(ErrInvalidDSData, ErrInvalidDSDigest)
+	}
+	return nil
+}
+
+// Equal checks if two DS records are the same, the digest is compared case insensitive
+func (ds DomainDSData) Equal(other DomainDSData) bool {
+	return ds.KeyTag == other.KeyTag &&
+		ds.Alg == other.Alg &&
+		ds.DigestType == other.DigestType &&
+		strings.EqualFold(ds.Digest, other.Digest)
+}
+
+// DomainSecDNS holds the DNSSEC delegation data of a domain
+type DomainSecDNS struct {
+	MaxSigLife int            `json:"MaxSigLife,omitempty"` // the child's preference for the number of seconds after signature generation when the parent's signature on the DS information should expire, 0 means no preference
+	DSData     []DomainDSData `json:"DSData"`
+}
+
+// Validate checks the maxSigLife, the individual DS records and that there are no duplicates
+func (s DomainSecDNS) Validate() error {
+	if s.MaxSigLife < 0 || s.MaxSigLife > math.MaxInt32 {
+		return ErrInvalidMaxSigLife
+	}
+	if len(s.DSData) > MaxDSDataPerDomain {
+		return ErrMaxDSDataPerDomainExceeded
+	}
+	for i, ds := range s.DSData {
+		if err := ds.Validate(); err != nil {
+			return err
+		}
+		for _, other := range s.DSData[:i] {
+			if ds.Equal(other) {
+				return ErrDuplicateDSData
+			}
+		}
+	}
+	return nil
+}
+
+// IsSigned checks if DS data is present, which makes the delegation signed
+func (s DomainSecDNS) IsSigned() bool {
+	return len(s.DSData) > 0
+}
+
+// IsSigned checks if the domain has a signed delegation (e.g. has at least one DS record)
+func (d *Domain) IsSigned() bool {
+	return d.SecDNS.IsSigned()
+}
+
+// AddDSData adds a DS record to the domain after validating it
+func (d *Domain) AddDSData(ds DomainDSData) error {
+	if err := ds.Validate(); err != nil {
+		return err
+	}
+	for _, existing := range d.SecDNS.DSData {
+		if existing.Equal(ds) {
+			return ErrDuplicateDSData
+		}
+	}
+	if len(d.SecDNS.DSData) >= MaxDSDataPerDomain {
+		return ErrMaxDSDataPerDomainExceeded
+	}
+	d.SecDNS.DSData = append(d.SecDNS.DSData, ds)
+	return nil
+}
+
+// RemoveDSData removes a DS record from the domain
+func (d *Domain) RemoveDSData(ds DomainDSData) error {
+	for i, existing := range d.SecDNS.DSData {
+		if existing.Equal(ds) {
+			d.SecDNS.DSData = append(d.SecDNS.DSData[:i], d.SecDNS.DSData[i+1:]...)
+			return nil
+		}
+	}
+	return ErrDSDataNotFound
+}
+
+// RemoveAllDSData removes all DS records from the domain, which makes the delegation unsigned
+func (d *Domain) RemoveAllDSData() {
+	d.SecDNS.DSData = nil
+}
+
+// SetMaxSigLife sets the maxSigLife of the domain, 0 removes the preference
+func (d *Domain) SetMaxSigLife(maxSigLife int) error {
+	if maxSigLife < 0 || maxSigLife > math.MaxInt32 {
+		return ErrInvalidMaxSigLife
+	}
+	d.SecDNS.MaxSigLife = maxSigLife
+	return nil
+}
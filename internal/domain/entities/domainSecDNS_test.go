@@ -0,0 +1,156 @@
+package entities
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testDSDigestSHA256 = "E2D3C916F6DEEAC73294E8268FB5885044A833FC5459588F4A9184CFC41A5766"
+
+func TestNewDomainDSData(t *testing.T) {
+	tests := []struct {
+		name       string
+		keyTag     int
+		alg        int
+		digestType int
+		digest     string
+		want       *DomainDSData
+		wantErr    error
+	}{
+		{
+			name:       "valid SHA-256",
+			keyTag:     12345,
+			alg:        13,
+			digestType: DSDigestTypeSHA256,
+			digest:     testDSDigestSHA256,
+			want:       &DomainDSData{KeyTag: 12345, Alg: 13, DigestType: 2, Digest: testDSDigestSHA256},
+		},
+		{
+			name:       "digest is normalized",
+			keyTag:     0,
+			alg:        8,
+			digestType: DSDigestTypeSHA256,
+			digest:     " e2d3c916f6deeac73294e8268fb58850 44a833fc5459588f4a9184cfc41a5766\n",
+			want:       &DomainDSData{KeyTag: 0, Alg: 8, DigestType: 2, Digest: testDSDigestSHA256},
+		},
+		{
+			name:       "valid SHA-1",
+			keyTag:     65535,
+			alg:        5,
+			digestType: DSDigestTypeSHA1,
+			digest:     strings.Repeat("A", 40),
+			want:       &DomainDSData{KeyTag: 65535, Alg: 5, DigestType: 1, Digest: strings.Repeat("A", 40)},
+		},
+		{
+			name:       "keyTag out of range",
+			keyTag:     65536,
+			alg:        13,
+			digestType: DSDigestTypeSHA256,
+			digest:     testDSDigestSHA256,
+			wantErr:    ErrInvalidDSKeyTag,
+		},
+		{
+			name:       "algorithm zero",
+			keyTag:     12345,
+			alg:        0,
+			digestType: DSDigestTypeSHA256,
+			digest:     testDSDigestSHA256,
+			wantErr:    ErrInvalidDSAlgorithm,
+		},
+		{
+			name:       "unsupported digest type",
+			keyTag:     12345,
+			alg:        13,
+			digestType: 5,
+			digest:     testDSDigestSHA256,
+			wantErr:    ErrUnsupportedDSDigestType,
+		},
+		{
+			name:       "digest length does not match the digest type",
+			keyTag:     12345,
+			alg:        13,
+			digestType: DSDigestTypeSHA384,
+			digest:     testDSDigestSHA256,
+			wantErr:    ErrInvalidDSDigest,
+		},
+		{
+			name:       "digest not hexadecimal",
+			keyTag:     12345,
+			alg:        13,
+			digestType: DSDigestTypeSHA1,
+			digest:     strings.Repeat("G", 40),
+			wantErr:    ErrInvalidDSDigest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ds, err := NewDomainDSData(tc.keyTag, tc.alg, tc.digestType, tc.digest)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				require.ErrorIs(t, err, ErrInvalidDSData)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, ds)
+		})
+	}
+}
+
+func TestDomainSecDNS_Validate(t *testing.T) {
+	ds := DomainDSData{KeyTag: 12345, Alg: 13, DigestType: 2, Digest: testDSDigestSHA256}
+
+	require.NoError(t, DomainSecDNS{}.Validate())
+	require.NoError(t, DomainSecDNS{MaxSigLife: 604800, DSData: []DomainDSData{ds}}.Validate())
+	require.ErrorIs(t, DomainSecDNS{MaxSigLife: -1}.Validate(), ErrInvalidMaxSigLife)
+	require.ErrorIs(t, DomainSecDNS{MaxSigLife: math.MaxInt32 + 1}.Validate(), ErrInvalidMaxSigLife)
+	require.ErrorIs(t, DomainSecDNS{DSData: []DomainDSData{ds, ds}}.Validate(), ErrDuplicateDSData)
+	require.ErrorIs(t, DomainSecDNS{DSData: []DomainDSData{{KeyTag: 1, Alg: 13, DigestType: 2}}}.Validate(), ErrInvalidDSDigest)
+
+	tooMany := DomainSecDNS{}
+	for i := 0; i <= MaxDSDataPerDomain; i++ {
+		tooMany.DSData = append(tooMany.DSData, DomainDSData{KeyTag: uint16(i), Alg: 13, DigestType: 2, Digest: testDSDigestSHA256})
+	}
+	require.ErrorIs(t, tooMany.Validate(), ErrMaxDSDataPerDomainExceeded)
+}
+
+func TestDomain_DSData(t *testing.T) {
+	d := &Domain{}
+	require.False(t, d.IsSigned())
+
+	ds1 := DomainDSData{KeyTag: 12345, Alg: 13, DigestType: 2, Digest: testDSDigestSHA256}
+	ds2 := DomainDSData{KeyTag: 54321, Alg: 13, DigestType: 2, Digest: testDSDigestSHA256}
+
+	require.NoError(t, d.AddDSData(ds1))
+	require.NoError(t, d.AddDSData(ds2))
+	require.True(t, d.IsSigned())
+
+	// The digest is compared case insensitive
+	lower := ds1
+	lower.Digest = strings.ToLower(lower.Digest)
+	require.ErrorIs(t, d.AddDSData(lower), ErrDuplicateDSData)
+	require.ErrorIs(t, d.AddDSData(DomainDSData{KeyTag: 1, Alg: 13, DigestType: 7, Digest: testDSDigestSHA256}), ErrUnsupportedDSDigestType)
+
+	require.NoError(t, d.RemoveDSData(lower))
+	require.Equal(t, []DomainDSData{ds2}, d.SecDNS.DSData)
+	require.ErrorIs(t, d.RemoveDSData(ds1), ErrDSDataNotFound)
+
+	require.NoError(t, d.SetMaxSigLife(3600))
+	require.ErrorIs(t, d.SetMaxSigLife(-1), ErrInvalidMaxSigLife)
+	require.Equal(t, 3600, d.SecDNS.MaxSigLife)
+
+	// Changes to a copy do not affect the original
+	copied := d.DeepCopy()
+	copied.RemoveAllDSData()
+	require.False(t, copied.IsSigned())
+	require.True(t, d.IsSigned())
+	require.Equal(t, 3600, copied.SecDNS.MaxSigLife)
+
+	for i := 0; len(d.SecDNS.DSData) < MaxDSDataPerDomain; i++ {
+		require.NoError(t, d.AddDSData(DomainDSData{KeyTag: uint16(i), Alg: 8, DigestType: 2, Digest: testDSDigestSHA256}))
+	}
+	require.ErrorIs(t, d.AddDSData(ds1), ErrMaxDSDataPerDomainExceeded)
+}
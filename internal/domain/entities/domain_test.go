@@ -1508,12 +1508,143 @@ func TestDomain_Restore(t *testing.T) {
 				assert.False(t, d.Status.PendingDelete)
 				assert.True(t, d.Status.PendingRestore)
 				assert.False(t, d.Status.OK)
+				assert.True(t, d.RGPStatus.PendingRestorePeriodEnd.After(time.Now().UTC().AddDate(0, 0, PendingRestorePeriod-1)))
+				assert.True(t, d.RestoreReport.IsNil())
 			}
 		})
 	}
 
 }
 
+func TestDomain_SubmitRestoreReport(t *testing.T) {
+	now := time.Now().UTC()
+	testcases := []struct {
+		name            string
+		DomainStatus    DomainStatus
+		DomainRGPStatus DomainRGPStatus
+		RestoreReport   DomainRestoreReport
+		wantErr         error
+	}{
+		{
+			name:            "report received in time",
+			DomainStatus:    DomainStatus{PendingRestore: true},
+			DomainRGPStatus: DomainRGPStatus{PendingRestorePeriodEnd: now.AddDate(0, 0, 1)},
+		},
+		{
+			name:            "domain not in pendingRestore",
+			DomainStatus:    DomainStatus{PendingDelete: true},
+			DomainRGPStatus: DomainRGPStatus{PendingRestorePeriodEnd: now.AddDate(0, 0, 1)},
+			wantErr:         ErrRestoreReportNotAllowed,
+		},
+		{
+			name:            "pendingRestore period ended",
+			DomainStatus:    DomainStatus{PendingRestore: true},
+			DomainRGPStatus: DomainRGPStatus{PendingRestorePeriodEnd: now.AddDate(0, 0, -1)},
+			wantErr:         ErrRestoreReportOverdue,
+		},
+		{
+			name:            "report already received",
+			DomainStatus:    DomainStatus{PendingRestore: true},
+			DomainRGPStatus: DomainRGPStatus{PendingRestorePeriodEnd: now.AddDate(0, 0, 1)},
+			RestoreReport:   DomainRestoreReport{ReportedAt: now},
+			wantErr:         ErrRestoreReportNotAllowed,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := &Domain{
+				ClID:          "GoMamma",
+				Status:        tc.DomainStatus,
+				RGPStatus:     tc.DomainRGPStatus,
+				RestoreReport: tc.RestoreReport,
+			}
+
+			err := d.SubmitRestoreReport(getValidRestoreReport())
+			require.ErrorIs(t, err, tc.wantErr)
+			if err == nil {
+				assert.False(t, d.RestoreReport.IsNil())
+				assert.Equal(t, "deleted by mistake", d.RestoreReport.ResReason)
+				assert.True(t, d.Status.PendingRestore)
+			}
+		})
+	}
+
+	t.Run("invalid report", func(t *testing.T) {
+		d := &Domain{Status: DomainStatus{PendingRestore: true}, RGPStatus: DomainRGPStatus{PendingRestorePeriodEnd: now.AddDate(0, 0, 1)}}
+		report := getValidRestoreReport()
+		report.Statements = report.Statements[:1]
+		require.ErrorIs(t, d.SubmitRestoreReport(report), ErrInvalidRestoreReport)
+		assert.True(t, d.RestoreReport.IsNil())
+	})
+}
+
+func TestDomain_CompleteRestore(t *testing.T) {
+	d := &Domain{Status: DomainStatus{PendingDelete: true}}
+	require.ErrorIs(t, d.CompleteRestore(), ErrDomainRestoreNotAllowed)
+
+	d = &Domain{Status: DomainStatus{PendingRestore: true, Inactive: true}}
+	require.ErrorIs(t, d.CompleteRestore(), ErrRestoreReportPending)
+	assert.True(t, d.Status.PendingRestore)
+
+	d.RestoreReport.ReportedAt = time.Now().UTC()
+	require.NoError(t, d.CompleteRestore())
+	assert.False(t, d.Status.PendingRestore)
+	assert.False(t, d.Status.PendingDelete)
+}
+
+func TestDomain_CancelRestore(t *testing.T) {
+	now := time.Now().UTC()
+	testcases := []struct {
+		name            string
+		DomainStatus    DomainStatus
+		DomainRGPStatus DomainRGPStatus
+		RestoreReport   DomainRestoreReport
+		wantErr         error
+	}{
+		{
+			name:            "report overdue",
+			DomainStatus:    DomainStatus{PendingRestore: true},
+			DomainRGPStatus: DomainRGPStatus{PendingRestorePeriodEnd: now.AddDate(0, 0, -1)},
+		},
+		{
+			name:            "still in pendingRestore period",
+			DomainStatus:    DomainStatus{PendingRestore: true},
+			DomainRGPStatus: DomainRGPStatus{PendingRestorePeriodEnd: now.AddDate(0, 0, 1)},
+			wantErr:         ErrRestoreReportPending,
+		},
+		{
+			name:            "report received",
+			DomainStatus:    DomainStatus{PendingRestore: true},
+			DomainRGPStatus: DomainRGPStatus{PendingRestorePeriodEnd: now.AddDate(0, 0, -1)},
+			RestoreReport:   DomainRestoreReport{ReportedAt: now.AddDate(0, 0, -2)},
+			wantErr:         ErrDomainRestoreNotAllowed,
+		},
+		{
+			name:         "domain not in pendingRestore",
+			DomainStatus: DomainStatus{OK: true},
+			wantErr:      ErrDomainRestoreNotAllowed,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := &Domain{
+				Status:        tc.DomainStatus,
+				RGPStatus:     tc.DomainRGPStatus,
+				RestoreReport: tc.RestoreReport,
+			}
+
+			err := d.CancelRestore()
+			require.ErrorIs(t, err, tc.wantErr)
+			if err == nil {
+				assert.False(t, d.Status.PendingRestore)
+				assert.True(t, d.Status.PendingDelete)
+			}
+		})
+	}
+}
+
 func TestDomain_MarkForDeletion(t *testing.T) {
 	now := time.Now().UTC()
 	testcases := []struct {
@@ -2235,3 +2366,86 @@ func TestDomain_CanBeTransferredExtensive(t *testing.T) {
 		})
 	}
 }
+
+func TestDomain_RequestTransfer(t *testing.T) {
+	testcases := []struct {
+		name     string
+		status   DomainStatus
+		lockEnd  time.Time
+		clid     string
+		authInfo string
+		wantErr  error
+	}{
+		{"valid", DomainStatus{OK: true}, time.Now().UTC().AddDate(0, 0, -1), "GainingRar", "STr0mgP@ZZ", nil},
+		{"update prohibited", DomainStatus{ClientUpdateProhibited: true}, time.Now().UTC().AddDate(0, 0, -1), "GainingRar", "STr0mgP@ZZ", nil},
+		{"to sponsor", DomainStatus{OK: true}, time.Now().UTC().AddDate(0, 0, -1), "GoMamma", "STr0mgP@ZZ", ErrTransferToSponsor},
+		{"wrong authInfo", DomainStatus{OK: true}, time.Now().UTC().AddDate(0, 0, -1), "GainingRar", "wr0ngP@ZZ", ErrTransferAuthInfoMismatch},
+		{"pending transfer", DomainStatus{PendingTransfer: true}, time.Now().UTC().AddDate(0, 0, -1), "GainingRar", "STr0mgP@ZZ", ErrDomainPendingTransfer},
+		{"pending delete", DomainStatus{PendingDelete: true}, time.Now().UTC().AddDate(0, 0, -1), "GainingRar", "STr0mgP@ZZ", ErrDomainTransferNotAllowed},
+		{"client transfer prohibited", DomainStatus{ClientTransferProhibited: true}, time.Now().UTC().AddDate(0, 0, -1), "GainingRar", "STr0mgP@ZZ", ErrDomainTransferNotAllowed},
+		{"server transfer prohibited", DomainStatus{ServerTransferProhibited: true}, time.Now().UTC().AddDate(0, 0, -1), "GainingRar", "STr0mgP@ZZ", ErrDomainTransferNotAllowed},
+		{"transfer lock", DomainStatus{OK: true}, time.Now().UTC().AddDate(0, 0, 1), "GainingRar", "STr0mgP@ZZ", ErrDomainTransferLocked},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := &Domain{
+				RoID:      "12345_DOM-APEX",
+				Name:      "de.domaintesttld",
+				ClID:      "GoMamma",
+				AuthInfo:  "STr0mgP@ZZ",
+				Status:    tc.status,
+				RGPStatus: DomainRGPStatus{TransferLockPeriodEnd: tc.lockEnd},
+			}
+			err := d.RequestTransfer(tc.clid, tc.authInfo)
+			require.ErrorIs(t, err, tc.wantErr)
+			if tc.wantErr == nil {
+				require.True(t, d.Status.PendingTransfer)
+				require.False(t, d.Status.OK)
+				require.Equal(t, ClIDType("GoMamma"), d.ClID)
+			}
+		})
+	}
+}
+
+func TestDomain_ClearPendingTransfer(t *testing.T) {
+	d := &Domain{ClID: "GoMamma", Status: DomainStatus{PendingTransfer: true}}
+	require.NoError(t, d.ClearPendingTransfer())
+	require.False(t, d.Status.PendingTransfer)
+	require.True(t, d.Status.OK)
+
+	require.ErrorIs(t, d.ClearPendingTransfer(), ErrNoPendingTransfer)
+}
+
+func TestDomain_CompleteTransfer(t *testing.T) {
+	expiry := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	phase := &Phase{Policy: PhasePolicy{TransferLockPeriod: 60}}
+	newDomain := func(gf DomainGrandFathering) *Domain {
+		return &Domain{
+			ClID:           "GoMamma",
+			ExpiryDate:     expiry,
+			Status:         DomainStatus{PendingTransfer: true},
+			GrandFathering: gf,
+		}
+	}
+
+	d := newDomain(DomainGrandFathering{GFAmount: 100, GFCurrency: "USD", GFExpiryCondition: GFConditionTransfer})
+	require.NoError(t, d.CompleteTransfer("GainingRar", TRANSFER_YEARS, phase))
+	require.Equal(t, ClIDType("GainingRar"), d.ClID)
+	require.Equal(t, ClIDType("GainingRar"), d.UpRr)
+	require.Equal(t, expiry.AddDate(1, 0, 0), d.ExpiryDate)
+	require.Equal(t, 1, d.RenewedYears)
+	require.True(t, d.RGPStatus.TransferLockPeriodEnd.After(time.Now().UTC().AddDate(0, 0, 59)))
+	require.False(t, d.Status.PendingTransfer)
+	require.True(t, d.Status.OK)
+	require.False(t, d.IsGrandFathered())
+
+	// Grandfathering that only expires on delete survives the transfer
+	d = newDomain(DomainGrandFathering{GFAmount: 100, GFCurrency: "USD", GFExpiryCondition: GFConditionDelete})
+	require.NoError(t, d.CompleteTransfer("GainingRar", TRANSFER_YEARS, phase))
+	require.True(t, d.IsGrandFathered())
+
+	require.ErrorIs(t, newDomain(DomainGrandFathering{}).CompleteTransfer("GainingRar", 2, phase), ErrInvalidTransferPeriod)
+	require.ErrorIs(t, newDomain(DomainGrandFathering{}).CompleteTransfer("GainingRar", TRANSFER_YEARS, nil), ErrPhaseNotProvided)
+	require.ErrorIs(t, d.CompleteTransfer("GainingRar", TRANSFER_YEARS, phase), ErrNoPendingTransfer)
+}
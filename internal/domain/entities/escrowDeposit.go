@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEscrowDepositNotFound = errors.New("escrow deposit not found")
+	ErrInvalidDepositType    = errors.New("invalid deposit type, must be one of FULL, DIFF or INCR")
+)
+
+// EscrowDeposit records an escrow deposit that was generated for a TLD.
+// The previous deposits of a TLD determine the base of the next DIFF or INCR deposit and whether a deposit is a resend.
+type EscrowDeposit struct {
+	// ID is the id of the deposit as used in the <rde:deposit> element and the report
+	ID      string
+	TLDName string
+	// Type is one of FULL, DIFF or INCR
+	Type string
+	// PrevID is the ID of the deposit this DIFF or INCR deposit builds on
+	PrevID string
+	// Resend is the number of times the deposit was resent, a deposit of the same type for the TLD on the same day is a resend and keeps the ID, watermark and PrevID of the original
+	Resend    int
+	Watermark time.Time
+	FileName  string
+	CreatedAt time.Time
+}
+
+// ValidateRDEDepositType returns the deposit type in upper case if it is valid, or an error if it is not
+func ValidateRDEDepositType(depositType string) (string, error) {
+	switch t := strings.ToUpper(depositType); t {
+	case RDEReportTypeFULL, RDEReportTypeDIFF, RDEReportTypeINCR:
+		return t, nil
+	}
+	return "", ErrInvalidDepositType
+}
+
+// RDEDepositTypeForDay returns the deposit type that is due on the day of the provided time.
+// ICANN requires a FULL deposit on Sundays and a DIFF deposit on the other days of the week.
+func RDEDepositTypeForDay(t time.Time) string {
+	if t.UTC().Weekday() == time.Sunday {
+		return RDEReportTypeFULL
+	}
+	return RDEReportTypeDIFF
+}
@@ -0,0 +1,38 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRDEDepositType(t *testing.T) {
+	for _, tc := range []struct {
+		in      string
+		want    string
+		wantErr error
+	}{
+		{"FULL", RDEReportTypeFULL, nil},
+		{"diff", RDEReportTypeDIFF, nil},
+		{"Incr", RDEReportTypeINCR, nil},
+		{"weekly", "", ErrInvalidDepositType},
+		{"", "", ErrInvalidDepositType},
+	} {
+		got, err := ValidateRDEDepositType(tc.in)
+		require.ErrorIs(t, err, tc.wantErr, tc.in)
+		require.Equal(t, tc.want, got, tc.in)
+	}
+}
+
+func TestRDEDepositTypeForDay(t *testing.T) {
+	// 2024-03-03 is a Sunday
+	sunday := time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, RDEReportTypeFULL, RDEDepositTypeForDay(sunday))
+	require.Equal(t, RDEReportTypeFULL, RDEDepositTypeForDay(sunday.Add(23*time.Hour)))
+	for i := 1; i < 7; i++ {
+		require.Equal(t, RDEReportTypeDIFF, RDEDepositTypeForDay(sunday.AddDate(0, 0, i)))
+	}
+	// The day is determined in UTC
+	require.Equal(t, RDEReportTypeDIFF, RDEDepositTypeForDay(sunday.Add(-time.Hour).In(time.FixedZone("UTC+2", 2*60*60))))
+}
@@ -31,6 +31,8 @@ var (
 	ErrOKStatusMustBeSet           = fmt.Errorf("ok status must be set when no prohibitions are set")
 	ErrInvalidHostStatus           = fmt.Errorf("invalid host status")
 	ErrHostUpdateProhibited        = fmt.Errorf("host update is prohibited")
+	ErrHostDeleteProhibited        = fmt.Errorf("host delete is prohibited")
+	ErrHostIsLinked                = fmt.Errorf("host is linked to one or more domains")
 	ErrInvalidHostRoID             = fmt.Errorf("invalid Host.RoID.ObjectIdentifier(), expecting '%s'", HOST_ROID_ID)
 )
 
@@ -69,6 +71,42 @@ func (hs *HostStatus) IsNil() bool {
 	return !hs.OK && !hs.Linked && !hs.PendingCreate && !hs.PendingDelete && !hs.PendingUpdate && !hs.PendingTransfer && !hs.ClientDeleteProhibited && !hs.ClientUpdateProhibited && !hs.ServerDeleteProhibited && !hs.ServerUpdateProhibited
 }
 
+// StringSlice returns a slice of strings representing the HostStatus. This is useful for building EPP and WHOIS responses
+func (hs *HostStatus) StringSlice() []string {
+	var status []string
+	if hs.OK {
+		status = append(status, HostStatusOK)
+	}
+	if hs.Linked {
+		status = append(status, HostStatusLinked)
+	}
+	if hs.ClientDeleteProhibited {
+		status = append(status, HostStatusClientDeleteProhibited)
+	}
+	if hs.ClientUpdateProhibited {
+		status = append(status, HostStatusClientUpdateProhibited)
+	}
+	if hs.ServerDeleteProhibited {
+		status = append(status, HostStatusServerDeleteProhibited)
+	}
+	if hs.ServerUpdateProhibited {
+		status = append(status, HostStatusServerUpdateProhibited)
+	}
+	if hs.PendingCreate {
+		status = append(status, HostStatusPendingCreate)
+	}
+	if hs.PendingDelete {
+		status = append(status, HostStatusPendingDelete)
+	}
+	if hs.PendingUpdate {
+		status = append(status, HostStatusPendingUpdate)
+	}
+	if hs.PendingTransfer {
+		status = append(status, HostStatusPendingTransfer)
+	}
+	return status
+}
+
 // NewHost creates a new Host with required fields. It will normalize strings
 func NewHost(name, roid, clid string) (*Host, error) {
 	domainName, err := NewDomainName(name)
@@ -763,3 +763,33 @@ func TestHost_DeepCopy(t *testing.T) {
 		})
 	}
 }
+
+func TestHostStatus_StringSlice(t *testing.T) {
+	testcases := []struct {
+		name string
+		hs   HostStatus
+		want []string
+	}{
+		{
+			name: "nil",
+			hs:   HostStatus{},
+			want: nil,
+		},
+		{
+			name: "ok linked",
+			hs:   HostStatus{OK: true, Linked: true},
+			want: []string{HostStatusOK, HostStatusLinked},
+		},
+		{
+			name: "prohibitions",
+			hs:   HostStatus{ClientDeleteProhibited: true, ServerUpdateProhibited: true},
+			want: []string{HostStatusClientDeleteProhibited, HostStatusServerUpdateProhibited},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.hs.StringSlice())
+		})
+	}
+}
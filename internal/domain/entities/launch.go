@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// SunrisePhaseName is the name of the launch phase in which only trademark holders can register a domain. Registrations in this phase require a mark.
+	SunrisePhaseName = "sunrise"
+	// ClaimsPhaseName is the name of the launch phase in which registrants must acknowledge a trademark claims notice before registering a domain that matches a trademark.
+	// While a launch phase with this name is active, the claims notice is required in all phases.
+	ClaimsPhaseName = "claims"
+)
+
+var (
+	// ErrMarkRequired is returned when a domain is registered in a sunrise phase without a mark
+	ErrMarkRequired = errors.New("a mark is required to register a domain in the sunrise phase")
+	// ErrClaimsNoticeRequired is returned when a domain that matches a trademark is registered during the claims phase without a claims notice
+	ErrClaimsNoticeRequired = errors.New("the domain matches a trademark, a claims notice is required")
+	// ErrInvalidClaimsNotice is returned when the claims notice is incomplete, expired or not yet accepted
+	ErrInvalidClaimsNotice = errors.New("invalid claims notice")
+)
+
+// ClaimsNotice is the acknowledgement of the trademark claims notice by the registrant as defined in RFC 8334.
+// The notice is retrieved from the TMCH using the claim key of the domain.
+// Ref: https://datatracker.ietf.org/doc/html/rfc8334#section-2.6
+type ClaimsNotice struct {
+	// NoticeID is the unique identifier of the claims notice
+	NoticeID string
+	// ValidatorID is the identifier of the party that issued the notice, empty means the TMCH
+	ValidatorID string
+	// NotAfter is the expiry of the notice, it must be accepted and used before this time
+	NotAfter time.Time
+	// AcceptedDate is the time the registrant acknowledged the notice
+	AcceptedDate time.Time
+}
+
+// Validate checks the claims notice is complete, was accepted in the past and has not expired
+func (n *ClaimsNotice) Validate() error {
+	now := time.Now().UTC()
+	if n.NoticeID == "" {
+		return errors.Join(ErrInvalidClaimsNotice, errors.New("noticeID is required"))
+	}
+	if n.AcceptedDate.IsZero() || n.AcceptedDate.After(now) {
+		return errors.Join(ErrInvalidClaimsNotice, errors.New("acceptedDate must be in the past"))
+	}
+	if n.NotAfter.Before(now) {
+		return errors.Join(ErrInvalidClaimsNotice, errors.New("the notice has expired"))
+	}
+	if n.AcceptedDate.After(n.NotAfter) {
+		return errors.Join(ErrInvalidClaimsNotice, errors.New("the notice was accepted after it expired"))
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimsNotice_Validate(t *testing.T) {
+	now := time.Now().UTC()
+	tests := []struct {
+		name    string
+		notice  ClaimsNotice
+		wantErr bool
+	}{
+		{"valid", ClaimsNotice{NoticeID: "370d0b7c9223372036854775807", NotAfter: now.Add(time.Hour), AcceptedDate: now.Add(-time.Minute)}, false},
+		{"missing noticeID", ClaimsNotice{NotAfter: now.Add(time.Hour), AcceptedDate: now.Add(-time.Minute)}, true},
+		{"missing acceptedDate", ClaimsNotice{NoticeID: "abc", NotAfter: now.Add(time.Hour)}, true},
+		{"accepted in the future", ClaimsNotice{NoticeID: "abc", NotAfter: now.Add(time.Hour), AcceptedDate: now.Add(time.Minute)}, true},
+		{"expired", ClaimsNotice{NoticeID: "abc", NotAfter: now.Add(-time.Minute), AcceptedDate: now.Add(-time.Hour)}, true},
+		{"accepted after expiry", ClaimsNotice{NoticeID: "abc", NotAfter: now.Add(-2 * time.Hour), AcceptedDate: now.Add(-time.Hour)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.notice.Validate()
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidClaimsNotice)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrPollMessageNotFound = errors.New("poll message not found")
+	ErrInvalidPollMessage  = errors.New("invalid poll message")
+)
+
+// PollMessage is a message queued for a registrar to retrieve through the EPP <poll> command.
+// Messages inform the registrar about actions on its objects that it did not initiate itself (e.g. auto-renewals, purges, server status changes)
+// Ref: https://datatracker.ietf.org/doc/html/rfc5730#section-2.9.2.3
+type PollMessage struct {
+	ID              int64           // ID is the unique identifier of the message, messages are dequeued in ascending ID order
+	ClID            ClIDType        // ClID is the registrar the message is queued for
+	Msg             string          // Msg is the human readable message
+	DomainName      DomainName      // DomainName is the domain the message is about, if applicable
+	DomainRoID      RoidType        // DomainRoID is the RoID of the domain the message is about, if applicable
+	TransactionType TransactionType // TransactionType is the type of transaction that triggered the message, if applicable
+	TraceID         string          // TraceID allows correlating the message with the action that triggered it
+	CreatedAt       time.Time       // CreatedAt is the time the message was queued (qDate)
+}
+
+// NewPollMessage creates a new PollMessage for the registrar with the provided ClID
+func NewPollMessage(clid, msg string) (*PollMessage, error) {
+	if clid == "" {
+		return nil, errors.Join(ErrInvalidPollMessage, ErrEmptyClientID)
+	}
+	if msg == "" {
+		return nil, errors.Join(ErrInvalidPollMessage, errors.New("message cannot be empty"))
+	}
+	return &PollMessage{
+		ClID:      ClIDType(clid),
+		Msg:       msg,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// NewPollMessageFromDomainLifeCycleEvent creates a new PollMessage for the registrar sponsoring the domain in the DomainLifeCycleEvent
+func NewPollMessageFromDomainLifeCycleEvent(event *DomainLifeCycleEvent, msg string) (*PollMessage, error) {
+	pm, err := NewPollMessage(event.ClientID, msg)
+	if err != nil {
+		return nil, err
+	}
+	pm.DomainName = DomainName(event.DomainName)
+	pm.DomainRoID = RoidType(event.DomainRoID)
+	pm.TransactionType = event.TransactionType
+	pm.TraceID = event.TraceID
+	if !event.TimeStamp.IsZero() {
+		pm.CreatedAt = event.TimeStamp
+	}
+	return pm, nil
+}
@@ -0,0 +1,58 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPollMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		clid    string
+		msg     string
+		wantErr error
+	}{
+		{"valid", "ClID-1", "Domain example.com auto-renewed for 1 years", nil},
+		{"empty clid", "", "Domain example.com auto-renewed for 1 years", ErrInvalidPollMessage},
+		{"empty msg", "ClID-1", "", ErrInvalidPollMessage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm, err := NewPollMessage(tt.clid, tt.msg)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, pm)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, ClIDType(tt.clid), pm.ClID)
+			require.Equal(t, tt.msg, pm.Msg)
+			require.False(t, pm.CreatedAt.IsZero())
+		})
+	}
+}
+
+func TestNewPollMessageFromDomainLifeCycleEvent(t *testing.T) {
+	event, err := NewDomainLifeCycleEvent("ClID-1", "", "com", "example.com", 1, TransactionTypeAutoRenewal)
+	require.NoError(t, err)
+	event.DomainRoID = "1234_DOM-APEX"
+	event.TraceID = "trace-1"
+	event.TimeStamp = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	pm, err := NewPollMessageFromDomainLifeCycleEvent(event, "Domain example.com auto-renewed for 1 years")
+	require.NoError(t, err)
+	require.Equal(t, ClIDType("ClID-1"), pm.ClID)
+	require.Equal(t, DomainName("example.com"), pm.DomainName)
+	require.Equal(t, RoidType("1234_DOM-APEX"), pm.DomainRoID)
+	require.Equal(t, TransactionTypeAutoRenewal, pm.TransactionType)
+	require.Equal(t, "trace-1", pm.TraceID)
+	require.Equal(t, event.TimeStamp, pm.CreatedAt)
+
+	// The event must belong to a registrar
+	event.ClientID = ""
+	_, err = NewPollMessageFromDomainLifeCycleEvent(event, "Domain example.com auto-renewed for 1 years")
+	require.ErrorIs(t, err, ErrInvalidPollMessage)
+}
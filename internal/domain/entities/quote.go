@@ -1,11 +1,17 @@
 package entities
 
 import (
+	"errors"
 	"time"
 
 	"github.com/Rhymond/go-money"
 )
 
+var (
+	ErrFeeMismatch = errors.New("fee does not match the quote")
+	ErrFeeRequired = errors.New("the fee must be provided for premium domains")
+)
+
 // Quote represents a quote for a specific transaction on the system.
 type Quote struct {
 	TimeStamp       time.Time
@@ -16,6 +22,7 @@ type Quote struct {
 	Price           *money.Money
 	Class           string
 	Fees            []*Fee
+	Discount        *money.Money `json:",omitempty"` // Discount that was deducted from the price, e.g. by an allocation token
 	FXRate          *FX
 	Phase           *Phase // `json:"-"`
 }
@@ -42,6 +49,11 @@ func NewQuoteFromQuoteRequest(qr QuoteRequest) (*Quote, error) {
 	return q, nil
 }
 
+// IsPremium returns true if the domain is priced in a premium class rather than at the standard price of the phase
+func (q *Quote) IsPremium() bool {
+	return q.Class != "" && q.Class != "standard"
+}
+
 // AddFeeAndUpdatePrice adds a fee to the quote and update the total price
 func (q *Quote) AddFeeAndUpdatePrice(fee *Fee, yearlyFee bool) error {
 	q.Fees = append(q.Fees, fee)
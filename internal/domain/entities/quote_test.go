@@ -52,6 +52,16 @@ func TestNewQuoteFromQuoteRequest_ValidationFail(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, quote)
 }
+
+func TestQuote_IsPremium(t *testing.T) {
+	quote := NewQuote("USD")
+	require.False(t, quote.IsPremium())
+	quote.Class = "standard"
+	require.False(t, quote.IsPremium())
+	quote.Class = "premium"
+	require.True(t, quote.IsPremium())
+}
+
 func TestAddFee(t *testing.T) {
 	testcases := []struct {
 		name      string
@@ -0,0 +1,543 @@
+package entities
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	RDAP_CONTENT_TYPE = "application/rdap+json"
+
+	RDAPObjectClassDomain     = "domain"
+	RDAPObjectClassNameserver = "nameserver"
+	RDAPObjectClassEntity     = "entity"
+
+	RDAPEventRegistration     = "registration"
+	RDAPEventExpiration       = "expiration"
+	RDAPEventLastChanged      = "last changed"
+	RDAPEventLastRDAPDBUpdate = "last update of RDAP database"
+
+	RDAPRoleRegistrar      = "registrar"
+	RDAPRoleAbuse          = "abuse"
+	RDAPRoleRegistrant     = "registrant"
+	RDAPRoleAdministrative = "administrative"
+	RDAPRoleTechnical      = "technical"
+	RDAPRoleBilling        = "billing"
+
+	RDAPPublicIDTypeIANARegistrarID = "IANA Registrar ID"
+
+	RDAPConformanceRedacted = "redacted"
+
+	RDAPRedactionMethodRemoval    = "removal"
+	RDAPRedactionMethodEmptyValue = "emptyValue"
+
+	RDAP_STATUS_CODES_URL         = "https://icann.org/epp"
+	RDAP_INACCURACY_COMPLAINT_URL = "https://icann.org/wicf"
+)
+
+var (
+	ErrInvalidRDAPQuery = errors.New("invalid RDAP query")
+
+	// RDAPConformance lists the specifications the RDAP responses conform to
+	RDAPConformance = []string{"rdap_level_0", "icann_rdap_response_profile_1", "icann_rdap_technical_implementation_guide_1"}
+
+	// rdapStatusExceptions are the EPP statuses that do not map to their RDAP status by splitting the words (RFC 8056 section 2)
+	rdapStatusExceptions = map[string]string{
+		DomainStatusOK:   "active",
+		HostStatusLinked: "associated",
+	}
+
+	// rdapRedactionPrefixes are the prefixes of the redacted field names per role as used by the ICANN RDAP Response Profile
+	rdapRedactionPrefixes = map[string]string{
+		RDAPRoleRegistrant:     "Registrant",
+		RDAPRoleAdministrative: "Admin",
+		RDAPRoleTechnical:      "Tech",
+		RDAPRoleBilling:        "Billing",
+	}
+)
+
+// RDAPLink represents a link in an RDAP response (RFC 9083 section 4.2)
+type RDAPLink struct {
+	Value string `json:"value,omitempty"`
+	Rel   string `json:"rel,omitempty"`
+	Href  string `json:"href"`
+	Type  string `json:"type,omitempty"`
+}
+
+// RDAPNotice represents a notice or remark in an RDAP response (RFC 9083 section 4.3)
+type RDAPNotice struct {
+	Title       string     `json:"title,omitempty"`
+	Type        string     `json:"type,omitempty"`
+	Description []string   `json:"description"`
+	Links       []RDAPLink `json:"links,omitempty"`
+}
+
+// RDAPEvent represents an event in an RDAP response (RFC 9083 section 4.5)
+type RDAPEvent struct {
+	EventAction string    `json:"eventAction"`
+	EventActor  string    `json:"eventActor,omitempty"`
+	EventDate   time.Time `json:"eventDate"`
+}
+
+// RDAPPublicID represents a public identifier in an RDAP response (RFC 9083 section 4.8)
+type RDAPPublicID struct {
+	Type       string `json:"type"`
+	Identifier string `json:"identifier"`
+}
+
+// RDAPEntity represents an entity object class in an RDAP response (RFC 9083 section 5.1)
+type RDAPEntity struct {
+	ObjectClassName string         `json:"objectClassName"`
+	RDAPConformance []string       `json:"rdapConformance,omitempty"`
+	Notices         []RDAPNotice   `json:"notices,omitempty"`
+	Handle          string         `json:"handle,omitempty"`
+	VCardArray      []any          `json:"vcardArray,omitempty"`
+	Roles           []string       `json:"roles,omitempty"`
+	PublicIDs       []RDAPPublicID `json:"publicIds,omitempty"`
+	Entities        []RDAPEntity   `json:"entities,omitempty"`
+	Remarks         []RDAPNotice   `json:"remarks,omitempty"`
+	Links           []RDAPLink     `json:"links,omitempty"`
+	Events          []RDAPEvent    `json:"events,omitempty"`
+	Status          []string       `json:"status,omitempty"`
+	Redacted        []RDAPRedacted `json:"redacted,omitempty"`
+}
+
+// RDAPIPAddresses holds the IP addresses of an RDAP nameserver
+type RDAPIPAddresses struct {
+	V4 []string `json:"v4,omitempty"`
+	V6 []string `json:"v6,omitempty"`
+}
+
+// RDAPNameserver represents a nameserver object class in an RDAP response (RFC 9083 section 5.2)
+type RDAPNameserver struct {
+	ObjectClassName string           `json:"objectClassName"`
+	RDAPConformance []string         `json:"rdapConformance,omitempty"`
+	Notices         []RDAPNotice     `json:"notices,omitempty"`
+	Handle          string           `json:"handle,omitempty"`
+	LdhName         string           `json:"ldhName"`
+	UnicodeName     string           `json:"unicodeName,omitempty"`
+	IPAddresses     *RDAPIPAddresses `json:"ipAddresses,omitempty"`
+	Entities        []RDAPEntity     `json:"entities,omitempty"`
+	Status          []string         `json:"status,omitempty"`
+	Remarks         []RDAPNotice     `json:"remarks,omitempty"`
+	Links           []RDAPLink       `json:"links,omitempty"`
+	Events          []RDAPEvent      `json:"events,omitempty"`
+}
+
+// RDAPSecureDNS represents the secureDNS member of an RDAP domain
+type RDAPSecureDNS struct {
+	DelegationSigned bool         `json:"delegationSigned"`
+	MaxSigLife       int          `json:"maxSigLife,omitempty"`
+	DSData           []RDAPDSData `json:"dsData,omitempty"`
+}
+
+// RDAPDSData represents a DS record in the secureDNS member of an RDAP domain
+type RDAPDSData struct {
+	KeyTag     uint16 `json:"keyTag"`
+	Algorithm  uint8  `json:"algorithm"`
+	Digest     string `json:"digest"`
+	DigestType uint8  `json:"digestType"`
+}
+
+// NewRDAPSecureDNS converts the DNSSEC delegation data of a domain to the secureDNS member of an RDAP domain
+func NewRDAPSecureDNS(s DomainSecDNS) *RDAPSecureDNS {
+	secureDNS := &RDAPSecureDNS{DelegationSigned: s.IsSigned()}
+	if !s.IsSigned() {
+		return secureDNS
+	}
+	secureDNS.MaxSigLife = s.MaxSigLife
+	for _, ds := range s.DSData {
+		secureDNS.DSData = append(secureDNS.DSData, RDAPDSData{
+			KeyTag:     ds.KeyTag,
+			Algorithm:  ds.Alg,
+			Digest:     ds.Digest,
+			DigestType: ds.DigestType,
+		})
+	}
+	return secureDNS
+}
+
+// RDAPDomain represents a domain object class in an RDAP response (RFC 9083 section 5.3)
+type RDAPDomain struct {
+	ObjectClassName string           `json:"objectClassName"`
+	RDAPConformance []string         `json:"rdapConformance,omitempty"`
+	Notices         []RDAPNotice     `json:"notices,omitempty"`
+	Handle          string           `json:"handle,omitempty"`
+	LdhName         string           `json:"ldhName"`
+	UnicodeName     string           `json:"unicodeName,omitempty"`
+	Nameservers     []RDAPNameserver `json:"nameservers,omitempty"`
+	SecureDNS       *RDAPSecureDNS   `json:"secureDNS,omitempty"`
+	Entities        []RDAPEntity     `json:"entities,omitempty"`
+	Status          []string         `json:"status,omitempty"`
+	Remarks         []RDAPNotice     `json:"remarks,omitempty"`
+	Links           []RDAPLink       `json:"links,omitempty"`
+	Events          []RDAPEvent      `json:"events,omitempty"`
+	Redacted        []RDAPRedacted   `json:"redacted,omitempty"`
+}
+
+// RDAPRedactedDescription describes the name or reason of a redacted field
+type RDAPRedactedDescription struct {
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// RDAPRedacted represents a redacted field as defined in RFC 9537
+type RDAPRedacted struct {
+	Name     RDAPRedactedDescription  `json:"name"`
+	PrePath  string                   `json:"prePath,omitempty"`
+	PostPath string                   `json:"postPath,omitempty"`
+	PathLang string                   `json:"pathLang,omitempty"`
+	Method   string                   `json:"method"`
+	Reason   *RDAPRedactedDescription `json:"reason,omitempty"`
+}
+
+// RDAPHelp represents the response to a help query (RFC 9083 section 7)
+type RDAPHelp struct {
+	RDAPConformance []string     `json:"rdapConformance"`
+	Notices         []RDAPNotice `json:"notices"`
+}
+
+// RDAPError represents an error response (RFC 9083 section 6)
+type RDAPError struct {
+	RDAPConformance []string     `json:"rdapConformance"`
+	Notices         []RDAPNotice `json:"notices,omitempty"`
+	ErrorCode       int          `json:"errorCode"`
+	Title           string       `json:"title"`
+	Description     []string     `json:"description,omitempty"`
+}
+
+// NewRDAPError creates a new RDAPError
+func NewRDAPError(code int, title string, description ...string) *RDAPError {
+	return &RDAPError{
+		RDAPConformance: RDAPConformance,
+		ErrorCode:       code,
+		Title:           title,
+		Description:     description,
+	}
+}
+
+// NewRDAPNotices returns the notices that are required in every response by the ICANN RDAP profile
+func NewRDAPNotices(termsOfServiceURL string) []RDAPNotice {
+	return []RDAPNotice{
+		{
+			Title:       "Terms of Use",
+			Description: []string{"Service subject to Terms of Use."},
+			Links:       []RDAPLink{{Value: termsOfServiceURL, Rel: "terms-of-service", Href: termsOfServiceURL, Type: "text/html"}},
+		},
+		{
+			Title:       "Status Codes",
+			Description: []string{"For more information on domain status codes, please visit https://icann.org/epp"},
+			Links:       []RDAPLink{{Value: RDAP_STATUS_CODES_URL, Rel: "glossary", Href: RDAP_STATUS_CODES_URL, Type: "text/html"}},
+		},
+		{
+			Title:       "RDDS Inaccuracy Complaint Form",
+			Description: []string{"URL of the ICANN RDDS Inaccuracy Complaint Form: https://icann.org/wicf"},
+			Links:       []RDAPLink{{Value: RDAP_INACCURACY_COMPLAINT_URL, Rel: "help", Href: RDAP_INACCURACY_COMPLAINT_URL, Type: "text/html"}},
+		},
+	}
+}
+
+// NewRDAPSelfLink returns the self link of an object, e.g. https://rdap.example/domain/example.com
+func NewRDAPSelfLink(baseURL, objectPath, key string) RDAPLink {
+	href := strings.TrimSuffix(baseURL, "/") + "/" + objectPath + "/" + key
+	return RDAPLink{Value: href, Rel: "self", Href: href, Type: RDAP_CONTENT_TYPE}
+}
+
+// RDAPStatusFromEPP maps an EPP status to its RDAP status as defined in RFC 8056, e.g. clientHold becomes "client hold"
+func RDAPStatusFromEPP(status string) string {
+	if s, ok := rdapStatusExceptions[status]; ok {
+		return s
+	}
+	var sb strings.Builder
+	for i, r := range status {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				sb.WriteRune(' ')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// rdapStatuses maps the EPP statuses to RDAP statuses without duplicates
+func rdapStatuses(statuses ...[]string) []string {
+	var result []string
+	for _, list := range statuses {
+		for _, s := range list {
+			if rs := RDAPStatusFromEPP(s); !slices.Contains(result, rs) {
+				result = append(result, rs)
+			}
+		}
+	}
+	return result
+}
+
+// newRDAPEvents returns the registration, expiration and last changed events, leaving out the dates that are not set
+func newRDAPEvents(created, expires, updated time.Time) []RDAPEvent {
+	var events []RDAPEvent
+	for _, e := range []RDAPEvent{
+		{EventAction: RDAPEventRegistration, EventDate: created},
+		{EventAction: RDAPEventExpiration, EventDate: expires},
+		{EventAction: RDAPEventLastChanged, EventDate: updated},
+	} {
+		if !e.EventDate.IsZero() {
+			e.EventDate = e.EventDate.UTC()
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// newRDAPVCard returns a jCard (RFC 7095) with the provided properties
+func newRDAPVCard(properties ...[]any) []any {
+	props := []any{[]any{"version", map[string]any{}, "text", "4.0"}}
+	for _, p := range properties {
+		props = append(props, p)
+	}
+	return []any{"vcard", props}
+}
+
+// rdapVCardProperty returns a jCard property, empty values return nil so they can be left out
+func rdapVCardProperty(name string, params map[string]any, valueType string, value any) []any {
+	if s, ok := value.(string); ok && s == "" {
+		return nil
+	}
+	if params == nil {
+		params = map[string]any{}
+	}
+	return []any{name, params, valueType, value}
+}
+
+// rdapVCardAddress returns the adr property of a jCard for the address, the redacted components are left empty
+func rdapVCardAddress(a *Address, r ContactRedaction) []any {
+	if a == nil {
+		return nil
+	}
+	city, pc := a.City.String(), a.PostalCode.String()
+	if r.City {
+		city = ""
+	}
+	if r.PostalCode {
+		pc = ""
+	}
+	// A jCard component holds a single value or a list of values
+	var street any = ""
+	var lines []string
+	for _, s := range []OptPostalLineType{a.Street1, a.Street2, a.Street3} {
+		if s != "" {
+			lines = append(lines, s.String())
+		}
+	}
+	if len(lines) > 0 && !r.Street {
+		street = lines
+	}
+	return rdapVCardProperty("adr", map[string]any{"cc": a.CountryCode.String()}, "text", []any{
+		"", "", street, city, a.StateProvince.String(), pc, "",
+	})
+}
+
+// compactVCardProperties removes the properties that were left out
+func compactVCardProperties(props ...[]any) [][]any {
+	var result [][]any
+	for _, p := range props {
+		if p != nil {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// NewRDAPRegistrarEntity converts a Registrar to an RDAP entity with the registrar role, its IANA ID and its abuse contact as required by the ICANN RDAP profile
+func NewRDAPRegistrarEntity(rar *Registrar) RDAPEntity {
+	gurID := strconv.Itoa(rar.GurID)
+	var adr []any
+	for _, pi := range rar.PostalInfo {
+		if pi != nil {
+			adr = rdapVCardAddress(pi.Address, ContactRedaction{})
+			break
+		}
+	}
+	entity := RDAPEntity{
+		ObjectClassName: RDAPObjectClassEntity,
+		Handle:          gurID,
+		Roles:           []string{RDAPRoleRegistrar},
+		PublicIDs:       []RDAPPublicID{{Type: RDAPPublicIDTypeIANARegistrarID, Identifier: gurID}},
+		VCardArray:      newRDAPVCard(compactVCardProperties(rdapVCardProperty("fn", nil, "text", rar.Name), adr)...),
+		Entities: []RDAPEntity{
+			{
+				ObjectClassName: RDAPObjectClassEntity,
+				Roles:           []string{RDAPRoleAbuse},
+				VCardArray: newRDAPVCard(compactVCardProperties(
+					rdapVCardProperty("fn", nil, "text", "Abuse Contact"),
+					rdapVCardProperty("tel", map[string]any{"type": "voice"}, "uri", rdapTelURI(rar.Voice)),
+					rdapVCardProperty("email", nil, "text", rar.Email),
+				)...),
+			},
+		},
+	}
+	if rar.URL != "" {
+		entity.Links = []RDAPLink{{Value: rar.URL.String(), Rel: "about", Href: rar.URL.String(), Type: "text/html"}}
+	}
+	return entity
+}
+
+// NewRDAPContactEntity converts a Contact to an RDAP entity with the provided roles. The redacted name and address components are left empty,
+// the other redacted properties are removed. Use NewRDAPContactRedactions to describe the redactions in the response.
+func NewRDAPContactEntity(c *Contact, r ContactRedaction, roles ...string) RDAPEntity {
+	r = r.applyTo(c)
+	props := [][]any{}
+	if pi := c.RDDSPostalInfo(); pi != nil {
+		fn := pi.Name.String()
+		if r.Name {
+			fn = ""
+		}
+		// fn is mandatory in a jCard, so a redacted name is left empty rather than removed
+		props = append(props, []any{"fn", map[string]any{}, "text", fn})
+		if !r.Org {
+			props = append(props, rdapVCardProperty("org", nil, "text", pi.Org.String()))
+		}
+		props = append(props, rdapVCardAddress(pi.Address, r))
+	}
+	if !r.Voice {
+		props = append(props, rdapVCardProperty("tel", map[string]any{"type": "voice"}, "uri", rdapTelURI(c.Voice)))
+	}
+	if !r.Fax {
+		props = append(props, rdapVCardProperty("tel", map[string]any{"type": "fax"}, "uri", rdapTelURI(c.Fax)))
+	}
+	if !r.Email {
+		props = append(props, rdapVCardProperty("email", nil, "text", c.Email))
+	}
+
+	entity := RDAPEntity{
+		ObjectClassName: RDAPObjectClassEntity,
+		Roles:           roles,
+		VCardArray:      newRDAPVCard(compactVCardProperties(props...)...),
+		Status:          rdapStatuses(c.Status.StringSlice()),
+		Events:          newRDAPEvents(c.CreatedAt, time.Time{}, c.UpdatedAt),
+	}
+	if !r.ID {
+		entity.Handle = c.ID.String()
+	}
+	if r.Email {
+		entity.Remarks = []RDAPNotice{{Title: "EMAIL REDACTED FOR PRIVACY", Type: "object redacted due to authorization", Description: []string{RDDS_REDACTED_EMAIL}}}
+	}
+	return entity
+}
+
+// NewRDAPContactRedactions describes the redactions of a contact entity as defined in RFC 9537. entityPath is the JSONPath of the entity in the response,
+// e.g. "$" for an entity lookup or "$.entities[?(@.roles[0]=='registrant')]" for a contact of a domain. A redaction is listed for each role of the entity.
+func NewRDAPContactRedactions(c *Contact, r ContactRedaction, entityPath string, roles ...string) []RDAPRedacted {
+	r = r.applyTo(c)
+	prefixes := []string{"Contact"}
+	if len(roles) > 0 {
+		prefixes = nil
+		for _, role := range roles {
+			prefixes = append(prefixes, rdapRedactionPrefixes[role])
+		}
+	}
+	vcard := entityPath + ".vcardArray[1]"
+
+	var result []RDAPRedacted
+	for _, prefix := range prefixes {
+		for _, f := range []struct {
+			redacted bool
+			name     string
+			method   string
+			path     string
+		}{
+			{r.ID, "Registry " + prefix + " ID", RDAPRedactionMethodRemoval, entityPath + ".handle"},
+			{r.Name, prefix + " Name", RDAPRedactionMethodEmptyValue, vcard + "[?(@[0]=='fn')][3]"},
+			{r.Org, prefix + " Organization", RDAPRedactionMethodRemoval, vcard + "[?(@[0]=='org')]"},
+			{r.Street, prefix + " Street", RDAPRedactionMethodEmptyValue, vcard + "[?(@[0]=='adr')][3][2]"},
+			{r.City, prefix + " City", RDAPRedactionMethodEmptyValue, vcard + "[?(@[0]=='adr')][3][3]"},
+			{r.PostalCode, prefix + " Postal Code", RDAPRedactionMethodEmptyValue, vcard + "[?(@[0]=='adr')][3][5]"},
+			{r.Voice, prefix + " Phone", RDAPRedactionMethodRemoval, vcard + "[?(@[1].type=='voice')]"},
+			{r.Fax, prefix + " Fax", RDAPRedactionMethodRemoval, vcard + "[?(@[1].type=='fax')]"},
+			{r.Email, prefix + " Email", RDAPRedactionMethodRemoval, vcard + "[?(@[0]=='email')]"},
+		} {
+			if !f.redacted {
+				continue
+			}
+			red := RDAPRedacted{
+				Name:     RDAPRedactedDescription{Type: f.name},
+				PathLang: "jsonpath",
+				Method:   f.method,
+				Reason:   &RDAPRedactedDescription{Description: "Server policy"},
+			}
+			if f.method == RDAPRedactionMethodRemoval {
+				red.PrePath = f.path
+			} else {
+				red.PostPath = f.path
+			}
+			result = append(result, red)
+		}
+	}
+	return result
+}
+
+// RDAPConformanceWithRedactions returns the rdapConformance of a response, adding the redacted extension when fields are redacted
+func RDAPConformanceWithRedactions(redacted []RDAPRedacted) []string {
+	if len(redacted) == 0 {
+		return RDAPConformance
+	}
+	return append(slices.Clone(RDAPConformance), RDAPConformanceRedacted)
+}
+
+// NewRDAPNameserver converts a Host to an RDAP nameserver
+func NewRDAPNameserver(h *Host) RDAPNameserver {
+	ns := RDAPNameserver{
+		ObjectClassName: RDAPObjectClassNameserver,
+		Handle:          h.RoID.String(),
+		LdhName:         h.Name.String(),
+		Status:          rdapStatuses(h.Status.StringSlice()),
+		Events:          newRDAPEvents(h.CreatedAt, time.Time{}, h.UpdatedAt),
+	}
+	if len(h.Addresses) > 0 {
+		ns.IPAddresses = &RDAPIPAddresses{}
+		for _, a := range h.Addresses {
+			if a.Is4() {
+				ns.IPAddresses.V4 = append(ns.IPAddresses.V4, a.String())
+			} else {
+				ns.IPAddresses.V6 = append(ns.IPAddresses.V6, a.String())
+			}
+		}
+	}
+	return ns
+}
+
+// NewRDAPDomain converts a Domain and its sponsoring Registrar to an RDAP domain. The RGP statuses are calculated at the provided time.
+func NewRDAPDomain(d *Domain, rar *Registrar, at time.Time) *RDAPDomain {
+	rdapDomain := &RDAPDomain{
+		ObjectClassName: RDAPObjectClassDomain,
+		Handle:          d.RoID.String(),
+		LdhName:         d.Name.String(),
+		Status:          rdapStatuses(d.Status.StringSlice(), d.RGPStatuses(at)),
+		SecureDNS:       NewRDAPSecureDNS(d.SecDNS),
+		Entities:        []RDAPEntity{NewRDAPRegistrarEntity(rar)},
+		Events:          newRDAPEvents(d.CreatedAt, d.ExpiryDate, d.UpdatedAt),
+	}
+	if d.UName != "" && d.UName != d.Name {
+		rdapDomain.UnicodeName = d.UName.String()
+	}
+	for _, h := range d.Hosts {
+		rdapDomain.Nameservers = append(rdapDomain.Nameservers, RDAPNameserver{
+			ObjectClassName: RDAPObjectClassNameserver,
+			LdhName:         h.Name.String(),
+		})
+	}
+	return rdapDomain
+}
+
+// rdapTelURI returns the tel URI of a phone number, e.g. tel:+1.5555555555
+func rdapTelURI(phone E164Type) string {
+	if phone == "" {
+		return ""
+	}
+	return "tel:" + phone.String()
+}
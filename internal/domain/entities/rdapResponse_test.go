@@ -0,0 +1,183 @@
+package entities
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRDAPStatusFromEPP(t *testing.T) {
+	tc := []struct {
+		status string
+		want   string
+	}{
+		{DomainStatusOK, "active"},
+		{HostStatusLinked, "associated"},
+		{"clientHold", "client hold"},
+		{"serverTransferProhibited", "server transfer prohibited"},
+		{"inactive", "inactive"},
+		{"pendingDelete", "pending delete"},
+		{RGPStatusRedemptionPeriod, "redemption period"},
+	}
+
+	for _, c := range tc {
+		t.Run(c.status, func(t *testing.T) {
+			require.Equal(t, c.want, RDAPStatusFromEPP(c.status))
+		})
+	}
+}
+
+func TestNewRDAPRegistrarEntity(t *testing.T) {
+	a, err := NewAddress("Brussels", "BE")
+	require.NoError(t, err)
+	pi, err := NewRegistrarPostalInfo("int", a)
+	require.NoError(t, err)
+	rar, err := NewRegistrar("rar1", "Registrar One", "abuse@rar1.com", 1234, [2]*RegistrarPostalInfo{pi, nil})
+	require.NoError(t, err)
+	rar.Voice = "+32.123456789"
+	rar.URL = "https://rar1.com"
+
+	e := NewRDAPRegistrarEntity(rar)
+	require.Equal(t, "1234", e.Handle)
+	require.Equal(t, []string{RDAPRoleRegistrar}, e.Roles)
+	require.Equal(t, []RDAPPublicID{{Type: RDAPPublicIDTypeIANARegistrarID, Identifier: "1234"}}, e.PublicIDs)
+	require.Len(t, e.Links, 1)
+	require.Len(t, e.Entities, 1)
+	require.Equal(t, []string{RDAPRoleAbuse}, e.Entities[0].Roles)
+
+	b, err := json.Marshal(e.Entities[0].VCardArray)
+	require.NoError(t, err)
+	require.Contains(t, string(b), `["tel",{"type":"voice"},"uri","tel:+32.123456789"]`)
+	require.Contains(t, string(b), `["email",{},"text","abuse@rar1.com"]`)
+}
+
+func TestNewRDAPContactEntity(t *testing.T) {
+	c, err := NewContact("myid", "1234_CONT-APEX", "me@my.com", "str0NGP@ZZw0rd", "rar1")
+	require.NoError(t, err)
+	a, err := NewAddress("Brussels", "BE")
+	require.NoError(t, err)
+	pi, err := NewContactPostalInfo("int", "John Doe", a)
+	require.NoError(t, err)
+	require.NoError(t, c.AddPostalInfo(pi))
+
+	e := NewRDAPContactEntity(c, ContactRedaction{}, RDAPRoleRegistrant, RDAPRoleTechnical)
+	require.Equal(t, "myid", e.Handle)
+	require.Equal(t, []string{RDAPRoleRegistrant, RDAPRoleTechnical}, e.Roles)
+
+	b, err := json.Marshal(e.VCardArray)
+	require.NoError(t, err)
+	require.Contains(t, string(b), `["fn",{},"text","John Doe"]`)
+	require.Contains(t, string(b), `["adr",{"cc":"BE"},"text",["","","","Brussels","","",""]]`)
+	require.Contains(t, string(b), `["email",{},"text","me@my.com"]`)
+	require.NotContains(t, string(b), `"fax"`)
+}
+
+func TestNewRDAPContactEntity_Redacted(t *testing.T) {
+	c, err := NewContact("myid", "1234_CONT-APEX", "me@my.com", "str0NGP@ZZw0rd", "rar1")
+	require.NoError(t, err)
+	a, err := NewAddress("Brussels", "BE")
+	require.NoError(t, err)
+	a.Street1 = "Rue de la Loi 16"
+	a.PostalCode = "1000"
+	pi, err := NewContactPostalInfo("int", "John Doe", a)
+	require.NoError(t, err)
+	require.NoError(t, c.AddPostalInfo(pi))
+	c.Voice = "+32.123456789"
+	// Only the email is disclosed
+	c.Disclose.Email = true
+
+	r := NewContactRedaction(c)
+	e := NewRDAPContactEntity(c, r, RDAPRoleRegistrant)
+	require.Empty(t, e.Handle)
+	require.Empty(t, e.Remarks)
+
+	b, err := json.Marshal(e.VCardArray)
+	require.NoError(t, err)
+	require.Contains(t, string(b), `["fn",{},"text",""]`)
+	require.Contains(t, string(b), `["adr",{"cc":"BE"},"text",["","","","","","",""]]`)
+	require.Contains(t, string(b), `["email",{},"text","me@my.com"]`)
+	require.NotContains(t, string(b), `tel:`)
+	require.NotContains(t, string(b), `John Doe`)
+
+	redacted := NewRDAPContactRedactions(c, r, "$.entities[?(@.roles[0]=='registrant')]", RDAPRoleRegistrant)
+	var names []string
+	for _, red := range redacted {
+		names = append(names, red.Name.Type)
+	}
+	// The organization and fax are empty so there is nothing to redact
+	require.Equal(t, []string{"Registry Registrant ID", "Registrant Name", "Registrant Street", "Registrant City", "Registrant Postal Code", "Registrant Phone"}, names)
+	require.Equal(t, RDAPRedactionMethodEmptyValue, redacted[1].Method)
+	require.Equal(t, "$.entities[?(@.roles[0]=='registrant')].vcardArray[1][?(@[0]=='fn')][3]", redacted[1].PostPath)
+	require.Equal(t, RDAPRedactionMethodRemoval, redacted[5].Method)
+	require.Equal(t, "$.entities[?(@.roles[0]=='registrant')].vcardArray[1][?(@[1].type=='voice')]", redacted[5].PrePath)
+
+	require.Equal(t, RDAPConformance, RDAPConformanceWithRedactions(nil))
+	require.Contains(t, RDAPConformanceWithRedactions(redacted), RDAPConformanceRedacted)
+	require.NotContains(t, RDAPConformance, RDAPConformanceRedacted)
+}
+
+func TestNewRDAPNameserver(t *testing.T) {
+	h, err := NewHost("ns1.apex.domains", "1234_HOST-APEX", "rar1")
+	require.NoError(t, err)
+	_, err = h.AddAddress("195.238.2.21")
+	require.NoError(t, err)
+	_, err = h.AddAddress("2001:db8::1")
+	require.NoError(t, err)
+
+	ns := NewRDAPNameserver(h)
+	require.Equal(t, RDAPObjectClassNameserver, ns.ObjectClassName)
+	require.Equal(t, "ns1.apex.domains", ns.LdhName)
+	require.Equal(t, &RDAPIPAddresses{V4: []string{"195.238.2.21"}, V6: []string{"2001:db8::1"}}, ns.IPAddresses)
+}
+
+func TestNewRDAPDomain(t *testing.T) {
+	d, err := NewDomain("1234_DOM-APEX", "apex.domains", "rar1", "str0NGP@ZZw0rd")
+	require.NoError(t, err)
+	d.CreatedAt = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	d.ExpiryDate = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	d.Status.ClientHold = true
+	h, err := NewHost("ns1.apex.domains", "1234_HOST-APEX", "rar1")
+	require.NoError(t, err)
+	d.Hosts = append(d.Hosts, h)
+	a, err := NewAddress("Brussels", "BE")
+	require.NoError(t, err)
+	pi, err := NewRegistrarPostalInfo("int", a)
+	require.NoError(t, err)
+	rar, err := NewRegistrar("rar1", "Registrar One", "abuse@rar1.com", 1234, [2]*RegistrarPostalInfo{pi, nil})
+	require.NoError(t, err)
+
+	rd := NewRDAPDomain(d, rar, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+	require.Equal(t, RDAPObjectClassDomain, rd.ObjectClassName)
+	require.Equal(t, "1234_DOM-APEX", rd.Handle)
+	require.Equal(t, "apex.domains", rd.LdhName)
+	require.Empty(t, rd.UnicodeName)
+	require.Contains(t, rd.Status, "client hold")
+	require.Equal(t, []RDAPEvent{
+		{EventAction: RDAPEventRegistration, EventDate: d.CreatedAt},
+		{EventAction: RDAPEventExpiration, EventDate: d.ExpiryDate},
+	}, rd.Events)
+	require.Len(t, rd.Nameservers, 1)
+	require.Equal(t, "ns1.apex.domains", rd.Nameservers[0].LdhName)
+	require.Len(t, rd.Entities, 1)
+	require.Equal(t, "1234", rd.Entities[0].Handle)
+	require.False(t, rd.SecureDNS.DelegationSigned)
+	require.Empty(t, rd.SecureDNS.DSData)
+
+	require.NoError(t, d.AddDSData(DomainDSData{KeyTag: 12345, Alg: 13, DigestType: 2, Digest: testDSDigestSHA256}))
+	require.NoError(t, d.SetMaxSigLife(604800))
+	rd = NewRDAPDomain(d, rar, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+	require.Equal(t, &RDAPSecureDNS{
+		DelegationSigned: true,
+		MaxSigLife:       604800,
+		DSData:           []RDAPDSData{{KeyTag: 12345, Algorithm: 13, Digest: testDSDigestSHA256, DigestType: 2}},
+	}, rd.SecureDNS)
+}
+
+func TestNewRDAPSelfLink(t *testing.T) {
+	l := NewRDAPSelfLink("https://rdap.apex.domains/", "domain", "apex.domains")
+	require.Equal(t, "https://rdap.apex.domains/domain/apex.domains", l.Href)
+	require.Equal(t, "self", l.Rel)
+	require.Equal(t, RDAP_CONTENT_TYPE, l.Type)
+}
@@ -0,0 +1,79 @@
+package entities
+
+import (
+	"encoding/base64"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+const (
+	// RDAPConformancePaging is the extension identifier of the paging_metadata member (RFC 8977)
+	RDAPConformancePaging = "paging"
+
+	// Notice types of a truncated result set (RFC 9083 section 10.2.1)
+	RDAPNoticeTypeResultSetTruncatedAuthorization = "result set truncated due to authorization"
+	RDAPNoticeTypeResultSetTruncatedLoad          = "result set truncated due to excessive load"
+	RDAPNoticeTypeResultSetTruncatedUnexplainable = "result set truncated due to unexplainable reasons"
+)
+
+// RDAPPagingMetadata holds the page size and the link to the next page of search results (RFC 8977)
+type RDAPPagingMetadata struct {
+	PageSize int        `json:"pageSize"`
+	Links    []RDAPLink `json:"links,omitempty"`
+}
+
+// RDAPDomainSearchResults is the response to a domain search (RFC 9083 section 8)
+type RDAPDomainSearchResults struct {
+	RDAPConformance     []string            `json:"rdapConformance"`
+	Notices             []RDAPNotice        `json:"notices,omitempty"`
+	DomainSearchResults []RDAPDomain        `json:"domainSearchResults"`
+	PagingMetadata      *RDAPPagingMetadata `json:"paging_metadata,omitempty"`
+}
+
+// RDAPNameserverSearchResults is the response to a nameserver search (RFC 9083 section 8)
+type RDAPNameserverSearchResults struct {
+	RDAPConformance         []string            `json:"rdapConformance"`
+	Notices                 []RDAPNotice        `json:"notices,omitempty"`
+	NameserverSearchResults []RDAPNameserver    `json:"nameserverSearchResults"`
+	PagingMetadata          *RDAPPagingMetadata `json:"paging_metadata,omitempty"`
+}
+
+// RDAPEntitySearchResults is the response to an entity search (RFC 9083 section 8)
+type RDAPEntitySearchResults struct {
+	RDAPConformance     []string            `json:"rdapConformance"`
+	Notices             []RDAPNotice        `json:"notices,omitempty"`
+	EntitySearchResults []RDAPEntity        `json:"entitySearchResults"`
+	PagingMetadata      *RDAPPagingMetadata `json:"paging_metadata,omitempty"`
+}
+
+// NewRDAPPagingMetadata returns the paging metadata of a page of search results.
+// If there is a next page, the base64url encoded cursor is added to the search query to create the link to it.
+func NewRDAPPagingMetadata(baseURL, searchPath string, query url.Values, pageSize int, nextCursor string) *RDAPPagingMetadata {
+	pm := &RDAPPagingMetadata{PageSize: pageSize}
+	if nextCursor == "" {
+		return pm
+	}
+	next := url.Values{}
+	for k, v := range query {
+		next[k] = v
+	}
+	next.Set("cursor", base64.URLEncoding.EncodeToString([]byte(nextCursor)))
+	href := strings.TrimSuffix(baseURL, "/") + "/" + searchPath + "?" + next.Encode()
+	pm.Links = []RDAPLink{{Value: href, Rel: "next", Href: href, Type: RDAP_CONTENT_TYPE}}
+	return pm
+}
+
+// NewRDAPTruncationNotice returns the notice that tells the client the result set is truncated
+func NewRDAPTruncationNotice(noticeType string, description ...string) RDAPNotice {
+	return RDAPNotice{
+		Title:       "Search Results Truncated",
+		Type:        noticeType,
+		Description: description,
+	}
+}
+
+// NewRDAPSearchConformance returns the conformance of a search response, which includes the paging extension
+func NewRDAPSearchConformance() []string {
+	return append(slices.Clone(RDAPConformance), RDAPConformancePaging)
+}
@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRDAPPagingMetadata(t *testing.T) {
+	pm := NewRDAPPagingMetadata("https://rdap.apex.domains/", "domains", url.Values{"name": {"exa*.apex"}}, 25, "")
+	require.Equal(t, 25, pm.PageSize)
+	require.Empty(t, pm.Links)
+
+	pm = NewRDAPPagingMetadata("https://rdap.apex.domains/", "domains", url.Values{"name": {"exa*.apex"}}, 25, "123_DOM-APEX")
+	require.Len(t, pm.Links, 1)
+	require.Equal(t, "next", pm.Links[0].Rel)
+	require.Equal(t, "https://rdap.apex.domains/domains?cursor=MTIzX0RPTS1BUEVY&name=exa%2A.apex", pm.Links[0].Href)
+}
+
+func TestNewRDAPSearchConformance(t *testing.T) {
+	c := NewRDAPSearchConformance()
+	require.Equal(t, RDAPConformancePaging, c[len(c)-1])
+	// The shared conformance is not modified
+	require.NotContains(t, RDAPConformance, RDAPConformancePaging)
+}
+
+func TestNewRDAPTruncationNotice(t *testing.T) {
+	n := NewRDAPTruncationNotice(RDAPNoticeTypeResultSetTruncatedAuthorization, "Log in for more results.")
+	require.Equal(t, RDAPNoticeTypeResultSetTruncatedAuthorization, n.Type)
+	require.Equal(t, []string{"Log in for more results."}, n.Description)
+}
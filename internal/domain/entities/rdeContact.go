@@ -257,3 +257,162 @@ func GetContactStatusFromRDEContactStatus(statuses []RDEContactStatus) (ContactS
 	}
 	return cs, nil
 }
+
+// NewRDEContactFromEntity converts a Contact entity to an RDEContact for inclusion in a deposit
+func NewRDEContactFromEntity(c *Contact) *RDEContact {
+	rdeContact := &RDEContact{
+		ID:     c.ID.String(),
+		RoID:   c.RoID.String(),
+		Voice:  c.Voice.String(),
+		Fax:    c.Fax.String(),
+		Email:  c.Email,
+		ClID:   c.ClID.String(),
+		CrRr:   c.CrRr.String(),
+		CrDate: formatRDEDate(c.CreatedAt),
+		UpRr:   c.UpRr.String(),
+		UpDate: formatRDEDate(c.UpdatedAt),
+	}
+	for _, s := range c.Status.StringSlice() {
+		rdeContact.Status = append(rdeContact.Status, RDEContactStatus{S: s})
+	}
+	for _, pi := range c.PostalInfo {
+		if pi == nil {
+			continue
+		}
+		rdePi := RDEContactPostalInfo{Type: string(pi.Type), Name: pi.Name.String(), Org: pi.Org.String()}
+		if pi.Address != nil {
+			rdePi.Address = NewRDEAddressFromEntity(pi.Address)
+		}
+		rdeContact.PostalInfo = append(rdeContact.PostalInfo, rdePi)
+	}
+
+	// Our data policy is not to disclose, so we only list the elements the registrar has chosen to disclose
+	d := c.Disclose
+	if !d.IsNil() {
+		rdeContact.Disclose.Flag = true
+		for _, f := range []struct {
+			set   bool
+			field *[]RDEContactWithType
+			typ   string
+		}{
+			{d.NameInt, &rdeContact.Disclose.Name, "int"},
+			{d.NameLoc, &rdeContact.Disclose.Name, "loc"},
+			{d.OrgInt, &rdeContact.Disclose.Org, "int"},
+			{d.OrgLoc, &rdeContact.Disclose.Org, "loc"},
+			{d.AddrInt, &rdeContact.Disclose.Addr, "int"},
+			{d.AddrLoc, &rdeContact.Disclose.Addr, "loc"},
+		} {
+			if f.set {
+				*f.field = append(*f.field, RDEContactWithType{Type: f.typ})
+			}
+		}
+		if d.Voice {
+			rdeContact.Disclose.Voice = []string{""}
+		}
+		if d.Fax {
+			rdeContact.Disclose.Fax = []string{""}
+		}
+		if d.Email {
+			rdeContact.Disclose.Email = []string{""}
+		}
+	}
+	return rdeContact
+}
+
+// rdeContactXML is the representation of the RDEContact used when generating a deposit
+type rdeContactXML struct {
+	XMLName    xml.Name                  `xml:"rdeContact:contact"`
+	ID         string                    `xml:"rdeContact:id"`
+	RoID       string                    `xml:"rdeContact:roid"`
+	Status     []rdeStatusXML            `xml:"rdeContact:status"`
+	PostalInfo []rdeContactPostalInfoXML `xml:"rdeContact:postalInfo"`
+	Voice      string                    `xml:"rdeContact:voice,omitempty"`
+	Fax        string                    `xml:"rdeContact:fax,omitempty"`
+	Email      string                    `xml:"rdeContact:email"`
+	ClID       string                    `xml:"rdeContact:clID"`
+	CrRr       string                    `xml:"rdeContact:crRr,omitempty"`
+	CrDate     string                    `xml:"rdeContact:crDate,omitempty"`
+	UpRr       string                    `xml:"rdeContact:upRr,omitempty"`
+	UpDate     string                    `xml:"rdeContact:upDate,omitempty"`
+	Disclose   *rdeContactDiscloseXML    `xml:"rdeContact:disclose"`
+}
+
+type rdeContactPostalInfoXML struct {
+	Type string               `xml:"type,attr"`
+	Name string               `xml:"contact:name"`
+	Org  string               `xml:"contact:org,omitempty"`
+	Addr rdeContactAddressXML `xml:"contact:addr"`
+}
+
+type rdeContactAddressXML struct {
+	Street        []string `xml:"contact:street"`
+	City          string   `xml:"contact:city"`
+	StateProvince string   `xml:"contact:sp,omitempty"`
+	PostalCode    string   `xml:"contact:pc,omitempty"`
+	CountryCode   string   `xml:"contact:cc"`
+}
+
+type rdeContactDiscloseXML struct {
+	Flag  string                  `xml:"flag,attr"`
+	Name  []rdeContactWithTypeXML `xml:"contact:name"`
+	Org   []rdeContactWithTypeXML `xml:"contact:org"`
+	Addr  []rdeContactWithTypeXML `xml:"contact:addr"`
+	Voice []string                `xml:"contact:voice"`
+	Fax   []string                `xml:"contact:fax"`
+	Email []string                `xml:"contact:email"`
+}
+
+type rdeContactWithTypeXML struct {
+	Type string `xml:"type,attr"`
+}
+
+// MarshalXML generates the <rdeContact:contact> element as defined in RFC 9022. The prefixes are declared on the deposit.
+func (c *RDEContact) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	x := rdeContactXML{
+		ID:     c.ID,
+		RoID:   c.RoID,
+		Voice:  c.Voice,
+		Fax:    c.Fax,
+		Email:  c.Email,
+		ClID:   c.ClID,
+		CrRr:   c.CrRr,
+		CrDate: c.CrDate,
+		UpRr:   c.UpRr,
+		UpDate: c.UpDate,
+	}
+	for _, s := range c.Status {
+		x.Status = append(x.Status, rdeStatusXML{S: s.S})
+	}
+	for _, pi := range c.PostalInfo {
+		x.PostalInfo = append(x.PostalInfo, rdeContactPostalInfoXML{
+			Type: pi.Type,
+			Name: pi.Name,
+			Org:  pi.Org,
+			Addr: rdeContactAddressXML{
+				Street:        pi.Address.Street,
+				City:          pi.Address.City,
+				StateProvince: pi.Address.StateProvince,
+				PostalCode:    pi.Address.PostalCode,
+				CountryCode:   pi.Address.CountryCode,
+			},
+		})
+	}
+	if c.Disclose.Flag {
+		x.Disclose = &rdeContactDiscloseXML{
+			Flag:  "1",
+			Voice: c.Disclose.Voice,
+			Fax:   c.Disclose.Fax,
+			Email: c.Disclose.Email,
+		}
+		for _, n := range c.Disclose.Name {
+			x.Disclose.Name = append(x.Disclose.Name, rdeContactWithTypeXML(n))
+		}
+		for _, o := range c.Disclose.Org {
+			x.Disclose.Org = append(x.Disclose.Org, rdeContactWithTypeXML(o))
+		}
+		for _, a := range c.Disclose.Addr {
+			x.Disclose.Addr = append(x.Disclose.Addr, rdeContactWithTypeXML(a))
+		}
+	}
+	return e.Encode(x)
+}
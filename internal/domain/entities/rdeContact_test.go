@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"encoding/xml"
 	"testing"
 	"time"
 
@@ -797,3 +798,30 @@ func TestRDEContact_IsLinked(t *testing.T) {
 		})
 	}
 }
+
+func TestNewRDEContactFromEntity(t *testing.T) {
+	c, err := NewContact("sh8013", "12345_CONT-APEX", "jdoe@apex.domains", "str0NGP@ZZw0rd", "GoMamma")
+	require.NoError(t, err)
+	addr, err := NewAddress("Brussels", "BE")
+	require.NoError(t, err)
+	addr.Street1 = "Rue de la Loi 16"
+	pi, err := NewContactPostalInfo("int", "John Doe", addr)
+	require.NoError(t, err)
+	require.NoError(t, c.AddPostalInfo(pi))
+	c.Disclose = ContactDisclose{NameInt: true, Email: true}
+
+	rdeContact := NewRDEContactFromEntity(c)
+	require.Equal(t, []RDEAddress{{Street: []string{"Rue de la Loi 16"}, City: "Brussels", CountryCode: "BE"}}, []RDEAddress{rdeContact.PostalInfo[0].Address})
+	require.True(t, rdeContact.Disclose.Flag)
+
+	b, err := xml.Marshal(rdeContact)
+	require.NoError(t, err)
+	require.Contains(t, string(b), `<rdeContact:postalInfo type="int"><contact:name>John Doe</contact:name><contact:addr><contact:street>Rue de la Loi 16</contact:street><contact:city>Brussels</contact:city><contact:cc>BE</contact:cc></contact:addr></rdeContact:postalInfo>`)
+	require.Contains(t, string(b), `<rdeContact:disclose flag="1"><contact:name type="int"></contact:name><contact:email></contact:email></rdeContact:disclose>`)
+
+	// Without anything to disclose the element is omitted
+	c.Disclose = ContactDisclose{}
+	b, err = xml.Marshal(NewRDEContactFromEntity(c))
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "disclose")
+}
@@ -0,0 +1,58 @@
+package entities
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// RDEDelete represents an object in the <rde:deletes> section of a DIFF or INCR deposit
+type RDEDelete struct {
+	ObjectURI string
+	Name      string
+	RoID      string
+}
+
+// NewRDEDeleteFromEntity converts a DeletedObject to an RDEDelete for inclusion in a deposit
+func NewRDEDeleteFromEntity(d *DeletedObject) *RDEDelete {
+	return &RDEDelete{
+		ObjectURI: d.ObjectURI,
+		Name:      d.Name,
+		RoID:      d.RoID,
+	}
+}
+
+type rdeDomainDeleteXML struct {
+	XMLName xml.Name `xml:"rdeDom:delete"`
+	Name    string   `xml:"rdeDom:name"`
+}
+
+type rdeHostDeleteXML struct {
+	XMLName xml.Name `xml:"rdeHost:delete"`
+	Name    string   `xml:"rdeHost:name"`
+	RoID    string   `xml:"rdeHost:roid,omitempty"`
+}
+
+type rdeContactDeleteXML struct {
+	XMLName xml.Name `xml:"rdeContact:delete"`
+	ID      string   `xml:"rdeContact:id"`
+}
+
+type rdeNNDNDeleteXML struct {
+	XMLName xml.Name `xml:"rdeNNDN:delete"`
+	AName   string   `xml:"rdeNNDN:aName"`
+}
+
+// MarshalXML generates the <delete> element of the object as defined in RFC 9022. The prefixes are declared on the deposit.
+func (d *RDEDelete) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	switch d.ObjectURI {
+	case DOMAIN_URI:
+		return e.Encode(rdeDomainDeleteXML{Name: d.Name})
+	case HOST_URI:
+		return e.Encode(rdeHostDeleteXML{Name: d.Name, RoID: d.RoID})
+	case CONTACT_URI:
+		return e.Encode(rdeContactDeleteXML{ID: d.Name})
+	case NNDN_URI:
+		return e.Encode(rdeNNDNDeleteXML{AName: d.Name})
+	}
+	return fmt.Errorf("unsupported object in deletes: %s", d.ObjectURI)
+}
@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRDEDelete_MarshalXML(t *testing.T) {
+	for _, tc := range []struct {
+		deleted *DeletedObject
+		want    string
+	}{
+		{NewDeletedObject(DOMAIN_URI, "apex", "example.apex", "1_DOM-APEX"), `<rdeDom:delete><rdeDom:name>example.apex</rdeDom:name></rdeDom:delete>`},
+		{NewDeletedObject(HOST_URI, "", "ns1.example.apex", "2_HOST-APEX"), `<rdeHost:delete><rdeHost:name>ns1.example.apex</rdeHost:name><rdeHost:roid>2_HOST-APEX</rdeHost:roid></rdeHost:delete>`},
+		{NewDeletedObject(CONTACT_URI, "", "sh8013", "3_CONT-APEX"), `<rdeContact:delete><rdeContact:id>sh8013</rdeContact:id></rdeContact:delete>`},
+		{NewDeletedObject(NNDN_URI, "apex", "blocked.apex", ""), `<rdeNNDN:delete><rdeNNDN:aName>blocked.apex</rdeNNDN:aName></rdeNNDN:delete>`},
+	} {
+		b, err := xml.Marshal(NewRDEDeleteFromEntity(tc.deleted))
+		require.NoError(t, err)
+		require.Equal(t, tc.want, string(b))
+	}
+
+	_, err := xml.Marshal(&RDEDelete{ObjectURI: REGISTRAR_URI, Name: "GoMamma"})
+	require.Error(t, err)
+}
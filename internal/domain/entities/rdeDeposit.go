@@ -1,6 +1,10 @@
 package entities
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"strconv"
+	"time"
+)
 
 type RDEDeposit struct {
 	XMLName   xml.Name `xml:"deposit" json:"-"`
@@ -12,3 +16,91 @@ type RDEDeposit struct {
 	FileName  string
 	FileSize  int64
 }
+
+var (
+	// RDEDepositNamespaces are the prefixes and namespaces declared on the root element of a generated deposit
+	RDEDepositNamespaces = [][2]string{
+		{"rde", RDE_URI},
+		{"rdeHeader", HEADER_URI},
+		{"rdeDom", DOMAIN_URI},
+		{"rdeHost", HOST_URI},
+		{"rdeContact", CONTACT_URI},
+		{"rdeRegistrar", REGISTRAR_URI},
+		{"rdeIDN", IDN_URI},
+		{"rdeNNDN", NNDN_URI},
+		{"rdeEppParams", EPP_PARAMS_URI},
+		{"domain", EPP_DOMAIN_URI},
+		{"contact", EPP_CONTACT_URI},
+		{"secDNS", SECDNS_URI},
+		{"rgp", RGP_URI},
+		{"epp", EPP_URI},
+	}
+	// RDEDepositObjURIs are the objects listed in the rdeMenu of a generated deposit
+	RDEDepositObjURIs = []string{HEADER_URI, DOMAIN_URI, HOST_URI, CONTACT_URI, REGISTRAR_URI, IDN_URI, NNDN_URI, EPP_PARAMS_URI}
+	// RDEBRDAObjURIs are the objects listed in the rdeMenu of a Bulk Registration Data Access (BRDA) file
+	RDEBRDAObjURIs = []string{HEADER_URI, DOMAIN_URI, REGISTRAR_URI}
+)
+
+// NewRDEDeposit creates a new RDEDeposit of the provided type (FULL, DIFF, INCR) for the watermark
+func NewRDEDeposit(id, depositType string, watermark time.Time) *RDEDeposit {
+	return &RDEDeposit{
+		Type:      depositType,
+		ID:        id,
+		Watermark: formatRDEDate(watermark),
+	}
+}
+
+// StartElement returns the <rde:deposit> start element including the namespace declarations for all objects in the deposit
+func (d *RDEDeposit) StartElement() xml.StartElement {
+	start := xml.StartElement{Name: xml.Name{Local: "rde:deposit"}}
+	start.Attr = append(start.Attr,
+		xml.Attr{Name: xml.Name{Local: "type"}, Value: d.Type},
+		xml.Attr{Name: xml.Name{Local: "id"}, Value: d.ID},
+	)
+	if d.PrevID != "" {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "prevId"}, Value: d.PrevID})
+	}
+	if d.Resend > 0 {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "resend"}, Value: strconv.Itoa(d.Resend)})
+	}
+	for _, ns := range RDEDepositNamespaces {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xmlns:" + ns[0]}, Value: ns[1]})
+	}
+	return start
+}
+
+// RDEMenu represents the <rde:rdeMenu> element of a deposit
+type RDEMenu struct {
+	XMLName xml.Name `xml:"rde:rdeMenu"`
+	Version string   `xml:"rde:version"`
+	ObjURI  []string `xml:"rde:objURI"`
+}
+
+// NewRDEMenu creates a new RDEMenu listing the objects in a generated deposit
+func NewRDEMenu() *RDEMenu {
+	return &RDEMenu{
+		Version: "1.0",
+		ObjURI:  RDEDepositObjURIs,
+	}
+}
+
+// NewRDEBRDAMenu creates a new RDEMenu listing the objects in a Bulk Registration Data Access (BRDA) file
+func NewRDEBRDAMenu() *RDEMenu {
+	return &RDEMenu{
+		Version: "1.0",
+		ObjURI:  RDEBRDAObjURIs,
+	}
+}
+
+// rdeStatusXML is used to generate the status elements of the RDE objects
+type rdeStatusXML struct {
+	S string `xml:"s,attr"`
+}
+
+// formatRDEDate formats a time in the dateTime format used in a deposit. A zero time returns an empty string so optional elements can be omitted.
+func formatRDEDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRDEDeposit_StartElement(t *testing.T) {
+	deposit := NewRDEDeposit("202403010000", "FULL", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	require.Equal(t, "2024-03-01T00:00:00Z", deposit.Watermark)
+
+	var buf bytes.Buffer
+	e := xml.NewEncoder(&buf)
+	start := deposit.StartElement()
+	require.NoError(t, e.EncodeToken(start))
+	require.NoError(t, e.EncodeToken(start.End()))
+	require.NoError(t, e.Flush())
+
+	out := buf.String()
+	require.Contains(t, out, `<rde:deposit type="FULL" id="202403010000" xmlns:rde="urn:ietf:params:xml:ns:rde-1.0"`)
+	require.NotContains(t, out, "prevId")
+	require.NotContains(t, out, "resend")
+	for _, ns := range RDEDepositNamespaces {
+		require.Contains(t, out, `xmlns:`+ns[0]+`="`+ns[1]+`"`)
+	}
+}
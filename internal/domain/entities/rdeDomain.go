@@ -138,6 +138,22 @@ func (d *RDEDomain) ToEntity() (*ToEntityResult, error) {
 		}
 	}
 
+	// Set the DNSSEC delegation data
+	for _, dsData := range d.SecDNS.DSData {
+		ds, err := NewDomainDSData(dsData.KeyTag, dsData.Alg, dsData.DigestType, dsData.Digest)
+		if err != nil {
+			return nil, err
+		}
+		if err := domain.AddDSData(*ds); err != nil {
+			return nil, err
+		}
+	}
+	if len(d.SecDNS.DSData) > 0 {
+		if err := domain.SetMaxSigLife(d.SecDNS.MaxSigLife); err != nil {
+			return nil, err
+		}
+	}
+
 	// Set the RenewedYears based on the ExpiryDate and CreatedAt
 	domain.RenewedYears = domain.ExpiryDate.Year() - domain.CreatedAt.Year() - 1 // the first year is a registration
 
@@ -217,7 +233,8 @@ type DSData struct {
 }
 
 type RDESecDNS struct {
-	DSData []DSData `xml:"dsData"`
+	MaxSigLife int      `xml:"maxSigLife"`
+	DSData     []DSData `xml:"dsData"`
 }
 
 type TrnData struct {
@@ -305,3 +322,191 @@ func GetDomainStatusFromRDEDomainStatus(statuses []RDEDomainStatus) (DomainStatu
 
 	return ds, nil
 }
+
+// NewRDEDomainFromEntity converts a Domain entity to an RDEDomain for inclusion in a deposit. The RGP statuses are determined at the provided time (e.g. the deposit's watermark).
+// The hosts are added as nameservers if they are loaded on the domain.
+func NewRDEDomainFromEntity(d *Domain, at time.Time) *RDEDomain {
+	rdeDomain := &RDEDomain{
+		Name:         d.Name,
+		RoID:         d.RoID.String(),
+		UName:        d.UName.String(),
+		OriginalName: d.OriginalName.String(),
+		Registrant:   d.RegistrantID.String(),
+		ClID:         d.ClID.String(),
+		CrRr:         d.CrRr.String(),
+		CrDate:       formatRDEDate(d.CreatedAt),
+		ExDate:       formatRDEDate(d.ExpiryDate),
+		UpRr:         d.UpRr.String(),
+		UpDate:       formatRDEDate(d.UpdatedAt),
+	}
+
+	// pendingRestore is an RGP status, in EPP the domain remains pendingDelete while it is being restored (RFC 3915)
+	for _, s := range d.Status.StringSlice() {
+		if s == DomainStatusPendingRestore {
+			s = DomainStatusPendingDelete
+			if d.Status.PendingDelete {
+				continue
+			}
+		}
+		rdeDomain.Status = append(rdeDomain.Status, RDEDomainStatus{S: s})
+	}
+	for _, s := range d.RGPStatuses(at) {
+		rdeDomain.RgpStatus = append(rdeDomain.RgpStatus, RDEDomainRGPStatus{S: s})
+	}
+
+	for _, c := range []RDEDomainContact{
+		{Type: "admin", ID: d.AdminID.String()},
+		{Type: "tech", ID: d.TechID.String()},
+		{Type: "billing", ID: d.BillingID.String()},
+	} {
+		if c.ID != "" {
+			rdeDomain.Contact = append(rdeDomain.Contact, c)
+		}
+	}
+
+	if len(d.Hosts) > 0 {
+		ns := RDEDomainHost{}
+		for _, h := range d.Hosts {
+			ns.HostObjs = append(ns.HostObjs, h.Name.String())
+		}
+		rdeDomain.Ns = []RDEDomainHost{ns}
+	}
+
+	if d.IsSigned() {
+		rdeDomain.SecDNS.MaxSigLife = d.SecDNS.MaxSigLife
+		for _, ds := range d.SecDNS.DSData {
+			rdeDomain.SecDNS.DSData = append(rdeDomain.SecDNS.DSData, DSData{
+				KeyTag:     int(ds.KeyTag),
+				Alg:        int(ds.Alg),
+				DigestType: int(ds.DigestType),
+				Digest:     ds.Digest,
+			})
+		}
+	}
+
+	return rdeDomain
+}
+
+// NewRDEThinDomainFromEntity converts a Domain to the thin RDEDomain included in a Bulk Registration Data Access (BRDA) file.
+// BRDA files do not contain contact data, only the domain, its name servers, statuses, dates and sponsoring registrar.
+func NewRDEThinDomainFromEntity(d *Domain, at time.Time) *RDEDomain {
+	rdeDomain := NewRDEDomainFromEntity(d, at)
+	rdeDomain.Registrant = ""
+	rdeDomain.Contact = nil
+	return rdeDomain
+}
+
+// NewRDETrnDataFromEntity converts a pending DomainTransfer to the TrnData of an RDEDomain
+func NewRDETrnDataFromEntity(t *DomainTransfer) TrnData {
+	return TrnData{
+		TrStatus: TrStatus{State: string(TransferStatusPending)},
+		ReRr:     ReRr{RegID: t.GainingRegistrar.String()},
+		ReDate:   formatRDEDate(t.CreatedAt),
+		AcRr:     AcRr{RegID: t.LosingRegistrar.String()},
+		AcDate:   formatRDEDate(t.ExpiryDate),
+	}
+}
+
+// rdeDomainXML is the representation of the RDEDomain used when generating a deposit
+type rdeDomainXML struct {
+	XMLName      xml.Name              `xml:"rdeDom:domain"`
+	Name         string                `xml:"rdeDom:name"`
+	RoID         string                `xml:"rdeDom:roid"`
+	UName        string                `xml:"rdeDom:uName,omitempty"`
+	IdnTableId   string                `xml:"rdeDom:idnTableId,omitempty"`
+	OriginalName string                `xml:"rdeDom:originalName,omitempty"`
+	Status       []rdeStatusXML        `xml:"rdeDom:status"`
+	RgpStatus    []rdeStatusXML        `xml:"rdeDom:rgpStatus"`
+	Registrant   string                `xml:"rdeDom:registrant,omitempty"`
+	Contact      []rdeDomainContactXML `xml:"rdeDom:contact"`
+	Ns           *rdeDomainNsXML       `xml:"rdeDom:ns"`
+	ClID         string                `xml:"rdeDom:clID"`
+	CrRr         string                `xml:"rdeDom:crRr,omitempty"`
+	CrDate       string                `xml:"rdeDom:crDate,omitempty"`
+	ExDate       string                `xml:"rdeDom:exDate,omitempty"`
+	UpRr         string                `xml:"rdeDom:upRr,omitempty"`
+	UpDate       string                `xml:"rdeDom:upDate,omitempty"`
+	SecDNS       *rdeSecDNSXML         `xml:"rdeDom:secDNS"`
+	TrnData      *rdeTrnDataXML        `xml:"rdeDom:trnData"`
+}
+
+type rdeDomainContactXML struct {
+	Type string `xml:"type,attr"`
+	ID   string `xml:",chardata"`
+}
+
+type rdeDomainNsXML struct {
+	HostObjs []string `xml:"domain:hostObj"`
+}
+
+type rdeSecDNSXML struct {
+	MaxSigLife int            `xml:"secDNS:maxSigLife,omitempty"`
+	DSData     []rdeDSDataXML `xml:"secDNS:dsData"`
+}
+
+type rdeDSDataXML struct {
+	KeyTag     int    `xml:"secDNS:keyTag"`
+	Alg        int    `xml:"secDNS:alg"`
+	DigestType int    `xml:"secDNS:digestType"`
+	Digest     string `xml:"secDNS:digest"`
+}
+
+type rdeTrnDataXML struct {
+	TrStatus string `xml:"rdeDom:trStatus"`
+	ReRr     string `xml:"rdeDom:reRr"`
+	ReDate   string `xml:"rdeDom:reDate"`
+	AcRr     string `xml:"rdeDom:acRr"`
+	AcDate   string `xml:"rdeDom:acDate"`
+	ExDate   string `xml:"rdeDom:exDate,omitempty"`
+}
+
+// MarshalXML generates the <rdeDom:domain> element as defined in RFC 9022. The prefixes are declared on the deposit.
+func (d *RDEDomain) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	x := rdeDomainXML{
+		Name:         d.Name.String(),
+		RoID:         d.RoID,
+		UName:        d.UName,
+		IdnTableId:   d.IdnTableId,
+		OriginalName: d.OriginalName,
+		Registrant:   d.Registrant,
+		ClID:         d.ClID,
+		CrRr:         d.CrRr,
+		CrDate:       d.CrDate,
+		ExDate:       d.ExDate,
+		UpRr:         d.UpRr,
+		UpDate:       d.UpDate,
+	}
+	for _, s := range d.Status {
+		x.Status = append(x.Status, rdeStatusXML{S: s.S})
+	}
+	for _, s := range d.RgpStatus {
+		x.RgpStatus = append(x.RgpStatus, rdeStatusXML{S: s.S})
+	}
+	for _, c := range d.Contact {
+		x.Contact = append(x.Contact, rdeDomainContactXML{Type: c.Type, ID: c.ID})
+	}
+	// The schema allows a single ns element
+	for _, ns := range d.Ns {
+		if x.Ns == nil {
+			x.Ns = &rdeDomainNsXML{}
+		}
+		x.Ns.HostObjs = append(x.Ns.HostObjs, ns.HostObjs...)
+	}
+	if len(d.SecDNS.DSData) > 0 {
+		x.SecDNS = &rdeSecDNSXML{MaxSigLife: d.SecDNS.MaxSigLife}
+		for _, ds := range d.SecDNS.DSData {
+			x.SecDNS.DSData = append(x.SecDNS.DSData, rdeDSDataXML(ds))
+		}
+	}
+	if d.TrnData.TrStatus.State != "" {
+		x.TrnData = &rdeTrnDataXML{
+			TrStatus: d.TrnData.TrStatus.State,
+			ReRr:     d.TrnData.ReRr.RegID,
+			ReDate:   d.TrnData.ReDate,
+			AcRr:     d.TrnData.AcRr.RegID,
+			AcDate:   d.TrnData.AcDate,
+			ExDate:   d.TrnData.ExDate,
+		}
+	}
+	return e.Encode(x)
+}
@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"encoding/xml"
 	"errors"
 	"testing"
 	"time"
@@ -667,3 +668,105 @@ func TestGetDomainStatusFromRDEDomainStatus(t *testing.T) {
 	}
 
 }
+
+func TestNewRDEDomainFromEntity(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	d := &Domain{
+		RoID:         "12345_DOM-APEX",
+		Name:         "apex.domains",
+		OriginalName: "apex.domains",
+		UName:        "apex.domains",
+		RegistrantID: "reg-1",
+		AdminID:      "admin-1",
+		TechID:       "tech-1",
+		ClID:         "GoMamma",
+		CrRr:         "GoMamma",
+		ExpiryDate:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		CreatedAt:    time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC),
+		Status:       DomainStatus{PendingDelete: true, PendingRestore: true},
+		RGPStatus:    DomainRGPStatus{AddPeriodEnd: now.Add(time.Hour)},
+		Hosts: []*Host{
+			{Name: "ns1.apex.domains"},
+			{Name: "ns2.apex.domains"},
+		},
+		SecDNS: DomainSecDNS{
+			MaxSigLife: 604800,
+			DSData:     []DomainDSData{{KeyTag: 12345, Alg: 13, DigestType: 2, Digest: "E2D3C916F6DEEAC73294E8268FB5885044A833FC5459588F4A9184CFC41A5766"}},
+		},
+	}
+
+	rdeDomain := NewRDEDomainFromEntity(d, now)
+	require.Equal(t, []RDEDomainStatus{{S: DomainStatusPendingDelete}}, rdeDomain.Status)
+	require.Equal(t, []RDEDomainRGPStatus{{S: RGPStatusPendingRestore}}, rdeDomain.RgpStatus)
+	require.Equal(t, []RDEDomainContact{{Type: "admin", ID: "admin-1"}, {Type: "tech", ID: "tech-1"}}, rdeDomain.Contact)
+	require.Equal(t, []RDEDomainHost{{HostObjs: []string{"ns1.apex.domains", "ns2.apex.domains"}}}, rdeDomain.Ns)
+	require.Equal(t, "2025-03-01T00:00:00Z", rdeDomain.ExDate)
+	require.Empty(t, rdeDomain.UpDate)
+	require.Equal(t, RDESecDNS{MaxSigLife: 604800, DSData: []DSData{{KeyTag: 12345, Alg: 13, DigestType: 2, Digest: "E2D3C916F6DEEAC73294E8268FB5885044A833FC5459588F4A9184CFC41A5766"}}}, rdeDomain.SecDNS)
+
+	transfer := NewDomainTransfer(5)
+	transfer.GainingRegistrar = "gainingRar"
+	transfer.LosingRegistrar = "GoMamma"
+	rdeDomain.TrnData = NewRDETrnDataFromEntity(&transfer)
+	require.Equal(t, "pending", rdeDomain.TrnData.TrStatus.State)
+	require.Equal(t, "gainingRar", rdeDomain.TrnData.ReRr.RegID)
+	require.Equal(t, "GoMamma", rdeDomain.TrnData.AcRr.RegID)
+
+	// BRDA files do not contain contacts
+	thin := NewRDEThinDomainFromEntity(d, now)
+	require.Empty(t, thin.Registrant)
+	require.Empty(t, thin.Contact)
+	require.Equal(t, rdeDomain.Ns, thin.Ns)
+	require.Equal(t, "GoMamma", thin.ClID)
+}
+
+func TestRDEDomain_MarshalXML(t *testing.T) {
+	rdeDomain := &RDEDomain{
+		Name:       "apex.domains",
+		RoID:       "12345_DOM-APEX",
+		Status:     []RDEDomainStatus{{S: "ok"}},
+		Registrant: "reg-1",
+		Contact:    []RDEDomainContact{{Type: "admin", ID: "admin-1"}},
+		Ns:         []RDEDomainHost{{HostObjs: []string{"ns1.apex.domains"}}, {HostObjs: []string{"ns2.apex.domains"}}},
+		ClID:       "GoMamma",
+		CrDate:     "2024-02-28T00:00:00Z",
+		SecDNS:     RDESecDNS{MaxSigLife: 3600, DSData: []DSData{{KeyTag: 12345, Alg: 13, DigestType: 2, Digest: "ABCDEF"}}},
+	}
+
+	b, err := xml.Marshal(rdeDomain)
+	require.NoError(t, err)
+	require.Equal(t, `<rdeDom:domain><rdeDom:name>apex.domains</rdeDom:name><rdeDom:roid>12345_DOM-APEX</rdeDom:roid><rdeDom:status s="ok"></rdeDom:status><rdeDom:registrant>reg-1</rdeDom:registrant><rdeDom:contact type="admin">admin-1</rdeDom:contact><rdeDom:ns><domain:hostObj>ns1.apex.domains</domain:hostObj><domain:hostObj>ns2.apex.domains</domain:hostObj></rdeDom:ns><rdeDom:clID>GoMamma</rdeDom:clID><rdeDom:crDate>2024-02-28T00:00:00Z</rdeDom:crDate><rdeDom:secDNS><secDNS:maxSigLife>3600</secDNS:maxSigLife><secDNS:dsData><secDNS:keyTag>12345</secDNS:keyTag><secDNS:alg>13</secDNS:alg><secDNS:digestType>2</secDNS:digestType><secDNS:digest>ABCDEF</secDNS:digest></secDNS:dsData></rdeDom:secDNS></rdeDom:domain>`, string(b))
+
+	// The generated XML can be read by the import
+	var decoded RDEDomain
+	require.NoError(t, xml.Unmarshal(b, &decoded))
+	require.Equal(t, rdeDomain.Name, decoded.Name)
+	require.Equal(t, rdeDomain.Contact, decoded.Contact)
+	require.Equal(t, []RDEDomainHost{{HostObjs: []string{"ns1.apex.domains", "ns2.apex.domains"}}}, decoded.Ns)
+	require.Equal(t, rdeDomain.SecDNS, decoded.SecDNS)
+}
+
+func TestRDEDomain_ToEntity_SecDNS(t *testing.T) {
+	rdeDomain := &RDEDomain{
+		Name:   "apex.domains",
+		RoID:   "12345_DOM-APEX",
+		ClID:   "GoMamma",
+		ExDate: "2022-01-01T00:00:00Z",
+		CrDate: "2021-01-01T00:00:00Z",
+		Status: []RDEDomainStatus{{S: "ok"}},
+		SecDNS: RDESecDNS{
+			MaxSigLife: 3600,
+			DSData:     []DSData{{KeyTag: 12345, Alg: 13, DigestType: 2, Digest: "e2d3c916f6deeac73294e8268fb5885044a833fc5459588f4a9184cfc41a5766"}},
+		},
+	}
+
+	result, err := rdeDomain.ToEntity()
+	require.NoError(t, err)
+	require.True(t, result.Domain.IsSigned())
+	require.Equal(t, 3600, result.Domain.SecDNS.MaxSigLife)
+	require.Equal(t, []DomainDSData{{KeyTag: 12345, Alg: 13, DigestType: 2, Digest: "E2D3C916F6DEEAC73294E8268FB5885044A833FC5459588F4A9184CFC41A5766"}}, result.Domain.SecDNS.DSData)
+
+	rdeDomain.SecDNS.DSData[0].DigestType = 9
+	_, err = rdeDomain.ToEntity()
+	require.ErrorIs(t, err, ErrUnsupportedDSDigestType)
+}
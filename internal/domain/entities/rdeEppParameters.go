@@ -1,5 +1,14 @@
 package entities
 
+import "encoding/xml"
+
+var (
+	// RDEEppObjURIs are the EPP object services listed in the EPP parameters of a generated deposit
+	RDEEppObjURIs = []string{EPP_DOMAIN_URI, EPP_CONTACT_URI, EPP_HOST_URI}
+	// RDEEppExtURIs are the EPP extensions listed in the EPP parameters of a generated deposit. These should match the extensions supported by the EPP server.
+	RDEEppExtURIs = []string{}
+)
+
 type RDEEppParameters struct {
 	Version      string       `xml:"version"`
 	Lang         string       `xml:"lang"`
@@ -12,3 +21,52 @@ type RDEEppParameters struct {
 type RDEExtension struct {
 	ExtURI []string `xml:"extURI"`
 }
+
+// NewRDEEppParameters creates the RDEEppParameters for a generated deposit
+func NewRDEEppParameters() *RDEEppParameters {
+	return &RDEEppParameters{
+		Version:      "1.0",
+		Lang:         "en",
+		ObjUri:       RDEEppObjURIs,
+		SvcExtension: RDEExtension{ExtURI: RDEEppExtURIs},
+	}
+}
+
+// rdeEppParametersXML is the representation of the RDEEppParameters used when generating a deposit
+type rdeEppParametersXML struct {
+	XMLName      xml.Name            `xml:"rdeEppParams:eppParams"`
+	Version      string              `xml:"rdeEppParams:version"`
+	Lang         string              `xml:"rdeEppParams:lang"`
+	ObjUri       []string            `xml:"rdeEppParams:objURI"`
+	SvcExtension *rdeEppExtensionXML `xml:"rdeEppParams:svcExtension"`
+	DCP          rdeEppDCPXML        `xml:"rdeEppParams:dcp"`
+}
+
+type rdeEppExtensionXML struct {
+	ExtURI []string `xml:"epp:extURI"`
+}
+
+// rdeEppDCPXML is the data collection policy as advertised in the EPP greeting (RFC 5730): all data is accessible to the registry, collected for administrative and provisioning purposes and retained as stated in the registry's policy
+type rdeEppDCPXML struct {
+	Access    rdeEppEmptyXML `xml:"epp:access>epp:all"`
+	Admin     rdeEppEmptyXML `xml:"epp:statement>epp:purpose>epp:admin"`
+	Prov      rdeEppEmptyXML `xml:"epp:statement>epp:purpose>epp:prov"`
+	Ours      rdeEppEmptyXML `xml:"epp:statement>epp:recipient>epp:ours"`
+	Public    rdeEppEmptyXML `xml:"epp:statement>epp:recipient>epp:public"`
+	Retention rdeEppEmptyXML `xml:"epp:statement>epp:retention>epp:stated"`
+}
+
+type rdeEppEmptyXML struct{}
+
+// MarshalXML generates the <rdeEppParams:eppParams> element as defined in RFC 9022. The prefixes are declared on the deposit.
+func (p *RDEEppParameters) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	x := rdeEppParametersXML{
+		Version: p.Version,
+		Lang:    p.Lang,
+		ObjUri:  p.ObjUri,
+	}
+	if len(p.SvcExtension.ExtURI) > 0 {
+		x.SvcExtension = &rdeEppExtensionXML{ExtURI: p.SvcExtension.ExtURI}
+	}
+	return e.Encode(x)
+}
@@ -14,6 +14,17 @@ var (
 	HOST_URI      = "urn:ietf:params:xml:ns:rdeHost-1.0"
 	NNDN_URI      = "urn:ietf:params:xml:ns:rdeNNDN-1.0"
 	REGISTRAR_URI = "urn:ietf:params:xml:ns:rdeRegistrar-1.0"
+
+	// Additional namespaces used when generating an RDE Escrow deposit
+	RDE_URI         = "urn:ietf:params:xml:ns:rde-1.0"
+	HEADER_URI      = "urn:ietf:params:xml:ns:rdeHeader-1.0"
+	EPP_PARAMS_URI  = "urn:ietf:params:xml:ns:rdeEppParams-1.0"
+	EPP_URI         = "urn:ietf:params:xml:ns:epp-1.0"
+	EPP_DOMAIN_URI  = "urn:ietf:params:xml:ns:domain-1.0"
+	EPP_CONTACT_URI = "urn:ietf:params:xml:ns:contact-1.0"
+	EPP_HOST_URI    = "urn:ietf:params:xml:ns:host-1.0"
+	SECDNS_URI      = "urn:ietf:params:xml:ns:secDNS-1.1"
+	RGP_URI         = "urn:ietf:params:xml:ns:rgp-1.0"
 )
 
 // RegistrarMapping maps the ID of the registrar in the RDE Escrow file to the RdeRegistrarInfo
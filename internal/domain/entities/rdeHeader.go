@@ -1,6 +1,9 @@
 package entities
 
-import "fmt"
+import (
+	"encoding/xml"
+	"fmt"
+)
 
 var (
 	ErrHeaderCountNotFount = fmt.Errorf("count for this object not found, have you analyzed the header?")
@@ -72,3 +75,31 @@ func (h *RDEHeader) RegistrarCount() int {
 	}
 	return 0
 }
+
+// SetCount sets the number of objects with the provided URI, adding a count if it does not exist yet
+func (h *RDEHeader) SetCount(uri string, count int) {
+	for i := range h.Count {
+		if h.Count[i].Uri == uri {
+			h.Count[i].ID = count
+			return
+		}
+	}
+	h.Count = append(h.Count, RDECount{Uri: uri, ID: count})
+}
+
+// rdeHeaderXML is the representation of the RDEHeader used when generating a deposit or report
+type rdeHeaderXML struct {
+	XMLName xml.Name   `xml:"rdeHeader:header"`
+	XMLNS   string     `xml:"xmlns:rdeHeader,attr"`
+	TLD     string     `xml:"rdeHeader:tld"`
+	Count   []RDECount `xml:"rdeHeader:count"`
+}
+
+// MarshalXML generates the <rdeHeader:header> element. It declares its own namespace so it can be embedded in both the deposit and the report.
+func (h *RDEHeader) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.Encode(rdeHeaderXML{
+		XMLNS: HEADER_URI,
+		TLD:   h.TLD,
+		Count: h.Count,
+	})
+}
@@ -1,7 +1,10 @@
 package entities
 
 import (
+	"encoding/xml"
 	"testing"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestRDEHeader_IDNCount(t *testing.T) {
@@ -98,3 +101,21 @@ func TestRDEHeader_EmptyCounts(t *testing.T) {
 		t.Errorf("RegistrarCount() = %v, want %v", got, 0)
 	}
 }
+
+func TestRDEHeader_SetCount_MarshalXML(t *testing.T) {
+	header := RDEHeader{TLD: "apex"}
+	header.SetCount(DOMAIN_URI, 10)
+	header.SetCount(HOST_URI, 5)
+	header.SetCount(DOMAIN_URI, 12)
+	require.Equal(t, 12, header.DomainCount())
+	require.Equal(t, 5, header.HostCount())
+
+	b, err := xml.Marshal(&header)
+	require.NoError(t, err)
+	require.Equal(t, `<rdeHeader:header xmlns:rdeHeader="urn:ietf:params:xml:ns:rdeHeader-1.0"><rdeHeader:tld>apex</rdeHeader:tld><rdeHeader:count uri="urn:ietf:params:xml:ns:rdeDomain-1.0">12</rdeHeader:count><rdeHeader:count uri="urn:ietf:params:xml:ns:rdeHost-1.0">5</rdeHeader:count></rdeHeader:header>`, string(b))
+
+	// The generated header can be read by the escrow analysis
+	var decoded RDEHeader
+	require.NoError(t, xml.Unmarshal(b, &decoded))
+	require.Equal(t, header, decoded)
+}
@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"encoding/xml"
 	"reflect"
 	"strings"
 	"time"
@@ -138,3 +139,66 @@ func GetHostStatusFromRDEHostStatus(statuses []RDEHostStatus) (HostStatus, error
 	}
 	return hs, nil
 }
+
+// NewRDEHostFromEntity converts a Host entity to an RDEHost for inclusion in a deposit
+func NewRDEHostFromEntity(h *Host) *RDEHost {
+	rdeHost := &RDEHost{
+		Name:   h.Name.String(),
+		RoID:   h.RoID.String(),
+		ClID:   h.ClID.String(),
+		CrRr:   h.CrRr.String(),
+		CrDate: formatRDEDate(h.CreatedAt),
+		UpRr:   h.UpRr.String(),
+		UpDate: formatRDEDate(h.UpdatedAt),
+	}
+	for _, s := range h.Status.StringSlice() {
+		rdeHost.Status = append(rdeHost.Status, RDEHostStatus{S: s})
+	}
+	for _, a := range h.Addresses {
+		ip := "v4"
+		if a.Is6() {
+			ip = "v6"
+		}
+		rdeHost.Addr = append(rdeHost.Addr, RDEHostAddr{IP: ip, ID: a.String()})
+	}
+	return rdeHost
+}
+
+// rdeHostXML is the representation of the RDEHost used when generating a deposit
+type rdeHostXML struct {
+	XMLName xml.Name         `xml:"rdeHost:host"`
+	Name    string           `xml:"rdeHost:name"`
+	RoID    string           `xml:"rdeHost:roid"`
+	Status  []rdeStatusXML   `xml:"rdeHost:status"`
+	Addr    []rdeHostAddrXML `xml:"rdeHost:addr"`
+	ClID    string           `xml:"rdeHost:clID"`
+	CrRr    string           `xml:"rdeHost:crRr,omitempty"`
+	CrDate  string           `xml:"rdeHost:crDate,omitempty"`
+	UpRr    string           `xml:"rdeHost:upRr,omitempty"`
+	UpDate  string           `xml:"rdeHost:upDate,omitempty"`
+}
+
+type rdeHostAddrXML struct {
+	IP   string `xml:"ip,attr"`
+	Addr string `xml:",chardata"`
+}
+
+// MarshalXML generates the <rdeHost:host> element as defined in RFC 9022. The prefixes are declared on the deposit.
+func (h *RDEHost) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	x := rdeHostXML{
+		Name:   h.Name,
+		RoID:   h.RoID,
+		ClID:   h.ClID,
+		CrRr:   h.CrRr,
+		CrDate: h.CrDate,
+		UpRr:   h.UpRr,
+		UpDate: h.UpDate,
+	}
+	for _, s := range h.Status {
+		x.Status = append(x.Status, rdeStatusXML{S: s.S})
+	}
+	for _, a := range h.Addr {
+		x.Addr = append(x.Addr, rdeHostAddrXML{IP: a.IP, Addr: a.ID})
+	}
+	return e.Encode(x)
+}
@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"encoding/xml"
 	"testing"
 	"time"
 
@@ -275,3 +276,24 @@ func TestRDEHost_IsLinked(t *testing.T) {
 		})
 	}
 }
+
+func TestNewRDEHostFromEntity(t *testing.T) {
+	h, err := NewHost("ns1.apex.domains", "12345_HOST-APEX", "GoMamma")
+	require.NoError(t, err)
+	_, err = h.AddAddress("195.238.2.21")
+	require.NoError(t, err)
+	_, err = h.AddAddress("2001:db8::1")
+	require.NoError(t, err)
+
+	rdeHost := NewRDEHostFromEntity(h)
+	require.Equal(t, []RDEHostAddr{{IP: "v4", ID: "195.238.2.21"}, {IP: "v6", ID: "2001:db8::1"}}, rdeHost.Addr)
+	require.Equal(t, []RDEHostStatus{{S: "ok"}}, rdeHost.Status)
+
+	b, err := xml.Marshal(rdeHost)
+	require.NoError(t, err)
+	require.Contains(t, string(b), `<rdeHost:host><rdeHost:name>ns1.apex.domains</rdeHost:name><rdeHost:roid>12345_HOST-APEX</rdeHost:roid><rdeHost:status s="ok"></rdeHost:status><rdeHost:addr ip="v4">195.238.2.21</rdeHost:addr><rdeHost:addr ip="v6">2001:db8::1</rdeHost:addr><rdeHost:clID>GoMamma</rdeHost:clID>`)
+
+	var decoded RDEHost
+	require.NoError(t, xml.Unmarshal(b, &decoded))
+	require.Equal(t, *rdeHost, decoded)
+}
@@ -8,3 +8,16 @@ type RDEIdnTableReference struct {
 	Url       string   `xml:"url"`
 	UrlPolicy string   `xml:"urlPolicy"`
 }
+
+// rdeIdnTableReferenceXML is the representation of the RDEIdnTableReference used when generating a deposit
+type rdeIdnTableReferenceXML struct {
+	XMLName   xml.Name `xml:"rdeIDN:idnTableRef"`
+	ID        string   `xml:"id,attr"`
+	Url       string   `xml:"rdeIDN:url"`
+	UrlPolicy string   `xml:"rdeIDN:urlPolicy"`
+}
+
+// MarshalXML generates the <rdeIDN:idnTableRef> element as defined in RFC 9022. The prefixes are declared on the deposit.
+func (r *RDEIdnTableReference) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.Encode(rdeIdnTableReferenceXML{ID: r.ID, Url: r.Url, UrlPolicy: r.UrlPolicy})
+}
@@ -20,3 +20,36 @@ type RDENNDN struct {
 func (n *RDENNDN) ToCSV() []string {
 	return []string{n.AName, n.UName, n.IDNTableID, n.OriginalName, n.NameState, n.CrDate}
 }
+
+// NewRDENNDNFromEntity converts an NNDN entity to an RDENNDN for inclusion in a deposit
+func NewRDENNDNFromEntity(n *NNDN) *RDENNDN {
+	return &RDENNDN{
+		AName:     n.Name.String(),
+		UName:     n.UName.String(),
+		NameState: string(n.NameState),
+		CrDate:    formatRDEDate(n.CreatedAt),
+	}
+}
+
+// rdeNNDNXML is the representation of the RDENNDN used when generating a deposit
+type rdeNNDNXML struct {
+	XMLName      xml.Name `xml:"rdeNNDN:NNDN"`
+	AName        string   `xml:"rdeNNDN:aName"`
+	UName        string   `xml:"rdeNNDN:uName,omitempty"`
+	IDNTableID   string   `xml:"rdeNNDN:idnTableId,omitempty"`
+	OriginalName string   `xml:"rdeNNDN:originalName,omitempty"`
+	NameState    string   `xml:"rdeNNDN:nameState"`
+	CrDate       string   `xml:"rdeNNDN:crDate,omitempty"`
+}
+
+// MarshalXML generates the <rdeNNDN:NNDN> element as defined in RFC 9022. The prefixes are declared on the deposit.
+func (n *RDENNDN) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.Encode(rdeNNDNXML{
+		AName:        n.AName,
+		UName:        n.UName,
+		IDNTableID:   n.IDNTableID,
+		OriginalName: n.OriginalName,
+		NameState:    n.NameState,
+		CrDate:       n.CrDate,
+	})
+}
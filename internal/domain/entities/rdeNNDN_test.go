@@ -1,7 +1,9 @@
 package entities
 
 import (
+	"encoding/xml"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -22,3 +24,13 @@ func TestRDENNDN_ToCSV(t *testing.T) {
 	require.Equal(t, expected, result, "CSV values mismatch")
 	require.Equal(t, len(RDE_NNDN_CSV_HEADER), len(result), "CSV length mismatch")
 }
+
+func TestNewRDENNDNFromEntity(t *testing.T) {
+	n, err := NewNNDN("example.apex")
+	require.NoError(t, err)
+	n.CreatedAt = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	b, err := xml.Marshal(NewRDENNDNFromEntity(n))
+	require.NoError(t, err)
+	require.Equal(t, `<rdeNNDN:NNDN><rdeNNDN:aName>example.apex</rdeNNDN:aName><rdeNNDN:nameState>blocked</rdeNNDN:nameState><rdeNNDN:crDate>2024-03-01T00:00:00Z</rdeNNDN:crDate></rdeNNDN:NNDN>`, string(b))
+}
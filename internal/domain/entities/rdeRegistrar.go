@@ -229,3 +229,113 @@ func (r *RDERegistrar) ToEntity() (*Registrar, error) {
 type RDERegistrarStatus struct {
 	S string `xml:"s,attr"`
 }
+
+// NewRDEAddressFromEntity converts an Address entity to an RDEAddress. Empty street lines are omitted.
+func NewRDEAddressFromEntity(a *Address) RDEAddress {
+	addr := RDEAddress{
+		City:          a.City.String(),
+		StateProvince: a.StateProvince.String(),
+		PostalCode:    a.PostalCode.String(),
+		CountryCode:   a.CountryCode.String(),
+	}
+	for _, street := range []OptPostalLineType{a.Street1, a.Street2, a.Street3} {
+		if street != "" {
+			addr.Street = append(addr.Street, street.String())
+		}
+	}
+	return addr
+}
+
+// NewRDERegistrarFromEntity converts a Registrar entity to an RDERegistrar for inclusion in a deposit
+func NewRDERegistrarFromEntity(r *Registrar) *RDERegistrar {
+	rdeRar := &RDERegistrar{
+		ID:        r.ClID.String(),
+		Name:      r.Name,
+		GurID:     r.GurID,
+		Status:    RDERegistrarStatus{S: r.Status.String()},
+		Voice:     r.Voice.String(),
+		Fax:       r.Fax.String(),
+		Email:     r.Email,
+		URL:       r.URL.String(),
+		WhoisInfo: RDEWhoisInfo{Name: r.WhoisInfo.Name.String(), URL: r.WhoisInfo.URL.String()},
+		CrDate:    formatRDEDate(r.CreatedAt),
+		UpDate:    formatRDEDate(r.UpdatedAt),
+	}
+	for _, pi := range r.PostalInfo {
+		if pi == nil || pi.Address == nil {
+			continue
+		}
+		rdeRar.PostalInfo = append(rdeRar.PostalInfo, RDERegistrarPostalInfo{Type: string(pi.Type), Address: NewRDEAddressFromEntity(pi.Address)})
+	}
+	return rdeRar
+}
+
+// rdeRegistrarXML is the representation of the RDERegistrar used when generating a deposit
+type rdeRegistrarXML struct {
+	XMLName    xml.Name                    `xml:"rdeRegistrar:registrar"`
+	ID         string                      `xml:"rdeRegistrar:id"`
+	Name       string                      `xml:"rdeRegistrar:name"`
+	GurID      int                         `xml:"rdeRegistrar:gurid,omitempty"`
+	Status     string                      `xml:"rdeRegistrar:status"`
+	PostalInfo []rdeRegistrarPostalInfoXML `xml:"rdeRegistrar:postalInfo"`
+	Voice      string                      `xml:"rdeRegistrar:voice,omitempty"`
+	Fax        string                      `xml:"rdeRegistrar:fax,omitempty"`
+	Email      string                      `xml:"rdeRegistrar:email"`
+	URL        string                      `xml:"rdeRegistrar:url,omitempty"`
+	WhoisInfo  *rdeRegistrarWhoisInfoXML   `xml:"rdeRegistrar:whoisInfo"`
+	CrDate     string                      `xml:"rdeRegistrar:crDate,omitempty"`
+	UpDate     string                      `xml:"rdeRegistrar:upDate,omitempty"`
+}
+
+type rdeRegistrarPostalInfoXML struct {
+	Type string                 `xml:"type,attr"`
+	Addr rdeRegistrarAddressXML `xml:"rdeRegistrar:addr"`
+}
+
+type rdeRegistrarAddressXML struct {
+	Street        []string `xml:"rdeRegistrar:street"`
+	City          string   `xml:"rdeRegistrar:city"`
+	StateProvince string   `xml:"rdeRegistrar:sp,omitempty"`
+	PostalCode    string   `xml:"rdeRegistrar:pc,omitempty"`
+	CountryCode   string   `xml:"rdeRegistrar:cc"`
+}
+
+type rdeRegistrarWhoisInfoXML struct {
+	Name string `xml:"rdeRegistrar:name,omitempty"`
+	URL  string `xml:"rdeRegistrar:url,omitempty"`
+}
+
+// MarshalXML generates the <rdeRegistrar:registrar> element as defined in RFC 9022. The prefixes are declared on the deposit.
+func (r *RDERegistrar) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	x := rdeRegistrarXML{
+		ID:     r.ID,
+		Name:   r.Name,
+		GurID:  r.GurID,
+		Status: r.Status.S,
+		Voice:  r.Voice,
+		Fax:    r.Fax,
+		Email:  r.Email,
+		URL:    r.URL,
+		CrDate: r.CrDate,
+		UpDate: r.UpDate,
+	}
+	if x.Status == "" {
+		x.Status = string(RegistrarStatusOK)
+	}
+	for _, pi := range r.PostalInfo {
+		x.PostalInfo = append(x.PostalInfo, rdeRegistrarPostalInfoXML{
+			Type: pi.Type,
+			Addr: rdeRegistrarAddressXML{
+				Street:        pi.Address.Street,
+				City:          pi.Address.City,
+				StateProvince: pi.Address.StateProvince,
+				PostalCode:    pi.Address.PostalCode,
+				CountryCode:   pi.Address.CountryCode,
+			},
+		})
+	}
+	if r.WhoisInfo.Name != "" || r.WhoisInfo.URL != "" {
+		x.WhoisInfo = &rdeRegistrarWhoisInfoXML{Name: r.WhoisInfo.Name, URL: r.WhoisInfo.URL}
+	}
+	return e.Encode(x)
+}
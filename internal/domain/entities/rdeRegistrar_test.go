@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"encoding/xml"
 	"testing"
 	"time"
 
@@ -716,3 +717,22 @@ func TestRDEAddress_ToCSV(t *testing.T) {
 		})
 	}
 }
+
+func TestNewRDERegistrarFromEntity(t *testing.T) {
+	addr, err := NewAddress("Brussels", "BE")
+	require.NoError(t, err)
+	pi, err := NewRegistrarPostalInfo("int", addr)
+	require.NoError(t, err)
+	rar, err := NewRegistrar("GoMamma", "Go Mamma Inc", "abuse@gomamma.com", 1234, [2]*RegistrarPostalInfo{pi})
+	require.NoError(t, err)
+
+	rdeRar := NewRDERegistrarFromEntity(rar)
+	b, err := xml.Marshal(rdeRar)
+	require.NoError(t, err)
+	require.Contains(t, string(b), `<rdeRegistrar:registrar><rdeRegistrar:id>GoMamma</rdeRegistrar:id><rdeRegistrar:name>Go Mamma Inc</rdeRegistrar:name><rdeRegistrar:gurid>1234</rdeRegistrar:gurid><rdeRegistrar:status>readonly</rdeRegistrar:status><rdeRegistrar:postalInfo type="int"><rdeRegistrar:addr><rdeRegistrar:city>Brussels</rdeRegistrar:city><rdeRegistrar:cc>BE</rdeRegistrar:cc></rdeRegistrar:addr></rdeRegistrar:postalInfo>`)
+
+	var decoded RDERegistrar
+	require.NoError(t, xml.Unmarshal(b, &decoded))
+	require.Equal(t, rdeRar.ID, decoded.ID)
+	require.Equal(t, rdeRar.PostalInfo[0].Address.City, decoded.PostalInfo[0].Address.City)
+}
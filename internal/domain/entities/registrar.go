@@ -1,11 +1,15 @@
 package entities
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
 	"net/mail"
 	"strings"
 	"time"
 
 	"errors"
+
+	"golang.org/x/crypto/bcrypt"
 )
 
 const (
@@ -18,6 +22,12 @@ const (
 
 	RegistrarPostalInfoTypeINT = "int"
 	RegistrarPostalInfoTypeLOC = "loc"
+
+	// EPP passwords longer than the RFC 5730 pwType (6-16 characters) can be provided through the login security extension (RFC 8807)
+	EPP_PASSWORD_MIN_LENGTH = 12
+	EPP_PASSWORD_MAX_LENGTH = 128
+	// EPP_PASSWORD_MIN_CHARACTER_CLASSES is the number of character classes (lowercase, uppercase, digits and other characters) an EPP password must contain
+	EPP_PASSWORD_MIN_CHARACTER_CLASSES = 3
 )
 
 var (
@@ -29,6 +39,10 @@ var (
 	ErrInvalidRegistrarIANAStatus                       = errors.New("invalid registrar status: status must be one of 'Reserved', 'Terminated', 'Accredited', 'Unknown'")
 	ErrRegistrarPostalInfoTypeExists                    = errors.New("postalinfo of this type already exists")
 	ErrRegistrarStatusPreventsAccreditation             = errors.New("registrar status prevents accreditation")
+	ErrRegistrarStatusPreventsCreate                    = errors.New("registrar status prevents creating new objects")
+	ErrInvalidEPPPassword                               = errors.New("invalid EPP password: must be between 12 and 128 characters and contain at least 3 of lowercase, uppercase, digits and other characters")
+	ErrEPPPasswordReused                                = errors.New("the new EPP password must be different from the current password")
+	ErrEPPAuthenticationFailed                          = errors.New("EPP authentication failed")
 	ErrOnlyICANNAccreditedRegistrarsCanAccreditForGTLDs = errors.New("only ICANN accredited registrars can accredit for gTLDs")
 
 	VALID_RAR_STATUSES = []RegistrarStatus{RegistrarStatusOK, RegistrarStatusReadonly, RegistrarStatusTerminated}
@@ -52,6 +66,11 @@ func (r *RegistrarStatus) IsValid() bool {
 	return false
 }
 
+// AllowsCreate returns true if a registrar with this status can create new objects. Readonly and terminated registrars can only manage their existing objects
+func (r *RegistrarStatus) AllowsCreate() bool {
+	return strings.EqualFold(string(*r), string(RegistrarStatusOK))
+}
+
 // Registrar object represents the sponsoring client for other objects and is typically referred to as the sponsoring registrar.
 // Ref: https://www.rfc-editor.org/rfc/rfc9022.html#name-registrar-object
 type Registrar struct {
@@ -83,6 +102,10 @@ type Registrar struct {
 	UpdatedAt   time.Time
 	// The TLDs the registrar is accredited for
 	TLDs []*TLD
+	// The bcrypt hash of the password the registrar uses to login to the EPP server. This is never exposed through the API
+	EPPPasswordHash string `json:"-"`
+	// EPPLogin holds the state of the EPP login security (RFC 8807) of the registrar
+	EPPLogin EPPLoginState
 }
 
 // RegistrarListItem is a subset of the Registrar object that is used in lists (e.g. list all registrars) when the full object is not needed
@@ -274,6 +297,44 @@ func (r *Registrar) SetStatus(s RegistrarStatus) error {
 	return nil
 }
 
+// SetEPPPassword validates the password against the password policy and stores its bcrypt hash on the registrar, see eppPasswordDigest.
+// The new password can't be the same as the current password, setting it restarts the password expiry period and lifts a failed login lockout.
+func (r *Registrar) SetEPPPassword(pw string) error {
+	if err := ValidateEPPPassword(pw); err != nil {
+		return err
+	}
+	if r.EPPPasswordHash != "" && r.VerifyEPPPassword(pw) == nil {
+		return ErrEPPPasswordReused
+	}
+	hash, err := bcrypt.GenerateFromPassword(eppPasswordDigest(pw), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	r.EPPPasswordHash = string(hash)
+	r.EPPLogin.PasswordChangedAt = time.Now().UTC()
+	r.EPPLogin.FailedLogins = 0
+	r.EPPLogin.LockedUntil = time.Time{}
+	return nil
+}
+
+// VerifyEPPPassword returns ErrEPPAuthenticationFailed if the password does not match the stored hash or no password has been set
+func (r *Registrar) VerifyEPPPassword(pw string) error {
+	if r.EPPPasswordHash == "" {
+		return ErrEPPAuthenticationFailed
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(r.EPPPasswordHash), eppPasswordDigest(pw)); err != nil {
+		return ErrEPPAuthenticationFailed
+	}
+	return nil
+}
+
+// eppPasswordDigest returns the base64 encoded SHA-256 digest of the password that is hashed with bcrypt.
+// bcrypt only accepts 72 bytes, the digest lets the full length of the passwords the policy allows count.
+func eppPasswordDigest(pw string) []byte {
+	digest := sha256.Sum256([]byte(pw))
+	return []byte(base64.StdEncoding.EncodeToString(digest[:]))
+}
+
 // DeepCopy creates a new Registrar with a copy of the original values
 func (r Registrar) DeepCopy() Registrar {
 	// First, do a shallow copy of all value fields:
@@ -294,6 +355,8 @@ func (r Registrar) DeepCopy() Registrar {
 		CreatedAt:   r.CreatedAt, // time.Time is a value type
 		UpdatedAt:   r.UpdatedAt,
 		// TLDs omitted per request (would need its own deep copy logic if included)
+		EPPPasswordHash: r.EPPPasswordHash,
+		EPPLogin:        r.EPPLogin,
 	}
 
 	// Now deep-copy the PostalInfo array (which holds *RegistrarPostalInfo):
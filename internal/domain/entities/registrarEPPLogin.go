@@ -0,0 +1,134 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// EPP_PASSWORD_MAX_AGE is the time after which an EPP password expires and must be changed at login
+	EPP_PASSWORD_MAX_AGE = 180 * 24 * time.Hour
+	// EPP_PASSWORD_EXPIRY_WARNING_PERIOD is the time before the expiry of the EPP password during which the registrar is warned at login
+	EPP_PASSWORD_EXPIRY_WARNING_PERIOD = 14 * 24 * time.Hour
+	// EPP_MAX_FAILED_LOGINS is the number of consecutive failed EPP logins after which the registrar is locked out
+	EPP_MAX_FAILED_LOGINS = 5
+	// EPP_LOGIN_LOCKOUT_DURATION is the time a registrar is locked out after too many consecutive failed EPP logins
+	EPP_LOGIN_LOCKOUT_DURATION = 30 * time.Minute
+)
+
+var (
+	ErrEPPPasswordExpired = errors.New("the EPP password has expired and must be changed")
+	ErrEPPLoginLocked     = errors.New("EPP login is locked after too many failed attempts")
+)
+
+// EPPUserAgent identifies the client software a registrar uses to connect to the EPP server as provided in the login security extension.
+// Ref: https://datatracker.ietf.org/doc/html/rfc8807#section-3.2
+type EPPUserAgent struct {
+	// App is the name and version of the client application or SDK
+	App string
+	// Tech is the name and version of the technology the client is built with
+	Tech string
+	// OS is the name and version of the operating system the client runs on
+	OS string
+}
+
+// IsEmpty returns true if the client did not provide any information about its user agent
+func (ua EPPUserAgent) IsEmpty() bool {
+	return ua.App == "" && ua.Tech == "" && ua.OS == ""
+}
+
+// EPPLoginState holds the state of the EPP login security of a registrar: the expiry of its password, the failed login attempts and the client it last logged in with.
+type EPPLoginState struct {
+	// PasswordChangedAt is the time the EPP password was last set, the password expires EPP_PASSWORD_MAX_AGE after this time
+	PasswordChangedAt time.Time
+	// FailedLogins is the number of consecutive failed logins since the last successful login
+	FailedLogins int
+	// LockedUntil is the time until which logins are refused after too many failed attempts
+	LockedUntil time.Time
+	// LastLoginAt is the time of the last successful login
+	LastLoginAt time.Time
+	// UserAgent is the client the registrar last logged in with
+	UserAgent EPPUserAgent
+}
+
+// ValidateEPPPassword checks the password complies with the EPP password policy.
+// It must be between EPP_PASSWORD_MIN_LENGTH and EPP_PASSWORD_MAX_LENGTH characters and contain at least EPP_PASSWORD_MIN_CHARACTER_CLASSES of lowercase letters, uppercase letters, digits and other characters.
+// Spaces are allowed inside passphrases, but don't count as a character class.
+func ValidateEPPPassword(pw string) error {
+	length := len([]rune(pw))
+	if length < EPP_PASSWORD_MIN_LENGTH || length > EPP_PASSWORD_MAX_LENGTH || strings.TrimSpace(pw) != pw {
+		return ErrInvalidEPPPassword
+	}
+	var lower, upper, digit, other int
+	for _, r := range pw {
+		switch {
+		case r == ' ':
+			continue
+		case unicode.IsSpace(r) || unicode.IsControl(r):
+			return ErrInvalidEPPPassword
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			other = 1
+		}
+	}
+	if lower+upper+digit+other < EPP_PASSWORD_MIN_CHARACTER_CLASSES {
+		return ErrInvalidEPPPassword
+	}
+	return nil
+}
+
+// EPPPasswordExpiresAt returns the time the EPP password expires. Passwords that were set before their change time was recorded don't expire and return the zero time.
+func (r *Registrar) EPPPasswordExpiresAt() time.Time {
+	if r.EPPLogin.PasswordChangedAt.IsZero() {
+		return time.Time{}
+	}
+	return r.EPPLogin.PasswordChangedAt.Add(EPP_PASSWORD_MAX_AGE)
+}
+
+// EPPPasswordExpired returns true if the EPP password has expired at the provided time
+func (r *Registrar) EPPPasswordExpired(t time.Time) bool {
+	expiresAt := r.EPPPasswordExpiresAt()
+	return !expiresAt.IsZero() && !t.Before(expiresAt)
+}
+
+// EPPPasswordExpiresSoon returns true if the EPP password expires within the warning period at the provided time
+func (r *Registrar) EPPPasswordExpiresSoon(t time.Time) bool {
+	expiresAt := r.EPPPasswordExpiresAt()
+	return !expiresAt.IsZero() && !t.Before(expiresAt.Add(-EPP_PASSWORD_EXPIRY_WARNING_PERIOD))
+}
+
+// CheckEPPLoginLock returns ErrEPPLoginLocked if the registrar is locked out at the provided time
+func (r *Registrar) CheckEPPLoginLock(t time.Time) error {
+	if t.Before(r.EPPLogin.LockedUntil) {
+		return errors.Join(ErrEPPLoginLocked, fmt.Errorf("locked until %s", r.EPPLogin.LockedUntil.Format(time.RFC3339)))
+	}
+	return nil
+}
+
+// RecordFailedEPPLogin records a failed login attempt at the provided time.
+// The registrar is locked out for EPP_LOGIN_LOCKOUT_DURATION once the number of consecutive failed logins reaches EPP_MAX_FAILED_LOGINS, every further failure renews the lockout.
+func (r *Registrar) RecordFailedEPPLogin(t time.Time) {
+	r.EPPLogin.FailedLogins++
+	if r.EPPLogin.FailedLogins >= EPP_MAX_FAILED_LOGINS {
+		r.EPPLogin.LockedUntil = t.Add(EPP_LOGIN_LOCKOUT_DURATION)
+	}
+}
+
+// RecordEPPLogin records a successful login at the provided time with the user agent of the client, it resets the failed login attempts.
+// An empty user agent keeps the previously recorded user agent.
+func (r *Registrar) RecordEPPLogin(t time.Time, ua EPPUserAgent) {
+	r.EPPLogin.FailedLogins = 0
+	r.EPPLogin.LockedUntil = time.Time{}
+	r.EPPLogin.LastLoginAt = t
+	if !ua.IsEmpty() {
+		r.EPPLogin.UserAgent = ua
+	}
+}
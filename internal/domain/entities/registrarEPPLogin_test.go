@@ -0,0 +1,96 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateEPPPassword(t *testing.T) {
+	tests := []struct {
+		name    string
+		pw      string
+		wantErr error
+	}{
+		{"valid", "s3cr3tPW-2024!", nil},
+		{"three classes", "s3cr3tpassword!", nil},
+		{"passphrase", "Correct horse battery staple 42", nil},
+		{"passphrase with two classes", "Correct horse battery staple", ErrInvalidEPPPassword},
+		{"leading space", " s3cr3tPW-2024!", ErrInvalidEPPPassword},
+		{"long password", strings.Repeat("aB3-", 32), nil},
+		{"too short", "aB3-aB3-", ErrInvalidEPPPassword},
+		{"too long", strings.Repeat("aB3-", 32) + "x", ErrInvalidEPPPassword},
+		{"two classes", "secretpassword123", ErrInvalidEPPPassword},
+		{"tab", "s3cr3t\tPW-2024!", ErrInvalidEPPPassword},
+		{"unicode", "contraseñaSegura1", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEPPPassword(tt.pw)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRegistrar_EPPPasswordExpiry(t *testing.T) {
+	now := time.Now().UTC()
+	r := &Registrar{ClID: "my-registrar"}
+
+	// Passwords without a change time don't expire
+	require.True(t, r.EPPPasswordExpiresAt().IsZero())
+	require.False(t, r.EPPPasswordExpired(now))
+	require.False(t, r.EPPPasswordExpiresSoon(now))
+
+	r.EPPLogin.PasswordChangedAt = now.Add(-EPP_PASSWORD_MAX_AGE).Add(time.Hour)
+	require.Equal(t, now.Add(time.Hour), r.EPPPasswordExpiresAt())
+	require.False(t, r.EPPPasswordExpired(now))
+	require.True(t, r.EPPPasswordExpiresSoon(now))
+
+	r.EPPLogin.PasswordChangedAt = now.Add(-EPP_PASSWORD_MAX_AGE)
+	require.True(t, r.EPPPasswordExpired(now))
+
+	r.EPPLogin.PasswordChangedAt = now
+	require.False(t, r.EPPPasswordExpiresSoon(now))
+}
+
+func TestRegistrar_EPPLoginLockout(t *testing.T) {
+	now := time.Now().UTC()
+	r := &Registrar{ClID: "my-registrar"}
+
+	for i := 1; i < EPP_MAX_FAILED_LOGINS; i++ {
+		r.RecordFailedEPPLogin(now)
+		require.NoError(t, r.CheckEPPLoginLock(now))
+	}
+	r.RecordFailedEPPLogin(now)
+	require.ErrorIs(t, r.CheckEPPLoginLock(now), ErrEPPLoginLocked)
+	require.NoError(t, r.CheckEPPLoginLock(now.Add(EPP_LOGIN_LOCKOUT_DURATION)))
+
+	// Setting a new password lifts the lockout
+	require.NoError(t, r.SetEPPPassword("s3cr3tPW-2024!"))
+	require.NoError(t, r.CheckEPPLoginLock(now))
+	require.Zero(t, r.EPPLogin.FailedLogins)
+}
+
+func TestRegistrar_RecordEPPLogin(t *testing.T) {
+	now := time.Now().UTC()
+	r := &Registrar{ClID: "my-registrar"}
+	r.RecordFailedEPPLogin(now)
+
+	ua := EPPUserAgent{App: "EPP SDK 1.0.0", Tech: "Go 1.22", OS: "x86_64 Linux"}
+	r.RecordEPPLogin(now, ua)
+	require.Zero(t, r.EPPLogin.FailedLogins)
+	require.Equal(t, now, r.EPPLogin.LastLoginAt)
+	require.Equal(t, ua, r.EPPLogin.UserAgent)
+
+	// A login without user agent keeps the previous one
+	r.RecordEPPLogin(now.Add(time.Hour), EPPUserAgent{})
+	require.Equal(t, ua, r.EPPLogin.UserAgent)
+	require.Equal(t, now.Add(time.Hour), r.EPPLogin.LastLoginAt)
+}
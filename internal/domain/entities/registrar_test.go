@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -664,3 +665,54 @@ func TestRegistrar_DeepCopy(t *testing.T) {
 		}
 	}
 }
+
+func TestRegistrarStatus_AllowsCreate(t *testing.T) {
+	testcases := []struct {
+		status RegistrarStatus
+		want   bool
+	}{
+		{RegistrarStatusOK, true},
+		{RegistrarStatus("OK"), true},
+		{RegistrarStatusReadonly, false},
+		{RegistrarStatusTerminated, false},
+		{RegistrarStatus(""), false},
+	}
+
+	for _, tc := range testcases {
+		t.Run(string(tc.status), func(t *testing.T) {
+			require.Equal(t, tc.want, tc.status.AllowsCreate())
+		})
+	}
+}
+
+func TestRegistrar_EPPPassword(t *testing.T) {
+	r := &Registrar{ClID: "my-registrar"}
+
+	// No password set
+	require.ErrorIs(t, r.VerifyEPPPassword("s3cr3tPW-2024!"), ErrEPPAuthenticationFailed)
+
+	// Invalid passwords
+	require.ErrorIs(t, r.SetEPPPassword("Sh0rt!"), ErrInvalidEPPPassword)
+	require.ErrorIs(t, r.SetEPPPassword("alllowercaseletters"), ErrInvalidEPPPassword)
+	require.Empty(t, r.EPPPasswordHash)
+	require.True(t, r.EPPLogin.PasswordChangedAt.IsZero())
+
+	// Valid password is stored as a hash
+	require.NoError(t, r.SetEPPPassword("s3cr3tPW-2024!"))
+	require.NotEmpty(t, r.EPPPasswordHash)
+	require.NotEqual(t, "s3cr3tPW-2024!", r.EPPPasswordHash)
+	require.False(t, r.EPPLogin.PasswordChangedAt.IsZero())
+
+	require.NoError(t, r.VerifyEPPPassword("s3cr3tPW-2024!"))
+	require.ErrorIs(t, r.VerifyEPPPassword("wr0ngPW!"), ErrEPPAuthenticationFailed)
+
+	// The current password can't be reused
+	require.ErrorIs(t, r.SetEPPPassword("s3cr3tPW-2024!"), ErrEPPPasswordReused)
+
+	// Passwords longer than the 72 bytes bcrypt accepts are verified in full
+	long := strings.Repeat("aB3-ñ", 25)
+	require.Greater(t, len(long), 100)
+	require.NoError(t, r.SetEPPPassword(long))
+	require.NoError(t, r.VerifyEPPPassword(long))
+	require.ErrorIs(t, r.VerifyEPPPassword("x"+long[1:]), ErrEPPAuthenticationFailed)
+}
@@ -159,9 +159,9 @@ func (t *TLD) GetCurrentGAPhase() (*Phase, error) {
 // GetCurrentLaunchPhases returns a slice of all current launch phases. If no active launch phase is found, an empty slice is returned.
 func (t *TLD) GetCurrentLaunchPhases() []Phase {
 	var phases []Phase
-	for i := 0; i < len(t.GetLaunchPhases()); i++ {
-		if t.Phases[i].Starts.Before(time.Now().UTC()) && (t.Phases[i].Ends == nil || t.Phases[i].Ends.After(time.Now().UTC())) {
-			phases = append(phases, t.Phases[i])
+	for _, launchPhase := range t.GetLaunchPhases() {
+		if launchPhase.IsCurrentlyActive() {
+			phases = append(phases, launchPhase)
 		}
 	}
 	return phases
@@ -781,10 +781,20 @@ func TestTLD_GetCurrentLaunchPhases(t *testing.T) {
 			inputTLD: &TLD{Name: "example.com", Phases: []Phase{{Name: "Launch", Type: PhaseTypeLaunch, Starts: time.Now().AddDate(0, 0, -1), Ends: &endDateTime}, {Name: "Launch2", Type: PhaseTypeLaunch, Starts: time.Now().AddDate(0, 0, -1), Ends: &endDateTime}}},
 			expected: 2,
 		},
+		{
+			name:     "current GA phase before a future Launch phase",
+			inputTLD: &TLD{Name: "example.com", Phases: []Phase{{Name: "GA", Type: PhaseTypeGA, Starts: time.Now().AddDate(0, 0, -1)}, {Name: "Launch", Type: PhaseTypeLaunch, Starts: time.Now().AddDate(0, 0, 1)}}},
+			expected: 0,
+		},
 	}
 
 	for _, test := range tests {
 		result := test.inputTLD.GetCurrentLaunchPhases()
+		for _, p := range result {
+			if p.Type != PhaseTypeLaunch {
+				t.Errorf("Expected only Launch phases, but got %s for input %s", p.Name, test.name)
+			}
+		}
 		if len(result) != test.expected {
 			t.Errorf("Expected number of Launch phases to be %d, but got %d for input %s", test.expected, len(result), test.name)
 		}
@@ -2,6 +2,7 @@ package entities
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -21,14 +22,44 @@ const (
 
 	// TransferStatusDenied means the transfer request was explicitly rejected.
 	TransferStatusDenied TransferStatus = "denied"
+
+	// TransferStatusCancelled means the gaining registrar withdrew the transfer request.
+	TransferStatusCancelled TransferStatus = "cancelled"
+
+	// TRANSFER_YEARS is the number of years a domain is extended with when a transfer completes.
+	TRANSFER_YEARS = 1
 )
 
 var (
-	// ErrTransferComplete is returned when trying to update a transfer that is already approved or denied.
-	ErrTransferComplete = errors.New("transfer is already approved or denied and cannot be updated")
+	// ErrTransferComplete is returned when trying to update a transfer that is already approved, denied or cancelled.
+	ErrTransferComplete = errors.New("transfer is already approved, denied or cancelled and cannot be updated")
 
 	// ErrInvalidTransferStatus is returned when trying to update a transfer with an invalid status.
 	ErrInvalidTransferStatus = errors.New("invalid transfer status")
+
+	// ErrTransferNotFound is returned when a domain has no transfer history.
+	ErrTransferNotFound = errors.New("transfer not found")
+
+	// ErrNoPendingTransfer is returned when trying to approve, reject or cancel a transfer on a domain that is not pending transfer.
+	ErrNoPendingTransfer = errors.New("domain is not pending transfer")
+
+	// ErrDomainPendingTransfer is returned when requesting a transfer of a domain that is already pending transfer.
+	ErrDomainPendingTransfer = errors.New("domain is already pending transfer")
+
+	// ErrTransferToSponsor is returned when the sponsoring registrar requests a transfer of its own domain.
+	ErrTransferToSponsor = errors.New("domain is already sponsored by the requesting registrar")
+
+	// ErrDomainTransferNotAllowed is returned when the status of a domain prohibits a transfer.
+	ErrDomainTransferNotAllowed = errors.New("domain status does not allow transfer")
+
+	// ErrDomainTransferLocked is returned when requesting a transfer of a domain that is within its transfer lock period.
+	ErrDomainTransferLocked = errors.New("domain is within its transfer lock period")
+
+	// ErrTransferAuthInfoMismatch is returned when the authInfo provided with a transfer request does not match the domain's authInfo.
+	ErrTransferAuthInfoMismatch = errors.New("authInfo does not match")
+
+	// ErrInvalidTransferPeriod is returned when a transfer is requested for a period other than TRANSFER_YEARS.
+	ErrInvalidTransferPeriod = fmt.Errorf("transfers extend the registration by %d year", TRANSFER_YEARS)
 )
 
 // DomainTransfer holds information about a domain's transfer event.
@@ -42,15 +73,18 @@ type DomainTransfer struct {
 	// The name of domain being transferred.
 	DomainName DomainName
 
-	// The registrar that is attempting to take over management of the domain.
-	GainingRegistrar Registrar
+	// The ClID of the registrar that is attempting to take over management of the domain.
+	GainingRegistrar ClIDType
 
-	// The current or losing registrar of the domain.
-	LosingRegistrar Registrar
+	// The ClID of the current or losing registrar of the domain.
+	LosingRegistrar ClIDType
 
-	// TransferStatus indicates whether the transfer is pending, approved, denied or failed.
+	// TransferStatus indicates whether the transfer is pending, approved, denied or cancelled.
 	Status TransferStatus
 
+	// The number of years the registration is extended with when the transfer completes.
+	Years int
+
 	// Time the transfer was requested (when <transfer> command was sent to the registry).
 	CreatedAt time.Time
 
@@ -81,6 +115,7 @@ func NewDomainTransfer(transferGracePolicyDays int) DomainTransfer {
 	return DomainTransfer{
 		ID:         uuid.New(),
 		Status:     TransferStatusPending,
+		Years:      TRANSFER_YEARS,
 		CreatedAt:  time.Now().UTC(),
 		ExpiryDate: time.Now().UTC().AddDate(0, 0, transferGracePolicyDays),
 		UpdatedAt:  time.Now().UTC(),
@@ -96,6 +131,13 @@ type RequestTransferCommand struct {
 	LosingRegistrar string
 
 	// DomainName is the name of the domain being transferred.
+	DomainName string
+
+	// AuthInfo is the authorization information of the domain provided by the gaining registrar.
+	AuthInfo string
+
+	// Years is the number of years the registration is extended with. If zero, TRANSFER_YEARS is used.
+	Years int
 }
 
 // FinalizeTransferCommand is a command that can be used to approve or deny a domain transfer.
@@ -110,7 +152,7 @@ type FinalizeTransferCommand struct {
 	Status TransferStatus
 }
 
-// Finalize is a method that can be used to approve or deny a domain transfer.
+// Finalize is a method that can be used to approve, deny or cancel a domain transfer.
 // It will return an error if the transfer cannot be finalized.
 func (t *DomainTransfer) Finalize(cmd FinalizeTransferCommand) error {
 	switch cmd.Status {
@@ -118,6 +160,8 @@ func (t *DomainTransfer) Finalize(cmd FinalizeTransferCommand) error {
 		return t.approve(cmd.CorrelationID, cmd.Reason)
 	case TransferStatusDenied:
 		return t.deny(cmd.CorrelationID, cmd.Reason)
+	case TransferStatusCancelled:
+		return t.cancel(cmd.CorrelationID, cmd.Reason)
 	default:
 		return ErrInvalidTransferStatus
 	}
@@ -161,8 +205,31 @@ func (t *DomainTransfer) deny(correlationID, reason string) error {
 	return nil
 }
 
-// isComplete returns true if the transfer is approved or denied.
+// cancel marks the transfer as cancelled and sets the status to TransferStatusCancelled.
+// CorrelationID is an optional field that can be used to store the ID of the request that cancelled the transfer.
+// If the transfer is already approved or denied, it will return an error. If the transfer is already cancelled, it will be idempotent.
+func (t *DomainTransfer) cancel(correlationID, reason string) error {
+	if t.isComplete() {
+		if t.Status == TransferStatusCancelled {
+			return nil // already cancelled - idempotent
+		}
+		return ErrTransferComplete
+	}
+	t.Status = TransferStatusCancelled
+	t.Reason = reason
+	t.CorrelationID = correlationID
+	t.UpdatedAt = time.Now().UTC()
+
+	return nil
+}
+
+// IsPending returns true if the transfer is waiting for a response from the losing registrar.
+func (t *DomainTransfer) IsPending() bool {
+	return t.Status == TransferStatusPending
+}
+
+// isComplete returns true if the transfer is approved, denied or cancelled.
 // a completed transfer should not be updated.
 func (t *DomainTransfer) isComplete() bool {
-	return t.Status == TransferStatusApproved || t.Status == TransferStatusDenied
+	return t.Status == TransferStatusApproved || t.Status == TransferStatusDenied || t.Status == TransferStatusCancelled
 }
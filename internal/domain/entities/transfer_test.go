@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewDomainTransfer(t *testing.T) {
@@ -218,3 +219,23 @@ func TestDomainTransfer_Finalize(t *testing.T) {
 		})
 	}
 }
+
+func TestDomainTransfer_Cancel(t *testing.T) {
+	cmd := FinalizeTransferCommand{Status: TransferStatusCancelled, CorrelationID: "correlationID", Reason: "reason"}
+
+	transfer := NewDomainTransfer(5)
+	require.True(t, transfer.IsPending())
+	require.NoError(t, transfer.Finalize(cmd))
+	require.Equal(t, TransferStatusCancelled, transfer.Status)
+	require.False(t, transfer.IsPending())
+
+	// Cancelling again is idempotent
+	require.NoError(t, transfer.Finalize(cmd))
+
+	// A cancelled transfer can no longer be approved
+	require.ErrorIs(t, transfer.Finalize(FinalizeTransferCommand{Status: TransferStatusApproved}), ErrTransferComplete)
+
+	// An approved transfer can no longer be cancelled
+	transfer = DomainTransfer{Status: TransferStatusApproved}
+	require.ErrorIs(t, transfer.Finalize(cmd), ErrTransferComplete)
+}
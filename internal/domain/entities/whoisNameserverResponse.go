@@ -0,0 +1,44 @@
+package entities
+
+import (
+	"time"
+)
+
+// WhoisNameserverResponse represents the WHOIS response for a nameserver as defined in the ICANN Registry Agreement Specification 4 section 1.6
+type WhoisNameserverResponse struct {
+	ServerName           string    `json:"serverName"`
+	IPAddresses          []string  `json:"ipAddresses"`
+	Registrar            string    `json:"registrar"`
+	RegistrarWhoisServer string    `json:"registrarWhoisServer"`
+	RegistrarURL         string    `json:"registrarURL"`
+	LastWhoisUpdate      time.Time `json:"lastWhoisUpdate"`
+}
+
+// NewWhoisNameserverResponse creates a new instance of WhoisNameserverResponse for a host and its sponsoring registrar
+func NewWhoisNameserverResponse(h *Host, rar *Registrar) *WhoisNameserverResponse {
+	w := &WhoisNameserverResponse{
+		ServerName:           h.Name.String(),
+		Registrar:            rar.Name,
+		RegistrarWhoisServer: rar.WhoisInfo.Name.String(),
+		RegistrarURL:         rar.URL.String(),
+		LastWhoisUpdate:      time.Now().UTC(),
+	}
+	for _, a := range h.Addresses {
+		w.IPAddresses = append(w.IPAddresses, a.String())
+	}
+	return w
+}
+
+// String returns the string representation of the WhoisNameserverResponse
+func (w WhoisNameserverResponse) String() string {
+	var resp string
+	resp += "Server Name: " + w.ServerName + "\n"
+	for _, ip := range w.IPAddresses {
+		resp += "IP Address: " + ip + "\n"
+	}
+	resp += "Registrar: " + w.Registrar + "\n"
+	resp += "Registrar WHOIS Server: " + w.RegistrarWhoisServer + "\n"
+	resp += "Registrar URL: " + w.RegistrarURL + "\n"
+	resp += ">>> Last update of WHOIS database: " + w.LastWhoisUpdate.UTC().Format(time.RFC3339) + " <<<\n"
+	return resp
+}
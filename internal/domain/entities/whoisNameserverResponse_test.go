@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWhoisNameserverResponse(t *testing.T) {
+	h, err := NewHost("ns1.example.com", "1_HOST-APEX", "rar1")
+	require.NoError(t, err)
+	h.Addresses = []netip.Addr{netip.MustParseAddr("192.0.2.123"), netip.MustParseAddr("2001:db8::1")}
+	rar := &Registrar{Name: "Example Registrar, Inc.", WhoisInfo: WhoisInfo{Name: "whois.example-registrar.tld"}, URL: "http://www.example-registrar.tld"}
+
+	w := NewWhoisNameserverResponse(h, rar)
+	require.Equal(t, "ns1.example.com", w.ServerName)
+	require.Equal(t, []string{"192.0.2.123", "2001:db8::1"}, w.IPAddresses)
+	require.Equal(t, "Example Registrar, Inc.", w.Registrar)
+	require.Equal(t, "whois.example-registrar.tld", w.RegistrarWhoisServer)
+	require.Equal(t, "http://www.example-registrar.tld", w.RegistrarURL)
+}
+
+func TestWhoisNameserverResponse_String(t *testing.T) {
+	w := WhoisNameserverResponse{
+		ServerName:           "ns1.example.com",
+		IPAddresses:          []string{"192.0.2.123", "2001:db8::1"},
+		Registrar:            "Example Registrar, Inc.",
+		RegistrarWhoisServer: "whois.example-registrar.tld",
+		RegistrarURL:         "http://www.example-registrar.tld",
+		LastWhoisUpdate:      time.Date(2009, 5, 29, 20, 15, 0, 0, time.UTC),
+	}
+
+	expected := "Server Name: ns1.example.com\n" +
+		"IP Address: 192.0.2.123\n" +
+		"IP Address: 2001:db8::1\n" +
+		"Registrar: Example Registrar, Inc.\n" +
+		"Registrar WHOIS Server: whois.example-registrar.tld\n" +
+		"Registrar URL: http://www.example-registrar.tld\n" +
+		">>> Last update of WHOIS database: 2009-05-29T20:15:00Z <<<\n"
+	require.Equal(t, expected, w.String())
+}
@@ -0,0 +1,72 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+// WhoisRegistrarResponse represents the WHOIS response for a registrar as defined in the ICANN Registry Agreement Specification 4 section 1.7.
+// The registry does not keep the registrar's admin and technical contact persons, so they are not part of the response.
+type WhoisRegistrarResponse struct {
+	Registrar            string    `json:"registrar"`
+	Street               string    `json:"street"`
+	City                 string    `json:"city"`
+	StateProvince        string    `json:"stateProvince"`
+	PostalCode           string    `json:"postalCode"`
+	Country              string    `json:"country"`
+	PhoneNumber          string    `json:"phoneNumber"`
+	FaxNumber            string    `json:"faxNumber"`
+	Email                string    `json:"email"`
+	RegistrarWhoisServer string    `json:"registrarWhoisServer"`
+	RegistrarURL         string    `json:"registrarURL"`
+	LastWhoisUpdate      time.Time `json:"lastWhoisUpdate"`
+}
+
+// NewWhoisRegistrarResponse creates a new instance of WhoisRegistrarResponse
+func NewWhoisRegistrarResponse(rar *Registrar) *WhoisRegistrarResponse {
+	w := &WhoisRegistrarResponse{
+		Registrar:            rar.Name,
+		PhoneNumber:          rar.Voice.String(),
+		FaxNumber:            rar.Fax.String(),
+		Email:                rar.Email,
+		RegistrarWhoisServer: rar.WhoisInfo.Name.String(),
+		RegistrarURL:         rar.URL.String(),
+		LastWhoisUpdate:      time.Now().UTC(),
+	}
+	for _, pi := range rar.PostalInfo {
+		if pi == nil || pi.Address == nil {
+			continue
+		}
+		var street []string
+		for _, s := range []OptPostalLineType{pi.Address.Street1, pi.Address.Street2, pi.Address.Street3} {
+			if s != "" {
+				street = append(street, s.String())
+			}
+		}
+		w.Street = strings.Join(street, ", ")
+		w.City = pi.Address.City.String()
+		w.StateProvince = pi.Address.StateProvince.String()
+		w.PostalCode = pi.Address.PostalCode.String()
+		w.Country = pi.Address.CountryCode.String()
+		break
+	}
+	return w
+}
+
+// String returns the string representation of the WhoisRegistrarResponse
+func (w WhoisRegistrarResponse) String() string {
+	var resp string
+	resp += "Registrar: " + w.Registrar + "\n"
+	resp += "Street: " + w.Street + "\n"
+	resp += "City: " + w.City + "\n"
+	resp += "State/Province: " + w.StateProvince + "\n"
+	resp += "Postal Code: " + w.PostalCode + "\n"
+	resp += "Country: " + w.Country + "\n"
+	resp += "Phone Number: " + w.PhoneNumber + "\n"
+	resp += "Fax Number: " + w.FaxNumber + "\n"
+	resp += "Email: " + w.Email + "\n"
+	resp += "Registrar WHOIS Server: " + w.RegistrarWhoisServer + "\n"
+	resp += "Registrar URL: " + w.RegistrarURL + "\n"
+	resp += ">>> Last update of WHOIS database: " + w.LastWhoisUpdate.UTC().Format(time.RFC3339) + " <<<\n"
+	return resp
+}
@@ -0,0 +1,70 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWhoisRegistrarResponse(t *testing.T) {
+	a, err := NewAddress("Marina del Rey", "US")
+	require.NoError(t, err)
+	a.Street1 = "1234 Admiralty Way"
+	a.Street2 = "Suite 1"
+	a.StateProvince = "CA"
+	a.PostalCode = "90292"
+	pi, err := NewRegistrarPostalInfo("int", a)
+	require.NoError(t, err)
+	rar := &Registrar{
+		Name:       "Example Registrar, Inc.",
+		PostalInfo: [2]*RegistrarPostalInfo{nil, pi},
+		Voice:      "+1.3105551212",
+		Fax:        "+1.3105551213",
+		Email:      "registrar@example.tld",
+		WhoisInfo:  WhoisInfo{Name: "whois.example-registrar.tld"},
+		URL:        "http://www.example-registrar.tld",
+	}
+
+	w := NewWhoisRegistrarResponse(rar)
+	require.Equal(t, "Example Registrar, Inc.", w.Registrar)
+	require.Equal(t, "1234 Admiralty Way, Suite 1", w.Street)
+	require.Equal(t, "Marina del Rey", w.City)
+	require.Equal(t, "CA", w.StateProvince)
+	require.Equal(t, "90292", w.PostalCode)
+	require.Equal(t, "US", w.Country)
+	require.Equal(t, "+1.3105551212", w.PhoneNumber)
+	require.Equal(t, "+1.3105551213", w.FaxNumber)
+	require.Equal(t, "registrar@example.tld", w.Email)
+}
+
+func TestWhoisRegistrarResponse_String(t *testing.T) {
+	w := WhoisRegistrarResponse{
+		Registrar:            "Example Registrar, Inc.",
+		Street:               "1234 Admiralty Way",
+		City:                 "Marina del Rey",
+		StateProvince:        "CA",
+		PostalCode:           "90292",
+		Country:              "US",
+		PhoneNumber:          "+1.3105551212",
+		FaxNumber:            "+1.3105551213",
+		Email:                "registrar@example.tld",
+		RegistrarWhoisServer: "whois.example-registrar.tld",
+		RegistrarURL:         "http://www.example-registrar.tld",
+		LastWhoisUpdate:      time.Date(2009, 5, 29, 20, 15, 0, 0, time.UTC),
+	}
+
+	expected := "Registrar: Example Registrar, Inc.\n" +
+		"Street: 1234 Admiralty Way\n" +
+		"City: Marina del Rey\n" +
+		"State/Province: CA\n" +
+		"Postal Code: 90292\n" +
+		"Country: US\n" +
+		"Phone Number: +1.3105551212\n" +
+		"Fax Number: +1.3105551213\n" +
+		"Email: registrar@example.tld\n" +
+		"Registrar WHOIS Server: whois.example-registrar.tld\n" +
+		"Registrar URL: http://www.example-registrar.tld\n" +
+		">>> Last update of WHOIS database: 2009-05-29T20:15:00Z <<<\n"
+	require.Equal(t, expected, w.String())
+}
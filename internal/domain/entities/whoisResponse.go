@@ -1,58 +1,97 @@
 package entities
 
 import (
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 )
 
-// WhoisResponse represents the WHOIS response
+const (
+	// WHOIS_EPP_STATUS_URL is the base URL of the EPP status code descriptions that follows every domain status
+	WHOIS_EPP_STATUS_URL = "https://icann.org/epp#"
+	// WHOIS_INACCURACY_COMPLAINT_URL is the URL of the ICANN Whois Inaccuracy Complaint Form
+	WHOIS_INACCURACY_COMPLAINT_URL = "https://www.icann.org/wicf/"
+
+	// WhoisDNSSECSignedDelegation is shown when the delegation of the domain is signed
+	WhoisDNSSECSignedDelegation = "signedDelegation"
+	// WhoisDNSSECUnsigned is shown when the delegation of the domain is not signed
+	WhoisDNSSECUnsigned = "unsigned"
+)
+
+// WhoisResponse represents the WHOIS response for a domain as defined in the ICANN Registry Agreement Specification 4 section 1.4
+// and the Registration Data Policy
 type WhoisResponse struct {
-	DomainName                 string    `json:"domainName"`
-	RegistryDomainID           string    `json:"registryDomainID"`
-	RegistrarWhoisServer       string    `json:"registrarWhoisServer"`
-	RegistrarURL               string    `json:"registrarURL"`
-	UpdatedDate                time.Time `json:"updatedDate"`
-	CreationDate               time.Time `json:"creationDate"`
-	RegistryExpiryDate         time.Time `json:"registryExpiryDate"`
-	Registrar                  string    `json:"registrar"`
-	RegistrarIANAID            string    `json:"registrarIANAID"`
-	RegistrarAbuseContactEmail string    `json:"registrarAbuseContactEmail"`
-	RegistrarAbuseContactPhone string    `json:"registrarAbuseContactPhone"`
-	DomainStatus               []string  `json:"domainStatus"`
-	NameServers                []string  `json:"nameServers"`
-	DNSSEC                     string    `json:"dnssec"`
-	ICANNComplaintURL          string    `json:"icannComplaintURL"`
-	LastWhoisUpdate            time.Time `json:"lastWhoisUpdate"`
+	DomainName string `json:"domainName"`
+	// InternationalizedDomainName is the U-label of an IDN, it is empty for other domains
+	InternationalizedDomainName string    `json:"internationalizedDomainName,omitempty"`
+	RegistryDomainID            string    `json:"registryDomainID"`
+	RegistrarWhoisServer        string    `json:"registrarWhoisServer"`
+	RegistrarURL                string    `json:"registrarURL"`
+	UpdatedDate                 time.Time `json:"updatedDate"`
+	CreationDate                time.Time `json:"creationDate"`
+	RegistryExpiryDate          time.Time `json:"registryExpiryDate"`
+	// RegistrarRegistrationExpirationDate is the expiration date in the agreement between the registrar and the registrant.
+	// The registry does not know it, so it is left blank unless it is set.
+	RegistrarRegistrationExpirationDate time.Time `json:"registrarRegistrationExpirationDate"`
+	Registrar                           string    `json:"registrar"`
+	RegistrarIANAID                     string    `json:"registrarIANAID"`
+	RegistrarAbuseContactEmail          string    `json:"registrarAbuseContactEmail"`
+	RegistrarAbuseContactPhone          string    `json:"registrarAbuseContactPhone"`
+	// DomainStatus holds the EPP and RGP status codes of the domain, the URL of their description is added in the WHOIS output
+	DomainStatus      []string       `json:"domainStatus"`
+	Contacts          []WhoisContact `json:"contacts,omitempty"`
+	NameServers       []string       `json:"nameServers"`
+	DNSSEC            string         `json:"dnssec"`
+	ICANNComplaintURL string         `json:"icannComplaintURL"`
+	LastWhoisUpdate   time.Time      `json:"lastWhoisUpdate"`
 }
 
 // String returns the string representation of the WhoisResponse
 func (w WhoisResponse) String() string {
 	var resp string
 	resp += "Domain Name: " + w.DomainName + "\n"
+	if w.InternationalizedDomainName != "" {
+		resp += "Internationalized Domain Name: " + w.InternationalizedDomainName + "\n"
+	}
 	resp += "Registry Domain ID: " + w.RegistryDomainID + "\n"
 	resp += "Registrar WHOIS Server: " + w.RegistrarWhoisServer + "\n"
 	resp += "Registrar URL: " + w.RegistrarURL + "\n"
-	resp += "Updated Date: " + w.UpdatedDate.Format(time.RFC3339) + "\n"
-	resp += "Creation Date: " + w.CreationDate.Format(time.RFC3339) + "\n"
-	resp += "Registry Expiry Date: " + w.RegistryExpiryDate.Format(time.RFC3339) + "\n"
+	resp += "Updated Date: " + whoisDate(w.UpdatedDate) + "\n"
+	resp += "Creation Date: " + whoisDate(w.CreationDate) + "\n"
+	resp += "Registry Expiry Date: " + whoisDate(w.RegistryExpiryDate) + "\n"
+	resp += "Registrar Registration Expiration Date: " + whoisDate(w.RegistrarRegistrationExpirationDate) + "\n"
 	resp += "Registrar: " + w.Registrar + "\n"
 	resp += "Registrar IANA ID: " + w.RegistrarIANAID + "\n"
 	resp += "Registrar Abuse Contact Email: " + w.RegistrarAbuseContactEmail + "\n"
 	resp += "Registrar Abuse Contact Phone: " + w.RegistrarAbuseContactPhone + "\n"
 	for _, d := range w.DomainStatus {
-		resp += "Domain Status: " + d + "\n"
+		resp += "Domain Status: " + d + " " + WHOIS_EPP_STATUS_URL + d + "\n"
+	}
+	for _, c := range w.Contacts {
+		resp += c.String()
 	}
 	for _, d := range w.NameServers {
 		resp += "Name Server: " + d + "\n"
 	}
 	resp += "DNSSEC: " + w.DNSSEC + "\n"
-	resp += "ICANN Complaint URL: " + w.ICANNComplaintURL + "\n"
-	resp += ">>> Last update of whois database:" + w.LastWhoisUpdate.Format(time.RFC3339) + " <<<\n"
+	resp += "URL of the ICANN Whois Inaccuracy Complaint Form: " + w.ICANNComplaintURL + "\n"
+	resp += ">>> Last update of WHOIS database: " + whoisDate(w.LastWhoisUpdate) + " <<<\n"
 	return resp
 }
 
-// NewWhoisResponse creates a new instance of WhoisResponse
-func NewWhoisResponse(dom *Domain, rar *Registrar) (*WhoisResponse, error) {
+// WhoisDomainContacts holds the preloaded contacts of a domain that are published in the WHOIS response.
+// A nil contact is left out, e.g. when the contact data policy of the TLD prohibits publishing it.
+type WhoisDomainContacts struct {
+	Registrant *Contact
+	Admin      *Contact
+	Tech       *Contact
+}
+
+// NewWhoisResponse creates a new instance of WhoisResponse. WHOIS is unauthenticated, so the contacts are always redacted according to their disclose flags.
+// The RGP statuses are calculated at the current time.
+func NewWhoisResponse(dom *Domain, rar *Registrar, contacts WhoisDomainContacts) (*WhoisResponse, error) {
+	now := time.Now().UTC()
 	w := &WhoisResponse{
 		DomainName:                 dom.Name.String(),
 		RegistryDomainID:           dom.RoID.String(),
@@ -67,9 +106,125 @@ func NewWhoisResponse(dom *Domain, rar *Registrar) (*WhoisResponse, error) {
 		RegistrarAbuseContactPhone: rar.Voice.String(),
 		DomainStatus:               dom.Status.StringSlice(),
 		NameServers:                dom.GetHostsAsStringSlice(),
-		DNSSEC:                     "unsigned",
-		ICANNComplaintURL:          "URL of the ICANN Whois Inaccuracy Complaint Form: https://www.icann.org/wicf/",
-		LastWhoisUpdate:            time.Now(),
+		DNSSEC:                     WhoisDNSSECUnsigned,
+		ICANNComplaintURL:          WHOIS_INACCURACY_COMPLAINT_URL,
+		LastWhoisUpdate:            now,
+	}
+	if dom.IsSigned() {
+		w.DNSSEC = WhoisDNSSECSignedDelegation
+	}
+	if dom.UName != "" && dom.UName != dom.Name {
+		w.InternationalizedDomainName = dom.UName.String()
+	}
+	for _, s := range dom.RGPStatuses(now) {
+		if !slices.Contains(w.DomainStatus, s) {
+			w.DomainStatus = append(w.DomainStatus, s)
+		}
+	}
+	for _, c := range []struct {
+		label   string
+		contact *Contact
+	}{
+		{"Registrant", contacts.Registrant},
+		{"Admin", contacts.Admin},
+		{"Tech", contacts.Tech},
+	} {
+		if c.contact != nil {
+			w.Contacts = append(w.Contacts, NewWhoisContact(c.label, c.contact, NewContactRedaction(c.contact)))
+		}
 	}
 	return w, nil
 }
+
+// whoisDate formats a date in UTC as required by the ICANN WHOIS output, a zero date is left blank
+func whoisDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// WhoisContact holds the contact fields of a WHOIS response. Redacted fields hold the redaction strings required by the Registration Data Policy.
+type WhoisContact struct {
+	// Label is the prefix of the contact fields: Registrant, Admin or Tech
+	Label         string `json:"label"`
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Organization  string `json:"organization"`
+	Street        string `json:"street"`
+	City          string `json:"city"`
+	StateProvince string `json:"stateProvince"`
+	PostalCode    string `json:"postalCode"`
+	Country       string `json:"country"`
+	Phone         string `json:"phone"`
+	Fax           string `json:"fax"`
+	Email         string `json:"email"`
+}
+
+// NewWhoisContact creates a new instance of WhoisContact and applies the redaction
+func NewWhoisContact(label string, c *Contact, r ContactRedaction) WhoisContact {
+	r = r.applyTo(c)
+	wc := WhoisContact{
+		Label: label,
+		ID:    c.ID.String(),
+		Phone: c.Voice.String(),
+		Fax:   c.Fax.String(),
+		Email: c.Email,
+	}
+	if pi := c.RDDSPostalInfo(); pi != nil {
+		wc.Name = pi.Name.String()
+		wc.Organization = pi.Org.String()
+		if a := pi.Address; a != nil {
+			var street []string
+			for _, s := range []OptPostalLineType{a.Street1, a.Street2, a.Street3} {
+				if s != "" {
+					street = append(street, s.String())
+				}
+			}
+			wc.Street = strings.Join(street, ", ")
+			wc.City = a.City.String()
+			wc.StateProvince = a.StateProvince.String()
+			wc.PostalCode = a.PostalCode.String()
+			wc.Country = a.CountryCode.String()
+		}
+	}
+
+	for _, f := range []struct {
+		redacted bool
+		value    *string
+	}{
+		{r.ID, &wc.ID},
+		{r.Name, &wc.Name},
+		{r.Org, &wc.Organization},
+		{r.Street, &wc.Street},
+		{r.City, &wc.City},
+		{r.PostalCode, &wc.PostalCode},
+		{r.Voice, &wc.Phone},
+		{r.Fax, &wc.Fax},
+	} {
+		if f.redacted {
+			*f.value = RDDS_REDACTED_FOR_PRIVACY
+		}
+	}
+	if r.Email {
+		wc.Email = RDDS_REDACTED_EMAIL
+	}
+	return wc
+}
+
+// String returns the string representation of the WhoisContact
+func (c WhoisContact) String() string {
+	var resp string
+	resp += "Registry " + c.Label + " ID: " + c.ID + "\n"
+	resp += c.Label + " Name: " + c.Name + "\n"
+	resp += c.Label + " Organization: " + c.Organization + "\n"
+	resp += c.Label + " Street: " + c.Street + "\n"
+	resp += c.Label + " City: " + c.City + "\n"
+	resp += c.Label + " State/Province: " + c.StateProvince + "\n"
+	resp += c.Label + " Postal Code: " + c.PostalCode + "\n"
+	resp += c.Label + " Country: " + c.Country + "\n"
+	resp += c.Label + " Phone: " + c.Phone + "\n"
+	resp += c.Label + " Fax: " + c.Fax + "\n"
+	resp += c.Label + " Email: " + c.Email + "\n"
+	return resp
+}
@@ -2,6 +2,7 @@ package entities
 
 import (
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -21,8 +22,8 @@ func TestWhoisResponse_String(t *testing.T) {
 		RegistrarAbuseContactPhone: "+1.1234567890",
 		DomainStatus:               []string{"active", "ok"},
 		NameServers:                []string{"ns1.example.com", "ns2.example.com"},
-		DNSSEC:                     "unsigned",
-		ICANNComplaintURL:          "http://example.com/complaint",
+		DNSSEC:                     WhoisDNSSECUnsigned,
+		ICANNComplaintURL:          WHOIS_INACCURACY_COMPLAINT_URL,
 		LastWhoisUpdate:            time.Now().UTC(),
 	}
 
@@ -33,17 +34,18 @@ func TestWhoisResponse_String(t *testing.T) {
 		"Updated Date: " + w.UpdatedDate.Format(time.RFC3339) + "\n" +
 		"Creation Date: " + w.CreationDate.Format(time.RFC3339) + "\n" +
 		"Registry Expiry Date: " + w.RegistryExpiryDate.Format(time.RFC3339) + "\n" +
+		"Registrar Registration Expiration Date: \n" +
 		"Registrar: Example Registrar\n" +
 		"Registrar IANA ID: 1234\n" +
 		"Registrar Abuse Contact Email: abuse@example.com\n" +
 		"Registrar Abuse Contact Phone: +1.1234567890\n" +
-		"Domain Status: active\n" +
-		"Domain Status: ok\n" +
+		"Domain Status: active https://icann.org/epp#active\n" +
+		"Domain Status: ok https://icann.org/epp#ok\n" +
 		"Name Server: ns1.example.com\n" +
 		"Name Server: ns2.example.com\n" +
 		"DNSSEC: unsigned\n" +
-		"ICANN Complaint URL: http://example.com/complaint\n" +
-		">>> Last update of whois database:" + w.LastWhoisUpdate.Format(time.RFC3339) + " <<<\n"
+		"URL of the ICANN Whois Inaccuracy Complaint Form: https://www.icann.org/wicf/\n" +
+		">>> Last update of WHOIS database: " + w.LastWhoisUpdate.Format(time.RFC3339) + " <<<\n"
 
 	if w.String() != expected {
 		t.Errorf("Expected:\n%s\nGot:\n%s", expected, w.String())
@@ -78,7 +80,7 @@ func TestNewWhoisResponse(t *testing.T) {
 		RdapBaseURL: "http://example.com/complaint",
 	}
 
-	w, err := NewWhoisResponse(dom, rar)
+	w, err := NewWhoisResponse(dom, rar, WhoisDomainContacts{})
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -134,14 +136,114 @@ func TestNewWhoisResponse(t *testing.T) {
 	if w.NameServers[2] != "ns2.example.com" {
 		t.Errorf("Expected NameServer ns2.example.com, got %s", w.NameServers[2])
 	}
-	if w.DNSSEC != "unsigned" {
+	if w.DNSSEC != WhoisDNSSECUnsigned {
 		t.Errorf("Expected DNSSEC unsigned, got %s", w.DNSSEC)
 	}
-	if w.ICANNComplaintURL != "URL of the ICANN Whois Inaccuracy Complaint Form: https://www.icann.org/wicf/" {
-		t.Errorf("Expected ICANNComplaintURL %s, got %s", "URL of the ICANN Whois Inaccuracy Complaint Form: https://www.icann.org/wicf/", w.ICANNComplaintURL)
+	if w.ICANNComplaintURL != WHOIS_INACCURACY_COMPLAINT_URL {
+		t.Errorf("Expected ICANNComplaintURL %s, got %s", WHOIS_INACCURACY_COMPLAINT_URL, w.ICANNComplaintURL)
+	}
+	if w.InternationalizedDomainName != "" {
+		t.Errorf("Expected no InternationalizedDomainName, got %s", w.InternationalizedDomainName)
+	}
+	if len(w.Contacts) != 0 {
+		t.Errorf("Expected no Contacts, got %d", len(w.Contacts))
 	}
 	if w.LastWhoisUpdate.IsZero() {
 		t.Errorf("Expected LastWhoisUpdate to be set, got zero value")
 	}
 
 }
+
+func TestNewWhoisResponse_IDNAndContacts(t *testing.T) {
+	dom, err := NewDomain("1234_DOM-APEX", "xn--caf-dma.example", "rar1", "str0NGP@ZZw0rd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dom.Status.PendingDelete = true
+	rar := &Registrar{Name: "Example Registrar", GurID: 1234}
+	c, err := NewContact("myid", "1234_CONT-APEX", "me@my.com", "str0NGP@ZZw0rd", "rar1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := NewWhoisResponse(dom, rar, WhoisDomainContacts{Registrant: c, Tech: c})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if w.InternationalizedDomainName != "café.example" {
+		t.Errorf("Expected InternationalizedDomainName café.example, got %s", w.InternationalizedDomainName)
+	}
+	// The RGP pendingDelete status is the same as the EPP status and is only listed once
+	if n := strings.Count(w.String(), "Domain Status: pendingDelete https://icann.org/epp#pendingDelete\n"); n != 1 {
+		t.Errorf("Expected pendingDelete to be listed once, got %d\n%s", n, w.String())
+	}
+	if len(w.Contacts) != 2 || w.Contacts[0].Label != "Registrant" || w.Contacts[1].Label != "Tech" {
+		t.Fatalf("Expected the Registrant and Tech contacts, got %+v", w.Contacts)
+	}
+	if w.Contacts[0].Email != RDDS_REDACTED_EMAIL {
+		t.Errorf("Expected the contact to be redacted, got %s", w.Contacts[0].Email)
+	}
+	if !strings.Contains(w.String(), "Internationalized Domain Name: café.example\n") {
+		t.Errorf("Expected the U-label in the output, got\n%s", w.String())
+	}
+}
+
+func TestNewWhoisResponse_SignedDelegation(t *testing.T) {
+	dom, err := NewDomain("1234_DOM-APEX", "example.com", "rar1", "str0NGP@ZZw0rd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dom.AddDSData(DomainDSData{KeyTag: 12345, Alg: 13, DigestType: 2, Digest: testDSDigestSHA256}); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := NewWhoisResponse(dom, &Registrar{Name: "Example Registrar", GurID: 1234}, WhoisDomainContacts{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(w.String(), "DNSSEC: signedDelegation\n") {
+		t.Errorf("Expected a signed delegation, got\n%s", w.String())
+	}
+}
+
+func TestNewWhoisContact(t *testing.T) {
+	c, err := NewContact("myid", "1234_CONT-APEX", "me@my.com", "str0NGP@ZZw0rd", "rar1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := NewAddress("Brussels", "BE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.Street1 = "Rue de la Loi 16"
+	a.StateProvince = "Brussels-Capital"
+	pi, err := NewContactPostalInfo("int", "John Doe", a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.AddPostalInfo(pi); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "Registry Registrant ID: REDACTED FOR PRIVACY\n" +
+		"Registrant Name: REDACTED FOR PRIVACY\n" +
+		"Registrant Organization: \n" +
+		"Registrant Street: REDACTED FOR PRIVACY\n" +
+		"Registrant City: REDACTED FOR PRIVACY\n" +
+		"Registrant State/Province: Brussels-Capital\n" +
+		"Registrant Postal Code: \n" +
+		"Registrant Country: BE\n" +
+		"Registrant Phone: \n" +
+		"Registrant Fax: \n" +
+		"Registrant Email: " + RDDS_REDACTED_EMAIL + "\n"
+	wc := NewWhoisContact("Registrant", c, NewContactRedaction(c))
+	if wc.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, wc.String())
+	}
+
+	// The full view does not redact anything
+	wc = NewWhoisContact("Tech", c, ContactRedaction{})
+	if wc.ID != "myid" || wc.Name != "John Doe" || wc.Street != "Rue de la Loi 16" || wc.Email != "me@my.com" {
+		t.Errorf("Expected unredacted contact, got %+v", wc)
+	}
+}
@@ -0,0 +1,271 @@
+package entities
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/miekg/dns"
+)
+
+var (
+	ErrInvalidZone           = errors.New("invalid zone")
+	ErrZoneMissingSOA        = errors.New("zone must start with a SOA record at the apex")
+	ErrZoneMultipleSOA       = errors.New("zone must contain exactly one SOA record")
+	ErrZoneMissingApexNS     = errors.New("zone must contain at least one NS record at the apex")
+	ErrZoneOutOfZoneData     = errors.New("record is outside of the zone")
+	ErrZoneOccludedData      = errors.New("only glue records are allowed below a delegation point")
+	ErrZoneDSNotAtDelegation = errors.New("DS records are only allowed at a delegation point")
+	ErrZoneCNAMEAndOtherData = errors.New("CNAME records cannot coexist with other data")
+	ErrZoneNSMissingAddress  = errors.New("in-zone NS target has no address records (A or AAAA)")
+)
+
+// Zone represents a DNS zone as it is published in an RFC 1035 master file. Use NewZone to create one and AddRecords to populate it.
+// Ref: https://datatracker.ietf.org/doc/html/rfc1035#section-5
+type Zone struct {
+	Origin  string
+	SOA     *dns.SOA
+	records []dns.RR
+	index   map[string]dns.RR // used to dedup identical records
+}
+
+// NewZone creates a new Zone for the origin with the provided SOA record. The SOA owner must be the origin.
+func NewZone(origin string, soa *dns.SOA) (*Zone, error) {
+	origin = dns.CanonicalName(origin)
+	if soa == nil || dns.CanonicalName(soa.Hdr.Name) != origin {
+		return nil, errors.Join(ErrInvalidZone, ErrZoneMissingSOA)
+	}
+	return &Zone{
+		Origin: origin,
+		SOA:    soa,
+		index:  map[string]dns.RR{},
+	}, nil
+}
+
+// AddRecords adds records to the zone. Identical records are only added once. SOA records and records outside of the zone are rejected.
+func (z *Zone) AddRecords(rrs ...dns.RR) error {
+	for _, rr := range rrs {
+		if rr == nil {
+			continue
+		}
+		if rr.Header().Rrtype == dns.TypeSOA {
+			return errors.Join(ErrInvalidZone, ErrZoneMultipleSOA)
+		}
+		if !dns.IsSubDomain(z.Origin, rr.Header().Name) {
+			return errors.Join(ErrInvalidZone, fmt.Errorf("%w: %s", ErrZoneOutOfZoneData, rr.Header().Name))
+		}
+		// Dedup based on the presentation format, ignoring the TTL
+		key := zoneRecordKey(rr)
+		if _, exists := z.index[key]; exists {
+			continue
+		}
+		z.index[key] = rr
+		z.records = append(z.records, rr)
+	}
+	return nil
+}
+
+// IsInZone checks if the name is at or below the zone origin
+func (z *Zone) IsInZone(name string) bool {
+	return dns.IsSubDomain(z.Origin, name)
+}
+
+// IncrementSerial increments the SOA serial using serial number arithmetic (wraps at 2^32)
+// Ref: https://datatracker.ietf.org/doc/html/rfc1982#section-3.1
+func (z *Zone) IncrementSerial() uint32 {
+	z.SOA.Serial++
+	return z.SOA.Serial
+}
+
+// Records returns all records of the zone in the order they are written to the master file: the SOA first, followed by the rest of the apex and then the other names in canonical order
+func (z *Zone) Records() []dns.RR {
+	rrs := make([]dns.RR, len(z.records))
+	copy(rrs, z.records)
+	sort.SliceStable(rrs, func(i, j int) bool {
+		ni, nj := dns.CanonicalName(rrs[i].Header().Name), dns.CanonicalName(rrs[j].Header().Name)
+		if ni != nj {
+			return canonicalNameLess(ni, nj)
+		}
+		ri, rj := zoneTypeRank(rrs[i].Header().Rrtype), zoneTypeRank(rrs[j].Header().Rrtype)
+		if ri != rj {
+			return ri < rj
+		}
+		return rrs[i].String() < rrs[j].String()
+	})
+	return append([]dns.RR{z.SOA}, rrs...)
+}
+
+// WriteTo streams the zone as an RFC 1035 master file to the writer. It implements the io.WriterTo interface.
+func (z *Zone) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+	n, err := fmt.Fprintf(w, "$ORIGIN %s\n", z.Origin)
+	total += int64(n)
+	if err != nil {
+		return total, err
+	}
+	for _, rr := range z.Records() {
+		n, err := io.WriteString(w, rr.String()+"\n")
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// String returns the zone as an RFC 1035 master file
+func (z *Zone) String() string {
+	var buf bytes.Buffer
+	_, _ = z.WriteTo(&buf)
+	return buf.String()
+}
+
+// Validate renders the zone as a master file and checks it using CheckZone
+func (z *Zone) Validate() error {
+	var buf bytes.Buffer
+	if _, err := z.WriteTo(&buf); err != nil {
+		return err
+	}
+	return CheckZone(z.Origin, &buf)
+}
+
+// CheckZone parses an RFC 1035 master file for the origin and checks its integrity similar to named-checkzone:
+//   - the file can be parsed and starts with the only SOA record, which is at the apex
+//   - the apex has NS records
+//   - all records are in the zone
+//   - only glue (A/AAAA) is present below delegation points, DS records are only present at delegation points
+//   - CNAME records do not coexist with other data
+//   - NS targets in the zone have address records
+//
+// All problems found are returned joined with ErrInvalidZone.
+func CheckZone(origin string, r io.Reader) error {
+	origin = dns.CanonicalName(origin)
+
+	zp := dns.NewZoneParser(r, origin, "")
+	var rrs []dns.RR
+	for rr, ok := zp.Next(); ok; rr, ok = zp.Next() {
+		rrs = append(rrs, rr)
+	}
+	if err := zp.Err(); err != nil {
+		return errors.Join(ErrInvalidZone, err)
+	}
+
+	var errs []error
+	if len(rrs) == 0 || rrs[0].Header().Rrtype != dns.TypeSOA || dns.CanonicalName(rrs[0].Header().Name) != origin {
+		errs = append(errs, ErrZoneMissingSOA)
+	}
+
+	// Index the types present at each name and collect the delegation points
+	types := map[string]map[uint16]bool{}
+	delegations := map[string]bool{}
+	soaCount := 0
+	for _, rr := range rrs {
+		name := dns.CanonicalName(rr.Header().Name)
+		if !dns.IsSubDomain(origin, name) {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrZoneOutOfZoneData, name))
+			continue
+		}
+		if types[name] == nil {
+			types[name] = map[uint16]bool{}
+		}
+		types[name][rr.Header().Rrtype] = true
+		switch rr.Header().Rrtype {
+		case dns.TypeSOA:
+			soaCount++
+		case dns.TypeNS:
+			if name != origin {
+				delegations[name] = true
+			}
+		}
+	}
+	if soaCount > 1 {
+		errs = append(errs, ErrZoneMultipleSOA)
+	}
+	if !types[origin][dns.TypeNS] {
+		errs = append(errs, ErrZoneMissingApexNS)
+	}
+
+	for name, present := range types {
+		if present[dns.TypeCNAME] && len(present) > 1 {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrZoneCNAMEAndOtherData, name))
+		}
+		if present[dns.TypeDS] && !delegations[name] {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrZoneDSNotAtDelegation, name))
+		}
+		// Anything below a delegation point must be glue
+		if cut := closestDelegation(origin, name, delegations); cut != "" && cut != name {
+			for t := range present {
+				if t != dns.TypeA && t != dns.TypeAAAA {
+					errs = append(errs, fmt.Errorf("%w: %s %s below %s", ErrZoneOccludedData, name, dns.TypeToString[t], cut))
+				}
+			}
+		}
+	}
+
+	for _, rr := range rrs {
+		ns, ok := rr.(*dns.NS)
+		if !ok {
+			continue
+		}
+		target := dns.CanonicalName(ns.Ns)
+		if dns.IsSubDomain(origin, target) && !types[target][dns.TypeA] && !types[target][dns.TypeAAAA] {
+			errs = append(errs, fmt.Errorf("%w: %s NS %s", ErrZoneNSMissingAddress, ns.Hdr.Name, ns.Ns))
+		}
+	}
+
+	if len(errs) > 0 {
+		// Sort for a stable error message as we range over maps
+		sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+		return errors.Join(append([]error{ErrInvalidZone}, errs...)...)
+	}
+	return nil
+}
+
+// closestDelegation returns the delegation point at or above the name, or an empty string if the name is not delegated
+func closestDelegation(origin, name string, delegations map[string]bool) string {
+	for n := name; n != origin && dns.IsSubDomain(origin, n); {
+		if delegations[n] {
+			return n
+		}
+		off, end := dns.NextLabel(n, 0)
+		if end {
+			break
+		}
+		n = n[off:]
+	}
+	return ""
+}
+
+// canonicalNameLess orders names by comparing their labels from right to left, so that the apex comes first and names are grouped per delegation
+// Ref: https://datatracker.ietf.org/doc/html/rfc4034#section-6.1
+func canonicalNameLess(a, b string) bool {
+	la, lb := dns.SplitDomainName(a), dns.SplitDomainName(b)
+	for i, j := len(la)-1, len(lb)-1; i >= 0 && j >= 0; i, j = i-1, j-1 {
+		if la[i] != lb[j] {
+			return la[i] < lb[j]
+		}
+	}
+	return len(la) < len(lb)
+}
+
+// zoneTypeRank orders the records at the same name: NS first, followed by DS and the other types in numerical order
+func zoneTypeRank(t uint16) int {
+	switch t {
+	case dns.TypeNS:
+		return 0
+	case dns.TypeDS:
+		return 1
+	default:
+		return int(t) + 2
+	}
+}
+
+// zoneRecordKey returns the presentation format of the record without TTL, identical records share the same key
+func zoneRecordKey(rr dns.RR) string {
+	cp := dns.Copy(rr)
+	cp.Header().Ttl = 0
+	cp.Header().Name = dns.CanonicalName(cp.Header().Name)
+	return cp.String()
+}
@@ -0,0 +1,119 @@
+package entities
+
+import (
+	"errors"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	// DefaultMaxZoneJournalEntries is the default number of zone changes kept to serve incremental zone transfers
+	DefaultMaxZoneJournalEntries = 100
+)
+
+var (
+	ErrZoneDiffOriginMismatch = errors.New("cannot compare zones with a different origin")
+	ErrZoneJournalNotFound    = errors.New("zone journal not found")
+)
+
+// ZoneDiff holds the changes between two versions of a zone, as they are sent in an incremental zone transfer (IXFR)
+// Ref: https://datatracker.ietf.org/doc/html/rfc1995#section-4
+type ZoneDiff struct {
+	FromSOA *dns.SOA
+	ToSOA   *dns.SOA
+	Deleted []dns.RR
+	Added   []dns.RR
+}
+
+// NewZoneDiff compares two versions of a zone and returns the records that were deleted and added, the SOA records are copied.
+// Records are compared including their TTL, so a TTL change results in the record being deleted and added.
+func NewZoneDiff(from, to *Zone) (*ZoneDiff, error) {
+	if from.Origin != to.Origin {
+		return nil, ErrZoneDiffOriginMismatch
+	}
+	diff := &ZoneDiff{
+		FromSOA: dns.Copy(from.SOA).(*dns.SOA),
+		ToSOA:   dns.Copy(to.SOA).(*dns.SOA),
+	}
+
+	// Skip the SOA records
+	fromRecords, toRecords := from.Records()[1:], to.Records()[1:]
+	fromIndex, toIndex := zoneDiffIndex(fromRecords), zoneDiffIndex(toRecords)
+	for _, rr := range fromRecords {
+		if _, ok := toIndex[zoneDiffKey(rr)]; !ok {
+			diff.Deleted = append(diff.Deleted, rr)
+		}
+	}
+	for _, rr := range toRecords {
+		if _, ok := fromIndex[zoneDiffKey(rr)]; !ok {
+			diff.Added = append(diff.Added, rr)
+		}
+	}
+
+	return diff, nil
+}
+
+// IsEmpty checks if the zone content is unchanged, a change in the SOA serial alone is not considered a change
+func (d *ZoneDiff) IsEmpty() bool {
+	if len(d.Deleted) > 0 || len(d.Added) > 0 {
+		return false
+	}
+	from, to := *d.FromSOA, *d.ToSOA
+	from.Serial, to.Serial = 0, 0
+	return dns.IsDuplicate(&from, &to) && from.Hdr.Ttl == to.Hdr.Ttl
+}
+
+// ZoneJournal keeps the most recent changes of a zone to serve incremental zone transfers
+type ZoneJournal struct {
+	MaxEntries int
+	Diffs      []*ZoneDiff
+}
+
+// NewZoneJournal creates a new ZoneJournal keeping at most maxEntries changes. DefaultMaxZoneJournalEntries is used if maxEntries is not positive.
+func NewZoneJournal(maxEntries int) *ZoneJournal {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxZoneJournalEntries
+	}
+	return &ZoneJournal{MaxEntries: maxEntries}
+}
+
+// Add adds a change to the journal, the oldest change is dropped when the journal is full
+func (j *ZoneJournal) Add(diff *ZoneDiff) {
+	j.Diffs = append(j.Diffs, diff)
+	if len(j.Diffs) > j.MaxEntries {
+		j.Diffs = j.Diffs[len(j.Diffs)-j.MaxEntries:]
+	}
+}
+
+// DiffsSince returns the consecutive changes from the serial up to the most recent version of the zone.
+// It returns false if the journal does not go back as far as the serial, in which case a full zone transfer is needed.
+func (j *ZoneJournal) DiffsSince(serial uint32) ([]*ZoneDiff, bool) {
+	for i, diff := range j.Diffs {
+		if diff.FromSOA.Serial != serial {
+			continue
+		}
+		for k := i + 1; k < len(j.Diffs); k++ {
+			if j.Diffs[k].FromSOA.Serial != j.Diffs[k-1].ToSOA.Serial {
+				return nil, false
+			}
+		}
+		return j.Diffs[i:], true
+	}
+	return nil, false
+}
+
+// zoneDiffIndex indexes the records by their zoneDiffKey
+func zoneDiffIndex(rrs []dns.RR) map[string]struct{} {
+	index := make(map[string]struct{}, len(rrs))
+	for _, rr := range rrs {
+		index[zoneDiffKey(rr)] = struct{}{}
+	}
+	return index
+}
+
+// zoneDiffKey returns the presentation format of the record with a canonical owner name
+func zoneDiffKey(rr dns.RR) string {
+	cp := dns.Copy(rr)
+	cp.Header().Name = dns.CanonicalName(cp.Header().Name)
+	return cp.String()
+}
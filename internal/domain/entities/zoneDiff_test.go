@@ -0,0 +1,93 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewZoneDiff(t *testing.T) {
+	from := newTestZone(t)
+	to := newTestZone(t)
+
+	diff, err := NewZoneDiff(from, to)
+	require.NoError(t, err)
+	require.True(t, diff.IsEmpty())
+
+	// A serial change alone is not a change
+	to.IncrementSerial()
+	diff, err = NewZoneDiff(from, to)
+	require.NoError(t, err)
+	require.True(t, diff.IsEmpty())
+	require.Equal(t, uint32(41), diff.FromSOA.Serial)
+	require.Equal(t, uint32(42), diff.ToSOA.Serial)
+
+	// The SOA records are copied
+	to.IncrementSerial()
+	require.Equal(t, uint32(42), diff.ToSOA.Serial)
+
+	// Other SOA changes are
+	to.SOA.Refresh = 3600
+	diff, err = NewZoneDiff(from, to)
+	require.NoError(t, err)
+	require.False(t, diff.IsEmpty())
+
+	added, err := dns.NewRR("c.example. 3600 IN NS ns.other.net.")
+	require.NoError(t, err)
+	to = newTestZone(t)
+	require.NoError(t, to.AddRecords(added))
+	to.records = to.records[1:] // drop the first apex NS record
+	diff, err = NewZoneDiff(from, to)
+	require.NoError(t, err)
+	require.False(t, diff.IsEmpty())
+	require.Len(t, diff.Added, 1)
+	require.Equal(t, added.String(), diff.Added[0].String())
+	require.Len(t, diff.Deleted, 1)
+	require.Equal(t, "example.\t3600\tIN\tNS\tns1.nic.example.", diff.Deleted[0].String())
+
+	soa, err := dns.NewRR("other. 3600 IN SOA ns1.nic.example. hostmaster.nic.example. 1 1800 900 604800 86400")
+	require.NoError(t, err)
+	other, err := NewZone("other.", soa.(*dns.SOA))
+	require.NoError(t, err)
+	_, err = NewZoneDiff(from, other)
+	require.ErrorIs(t, err, ErrZoneDiffOriginMismatch)
+}
+
+func TestZoneJournal(t *testing.T) {
+	newDiff := func(from, to uint32) *ZoneDiff {
+		return &ZoneDiff{FromSOA: &dns.SOA{Serial: from}, ToSOA: &dns.SOA{Serial: to}}
+	}
+
+	require.Equal(t, DefaultMaxZoneJournalEntries, NewZoneJournal(0).MaxEntries)
+
+	j := NewZoneJournal(3)
+	_, ok := j.DiffsSince(1)
+	require.False(t, ok)
+
+	for i := uint32(1); i <= 4; i++ {
+		j.Add(newDiff(i, i+1))
+	}
+	require.Len(t, j.Diffs, 3)
+
+	// The oldest change was dropped
+	_, ok = j.DiffsSince(1)
+	require.False(t, ok)
+
+	diffs, ok := j.DiffsSince(2)
+	require.True(t, ok)
+	require.Len(t, diffs, 3)
+
+	diffs, ok = j.DiffsSince(4)
+	require.True(t, ok)
+	require.Len(t, diffs, 1)
+	require.Equal(t, uint32(5), diffs[0].ToSOA.Serial)
+
+	// A gap in the history cannot be served incrementally
+	j.Add(newDiff(7, 8))
+	_, ok = j.DiffsSince(3)
+	require.False(t, ok)
+	diffs, ok = j.DiffsSince(7)
+	require.True(t, ok)
+	require.Len(t, diffs, 1)
+}
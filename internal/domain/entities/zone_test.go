@@ -0,0 +1,171 @@
+package entities
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestZone(t *testing.T) *Zone {
+	t.Helper()
+	soa, err := dns.NewRR("example. 3600 IN SOA ns1.nic.example. hostmaster.nic.example. 41 1800 900 604800 86400")
+	require.NoError(t, err)
+	z, err := NewZone("Example", soa.(*dns.SOA))
+	require.NoError(t, err)
+	for _, s := range []string{
+		"example. 3600 IN NS ns1.nic.example.",
+		"example. 3600 IN NS ns.other.net.",
+		"ns1.nic.example. 3600 IN A 192.0.2.1",
+		"b.example. 3600 IN NS ns.other.net.",
+		"a.example. 3600 IN NS ns1.a.example.",
+		"a.example. 3600 IN DS 12345 13 2 E2D3C916F6DEEAC73294E8268FB5885044A833FC5459588F4A9184CFC41A5766",
+		"ns1.a.example. 3600 IN AAAA 2001:db8::1",
+	} {
+		rr, err := dns.NewRR(s)
+		require.NoError(t, err)
+		require.NoError(t, z.AddRecords(rr))
+	}
+	return z
+}
+
+func TestNewZone(t *testing.T) {
+	_, err := NewZone("example.", nil)
+	require.ErrorIs(t, err, ErrZoneMissingSOA)
+
+	soa, err := dns.NewRR("other. 3600 IN SOA ns1.nic.example. hostmaster.nic.example. 1 1800 900 604800 86400")
+	require.NoError(t, err)
+	_, err = NewZone("example.", soa.(*dns.SOA))
+	require.ErrorIs(t, err, ErrZoneMissingSOA)
+}
+
+func TestZone_AddRecords(t *testing.T) {
+	z := newTestZone(t)
+	require.Len(t, z.Records(), 8)
+
+	// Identical records are added once, regardless of TTL and case
+	dup, err := dns.NewRR("A.Example. 60 IN NS ns1.a.example.")
+	require.NoError(t, err)
+	require.NoError(t, z.AddRecords(dup, nil))
+	require.Len(t, z.Records(), 8)
+
+	out, err := dns.NewRR("ns.other.net. 3600 IN A 192.0.2.2")
+	require.NoError(t, err)
+	require.ErrorIs(t, z.AddRecords(out), ErrZoneOutOfZoneData)
+	require.False(t, z.IsInZone("ns.other.net."))
+	require.True(t, z.IsInZone("ns1.a.example."))
+
+	soa, err := dns.NewRR("example. 3600 IN SOA ns1.nic.example. hostmaster.nic.example. 1 1800 900 604800 86400")
+	require.NoError(t, err)
+	require.ErrorIs(t, z.AddRecords(soa), ErrZoneMultipleSOA)
+}
+
+func TestZone_IncrementSerial(t *testing.T) {
+	z := newTestZone(t)
+	require.Equal(t, uint32(42), z.IncrementSerial())
+	require.Equal(t, uint32(42), z.SOA.Serial)
+
+	z.SOA.Serial = 4294967295
+	require.Equal(t, uint32(0), z.IncrementSerial())
+}
+
+func TestZone_WriteTo(t *testing.T) {
+	z := newTestZone(t)
+
+	var buf bytes.Buffer
+	n, err := z.WriteTo(&buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(buf.Len()), n)
+	require.Equal(t, z.String(), buf.String())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Equal(t, "$ORIGIN example.", lines[0])
+	require.True(t, strings.HasPrefix(lines[1], "example.\t3600\tIN\tSOA\t"))
+	require.True(t, strings.HasPrefix(lines[2], "example.\t3600\tIN\tNS\t"))
+	require.True(t, strings.HasPrefix(lines[3], "example.\t3600\tIN\tNS\t"))
+	require.True(t, strings.HasPrefix(lines[4], "a.example.\t3600\tIN\tNS\t"))
+	require.True(t, strings.HasPrefix(lines[5], "a.example.\t3600\tIN\tDS\t"))
+	require.True(t, strings.HasPrefix(lines[6], "ns1.a.example.\t3600\tIN\tAAAA\t"))
+	require.True(t, strings.HasPrefix(lines[7], "b.example.\t3600\tIN\tNS\t"))
+	require.True(t, strings.HasPrefix(lines[8], "ns1.nic.example.\t3600\tIN\tA\t"))
+
+	require.NoError(t, z.Validate())
+}
+
+func TestCheckZone(t *testing.T) {
+	header := "$ORIGIN example.\n$TTL 3600\n@ IN SOA ns1.nic hostmaster.nic 1 1800 900 604800 86400\n"
+	tests := []struct {
+		name    string
+		zone    string
+		wantErr error
+	}{
+		{
+			name: "valid",
+			zone: header + "@ IN NS ns1.nic\nns1.nic IN A 192.0.2.1\na IN NS ns1.a\na IN DS 12345 13 2 E2D3C916F6DEEAC73294E8268FB5885044A833FC5459588F4A9184CFC41A5766\nns1.a IN A 192.0.2.2\n",
+		},
+		{
+			name:    "syntax error",
+			zone:    header + "@ IN A 192.0.2.300\n",
+			wantErr: ErrInvalidZone,
+		},
+		{
+			name:    "no SOA",
+			zone:    "$ORIGIN example.\n$TTL 3600\n@ IN NS ns.other.net.\n",
+			wantErr: ErrZoneMissingSOA,
+		},
+		{
+			name:    "multiple SOA",
+			zone:    header + "@ IN SOA ns1.nic hostmaster.nic 2 1800 900 604800 86400\n@ IN NS ns.other.net.\n",
+			wantErr: ErrZoneMultipleSOA,
+		},
+		{
+			name:    "no apex NS",
+			zone:    header + "a IN NS ns.other.net.\n",
+			wantErr: ErrZoneMissingApexNS,
+		},
+		{
+			name:    "out of zone data",
+			zone:    header + "@ IN NS ns.other.net.\nns.other.net. IN A 192.0.2.1\n",
+			wantErr: ErrZoneOutOfZoneData,
+		},
+		{
+			name:    "occluded data",
+			zone:    header + "@ IN NS ns.other.net.\na IN NS ns.other.net.\nwww.a IN TXT \"hidden\"\n",
+			wantErr: ErrZoneOccludedData,
+		},
+		{
+			name:    "DS without delegation",
+			zone:    header + "@ IN NS ns.other.net.\na IN DS 12345 13 2 E2D3C916F6DEEAC73294E8268FB5885044A833FC5459588F4A9184CFC41A5766\n",
+			wantErr: ErrZoneDSNotAtDelegation,
+		},
+		{
+			name:    "DS at apex",
+			zone:    header + "@ IN NS ns.other.net.\n@ IN DS 12345 13 2 E2D3C916F6DEEAC73294E8268FB5885044A833FC5459588F4A9184CFC41A5766\n",
+			wantErr: ErrZoneDSNotAtDelegation,
+		},
+		{
+			name:    "CNAME and other data",
+			zone:    header + "@ IN NS ns.other.net.\nwww IN CNAME ns.other.net.\nwww IN TXT \"other\"\n",
+			wantErr: ErrZoneCNAMEAndOtherData,
+		},
+		{
+			name:    "in-zone NS target without glue",
+			zone:    header + "@ IN NS ns.other.net.\na IN NS ns1.a\n",
+			wantErr: ErrZoneNSMissingAddress,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckZone("example.", strings.NewReader(tc.zone))
+			if tc.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tc.wantErr)
+			require.ErrorIs(t, err, ErrInvalidZone)
+		})
+	}
+}
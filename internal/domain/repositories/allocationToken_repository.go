@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+// AllocationTokenRepository is the interface for the allocation token repository
+type AllocationTokenRepository interface {
+	// Create stores a new allocation token
+	Create(ctx context.Context, token *entities.AllocationToken) (*entities.AllocationToken, error)
+	// Update updates an existing allocation token
+	Update(ctx context.Context, token *entities.AllocationToken) (*entities.AllocationToken, error)
+	// GetByToken retrieves an allocation token by its token value
+	GetByToken(ctx context.Context, token string) (*entities.AllocationToken, error)
+	// Delete deletes an allocation token by its token value
+	Delete(ctx context.Context, token string) error
+	// List returns a list of allocation tokens and a cursor for pagination
+	List(ctx context.Context, params queries.ListItemsQuery) ([]*entities.AllocationToken, string, error)
+	// Count returns the number of allocation tokens matching the filter
+	Count(ctx context.Context, filter queries.ListAllocationTokensFilter) (int64, error)
+}
+
+// MockAllocationTokenRepository is the mock implementation of the AllocationTokenRepository
+type MockAllocationTokenRepository struct {
+	mock.Mock
+}
+
+// Create stores a new allocation token
+func (m *MockAllocationTokenRepository) Create(ctx context.Context, token *entities.AllocationToken) (*entities.AllocationToken, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.AllocationToken), args.Error(1)
+}
+
+// Update updates an existing allocation token
+func (m *MockAllocationTokenRepository) Update(ctx context.Context, token *entities.AllocationToken) (*entities.AllocationToken, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.AllocationToken), args.Error(1)
+}
+
+// GetByToken retrieves an allocation token by its token value
+func (m *MockAllocationTokenRepository) GetByToken(ctx context.Context, token string) (*entities.AllocationToken, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.AllocationToken), args.Error(1)
+}
+
+// Delete deletes an allocation token by its token value
+func (m *MockAllocationTokenRepository) Delete(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+// List returns a list of allocation tokens and a cursor for pagination
+func (m *MockAllocationTokenRepository) List(ctx context.Context, params queries.ListItemsQuery) ([]*entities.AllocationToken, string, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]*entities.AllocationToken), args.String(1), args.Error(2)
+}
+
+// Count returns the number of allocation tokens matching the filter
+func (m *MockAllocationTokenRepository) Count(ctx context.Context, filter queries.ListAllocationTokensFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// ClaimsRepository provides the trademark claims of domain labels, e.g. from the TMCH Domain Name Label (DNL) list
+type ClaimsRepository interface {
+	// GetClaimKey returns the key to retrieve the claims notice of the label, or an empty string if the label does not match a trademark
+	GetClaimKey(ctx context.Context, label string) (string, error)
+}
+
+// MockClaimsRepository is the mock implementation of the ClaimsRepository
+type MockClaimsRepository struct {
+	mock.Mock
+}
+
+// GetClaimKey returns the key to retrieve the claims notice of the label, or an empty string if the label does not match a trademark
+func (m *MockClaimsRepository) GetClaimKey(ctx context.Context, label string) (string, error) {
+	args := m.Called(ctx, label)
+	return args.String(0), args.Error(1)
+}
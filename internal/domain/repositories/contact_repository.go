@@ -15,4 +15,6 @@ type ContactRepository interface {
 	DeleteContactByID(ctx context.Context, id string) error
 	ListContacts(ctx context.Context, params queries.ListItemsQuery) ([]*entities.Contact, string, error)
 	BulkCreate(ctx context.Context, contacts []*entities.Contact) error
+	// GetContactAssociationCount returns the number of domains that reference the contact in any role
+	GetContactAssociationCount(ctx context.Context, id string) (int64, error)
 }
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+// DeletedObjectRepository is the interface for the repository that keeps track of deleted objects for escrow purposes.
+// The objects are recorded by the repositories that delete them.
+type DeletedObjectRepository interface {
+	// ListDeletedAfter returns the objects of the TLD, as well as the hosts and contacts, that were deleted after the provided time
+	ListDeletedAfter(ctx context.Context, tld string, after time.Time) ([]*entities.DeletedObject, error)
+}
+
+// MockDeletedObjectRepository is the mock implementation of the DeletedObjectRepository
+type MockDeletedObjectRepository struct {
+	mock.Mock
+}
+
+// ListDeletedAfter returns the objects that were deleted after the provided time
+func (m *MockDeletedObjectRepository) ListDeletedAfter(ctx context.Context, tld string, after time.Time) ([]*entities.DeletedObject, error) {
+	args := m.Called(ctx, tld, after)
+	return args.Get(0).([]*entities.DeletedObject), args.Error(1)
+}
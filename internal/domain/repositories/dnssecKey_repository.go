@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/miekg/dns"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// DNSSECKeyRepository is the interface for the storage of the DNSSEC keys of our zones
+type DNSSECKeyRepository interface {
+	ListByZone(ctx context.Context, zone string) ([]*entities.DNSSECKey, error)
+	Create(ctx context.Context, key *entities.DNSSECKey) error
+	Update(ctx context.Context, key *entities.DNSSECKey) error
+	Delete(ctx context.Context, key *entities.DNSSECKey) error
+	// SaveDS stores the DS records of the zone for submission to the parent
+	SaveDS(ctx context.Context, zone string, ds []*dns.DS) error
+}
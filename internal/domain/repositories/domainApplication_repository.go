@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+// DomainApplicationRepository is the interface for the domain application repository
+type DomainApplicationRepository interface {
+	// Create stores a new domain application
+	Create(ctx context.Context, app *entities.DomainApplication) (*entities.DomainApplication, error)
+	// Update updates an existing domain application
+	Update(ctx context.Context, app *entities.DomainApplication) (*entities.DomainApplication, error)
+	// GetByID retrieves a domain application by its ID
+	GetByID(ctx context.Context, id string) (*entities.DomainApplication, error)
+	// Delete deletes a domain application by its ID
+	Delete(ctx context.Context, id string) error
+	// List returns a list of domain applications and a cursor for pagination
+	List(ctx context.Context, params queries.ListItemsQuery) ([]*entities.DomainApplication, string, error)
+	// Count returns the number of domain applications matching the filter
+	Count(ctx context.Context, filter queries.ListDomainApplicationsFilter) (int64, error)
+}
+
+// MockDomainApplicationRepository is the mock implementation of the DomainApplicationRepository
+type MockDomainApplicationRepository struct {
+	mock.Mock
+}
+
+// Create stores a new domain application
+func (m *MockDomainApplicationRepository) Create(ctx context.Context, app *entities.DomainApplication) (*entities.DomainApplication, error) {
+	args := m.Called(ctx, app)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DomainApplication), args.Error(1)
+}
+
+// Update updates an existing domain application
+func (m *MockDomainApplicationRepository) Update(ctx context.Context, app *entities.DomainApplication) (*entities.DomainApplication, error) {
+	args := m.Called(ctx, app)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DomainApplication), args.Error(1)
+}
+
+// GetByID retrieves a domain application by its ID
+func (m *MockDomainApplicationRepository) GetByID(ctx context.Context, id string) (*entities.DomainApplication, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DomainApplication), args.Error(1)
+}
+
+// Delete deletes a domain application by its ID
+func (m *MockDomainApplicationRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// List returns a list of domain applications and a cursor for pagination
+func (m *MockDomainApplicationRepository) List(ctx context.Context, params queries.ListItemsQuery) ([]*entities.DomainApplication, string, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]*entities.DomainApplication), args.String(1), args.Error(2)
+}
+
+// Count returns the number of domain applications matching the filter
+func (m *MockDomainApplicationRepository) Count(ctx context.Context, filter queries.ListDomainApplicationsFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
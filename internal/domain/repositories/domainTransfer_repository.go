@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+// DomainTransferRepository is the interface for the domain transfer repository
+type DomainTransferRepository interface {
+	// Create stores a new domain transfer
+	Create(ctx context.Context, transfer *entities.DomainTransfer) (*entities.DomainTransfer, error)
+	// Update updates an existing domain transfer
+	Update(ctx context.Context, transfer *entities.DomainTransfer) (*entities.DomainTransfer, error)
+	// GetByID retrieves a domain transfer by its ID
+	GetByID(ctx context.Context, id string) (*entities.DomainTransfer, error)
+	// GetLatestByDomainName retrieves the most recent transfer of the domain with the provided name
+	GetLatestByDomainName(ctx context.Context, name string) (*entities.DomainTransfer, error)
+	// List returns a list of domain transfers and a cursor for pagination
+	List(ctx context.Context, params queries.ListItemsQuery) ([]*entities.DomainTransfer, string, error)
+	// Count returns the number of domain transfers matching the filter
+	Count(ctx context.Context, filter queries.ListDomainTransfersFilter) (int64, error)
+}
+
+// MockDomainTransferRepository is the mock implementation of the DomainTransferRepository
+type MockDomainTransferRepository struct {
+	mock.Mock
+}
+
+// Create stores a new domain transfer
+func (m *MockDomainTransferRepository) Create(ctx context.Context, transfer *entities.DomainTransfer) (*entities.DomainTransfer, error) {
+	args := m.Called(ctx, transfer)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DomainTransfer), args.Error(1)
+}
+
+// Update updates an existing domain transfer
+func (m *MockDomainTransferRepository) Update(ctx context.Context, transfer *entities.DomainTransfer) (*entities.DomainTransfer, error) {
+	args := m.Called(ctx, transfer)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DomainTransfer), args.Error(1)
+}
+
+// GetByID retrieves a domain transfer by its ID
+func (m *MockDomainTransferRepository) GetByID(ctx context.Context, id string) (*entities.DomainTransfer, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DomainTransfer), args.Error(1)
+}
+
+// GetLatestByDomainName retrieves the most recent transfer of the domain with the provided name
+func (m *MockDomainTransferRepository) GetLatestByDomainName(ctx context.Context, name string) (*entities.DomainTransfer, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DomainTransfer), args.Error(1)
+}
+
+// List returns a list of domain transfers and a cursor for pagination
+func (m *MockDomainTransferRepository) List(ctx context.Context, params queries.ListItemsQuery) ([]*entities.DomainTransfer, string, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]*entities.DomainTransfer), args.String(1), args.Error(2)
+}
+
+// Count returns the number of domain transfers matching the filter
+func (m *MockDomainTransferRepository) Count(ctx context.Context, filter queries.ListDomainTransfersFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
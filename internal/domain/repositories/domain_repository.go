@@ -13,8 +13,12 @@ import (
 // DomainRepository is the interface for the DomainRepository
 type DomainRepository interface {
 	Create(ctx context.Context, d *entities.Domain) (*entities.Domain, error)
+	CreateAndRedeemAllocationToken(ctx context.Context, d *entities.Domain, token string) (*entities.Domain, error)
 	GetDomainByName(ctx context.Context, name string, preloadHosts bool) (*entities.Domain, error)
 	UpdateDomain(ctx context.Context, d *entities.Domain) (*entities.Domain, error)
+	UpdateDomainAndHosts(ctx context.Context, name string, update func(d *entities.Domain) (removedHosts []*entities.Host, err error)) (*entities.Domain, error)
+	UpdateDomainAndCreateTransfer(ctx context.Context, d *entities.Domain, t *entities.DomainTransfer) (*entities.Domain, *entities.DomainTransfer, error)
+	UpdateDomainAndTransfer(ctx context.Context, d *entities.Domain, t *entities.DomainTransfer) (*entities.Domain, *entities.DomainTransfer, error)
 	DeleteDomainByName(ctx context.Context, name string) error
 	ListDomains(ctx context.Context, params queries.ListItemsQuery) ([]*entities.Domain, string, error)
 	AddHostToDomain(ctx context.Context, domRoid int64, hostRoid int64) error
@@ -42,6 +46,12 @@ func (m *MockDomainRepository) Create(ctx context.Context, d *entities.Domain) (
 	return args.Get(0).(*entities.Domain), args.Error(1)
 }
 
+// CreateAndRedeemAllocationToken creates a new domain and redeems the allocation token it was registered with
+func (m *MockDomainRepository) CreateAndRedeemAllocationToken(ctx context.Context, d *entities.Domain, token string) (*entities.Domain, error) {
+	args := m.Called(ctx, d, token)
+	return args.Get(0).(*entities.Domain), args.Error(1)
+}
+
 // BulkCreate creates multiple domains
 func (m *MockDomainRepository) BulkCreate(ctx context.Context, domains []*entities.Domain) error {
 	args := m.Called(ctx, domains)
@@ -60,6 +70,31 @@ func (m *MockDomainRepository) UpdateDomain(ctx context.Context, d *entities.Dom
 	return args.Get(0).(*entities.Domain), args.Error(1)
 }
 
+// UpdateDomainAndHosts applies the update to the domain the mock returns, like the repository applies it to the domain it read for update
+func (m *MockDomainRepository) UpdateDomainAndHosts(ctx context.Context, name string, update func(d *entities.Domain) (removedHosts []*entities.Host, err error)) (*entities.Domain, error) {
+	args := m.Called(ctx, name, update)
+	if err := args.Error(1); err != nil {
+		return nil, err
+	}
+	d := args.Get(0).(*entities.Domain)
+	if _, err := update(d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// UpdateDomainAndCreateTransfer updates a domain and creates its transfer
+func (m *MockDomainRepository) UpdateDomainAndCreateTransfer(ctx context.Context, d *entities.Domain, t *entities.DomainTransfer) (*entities.Domain, *entities.DomainTransfer, error) {
+	args := m.Called(ctx, d, t)
+	return args.Get(0).(*entities.Domain), args.Get(1).(*entities.DomainTransfer), args.Error(2)
+}
+
+// UpdateDomainAndTransfer updates a domain and its transfer
+func (m *MockDomainRepository) UpdateDomainAndTransfer(ctx context.Context, d *entities.Domain, t *entities.DomainTransfer) (*entities.Domain, *entities.DomainTransfer, error) {
+	args := m.Called(ctx, d, t)
+	return args.Get(0).(*entities.Domain), args.Get(1).(*entities.DomainTransfer), args.Error(2)
+}
+
 // DeleteDomainByName deletes a domain by its name
 func (m *MockDomainRepository) DeleteDomainByName(ctx context.Context, name string) error {
 	args := m.Called(ctx, name)
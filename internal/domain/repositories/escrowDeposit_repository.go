@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+// EscrowDepositRepository is the interface for the repository that keeps track of the escrow deposits generated for each TLD
+type EscrowDepositRepository interface {
+	// Save stores the escrow deposit, a deposit with the same ID for the TLD is replaced (e.g. when it is resent)
+	Save(ctx context.Context, deposit *entities.EscrowDeposit) (*entities.EscrowDeposit, error)
+	// GetByID retrieves the deposit of the TLD with the provided ID
+	GetByID(ctx context.Context, tld, id string) (*entities.EscrowDeposit, error)
+	// GetLatest retrieves the deposit with the most recent watermark for the TLD. If depositType is not empty only deposits of that type are considered.
+	GetLatest(ctx context.Context, tld, depositType string) (*entities.EscrowDeposit, error)
+	// GetByDay retrieves the deposit of the type that was generated for the TLD with a watermark on the same (UTC) day as the provided time
+	GetByDay(ctx context.Context, tld, depositType string, day time.Time) (*entities.EscrowDeposit, error)
+}
+
+// MockEscrowDepositRepository is the mock implementation of the EscrowDepositRepository
+type MockEscrowDepositRepository struct {
+	mock.Mock
+}
+
+// Save stores the escrow deposit
+func (m *MockEscrowDepositRepository) Save(ctx context.Context, deposit *entities.EscrowDeposit) (*entities.EscrowDeposit, error) {
+	args := m.Called(ctx, deposit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.EscrowDeposit), args.Error(1)
+}
+
+// GetByID retrieves the deposit of the TLD with the provided ID
+func (m *MockEscrowDepositRepository) GetByID(ctx context.Context, tld, id string) (*entities.EscrowDeposit, error) {
+	args := m.Called(ctx, tld, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.EscrowDeposit), args.Error(1)
+}
+
+// GetLatest retrieves the deposit with the most recent watermark for the TLD
+func (m *MockEscrowDepositRepository) GetLatest(ctx context.Context, tld, depositType string) (*entities.EscrowDeposit, error) {
+	args := m.Called(ctx, tld, depositType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.EscrowDeposit), args.Error(1)
+}
+
+// GetByDay retrieves the deposit of the type that was generated for the TLD on the same day
+func (m *MockEscrowDepositRepository) GetByDay(ctx context.Context, tld, depositType string, day time.Time) (*entities.EscrowDeposit, error) {
+	args := m.Called(ctx, tld, depositType, day)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.EscrowDeposit), args.Error(1)
+}
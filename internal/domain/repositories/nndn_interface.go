@@ -5,6 +5,7 @@ import (
 
 	"github.com/onasunnymorning/domain-os/internal/application/queries"
 	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
 )
 
 // NNDNRepository defines the interface for interacting with NNDN data storage.
@@ -27,3 +28,53 @@ type NNDNRepository interface {
 	// Count returns the number of NNDN objects in the repository optionally filtered by the provided query.
 	Count(ctx context.Context, filter queries.ListNndnsFilter) (int64, error)
 }
+
+// MockNNDNRepository is the mock implementation of the NNDNRepository
+type MockNNDNRepository struct {
+	mock.Mock
+}
+
+// CreateNNDN persists a new NNDN object in the repository.
+func (m *MockNNDNRepository) CreateNNDN(ctx context.Context, nndn *entities.NNDN) (*entities.NNDN, error) {
+	args := m.Called(ctx, nndn)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.NNDN), args.Error(1)
+}
+
+// GetNNDN retrieves an NNDN object by its ID/Name from the repository.
+func (m *MockNNDNRepository) GetNNDN(ctx context.Context, name string) (*entities.NNDN, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.NNDN), args.Error(1)
+}
+
+// UpdateNNDN updates an existing NNDN object in the repository.
+func (m *MockNNDNRepository) UpdateNNDN(ctx context.Context, nndn *entities.NNDN) (*entities.NNDN, error) {
+	args := m.Called(ctx, nndn)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.NNDN), args.Error(1)
+}
+
+// DeleteNNDN removes an NNDN object from the repository by its ID/Name.
+func (m *MockNNDNRepository) DeleteNNDN(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
+// ListNNDNs returns a list of NNDN objects, with pagination support.
+func (m *MockNNDNRepository) ListNNDNs(ctx context.Context, params queries.ListItemsQuery) ([]*entities.NNDN, string, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]*entities.NNDN), args.String(1), args.Error(2)
+}
+
+// Count returns the number of NNDN objects in the repository optionally filtered by the provided query.
+func (m *MockNNDNRepository) Count(ctx context.Context, filter queries.ListNndnsFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+// PollMessageRepository is the interface for the poll message repository
+type PollMessageRepository interface {
+	// Create queues a new poll message
+	Create(ctx context.Context, msg *entities.PollMessage) (*entities.PollMessage, error)
+	// GetByID retrieves a poll message by its ID
+	GetByID(ctx context.Context, id int64) (*entities.PollMessage, error)
+	// GetOldest retrieves the oldest poll message queued for the registrar with the provided ClID
+	GetOldest(ctx context.Context, clid string) (*entities.PollMessage, error)
+	// Delete removes a poll message from the queue
+	Delete(ctx context.Context, id int64) error
+	// List returns a list of poll messages and a cursor for pagination
+	List(ctx context.Context, params queries.ListItemsQuery) ([]*entities.PollMessage, string, error)
+	// Count returns the number of poll messages matching the filter
+	Count(ctx context.Context, filter queries.ListPollMessagesFilter) (int64, error)
+}
+
+// MockPollMessageRepository is the mock implementation of the PollMessageRepository
+type MockPollMessageRepository struct {
+	mock.Mock
+}
+
+// Create queues a new poll message
+func (m *MockPollMessageRepository) Create(ctx context.Context, msg *entities.PollMessage) (*entities.PollMessage, error) {
+	args := m.Called(ctx, msg)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PollMessage), args.Error(1)
+}
+
+// GetByID retrieves a poll message by its ID
+func (m *MockPollMessageRepository) GetByID(ctx context.Context, id int64) (*entities.PollMessage, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PollMessage), args.Error(1)
+}
+
+// GetOldest retrieves the oldest poll message queued for the registrar with the provided ClID
+func (m *MockPollMessageRepository) GetOldest(ctx context.Context, clid string) (*entities.PollMessage, error) {
+	args := m.Called(ctx, clid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PollMessage), args.Error(1)
+}
+
+// Delete removes a poll message from the queue
+func (m *MockPollMessageRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// List returns a list of poll messages and a cursor for pagination
+func (m *MockPollMessageRepository) List(ctx context.Context, params queries.ListItemsQuery) ([]*entities.PollMessage, string, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]*entities.PollMessage), args.String(1), args.Error(2)
+}
+
+// Count returns the number of poll messages matching the filter
+func (m *MockPollMessageRepository) Count(ctx context.Context, filter queries.ListPollMessagesFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
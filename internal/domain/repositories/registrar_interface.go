@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/onasunnymorning/domain-os/internal/application/queries"
 	"github.com/onasunnymorning/domain-os/internal/domain/entities"
@@ -19,6 +20,7 @@ type RegistrarRepository interface {
 	List(ctx context.Context, params queries.ListItemsQuery) ([]*entities.RegistrarListItem, string, error)
 	Count(ctx context.Context) (int64, error)
 	IsRegistrarAccreditedForTLD(ctx context.Context, tldName, rarClID string) (bool, error)
+	RecordFailedEPPLogin(ctx context.Context, clid string, t time.Time) (int, time.Time, error)
 }
 
 // MockRegistrarRepository is the mock implementation of the RegistrarRepository
@@ -79,3 +81,9 @@ func (m *MockRegistrarRepository) IsRegistrarAccreditedForTLD(ctx context.Contex
 	args := m.Called(ctx, clid, tld)
 	return args.Bool(0), args.Error(1)
 }
+
+// RecordFailedEPPLogin counts a failed EPP login of a registrar
+func (m *MockRegistrarRepository) RecordFailedEPPLogin(ctx context.Context, clid string, t time.Time) (int, time.Time, error) {
+	args := m.Called(ctx, clid, t)
+	return args.Int(0), args.Get(1).(time.Time), args.Error(2)
+}
@@ -10,5 +10,6 @@ import (
 type TLDDNSRecordRepository interface {
 	Create(ctx context.Context, record *postgres.TLDDNSRecord) (*postgres.TLDDNSRecord, error)
 	GetByZone(ctx context.Context, zone string) ([]*postgres.TLDDNSRecord, error)
+	Update(ctx context.Context, record *postgres.TLDDNSRecord) (*postgres.TLDDNSRecord, error)
 	Delete(ctx context.Context, id int) error
 }
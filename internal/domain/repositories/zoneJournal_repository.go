@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// ZoneJournalRepository is the interface for the storage of the published version of our zones and their recent changes,
+// so the primary can keep serving the same serial and incremental zone transfers after a restart
+type ZoneJournalRepository interface {
+	// GetByZone returns the published version of the zone and its journal, or entities.ErrZoneJournalNotFound if the zone was never stored
+	GetByZone(ctx context.Context, origin string) (*entities.Zone, *entities.ZoneJournal, error)
+	// Save stores the published version of the zone and its journal, replacing the previous version
+	Save(ctx context.Context, zone *entities.Zone, journal *entities.ZoneJournal) error
+}
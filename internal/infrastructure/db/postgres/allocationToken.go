@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// AllocationToken is the GORM representation of an AllocationToken
+type AllocationToken struct {
+	Token              string `gorm:"primaryKey"`
+	TLDName            string `gorm:"not null;index"`
+	DomainName         string `gorm:"index"`
+	ClID               string `gorm:"index"`
+	PhaseName          string
+	ExpiresAt          time.Time
+	MultiUse           bool
+	DiscountPercentage int
+	Redemptions        int
+	RedeemedAt         time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// TableName returns the table name for the AllocationToken model
+func (AllocationToken) TableName() string {
+	return "allocation_tokens"
+}
+
+// ToDBAllocationToken converts an AllocationToken entity to a GORM AllocationToken
+func ToDBAllocationToken(at *entities.AllocationToken) *AllocationToken {
+	return &AllocationToken{
+		Token:              at.Token,
+		TLDName:            at.TLDName.String(),
+		DomainName:         at.DomainName.String(),
+		ClID:               at.ClID.String(),
+		PhaseName:          at.PhaseName.String(),
+		ExpiresAt:          at.ExpiresAt,
+		MultiUse:           at.MultiUse,
+		DiscountPercentage: at.DiscountPercentage,
+		Redemptions:        at.Redemptions,
+		RedeemedAt:         at.RedeemedAt,
+		CreatedAt:          at.CreatedAt,
+		UpdatedAt:          at.UpdatedAt,
+	}
+}
+
+// FromDBAllocationToken converts a GORM AllocationToken to an AllocationToken entity
+func FromDBAllocationToken(dbat *AllocationToken) *entities.AllocationToken {
+	return &entities.AllocationToken{
+		Token:              dbat.Token,
+		TLDName:            entities.DomainName(dbat.TLDName),
+		DomainName:         entities.DomainName(dbat.DomainName),
+		ClID:               entities.ClIDType(dbat.ClID),
+		PhaseName:          entities.ClIDType(dbat.PhaseName),
+		ExpiresAt:          dbat.ExpiresAt.UTC(),
+		MultiUse:           dbat.MultiUse,
+		DiscountPercentage: dbat.DiscountPercentage,
+		Redemptions:        dbat.Redemptions,
+		RedeemedAt:         dbat.RedeemedAt.UTC(),
+		CreatedAt:          dbat.CreatedAt.UTC(),
+		UpdatedAt:          dbat.UpdatedAt.UTC(),
+	}
+}
@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"gorm.io/gorm"
+)
+
+// AllocationTokenRepository implements the AllocationTokenRepository interface
+type AllocationTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewAllocationTokenRepository returns a new AllocationTokenRepository
+func NewAllocationTokenRepository(db *gorm.DB) *AllocationTokenRepository {
+	return &AllocationTokenRepository{
+		db: db,
+	}
+}
+
+// Create stores a new allocation token
+func (r *AllocationTokenRepository) Create(ctx context.Context, at *entities.AllocationToken) (*entities.AllocationToken, error) {
+	dbat := ToDBAllocationToken(at)
+	err := r.db.WithContext(ctx).Create(dbat).Error
+	if err != nil {
+		var perr *pgconn.PgError
+		if errors.As(err, &perr) && perr.Code == "23505" {
+			return nil, entities.ErrDuplicateAllocationToken
+		}
+		return nil, err
+	}
+	return FromDBAllocationToken(dbat), nil
+}
+
+// Update updates an existing allocation token
+func (r *AllocationTokenRepository) Update(ctx context.Context, at *entities.AllocationToken) (*entities.AllocationToken, error) {
+	dbat := ToDBAllocationToken(at)
+	err := r.db.WithContext(ctx).Save(dbat).Error
+	if err != nil {
+		return nil, err
+	}
+	return FromDBAllocationToken(dbat), nil
+}
+
+// redeemAllocationToken records a redemption of the token at the provided time.
+// The redemptions are incremented by a single conditional update so concurrent transactions can't redeem a single use token twice, ErrAllocationTokenRedeemed is returned if no token was updated.
+func redeemAllocationToken(tx *gorm.DB, token string, at time.Time) error {
+	result := tx.Model(&AllocationToken{}).
+		Where("token = ? AND (multi_use OR redemptions = 0)", token).
+		Updates(map[string]interface{}{
+			"redemptions": gorm.Expr("redemptions + 1"),
+			"redeemed_at": at,
+			"updated_at":  at,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrAllocationTokenRedeemed
+	}
+	return nil
+}
+
+// GetByToken retrieves an allocation token by its token value
+func (r *AllocationTokenRepository) GetByToken(ctx context.Context, token string) (*entities.AllocationToken, error) {
+	dbat := &AllocationToken{}
+	err := r.db.WithContext(ctx).Where("token = ?", token).First(dbat).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrAllocationTokenNotFound
+		}
+		return nil, err
+	}
+	return FromDBAllocationToken(dbat), nil
+}
+
+// Delete deletes an allocation token by its token value, deleting a token that does not exist is idempotent
+func (r *AllocationTokenRepository) Delete(ctx context.Context, token string) error {
+	return r.db.WithContext(ctx).Where("token = ?", token).Delete(&AllocationToken{}).Error
+}
+
+// Count returns the number of allocation tokens matching the filter
+func (r *AllocationTokenRepository) Count(ctx context.Context, filter queries.ListAllocationTokensFilter) (int64, error) {
+	var count int64
+	err := setAllocationTokenFilters(r.db.WithContext(ctx).Model(&AllocationToken{}), filter).Count(&count).Error
+	return count, err
+}
+
+// List returns a list of allocation tokens ordered by token and a cursor for pagination
+func (r *AllocationTokenRepository) List(ctx context.Context, params queries.ListItemsQuery) ([]*entities.AllocationToken, string, error) {
+	// Get a query object ordering by token (PK used for cursor pagination)
+	dbQuery := r.db.WithContext(ctx).Order("token ASC")
+
+	// Add cursor pagination if a cursor is provided
+	if params.PageCursor != "" {
+		dbQuery = dbQuery.Where("token > ?", params.PageCursor)
+	}
+
+	// Add filters if provided
+	if params.Filter != nil {
+		filter, ok := params.Filter.(queries.ListAllocationTokensFilter)
+		if !ok {
+			return nil, "", ErrInvalidFilterType
+		}
+		dbQuery = setAllocationTokenFilters(dbQuery, filter)
+	}
+
+	// Fetch one more than the limit to determine if there are more results
+	dbQuery = dbQuery.Limit(params.PageSize + 1)
+
+	var dbats []*AllocationToken
+	if err := dbQuery.Find(&dbats).Error; err != nil {
+		return nil, "", err
+	}
+
+	// Check if there are more results
+	hasMore := len(dbats) == params.PageSize+1
+	if hasMore {
+		// Return only up to the limit
+		dbats = dbats[:params.PageSize]
+	}
+
+	tokens := make([]*entities.AllocationToken, len(dbats))
+	for i, dbat := range dbats {
+		tokens[i] = FromDBAllocationToken(dbat)
+	}
+
+	// Set the cursor to the last token in the list
+	var newCursor string
+	if hasMore {
+		newCursor = tokens[len(tokens)-1].Token
+	}
+
+	return tokens, newCursor, nil
+}
+
+// setAllocationTokenFilters adds the filters to the query
+func setAllocationTokenFilters(dbQuery *gorm.DB, filter queries.ListAllocationTokensFilter) *gorm.DB {
+	if filter.TldEquals != "" {
+		dbQuery = dbQuery.Where("tld_name = ?", filter.TldEquals)
+	}
+	if filter.DomainNameEquals != "" {
+		dbQuery = dbQuery.Where("domain_name = ?", filter.DomainNameEquals)
+	}
+	if filter.ClIDEquals != "" {
+		dbQuery = dbQuery.Where("cl_id = ?", filter.ClIDEquals)
+	}
+	if filter.PhaseNameEquals != "" {
+		dbQuery = dbQuery.Where("phase_name = ?", filter.PhaseNameEquals)
+	}
+	return dbQuery
+}
@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type AllocationTokenSuite struct {
+	suite.Suite
+	db *gorm.DB
+}
+
+func TestAllocationTokenSuite(t *testing.T) {
+	suite.Run(t, new(AllocationTokenSuite))
+}
+
+func (s *AllocationTokenSuite) SetupSuite() {
+	s.db = setupTestDB()
+}
+
+func (s *AllocationTokenSuite) TestAllocationToken_Lifecycle() {
+	repo := NewAllocationTokenRepository(s.db)
+	ctx := context.Background()
+
+	at, err := entities.NewAllocationToken("repotest-token", "repotest")
+	s.Require().NoError(err)
+	s.Require().NoError(at.SetDomainName("reserved.repotest"))
+	at.ClID = "tokenRar"
+
+	_, err = repo.GetByToken(ctx, at.Token)
+	s.Require().ErrorIs(err, entities.ErrAllocationTokenNotFound)
+
+	created, err := repo.Create(ctx, at)
+	s.Require().NoError(err)
+	s.Require().Equal(at.Token, created.Token)
+
+	_, err = repo.Create(ctx, at)
+	s.Require().ErrorIs(err, entities.ErrDuplicateAllocationToken)
+
+	s.Require().NoError(created.Redeem("reserved.repotest", "tokenRar", ""))
+	_, err = repo.Update(ctx, created)
+	s.Require().NoError(err)
+
+	read, err := repo.GetByToken(ctx, at.Token)
+	s.Require().NoError(err)
+	s.Require().Equal(1, read.Redemptions)
+
+	count, err := repo.Count(ctx, queries.ListAllocationTokensFilter{TldEquals: "repotest", ClIDEquals: "tokenRar"})
+	s.Require().NoError(err)
+	s.Require().Equal(int64(1), count)
+
+	list, _, err := repo.List(ctx, queries.ListItemsQuery{PageSize: 10, Filter: queries.ListAllocationTokensFilter{DomainNameEquals: "reserved.repotest"}})
+	s.Require().NoError(err)
+	s.Require().Len(list, 1)
+
+	s.Require().NoError(repo.Delete(ctx, at.Token))
+	_, err = repo.GetByToken(ctx, at.Token)
+	s.Require().ErrorIs(err, entities.ErrAllocationTokenNotFound)
+}
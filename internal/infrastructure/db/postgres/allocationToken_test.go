@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocationToken_TableName(t *testing.T) {
+	require.Equal(t, "allocation_tokens", AllocationToken{}.TableName())
+}
+
+func TestAllocationToken_Mapping(t *testing.T) {
+	at := &entities.AllocationToken{
+		Token:              "abc123",
+		TLDName:            "apex",
+		DomainName:         "reserved.apex",
+		ClID:               "ClID-1",
+		PhaseName:          "GA",
+		ExpiresAt:          time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		DiscountPercentage: 20,
+		CreatedAt:          time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:          time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	require.Equal(t, at, FromDBAllocationToken(ToDBAllocationToken(at)))
+
+	at.MultiUse = true
+	at.Redemptions = 2
+	at.RedeemedAt = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, at, FromDBAllocationToken(ToDBAllocationToken(at)))
+}
@@ -26,10 +26,17 @@ func AutoMigrate(db *gorm.DB) error {
 		&Host{},
 		&HostAddress{},
 		&Domain{},
+		&DomainDSData{},
 		&PremiumList{},
 		&PremiumLabel{},
 		&FX{},
 		&TLDDNSRecord{},
+		&PollMessage{},
+		&DomainTransfer{},
+		&DomainApplication{},
+		&AllocationToken{},
+		&EscrowDeposit{},
+		&DeletedObject{},
 	)
 	if err != nil {
 		return err
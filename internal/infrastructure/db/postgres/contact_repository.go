@@ -76,7 +76,14 @@ func (r *ContactRepository) UpdateContact(ctx context.Context, c *entities.Conta
 
 // DeleteContactByID deletes a contact from the database
 func (r *ContactRepository) DeleteContactByID(ctx context.Context, id string) error {
-	err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&Contact{}).Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ?", id).Delete(&Contact{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		// Contacts are not bound to a TLD, they are included in the deletes of every TLD
+		return recordDeletedObject(tx, entities.CONTACT_URI, "", id, "")
+	})
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.Join(entities.ErrContactNotFound, err)
@@ -86,6 +93,17 @@ func (r *ContactRepository) DeleteContactByID(ctx context.Context, id string) er
 	return nil
 }
 
+// GetContactAssociationCount returns the number of domains that reference the contact as registrant, admin, tech or billing contact.
+// This can be used to determine if a contact is linked
+func (r *ContactRepository) GetContactAssociationCount(ctx context.Context, id string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&Domain{}).Where("registrant_id = ? OR admin_id = ? OR tech_id = ? OR billing_id = ?", id, id, id, id).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 // ListContacts returns a list of contacts
 func (r *ContactRepository) ListContacts(ctx context.Context, params queries.ListItemsQuery) ([]*entities.Contact, string, error) {
 	// Create a query object
@@ -128,9 +146,19 @@ func (r *ContactRepository) ListContacts(ctx context.Context, params queries.Lis
 			if f.EmailLike != "" {
 				dbQuery = dbQuery.Where("email ILIKE ?", "%"+f.EmailLike+"%")
 			}
+			if f.NameMatches != "" {
+				pattern := wildcardToLikePattern(f.NameMatches)
+				dbQuery = dbQuery.Where("(name_int ILIKE ? OR name_loc ILIKE ?)", pattern, pattern)
+			}
 			if f.ClidEquals != "" {
 				dbQuery = dbQuery.Where("cl_id = ?", f.ClidEquals)
 			}
+			if f.TldEquals != "" {
+				dbQuery = dbQuery.Where("id IN (SELECT unnest(ARRAY[d.registrant_id, d.admin_id, d.tech_id, d.billing_id]) FROM domains d WHERE d.tld_name = ?)", f.TldEquals)
+			}
+			if !f.UpdatedAfter.IsZero() {
+				dbQuery = dbQuery.Where("updated_at > ?", f.UpdatedAfter)
+			}
 		}
 	}
 
@@ -194,6 +194,22 @@ func (s *ContactSuite) TestDeleteContact() {
 
 }
 
+func (s *ContactSuite) TestGetContactAssociationCount() {
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	repo := NewContactRepository(tx)
+
+	contact, err := entities.NewContact("contactID1", "1234_CONT-APEX", "jon@doe.com", "str0NGP@ZZw0rd", s.rarClid)
+	s.Require().NoError(err)
+
+	createdContact, err := repo.CreateContact(context.Background(), contact)
+	s.Require().NoError(err)
+
+	count, err := repo.GetContactAssociationCount(context.Background(), createdContact.ID.String())
+	s.Require().NoError(err)
+	s.Require().Equal(int64(0), count)
+}
+
 func (s *ContactSuite) TestListContacts() {
 	tx := s.db.Begin()
 	defer tx.Rollback()
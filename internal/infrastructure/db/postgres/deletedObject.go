@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"gorm.io/gorm"
+)
+
+// DeletedObject is the GORM representation of a DeletedObject
+type DeletedObject struct {
+	ID        int64  `gorm:"primaryKey;autoIncrement"`
+	ObjectURI string `gorm:"not null"`
+	TLDName   string `gorm:"index"`
+	Name      string `gorm:"not null"`
+	RoID      string
+	DeletedAt time.Time `gorm:"not null;index"`
+}
+
+// TableName returns the table name for the DeletedObject model
+func (DeletedObject) TableName() string {
+	return "deleted_objects"
+}
+
+// ToDBDeletedObject converts a DeletedObject entity to a GORM DeletedObject
+func ToDBDeletedObject(d *entities.DeletedObject) *DeletedObject {
+	return &DeletedObject{
+		ID:        d.ID,
+		ObjectURI: d.ObjectURI,
+		TLDName:   d.TLDName,
+		Name:      d.Name,
+		RoID:      d.RoID,
+		DeletedAt: d.DeletedAt,
+	}
+}
+
+// FromDBDeletedObject converts a GORM DeletedObject to a DeletedObject entity
+func FromDBDeletedObject(dbd *DeletedObject) *entities.DeletedObject {
+	return &entities.DeletedObject{
+		ID:        dbd.ID,
+		ObjectURI: dbd.ObjectURI,
+		TLDName:   dbd.TLDName,
+		Name:      dbd.Name,
+		RoID:      dbd.RoID,
+		DeletedAt: dbd.DeletedAt.UTC(),
+	}
+}
+
+// recordDeletedObject stores the deleted object in the same transaction as the delete
+func recordDeletedObject(tx *gorm.DB, objectURI, tld, name, roid string) error {
+	return tx.Create(ToDBDeletedObject(entities.NewDeletedObject(objectURI, tld, name, roid))).Error
+}
@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"gorm.io/gorm"
+)
+
+// DeletedObjectRepository implements the DeletedObjectRepository interface
+type DeletedObjectRepository struct {
+	db *gorm.DB
+}
+
+// NewDeletedObjectRepository returns a new DeletedObjectRepository
+func NewDeletedObjectRepository(db *gorm.DB) *DeletedObjectRepository {
+	return &DeletedObjectRepository{
+		db: db,
+	}
+}
+
+// ListDeletedAfter returns the objects of the TLD, as well as the hosts and contacts, that were deleted after the provided time ordered by the time of deletion
+func (r *DeletedObjectRepository) ListDeletedAfter(ctx context.Context, tld string, after time.Time) ([]*entities.DeletedObject, error) {
+	dbObjects := []*DeletedObject{}
+	err := r.db.WithContext(ctx).
+		Where("tld_name = ? OR tld_name = ''", tld).
+		Where("deleted_at > ?", after).
+		Order("deleted_at ASC").
+		Find(&dbObjects).Error
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]*entities.DeletedObject, len(dbObjects))
+	for i, o := range dbObjects {
+		objects[i] = FromDBDeletedObject(o)
+	}
+	return objects, nil
+}
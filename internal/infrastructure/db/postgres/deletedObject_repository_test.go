@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type DeletedObjectSuite struct {
+	suite.Suite
+	db *gorm.DB
+}
+
+func TestDeletedObjectSuite(t *testing.T) {
+	suite.Run(t, new(DeletedObjectSuite))
+}
+
+func (s *DeletedObjectSuite) SetupSuite() {
+	s.db = setupTestDB()
+}
+
+func (s *DeletedObjectSuite) TestDeletedObject_ListDeletedAfter() {
+	ctx := context.Background()
+	since := time.Now().UTC()
+
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	s.Require().NoError(recordDeletedObject(tx, entities.DOMAIN_URI, "deleterepotest", "example.deleterepotest", "1_DOM-APEX"))
+	s.Require().NoError(recordDeletedObject(tx, entities.DOMAIN_URI, "otherrepotest", "example.otherrepotest", "2_DOM-APEX"))
+	s.Require().NoError(recordDeletedObject(tx, entities.CONTACT_URI, "", "deleterepotest-contact", ""))
+
+	repo := NewDeletedObjectRepository(tx)
+	objects, err := repo.ListDeletedAfter(ctx, "deleterepotest", since)
+	s.Require().NoError(err)
+	names := []string{}
+	for _, o := range objects {
+		names = append(names, o.Name)
+	}
+	s.Require().Contains(names, "example.deleterepotest")
+	s.Require().Contains(names, "deleterepotest-contact")
+	s.Require().NotContains(names, "example.otherrepotest")
+}
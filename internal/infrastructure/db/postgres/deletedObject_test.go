@@ -0,0 +1,18 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeletedObject_TableName(t *testing.T) {
+	require.Equal(t, "deleted_objects", DeletedObject{}.TableName())
+}
+
+func TestDeletedObject_Mapping(t *testing.T) {
+	obj := entities.NewDeletedObject(entities.HOST_URI, "", "ns1.example.apex", "1234_HOST-APEX")
+	obj.ID = 1
+	require.Equal(t, obj, FromDBDeletedObject(ToDBDeletedObject(obj)))
+}
@@ -30,7 +30,10 @@ type Domain struct {
 	entities.DomainStatus         `gorm:"embedded"`
 	entities.DomainRGPStatus      `gorm:"embedded"`
 	entities.DomainGrandFathering `gorm:"embedded"`
-	Hosts                         []Host `gorm:"many2many:domain_hosts;"`
+	RestoreReport                 entities.DomainRestoreReport `gorm:"embedded;embeddedPrefix:restore_report_"`
+	MaxSigLife                    int
+	DSData                        []DomainDSData `gorm:"foreignKey:DomainRoID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"` // When the parent domain is deleted, delete the DS data
+	Hosts                         []Host         `gorm:"many2many:domain_hosts;"`
 }
 
 // TableName returns the table name for the Domain model
@@ -69,6 +72,7 @@ func ToDomain(dbDom *Domain) *entities.Domain {
 	d.Status = dbDom.DomainStatus
 	d.RGPStatus = dbDom.DomainRGPStatus
 	d.GrandFathering = dbDom.DomainGrandFathering
+	d.RestoreReport = dbDom.RestoreReport
 	if dbDom.CrRr != nil {
 		d.CrRr = entities.ClIDType(*dbDom.CrRr)
 	}
@@ -76,6 +80,11 @@ func ToDomain(dbDom *Domain) *entities.Domain {
 		d.UpRr = entities.ClIDType(*dbDom.UpRr)
 	}
 
+	d.SecDNS.MaxSigLife = dbDom.MaxSigLife
+	for _, ds := range dbDom.DSData {
+		d.SecDNS.DSData = append(d.SecDNS.DSData, ToDomainDSData(&ds))
+	}
+
 	for _, h := range dbDom.Hosts {
 		d.Hosts = append(d.Hosts, ToHost(&h))
 	}
@@ -120,6 +129,7 @@ func ToDBDomain(d *entities.Domain) *Domain {
 	dbDomain.DomainStatus = d.Status
 	dbDomain.DomainRGPStatus = d.RGPStatus
 	dbDomain.DomainGrandFathering = d.GrandFathering
+	dbDomain.RestoreReport = d.RestoreReport
 
 	if d.CrRr != entities.ClIDType("") {
 		rar := d.CrRr.String()
@@ -130,6 +140,11 @@ func ToDBDomain(d *entities.Domain) *Domain {
 		dbDomain.UpRr = &rar
 	}
 
+	dbDomain.MaxSigLife = d.SecDNS.MaxSigLife
+	for _, ds := range d.SecDNS.DSData {
+		dbDomain.DSData = append(dbDomain.DSData, *ToDBDomainDSData(ds, dbDomain.RoID))
+	}
+
 	for _, h := range d.Hosts {
 		dbDomain.Hosts = append(dbDomain.Hosts, *ToDBHost(h))
 	}
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// DomainApplication is the GORM representation of a DomainApplication
+type DomainApplication struct {
+	ID            string `gorm:"type:uuid;primaryKey"`
+	DomainName    string `gorm:"not null;index"`
+	PhaseName     string `gorm:"not null;index"`
+	ClID          string `gorm:"not null;index"`
+	Status        string `gorm:"not null"`
+	AuthInfo      string
+	RegistrantID  string
+	AdminID       string
+	TechID        string
+	BillingID     string
+	HostNames     []string `gorm:"serializer:json"`
+	Years         int
+	Mark          string
+	NoticeID      string
+	DomainRoID    string
+	Reason        string
+	CorrelationID string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// TableName returns the table name for the DomainApplication model
+func (DomainApplication) TableName() string {
+	return "domain_applications"
+}
+
+// ToDBDomainApplication converts a DomainApplication entity to a GORM DomainApplication
+func ToDBDomainApplication(a *entities.DomainApplication) *DomainApplication {
+	return &DomainApplication{
+		ID:            a.ID.String(),
+		DomainName:    a.DomainName.String(),
+		PhaseName:     a.PhaseName.String(),
+		ClID:          a.ClID.String(),
+		Status:        string(a.Status),
+		AuthInfo:      a.AuthInfo.String(),
+		RegistrantID:  a.RegistrantID.String(),
+		AdminID:       a.AdminID.String(),
+		TechID:        a.TechID.String(),
+		BillingID:     a.BillingID.String(),
+		HostNames:     a.HostNames,
+		Years:         a.Years,
+		Mark:          a.Mark,
+		NoticeID:      a.NoticeID,
+		DomainRoID:    a.DomainRoID.String(),
+		Reason:        a.Reason,
+		CorrelationID: a.CorrelationID,
+		CreatedAt:     a.CreatedAt,
+		UpdatedAt:     a.UpdatedAt,
+	}
+}
+
+// FromDBDomainApplication converts a GORM DomainApplication to a DomainApplication entity
+func FromDBDomainApplication(dba *DomainApplication) *entities.DomainApplication {
+	return &entities.DomainApplication{
+		ID:            uuid.MustParse(dba.ID),
+		DomainName:    entities.DomainName(dba.DomainName),
+		PhaseName:     entities.ClIDType(dba.PhaseName),
+		ClID:          entities.ClIDType(dba.ClID),
+		Status:        entities.ApplicationStatus(dba.Status),
+		AuthInfo:      entities.AuthInfoType(dba.AuthInfo),
+		RegistrantID:  entities.ClIDType(dba.RegistrantID),
+		AdminID:       entities.ClIDType(dba.AdminID),
+		TechID:        entities.ClIDType(dba.TechID),
+		BillingID:     entities.ClIDType(dba.BillingID),
+		HostNames:     dba.HostNames,
+		Years:         dba.Years,
+		Mark:          dba.Mark,
+		NoticeID:      dba.NoticeID,
+		DomainRoID:    entities.RoidType(dba.DomainRoID),
+		Reason:        dba.Reason,
+		CorrelationID: dba.CorrelationID,
+		CreatedAt:     dba.CreatedAt.UTC(),
+		UpdatedAt:     dba.UpdatedAt.UTC(),
+	}
+}
@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"gorm.io/gorm"
+)
+
+// DomainApplicationRepository implements the DomainApplicationRepository interface
+type DomainApplicationRepository struct {
+	db *gorm.DB
+}
+
+// NewDomainApplicationRepository returns a new DomainApplicationRepository
+func NewDomainApplicationRepository(db *gorm.DB) *DomainApplicationRepository {
+	return &DomainApplicationRepository{
+		db: db,
+	}
+}
+
+// Create stores a new domain application
+func (r *DomainApplicationRepository) Create(ctx context.Context, a *entities.DomainApplication) (*entities.DomainApplication, error) {
+	dba := ToDBDomainApplication(a)
+	err := r.db.WithContext(ctx).Create(dba).Error
+	if err != nil {
+		return nil, err
+	}
+	return FromDBDomainApplication(dba), nil
+}
+
+// Update updates an existing domain application
+func (r *DomainApplicationRepository) Update(ctx context.Context, a *entities.DomainApplication) (*entities.DomainApplication, error) {
+	dba := ToDBDomainApplication(a)
+	err := r.db.WithContext(ctx).Save(dba).Error
+	if err != nil {
+		return nil, err
+	}
+	return FromDBDomainApplication(dba), nil
+}
+
+// GetByID retrieves a domain application by its ID
+func (r *DomainApplicationRepository) GetByID(ctx context.Context, id string) (*entities.DomainApplication, error) {
+	dba := &DomainApplication{}
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(dba).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrDomainApplicationNotFound
+		}
+		return nil, err
+	}
+	return FromDBDomainApplication(dba), nil
+}
+
+// Delete deletes a domain application by its ID, deleting an application that does not exist is idempotent
+func (r *DomainApplicationRepository) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&DomainApplication{}).Error
+}
+
+// Count returns the number of domain applications matching the filter
+func (r *DomainApplicationRepository) Count(ctx context.Context, filter queries.ListDomainApplicationsFilter) (int64, error) {
+	var count int64
+	err := setDomainApplicationFilters(r.db.WithContext(ctx).Model(&DomainApplication{}), filter).Count(&count).Error
+	return count, err
+}
+
+// List returns a list of domain applications ordered by ID and a cursor for pagination
+func (r *DomainApplicationRepository) List(ctx context.Context, params queries.ListItemsQuery) ([]*entities.DomainApplication, string, error) {
+	// Get a query object ordering by ID (PK used for cursor pagination)
+	dbQuery := r.db.WithContext(ctx).Order("id ASC")
+
+	// Add cursor pagination if a cursor is provided
+	if params.PageCursor != "" {
+		dbQuery = dbQuery.Where("id > ?", params.PageCursor)
+	}
+
+	// Add filters if provided
+	if params.Filter != nil {
+		filter, ok := params.Filter.(queries.ListDomainApplicationsFilter)
+		if !ok {
+			return nil, "", ErrInvalidFilterType
+		}
+		dbQuery = setDomainApplicationFilters(dbQuery, filter)
+	}
+
+	// Fetch one more than the limit to determine if there are more results
+	dbQuery = dbQuery.Limit(params.PageSize + 1)
+
+	var dbas []*DomainApplication
+	if err := dbQuery.Find(&dbas).Error; err != nil {
+		return nil, "", err
+	}
+
+	// Check if there are more results
+	hasMore := len(dbas) == params.PageSize+1
+	if hasMore {
+		// Return only up to the limit
+		dbas = dbas[:params.PageSize]
+	}
+
+	applications := make([]*entities.DomainApplication, len(dbas))
+	for i, dba := range dbas {
+		applications[i] = FromDBDomainApplication(dba)
+	}
+
+	// Set the cursor to the last ID in the list
+	var newCursor string
+	if hasMore {
+		newCursor = applications[len(applications)-1].ID.String()
+	}
+
+	return applications, newCursor, nil
+}
+
+// setDomainApplicationFilters adds the filters to the query
+func setDomainApplicationFilters(dbQuery *gorm.DB, filter queries.ListDomainApplicationsFilter) *gorm.DB {
+	if filter.DomainNameEquals != "" {
+		dbQuery = dbQuery.Where("domain_name = ?", filter.DomainNameEquals)
+	}
+	if filter.DomainNameLike != "" {
+		dbQuery = dbQuery.Where("domain_name ILIKE ?", "%"+filter.DomainNameLike+"%")
+	}
+	if filter.ClIDEquals != "" {
+		dbQuery = dbQuery.Where("cl_id = ?", filter.ClIDEquals)
+	}
+	if filter.PhaseNameEquals != "" {
+		dbQuery = dbQuery.Where("phase_name = ?", filter.PhaseNameEquals)
+	}
+	if filter.StatusEquals != "" {
+		dbQuery = dbQuery.Where("status = ?", filter.StatusEquals)
+	}
+	return dbQuery
+}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type DomainApplicationSuite struct {
+	suite.Suite
+	db *gorm.DB
+}
+
+func TestDomainApplicationSuite(t *testing.T) {
+	suite.Run(t, new(DomainApplicationSuite))
+}
+
+func (s *DomainApplicationSuite) SetupSuite() {
+	s.db = setupTestDB()
+}
+
+func (s *DomainApplicationSuite) TestDomainApplication_Lifecycle() {
+	repo := NewDomainApplicationRepository(s.db)
+	ctx := context.Background()
+
+	app, err := entities.NewDomainApplication("application.repotest", "landrush", "applicantRar", 1)
+	s.Require().NoError(err)
+	app.AddHostName("ns1.application.repotest")
+
+	_, err = repo.GetByID(ctx, app.ID.String())
+	s.Require().ErrorIs(err, entities.ErrDomainApplicationNotFound)
+
+	created, err := repo.Create(ctx, app)
+	s.Require().NoError(err)
+	s.Require().Equal(entities.ApplicationStatusPendingValidation, created.Status)
+
+	s.Require().NoError(created.SetStatus(entities.ApplicationStatusValidated, ""))
+	_, err = repo.Update(ctx, created)
+	s.Require().NoError(err)
+
+	read, err := repo.GetByID(ctx, app.ID.String())
+	s.Require().NoError(err)
+	s.Require().Equal(entities.ApplicationStatusValidated, read.Status)
+	s.Require().Equal([]string{"ns1.application.repotest"}, read.HostNames)
+
+	count, err := repo.Count(ctx, queries.ListDomainApplicationsFilter{DomainNameEquals: "application.repotest", StatusEquals: "validated"})
+	s.Require().NoError(err)
+	s.Require().Equal(int64(1), count)
+
+	list, _, err := repo.List(ctx, queries.ListItemsQuery{PageSize: 10, Filter: queries.ListDomainApplicationsFilter{ClIDEquals: "applicantRar", PhaseNameEquals: "landrush"}})
+	s.Require().NoError(err)
+	s.Require().NotEmpty(list)
+
+	s.Require().NoError(repo.Delete(ctx, app.ID.String()))
+	_, err = repo.GetByID(ctx, app.ID.String())
+	s.Require().ErrorIs(err, entities.ErrDomainApplicationNotFound)
+}
@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomainApplication_TableName(t *testing.T) {
+	require.Equal(t, "domain_applications", DomainApplication{}.TableName())
+}
+
+func TestDomainApplication_Mapping(t *testing.T) {
+	app := &entities.DomainApplication{
+		ID:            uuid.New(),
+		DomainName:    "example.com",
+		PhaseName:     "landrush",
+		ClID:          "ClID-1",
+		Status:        entities.ApplicationStatusPendingValidation,
+		AuthInfo:      "STr0ngP@ssw0rd",
+		RegistrantID:  "registrant",
+		AdminID:       "admin",
+		HostNames:     []string{"ns1.example.net", "ns2.example.net"},
+		Years:         2,
+		Mark:          "PD94bWwgdmVyc2lvbj0iMS4wIj8+",
+		NoticeID:      "abc123",
+		CorrelationID: "trace-1",
+		CreatedAt:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	require.Equal(t, app, FromDBDomainApplication(ToDBDomainApplication(app)))
+
+	require.NoError(t, app.SetStatus(entities.ApplicationStatusValidated, ""))
+	require.NoError(t, app.Allocate("1234_DOM-APEX"))
+	require.Equal(t, app, FromDBDomainApplication(ToDBDomainApplication(app)))
+}
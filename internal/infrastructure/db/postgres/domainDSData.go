@@ -0,0 +1,38 @@
+package postgres
+
+import "github.com/onasunnymorning/domain-os/internal/domain/entities"
+
+// DomainDSData is the GORM model for the domain_ds_data table
+type DomainDSData struct {
+	DomainRoID int64  `gorm:"primaryKey"`
+	KeyTag     int    `gorm:"primaryKey"`
+	Alg        int    `gorm:"primaryKey"`
+	DigestType int    `gorm:"primaryKey"`
+	Digest     string `gorm:"primaryKey"`
+}
+
+// TableName returns the table name for the DomainDSData model
+func (DomainDSData) TableName() string {
+	return "domain_ds_data"
+}
+
+// ToDomainDSData converts a postgres.DomainDSData to an entities.DomainDSData
+func ToDomainDSData(dbDSData *DomainDSData) entities.DomainDSData {
+	return entities.DomainDSData{
+		KeyTag:     uint16(dbDSData.KeyTag),
+		Alg:        uint8(dbDSData.Alg),
+		DigestType: uint8(dbDSData.DigestType),
+		Digest:     dbDSData.Digest,
+	}
+}
+
+// ToDBDomainDSData converts an entities.DomainDSData to a postgres.DomainDSData
+func ToDBDomainDSData(ds entities.DomainDSData, domainRoID int64) *DomainDSData {
+	return &DomainDSData{
+		DomainRoID: domainRoID,
+		KeyTag:     int(ds.KeyTag),
+		Alg:        int(ds.Alg),
+		DigestType: int(ds.DigestType),
+		Digest:     ds.Digest,
+	}
+}
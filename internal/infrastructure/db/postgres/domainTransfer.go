@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// DomainTransfer is the GORM representation of a DomainTransfer
+type DomainTransfer struct {
+	ID               string `gorm:"type:uuid;primaryKey"`
+	DomainRoID       string `gorm:"not null"`
+	DomainName       string `gorm:"not null;index"`
+	GainingRegistrar string `gorm:"not null;index"`
+	LosingRegistrar  string `gorm:"not null;index"`
+	Status           string `gorm:"not null"`
+	Years            int
+	ExpiryDate       time.Time
+	AcceptDate       *time.Time
+	Reason           string
+	CorrelationID    string
+	Notes            string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// TableName returns the table name for the DomainTransfer model
+func (DomainTransfer) TableName() string {
+	return "domain_transfers"
+}
+
+// ToDBDomainTransfer converts a DomainTransfer entity to a GORM DomainTransfer
+func ToDBDomainTransfer(t *entities.DomainTransfer) *DomainTransfer {
+	dbt := &DomainTransfer{
+		ID:               t.ID.String(),
+		DomainRoID:       t.DomainRoiD.String(),
+		DomainName:       t.DomainName.String(),
+		GainingRegistrar: t.GainingRegistrar.String(),
+		LosingRegistrar:  t.LosingRegistrar.String(),
+		Status:           string(t.Status),
+		Years:            t.Years,
+		ExpiryDate:       t.ExpiryDate,
+		Reason:           t.Reason,
+		CorrelationID:    t.CorrelationID,
+		Notes:            t.Notes,
+		CreatedAt:        t.CreatedAt,
+		UpdatedAt:        t.UpdatedAt,
+	}
+	if !t.AcceptDate.IsZero() {
+		acceptDate := t.AcceptDate
+		dbt.AcceptDate = &acceptDate
+	}
+	return dbt
+}
+
+// FromDBDomainTransfer converts a GORM DomainTransfer to a DomainTransfer entity
+func FromDBDomainTransfer(dbt *DomainTransfer) *entities.DomainTransfer {
+	t := &entities.DomainTransfer{
+		ID:               uuid.MustParse(dbt.ID),
+		DomainRoiD:       entities.RoidType(dbt.DomainRoID),
+		DomainName:       entities.DomainName(dbt.DomainName),
+		GainingRegistrar: entities.ClIDType(dbt.GainingRegistrar),
+		LosingRegistrar:  entities.ClIDType(dbt.LosingRegistrar),
+		Status:           entities.TransferStatus(dbt.Status),
+		Years:            dbt.Years,
+		ExpiryDate:       dbt.ExpiryDate.UTC(),
+		Reason:           dbt.Reason,
+		CorrelationID:    dbt.CorrelationID,
+		Notes:            dbt.Notes,
+		CreatedAt:        dbt.CreatedAt.UTC(),
+		UpdatedAt:        dbt.UpdatedAt.UTC(),
+	}
+	if dbt.AcceptDate != nil {
+		t.AcceptDate = dbt.AcceptDate.UTC()
+	}
+	return t
+}
@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"gorm.io/gorm"
+)
+
+// DomainTransferRepository implements the DomainTransferRepository interface
+type DomainTransferRepository struct {
+	db *gorm.DB
+}
+
+// NewDomainTransferRepository returns a new DomainTransferRepository
+func NewDomainTransferRepository(db *gorm.DB) *DomainTransferRepository {
+	return &DomainTransferRepository{
+		db: db,
+	}
+}
+
+// Create stores a new domain transfer
+func (r *DomainTransferRepository) Create(ctx context.Context, t *entities.DomainTransfer) (*entities.DomainTransfer, error) {
+	dbt := ToDBDomainTransfer(t)
+	err := r.db.WithContext(ctx).Create(dbt).Error
+	if err != nil {
+		return nil, err
+	}
+	return FromDBDomainTransfer(dbt), nil
+}
+
+// Update updates an existing domain transfer
+func (r *DomainTransferRepository) Update(ctx context.Context, t *entities.DomainTransfer) (*entities.DomainTransfer, error) {
+	dbt := ToDBDomainTransfer(t)
+	err := r.db.WithContext(ctx).Save(dbt).Error
+	if err != nil {
+		return nil, err
+	}
+	return FromDBDomainTransfer(dbt), nil
+}
+
+// GetByID retrieves a domain transfer by its ID
+func (r *DomainTransferRepository) GetByID(ctx context.Context, id string) (*entities.DomainTransfer, error) {
+	dbt := &DomainTransfer{}
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(dbt).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrTransferNotFound
+		}
+		return nil, err
+	}
+	return FromDBDomainTransfer(dbt), nil
+}
+
+// GetLatestByDomainName retrieves the most recent transfer of the domain with the provided name
+func (r *DomainTransferRepository) GetLatestByDomainName(ctx context.Context, name string) (*entities.DomainTransfer, error) {
+	dbt := &DomainTransfer{}
+	err := r.db.WithContext(ctx).Where("domain_name = ?", name).Order("created_at DESC").First(dbt).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrTransferNotFound
+		}
+		return nil, err
+	}
+	return FromDBDomainTransfer(dbt), nil
+}
+
+// Count returns the number of domain transfers matching the filter
+func (r *DomainTransferRepository) Count(ctx context.Context, filter queries.ListDomainTransfersFilter) (int64, error) {
+	var count int64
+	err := setDomainTransferFilters(r.db.WithContext(ctx).Model(&DomainTransfer{}), filter).Count(&count).Error
+	return count, err
+}
+
+// List returns a list of domain transfers ordered by ID and a cursor for pagination
+func (r *DomainTransferRepository) List(ctx context.Context, params queries.ListItemsQuery) ([]*entities.DomainTransfer, string, error) {
+	// Get a query object ordering by ID (PK used for cursor pagination)
+	dbQuery := r.db.WithContext(ctx).Order("id ASC")
+
+	// Add cursor pagination if a cursor is provided
+	if params.PageCursor != "" {
+		dbQuery = dbQuery.Where("id > ?", params.PageCursor)
+	}
+
+	// Add filters if provided
+	if params.Filter != nil {
+		filter, ok := params.Filter.(queries.ListDomainTransfersFilter)
+		if !ok {
+			return nil, "", ErrInvalidFilterType
+		}
+		dbQuery = setDomainTransferFilters(dbQuery, filter)
+	}
+
+	// Fetch one more than the limit to determine if there are more results
+	dbQuery = dbQuery.Limit(params.PageSize + 1)
+
+	var dbts []*DomainTransfer
+	if err := dbQuery.Find(&dbts).Error; err != nil {
+		return nil, "", err
+	}
+
+	// Check if there are more results
+	hasMore := len(dbts) == params.PageSize+1
+	if hasMore {
+		// Return only up to the limit
+		dbts = dbts[:params.PageSize]
+	}
+
+	transfers := make([]*entities.DomainTransfer, len(dbts))
+	for i, dbt := range dbts {
+		transfers[i] = FromDBDomainTransfer(dbt)
+	}
+
+	// Set the cursor to the last ID in the list
+	var newCursor string
+	if hasMore {
+		newCursor = transfers[len(transfers)-1].ID.String()
+	}
+
+	return transfers, newCursor, nil
+}
+
+// setDomainTransferFilters adds the filters to the query
+func setDomainTransferFilters(dbQuery *gorm.DB, filter queries.ListDomainTransfersFilter) *gorm.DB {
+	if filter.DomainNameLike != "" {
+		dbQuery = dbQuery.Where("domain_name ILIKE ?", "%"+filter.DomainNameLike+"%")
+	}
+	if filter.GainingRegistrarEquals != "" {
+		dbQuery = dbQuery.Where("gaining_registrar = ?", filter.GainingRegistrarEquals)
+	}
+	if filter.LosingRegistrarEquals != "" {
+		dbQuery = dbQuery.Where("losing_registrar = ?", filter.LosingRegistrarEquals)
+	}
+	if filter.StatusEquals != "" {
+		dbQuery = dbQuery.Where("status = ?", filter.StatusEquals)
+	}
+	if !filter.ExpiresBefore.IsZero() {
+		dbQuery = dbQuery.Where("expiry_date < ?", filter.ExpiresBefore)
+	}
+	return dbQuery
+}
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type DomainTransferSuite struct {
+	suite.Suite
+	db *gorm.DB
+}
+
+func TestDomainTransferSuite(t *testing.T) {
+	suite.Run(t, new(DomainTransferSuite))
+}
+
+func (s *DomainTransferSuite) SetupSuite() {
+	s.db = setupTestDB()
+}
+
+func (s *DomainTransferSuite) TestDomainTransfer_Lifecycle() {
+	repo := NewDomainTransferRepository(s.db)
+	ctx := context.Background()
+
+	_, err := repo.GetLatestByDomainName(ctx, "transfer.repotest")
+	s.Require().ErrorIs(err, entities.ErrTransferNotFound)
+
+	transfer := entities.NewDomainTransfer(5)
+	transfer.DomainRoiD = "1234_DOM-APEX"
+	transfer.DomainName = "transfer.repotest"
+	transfer.GainingRegistrar = "gainingRar"
+	transfer.LosingRegistrar = "losingRar"
+	created, err := repo.Create(ctx, &transfer)
+	s.Require().NoError(err)
+	s.Require().Equal(entities.TransferStatusPending, created.Status)
+
+	latest, err := repo.GetLatestByDomainName(ctx, "transfer.repotest")
+	s.Require().NoError(err)
+	s.Require().Equal(created.ID, latest.ID)
+
+	s.Require().NoError(latest.Finalize(entities.FinalizeTransferCommand{Status: entities.TransferStatusApproved}))
+	_, err = repo.Update(ctx, latest)
+	s.Require().NoError(err)
+
+	read, err := repo.GetByID(ctx, created.ID.String())
+	s.Require().NoError(err)
+	s.Require().Equal(entities.TransferStatusApproved, read.Status)
+	s.Require().False(read.AcceptDate.IsZero())
+
+	count, err := repo.Count(ctx, queries.ListDomainTransfersFilter{DomainNameLike: "transfer.repotest", StatusEquals: "approved"})
+	s.Require().NoError(err)
+	s.Require().Equal(int64(1), count)
+
+	list, _, err := repo.List(ctx, queries.ListItemsQuery{PageSize: 10, Filter: queries.ListDomainTransfersFilter{GainingRegistrarEquals: "gainingRar"}})
+	s.Require().NoError(err)
+	s.Require().NotEmpty(list)
+
+	// The transfer expires after 5 days
+	count, err = repo.Count(ctx, queries.ListDomainTransfersFilter{DomainNameLike: "transfer.repotest", ExpiresBefore: time.Now().UTC()})
+	s.Require().NoError(err)
+	s.Require().Equal(int64(0), count)
+	count, err = repo.Count(ctx, queries.ListDomainTransfersFilter{DomainNameLike: "transfer.repotest", ExpiresBefore: time.Now().UTC().AddDate(0, 0, 6)})
+	s.Require().NoError(err)
+	s.Require().Equal(int64(1), count)
+
+	s.Require().NoError(s.db.Where("id = ?", created.ID.String()).Delete(&DomainTransfer{}).Error)
+}
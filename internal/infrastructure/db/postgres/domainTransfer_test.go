@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomainTransfer_TableName(t *testing.T) {
+	require.Equal(t, "domain_transfers", DomainTransfer{}.TableName())
+}
+
+func TestDomainTransfer_Mapping(t *testing.T) {
+	transfer := &entities.DomainTransfer{
+		ID:               uuid.New(),
+		DomainRoiD:       "1234_DOM-APEX",
+		DomainName:       "example.com",
+		GainingRegistrar: "ClID-1",
+		LosingRegistrar:  "ClID-2",
+		Status:           entities.TransferStatusPending,
+		Years:            1,
+		CreatedAt:        time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		ExpiryDate:       time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:        time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		CorrelationID:    "trace-1",
+	}
+
+	dbt := ToDBDomainTransfer(transfer)
+	require.Nil(t, dbt.AcceptDate)
+	require.Equal(t, transfer, FromDBDomainTransfer(dbt))
+
+	transfer.Status = entities.TransferStatusApproved
+	transfer.AcceptDate = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, transfer, FromDBDomainTransfer(ToDBDomainTransfer(transfer)))
+}
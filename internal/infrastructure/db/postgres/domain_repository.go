@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgconn"
@@ -11,6 +12,7 @@ import (
 	"github.com/onasunnymorning/domain-os/internal/application/queries"
 	"github.com/onasunnymorning/domain-os/internal/domain/entities"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // DomainRepository is the postgres implementation of the DomainRepository Interface
@@ -28,15 +30,36 @@ func (dr *DomainRepository) Create(ctx context.Context, d *entities.Domain) (*en
 	dbDomain := ToDBDomain(d)
 	err := dr.db.WithContext(ctx).Create(dbDomain).Error
 	if err != nil {
-		var perr *pgconn.PgError
-		if errors.As(err, &perr) && perr.Code == "23505" {
-			return nil, entities.ErrDomainAlreadyExists
+		return nil, toCreateDomainError(err)
+	}
+	return ToDomain(dbDomain), nil
+}
+
+// CreateAndRedeemAllocationToken creates a new domain and redeems the allocation token it was registered with in a single transaction.
+// It returns ErrAllocationTokenRedeemed and does not create the domain if the token can't be redeemed anymore, e.g. because a concurrent registration redeemed the single use token first.
+func (dr *DomainRepository) CreateAndRedeemAllocationToken(ctx context.Context, d *entities.Domain, token string) (*entities.Domain, error) {
+	dbDomain := ToDBDomain(d)
+	err := dr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := redeemAllocationToken(tx, token, time.Now().UTC()); err != nil {
+			return err
 		}
-		return nil, err
+		return tx.Create(dbDomain).Error
+	})
+	if err != nil {
+		return nil, toCreateDomainError(err)
 	}
 	return ToDomain(dbDomain), nil
 }
 
+// toCreateDomainError maps a unique violation on creating a domain to ErrDomainAlreadyExists
+func toCreateDomainError(err error) error {
+	var perr *pgconn.PgError
+	if errors.As(err, &perr) && perr.Code == "23505" {
+		return entities.ErrDomainAlreadyExists
+	}
+	return err
+}
+
 // Bulk Create Creates multiple domains in the repository, useful when importing data. Does not persist Hosts if present
 func (r *DomainRepository) BulkCreate(ctx context.Context, doms []*entities.Domain) error {
 	dbdoms := make([]*Domain, len(doms))
@@ -51,9 +74,9 @@ func (dr *DomainRepository) GetDomainByID(ctx context.Context, id int64, preload
 	var err error
 	d := &Domain{}
 	if preloadHosts {
-		err = dr.db.WithContext(ctx).Preload("Hosts").First(d, id).Error
+		err = dr.db.WithContext(ctx).Preload("DSData").Preload("Hosts").First(d, id).Error
 	} else {
-		err = dr.db.WithContext(ctx).First(d, id).Error
+		err = dr.db.WithContext(ctx).Preload("DSData").First(d, id).Error
 	}
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -69,9 +92,9 @@ func (dr *DomainRepository) GetDomainByName(ctx context.Context, name string, pr
 	var err error
 	d := &Domain{}
 	if preloadHosts {
-		err = dr.db.WithContext(ctx).Preload("Hosts").Where("name = ?", name).First(d).Error
+		err = dr.db.WithContext(ctx).Preload("DSData").Preload("Hosts").Where("name = ?", name).First(d).Error
 	} else {
-		err = dr.db.WithContext(ctx).Where("name = ?", name).First(d).Error
+		err = dr.db.WithContext(ctx).Preload("DSData").Where("name = ?", name).First(d).Error
 	}
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -82,10 +105,112 @@ func (dr *DomainRepository) GetDomainByName(ctx context.Context, name string, pr
 	return ToDomain(d), nil
 }
 
-// UpdateDomain updates a domain in the database
+// UpdateDomain updates a domain in the database. The DS data of the domain is replaced by the DS data of the provided domain.
 func (dr *DomainRepository) UpdateDomain(ctx context.Context, d *entities.Domain) (*entities.Domain, error) {
 	dbDomain := ToDBDomain(d)
-	err := dr.db.WithContext(ctx).Save(dbDomain).Error
+	err := dr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return saveDomain(tx, dbDomain)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ToDomain(dbDomain), nil
+}
+
+// UpdateDomainAndCreateTransfer updates a domain and creates its transfer in a single transaction, so a domain is never pendingTransfer without a pending transfer
+func (dr *DomainRepository) UpdateDomainAndCreateTransfer(ctx context.Context, d *entities.Domain, t *entities.DomainTransfer) (*entities.Domain, *entities.DomainTransfer, error) {
+	dbDomain := ToDBDomain(d)
+	dbTransfer := ToDBDomainTransfer(t)
+	err := dr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := saveDomain(tx, dbDomain); err != nil {
+			return err
+		}
+		return tx.Create(dbTransfer).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return ToDomain(dbDomain), FromDBDomainTransfer(dbTransfer), nil
+}
+
+// UpdateDomainAndTransfer updates a domain and its transfer in a single transaction, so the domain and the status of the transfer can't get out of sync when the transfer is finalized
+func (dr *DomainRepository) UpdateDomainAndTransfer(ctx context.Context, d *entities.Domain, t *entities.DomainTransfer) (*entities.Domain, *entities.DomainTransfer, error) {
+	dbDomain := ToDBDomain(d)
+	dbTransfer := ToDBDomainTransfer(t)
+	err := dr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := saveDomain(tx, dbDomain); err != nil {
+			return err
+		}
+		return tx.Save(dbTransfer).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return ToDomain(dbDomain), FromDBDomainTransfer(dbTransfer), nil
+}
+
+// saveDomain saves the domain in the transaction, its DS data is replaced by the DS data of the provided domain
+func saveDomain(tx *gorm.DB, dbDomain *Domain) error {
+	if err := tx.Where("domain_ro_id = ?", dbDomain.RoID).Delete(&DomainDSData{}).Error; err != nil {
+		return err
+	}
+	return tx.Save(dbDomain).Error
+}
+
+// UpdateDomainAndHosts reads the domain for update and applies the update to it, then saves the domain together with its host associations in a single transaction.
+// The domain row is locked until the transaction ends, so concurrent updates are applied one after the other and each one sees the changes of the previous one.
+// The associations with the hosts the update removed are deleted and the associations with the hosts of the domain are saved. The hosts of the domain are flagged as linked
+// and the removed hosts that are no longer associated with any domain are unlinked. Nothing is saved if the update returns an error.
+func (dr *DomainRepository) UpdateDomainAndHosts(ctx context.Context, name string, update func(d *entities.Domain) (removedHosts []*entities.Host, err error)) (*entities.Domain, error) {
+	dbDomain := &Domain{}
+	err := dr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("DSData").Preload("Hosts").Where("name = ?", name).First(dbDomain).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return entities.ErrDomainNotFound
+			}
+			return err
+		}
+		d := ToDomain(dbDomain)
+		removedHosts, err := update(d)
+		if err != nil {
+			return err
+		}
+		dbDomain = ToDBDomain(d)
+		if err := tx.Where("domain_ro_id = ?", dbDomain.RoID).Delete(&DomainDSData{}).Error; err != nil {
+			return err
+		}
+		removedRoIDs := make([]int64, 0, len(removedHosts))
+		removed := make([]*Host, 0, len(removedHosts))
+		for _, h := range removedHosts {
+			roid, err := h.RoID.Int64()
+			if err != nil {
+				return err
+			}
+			removedRoIDs = append(removedRoIDs, roid)
+			removed = append(removed, &Host{RoID: roid})
+		}
+		if len(removed) > 0 {
+			if err := tx.Model(&Domain{RoID: dbDomain.RoID}).Association("Hosts").Delete(removed); err != nil {
+				return err
+			}
+		}
+		if err := tx.Save(dbDomain).Error; err != nil {
+			return err
+		}
+		for _, h := range dbDomain.Hosts {
+			err := tx.Model(&Host{RoID: h.RoID}).Updates(map[string]interface{}{"linked": true, "in_bailiwick": h.InBailiwick}).Error
+			if err != nil {
+				return err
+			}
+		}
+		if len(removedRoIDs) > 0 {
+			return tx.Model(&Host{}).
+				Where("ro_id IN ? AND NOT EXISTS (SELECT 1 FROM domain_hosts dh WHERE dh.host_ro_id = hosts.ro_id)", removedRoIDs).
+				Update("linked", false).Error
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -94,12 +219,30 @@ func (dr *DomainRepository) UpdateDomain(ctx context.Context, d *entities.Domain
 
 // DeleteDomain deletes a domain from the database by its id
 func (dr *DomainRepository) DeleteDomainByID(ctx context.Context, id int64) error {
-	return dr.db.WithContext(ctx).Delete(&Domain{}, id).Error
+	return dr.deleteDomain(ctx, "ro_id = ?", id)
 }
 
 // DeleteDomain deletes a domain from the database by its name
 func (dr *DomainRepository) DeleteDomainByName(ctx context.Context, name string) error {
-	return dr.db.WithContext(ctx).Where("name = ?", name).Delete(&Domain{}).Error
+	return dr.deleteDomain(ctx, "name = ?", name)
+}
+
+// deleteDomain deletes the domain matching the condition and records the deletion for escrow in the same transaction.
+// Deleting a domain that does not exist is not an error.
+func (dr *DomainRepository) deleteDomain(ctx context.Context, condition string, value any) error {
+	return dr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		dbDomain := &Domain{}
+		err := tx.Where(condition, value).Limit(1).Find(dbDomain).Error
+		if err != nil || dbDomain.RoID == 0 {
+			return err
+		}
+		err = tx.Delete(&Domain{}, dbDomain.RoID).Error
+		if err != nil {
+			return err
+		}
+		roid, _ := entities.NewRoidType(dbDomain.RoID, entities.RoidTypeDomain)
+		return recordDeletedObject(tx, entities.DOMAIN_URI, dbDomain.TLDName, dbDomain.Name, roid.String())
+	})
 }
 
 // ListDomains retrieves domains from the database applying optional filters and cursor-based pagination.
@@ -112,7 +255,7 @@ func (dr *DomainRepository) DeleteDomainByName(ctx context.Context, name string)
 // a new cursor is set to the ro_id of the last returned domain, enabling further pagination.
 func (dr *DomainRepository) ListDomains(ctx context.Context, params queries.ListItemsQuery) ([]*entities.Domain, string, error) {
 	// Create a query and order by our pk
-	dbQuery := dr.db.WithContext(ctx).Order("ro_id ASC")
+	dbQuery := dr.db.WithContext(ctx).Preload("DSData").Order("ro_id ASC")
 
 	// Add cursor pagination if a cursor is provided
 	if params.PageCursor != "" {
@@ -184,8 +327,18 @@ type ActiveDomainQueryResult struct {
 	Host   string
 }
 
-// GetActiveDomainsWithHosts gets the domains that are flagged as active and their associated hosts
-// This data is used to build the NS records for a given TLD
+// ActiveDomainDSQueryResult holds a DS record of an active domain
+type ActiveDomainDSQueryResult struct {
+	Domain     string
+	KeyTag     int
+	Alg        int
+	DigestType int
+	Digest     string
+}
+
+// GetActiveDomainsWithHosts gets the domains that are flagged as active and their associated hosts.
+// Domains that are pending create (e.g. sunrise registrations awaiting validation of the mark), pending delete or have a hold status (clientHold or serverHold) are excluded as they should not be published in the DNS.
+// This data is used to build the NS records for a given TLD. The DS records of signed delegations are included after the NS records.
 func (dr *DomainRepository) GetActiveDomainsWithHosts(ctx context.Context, params queries.ActiveDomainsWithHostsQuery) ([]dns.RR, error) {
 	var queryResults []ActiveDomainQueryResult
 	err := dr.db.Raw(`
@@ -195,7 +348,11 @@ func (dr *DomainRepository) GetActiveDomainsWithHosts(ctx context.Context, param
 		LEFT JOIN hosts ho ON dh.host_ro_id = ho.ro_id
 		WHERE dom.tld_name = ?
 		AND dom.inactive = false
+		AND dom.pending_create = false
 		AND dom.pending_delete = false
+		AND dom.client_hold = false
+		AND dom.server_hold = false
+		ORDER BY dom.name, ho.name
 	`, params.TldName).Scan(&queryResults).Error
 	if err != nil {
 		return nil, err
@@ -211,6 +368,38 @@ func (dr *DomainRepository) GetActiveDomainsWithHosts(ctx context.Context, param
 		response[i] = ns
 	}
 
+	// Add the DS records of the active domains
+	var dsResults []ActiveDomainDSQueryResult
+	err = dr.db.Raw(`
+		SELECT dom.name AS domain, ds.key_tag, ds.alg, ds.digest_type, ds.digest
+		FROM public.domains dom
+		JOIN domain_ds_data ds ON ds.domain_ro_id = dom.ro_id
+		WHERE dom.tld_name = ?
+		AND dom.inactive = false
+		AND dom.pending_create = false
+		AND dom.pending_delete = false
+		AND dom.client_hold = false
+		AND dom.server_hold = false
+		ORDER BY dom.name, ds.key_tag
+	`, params.TldName).Scan(&dsResults).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range dsResults {
+		response = append(response, &dns.DS{
+			Hdr: dns.RR_Header{
+				Name:   dns.Fqdn(result.Domain),
+				Rrtype: dns.TypeDS,
+				Class:  dns.ClassINET,
+				Ttl:    3600,
+			},
+			KeyTag:     uint16(result.KeyTag),
+			Algorithm:  uint8(result.Alg),
+			DigestType: uint8(result.DigestType),
+			Digest:     result.Digest,
+		})
+	}
+
 	return response, nil
 }
 
@@ -223,19 +412,24 @@ type GlueQueryResult struct {
 	Version int
 }
 
-// GetActiveDomainGlue gets the glue records for a given TLD
+// GetActiveDomainGlue gets the glue records for a given TLD. These are the addresses of the hosts within the TLD that are used by the delegated domains (see GetActiveDomainsWithHosts).
 func (dr *DomainRepository) GetActiveDomainGlue(ctx context.Context, tld string) ([]dns.RR, error) {
 	var queryResults []GlueQueryResult
 	err := dr.db.Raw(`
-		SELECT ho.name AS host, address, version
+		SELECT DISTINCT ho.name AS host, address, version
 		FROM public.domains dom
-		LEFT JOIN domain_hosts dh ON dh.domain_ro_id = dom.ro_id
-		LEFT JOIN hosts ho ON dh.host_ro_id = ho.ro_id
-		LEFT JOIN host_addresses ha ON ho.ro_id = ha.host_ro_id 
+		JOIN domain_hosts dh ON dh.domain_ro_id = dom.ro_id
+		JOIN hosts ho ON dh.host_ro_id = ho.ro_id
+		JOIN host_addresses ha ON ho.ro_id = ha.host_ro_id
 		WHERE dom.tld_name = ?
 		AND dom.inactive = false
-		AND ho.in_bailiwick = true
-	`, tld).Scan(&queryResults).Error
+		AND dom.pending_create = false
+		AND dom.pending_delete = false
+		AND dom.client_hold = false
+		AND dom.server_hold = false
+		AND (ho.in_bailiwick = true OR ho.name LIKE ?)
+		ORDER BY ho.name, address
+	`, tld, "%."+tld).Scan(&queryResults).Error
 	if err != nil {
 		return nil, err
 	}
@@ -394,6 +588,12 @@ func setDomainFilters(dbQuery *gorm.DB, filter queries.ListDomainsFilter) (*gorm
 	if filter.NameEquals != "" {
 		dbQuery = dbQuery.Where("name = ?", filter.NameEquals)
 	}
+	if filter.NameMatches != "" {
+		dbQuery = dbQuery.Where("name ILIKE ?", wildcardToLikePattern(filter.NameMatches))
+	}
+	if filter.NsNameMatches != "" {
+		dbQuery = dbQuery.Where("ro_id IN (SELECT dh.domain_ro_id FROM domain_hosts dh JOIN hosts h ON h.ro_id = dh.host_ro_id WHERE h.name ILIKE ?)", wildcardToLikePattern(filter.NsNameMatches))
+	}
 	if filter.RoidGreaterThan != "" {
 		roidInt, err := getInt64RoidFromDomainRoidString(filter.RoidGreaterThan)
 		if err != nil {
@@ -420,6 +620,16 @@ func setDomainFilters(dbQuery *gorm.DB, filter queries.ListDomainsFilter) (*gorm
 	if !filter.CreatedAfter.IsZero() {
 		dbQuery = dbQuery.Where("created_at > ?", filter.CreatedAfter)
 	}
+	if !filter.UpdatedAfter.IsZero() {
+		dbQuery = dbQuery.Where("updated_at > ?", filter.UpdatedAfter)
+	}
 
 	return dbQuery, nil
 }
+
+// wildcardToLikePattern converts a search pattern where * matches any sequence of characters to a LIKE pattern.
+// The LIKE wildcards % and _ are escaped so they are matched literally.
+func wildcardToLikePattern(pattern string) string {
+	pattern = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(pattern)
+	return strings.ReplaceAll(pattern, "*", "%")
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"net/netip"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/miekg/dns"
 	"github.com/onasunnymorning/domain-os/internal/application/queries"
 	"github.com/onasunnymorning/domain-os/internal/domain/entities"
 	"github.com/stretchr/testify/suite"
@@ -153,6 +155,86 @@ func (s *DomainSuite) TestDomainRepository_CreateDomain() {
 
 }
 
+func (s *DomainSuite) TestDomainRepository_CreateAndRedeemAllocationToken() {
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	repo := NewDomainRepository(tx)
+
+	at, err := entities.NewAllocationToken("", "domaintesttld")
+	s.Require().NoError(err)
+	_, err = NewAllocationTokenRepository(tx).Create(context.Background(), at)
+	s.Require().NoError(err)
+
+	newDomain := func(roid, name string) *entities.Domain {
+		domain, err := entities.NewDomain(roid, name, "GoMamma", "STr0mgP@ZZ")
+		s.Require().NoError(err)
+		domain.ClID = "domaintestRar"
+		domain.RegistrantID = "myTestContact007"
+		domain.AdminID = "myTestContact007"
+		domain.TechID = "myTestContact007"
+		domain.BillingID = "myTestContact007"
+		return domain
+	}
+
+	_, err = repo.CreateAndRedeemAllocationToken(context.Background(), newDomain("1235_DOM-APEX", "token.domaintesttld"), at.Token)
+	s.Require().NoError(err)
+	read, err := NewAllocationTokenRepository(tx).GetByToken(context.Background(), at.Token)
+	s.Require().NoError(err)
+	s.Require().Equal(1, read.Redemptions)
+
+	// The single use token was redeemed, the second domain is not created
+	_, err = repo.CreateAndRedeemAllocationToken(context.Background(), newDomain("1236_DOM-APEX", "token2.domaintesttld"), at.Token)
+	s.Require().ErrorIs(err, entities.ErrAllocationTokenRedeemed)
+	_, err = repo.GetDomainByName(context.Background(), "token2.domaintesttld", false)
+	s.Require().ErrorIs(err, entities.ErrDomainNotFound)
+}
+
+func (s *DomainSuite) TestDomainRepository_UpdateDomainAndTransfer() {
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	repo := NewDomainRepository(tx)
+	ctx := context.Background()
+
+	domain, err := entities.NewDomain("1237_DOM-APEX", "transfer.domaintesttld", "GoMamma", "STr0mgP@ZZ")
+	s.Require().NoError(err)
+	domain.ClID = "domaintestRar"
+	domain.RegistrantID = "myTestContact007"
+	domain.AdminID = "myTestContact007"
+	domain.TechID = "myTestContact007"
+	domain.BillingID = "myTestContact007"
+	createdDomain, err := repo.Create(ctx, domain)
+	s.Require().NoError(err)
+
+	transfer := entities.NewDomainTransfer(5)
+	transfer.DomainRoiD = createdDomain.RoID
+	transfer.DomainName = createdDomain.Name
+	transfer.GainingRegistrar = "gainingRar"
+	transfer.LosingRegistrar = "domaintestRar"
+
+	createdDomain.Status.PendingTransfer = true
+	_, createdTransfer, err := repo.UpdateDomainAndCreateTransfer(ctx, createdDomain, &transfer)
+	s.Require().NoError(err)
+	s.Require().Equal(entities.TransferStatusPending, createdTransfer.Status)
+
+	// Creating the same transfer again fails, the domain update is rolled back with it
+	createdDomain.ClID = "gainingRar"
+	_, _, err = repo.UpdateDomainAndCreateTransfer(ctx, createdDomain, &transfer)
+	s.Require().Error(err)
+	read, err := repo.GetDomainByName(ctx, "transfer.domaintesttld", false)
+	s.Require().NoError(err)
+	s.Require().Equal(entities.ClIDType("domaintestRar"), read.ClID)
+	s.Require().True(read.Status.PendingTransfer)
+
+	read.Status.PendingTransfer = false
+	s.Require().NoError(createdTransfer.Finalize(entities.FinalizeTransferCommand{Status: entities.TransferStatusCancelled}))
+	_, updatedTransfer, err := repo.UpdateDomainAndTransfer(ctx, read, createdTransfer)
+	s.Require().NoError(err)
+	s.Require().Equal(entities.TransferStatusCancelled, updatedTransfer.Status)
+	read, err = repo.GetDomainByName(ctx, "transfer.domaintesttld", false)
+	s.Require().NoError(err)
+	s.Require().False(read.Status.PendingTransfer)
+}
+
 func (s *DomainSuite) TestDomainRepository_CreateDomainWithHosts() {
 	tx := s.db.Begin()
 	defer tx.Rollback()
@@ -256,6 +338,17 @@ func (s *DomainSuite) TestDomainRepository_GetGlue() {
 	s.Require().NoError(err)
 	s.Require().Equal(len(domain.Hosts), len(glue))
 
+	// Domains on hold are not published
+	retrievedDomain.Status.ClientHold = true
+	_, err = repo.UpdateDomain(context.Background(), retrievedDomain)
+	s.Require().NoError(err)
+	rr, err = repo.GetActiveDomainsWithHosts(context.Background(), queries.ActiveDomainsWithHostsQuery{TldName: s.tld})
+	s.Require().NoError(err)
+	s.Require().Empty(rr)
+	glue, err = repo.GetActiveDomainGlue(context.Background(), s.tld)
+	s.Require().NoError(err)
+	s.Require().Empty(glue)
+
 	// try and delete the domain with hosts associated, should fail
 	err = repo.DeleteDomainByName(context.Background(), createdDomain.Name.String())
 	s.Require().Error(err)
@@ -441,6 +534,54 @@ func (s *DomainSuite) TestDomainRepository_UpdateDomain() {
 	s.Require().Equal(createdDomain.RoID, updatedDomain.RoID)
 }
 
+func (s *DomainSuite) TestDomainRepository_DSData() {
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	repo := NewDomainRepository(tx)
+
+	// Create an active domain with DS data
+	domain, err := entities.NewDomain("1234_DOM-APEX", "geoff.domaintesttld", "GoMamma", "STr0mgP@ZZ")
+	s.Require().NoError(err)
+	domain.ClID = "domaintestRar"
+	domain.RegistrantID = "myTestContact007"
+	domain.AdminID = "myTestContact007"
+	domain.TechID = "myTestContact007"
+	domain.BillingID = "myTestContact007"
+	domain.Hosts = s.hosts
+	domain.Status.Inactive = false
+	ds1 := entities.DomainDSData{KeyTag: 12345, Alg: 13, DigestType: 2, Digest: "E2D3C916F6DEEAC73294E8268FB5885044A833FC5459588F4A9184CFC41A5766"}
+	ds2 := entities.DomainDSData{KeyTag: 54321, Alg: 13, DigestType: 2, Digest: "E2D3C916F6DEEAC73294E8268FB5885044A833FC5459588F4A9184CFC41A5766"}
+	s.Require().NoError(domain.AddDSData(ds1))
+	s.Require().NoError(domain.SetMaxSigLife(3600))
+	createdDomain, err := repo.Create(context.Background(), domain)
+	s.Require().NoError(err)
+
+	// The DS data is always retrieved
+	retrievedDomain, err := repo.GetDomainByName(context.Background(), createdDomain.Name.String(), false)
+	s.Require().NoError(err)
+	s.Require().Equal(domain.SecDNS, retrievedDomain.SecDNS)
+
+	// Updating the domain replaces the DS data
+	s.Require().NoError(retrievedDomain.RemoveDSData(ds1))
+	s.Require().NoError(retrievedDomain.AddDSData(ds2))
+	_, err = repo.UpdateDomain(context.Background(), retrievedDomain)
+	s.Require().NoError(err)
+	roid, _ := retrievedDomain.RoID.Int64()
+	retrievedDomain, err = repo.GetDomainByID(context.Background(), roid, false)
+	s.Require().NoError(err)
+	s.Require().Equal([]entities.DomainDSData{ds2}, retrievedDomain.SecDNS.DSData)
+
+	// The DS records are published after the NS records
+	rr, err := repo.GetActiveDomainsWithHosts(context.Background(), queries.ActiveDomainsWithHostsQuery{TldName: s.tld})
+	s.Require().NoError(err)
+	s.Require().Equal(len(domain.Hosts)+1, len(rr))
+	ds, ok := rr[len(rr)-1].(*dns.DS)
+	s.Require().True(ok)
+	s.Require().Equal("geoff.domaintesttld.", ds.Hdr.Name)
+	s.Require().Equal(uint16(54321), ds.KeyTag)
+	s.Require().Equal(ds2.Digest, ds.Digest)
+}
+
 func (s *DomainSuite) TestDomainRepository_DeleteDomain() {
 	tx := s.db.Begin()
 	defer tx.Rollback()
@@ -716,6 +857,101 @@ func (s *DomainSuite) TestDomainRepository_UpdateDomainWithHosts() {
 	s.Require().Equal("newAu123$th", retrievedDomain.AuthInfo.String())
 }
 
+func (s *DomainSuite) TestDomainRepository_UpdateDomainAndHosts() {
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	repo := NewDomainRepository(tx)
+	hostRepo := NewGormHostRepository(tx)
+
+	// Create a domain with the first host
+	domain, err := entities.NewDomain("1234_DOM-APEX", "geoff.domaintesttld", "GoMamma", "STr0mgP@ZZ")
+	s.Require().NoError(err)
+	domain.ClID = "domaintestRar"
+	domain.RegistrantID = "myTestContact007"
+	domain.AdminID = "myTestContact007"
+	domain.TechID = "myTestContact007"
+	domain.BillingID = "myTestContact007"
+	domain.Hosts = s.hosts[:1]
+	createdDomain, err := repo.Create(context.Background(), domain)
+	s.Require().NoError(err)
+
+	// Nothing is saved if the update fails
+	_, err = repo.UpdateDomainAndHosts(context.Background(), createdDomain.Name.String(), func(d *entities.Domain) ([]*entities.Host, error) {
+		d.AuthInfo = "newAu123$th"
+		return nil, entities.ErrDomainUpdateNotAllowed
+	})
+	s.Require().ErrorIs(err, entities.ErrDomainUpdateNotAllowed)
+	retrievedDomain, err := repo.GetDomainByName(context.Background(), createdDomain.Name.String(), false)
+	s.Require().NoError(err)
+	s.Require().Equal("STr0mgP@ZZ", retrievedDomain.AuthInfo.String())
+
+	// Replace the first host with the others and change the authInfo in one go
+	_, err = repo.UpdateDomainAndHosts(context.Background(), createdDomain.Name.String(), func(d *entities.Domain) ([]*entities.Host, error) {
+		s.Require().Len(d.Hosts, 1)
+		d.AuthInfo = "newAu123$th"
+		d.Hosts = s.hosts[1:]
+		return s.hosts[:1], nil
+	})
+	s.Require().NoError(err)
+
+	retrievedDomain, err = repo.GetDomainByName(context.Background(), createdDomain.Name.String(), true)
+	s.Require().NoError(err)
+	s.Require().Equal("newAu123$th", retrievedDomain.AuthInfo.String())
+	s.Require().Equal(len(s.hosts)-1, len(retrievedDomain.Hosts))
+
+	// The added hosts are linked, the removed host is not associated with any domain and is unlinked
+	for i, host := range s.hosts {
+		roid, _ := host.RoID.Int64()
+		h, err := hostRepo.GetHostByRoid(context.Background(), roid)
+		s.Require().NoError(err)
+		s.Require().Equal(i > 0, h.Status.Linked)
+	}
+}
+
+func (s *DomainSuite) TestDomainRepository_UpdateDomainAndHosts_Concurrent() {
+	// Concurrent updates use their own connections, so the domain is committed rather than created in a transaction
+	repo := NewDomainRepository(s.db)
+	ctx := context.Background()
+
+	domain, err := entities.NewDomain("1235_DOM-APEX", "concurrent.domaintesttld", "GoMamma", "STr0mgP@ZZ")
+	s.Require().NoError(err)
+	domain.ClID = "domaintestRar"
+	domain.RegistrantID = "myTestContact007"
+	domain.AdminID = "myTestContact007"
+	domain.TechID = "myTestContact007"
+	domain.BillingID = "myTestContact007"
+	_, err = repo.Create(ctx, domain)
+	s.Require().NoError(err)
+	defer func() { _ = repo.DeleteDomainByName(ctx, domain.Name.String()) }()
+
+	// Every concurrent update adds its own DS record to the domain as it is stored, none of them are lost
+	updates := 5
+	errs := make(chan error, updates)
+	var wg sync.WaitGroup
+	for i := 0; i < updates; i++ {
+		wg.Add(1)
+		go func(keyTag uint16) {
+			defer wg.Done()
+			_, err := repo.UpdateDomainAndHosts(ctx, domain.Name.String(), func(d *entities.Domain) ([]*entities.Host, error) {
+				return nil, d.AddDSData(entities.DomainDSData{KeyTag: keyTag, Alg: 13, DigestType: 2, Digest: "E2D3C916F6DEEAC73294E8268FB5885044A833FC5459588F4A9184CFC41A5766"})
+			})
+			errs <- err
+		}(uint16(1000 + i))
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		s.Require().NoError(err)
+	}
+
+	retrievedDomain, err := repo.GetDomainByName(ctx, domain.Name.String(), false)
+	s.Require().NoError(err)
+	s.Require().Len(retrievedDomain.SecDNS.DSData, updates)
+
+	_, err = repo.UpdateDomainAndHosts(ctx, "unknown.domaintesttld", func(d *entities.Domain) ([]*entities.Host, error) { return nil, nil })
+	s.Require().ErrorIs(err, entities.ErrDomainNotFound)
+}
+
 func (s *DomainSuite) TestDomainRepository_ListExpiringDomains() {
 	// Create a couple of domains with different expiry dates
 	tx := s.db.Begin()
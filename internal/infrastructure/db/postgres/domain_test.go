@@ -40,12 +40,29 @@ func getValidDBDomain() *Domain {
 				Name: "ns2.example.com",
 			},
 		},
+		MaxSigLife: 3600,
+		DSData: []DomainDSData{
+			{
+				DomainRoID: 12345678,
+				KeyTag:     12345,
+				Alg:        13,
+				DigestType: 2,
+				Digest:     "E2D3C916F6DEEAC73294E8268FB5885044A833FC5459588F4A9184CFC41A5766",
+			},
+		},
 		DomainGrandFathering: entities.DomainGrandFathering{
 			GFAmount:          100,
 			GFCurrency:        "USD",
 			GFExpiryCondition: "transfer",
 			GFVoidDate:        &t,
 		},
+		RestoreReport: entities.DomainRestoreReport{
+			PreData:    "pre",
+			PostData:   "post",
+			ResReason:  "deleted by mistake",
+			Statements: []string{"statement 1", "statement 2"},
+			ReportedAt: t,
+		},
 	}
 }
 
@@ -68,6 +85,11 @@ func TestDomain_ToDomain(t *testing.T) {
 	require.Equal(t, dbDomain.RenewedYears, d.RenewedYears)
 	require.Equal(t, dbDomain.AuthInfo, d.AuthInfo.String())
 	require.Equal(t, len(dbDomain.Hosts), len(d.Hosts))
+	require.Equal(t, entities.DomainSecDNS{
+		MaxSigLife: 3600,
+		DSData:     []entities.DomainDSData{{KeyTag: 12345, Alg: 13, DigestType: 2, Digest: "E2D3C916F6DEEAC73294E8268FB5885044A833FC5459588F4A9184CFC41A5766"}},
+	}, d.SecDNS)
+	require.Equal(t, dbDomain.RestoreReport, d.RestoreReport)
 }
 
 func TestDomain_ToDBDomain(t *testing.T) {
@@ -94,5 +116,8 @@ func TestDomain_ToDBDomain(t *testing.T) {
 	require.Equal(t, dbDom.DomainStatus, dbDomain.DomainStatus)
 	require.Equal(t, dbDom.DomainRGPStatus, dbDomain.DomainRGPStatus)
 	require.Equal(t, len(dbDom.Hosts), len(dbDomain.Hosts))
+	require.Equal(t, dbDom.MaxSigLife, dbDomain.MaxSigLife)
+	require.Equal(t, dbDom.DSData, dbDomain.DSData)
+	require.Equal(t, dbDom.RestoreReport, dbDomain.RestoreReport)
 
 }
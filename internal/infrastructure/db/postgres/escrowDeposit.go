@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// EscrowDeposit is the GORM representation of an EscrowDeposit
+type EscrowDeposit struct {
+	ID        string `gorm:"primaryKey"`
+	TLDName   string `gorm:"primaryKey"`
+	Type      string `gorm:"not null;index"`
+	PrevID    string
+	Resend    int
+	Watermark time.Time `gorm:"not null;index"`
+	FileName  string
+	CreatedAt time.Time
+}
+
+// TableName returns the table name for the EscrowDeposit model
+func (EscrowDeposit) TableName() string {
+	return "escrow_deposits"
+}
+
+// ToDBEscrowDeposit converts an EscrowDeposit entity to a GORM EscrowDeposit
+func ToDBEscrowDeposit(d *entities.EscrowDeposit) *EscrowDeposit {
+	return &EscrowDeposit{
+		ID:        d.ID,
+		TLDName:   d.TLDName,
+		Type:      d.Type,
+		PrevID:    d.PrevID,
+		Resend:    d.Resend,
+		Watermark: d.Watermark,
+		FileName:  d.FileName,
+		CreatedAt: d.CreatedAt,
+	}
+}
+
+// FromDBEscrowDeposit converts a GORM EscrowDeposit to an EscrowDeposit entity
+func FromDBEscrowDeposit(dbd *EscrowDeposit) *entities.EscrowDeposit {
+	return &entities.EscrowDeposit{
+		ID:        dbd.ID,
+		TLDName:   dbd.TLDName,
+		Type:      dbd.Type,
+		PrevID:    dbd.PrevID,
+		Resend:    dbd.Resend,
+		Watermark: dbd.Watermark.UTC(),
+		FileName:  dbd.FileName,
+		CreatedAt: dbd.CreatedAt.UTC(),
+	}
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"gorm.io/gorm"
+)
+
+// EscrowDepositRepository implements the EscrowDepositRepository interface
+type EscrowDepositRepository struct {
+	db *gorm.DB
+}
+
+// NewEscrowDepositRepository returns a new EscrowDepositRepository
+func NewEscrowDepositRepository(db *gorm.DB) *EscrowDepositRepository {
+	return &EscrowDepositRepository{
+		db: db,
+	}
+}
+
+// Save stores the escrow deposit, a deposit with the same ID for the TLD is replaced (e.g. when it is resent)
+func (r *EscrowDepositRepository) Save(ctx context.Context, d *entities.EscrowDeposit) (*entities.EscrowDeposit, error) {
+	dbd := ToDBEscrowDeposit(d)
+	err := r.db.WithContext(ctx).Save(dbd).Error
+	if err != nil {
+		return nil, err
+	}
+	return FromDBEscrowDeposit(dbd), nil
+}
+
+// GetByID retrieves the deposit of the TLD with the provided ID
+func (r *EscrowDepositRepository) GetByID(ctx context.Context, tld, id string) (*entities.EscrowDeposit, error) {
+	return r.first(r.db.WithContext(ctx).Where("tld_name = ? AND id = ?", tld, id))
+}
+
+// GetLatest retrieves the deposit with the most recent watermark for the TLD. If depositType is not empty only deposits of that type are considered.
+func (r *EscrowDepositRepository) GetLatest(ctx context.Context, tld, depositType string) (*entities.EscrowDeposit, error) {
+	dbQuery := r.db.WithContext(ctx).Where("tld_name = ?", tld)
+	if depositType != "" {
+		dbQuery = dbQuery.Where("type = ?", depositType)
+	}
+	return r.first(dbQuery)
+}
+
+// GetByDay retrieves the deposit of the type that was generated for the TLD with a watermark on the same (UTC) day as the provided time
+func (r *EscrowDepositRepository) GetByDay(ctx context.Context, tld, depositType string, day time.Time) (*entities.EscrowDeposit, error) {
+	start := day.UTC().Truncate(24 * time.Hour)
+	return r.first(r.db.WithContext(ctx).
+		Where("tld_name = ? AND type = ?", tld, depositType).
+		Where("watermark >= ? AND watermark < ?", start, start.AddDate(0, 0, 1)))
+}
+
+// first returns the deposit with the most recent watermark that matches the query or ErrEscrowDepositNotFound
+func (r *EscrowDepositRepository) first(dbQuery *gorm.DB) (*entities.EscrowDeposit, error) {
+	dbd := &EscrowDeposit{}
+	err := dbQuery.Order("watermark DESC").Order("created_at DESC").First(dbd).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrEscrowDepositNotFound
+		}
+		return nil, err
+	}
+	return FromDBEscrowDeposit(dbd), nil
+}
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type EscrowDepositSuite struct {
+	suite.Suite
+	db *gorm.DB
+}
+
+func TestEscrowDepositSuite(t *testing.T) {
+	suite.Run(t, new(EscrowDepositSuite))
+}
+
+func (s *EscrowDepositSuite) SetupSuite() {
+	s.db = setupTestDB()
+}
+
+func (s *EscrowDepositSuite) TestEscrowDeposit_Lifecycle() {
+	repo := NewEscrowDepositRepository(s.db)
+	ctx := context.Background()
+	tld := "escrowrepotest"
+	day := time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)
+
+	_, err := repo.GetLatest(ctx, tld, "")
+	s.Require().ErrorIs(err, entities.ErrEscrowDepositNotFound)
+
+	full := &entities.EscrowDeposit{ID: "202403030000", TLDName: tld, Type: entities.RDEReportTypeFULL, Watermark: day}
+	_, err = repo.Save(ctx, full)
+	s.Require().NoError(err)
+	diff := &entities.EscrowDeposit{ID: "202403040000", TLDName: tld, Type: entities.RDEReportTypeDIFF, PrevID: full.ID, Watermark: day.AddDate(0, 0, 1)}
+	_, err = repo.Save(ctx, diff)
+	s.Require().NoError(err)
+
+	latest, err := repo.GetLatest(ctx, tld, "")
+	s.Require().NoError(err)
+	s.Require().Equal(diff.ID, latest.ID)
+
+	latest, err = repo.GetLatest(ctx, tld, entities.RDEReportTypeFULL)
+	s.Require().NoError(err)
+	s.Require().Equal(full.ID, latest.ID)
+
+	sameDay, err := repo.GetByDay(ctx, tld, entities.RDEReportTypeFULL, day.Add(12*time.Hour))
+	s.Require().NoError(err)
+	s.Require().Equal(full.ID, sameDay.ID)
+
+	_, err = repo.GetByDay(ctx, tld, entities.RDEReportTypeFULL, day.AddDate(0, 0, 1))
+	s.Require().ErrorIs(err, entities.ErrEscrowDepositNotFound)
+
+	// Resending a deposit replaces its record
+	full.Resend = 1
+	_, err = repo.Save(ctx, full)
+	s.Require().NoError(err)
+	resent, err := repo.GetByID(ctx, tld, full.ID)
+	s.Require().NoError(err)
+	s.Require().Equal(1, resent.Resend)
+
+	s.Require().NoError(s.db.Where("tld_name = ?", tld).Delete(&EscrowDeposit{}).Error)
+}
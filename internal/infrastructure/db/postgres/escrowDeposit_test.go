@@ -0,0 +1,27 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscrowDeposit_TableName(t *testing.T) {
+	require.Equal(t, "escrow_deposits", EscrowDeposit{}.TableName())
+}
+
+func TestEscrowDeposit_Mapping(t *testing.T) {
+	deposit := &entities.EscrowDeposit{
+		ID:        "202403020000",
+		TLDName:   "apex",
+		Type:      entities.RDEReportTypeDIFF,
+		PrevID:    "202403010000",
+		Resend:    1,
+		Watermark: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC),
+		FileName:  "apex_2024-03-02_diff_S1_R1.xml",
+		CreatedAt: time.Date(2024, 3, 2, 0, 5, 0, 0, time.UTC),
+	}
+	require.Equal(t, deposit, FromDBEscrowDeposit(ToDBEscrowDeposit(deposit)))
+}
@@ -88,13 +88,26 @@ func (r *HostRepository) UpdateHost(ctx context.Context, host *entities.Host) (*
 
 // DeleteHostByRoid deletes a host by its roid
 func (r *HostRepository) DeleteHostByRoid(ctx context.Context, roid int64) error {
-	return r.db.WithContext(ctx).Delete(&Host{}, roid).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		dbHost := &Host{}
+		err := tx.Where("ro_id = ?", roid).Limit(1).Find(dbHost).Error
+		if err != nil || dbHost.RoID == 0 {
+			return err
+		}
+		err = tx.Delete(&Host{}, roid).Error
+		if err != nil {
+			return err
+		}
+		// Hosts are not bound to a TLD, they are included in the deletes of every TLD
+		roidString, _ := entities.NewRoidType(roid, entities.RoidTypeHost)
+		return recordDeletedObject(tx, entities.HOST_URI, "", dbHost.Name, roidString.String())
+	})
 }
 
 // ListHosts lists hosts
 func (r *HostRepository) ListHosts(ctx context.Context, params queries.ListItemsQuery) ([]*entities.Host, string, error) {
 	// Get a query object ordering by ro_id (PK used for cursor pagination)
-	dbQuery := r.db.WithContext(ctx).Order("ro_id ASC")
+	dbQuery := r.db.WithContext(ctx).Preload("Addresses").Order("ro_id ASC")
 
 	// Add cursor pagination if a cursor is provided
 	if params.PageCursor != "" {
@@ -131,6 +144,21 @@ func (r *HostRepository) ListHosts(ctx context.Context, params queries.ListItems
 			if f.NameLike != "" {
 				dbQuery = dbQuery.Where("name ILIKE ?", "%"+f.NameLike+"%")
 			}
+			if f.NameEquals != "" {
+				dbQuery = dbQuery.Where("name = ?", f.NameEquals)
+			}
+			if f.NameMatches != "" {
+				dbQuery = dbQuery.Where("name ILIKE ?", wildcardToLikePattern(f.NameMatches))
+			}
+			if f.AddressEquals != "" {
+				dbQuery = dbQuery.Where("ro_id IN (SELECT host_ro_id FROM host_addresses WHERE address = ?)", f.AddressEquals)
+			}
+			if f.TldEquals != "" {
+				dbQuery = dbQuery.Where("ro_id IN (SELECT dh.host_ro_id FROM domain_hosts dh JOIN domains d ON d.ro_id = dh.domain_ro_id WHERE d.tld_name = ?)", f.TldEquals)
+			}
+			if !f.UpdatedAfter.IsZero() {
+				dbQuery = dbQuery.Where("updated_at > ?", f.UpdatedAfter)
+			}
 		}
 	}
 
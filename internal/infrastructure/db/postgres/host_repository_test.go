@@ -262,6 +262,38 @@ func (s *HostSuite) TestListHosts() {
 	s.Require().Nil(hosts)
 
 }
+func (s *HostSuite) TestListHosts_SearchFilters() {
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	repo := NewGormHostRepository(tx)
+
+	t := time.Now().UTC()
+	host := getValidHost("hostSuiteRar", &t)
+	host.ClID = entities.ClIDType(s.rarClid)
+	host.Name = "ns1.searchfilters.example"
+	host.Addresses = []netip.Addr{netip.MustParseAddr("192.0.2.201")}
+	_, err := repo.CreateHost(context.Background(), host)
+	s.Require().NoError(err)
+
+	// Match the name with a wildcard
+	hosts, _, err := repo.ListHosts(context.Background(), queries.ListItemsQuery{PageSize: 25, Filter: queries.ListHostsFilter{NameMatches: "NS1.searchfilters.*"}})
+	s.Require().NoError(err)
+	s.Require().Len(hosts, 1)
+	s.Require().Equal(host.Name, hosts[0].Name)
+	s.Require().Equal(host.Addresses, hosts[0].Addresses)
+
+	// Match the address
+	hosts, _, err = repo.ListHosts(context.Background(), queries.ListItemsQuery{PageSize: 25, Filter: queries.ListHostsFilter{AddressEquals: "192.0.2.201"}})
+	s.Require().NoError(err)
+	s.Require().Len(hosts, 1)
+	s.Require().Equal(host.Name, hosts[0].Name)
+
+	// The LIKE wildcards are matched literally
+	hosts, _, err = repo.ListHosts(context.Background(), queries.ListItemsQuery{PageSize: 25, Filter: queries.ListHostsFilter{NameMatches: "ns1.searchfilters%"}})
+	s.Require().NoError(err)
+	s.Require().Empty(hosts)
+}
+
 func (s *HostSuite) TestGetHostByNameAndClID() {
 	tx := s.db.Begin()
 	defer tx.Rollback()
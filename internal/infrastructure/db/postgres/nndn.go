@@ -105,8 +105,18 @@ func (r *GormNNDNRepository) UpdateNNDN(ctx context.Context, nndn *entities.NNDN
 }
 
 func (r *GormNNDNRepository) DeleteNNDN(ctx context.Context, name string) error {
-	result := r.db.WithContext(ctx).Where("Name = ?", name).Delete(&NNDN{})
-	return result.Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		dbNNDN := &NNDN{}
+		err := tx.Where("Name = ?", name).Limit(1).Find(dbNNDN).Error
+		if err != nil || dbNNDN.Name == "" {
+			return err
+		}
+		err = tx.Where("Name = ?", name).Delete(&NNDN{}).Error
+		if err != nil {
+			return err
+		}
+		return recordDeletedObject(tx, entities.NNDN_URI, dbNNDN.TLDName, dbNNDN.Name, "")
+	})
 }
 
 func (r *GormNNDNRepository) Count(ctx context.Context, filter queries.ListNndnsFilter) (int64, error) {
@@ -194,5 +204,9 @@ func setNNDNFilters(dbQuery *gorm.DB, filter queries.ListNndnsFilter) (*gorm.DB,
 		dbQuery = dbQuery.Where("reason ILIKE ?", "%"+filter.ReasonLike+"%")
 	}
 
+	if !filter.UpdatedAfter.IsZero() {
+		dbQuery = dbQuery.Where("updated_at > ?", filter.UpdatedAfter)
+	}
+
 	return dbQuery, nil
 }
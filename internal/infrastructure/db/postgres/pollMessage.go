@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// PollMessage is the GORM representation of a PollMessage
+type PollMessage struct {
+	ID              int64  `gorm:"primaryKey;autoIncrement"`
+	ClID            string `gorm:"not null;index"`
+	Msg             string `gorm:"not null"`
+	DomainName      string
+	DomainRoID      string
+	TransactionType string
+	TraceID         string
+	CreatedAt       time.Time
+}
+
+// TableName returns the table name for the PollMessage model
+func (PollMessage) TableName() string {
+	return "poll_messages"
+}
+
+// ToDBPollMessage converts a PollMessage entity to a GORM PollMessage
+func ToDBPollMessage(pm *entities.PollMessage) *PollMessage {
+	return &PollMessage{
+		ID:              pm.ID,
+		ClID:            pm.ClID.String(),
+		Msg:             pm.Msg,
+		DomainName:      pm.DomainName.String(),
+		DomainRoID:      pm.DomainRoID.String(),
+		TransactionType: pm.TransactionType.String(),
+		TraceID:         pm.TraceID,
+		CreatedAt:       pm.CreatedAt,
+	}
+}
+
+// FromDBPollMessage converts a GORM PollMessage to a PollMessage entity
+func FromDBPollMessage(dbpm *PollMessage) *entities.PollMessage {
+	return &entities.PollMessage{
+		ID:              dbpm.ID,
+		ClID:            entities.ClIDType(dbpm.ClID),
+		Msg:             dbpm.Msg,
+		DomainName:      entities.DomainName(dbpm.DomainName),
+		DomainRoID:      entities.RoidType(dbpm.DomainRoID),
+		TransactionType: entities.TransactionType(dbpm.TransactionType),
+		TraceID:         dbpm.TraceID,
+		CreatedAt:       dbpm.CreatedAt.UTC(),
+	}
+}
@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"gorm.io/gorm"
+)
+
+// PollMessageRepository implements the PollMessageRepository interface
+type PollMessageRepository struct {
+	db *gorm.DB
+}
+
+// NewPollMessageRepository returns a new PollMessageRepository
+func NewPollMessageRepository(db *gorm.DB) *PollMessageRepository {
+	return &PollMessageRepository{
+		db: db,
+	}
+}
+
+// Create queues a new poll message
+func (r *PollMessageRepository) Create(ctx context.Context, pm *entities.PollMessage) (*entities.PollMessage, error) {
+	dbpm := ToDBPollMessage(pm)
+	err := r.db.WithContext(ctx).Create(dbpm).Error
+	if err != nil {
+		return nil, err
+	}
+	return FromDBPollMessage(dbpm), nil
+}
+
+// GetByID retrieves a poll message by its ID
+func (r *PollMessageRepository) GetByID(ctx context.Context, id int64) (*entities.PollMessage, error) {
+	dbpm := &PollMessage{}
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(dbpm).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrPollMessageNotFound
+		}
+		return nil, err
+	}
+	return FromDBPollMessage(dbpm), nil
+}
+
+// GetOldest retrieves the oldest poll message queued for the registrar with the provided ClID
+func (r *PollMessageRepository) GetOldest(ctx context.Context, clid string) (*entities.PollMessage, error) {
+	dbpm := &PollMessage{}
+	err := r.db.WithContext(ctx).Where("cl_id = ?", clid).Order("id ASC").First(dbpm).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrPollMessageNotFound
+		}
+		return nil, err
+	}
+	return FromDBPollMessage(dbpm), nil
+}
+
+// Delete removes a poll message from the queue
+func (r *PollMessageRepository) Delete(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&PollMessage{}).Error
+}
+
+// Count returns the number of poll messages matching the filter
+func (r *PollMessageRepository) Count(ctx context.Context, filter queries.ListPollMessagesFilter) (int64, error) {
+	var count int64
+	err := setPollMessageFilters(r.db.WithContext(ctx).Model(&PollMessage{}), filter).Count(&count).Error
+	return count, err
+}
+
+// List returns a list of poll messages ordered by ID (oldest first) and a cursor for pagination
+func (r *PollMessageRepository) List(ctx context.Context, params queries.ListItemsQuery) ([]*entities.PollMessage, string, error) {
+	// Get a query object ordering by ID (PK used for cursor pagination)
+	dbQuery := r.db.WithContext(ctx).Order("id ASC")
+
+	// Add cursor pagination if a cursor is provided
+	if params.PageCursor != "" {
+		cursor, err := strconv.ParseInt(params.PageCursor, 10, 64)
+		if err != nil {
+			return nil, "", err
+		}
+		dbQuery = dbQuery.Where("id > ?", cursor)
+	}
+
+	// Add filters if provided
+	if params.Filter != nil {
+		filter, ok := params.Filter.(queries.ListPollMessagesFilter)
+		if !ok {
+			return nil, "", ErrInvalidFilterType
+		}
+		dbQuery = setPollMessageFilters(dbQuery, filter)
+	}
+
+	// Fetch one more than the limit to determine if there are more results
+	dbQuery = dbQuery.Limit(params.PageSize + 1)
+
+	var dbpms []*PollMessage
+	if err := dbQuery.Find(&dbpms).Error; err != nil {
+		return nil, "", err
+	}
+
+	// Check if there are more results
+	hasMore := len(dbpms) == params.PageSize+1
+	if hasMore {
+		// Return only up to the limit
+		dbpms = dbpms[:params.PageSize]
+	}
+
+	pms := make([]*entities.PollMessage, len(dbpms))
+	for i, dbpm := range dbpms {
+		pms[i] = FromDBPollMessage(dbpm)
+	}
+
+	// Set the cursor to the last ID in the list
+	var newCursor string
+	if hasMore {
+		newCursor = strconv.FormatInt(pms[len(pms)-1].ID, 10)
+	}
+
+	return pms, newCursor, nil
+}
+
+// setPollMessageFilters adds the filters to the query
+func setPollMessageFilters(dbQuery *gorm.DB, filter queries.ListPollMessagesFilter) *gorm.DB {
+	if filter.ClidEquals != "" {
+		dbQuery = dbQuery.Where("cl_id = ?", filter.ClidEquals)
+	}
+	if filter.DomainNameLike != "" {
+		dbQuery = dbQuery.Where("domain_name ILIKE ?", "%"+filter.DomainNameLike+"%")
+	}
+	if filter.TransactionTypeEquals != "" {
+		dbQuery = dbQuery.Where("transaction_type = ?", filter.TransactionTypeEquals)
+	}
+	return dbQuery
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type PollMessageSuite struct {
+	suite.Suite
+	db *gorm.DB
+}
+
+func TestPollMessageSuite(t *testing.T) {
+	suite.Run(t, new(PollMessageSuite))
+}
+
+func (s *PollMessageSuite) SetupSuite() {
+	s.db = setupTestDB()
+}
+
+func (s *PollMessageSuite) TestPollMessage_Queue() {
+	repo := NewPollMessageRepository(s.db)
+	ctx := context.Background()
+
+	first, err := entities.NewPollMessage("pollMsgRar", "first message")
+	s.Require().NoError(err)
+	createdFirst, err := repo.Create(ctx, first)
+	s.Require().NoError(err)
+	s.Require().NotZero(createdFirst.ID)
+
+	second, err := entities.NewPollMessage("pollMsgRar", "second message")
+	s.Require().NoError(err)
+	createdSecond, err := repo.Create(ctx, second)
+	s.Require().NoError(err)
+
+	// The oldest message is returned first
+	oldest, err := repo.GetOldest(ctx, "pollMsgRar")
+	s.Require().NoError(err)
+	s.Require().Equal(createdFirst.ID, oldest.ID)
+
+	count, err := repo.Count(ctx, queries.ListPollMessagesFilter{ClidEquals: "pollMsgRar"})
+	s.Require().NoError(err)
+	s.Require().Equal(int64(2), count)
+
+	list, cursor, err := repo.List(ctx, queries.ListItemsQuery{PageSize: 1, Filter: queries.ListPollMessagesFilter{ClidEquals: "pollMsgRar"}})
+	s.Require().NoError(err)
+	s.Require().Len(list, 1)
+	s.Require().NotEmpty(cursor)
+
+	// Dequeue the messages
+	s.Require().NoError(repo.Delete(ctx, createdFirst.ID))
+	_, err = repo.GetByID(ctx, createdFirst.ID)
+	s.Require().ErrorIs(err, entities.ErrPollMessageNotFound)
+
+	oldest, err = repo.GetOldest(ctx, "pollMsgRar")
+	s.Require().NoError(err)
+	s.Require().Equal(createdSecond.ID, oldest.ID)
+
+	s.Require().NoError(repo.Delete(ctx, createdSecond.ID))
+	_, err = repo.GetOldest(ctx, "pollMsgRar")
+	s.Require().ErrorIs(err, entities.ErrPollMessageNotFound)
+}
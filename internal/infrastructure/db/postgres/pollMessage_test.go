@@ -0,0 +1,28 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollMessage_TableName(t *testing.T) {
+	require.Equal(t, "poll_messages", PollMessage{}.TableName())
+}
+
+func TestPollMessage_Mapping(t *testing.T) {
+	pm := &entities.PollMessage{
+		ID:              42,
+		ClID:            "ClID-1",
+		Msg:             "Domain example.com purged",
+		DomainName:      "example.com",
+		DomainRoID:      "1234_DOM-APEX",
+		TransactionType: entities.TransactionTypePurge,
+		TraceID:         "trace-1",
+		CreatedAt:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	require.Equal(t, pm, FromDBPollMessage(ToDBPollMessage(pm)))
+}
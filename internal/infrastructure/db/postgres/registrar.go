@@ -36,8 +36,18 @@ type Registrar struct {
 	Whois43     string
 	Whois80     string
 	RdapBaseUrl string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	// EPPPasswordHash is the bcrypt hash of the EPP password, never the password itself
+	EPPPasswordHash string
+	// EPP login security state, see entities.EPPLoginState
+	EPPPasswordChangedAt time.Time
+	EPPFailedLogins      int
+	EPPLockedUntil       time.Time
+	EPPLastLoginAt       time.Time
+	EPPUserAgentApp      string
+	EPPUserAgentTech     string
+	EPPUserAgentOS       string `gorm:"column:epp_user_agent_os"`
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
 
 	// FK relationships with contacts
 	Contacts        []*Contact `gorm:"foreignKey:ClID"`
@@ -80,6 +90,15 @@ func ToDBRegistrar(r *entities.Registrar) *Registrar {
 		RdapBaseUrl: r.RdapBaseURL.String(),
 		CreatedAt:   r.CreatedAt,
 		UpdatedAt:   r.UpdatedAt,
+
+		EPPPasswordHash:      r.EPPPasswordHash,
+		EPPPasswordChangedAt: r.EPPLogin.PasswordChangedAt,
+		EPPFailedLogins:      r.EPPLogin.FailedLogins,
+		EPPLockedUntil:       r.EPPLogin.LockedUntil,
+		EPPLastLoginAt:       r.EPPLogin.LastLoginAt,
+		EPPUserAgentApp:      r.EPPLogin.UserAgent.App,
+		EPPUserAgentTech:     r.EPPLogin.UserAgent.Tech,
+		EPPUserAgentOS:       r.EPPLogin.UserAgent.OS,
 	}
 
 	if r.PostalInfo[0] != nil {
@@ -133,6 +152,19 @@ func FromDBRegistrar(dbr *Registrar) *entities.Registrar {
 		RdapBaseURL: entities.URL(dbr.RdapBaseUrl),
 		CreatedAt:   dbr.CreatedAt,
 		UpdatedAt:   dbr.UpdatedAt,
+
+		EPPPasswordHash: dbr.EPPPasswordHash,
+		EPPLogin: entities.EPPLoginState{
+			PasswordChangedAt: dbr.EPPPasswordChangedAt,
+			FailedLogins:      dbr.EPPFailedLogins,
+			LockedUntil:       dbr.EPPLockedUntil,
+			LastLoginAt:       dbr.EPPLastLoginAt,
+			UserAgent: entities.EPPUserAgent{
+				App:  dbr.EPPUserAgentApp,
+				Tech: dbr.EPPUserAgentTech,
+				OS:   dbr.EPPUserAgentOS,
+			},
+		},
 	}
 
 	a0 := &entities.Address{
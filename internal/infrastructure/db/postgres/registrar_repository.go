@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/onasunnymorning/domain-os/internal/application/queries"
 	"github.com/onasunnymorning/domain-os/internal/domain/entities"
@@ -194,6 +195,30 @@ func (r *GormRegistrarRepository) IsRegistrarAccreditedForTLD(ctx context.Contex
 	return rar == rarClID, nil
 }
 
+// RecordFailedEPPLogin counts a failed EPP login of the registrar at the provided time and locks it out for EPP_LOGIN_LOCKOUT_DURATION once the failed logins reach EPP_MAX_FAILED_LOGINS, like Registrar.RecordFailedEPPLogin.
+// The login state is updated in a single statement so concurrent failed logins are all counted. It returns the failed logins and the lockout after the update.
+func (r *GormRegistrarRepository) RecordFailedEPPLogin(ctx context.Context, clid string, t time.Time) (int, time.Time, error) {
+	var state struct {
+		EPPFailedLogins int       `gorm:"column:epp_failed_logins"`
+		EPPLockedUntil  time.Time `gorm:"column:epp_locked_until"`
+	}
+	result := r.db.WithContext(ctx).Raw(`
+		UPDATE registrars SET
+			epp_failed_logins = epp_failed_logins + 1,
+			epp_locked_until = CASE WHEN epp_failed_logins + 1 >= ? THEN ? ELSE epp_locked_until END
+		WHERE cl_id = ?
+		RETURNING epp_failed_logins, epp_locked_until`,
+		entities.EPP_MAX_FAILED_LOGINS, t.Add(entities.EPP_LOGIN_LOCKOUT_DURATION), clid,
+	).Scan(&state)
+	if result.Error != nil {
+		return 0, time.Time{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return 0, time.Time{}, entities.ErrRegistrarNotFound
+	}
+	return state.EPPFailedLogins, state.EPPLockedUntil.UTC(), nil
+}
+
 // setRegistrarFilters applies the provided filters to the query
 func setRegistrarFilters(dbQuery *gorm.DB, filter queries.ListRegistrarsFilter) (*gorm.DB, error) {
 	if filter.ClidLike != "" {
@@ -202,6 +227,9 @@ func setRegistrarFilters(dbQuery *gorm.DB, filter queries.ListRegistrarsFilter)
 	if filter.NameLike != "" {
 		dbQuery = dbQuery.Where("name ILIKE ?", "%"+filter.NameLike+"%")
 	}
+	if filter.NameEquals != "" {
+		dbQuery = dbQuery.Where("LOWER(name) = LOWER(?)", filter.NameEquals)
+	}
 	if filter.NickNameLike != "" {
 		dbQuery = dbQuery.Where("nick_name ILIKE ?", "%"+filter.NickNameLike+"%")
 	}
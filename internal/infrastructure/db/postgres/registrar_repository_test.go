@@ -3,7 +3,9 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/onasunnymorning/domain-os/internal/application/queries"
 	"github.com/onasunnymorning/domain-os/internal/domain/entities"
@@ -205,6 +207,12 @@ func (s *RegistrarSuite) TestListRegistrars() {
 	require.NotNil(s.T(), registrars)
 	require.Len(s.T(), registrars, 2)
 
+	// Filter by name, case insensitive
+	registrars, _, err = repo.List(context.Background(), queries.ListItemsQuery{PageSize: 2, Filter: queries.ListRegistrarsFilter{NameEquals: "gobro inc."}})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), registrars, 1)
+	require.Equal(s.T(), createdRegistrar2.ClID, registrars[0].ClID)
+
 	// Delete one registrar
 	err = repo.Delete(context.Background(), createdRegistrar1.ClID.String())
 	require.NoError(s.T(), err)
@@ -284,3 +292,42 @@ func (s *RegistrarSuite) TestCountRegistrars() {
 	require.GreaterOrEqual(s.T(), count, int64(0))
 
 }
+
+func (s *RegistrarSuite) TestRecordFailedEPPLogin_Concurrent() {
+	// Concurrent logins use their own connections, so the registrar is committed rather than created in a transaction
+	repo := NewGormRegistrarRepository(s.db)
+	ctx := context.Background()
+
+	registrar, err := entities.NewRegistrar("failed-login-rar", "Gomamma Inc.", "contact@gomamma.com", 12399, getValidRegistrarPostalInfoArr())
+	s.Require().NoError(err)
+	_, err = repo.Create(ctx, registrar)
+	s.Require().NoError(err)
+	defer func() { _ = repo.Delete(ctx, registrar.ClID.String()) }()
+
+	// Every concurrent failed login is counted
+	now := time.Now().UTC()
+	attempts := 2 * entities.EPP_MAX_FAILED_LOGINS
+	errs := make(chan error, attempts)
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := repo.RecordFailedEPPLogin(ctx, registrar.ClID.String(), now)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		s.Require().NoError(err)
+	}
+
+	read, err := repo.GetByClID(ctx, registrar.ClID.String(), false)
+	s.Require().NoError(err)
+	s.Require().Equal(attempts, read.EPPLogin.FailedLogins)
+	s.Require().ErrorIs(read.CheckEPPLoginLock(now), entities.ErrEPPLoginLocked)
+
+	_, _, err = repo.RecordFailedEPPLogin(ctx, "unknown-rar", now)
+	s.Require().ErrorIs(err, entities.ErrRegistrarNotFound)
+}
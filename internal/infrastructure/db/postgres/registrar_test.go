@@ -27,6 +27,16 @@ func getValidRegistrar() *entities.Registrar {
 			Name: entities.DomainName("whois.myregistrar.com"),
 			URL:  entities.URL("http://whois.myregistrar.com"),
 		},
+		EPPLogin: entities.EPPLoginState{
+			PasswordChangedAt: cr,
+			FailedLogins:      2,
+			LastLoginAt:       cr,
+			UserAgent: entities.EPPUserAgent{
+				App:  "EPP SDK 1.0.0",
+				Tech: "Go 1.22",
+				OS:   "x86_64 Linux",
+			},
+		},
 		CreatedAt: cr,
 		UpdatedAt: cr,
 	}
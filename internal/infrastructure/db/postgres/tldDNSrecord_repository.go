@@ -35,6 +35,15 @@ func (r *DNSRecordRepository) GetByZone(ctx context.Context, zone string) ([]*TL
 	return records, nil
 }
 
+// Update updates an existing DNS record in the database
+func (r *DNSRecordRepository) Update(ctx context.Context, record *TLDDNSRecord) (*TLDDNSRecord, error) {
+	err := r.db.WithContext(ctx).Save(record).Error
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
 // Delete deletes a DNS record from the database
 func (r *DNSRecordRepository) Delete(ctx context.Context, id int) error {
 	err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&TLDDNSRecord{}).Error
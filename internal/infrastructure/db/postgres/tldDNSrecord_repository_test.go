@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/miekg/dns"
 	"github.com/onasunnymorning/domain-os/internal/domain/entities"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
@@ -127,3 +128,31 @@ func (s *DNSRecordSuite) TestGetDNSRecordsByZone() {
 	s.Require().NoError(err)
 	s.Require().Len(createdRecords, 0)
 }
+
+func (s *DNSRecordSuite) TestUpdateDNSRecord() {
+	record := &TLDDNSRecord{
+		Zone: "waves",
+		Name: "waves",
+		Type: "SOA",
+		TTL:  3600,
+		Data: `{"ns": "ns1.nic.waves", "mbox": "hostmaster.nic.waves", "serial": 1, "refresh": 1800, "retry": 900, "expire": 604800, "minttl": 86400}`,
+	}
+
+	repo := NewGormDNSRecordRepository(s.db)
+	createdRecord, err := repo.Create(context.Background(), record)
+	s.Require().NoError(err)
+
+	createdRecord.Data = `{"ns": "ns1.nic.waves", "mbox": "hostmaster.nic.waves", "serial": 2, "refresh": 1800, "retry": 900, "expire": 604800, "minttl": 86400}`
+	_, err = repo.Update(context.Background(), createdRecord)
+	s.Require().NoError(err)
+
+	records, err := repo.GetByZone(context.Background(), "waves")
+	s.Require().NoError(err)
+	s.Require().Len(records, 1)
+	rr, err := records[0].ToRR()
+	s.Require().NoError(err)
+	s.Require().Equal(uint32(2), rr.(*dns.SOA).Serial)
+
+	err = repo.Delete(context.Background(), createdRecord.ID)
+	s.Require().NoError(err)
+}
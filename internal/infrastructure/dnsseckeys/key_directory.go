@@ -0,0 +1,227 @@
+package dnsseckeys
+
+import (
+	"bufio"
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+const (
+	// timingFormat is the format of the timing metadata in BIND private key files
+	timingFormat = "20060102150405"
+)
+
+var (
+	ErrKeyFileExists = errors.New("key file already exists")
+)
+
+// KeyDirectory stores DNSSEC keys as BIND key files (K<zone>+<alg>+<keytag>.key and .private) in a directory, so keys can be shared with
+// (or created by) BIND's dnssec-keygen. The timing metadata is kept in the private key file. The DS records are written to dsset-<zone> files.
+// Ref: https://bind9.readthedocs.io/en/latest/manpages.html#dnssec-keygen-dnssec-key-generation-tool
+type KeyDirectory struct {
+	dir string
+}
+
+// NewKeyDirectory creates a new KeyDirectory for the directory, which must exist
+func NewKeyDirectory(dir string) *KeyDirectory {
+	return &KeyDirectory{dir: dir}
+}
+
+// ListByZone reads all keys of the zone from the directory
+func (d *KeyDirectory) ListByZone(ctx context.Context, zone string) ([]*entities.DNSSECKey, error) {
+	pattern := filepath.Join(d.dir, "K"+escapeGlob(dns.CanonicalName(zone))+"+*.key")
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*entities.DNSSECKey, 0, len(files))
+	for _, file := range files {
+		key, err := readKey(strings.TrimSuffix(file, ".key"))
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// Create writes the key files for a new key, it fails if the key already exists
+func (d *KeyDirectory) Create(ctx context.Context, key *entities.DNSSECKey) error {
+	base := d.keyFileBase(key)
+	if _, err := os.Stat(base + ".key"); err == nil {
+		return fmt.Errorf("%w: %s", ErrKeyFileExists, base+".key")
+	}
+	return writeKey(base, key)
+}
+
+// Update rewrites the key files of an existing key to store its timing metadata
+func (d *KeyDirectory) Update(ctx context.Context, key *entities.DNSSECKey) error {
+	base := d.keyFileBase(key)
+	if _, err := os.Stat(base + ".key"); errors.Is(err, fs.ErrNotExist) {
+		return errors.Join(entities.ErrDNSSECKeyNotFound, err)
+	}
+	return writeKey(base, key)
+}
+
+// Delete removes the key files
+func (d *KeyDirectory) Delete(ctx context.Context, key *entities.DNSSECKey) error {
+	base := d.keyFileBase(key)
+	for _, file := range []string{base + ".key", base + ".private"} {
+		if err := os.Remove(file); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveDS writes the DS records to the dsset-<zone> file
+func (d *KeyDirectory) SaveDS(ctx context.Context, zone string, ds []*dns.DS) error {
+	var b strings.Builder
+	for _, rr := range ds {
+		b.WriteString(rr.String() + "\n")
+	}
+	return writeFileAtomic(filepath.Join(d.dir, "dsset-"+dns.CanonicalName(zone)), []byte(b.String()), 0o644)
+}
+
+// keyFileBase returns the path of the key files without extension
+func (d *KeyDirectory) keyFileBase(key *entities.DNSSECKey) string {
+	return filepath.Join(d.dir, fmt.Sprintf("K%s+%03d+%05d", key.Zone(), key.DNSKEY.Algorithm, key.KeyTag()))
+}
+
+// readKey reads the public and private key files
+func readKey(base string) (*entities.DNSSECKey, error) {
+	pub, err := os.Open(base + ".key")
+	if err != nil {
+		return nil, err
+	}
+	defer pub.Close()
+	rr, err := dns.ReadRR(pub, base+".key")
+	if err != nil {
+		return nil, errors.Join(entities.ErrInvalidDNSSECKey, err)
+	}
+	dnskey, ok := rr.(*dns.DNSKEY)
+	if !ok {
+		return nil, errors.Join(entities.ErrInvalidDNSSECKey, fmt.Errorf("%s does not contain a DNSKEY record", base+".key"))
+	}
+
+	data, err := os.ReadFile(base + ".private")
+	if err != nil {
+		return nil, err
+	}
+	priv, err := dnskey.NewPrivateKey(string(data))
+	if err != nil {
+		return nil, errors.Join(entities.ErrInvalidDNSSECKey, fmt.Errorf("%s: %w", base+".private", err))
+	}
+	signer, ok := priv.(crypto.Signer)
+	if !ok {
+		return nil, errors.Join(entities.ErrInvalidDNSSECKey, entities.ErrUnsupportedDNSSECAlgorithm)
+	}
+
+	key := &entities.DNSSECKey{DNSKEY: dnskey, PrivateKey: signer}
+	if err := parseTiming(key, string(data)); err != nil {
+		return nil, errors.Join(entities.ErrInvalidDNSSECKey, fmt.Errorf("%s: %w", base+".private", err))
+	}
+	return key, nil
+}
+
+// writeKey writes the public and private key files
+func writeKey(base string, key *entities.DNSSECKey) error {
+	pub := fmt.Sprintf("; This is a %s, keyid %d, for %s\n%s\n", keyDescription(key), key.KeyTag(), key.Zone(), key.DNSKEY.String())
+	if err := writeFileAtomic(base+".key", []byte(pub), 0o644); err != nil {
+		return err
+	}
+
+	priv := key.DNSKEY.PrivateKeyString(key.PrivateKey)
+	for _, t := range []struct {
+		name string
+		time time.Time
+	}{
+		{"Created", key.Created},
+		{"Publish", key.Publish},
+		{"Activate", key.Activate},
+		{"Inactive", key.Inactive},
+		{"Delete", key.Delete},
+	} {
+		if !t.time.IsZero() {
+			priv += fmt.Sprintf("%s: %s\n", t.name, t.time.UTC().Format(timingFormat))
+		}
+	}
+	return writeFileAtomic(base+".private", []byte(priv), 0o600)
+}
+
+// parseTiming sets the timing metadata of the key from the private key file
+func parseTiming(key *entities.DNSSECKey, data string) error {
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		name, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		var field *time.Time
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "created":
+			field = &key.Created
+		case "publish":
+			field = &key.Publish
+		case "activate":
+			field = &key.Activate
+		case "inactive":
+			field = &key.Inactive
+		case "delete":
+			field = &key.Delete
+		default:
+			continue
+		}
+		t, err := time.Parse(timingFormat, strings.TrimSpace(value))
+		if err != nil {
+			return err
+		}
+		*field = t
+	}
+	return scanner.Err()
+}
+
+// keyDescription returns the description of the key type as used in the comment of BIND key files
+func keyDescription(key *entities.DNSSECKey) string {
+	if key.Type() == entities.DNSSECKeyTypeKSK {
+		return "key-signing key"
+	}
+	return "zone-signing key"
+}
+
+// writeFileAtomic writes the file through a temporary file, so readers never see a partially written file
+func writeFileAtomic(name string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+// escapeGlob escapes the characters of a zone name that have a special meaning in a glob pattern
+func escapeGlob(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`, "[", `\[`)
+	return r.Replace(s)
+}
@@ -0,0 +1,108 @@
+package dnsseckeys
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyDirectory(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	kd := NewKeyDirectory(dir)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	ksk, err := entities.NewDNSSECKey("example", dns.ECDSAP256SHA256, entities.DNSSECKeyTypeKSK, 3600, now)
+	require.NoError(t, err)
+	ksk.Publish, ksk.Activate = now, now
+	require.NoError(t, kd.Create(ctx, ksk))
+	require.ErrorIs(t, kd.Create(ctx, ksk), ErrKeyFileExists)
+
+	// Keys of other zones are not listed
+	other, err := entities.NewDNSSECKey("sub.example", dns.ED25519, entities.DNSSECKeyTypeZSK, 3600, now)
+	require.NoError(t, err)
+	require.NoError(t, kd.Create(ctx, other))
+
+	base := filepath.Join(dir, "Kexample.+013+"+padKeyTag(ksk.KeyTag()))
+	require.FileExists(t, base+".key")
+	info, err := os.Stat(base + ".private")
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	keys, err := kd.ListByZone(ctx, "example.")
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.Equal(t, ksk.DNSKEY.String(), keys[0].DNSKEY.String())
+	require.Equal(t, ksk.PrivateKey, keys[0].PrivateKey)
+	require.Equal(t, now, keys[0].Created)
+	require.Equal(t, now, keys[0].Activate)
+	require.True(t, keys[0].Inactive.IsZero())
+
+	// Update stores the timing metadata
+	keys[0].Inactive = now.Add(time.Hour)
+	require.NoError(t, kd.Update(ctx, keys[0]))
+	keys, err = kd.ListByZone(ctx, "example")
+	require.NoError(t, err)
+	require.Equal(t, now.Add(time.Hour), keys[0].Inactive)
+
+	require.NoError(t, kd.Delete(ctx, keys[0]))
+	keys, err = kd.ListByZone(ctx, "example")
+	require.NoError(t, err)
+	require.Empty(t, keys)
+	require.ErrorIs(t, kd.Update(ctx, ksk), entities.ErrDNSSECKeyNotFound)
+}
+
+func TestKeyDirectory_BINDKeyFiles(t *testing.T) {
+	// Key files as written by dnssec-keygen -a ECDSAP256SHA256 -f KSK example
+	dir := t.TempDir()
+	base := filepath.Join(dir, "Kexample.+013+52970")
+	require.NoError(t, os.WriteFile(base+".key", []byte(`; This is a key-signing key, keyid 52970, for example.
+; Created: 20240101120000 (Mon Jan  1 12:00:00 2024)
+; Publish: 20240101120000 (Mon Jan  1 12:00:00 2024)
+; Activate: 20240101120000 (Mon Jan  1 12:00:00 2024)
+example. IN DNSKEY 257 3 13 CQ9zLwDEK0zaZIwFrHoFtru21ONRrFQIjwXASjfbWu+d/pToxPhM iEnjp6mJTVnmIlRIjLTsSwgkb3mCr+Yfsg==
+`), 0o644))
+	require.NoError(t, os.WriteFile(base+".private", []byte(`Private-key-format: v1.3
+Algorithm: 13 (ECDSAP256SHA256)
+PrivateKey: R3KX6ZL9rgiFpmRKNUMdx4F52YTZRVPJP3cCFbQbCpc=
+Created: 20240101120000
+Publish: 20240101120000
+Activate: 20240101120000
+`), 0o600))
+
+	keys, err := NewKeyDirectory(dir).ListByZone(context.Background(), "example.")
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.Equal(t, entities.DNSSECKeyTypeKSK, keys[0].Type())
+	require.Equal(t, uint16(52970), keys[0].KeyTag())
+	require.True(t, keys[0].IsActive(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)))
+
+	// The private key matches the public key
+	rr, err := dns.NewRR("example. 3600 IN NS ns1.example.")
+	require.NoError(t, err)
+	sig := &dns.RRSIG{Algorithm: dns.ECDSAP256SHA256, KeyTag: keys[0].KeyTag(), SignerName: "example.", Expiration: 1}
+	require.NoError(t, sig.Sign(keys[0].PrivateKey, []dns.RR{rr}))
+	require.NoError(t, sig.Verify(keys[0].DNSKEY, []dns.RR{rr}))
+}
+
+func TestKeyDirectory_SaveDS(t *testing.T) {
+	dir := t.TempDir()
+	rr, err := dns.NewRR("example. 3600 IN DS 52970 13 2 E2D3C916F6DEEAC73294E8268FB5885044A833FC5459588F4A9184CFC41A5766")
+	require.NoError(t, err)
+	require.NoError(t, NewKeyDirectory(dir).SaveDS(context.Background(), "example", []*dns.DS{rr.(*dns.DS)}))
+
+	data, err := os.ReadFile(filepath.Join(dir, "dsset-example."))
+	require.NoError(t, err)
+	require.Equal(t, rr.String()+"\n", string(data))
+}
+
+func padKeyTag(tag uint16) string {
+	return fmt.Sprintf("%05d", tag)
+}
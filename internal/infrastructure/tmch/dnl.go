@@ -0,0 +1,86 @@
+package tmch
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+var (
+	ErrInvalidDNL = errors.New("invalid DNL file")
+)
+
+// DNL is the TMCH Domain Name Label list: the labels that match a trademark with the lookup key to retrieve their claims notice.
+// It implements the ClaimsRepository, registries download the list from the TMCH regularly.
+// Ref: https://datatracker.ietf.org/doc/html/rfc9361
+type DNL struct {
+	claimKeys map[string]string
+}
+
+// NewDNL creates a new empty DNL, no label matches a trademark
+func NewDNL() *DNL {
+	return &DNL{claimKeys: map[string]string{}}
+}
+
+// LoadDNLFile reads the DNL from a file in the CSV format of the TMCH
+func LoadDNLFile(name string) (*DNL, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadDNL(f)
+}
+
+// ReadDNL reads the DNL in the CSV format of the TMCH. The first line holds the version and creation time,
+// the second line is the header "DNL,lookup-key,insertion-datetime" and each following line is a label.
+func ReadDNL(r io.Reader) (*DNL, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	version, err := reader.Read()
+	if err != nil {
+		return nil, errors.Join(ErrInvalidDNL, err)
+	}
+	if len(version) != 2 || version[0] != "1" {
+		return nil, errors.Join(ErrInvalidDNL, fmt.Errorf("unsupported version line: %s", strings.Join(version, ",")))
+	}
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.Join(ErrInvalidDNL, err)
+	}
+	if len(header) != 3 || header[0] != "DNL" || header[1] != "lookup-key" {
+		return nil, errors.Join(ErrInvalidDNL, fmt.Errorf("unexpected header: %s", strings.Join(header, ",")))
+	}
+
+	dnl := NewDNL()
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Join(ErrInvalidDNL, err)
+		}
+		if len(record) != 3 || record[0] == "" || record[1] == "" {
+			line, _ := reader.FieldPos(0)
+			return nil, errors.Join(ErrInvalidDNL, fmt.Errorf("line %d: expected label, lookup key and insertion time", line))
+		}
+		dnl.claimKeys[strings.ToLower(record[0])] = record[1]
+	}
+	return dnl, nil
+}
+
+// GetClaimKey returns the lookup key of the label, or an empty string if the label is not on the DNL
+func (d *DNL) GetClaimKey(ctx context.Context, label string) (string, error) {
+	return d.claimKeys[strings.ToLower(label)], nil
+}
+
+// Len returns the number of labels on the DNL
+func (d *DNL) Len() int {
+	return len(d.claimKeys)
+}
@@ -0,0 +1,69 @@
+package tmch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testDNL = `1,2013-11-19T12:33:01.6Z
+DNL,lookup-key,insertion-datetime
+example,2013041500/2/6/9/rJ1NrDO92vDsAzf7EQzgjX4R0000000001,2010-07-14T00:00:00.0Z
+another-example,2013041500/6/A/5/alJAqG2vI2BmCv5PfUvuDkf40000000002,2012-08-16T00:00:00.0Z
+`
+
+func TestReadDNL(t *testing.T) {
+	dnl, err := ReadDNL(strings.NewReader(testDNL))
+	require.NoError(t, err)
+	require.Equal(t, 2, dnl.Len())
+
+	key, err := dnl.GetClaimKey(context.Background(), "Example")
+	require.NoError(t, err)
+	require.Equal(t, "2013041500/2/6/9/rJ1NrDO92vDsAzf7EQzgjX4R0000000001", key)
+
+	key, err = dnl.GetClaimKey(context.Background(), "no-claims")
+	require.NoError(t, err)
+	require.Empty(t, key)
+}
+
+func TestReadDNL_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		dnl  string
+	}{
+		{"empty", ""},
+		{"unsupported version", "2,2013-11-19T12:33:01.6Z\nDNL,lookup-key,insertion-datetime\n"},
+		{"missing header", "1,2013-11-19T12:33:01.6Z\n"},
+		{"wrong header", "1,2013-11-19T12:33:01.6Z\nSURL,lookup-key,insertion-datetime\n"},
+		{"missing lookup key", "1,2013-11-19T12:33:01.6Z\nDNL,lookup-key,insertion-datetime\nexample,,2010-07-14T00:00:00.0Z\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadDNL(strings.NewReader(tt.dnl))
+			require.ErrorIs(t, err, ErrInvalidDNL)
+		})
+	}
+}
+
+func TestLoadDNLFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "dnl-latest.csv")
+	require.NoError(t, os.WriteFile(name, []byte(testDNL), 0o644))
+
+	dnl, err := LoadDNLFile(name)
+	require.NoError(t, err)
+	require.Equal(t, 2, dnl.Len())
+
+	_, err = LoadDNLFile(filepath.Join(t.TempDir(), "missing.csv"))
+	require.Error(t, err)
+}
+
+func TestNewDNL(t *testing.T) {
+	key, err := NewDNL().GetClaimKey(context.Background(), "example")
+	require.NoError(t, err)
+	require.Empty(t, key)
+}
@@ -0,0 +1,176 @@
+package zonejournal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/miekg/dns"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+var (
+	ErrInvalidJournal = errors.New("invalid zone journal")
+)
+
+// JournalDirectory stores the published version of each zone as a master file (<zone>zone, e.g. example.zone) and its journal (<zone>jnl) in a directory.
+// The journal holds the changes in the order they were published, each change is written like in an incremental zone transfer:
+// the old SOA, the deleted records, the new SOA and the added records.
+// Ref: https://datatracker.ietf.org/doc/html/rfc1995#section-4
+type JournalDirectory struct {
+	dir string
+}
+
+// NewJournalDirectory creates a new JournalDirectory for the directory, which must exist
+func NewJournalDirectory(dir string) *JournalDirectory {
+	return &JournalDirectory{dir: dir}
+}
+
+// GetByZone reads the published version of the zone and its journal, the journal is empty if the zone has no changes yet.
+// It returns entities.ErrZoneJournalNotFound if the zone was never stored.
+func (d *JournalDirectory) GetByZone(ctx context.Context, origin string) (*entities.Zone, *entities.ZoneJournal, error) {
+	origin = dns.CanonicalName(origin)
+	zone, err := readZone(d.file(origin, "zone"), origin)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, errors.Join(entities.ErrZoneJournalNotFound, err)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	journal, err := readJournal(d.file(origin, "jnl"), origin)
+	if errors.Is(err, fs.ErrNotExist) {
+		return zone, &entities.ZoneJournal{}, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return zone, journal, nil
+}
+
+// Save writes the published version of the zone and its journal, each file is replaced atomically
+func (d *JournalDirectory) Save(ctx context.Context, zone *entities.Zone, journal *entities.ZoneJournal) error {
+	if err := writeFileAtomic(d.file(zone.Origin, "zone"), []byte(zone.String()), 0o644); err != nil {
+		return err
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "; journal of %s\n", zone.Origin)
+	for _, diff := range journal.Diffs {
+		b.WriteString(diff.FromSOA.String() + "\n")
+		for _, rr := range diff.Deleted {
+			b.WriteString(rr.String() + "\n")
+		}
+		b.WriteString(diff.ToSOA.String() + "\n")
+		for _, rr := range diff.Added {
+			b.WriteString(rr.String() + "\n")
+		}
+	}
+	return writeFileAtomic(d.file(zone.Origin, "jnl"), []byte(b.String()), 0o644)
+}
+
+// file returns the path of the file of the zone with the extension
+func (d *JournalDirectory) file(origin, ext string) string {
+	return filepath.Join(d.dir, dns.CanonicalName(origin)+ext)
+}
+
+// readZone reads a zone from its master file
+func readZone(file, origin string) (*entities.Zone, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	rrs, err := readRecords(f, file, origin)
+	if err != nil {
+		return nil, err
+	}
+	if len(rrs) == 0 {
+		return nil, errors.Join(entities.ErrInvalidZone, entities.ErrZoneMissingSOA)
+	}
+	soa, ok := rrs[0].(*dns.SOA)
+	if !ok {
+		return nil, errors.Join(entities.ErrInvalidZone, entities.ErrZoneMissingSOA)
+	}
+	zone, err := entities.NewZone(origin, soa)
+	if err != nil {
+		return nil, err
+	}
+	if err := zone.AddRecords(rrs[1:]...); err != nil {
+		return nil, err
+	}
+	return zone, nil
+}
+
+// readJournal reads a journal, every change starts with the old SOA and the records up to the new SOA are the deleted records
+func readJournal(file, origin string) (*entities.ZoneJournal, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	rrs, err := readRecords(f, file, origin)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidJournal, err)
+	}
+
+	journal := &entities.ZoneJournal{}
+	var diff *entities.ZoneDiff
+	for _, rr := range rrs {
+		soa, isSOA := rr.(*dns.SOA)
+		switch {
+		case isSOA && (diff == nil || diff.ToSOA != nil):
+			diff = &entities.ZoneDiff{FromSOA: soa}
+			journal.Diffs = append(journal.Diffs, diff)
+		case isSOA:
+			diff.ToSOA = soa
+		case diff == nil:
+			return nil, errors.Join(ErrInvalidJournal, fmt.Errorf("%s: a change must start with a SOA record", file))
+		case diff.ToSOA == nil:
+			diff.Deleted = append(diff.Deleted, rr)
+		default:
+			diff.Added = append(diff.Added, rr)
+		}
+	}
+	if diff != nil && diff.ToSOA == nil {
+		return nil, errors.Join(ErrInvalidJournal, fmt.Errorf("%s: the last change has no new SOA record", file))
+	}
+	return journal, nil
+}
+
+// readRecords parses all records of a master file
+func readRecords(r io.Reader, file, origin string) ([]dns.RR, error) {
+	zp := dns.NewZoneParser(r, origin, file)
+	var rrs []dns.RR
+	for rr, ok := zp.Next(); ok; rr, ok = zp.Next() {
+		rrs = append(rrs, rr)
+	}
+	if err := zp.Err(); err != nil {
+		return nil, err
+	}
+	return rrs, nil
+}
+
+// writeFileAtomic writes the data to a temporary file in the same directory and renames it, so readers never see a partially written file
+func writeFileAtomic(name string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
@@ -0,0 +1,82 @@
+package zonejournal
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestZone returns the example. zone with the serial and the records
+func newTestZone(t *testing.T, serial uint32, records ...string) *entities.Zone {
+	t.Helper()
+	soa, err := dns.NewRR("example. 3600 IN SOA ns1.nic.example. hostmaster.nic.example. 1 1800 900 604800 86400")
+	require.NoError(t, err)
+	soa.(*dns.SOA).Serial = serial
+	zone, err := entities.NewZone("example.", soa.(*dns.SOA))
+	require.NoError(t, err)
+	for _, s := range records {
+		rr, err := dns.NewRR(s)
+		require.NoError(t, err)
+		require.NoError(t, zone.AddRecords(rr))
+	}
+	return zone
+}
+
+func TestJournalDirectory(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	jd := NewJournalDirectory(dir)
+
+	_, _, err := jd.GetByZone(ctx, "example")
+	require.ErrorIs(t, err, entities.ErrZoneJournalNotFound)
+
+	v1 := newTestZone(t, 1, "example. 3600 IN NS ns.other.net.", "a.example. 3600 IN NS ns.other.net.", "b.example. 3600 IN NS ns.other.net.")
+	v2 := newTestZone(t, 2, "example. 3600 IN NS ns.other.net.", "a.example. 3600 IN NS ns.other.net.", "c.example. 3600 IN NS ns.other.net.")
+	v3 := newTestZone(t, 3, "example. 3600 IN NS ns.other.net.", "a.example. 3600 IN NS ns.other.net.", "c.example. 3600 IN NS ns.other.net.", "d.example. 3600 IN NS ns.other.net.")
+	journal := entities.NewZoneJournal(10)
+	for _, versions := range [][2]*entities.Zone{{v1, v2}, {v2, v3}} {
+		diff, err := entities.NewZoneDiff(versions[0], versions[1])
+		require.NoError(t, err)
+		journal.Add(diff)
+	}
+
+	// A zone without changes has an empty journal
+	require.NoError(t, jd.Save(ctx, v1, &entities.ZoneJournal{}))
+	zone, stored, err := jd.GetByZone(ctx, "example.")
+	require.NoError(t, err)
+	require.Equal(t, v1.String(), zone.String())
+	require.Empty(t, stored.Diffs)
+
+	require.NoError(t, jd.Save(ctx, v3, journal))
+	require.FileExists(t, filepath.Join(dir, "example.zone"))
+	require.FileExists(t, filepath.Join(dir, "example.jnl"))
+
+	zone, stored, err = jd.GetByZone(ctx, "example")
+	require.NoError(t, err)
+	require.Equal(t, v3.String(), zone.String())
+	require.Len(t, stored.Diffs, 2)
+	for i, diff := range journal.Diffs {
+		require.Equal(t, diff.FromSOA.String(), stored.Diffs[i].FromSOA.String())
+		require.Equal(t, diff.ToSOA.String(), stored.Diffs[i].ToSOA.String())
+		require.Equal(t, len(diff.Deleted), len(stored.Diffs[i].Deleted))
+		require.Equal(t, len(diff.Added), len(stored.Diffs[i].Added))
+	}
+	require.Equal(t, "b.example.", stored.Diffs[0].Deleted[0].Header().Name)
+	require.Equal(t, "c.example.", stored.Diffs[0].Added[0].Header().Name)
+	require.Empty(t, stored.Diffs[1].Deleted)
+	require.Equal(t, "d.example.", stored.Diffs[1].Added[0].Header().Name)
+
+	diffs, ok := stored.DiffsSince(1)
+	require.True(t, ok)
+	require.Len(t, diffs, 2)
+
+	// A journal that ends in the middle of a change is invalid
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "example.jnl"), []byte(v1.SOA.String()+"\nb.example. 3600 IN NS ns.other.net.\n"), 0o644))
+	_, _, err = jd.GetByZone(ctx, "example.")
+	require.ErrorIs(t, err, ErrInvalidJournal)
+}
@@ -0,0 +1,151 @@
+package dnsserver
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+const (
+	// DefaultRefreshInterval is the default interval at which the zones are rebuilt to pick up changes
+	DefaultRefreshInterval = time.Minute
+
+	// Environment variables used by ParseConfig
+	EnvSecondaries       = "DNS_SECONDARIES"         // comma separated list of IP[:port] that receive a NOTIFY when a zone changes, port defaults to 53
+	EnvAllowTransfer     = "DNS_ALLOW_TRANSFER"      // comma separated list of networks (CIDR) that can transfer zones without TSIG
+	EnvTSIGKeys          = "DNS_TSIG_KEYS"           // comma separated list of [algorithm:]name:secret (as used by dig -y), algorithm defaults to hmac-sha256
+	EnvNotifyTSIGKey     = "DNS_NOTIFY_TSIG_KEY"     // name of the TSIG key used to sign NOTIFY messages, NOTIFY messages are not signed if empty
+	EnvRefreshInterval   = "DNS_REFRESH_INTERVAL"    // Go duration, defaults to DefaultRefreshInterval
+	EnvMaxJournalEntries = "DNS_MAX_JOURNAL_ENTRIES" // number of zone changes kept per zone for IXFR, defaults to entities.DefaultMaxZoneJournalEntries
+)
+
+var (
+	ErrInvalidDNSServerConfig = errors.New("invalid DNS server configuration")
+	ErrUnknownNotifyTSIGKey   = errors.New("the NOTIFY TSIG key is not one of the configured TSIG keys")
+)
+
+// TSIGKey is a shared secret used to authenticate zone transfers and NOTIFY messages
+// Ref: https://datatracker.ietf.org/doc/html/rfc8945
+type TSIGKey struct {
+	Name      string // FQDN
+	Algorithm string // FQDN, e.g. dns.HmacSHA256
+	Secret    string // base64 encoded
+}
+
+// Config holds the configuration of the primary DNS server
+type Config struct {
+	Secondaries       []netip.AddrPort
+	AllowTransfer     []netip.Prefix
+	TSIGKeys          []TSIGKey
+	NotifyTSIGKey     string
+	RefreshInterval   time.Duration
+	MaxJournalEntries int
+}
+
+// ParseConfig reads the configuration from the environment using getenv (e.g. os.Getenv)
+func ParseConfig(getenv func(string) string) (*Config, error) {
+	cfg := &Config{
+		RefreshInterval:   DefaultRefreshInterval,
+		MaxJournalEntries: entities.DefaultMaxZoneJournalEntries,
+	}
+
+	for _, s := range splitList(getenv(EnvSecondaries)) {
+		addrPort, err := netip.ParseAddrPort(s)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(s)
+			if addrErr != nil {
+				return nil, errors.Join(ErrInvalidDNSServerConfig, fmt.Errorf("%s: %w", EnvSecondaries, err))
+			}
+			addrPort = netip.AddrPortFrom(addr, 53)
+		}
+		cfg.Secondaries = append(cfg.Secondaries, addrPort)
+	}
+
+	for _, s := range splitList(getenv(EnvAllowTransfer)) {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return nil, errors.Join(ErrInvalidDNSServerConfig, fmt.Errorf("%s: %w", EnvAllowTransfer, err))
+		}
+		cfg.AllowTransfer = append(cfg.AllowTransfer, prefix.Masked())
+	}
+
+	for _, s := range splitList(getenv(EnvTSIGKeys)) {
+		key := TSIGKey{Algorithm: dns.HmacSHA256}
+		parts := strings.Split(s, ":")
+		switch len(parts) {
+		case 2:
+			key.Name, key.Secret = parts[0], parts[1]
+		case 3:
+			key.Algorithm, key.Name, key.Secret = dns.Fqdn(strings.ToLower(parts[0])), parts[1], parts[2]
+		default:
+			return nil, errors.Join(ErrInvalidDNSServerConfig, fmt.Errorf("%s: expected [algorithm:]name:secret", EnvTSIGKeys))
+		}
+		key.Name = dns.CanonicalName(key.Name)
+		if _, err := base64.StdEncoding.DecodeString(key.Secret); err != nil {
+			return nil, errors.Join(ErrInvalidDNSServerConfig, fmt.Errorf("%s: secret of %s is not base64 encoded", EnvTSIGKeys, key.Name))
+		}
+		cfg.TSIGKeys = append(cfg.TSIGKeys, key)
+	}
+
+	if name := getenv(EnvNotifyTSIGKey); name != "" {
+		cfg.NotifyTSIGKey = dns.CanonicalName(name)
+		if _, ok := cfg.tsigKey(cfg.NotifyTSIGKey); !ok {
+			return nil, errors.Join(ErrInvalidDNSServerConfig, ErrUnknownNotifyTSIGKey)
+		}
+	}
+
+	if s := getenv(EnvRefreshInterval); s != "" {
+		interval, err := time.ParseDuration(s)
+		if err != nil || interval <= 0 {
+			return nil, errors.Join(ErrInvalidDNSServerConfig, fmt.Errorf("%s: must be a positive duration", EnvRefreshInterval))
+		}
+		cfg.RefreshInterval = interval
+	}
+
+	if s := getenv(EnvMaxJournalEntries); s != "" {
+		entries, err := strconv.Atoi(s)
+		if err != nil || entries <= 0 {
+			return nil, errors.Join(ErrInvalidDNSServerConfig, fmt.Errorf("%s: must be a positive number", EnvMaxJournalEntries))
+		}
+		cfg.MaxJournalEntries = entries
+	}
+
+	return cfg, nil
+}
+
+// TSIGSecrets returns the TSIG secrets by key name as used by dns.Server and dns.Client
+func (c *Config) TSIGSecrets() map[string]string {
+	secrets := make(map[string]string, len(c.TSIGKeys))
+	for _, key := range c.TSIGKeys {
+		secrets[key.Name] = key.Secret
+	}
+	return secrets
+}
+
+// tsigKey returns the TSIG key by name
+func (c *Config) tsigKey(name string) (TSIGKey, bool) {
+	for _, key := range c.TSIGKeys {
+		if key.Name == name {
+			return key, true
+		}
+	}
+	return TSIGKey{}, false
+}
+
+// splitList splits a comma separated list and trims the items, empty items are skipped
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
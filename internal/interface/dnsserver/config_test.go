@@ -0,0 +1,104 @@
+package dnsserver
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    *Config
+		wantErr error
+	}{
+		{
+			name: "defaults",
+			env:  map[string]string{},
+			want: &Config{RefreshInterval: DefaultRefreshInterval, MaxJournalEntries: entities.DefaultMaxZoneJournalEntries},
+		},
+		{
+			name: "all set",
+			env: map[string]string{
+				EnvSecondaries:       "192.0.2.1, [2001:db8::1]:5353,2001:db8::2",
+				EnvAllowTransfer:     "192.0.2.0/24,2001:db8::1/64",
+				EnvTSIGKeys:          "Transfer.Example:" + testTSIGSecret + ",hmac-sha512:notify.example.:" + testTSIGSecret,
+				EnvNotifyTSIGKey:     "notify.example",
+				EnvRefreshInterval:   "30s",
+				EnvMaxJournalEntries: "5",
+			},
+			want: &Config{
+				Secondaries: []netip.AddrPort{
+					netip.MustParseAddrPort("192.0.2.1:53"),
+					netip.MustParseAddrPort("[2001:db8::1]:5353"),
+					netip.MustParseAddrPort("[2001:db8::2]:53"),
+				},
+				AllowTransfer: []netip.Prefix{netip.MustParsePrefix("192.0.2.0/24"), netip.MustParsePrefix("2001:db8::/64")},
+				TSIGKeys: []TSIGKey{
+					{Name: "transfer.example.", Algorithm: dns.HmacSHA256, Secret: testTSIGSecret},
+					{Name: "notify.example.", Algorithm: dns.HmacSHA512, Secret: testTSIGSecret},
+				},
+				NotifyTSIGKey:     "notify.example.",
+				RefreshInterval:   30 * time.Second,
+				MaxJournalEntries: 5,
+			},
+		},
+		{
+			name:    "invalid secondary",
+			env:     map[string]string{EnvSecondaries: "ns1.example"},
+			wantErr: ErrInvalidDNSServerConfig,
+		},
+		{
+			name:    "invalid network",
+			env:     map[string]string{EnvAllowTransfer: "192.0.2.1"},
+			wantErr: ErrInvalidDNSServerConfig,
+		},
+		{
+			name:    "invalid TSIG key",
+			env:     map[string]string{EnvTSIGKeys: "transfer.example"},
+			wantErr: ErrInvalidDNSServerConfig,
+		},
+		{
+			name:    "TSIG secret not base64",
+			env:     map[string]string{EnvTSIGKeys: "transfer.example:not base64!"},
+			wantErr: ErrInvalidDNSServerConfig,
+		},
+		{
+			name:    "unknown NOTIFY key",
+			env:     map[string]string{EnvTSIGKeys: "transfer.example:" + testTSIGSecret, EnvNotifyTSIGKey: "notify.example"},
+			wantErr: ErrUnknownNotifyTSIGKey,
+		},
+		{
+			name:    "invalid refresh interval",
+			env:     map[string]string{EnvRefreshInterval: "-1m"},
+			wantErr: ErrInvalidDNSServerConfig,
+		},
+		{
+			name:    "invalid journal size",
+			env:     map[string]string{EnvMaxJournalEntries: "0"},
+			wantErr: ErrInvalidDNSServerConfig,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := ParseConfig(func(key string) string { return tc.env[key] })
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, cfg)
+		})
+	}
+}
+
+func TestConfig_TSIGSecrets(t *testing.T) {
+	cfg := Config{TSIGKeys: []TSIGKey{{Name: "transfer.example.", Algorithm: dns.HmacSHA256, Secret: testTSIGSecret}}}
+	require.Equal(t, map[string]string{"transfer.example.": testTSIGSecret}, cfg.TSIGSecrets())
+}
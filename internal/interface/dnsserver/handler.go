@@ -0,0 +1,185 @@
+package dnsserver
+
+import (
+	"log"
+	"net"
+	"net/netip"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+const (
+	// transferChunkSize is the number of records sent per message in a zone transfer
+	transferChunkSize = 256
+)
+
+// ServeDNS implements the dns.Handler interface. It answers SOA queries for the zone apex and zone transfers (AXFR and IXFR), all other queries are refused.
+func (p *Primary) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
+	m := new(dns.Msg)
+	if r.Opcode != dns.OpcodeQuery {
+		m.SetRcode(r, dns.RcodeNotImplemented)
+		p.reply(w, r, m)
+		return
+	}
+	if len(r.Question) != 1 {
+		m.SetRcode(r, dns.RcodeFormatError)
+		p.reply(w, r, m)
+		return
+	}
+
+	q := r.Question[0]
+	zone, journal := p.getZone(q.Name)
+	if zone == nil || q.Qclass != dns.ClassINET {
+		m.SetRcode(r, dns.RcodeRefused)
+		p.reply(w, r, m)
+		return
+	}
+
+	switch q.Qtype {
+	case dns.TypeSOA:
+		m.SetReply(r)
+		m.Authoritative = true
+		m.Answer = []dns.RR{zone.SOA}
+		p.reply(w, r, m)
+	case dns.TypeAXFR, dns.TypeIXFR:
+		if rcode := p.authorizeTransfer(w, r); rcode != dns.RcodeSuccess {
+			m.SetRcode(r, rcode)
+			p.reply(w, r, m)
+			return
+		}
+		if q.Qtype == dns.TypeIXFR {
+			p.serveIXFR(w, r, zone, journal)
+			return
+		}
+		p.serveAXFR(w, r, zone)
+	default:
+		m.SetRcode(r, dns.RcodeRefused)
+		p.reply(w, r, m)
+	}
+}
+
+// authorizeTransfer allows zone transfers that are signed with a valid TSIG key or that come from a network in AllowTransfer
+func (p *Primary) authorizeTransfer(w dns.ResponseWriter, r *dns.Msg) int {
+	if r.IsTsig() != nil {
+		if err := w.TsigStatus(); err != nil {
+			log.Printf("Refused zone transfer of %s to %s: %v", r.Question[0].Name, w.RemoteAddr(), err)
+			return dns.RcodeNotAuth
+		}
+		return dns.RcodeSuccess
+	}
+	source := remoteAddr(w)
+	for _, prefix := range p.cfg.AllowTransfer {
+		if prefix.Contains(source) {
+			return dns.RcodeSuccess
+		}
+	}
+	log.Printf("Refused zone transfer of %s to %s: not signed and not in the allowed networks", r.Question[0].Name, w.RemoteAddr())
+	return dns.RcodeRefused
+}
+
+// serveAXFR sends the full zone, starting and ending with the SOA record
+// Ref: https://datatracker.ietf.org/doc/html/rfc5936
+func (p *Primary) serveAXFR(w dns.ResponseWriter, r *dns.Msg, zone *entities.Zone) {
+	if isUDP(w) {
+		m := new(dns.Msg)
+		m.SetRcode(r, dns.RcodeRefused)
+		p.reply(w, r, m)
+		return
+	}
+	rrs := append(zone.Records(), zone.SOA)
+	log.Printf("AXFR of %s serial %d to %s", zone.Origin, zone.SOA.Serial, w.RemoteAddr())
+	p.transfer(w, r, rrs)
+}
+
+// serveIXFR sends the changes since the serial of the client. A full zone is sent if the changes are not available,
+// the current SOA is sent if the client is up to date or if the query came over UDP (to make the client retry over TCP).
+// Ref: https://datatracker.ietf.org/doc/html/rfc1995#section-4
+func (p *Primary) serveIXFR(w dns.ResponseWriter, r *dns.Msg, zone *entities.Zone, journal *entities.ZoneJournal) {
+	var clientSOA *dns.SOA
+	for _, rr := range r.Ns {
+		if soa, ok := rr.(*dns.SOA); ok {
+			clientSOA = soa
+		}
+	}
+	if clientSOA == nil {
+		m := new(dns.Msg)
+		m.SetRcode(r, dns.RcodeFormatError)
+		p.reply(w, r, m)
+		return
+	}
+
+	if clientSOA.Serial == zone.SOA.Serial || isUDP(w) {
+		m := new(dns.Msg)
+		m.SetReply(r)
+		m.Authoritative = true
+		m.Answer = []dns.RR{zone.SOA}
+		p.reply(w, r, m)
+		return
+	}
+
+	diffs, ok := journal.DiffsSince(clientSOA.Serial)
+	if !ok {
+		p.serveAXFR(w, r, zone)
+		return
+	}
+	rrs := []dns.RR{zone.SOA}
+	for _, diff := range diffs {
+		rrs = append(rrs, diff.FromSOA)
+		rrs = append(rrs, diff.Deleted...)
+		rrs = append(rrs, diff.ToSOA)
+		rrs = append(rrs, diff.Added...)
+	}
+	rrs = append(rrs, zone.SOA)
+	log.Printf("IXFR of %s from serial %d to %d to %s", zone.Origin, clientSOA.Serial, zone.SOA.Serial, w.RemoteAddr())
+	p.transfer(w, r, rrs)
+}
+
+// transfer streams the records to the client in chunks
+func (p *Primary) transfer(w dns.ResponseWriter, r *dns.Msg, rrs []dns.RR) {
+	ch := make(chan *dns.Envelope)
+	done := make(chan struct{})
+	go func() {
+		defer close(ch)
+		for i := 0; i < len(rrs); i += transferChunkSize {
+			select {
+			case ch <- &dns.Envelope{RR: rrs[i:min(i+transferChunkSize, len(rrs))]}:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	tr := new(dns.Transfer)
+	if err := tr.Out(w, r, ch); err != nil {
+		log.Printf("Error transferring %s to %s: %v", r.Question[0].Name, w.RemoteAddr(), err)
+	}
+	close(done)
+	w.Close()
+}
+
+// reply writes the response, signing it if the request was signed with a valid TSIG key
+func (p *Primary) reply(w dns.ResponseWriter, r *dns.Msg, m *dns.Msg) {
+	if tsig := r.IsTsig(); tsig != nil && w.TsigStatus() == nil {
+		m.SetTsig(tsig.Hdr.Name, tsig.Algorithm, tsig.Fudge, time.Now().Unix())
+	}
+	if err := w.WriteMsg(m); err != nil {
+		log.Printf("Error writing response to %s: %v", w.RemoteAddr(), err)
+	}
+}
+
+// remoteAddr returns the IP address of the client
+func remoteAddr(w dns.ResponseWriter) netip.Addr {
+	addrPort, err := netip.ParseAddrPort(w.RemoteAddr().String())
+	if err != nil {
+		return netip.Addr{}
+	}
+	return addrPort.Addr().Unmap()
+}
+
+// isUDP checks if the query was received over UDP
+func isUDP(w dns.ResponseWriter) bool {
+	_, ok := w.RemoteAddr().(*net.UDPAddr)
+	return ok
+}
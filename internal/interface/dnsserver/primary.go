@@ -0,0 +1,270 @@
+package dnsserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/onasunnymorning/domain-os/internal/application/interfaces"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/domain/repositories"
+)
+
+const (
+	// NotifyTimeout is the time a secondary has to acknowledge a NOTIFY
+	NotifyTimeout = 5 * time.Second
+)
+
+// zoneState holds the published version of a zone and its recent changes
+type zoneState struct {
+	zone    *entities.Zone
+	journal *entities.ZoneJournal
+}
+
+// Primary is an authoritative primary (hidden master) for the TLD zones. It serves zone transfers (AXFR and IXFR) and SOA queries
+// and sends a NOTIFY to the secondaries when a zone changes. Zones are signed with DNSSEC if a signer is set.
+// The zones are rebuilt periodically from the registry data. A new SOA serial is only published when the content of the zone changed,
+// the changes are kept in a journal to serve incremental transfers. The published zones and their journals are stored in the journal repository,
+// so after a restart an unchanged zone keeps its serial and secondaries can keep transferring incrementally.
+// Without a journal repository the journals are only kept in memory, after a restart a new serial is published and secondaries fall back to a full transfer.
+type Primary struct {
+	zoneService  interfaces.ZoneService
+	signer       interfaces.ZoneSigner
+	journals     repositories.ZoneJournalRepository
+	cfg          Config
+	notifyClient *dns.Client
+
+	mu    sync.RWMutex
+	zones map[string]*zoneState // by origin
+}
+
+// NewPrimary creates a new Primary, the signer (nil serves unsigned zones) and the journal repository (nil keeps the journals in memory) are optional.
+// Use Refresh or Run to load the zones.
+func NewPrimary(zoneService interfaces.ZoneService, signer interfaces.ZoneSigner, journals repositories.ZoneJournalRepository, cfg Config) *Primary {
+	return &Primary{
+		zoneService: zoneService,
+		signer:      signer,
+		journals:    journals,
+		cfg:         cfg,
+		notifyClient: &dns.Client{
+			Net:        "udp",
+			Timeout:    NotifyTimeout,
+			TsigSecret: cfg.TSIGSecrets(),
+		},
+		zones: map[string]*zoneState{},
+	}
+}
+
+// Run refreshes the zones at the configured interval until the context is done
+func (p *Primary) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.cfg.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		if err := p.Refresh(ctx); err != nil {
+			log.Printf("Error refreshing zones: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh rebuilds the zones of all TLDs with DNS enabled and publishes the zones that changed.
+// Zones of TLDs that no longer have DNS enabled are no longer served.
+// A zone that fails to build keeps being served in its last published version.
+func (p *Primary) Refresh(ctx context.Context) error {
+	names, err := p.zoneService.ListZones(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	enabled := map[string]bool{}
+	for _, name := range names {
+		enabled[dns.CanonicalName(name)] = true
+		zone, changed, err := p.refreshZone(ctx, name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("zone %s: %w", name, err))
+			continue
+		}
+		if !changed {
+			continue
+		}
+		log.Printf("Published zone %s with serial %d", zone.Origin, zone.SOA.Serial)
+		if err := p.Notify(ctx, zone); err != nil {
+			errs = append(errs, fmt.Errorf("zone %s: %w", name, err))
+		}
+	}
+
+	p.mu.Lock()
+	for origin := range p.zones {
+		if !enabled[origin] {
+			delete(p.zones, origin)
+		}
+	}
+	p.mu.Unlock()
+
+	return errors.Join(errs...)
+}
+
+// refreshZone rebuilds a zone and publishes it with a new serial if it changed, signatures that are due for a refresh also change the zone
+func (p *Primary) refreshZone(ctx context.Context, name string) (*entities.Zone, bool, error) {
+	zone, err := p.zoneService.BuildZone(ctx, name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	p.mu.RLock()
+	state := p.zones[zone.Origin]
+	p.mu.RUnlock()
+
+	// Continue from the version that was published before a restart
+	if state == nil {
+		if state, err = p.loadZone(ctx, zone.Origin); err != nil {
+			return nil, false, err
+		}
+	}
+
+	// The zone was never published, so we don't know what was published with the current serial. Publish a new serial to be safe.
+	if state == nil {
+		if err := p.zoneService.PublishZone(ctx, zone); err != nil {
+			return nil, false, err
+		}
+		signed, err := p.sign(ctx, zone)
+		if err != nil {
+			return nil, false, err
+		}
+		state = &zoneState{zone: signed, journal: entities.NewZoneJournal(p.cfg.MaxJournalEntries)}
+		p.mu.Lock()
+		p.zones[zone.Origin] = state
+		p.mu.Unlock()
+		p.saveZone(ctx, state)
+		return signed, true, nil
+	}
+
+	// Compare with the published serial first
+	signed, err := p.sign(ctx, zone)
+	if err != nil {
+		return nil, false, err
+	}
+	diff, err := entities.NewZoneDiff(state.zone, signed)
+	if err != nil {
+		return nil, false, err
+	}
+	if diff.IsEmpty() {
+		return state.zone, false, nil
+	}
+	if err := p.zoneService.PublishZone(ctx, zone); err != nil {
+		return nil, false, err
+	}
+	// Sign again to cover the new serial
+	if signed, err = p.sign(ctx, zone); err != nil {
+		return nil, false, err
+	}
+	if diff, err = entities.NewZoneDiff(state.zone, signed); err != nil {
+		return nil, false, err
+	}
+
+	p.mu.Lock()
+	state.zone = signed
+	state.journal.Add(diff)
+	p.mu.Unlock()
+	p.saveZone(ctx, state)
+
+	return signed, true, nil
+}
+
+// loadZone serves the version of the zone that was published before a restart with its journal, the signer reuses its signatures.
+// It returns nil if there is no journal repository or the zone was never stored.
+func (p *Primary) loadZone(ctx context.Context, origin string) (*zoneState, error) {
+	if p.journals == nil {
+		return nil, nil
+	}
+	published, stored, err := p.journals.GetByZone(ctx, origin)
+	if errors.Is(err, entities.ErrZoneJournalNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if p.signer != nil {
+		if err := p.signer.LoadSignatures(ctx, published); err != nil {
+			return nil, err
+		}
+	}
+	journal := entities.NewZoneJournal(p.cfg.MaxJournalEntries)
+	for _, diff := range stored.Diffs {
+		journal.Add(diff)
+	}
+	state := &zoneState{zone: published, journal: journal}
+
+	p.mu.Lock()
+	p.zones[origin] = state
+	p.mu.Unlock()
+	log.Printf("Loaded zone %s with serial %d and %d changes", origin, published.SOA.Serial, len(journal.Diffs))
+	return state, nil
+}
+
+// saveZone stores the published version of the zone and its journal. The zone is already published, so a failure is only logged:
+// after a restart the zone is compared with the last version that was stored.
+func (p *Primary) saveZone(ctx context.Context, state *zoneState) {
+	if p.journals == nil {
+		return
+	}
+	p.mu.RLock()
+	zone, journal := state.zone, &entities.ZoneJournal{MaxEntries: state.journal.MaxEntries, Diffs: state.journal.Diffs}
+	p.mu.RUnlock()
+	if err := p.journals.Save(ctx, zone, journal); err != nil {
+		log.Printf("Error storing zone %s: %v", zone.Origin, err)
+	}
+}
+
+// sign signs the zone if a signer is set
+func (p *Primary) sign(ctx context.Context, zone *entities.Zone) (*entities.Zone, error) {
+	if p.signer == nil {
+		return zone, nil
+	}
+	return p.signer.SignZone(ctx, zone)
+}
+
+// getZone returns the published version of the zone and its changes, or nil if the zone is not served
+func (p *Primary) getZone(origin string) (*entities.Zone, *entities.ZoneJournal) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	state, ok := p.zones[dns.CanonicalName(origin)]
+	if !ok {
+		return nil, nil
+	}
+	journal := &entities.ZoneJournal{MaxEntries: state.journal.MaxEntries, Diffs: state.journal.Diffs}
+	return state.zone, journal
+}
+
+// Notify sends a NOTIFY for the zone to all secondaries, signed with the NOTIFY TSIG key if configured
+// Ref: https://datatracker.ietf.org/doc/html/rfc1996
+func (p *Primary) Notify(ctx context.Context, zone *entities.Zone) error {
+	var errs []error
+	for _, secondary := range p.cfg.Secondaries {
+		m := new(dns.Msg)
+		m.SetNotify(zone.Origin)
+		m.Answer = []dns.RR{dns.Copy(zone.SOA)}
+		if key, ok := p.cfg.tsigKey(p.cfg.NotifyTSIGKey); ok {
+			m.SetTsig(key.Name, key.Algorithm, 300, time.Now().Unix())
+		}
+
+		resp, _, err := p.notifyClient.ExchangeContext(ctx, m, secondary.String())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("NOTIFY %s: %w", secondary, err))
+			continue
+		}
+		if resp.Rcode != dns.RcodeSuccess {
+			errs = append(errs, fmt.Errorf("NOTIFY %s: %s", secondary, dns.RcodeToString[resp.Rcode]))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,408 @@
+package dnsserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testTSIGKey    = "transfer.example."
+	testTSIGSecret = "c2VjcmV0LXNoYXJlZC13aXRoLXRoZS1zZWNvbmRhcnk="
+)
+
+// fakeZoneService builds the zone from a list of records and keeps the serial like the ZoneService does
+type fakeZoneService struct {
+	mu      sync.Mutex
+	serial  uint32
+	records []string
+}
+
+func (f *fakeZoneService) setRecords(records ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.records = records
+}
+
+func (f *fakeZoneService) GenerateZone(ctx context.Context, tldName string) (*entities.Zone, error) {
+	zone, err := f.BuildZone(ctx, tldName)
+	if err != nil {
+		return nil, err
+	}
+	return zone, f.PublishZone(ctx, zone)
+}
+
+func (f *fakeZoneService) BuildZone(ctx context.Context, tldName string) (*entities.Zone, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	soa := &dns.SOA{
+		Hdr:     dns.RR_Header{Name: "example.", Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: 3600},
+		Ns:      "ns1.nic.example.",
+		Mbox:    "hostmaster.nic.example.",
+		Serial:  f.serial,
+		Refresh: 1800, Retry: 900, Expire: 604800, Minttl: 86400,
+	}
+	zone, err := entities.NewZone("example.", soa)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range f.records {
+		rr, err := dns.NewRR(s)
+		if err != nil {
+			return nil, err
+		}
+		if err := zone.AddRecords(rr); err != nil {
+			return nil, err
+		}
+	}
+	return zone, nil
+}
+
+func (f *fakeZoneService) PublishZone(ctx context.Context, zone *entities.Zone) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.serial = zone.IncrementSerial()
+	return nil
+}
+
+func (f *fakeZoneService) ListZones(ctx context.Context) ([]string, error) {
+	return []string{"example"}, nil
+}
+
+// startTestServer starts a DNS server on a random localhost port for the network and returns its address
+func startTestServer(t *testing.T, network string, handler dns.Handler, secrets map[string]string) string {
+	t.Helper()
+	started := make(chan struct{})
+	srv := &dns.Server{Net: network, Handler: handler, TsigSecret: secrets, NotifyStartedFunc: func() { close(started) }}
+	var addr string
+	switch network {
+	case "tcp":
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		srv.Listener, addr = l, l.Addr().String()
+	default:
+		pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		srv.PacketConn, addr = pc, pc.LocalAddr().String()
+	}
+	go func() { _ = srv.ActivateAndServe() }()
+	<-started
+	t.Cleanup(func() { _ = srv.Shutdown() })
+	return addr
+}
+
+// transferIn runs a zone transfer and returns the records, signed with the test TSIG key if sign is true
+func transferIn(t *testing.T, addr string, m *dns.Msg, sign bool) ([]dns.RR, error) {
+	t.Helper()
+	tr := &dns.Transfer{DialTimeout: time.Second, ReadTimeout: time.Second}
+	if sign {
+		tr.TsigSecret = map[string]string{testTSIGKey: testTSIGSecret}
+		m.SetTsig(testTSIGKey, dns.HmacSHA256, 300, time.Now().Unix())
+	}
+	env, err := tr.In(m, addr)
+	if err != nil {
+		return nil, err
+	}
+	var rrs []dns.RR
+	for e := range env {
+		if e.Error != nil {
+			return nil, e.Error
+		}
+		rrs = append(rrs, e.RR...)
+	}
+	return rrs, nil
+}
+
+func newIXFR(serial uint32) *dns.Msg {
+	m := new(dns.Msg)
+	m.SetIxfr("example.", serial, "ns1.nic.example.", "hostmaster.nic.example.")
+	return m
+}
+
+func TestPrimary(t *testing.T) {
+	// A secondary that records the NOTIFY messages it receives
+	notifications := make(chan *dns.Msg, 10)
+	secondary := startTestServer(t, "udp", dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		notifications <- r
+		m := new(dns.Msg)
+		m.SetReply(r)
+		if r.IsTsig() != nil && w.TsigStatus() == nil {
+			m.SetTsig(testTSIGKey, dns.HmacSHA256, 300, time.Now().Unix())
+		}
+		_ = w.WriteMsg(m)
+	}), map[string]string{testTSIGKey: testTSIGSecret})
+
+	cfg := Config{
+		Secondaries:       []netip.AddrPort{netip.MustParseAddrPort(secondary)},
+		TSIGKeys:          []TSIGKey{{Name: testTSIGKey, Algorithm: dns.HmacSHA256, Secret: testTSIGSecret}},
+		NotifyTSIGKey:     testTSIGKey,
+		RefreshInterval:   time.Minute,
+		MaxJournalEntries: 10,
+	}
+	zoneService := &fakeZoneService{serial: 41}
+	zoneService.setRecords(
+		"example. 3600 IN NS ns.other.net.",
+		"a.example. 3600 IN NS ns.other.net.",
+		"b.example. 3600 IN NS ns.other.net.",
+	)
+	primary := NewPrimary(zoneService, nil, nil, cfg)
+	addr := startTestServer(t, "tcp", primary, cfg.TSIGSecrets())
+	udpAddr := startTestServer(t, "udp", primary, cfg.TSIGSecrets())
+
+	// Loading the zone publishes a new serial and notifies the secondaries
+	require.NoError(t, primary.Refresh(context.Background()))
+	notify := <-notifications
+	require.Equal(t, dns.OpcodeNotify, notify.Opcode)
+	require.Equal(t, "example.", notify.Question[0].Name)
+	require.NotNil(t, notify.IsTsig())
+	require.Equal(t, uint32(42), notify.Answer[0].(*dns.SOA).Serial)
+
+	// Unchanged zones are not published again
+	require.NoError(t, primary.Refresh(context.Background()))
+	require.Len(t, notifications, 0)
+	require.Equal(t, uint32(42), zoneService.serial)
+
+	t.Run("SOA query", func(t *testing.T) {
+		m := new(dns.Msg)
+		m.SetQuestion("example.", dns.TypeSOA)
+		resp, err := dns.Exchange(m, udpAddr)
+		require.NoError(t, err)
+		require.True(t, resp.Authoritative)
+		require.Equal(t, uint32(42), resp.Answer[0].(*dns.SOA).Serial)
+
+		m.SetQuestion("other.", dns.TypeSOA)
+		resp, err = dns.Exchange(m, udpAddr)
+		require.NoError(t, err)
+		require.Equal(t, dns.RcodeRefused, resp.Rcode)
+
+		m.SetQuestion("example.", dns.TypeA)
+		resp, err = dns.Exchange(m, udpAddr)
+		require.NoError(t, err)
+		require.Equal(t, dns.RcodeRefused, resp.Rcode)
+	})
+
+	t.Run("AXFR", func(t *testing.T) {
+		m := new(dns.Msg)
+		m.SetAxfr("example.")
+		rrs, err := transferIn(t, addr, m, true)
+		require.NoError(t, err)
+		require.Len(t, rrs, 5)
+		require.Equal(t, dns.TypeSOA, rrs[0].Header().Rrtype)
+		require.Equal(t, dns.TypeSOA, rrs[4].Header().Rrtype)
+
+		// Unsigned transfers are refused unless the source is allowed
+		m = new(dns.Msg)
+		m.SetAxfr("example.")
+		_, err = transferIn(t, addr, m, false)
+		require.Error(t, err)
+
+		// Transfers signed with an unknown key are refused
+		m = new(dns.Msg)
+		m.SetAxfr("example.")
+		m.SetTsig("unknown.", dns.HmacSHA256, 300, time.Now().Unix())
+		tr := &dns.Transfer{TsigSecret: map[string]string{"unknown.": testTSIGSecret}}
+		env, err := tr.In(m, addr)
+		if err == nil {
+			for e := range env {
+				if e.Error != nil {
+					err = e.Error
+				}
+			}
+		}
+		require.Error(t, err)
+	})
+
+	t.Run("AXFR from an allowed network", func(t *testing.T) {
+		cfg := cfg
+		cfg.AllowTransfer = []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")}
+		allowed := NewPrimary(zoneService, nil, nil, cfg)
+		allowed.zones = primary.zones
+		allowedAddr := startTestServer(t, "tcp", allowed, nil)
+
+		m := new(dns.Msg)
+		m.SetAxfr("example.")
+		rrs, err := transferIn(t, allowedAddr, m, false)
+		require.NoError(t, err)
+		require.Len(t, rrs, 5)
+	})
+
+	// Change the zone: delete b, add c
+	zoneService.setRecords(
+		"example. 3600 IN NS ns.other.net.",
+		"a.example. 3600 IN NS ns.other.net.",
+		"c.example. 3600 IN NS ns.other.net.",
+	)
+	require.NoError(t, primary.Refresh(context.Background()))
+	notify = <-notifications
+	require.Equal(t, uint32(43), notify.Answer[0].(*dns.SOA).Serial)
+
+	t.Run("IXFR", func(t *testing.T) {
+		rrs, err := transferIn(t, addr, newIXFR(42), true)
+		require.NoError(t, err)
+		require.Len(t, rrs, 6)
+		require.Equal(t, uint32(43), rrs[0].(*dns.SOA).Serial)
+		require.Equal(t, uint32(42), rrs[1].(*dns.SOA).Serial)
+		require.Equal(t, "b.example.", rrs[2].Header().Name)
+		require.Equal(t, uint32(43), rrs[3].(*dns.SOA).Serial)
+		require.Equal(t, "c.example.", rrs[4].Header().Name)
+		require.Equal(t, uint32(43), rrs[5].(*dns.SOA).Serial)
+	})
+
+	t.Run("IXFR up to date", func(t *testing.T) {
+		rrs, err := transferIn(t, addr, newIXFR(43), true)
+		require.NoError(t, err)
+		require.Len(t, rrs, 1)
+		require.Equal(t, uint32(43), rrs[0].(*dns.SOA).Serial)
+	})
+
+	t.Run("IXFR over UDP", func(t *testing.T) {
+		m := newIXFR(42)
+		m.SetTsig(testTSIGKey, dns.HmacSHA256, 300, time.Now().Unix())
+		c := &dns.Client{TsigSecret: cfg.TSIGSecrets()}
+		resp, _, err := c.Exchange(m, udpAddr)
+		require.NoError(t, err)
+		require.Len(t, resp.Answer, 1)
+		require.Equal(t, uint32(43), resp.Answer[0].(*dns.SOA).Serial)
+	})
+
+	t.Run("IXFR falls back to AXFR", func(t *testing.T) {
+		rrs, err := transferIn(t, addr, newIXFR(7), true)
+		require.NoError(t, err)
+		require.Len(t, rrs, 5)
+		require.Equal(t, uint32(43), rrs[0].(*dns.SOA).Serial)
+		require.Equal(t, "a.example.", rrs[2].Header().Name)
+		require.Equal(t, "c.example.", rrs[3].Header().Name)
+	})
+}
+
+// fakeSigner adds a record that depends on the version of its keys and the serial, like the signature of the SOA
+type fakeSigner struct {
+	version int
+	loaded  []*entities.Zone
+}
+
+func (f *fakeSigner) LoadSignatures(ctx context.Context, zone *entities.Zone) error {
+	f.loaded = append(f.loaded, zone)
+	return nil
+}
+
+func (f *fakeSigner) SignZone(ctx context.Context, zone *entities.Zone) (*entities.Zone, error) {
+	signed, err := entities.NewZone(zone.Origin, dns.Copy(zone.SOA).(*dns.SOA))
+	if err != nil {
+		return nil, err
+	}
+	if err := signed.AddRecords(zone.Records()[1:]...); err != nil {
+		return nil, err
+	}
+	rr, err := dns.NewRR(fmt.Sprintf(`example. 3600 IN TXT "sig-%d-%d"`, f.version, zone.SOA.Serial))
+	if err != nil {
+		return nil, err
+	}
+	return signed, signed.AddRecords(rr)
+}
+
+func TestPrimary_Signer(t *testing.T) {
+	zoneService := &fakeZoneService{serial: 41}
+	zoneService.setRecords("example. 3600 IN NS ns.other.net.")
+	signer := &fakeSigner{version: 1}
+	primary := NewPrimary(zoneService, signer, nil, Config{MaxJournalEntries: 10})
+
+	require.NoError(t, primary.Refresh(context.Background()))
+	zone, _ := primary.getZone("example.")
+	require.Equal(t, uint32(42), zone.SOA.Serial)
+	require.Contains(t, zone.String(), `"sig-1-42"`)
+
+	// Signing the unchanged zone again does not publish a new serial
+	require.NoError(t, primary.Refresh(context.Background()))
+	zone, _ = primary.getZone("example.")
+	require.Equal(t, uint32(42), zone.SOA.Serial)
+
+	// New signatures are published with a new serial, and signed with it
+	signer.version = 2
+	require.NoError(t, primary.Refresh(context.Background()))
+	zone, journal := primary.getZone("example.")
+	require.Equal(t, uint32(43), zone.SOA.Serial)
+	require.Contains(t, zone.String(), `"sig-2-43"`)
+
+	diffs, ok := journal.DiffsSince(42)
+	require.True(t, ok)
+	require.Len(t, diffs, 1)
+	require.Equal(t, "sig-1-42", diffs[0].Deleted[0].(*dns.TXT).Txt[0])
+	require.Equal(t, "sig-2-43", diffs[0].Added[0].(*dns.TXT).Txt[0])
+}
+
+// fakeZoneJournalRepository keeps the stored zones and journals in memory
+type fakeZoneJournalRepository struct {
+	mu       sync.Mutex
+	zones    map[string]*entities.Zone
+	journals map[string]*entities.ZoneJournal
+}
+
+func (f *fakeZoneJournalRepository) GetByZone(ctx context.Context, origin string) (*entities.Zone, *entities.ZoneJournal, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	zone, ok := f.zones[origin]
+	if !ok {
+		return nil, nil, entities.ErrZoneJournalNotFound
+	}
+	return zone, f.journals[origin], nil
+}
+
+func (f *fakeZoneJournalRepository) Save(ctx context.Context, zone *entities.Zone, journal *entities.ZoneJournal) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.zones[zone.Origin] = zone
+	f.journals[zone.Origin] = &entities.ZoneJournal{Diffs: append([]*entities.ZoneDiff(nil), journal.Diffs...)}
+	return nil
+}
+
+func TestPrimary_Restart(t *testing.T) {
+	zoneService := &fakeZoneService{serial: 41}
+	zoneService.setRecords("example. 3600 IN NS ns.other.net.", "a.example. 3600 IN NS ns.other.net.")
+	journals := &fakeZoneJournalRepository{zones: map[string]*entities.Zone{}, journals: map[string]*entities.ZoneJournal{}}
+	signer := &fakeSigner{version: 1}
+	cfg := Config{MaxJournalEntries: 10}
+
+	primary := NewPrimary(zoneService, signer, journals, cfg)
+	require.NoError(t, primary.Refresh(context.Background()))
+	zoneService.setRecords("example. 3600 IN NS ns.other.net.", "b.example. 3600 IN NS ns.other.net.")
+	require.NoError(t, primary.Refresh(context.Background()))
+	require.Equal(t, uint32(43), zoneService.serial)
+	require.Len(t, journals.journals["example."].Diffs, 1)
+
+	// After a restart the unchanged zone is served with the same serial and its journal
+	restarted := NewPrimary(zoneService, signer, journals, cfg)
+	require.NoError(t, restarted.Refresh(context.Background()))
+	require.Equal(t, uint32(43), zoneService.serial)
+	require.Len(t, signer.loaded, 1)
+	zone, journal := restarted.getZone("example.")
+	require.Equal(t, uint32(43), zone.SOA.Serial)
+	require.Contains(t, zone.String(), `"sig-1-43"`)
+	diffs, ok := journal.DiffsSince(42)
+	require.True(t, ok)
+	require.Len(t, diffs, 1)
+
+	// A change made while the primary was down is published with the next serial and kept in the journal
+	restarted = NewPrimary(zoneService, signer, journals, cfg)
+	zoneService.setRecords("example. 3600 IN NS ns.other.net.", "c.example. 3600 IN NS ns.other.net.")
+	require.NoError(t, restarted.Refresh(context.Background()))
+	require.Equal(t, uint32(44), zoneService.serial)
+	_, journal = restarted.getZone("example.")
+	diffs, ok = journal.DiffsSince(42)
+	require.True(t, ok)
+	require.Len(t, diffs, 2)
+	var deleted []string
+	for _, rr := range diffs[1].Deleted {
+		deleted = append(deleted, rr.Header().Name)
+	}
+	require.Contains(t, deleted, "b.example.")
+	require.Len(t, journals.journals["example."].Diffs, 2)
+}
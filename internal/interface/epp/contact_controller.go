@@ -0,0 +1,343 @@
+package epp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/beevik/etree"
+	epplib "github.com/dotse/epp-lib"
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+	"github.com/onasunnymorning/domain-os/internal/application/interfaces"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// ContactController handles the RFC 5733 contact commands
+type ContactController struct {
+	contactService interfaces.ContactService
+}
+
+// NewContactController creates a new ContactController and binds the contact commands to the provided CommandMux
+func NewContactController(mux *epplib.CommandMux, contactService interfaces.ContactService) *ContactController {
+	controller := &ContactController{
+		contactService: contactService,
+	}
+
+	ns := epplib.NamespaceIETFContact10.String()
+	mux.BindCommand("check", ns, controller.Check)
+	mux.BindCommand("info", ns, controller.Info)
+	mux.BindCommand("create", ns, controller.Create)
+	mux.BindCommand("update", ns, controller.Update)
+	mux.BindCommand("delete", ns, controller.Delete)
+
+	return controller
+}
+
+// Check handles the contact <check> command
+func (ctrl *ContactController) Check(ctx context.Context, rw epplib.Writer, doc *etree.Document) {
+	var cmd ContactCheckCommand
+	if err := unmarshalCommand(doc, &cmd); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	if _, err := clIDFromContext(ctx); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	if len(cmd.IDs) == 0 {
+		writeResponse(ctx, rw, NewErrorResponse(ErrMissingContactID, cmd.ClTRID))
+		return
+	}
+
+	chkData := NewContactChkData()
+	for _, id := range cmd.IDs {
+		_, err := ctrl.contactService.GetContactByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, entities.ErrContactNotFound) {
+				chkData.Add(id, true, "")
+				continue
+			}
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+		chkData.Add(id, false, entities.ErrContactAlreadyExists.Error())
+	}
+
+	writeResponse(ctx, rw, NewResponse(epplib.StatusSuccess, cmd.ClTRID).WithResData(chkData))
+}
+
+// Info handles the contact <info> command.
+// Contacts contain personal data, so a non-sponsoring client must provide the correct authInfo to see the contact.
+// The authInfo itself is only included if the requesting client is the sponsor of the contact.
+func (ctrl *ContactController) Info(ctx context.Context, rw epplib.Writer, doc *etree.Document) {
+	var cmd ContactInfoCommand
+	if err := unmarshalCommand(doc, &cmd); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	clID, err := clIDFromContext(ctx)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	if cmd.ID == "" {
+		writeResponse(ctx, rw, NewErrorResponse(ErrMissingContactID, cmd.ClTRID))
+		return
+	}
+
+	c, err := ctrl.contactService.GetContactByID(ctx, cmd.ID)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+
+	isSponsor := c.ClID.String() == clID
+	if !isSponsor {
+		if cmd.AuthInfo == "" {
+			writeResponse(ctx, rw, NewErrorResponse(entities.ErrInvalidRegistrar, cmd.ClTRID))
+			return
+		}
+		if cmd.AuthInfo != c.AuthInfo.String() {
+			writeResponse(ctx, rw, NewErrorResponse(ErrAuthInfoMismatch, cmd.ClTRID))
+			return
+		}
+	}
+
+	writeResponse(ctx, rw, NewResponse(epplib.StatusSuccess, cmd.ClTRID).WithResData(NewContactInfData(c, isSponsor)))
+}
+
+// Create handles the contact <create> command
+func (ctrl *ContactController) Create(ctx context.Context, rw epplib.Writer, doc *etree.Document) {
+	var cmd ContactCreateCommand
+	if err := unmarshalCommand(doc, &cmd); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	clID, err := clIDForCreateFromContext(ctx)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	if cmd.ID == "" {
+		writeResponse(ctx, rw, NewErrorResponse(ErrMissingContactID, cmd.ClTRID))
+		return
+	}
+	if len(cmd.PostalInfo) == 0 {
+		writeResponse(ctx, rw, NewErrorResponse(ErrMissingPostalInfo, cmd.ClTRID))
+		return
+	}
+
+	createCmd := &commands.CreateContactCommand{
+		ID:       cmd.ID,
+		Email:    cmd.Email,
+		AuthInfo: cmd.AuthInfo,
+		ClID:     clID,
+		CrRr:     clID,
+	}
+	for i, pi := range cmd.PostalInfo {
+		if i > 1 {
+			writeResponse(ctx, rw, NewErrorResponse(errors.Join(entities.ErrInvalidContactPostalInfo, errors.New("a maximum of two postalInfo elements is allowed")), cmd.ClTRID))
+			return
+		}
+		e, err := pi.ToEntity(nil)
+		if err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(errors.Join(entities.ErrInvalidContact, err), cmd.ClTRID))
+			return
+		}
+		createCmd.PostalInfo[i] = e
+	}
+	if cmd.Voice != nil {
+		createCmd.Voice = cmd.Voice.Value
+	}
+	if cmd.Fax != nil {
+		createCmd.Fax = cmd.Fax.Value
+	}
+	if cmd.Disclose != nil {
+		if err := cmd.Disclose.Apply(&createCmd.Disclose); err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+	}
+
+	c, err := ctrl.contactService.CreateContact(ctx, createCmd)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+
+	writeResponse(ctx, rw, NewResponse(epplib.StatusSuccess, cmd.ClTRID).WithResData(NewContactCreData(c)))
+}
+
+// Update handles the contact <update> command.
+// Statuses are removed first and added last, which allows a client to remove clientUpdateProhibited, make changes and set it again in a single command.
+func (ctrl *ContactController) Update(ctx context.Context, rw epplib.Writer, doc *etree.Document) {
+	var cmd ContactUpdateCommand
+	if err := unmarshalCommand(doc, &cmd); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	clID, err := clIDFromContext(ctx)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	if cmd.ID == "" {
+		writeResponse(ctx, rw, NewErrorResponse(ErrMissingContactID, cmd.ClTRID))
+		return
+	}
+	if cmd.Add == nil {
+		cmd.Add = &ContactAddRem{}
+	}
+	if cmd.Rem == nil {
+		cmd.Rem = &ContactAddRem{}
+	}
+
+	// Clients can only manipulate client statuses
+	for _, s := range slices.Concat(cmd.Add.Statuses, cmd.Rem.Statuses) {
+		if !s.IsClientStatus() {
+			writeResponse(ctx, rw, NewErrorResponse(errors.Join(ErrServerStatusNotAllowed, fmt.Errorf("status: %s", s.S)), cmd.ClTRID))
+			return
+		}
+	}
+
+	c, err := ctrl.contactService.GetContactByID(ctx, cmd.ID)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	if c.ClID.String() != clID {
+		writeResponse(ctx, rw, NewErrorResponse(entities.ErrInvalidRegistrar, cmd.ClTRID))
+		return
+	}
+
+	// 1. Remove statuses
+	for _, s := range cmd.Rem.Statuses {
+		if err := c.UnSetStatus(s.S); err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+	}
+
+	// 2. Apply the chg element, only if the contact can be updated
+	if !cmd.Chg.IsEmpty() {
+		if !c.CanBeUpdated() {
+			writeResponse(ctx, rw, NewErrorResponse(entities.ErrContactUpdateNotAllowed, cmd.ClTRID))
+			return
+		}
+		if err := applyContactChanges(c, cmd.Chg); err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+	}
+
+	// 3. Add statuses
+	for _, s := range cmd.Add.Statuses {
+		if err := c.SetStatus(entities.ContactStatusType(s.S)); err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+	}
+
+	c.UpRr = entities.ClIDType(clID)
+	if _, err := ctrl.contactService.UpdateContact(ctx, c); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+
+	writeResponse(ctx, rw, NewResponse(epplib.StatusSuccess, cmd.ClTRID))
+}
+
+// Delete handles the contact <delete> command.
+// Contacts that are linked to a domain can't be deleted (2305)
+func (ctrl *ContactController) Delete(ctx context.Context, rw epplib.Writer, doc *etree.Document) {
+	var cmd ContactDeleteCommand
+	if err := unmarshalCommand(doc, &cmd); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	clID, err := clIDFromContext(ctx)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	if cmd.ID == "" {
+		writeResponse(ctx, rw, NewErrorResponse(ErrMissingContactID, cmd.ClTRID))
+		return
+	}
+
+	c, err := ctrl.contactService.GetContactByID(ctx, cmd.ID)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	if c.ClID.String() != clID {
+		writeResponse(ctx, rw, NewErrorResponse(entities.ErrInvalidRegistrar, cmd.ClTRID))
+		return
+	}
+
+	if err := ctrl.contactService.DeleteContactByID(ctx, cmd.ID); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+
+	writeResponse(ctx, rw, NewResponse(epplib.StatusSuccess, cmd.ClTRID))
+}
+
+// applyContactChanges applies the <contact:chg> element to the contact.
+// A postalInfo element replaces the elements it contains in the postalInfo of the same type.
+func applyContactChanges(c *entities.Contact, chg *ContactChg) error {
+	for _, pi := range chg.PostalInfo {
+		var base *entities.ContactPostalInfo
+		switch pi.Type {
+		case entities.PostalInfoEnumTypeINT:
+			base = c.PostalInfo[0]
+		case entities.PostalInfoEnumTypeLOC:
+			base = c.PostalInfo[1]
+		}
+		e, err := pi.ToEntity(base)
+		if err != nil {
+			return errors.Join(entities.ErrInvalidContact, err)
+		}
+		if err := c.RemovePostalInfo(string(e.Type)); err != nil {
+			return err
+		}
+		if err := c.AddPostalInfo(e); err != nil {
+			return errors.Join(entities.ErrInvalidContact, err)
+		}
+	}
+	for _, p := range []struct {
+		value *ContactE164
+		field *entities.E164Type
+	}{
+		{chg.Voice, &c.Voice},
+		{chg.Fax, &c.Fax},
+	} {
+		if p.value == nil {
+			continue
+		}
+		// An empty element removes the number
+		if p.value.Value == "" {
+			*p.field = ""
+			continue
+		}
+		e, err := entities.NewE164Type(p.value.Value)
+		if err != nil {
+			return errors.Join(entities.ErrInvalidContact, err)
+		}
+		*p.field = *e
+	}
+	if chg.Email != nil {
+		c.Email = strings.ToLower(entities.NormalizeString(*chg.Email))
+	}
+	if chg.AuthInfo != nil {
+		c.AuthInfo = entities.AuthInfoType(entities.NormalizeString(chg.AuthInfo.Pw))
+	}
+	if chg.Disclose != nil {
+		if err := chg.Disclose.Apply(&c.Disclose); err != nil {
+			return err
+		}
+	}
+	return nil
+}
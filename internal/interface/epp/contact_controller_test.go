@@ -0,0 +1,283 @@
+package epp
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockContactService is a mock implementation of the ContactService
+type MockContactService struct {
+	mock.Mock
+}
+
+func (m *MockContactService) CreateContact(ctx context.Context, cmd *commands.CreateContactCommand) (*entities.Contact, error) {
+	args := m.Called(ctx, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Contact), args.Error(1)
+}
+
+func (m *MockContactService) GetContactByID(ctx context.Context, id string) (*entities.Contact, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Contact), args.Error(1)
+}
+
+func (m *MockContactService) UpdateContact(ctx context.Context, c *entities.Contact) (*entities.Contact, error) {
+	args := m.Called(ctx, c)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Contact), args.Error(1)
+}
+
+func (m *MockContactService) DeleteContactByID(ctx context.Context, id string) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *MockContactService) ListContacts(ctx context.Context, params queries.ListItemsQuery) ([]*entities.Contact, string, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]*entities.Contact), args.String(1), args.Error(2)
+}
+
+func (m *MockContactService) BulkCreate(ctx context.Context, cmds []*commands.CreateContactCommand) error {
+	return m.Called(ctx, cmds).Error(0)
+}
+
+// getTestContact returns a linked contact sponsored by ClID-1
+func getTestContact() *entities.Contact {
+	return &entities.Contact{
+		ID:       "sh8013",
+		RoID:     "12345_CONT-APEX",
+		Email:    "jdoe@example.com",
+		ClID:     "ClID-1",
+		CrRr:     "ClID-1",
+		AuthInfo: "sTr0ngP@ss",
+		Voice:    "+1.7035555555",
+		PostalInfo: [2]*entities.ContactPostalInfo{
+			{
+				Type: entities.PostalInfoEnumTypeINT,
+				Name: "John Doe",
+				Org:  "Example Inc.",
+				Address: &entities.Address{
+					Street1:     "123 Example Dr.",
+					City:        "Dulles",
+					PostalCode:  "20166-6503",
+					CountryCode: "US",
+				},
+			},
+		},
+		CreatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		Status:    entities.ContactStatus{OK: true, Linked: true},
+		Disclose:  entities.ContactDisclose{Email: true},
+	}
+}
+
+func TestContactController_Check(t *testing.T) {
+	svc := new(MockContactService)
+	ctrl := &ContactController{contactService: svc}
+
+	svc.On("GetContactByID", mock.Anything, "sh8013").Return(getTestContact(), nil)
+	svc.On("GetContactByID", mock.Anything, "free").Return(nil, entities.ErrContactNotFound)
+
+	w := &testWriter{}
+	ctrl.Check(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<check><contact:check xmlns:contact="urn:ietf:params:xml:ns:contact-1.0"><contact:id>sh8013</contact:id><contact:id>free</contact:id></contact:check></check>`)))
+
+	s := w.String()
+	require.Contains(t, s, `<result code="1000">`)
+	require.Contains(t, s, `<contact:chkData xmlns:contact="urn:ietf:params:xml:ns:contact-1.0">`)
+	require.Contains(t, s, `<contact:id avail="0">sh8013</contact:id><contact:reason>contact already exists</contact:reason>`)
+	require.Contains(t, s, `<contact:id avail="1">free</contact:id>`)
+}
+
+func TestContactController_Info(t *testing.T) {
+	tc := []struct {
+		name         string
+		clID         string
+		authInfo     string
+		wantCode     int
+		wantAuthInfo bool
+	}{
+		{"sponsor", "ClID-1", "", 1000, true},
+		{"non sponsor with correct authInfo", "ClID-2", `<contact:authInfo><contact:pw>sTr0ngP@ss</contact:pw></contact:authInfo>`, 1000, false},
+		{"non sponsor with wrong authInfo", "ClID-2", `<contact:authInfo><contact:pw>wrong</contact:pw></contact:authInfo>`, 2202, false},
+		{"non sponsor without authInfo", "ClID-2", "", 2201, false},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockContactService)
+			ctrl := &ContactController{contactService: svc}
+			svc.On("GetContactByID", mock.Anything, "sh8013").Return(getTestContact(), nil)
+
+			w := &testWriter{}
+			ctrl.Info(newTestContext(tt.clID), w, newTestDoc(t, eppCommand(`<info><contact:info xmlns:contact="urn:ietf:params:xml:ns:contact-1.0"><contact:id>sh8013</contact:id>`+tt.authInfo+`</contact:info></info>`)))
+
+			require.Equal(t, tt.wantCode, decodeResultCode(t, w.Bytes()))
+			s := w.String()
+			if tt.wantCode != 1000 {
+				require.NotContains(t, s, "infData")
+				return
+			}
+			require.Contains(t, s, `<contact:id>sh8013</contact:id><contact:roid>12345_CONT-APEX</contact:roid><contact:status s="ok"></contact:status><contact:status s="linked"></contact:status>`)
+			require.Contains(t, s, `<contact:postalInfo type="int"><contact:name>John Doe</contact:name><contact:org>Example Inc.</contact:org><contact:addr><contact:street>123 Example Dr.</contact:street><contact:city>Dulles</contact:city><contact:pc>20166-6503</contact:pc><contact:cc>US</contact:cc></contact:addr></contact:postalInfo>`)
+			require.Contains(t, s, `<contact:voice>+1.7035555555</contact:voice>`)
+			require.Contains(t, s, `<contact:disclose flag="1"><contact:email></contact:email></contact:disclose>`)
+			require.Equal(t, tt.wantAuthInfo, strings.Contains(s, "<contact:authInfo>"))
+		})
+	}
+}
+
+func TestContactController_Create(t *testing.T) {
+	svc := new(MockContactService)
+	ctrl := &ContactController{contactService: svc}
+
+	svc.On("CreateContact", mock.Anything, mock.MatchedBy(func(cmd *commands.CreateContactCommand) bool {
+		return cmd.ID == "sh8013" &&
+			cmd.ClID == "ClID-1" &&
+			cmd.CrRr == "ClID-1" &&
+			cmd.Email == "jdoe@example.com" &&
+			cmd.Voice == "+1.7035555555" &&
+			cmd.PostalInfo[0] != nil && cmd.PostalInfo[0].Name == "John Doe" && cmd.PostalInfo[0].Address.Street2 == "Suite 100" &&
+			cmd.PostalInfo[1] == nil &&
+			cmd.Disclose.Voice && cmd.Disclose.NameInt && !cmd.Disclose.NameLoc && !cmd.Disclose.Email
+	})).Return(getTestContact(), nil)
+
+	w := &testWriter{}
+	ctrl.Create(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<create><contact:create xmlns:contact="urn:ietf:params:xml:ns:contact-1.0">
+<contact:id>sh8013</contact:id>
+<contact:postalInfo type="int"><contact:name>John Doe</contact:name><contact:org>Example Inc.</contact:org>
+<contact:addr><contact:street>123 Example Dr.</contact:street><contact:street>Suite 100</contact:street><contact:city>Dulles</contact:city><contact:sp>VA</contact:sp><contact:pc>20166-6503</contact:pc><contact:cc>US</contact:cc></contact:addr></contact:postalInfo>
+<contact:voice x="1234">+1.7035555555</contact:voice>
+<contact:email>jdoe@example.com</contact:email>
+<contact:authInfo><contact:pw>2fooBAR!</contact:pw></contact:authInfo>
+<contact:disclose flag="1"><contact:voice/><contact:name type="int"/></contact:disclose>
+</contact:create></create>`)))
+
+	s := w.String()
+	require.Contains(t, s, `<result code="1000">`)
+	require.Contains(t, s, `<contact:creData xmlns:contact="urn:ietf:params:xml:ns:contact-1.0"><contact:id>sh8013</contact:id><contact:crDate>2023-01-01T00:00:00.0Z</contact:crDate></contact:creData>`)
+	svc.AssertExpectations(t)
+}
+
+func TestContactController_Create_Errors(t *testing.T) {
+	tc := []struct {
+		name     string
+		body     string
+		wantCode int
+	}{
+		{"missing id", `<contact:email>jdoe@example.com</contact:email>`, 2003},
+		{"missing postalInfo", `<contact:id>sh8013</contact:id><contact:email>jdoe@example.com</contact:email>`, 2003},
+		{"invalid postalInfo type", `<contact:id>sh8013</contact:id><contact:postalInfo type="xyz"><contact:name>John</contact:name><contact:addr><contact:city>Dulles</contact:city><contact:cc>US</contact:cc></contact:addr></contact:postalInfo>`, 2005},
+		{"invalid country code", `<contact:id>sh8013</contact:id><contact:postalInfo type="int"><contact:name>John</contact:name><contact:addr><contact:city>Dulles</contact:city><contact:cc>USA</contact:cc></contact:addr></contact:postalInfo>`, 2005},
+		{"invalid disclose flag", `<contact:id>sh8013</contact:id><contact:postalInfo type="int"><contact:name>John</contact:name><contact:addr><contact:city>Dulles</contact:city><contact:cc>US</contact:cc></contact:addr></contact:postalInfo><contact:disclose flag="x"><contact:voice/></contact:disclose>`, 2001},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := &ContactController{contactService: new(MockContactService)}
+
+			w := &testWriter{}
+			ctrl.Create(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<create><contact:create xmlns:contact="urn:ietf:params:xml:ns:contact-1.0">`+tt.body+`</contact:create></create>`)))
+
+			require.Equal(t, tt.wantCode, decodeResultCode(t, w.Bytes()))
+		})
+	}
+}
+
+func TestContactController_Update(t *testing.T) {
+	svc := new(MockContactService)
+	ctrl := &ContactController{contactService: svc}
+
+	c := getTestContact()
+	c.Status = entities.ContactStatus{ClientUpdateProhibited: true, Linked: true}
+	svc.On("GetContactByID", mock.Anything, "sh8013").Return(c, nil)
+	svc.On("UpdateContact", mock.Anything, mock.MatchedBy(func(c *entities.Contact) bool {
+		return c.UpRr == "ClID-1" &&
+			c.Email == "new@example.com" &&
+			c.Voice == "" &&
+			c.PostalInfo[0].Name == "Jane Doe" && c.PostalInfo[0].Org == "Example Inc." && c.PostalInfo[0].Address.City == "Dulles" &&
+			c.Status.ClientDeleteProhibited && !c.Status.ClientUpdateProhibited && !c.Status.OK
+	})).Return(c, nil)
+
+	w := &testWriter{}
+	ctrl.Update(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<update><contact:update xmlns:contact="urn:ietf:params:xml:ns:contact-1.0">
+<contact:id>sh8013</contact:id>
+<contact:add><contact:status s="clientDeleteProhibited"/></contact:add>
+<contact:rem><contact:status s="clientUpdateProhibited"/></contact:rem>
+<contact:chg><contact:postalInfo type="int"><contact:name>Jane Doe</contact:name></contact:postalInfo><contact:voice/><contact:email>New@Example.com</contact:email></contact:chg>
+</contact:update></update>`)))
+
+	require.Contains(t, w.String(), `<result code="1000">`)
+	svc.AssertExpectations(t)
+}
+
+func TestContactController_Update_Errors(t *testing.T) {
+	tc := []struct {
+		name     string
+		clID     string
+		status   entities.ContactStatus
+		body     string
+		wantCode int
+	}{
+		{"server status", "ClID-1", entities.ContactStatus{OK: true}, `<contact:add><contact:status s="serverUpdateProhibited"/></contact:add>`, 2306},
+		{"not the sponsor", "ClID-2", entities.ContactStatus{OK: true}, `<contact:chg><contact:email>new@example.com</contact:email></contact:chg>`, 2201},
+		{"update prohibited", "ClID-1", entities.ContactStatus{ClientUpdateProhibited: true}, `<contact:chg><contact:email>new@example.com</contact:email></contact:chg>`, 2304},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockContactService)
+			ctrl := &ContactController{contactService: svc}
+			c := getTestContact()
+			c.Status = tt.status
+			svc.On("GetContactByID", mock.Anything, "sh8013").Return(c, nil)
+
+			w := &testWriter{}
+			ctrl.Update(newTestContext(tt.clID), w, newTestDoc(t, eppCommand(`<update><contact:update xmlns:contact="urn:ietf:params:xml:ns:contact-1.0"><contact:id>sh8013</contact:id>`+tt.body+`</contact:update></update>`)))
+
+			require.Equal(t, tt.wantCode, decodeResultCode(t, w.Bytes()))
+			svc.AssertNotCalled(t, "UpdateContact", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestContactController_Delete(t *testing.T) {
+	tc := []struct {
+		name      string
+		clID      string
+		deleteErr error
+		wantCode  int
+	}{
+		{"success", "ClID-1", nil, 1000},
+		{"linked", "ClID-1", errors.Join(entities.ErrContactIsLinked, errors.New("referenced by 1 domain(s)")), 2305},
+		{"delete prohibited", "ClID-1", entities.ErrContactDeleteNotAllowed, 2304},
+		{"not the sponsor", "ClID-2", nil, 2201},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockContactService)
+			ctrl := &ContactController{contactService: svc}
+			svc.On("GetContactByID", mock.Anything, "sh8013").Return(getTestContact(), nil)
+			svc.On("DeleteContactByID", mock.Anything, "sh8013").Return(tt.deleteErr)
+
+			w := &testWriter{}
+			ctrl.Delete(newTestContext(tt.clID), w, newTestDoc(t, eppCommand(`<delete><contact:delete xmlns:contact="urn:ietf:params:xml:ns:contact-1.0"><contact:id>sh8013</contact:id></contact:delete></delete>`)))
+
+			require.Equal(t, tt.wantCode, decodeResultCode(t, w.Bytes()))
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package epp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// The structs in this file are used to unmarshal the RFC 5733 contact commands.
+// Ref: https://datatracker.ietf.org/doc/html/rfc5733#section-3
+
+// ContactAddr is the <contact:addr> element
+type ContactAddr struct {
+	Street []string `xml:"street"`
+	City   string   `xml:"city"`
+	SP     string   `xml:"sp"`
+	PC     string   `xml:"pc"`
+	CC     string   `xml:"cc"`
+}
+
+// ToEntity converts the ContactAddr to an Address entity
+func (a *ContactAddr) ToEntity() (*entities.Address, error) {
+	if len(a.Street) > 3 {
+		return nil, entities.ErrInvalidStreetCount
+	}
+	addr, err := entities.NewAddress(a.City, a.CC)
+	if err != nil {
+		return nil, err
+	}
+	streets := []*entities.OptPostalLineType{&addr.Street1, &addr.Street2, &addr.Street3}
+	for i, s := range a.Street {
+		line, err := entities.NewOptPostalLineType(s)
+		if err != nil {
+			return nil, err
+		}
+		*streets[i] = *line
+	}
+	if a.SP != "" {
+		sp, err := entities.NewOptPostalLineType(a.SP)
+		if err != nil {
+			return nil, err
+		}
+		addr.StateProvince = *sp
+	}
+	if a.PC != "" {
+		pc, err := entities.NewPCType(a.PC)
+		if err != nil {
+			return nil, err
+		}
+		addr.PostalCode = *pc
+	}
+	return addr, nil
+}
+
+// ContactPostalInfo is the <contact:postalInfo> element.
+// The child elements are pointers because they are optional in the <contact:chg> element of an update command
+type ContactPostalInfo struct {
+	Type string       `xml:"type,attr"`
+	Name *string      `xml:"name"`
+	Org  *string      `xml:"org"`
+	Addr *ContactAddr `xml:"addr"`
+}
+
+// ToEntity converts the ContactPostalInfo into a ContactPostalInfo entity.
+// If base is not nil, only the elements that are present replace the values of base, which is how <contact:chg> works.
+func (pi *ContactPostalInfo) ToEntity(base *entities.ContactPostalInfo) (*entities.ContactPostalInfo, error) {
+	t, err := entities.NewPostalInfoEnumType(pi.Type)
+	if err != nil {
+		return nil, err
+	}
+	e := &entities.ContactPostalInfo{Type: *t}
+	if base != nil {
+		e.Name = base.Name
+		e.Org = base.Org
+		if base.Address != nil {
+			addr := base.Address.DeepCopy()
+			e.Address = &addr
+		}
+	}
+	if pi.Name != nil {
+		name, err := entities.NewPostalLineType(*pi.Name)
+		if err != nil {
+			return nil, err
+		}
+		e.Name = *name
+	}
+	if pi.Org != nil {
+		org, err := entities.NewOptPostalLineType(*pi.Org)
+		if err != nil {
+			return nil, err
+		}
+		e.Org = *org
+	}
+	if pi.Addr != nil {
+		e.Address, err = pi.Addr.ToEntity()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !e.IsValid() {
+		return nil, errors.Join(entities.ErrInvalidContactPostalInfo, fmt.Errorf("postalInfo type: %s", pi.Type))
+	}
+	return e, nil
+}
+
+// ContactE164 is the <contact:voice> and <contact:fax> element. The extension attribute is accepted but not stored
+type ContactE164 struct {
+	X     string `xml:"x,attr"`
+	Value string `xml:",chardata"`
+}
+
+// ContactAuthInfo is the <contact:authInfo> element
+type ContactAuthInfo struct {
+	Pw string `xml:"pw"`
+}
+
+// ContactDiscloseType is an element of the <contact:disclose> element that carries the type attribute (name, org, addr)
+type ContactDiscloseType struct {
+	Type string `xml:"type,attr"`
+}
+
+// ContactDisclose is the <contact:disclose> element.
+// flag="1" means the listed elements may be disclosed, flag="0" means they must not be disclosed.
+type ContactDisclose struct {
+	Flag  string                `xml:"flag,attr"`
+	Name  []ContactDiscloseType `xml:"name"`
+	Org   []ContactDiscloseType `xml:"org"`
+	Addr  []ContactDiscloseType `xml:"addr"`
+	Voice *struct{}             `xml:"voice"`
+	Fax   *struct{}             `xml:"fax"`
+	Email *struct{}             `xml:"email"`
+}
+
+// Apply sets the disclose flags for the listed elements on the provided ContactDisclose entity
+func (d *ContactDisclose) Apply(e *entities.ContactDisclose) error {
+	var v bool
+	switch d.Flag {
+	case "1", "true":
+		v = true
+	case "0", "false":
+		v = false
+	default:
+		return errors.Join(ErrInvalidCommand, fmt.Errorf("invalid disclose flag: %s", d.Flag))
+	}
+	for _, l := range []struct {
+		elements         []ContactDiscloseType
+		intFlag, locFlag *bool
+	}{
+		{d.Name, &e.NameInt, &e.NameLoc},
+		{d.Org, &e.OrgInt, &e.OrgLoc},
+		{d.Addr, &e.AddrInt, &e.AddrLoc},
+	} {
+		for _, el := range l.elements {
+			switch el.Type {
+			case entities.PostalInfoEnumTypeINT:
+				*l.intFlag = v
+			case entities.PostalInfoEnumTypeLOC:
+				*l.locFlag = v
+			default:
+				return errors.Join(ErrInvalidCommand, fmt.Errorf("invalid disclose type: %s", el.Type))
+			}
+		}
+	}
+	if d.Voice != nil {
+		e.Voice = v
+	}
+	if d.Fax != nil {
+		e.Fax = v
+	}
+	if d.Email != nil {
+		e.Email = v
+	}
+	return nil
+}
+
+// ContactStatusValue is the <contact:status> element
+type ContactStatusValue struct {
+	S     string `xml:"s,attr"`
+	Lang  string `xml:"lang,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+// IsClientStatus returns true if the status can be set by the client (client* statuses)
+func (s ContactStatusValue) IsClientStatus() bool {
+	return strings.HasPrefix(s.S, "client")
+}
+
+// ContactCheckCommand is the <check> command for contacts
+type ContactCheckCommand struct {
+	IDs    []string `xml:"command>check>check>id"`
+	ClTRID string   `xml:"command>clTRID"`
+}
+
+// ContactInfoCommand is the <info> command for contacts
+type ContactInfoCommand struct {
+	ID       string `xml:"command>info>info>id"`
+	AuthInfo string `xml:"command>info>info>authInfo>pw"`
+	ClTRID   string `xml:"command>clTRID"`
+}
+
+// ContactCreateCommand is the <create> command for contacts
+type ContactCreateCommand struct {
+	ID         string              `xml:"command>create>create>id"`
+	PostalInfo []ContactPostalInfo `xml:"command>create>create>postalInfo"`
+	Voice      *ContactE164        `xml:"command>create>create>voice"`
+	Fax        *ContactE164        `xml:"command>create>create>fax"`
+	Email      string              `xml:"command>create>create>email"`
+	AuthInfo   string              `xml:"command>create>create>authInfo>pw"`
+	Disclose   *ContactDisclose    `xml:"command>create>create>disclose"`
+	ClTRID     string              `xml:"command>clTRID"`
+}
+
+// ContactAddRem is the <contact:add> and <contact:rem> element of the update command
+type ContactAddRem struct {
+	Statuses []ContactStatusValue `xml:"status"`
+}
+
+// ContactChg is the <contact:chg> element of the update command
+type ContactChg struct {
+	PostalInfo []ContactPostalInfo `xml:"postalInfo"`
+	Voice      *ContactE164        `xml:"voice"`
+	Fax        *ContactE164        `xml:"fax"`
+	Email      *string             `xml:"email"`
+	AuthInfo   *ContactAuthInfo    `xml:"authInfo"`
+	Disclose   *ContactDisclose    `xml:"disclose"`
+}
+
+// IsEmpty returns true if the chg element does not contain any changes
+func (c *ContactChg) IsEmpty() bool {
+	return c == nil || (len(c.PostalInfo) == 0 && c.Voice == nil && c.Fax == nil && c.Email == nil && c.AuthInfo == nil && c.Disclose == nil)
+}
+
+// ContactUpdateCommand is the <update> command for contacts
+type ContactUpdateCommand struct {
+	ID     string         `xml:"command>update>update>id"`
+	Add    *ContactAddRem `xml:"command>update>update>add"`
+	Rem    *ContactAddRem `xml:"command>update>update>rem"`
+	Chg    *ContactChg    `xml:"command>update>update>chg"`
+	ClTRID string         `xml:"command>clTRID"`
+}
+
+// ContactDeleteCommand is the <delete> command for contacts
+type ContactDeleteCommand struct {
+	ID     string `xml:"command>delete>delete>id"`
+	ClTRID string `xml:"command>clTRID"`
+}
@@ -0,0 +1,207 @@
+package epp
+
+import (
+	"encoding/xml"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// The structs in this file are used to marshal the RFC 5733 <resData> elements.
+// Ref: https://datatracker.ietf.org/doc/html/rfc5733#section-3
+
+// ContactChkData is the <contact:chkData> element of the check response
+type ContactChkData struct {
+	XMLName      xml.Name    `xml:"contact:chkData"`
+	XMLNSContact string      `xml:"xmlns:contact,attr"`
+	CD           []ContactCD `xml:"contact:cd"`
+}
+
+// ContactCD is a single <contact:cd> element of the check response
+type ContactCD struct {
+	ID     ContactCheckID `xml:"contact:id"`
+	Reason string         `xml:"contact:reason,omitempty"`
+}
+
+// ContactCheckID is the <contact:id> element of the check response
+type ContactCheckID struct {
+	Avail int    `xml:"avail,attr"`
+	Value string `xml:",chardata"`
+}
+
+// NewContactChkData creates a new empty ContactChkData
+func NewContactChkData() *ContactChkData {
+	return &ContactChkData{XMLNSContact: CONTACT_NAMESPACE}
+}
+
+// Add adds a check result to the chkData
+func (c *ContactChkData) Add(id string, available bool, reason string) {
+	cd := ContactCD{ID: ContactCheckID{Value: id}}
+	if available {
+		cd.ID.Avail = 1
+	} else {
+		cd.Reason = reason
+	}
+	c.CD = append(c.CD, cd)
+}
+
+// ContactInfStatus is a <contact:status> element of the info response
+type ContactInfStatus struct {
+	S string `xml:"s,attr"`
+}
+
+// ContactInfAddr is the <contact:addr> element of the info response
+type ContactInfAddr struct {
+	Street []string `xml:"contact:street"`
+	City   string   `xml:"contact:city"`
+	SP     string   `xml:"contact:sp,omitempty"`
+	PC     string   `xml:"contact:pc,omitempty"`
+	CC     string   `xml:"contact:cc"`
+}
+
+// ContactInfPostalInfo is the <contact:postalInfo> element of the info response
+type ContactInfPostalInfo struct {
+	Type string         `xml:"type,attr"`
+	Name string         `xml:"contact:name"`
+	Org  string         `xml:"contact:org,omitempty"`
+	Addr ContactInfAddr `xml:"contact:addr"`
+}
+
+// ContactInfAuthInfo is the <contact:authInfo> element of the info response
+type ContactInfAuthInfo struct {
+	Pw string `xml:"contact:pw"`
+}
+
+// ContactInfDiscloseType is an element of the <contact:disclose> element that carries the type attribute
+type ContactInfDiscloseType struct {
+	Type string `xml:"type,attr"`
+}
+
+// ContactInfDisclose is the <contact:disclose> element of the info response
+type ContactInfDisclose struct {
+	Flag  int                      `xml:"flag,attr"`
+	Name  []ContactInfDiscloseType `xml:"contact:name"`
+	Org   []ContactInfDiscloseType `xml:"contact:org"`
+	Addr  []ContactInfDiscloseType `xml:"contact:addr"`
+	Voice *struct{}                `xml:"contact:voice"`
+	Fax   *struct{}                `xml:"contact:fax"`
+	Email *struct{}                `xml:"contact:email"`
+}
+
+// ContactInfData is the <contact:infData> element of the info response
+type ContactInfData struct {
+	XMLName      xml.Name               `xml:"contact:infData"`
+	XMLNSContact string                 `xml:"xmlns:contact,attr"`
+	ID           string                 `xml:"contact:id"`
+	RoID         string                 `xml:"contact:roid"`
+	Status       []ContactInfStatus     `xml:"contact:status"`
+	PostalInfo   []ContactInfPostalInfo `xml:"contact:postalInfo"`
+	Voice        string                 `xml:"contact:voice,omitempty"`
+	Fax          string                 `xml:"contact:fax,omitempty"`
+	Email        string                 `xml:"contact:email"`
+	ClID         string                 `xml:"contact:clID"`
+	CrID         string                 `xml:"contact:crID,omitempty"`
+	CrDate       string                 `xml:"contact:crDate,omitempty"`
+	UpID         string                 `xml:"contact:upID,omitempty"`
+	UpDate       string                 `xml:"contact:upDate,omitempty"`
+	AuthInfo     *ContactInfAuthInfo    `xml:"contact:authInfo,omitempty"`
+	Disclose     *ContactInfDisclose    `xml:"contact:disclose,omitempty"`
+}
+
+// NewContactInfData creates a ContactInfData from a contact entity.
+// includeAuthInfo should only be set if the requesting client is the sponsoring registrar.
+func NewContactInfData(c *entities.Contact, includeAuthInfo bool) *ContactInfData {
+	inf := &ContactInfData{
+		XMLNSContact: CONTACT_NAMESPACE,
+		ID:           c.ID.String(),
+		RoID:         c.RoID.String(),
+		Voice:        c.Voice.String(),
+		Fax:          c.Fax.String(),
+		Email:        c.Email,
+		ClID:         c.ClID.String(),
+		CrID:         c.CrRr.String(),
+		CrDate:       formatEPPDate(c.CreatedAt),
+		UpID:         c.UpRr.String(),
+		UpDate:       formatEPPDate(c.UpdatedAt),
+		Disclose:     newContactInfDisclose(c.Disclose),
+	}
+	for _, s := range c.Status.StringSlice() {
+		inf.Status = append(inf.Status, ContactInfStatus{S: s})
+	}
+	for _, pi := range c.PostalInfo {
+		if pi == nil {
+			continue
+		}
+		infPi := ContactInfPostalInfo{
+			Type: string(pi.Type),
+			Name: pi.Name.String(),
+			Org:  pi.Org.String(),
+		}
+		if pi.Address != nil {
+			for _, s := range []entities.OptPostalLineType{pi.Address.Street1, pi.Address.Street2, pi.Address.Street3} {
+				if s != "" {
+					infPi.Addr.Street = append(infPi.Addr.Street, s.String())
+				}
+			}
+			infPi.Addr.City = pi.Address.City.String()
+			infPi.Addr.SP = pi.Address.StateProvince.String()
+			infPi.Addr.PC = pi.Address.PostalCode.String()
+			infPi.Addr.CC = pi.Address.CountryCode.String()
+		}
+		inf.PostalInfo = append(inf.PostalInfo, infPi)
+	}
+	if includeAuthInfo {
+		inf.AuthInfo = &ContactInfAuthInfo{Pw: c.AuthInfo.String()}
+	}
+	return inf
+}
+
+// newContactInfDisclose lists the elements that may be disclosed.
+// Our server policy is to not disclose by default, so if nothing may be disclosed the element is omitted.
+func newContactInfDisclose(d entities.ContactDisclose) *ContactInfDisclose {
+	if d.IsNil() {
+		return nil
+	}
+	disclose := &ContactInfDisclose{Flag: 1}
+	for _, l := range []struct {
+		elements         *[]ContactInfDiscloseType
+		intFlag, locFlag bool
+	}{
+		{&disclose.Name, d.NameInt, d.NameLoc},
+		{&disclose.Org, d.OrgInt, d.OrgLoc},
+		{&disclose.Addr, d.AddrInt, d.AddrLoc},
+	} {
+		if l.intFlag {
+			*l.elements = append(*l.elements, ContactInfDiscloseType{Type: entities.PostalInfoEnumTypeINT})
+		}
+		if l.locFlag {
+			*l.elements = append(*l.elements, ContactInfDiscloseType{Type: entities.PostalInfoEnumTypeLOC})
+		}
+	}
+	if d.Voice {
+		disclose.Voice = &struct{}{}
+	}
+	if d.Fax {
+		disclose.Fax = &struct{}{}
+	}
+	if d.Email {
+		disclose.Email = &struct{}{}
+	}
+	return disclose
+}
+
+// ContactCreData is the <contact:creData> element of the create response
+type ContactCreData struct {
+	XMLName      xml.Name `xml:"contact:creData"`
+	XMLNSContact string   `xml:"xmlns:contact,attr"`
+	ID           string   `xml:"contact:id"`
+	CrDate       string   `xml:"contact:crDate"`
+}
+
+// NewContactCreData creates a ContactCreData from a contact entity
+func NewContactCreData(c *entities.Contact) *ContactCreData {
+	return &ContactCreData{
+		XMLNSContact: CONTACT_NAMESPACE,
+		ID:           c.ID.String(),
+		CrDate:       formatEPPDate(c.CreatedAt),
+	}
+}
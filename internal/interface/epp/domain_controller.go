@@ -0,0 +1,862 @@
+package epp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/beevik/etree"
+	epplib "github.com/dotse/epp-lib"
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+	"github.com/onasunnymorning/domain-os/internal/application/interfaces"
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+const (
+	// CUR_EXP_DATE_FORMAT is the format of the <domain:curExpDate> element in the renew command
+	CUR_EXP_DATE_FORMAT = "2006-01-02"
+	// DEFAULT_FEE_CURRENCY is the currency of a fee check response when the client did not request one and none of the domains could be quoted
+	DEFAULT_FEE_CURRENCY = "USD"
+)
+
+// DomainController handles the RFC 5731 domain commands
+type DomainController struct {
+	domainService interfaces.DomainService
+}
+
+// NewDomainController creates a new DomainController and binds the domain commands to the provided CommandMux
+func NewDomainController(mux *epplib.CommandMux, domService interfaces.DomainService) *DomainController {
+	controller := &DomainController{
+		domainService: domService,
+	}
+
+	ns := epplib.NamespaceIETFDomain10.String()
+	mux.BindCommand("check", ns, controller.Check)
+	mux.BindCommand("info", ns, controller.Info)
+	mux.BindCommand("create", ns, controller.Create)
+	mux.BindCommand("update", ns, controller.Update)
+	mux.BindCommand("delete", ns, controller.Delete)
+	mux.BindCommand("renew", ns, controller.Renew)
+	mux.BindCommand("transfer", ns, controller.Transfer)
+
+	return controller
+}
+
+// Check handles the domain <check> command
+func (ctrl *DomainController) Check(ctx context.Context, rw epplib.Writer, doc *etree.Document) {
+	var cmd DomainCheckCommand
+	if err := unmarshalCommand(doc, &cmd); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	clID, err := clIDFromContext(ctx)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	if len(cmd.Names) == 0 {
+		writeResponse(ctx, rw, NewErrorResponse(ErrMissingDomainName, cmd.ClTRID))
+		return
+	}
+	if cmd.Extension.Fee != nil {
+		if !hasExtensionFromContext(ctx, FEE_NAMESPACE) {
+			writeResponse(ctx, rw, NewErrorResponse(errors.Join(ErrExtensionNotRequested, fmt.Errorf("extURI: %s", FEE_NAMESPACE)), cmd.ClTRID))
+			return
+		}
+		if len(cmd.Extension.Fee.Commands) == 0 {
+			writeResponse(ctx, rw, NewErrorResponse(ErrMissingFeeCommand, cmd.ClTRID))
+			return
+		}
+	}
+
+	if cmd.Extension.AllocationToken != "" && !hasExtensionFromContext(ctx, ALLOCATION_TOKEN_NAMESPACE) {
+		writeResponse(ctx, rw, NewErrorResponse(errors.Join(ErrExtensionNotRequested, fmt.Errorf("extURI: %s", ALLOCATION_TOKEN_NAMESPACE)), cmd.ClTRID))
+		return
+	}
+
+	// The launch extension either checks for trademark claims instead of availability or checks availability in a launch phase
+	phaseName := ""
+	if cmd.Extension.Launch != nil {
+		if err := launchExtensionRequested(ctx); err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+		switch cmd.Extension.Launch.Type {
+		case "", "claims":
+			ctrl.claimsCheck(ctx, rw, &cmd)
+			return
+		case "avail":
+			phaseName = cmd.Extension.Launch.Phase.PhaseName()
+		default:
+			writeResponse(ctx, rw, NewErrorResponse(errors.Join(ErrInvalidCommand, fmt.Errorf("unknown launch check type: %s", cmd.Extension.Launch.Type)), cmd.ClTRID))
+			return
+		}
+	}
+
+	chkData := NewDomainChkData()
+	for _, name := range cmd.Names {
+		result, err := ctrl.domainService.CheckDomainAvailability(ctx, name, phaseName, clID, cmd.Extension.AllocationToken)
+		if err != nil {
+			// Errors we can't map mean we failed to determine availability, in which case we fail the whole command rather than reporting a false negative
+			if ResultCodeFromError(err) == epplib.StatusCommandFailed {
+				writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+				return
+			}
+			chkData.Add(name, false, err.Error())
+			continue
+		}
+		chkData.Add(name, result.Available, result.Reason)
+	}
+
+	resp := NewResponse(epplib.StatusSuccess, cmd.ClTRID).WithResData(chkData)
+	if cmd.Extension.Fee != nil {
+		feeChkData, err := ctrl.feeCheck(ctx, clID, cmd.Names, cmd.Extension.Fee, cmd.Extension.AllocationToken)
+		if err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+		resp.WithExtension(feeChkData)
+	}
+	writeResponse(ctx, rw, resp)
+}
+
+// Info handles the domain <info> command.
+// The authInfo is only included if the requesting client is the sponsor of the domain.
+// A non-sponsoring client that provides authInfo must provide the correct one.
+func (ctrl *DomainController) Info(ctx context.Context, rw epplib.Writer, doc *etree.Document) {
+	var cmd DomainInfoCommand
+	if err := unmarshalCommand(doc, &cmd); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	clID, err := clIDFromContext(ctx)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	if cmd.Name.Value == "" {
+		writeResponse(ctx, rw, NewErrorResponse(ErrMissingDomainName, cmd.ClTRID))
+		return
+	}
+	if cmd.Extension.Launch != nil {
+		ctrl.applicationInfo(ctx, rw, &cmd, clID)
+		return
+	}
+
+	dom, err := ctrl.domainService.GetDomainByName(ctx, cmd.Name.Value, true)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+
+	isSponsor := dom.ClID.String() == clID
+	if !isSponsor && cmd.AuthInfo != "" && cmd.AuthInfo != dom.AuthInfo.String() {
+		writeResponse(ctx, rw, NewErrorResponse(ErrAuthInfoMismatch, cmd.ClTRID))
+		return
+	}
+
+	includeHosts := cmd.Name.Hosts == "" || cmd.Name.Hosts == "all" || cmd.Name.Hosts == "del"
+
+	resp := NewResponse(epplib.StatusSuccess, cmd.ClTRID).WithResData(NewDomainInfData(dom, includeHosts, isSponsor))
+	if dom.IsSigned() && hasExtensionFromContext(ctx, SECDNS_NAMESPACE) {
+		resp.WithExtension(NewSecDNSInfData(dom.SecDNS))
+	}
+	if hasExtensionFromContext(ctx, RGP_NAMESPACE) {
+		if rgpData := NewRGPInfData(dom, time.Now().UTC()); rgpData != nil {
+			resp.WithExtension(rgpData)
+		}
+	}
+	writeResponse(ctx, rw, resp)
+}
+
+// Create handles the domain <create> command
+func (ctrl *DomainController) Create(ctx context.Context, rw epplib.Writer, doc *etree.Document) {
+	var cmd DomainCreateCommand
+	if err := unmarshalCommand(doc, &cmd); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	clID, err := clIDForCreateFromContext(ctx)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	if cmd.Name == "" {
+		writeResponse(ctx, rw, NewErrorResponse(ErrMissingDomainName, cmd.ClTRID))
+		return
+	}
+
+	years, err := cmd.Period.Years()
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+
+	regCmd := &commands.RegisterDomainCommand{
+		Name:         cmd.Name,
+		ClID:         clID,
+		AuthInfo:     cmd.AuthInfo,
+		RegistrantID: cmd.Registrant,
+		Years:        years,
+		HostNames:    cmd.HostObjs,
+	}
+	for _, c := range cmd.Contacts {
+		switch c.Type {
+		case "admin":
+			regCmd.AdminID = c.Value
+		case "tech":
+			regCmd.TechID = c.Value
+		case "billing":
+			regCmd.BillingID = c.Value
+		default:
+			writeResponse(ctx, rw, NewErrorResponse(errors.Join(entities.ErrInvalidContact, fmt.Errorf("unknown contact type: %s", c.Type)), cmd.ClTRID))
+			return
+		}
+	}
+	if cmd.Extension.Launch != nil {
+		if err := launchExtensionRequested(ctx); err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+		switch cmd.Extension.Launch.Type {
+		case "application":
+			ctrl.createApplication(ctx, rw, &cmd, regCmd)
+			return
+		case "", "registration":
+			if err := applyLaunchCreate(regCmd, cmd.Extension.Launch); err != nil {
+				writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+				return
+			}
+		default:
+			writeResponse(ctx, rw, NewErrorResponse(errors.Join(ErrInvalidCommand, fmt.Errorf("unknown launch create type: %s", cmd.Extension.Launch.Type)), cmd.ClTRID))
+			return
+		}
+	}
+	if cmd.Extension.AllocationToken != "" {
+		if !hasExtensionFromContext(ctx, ALLOCATION_TOKEN_NAMESPACE) {
+			writeResponse(ctx, rw, NewErrorResponse(errors.Join(ErrExtensionNotRequested, fmt.Errorf("extURI: %s", ALLOCATION_TOKEN_NAMESPACE)), cmd.ClTRID))
+			return
+		}
+		regCmd.AllocationToken = cmd.Extension.AllocationToken
+	}
+	if cmd.Extension.SecDNS != nil {
+		secDNS, err := secDNSFromCreateExtension(ctx, cmd.Extension.SecDNS)
+		if err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+		regCmd.SecDNS = *secDNS
+	}
+	var feeData *FeeTrnData
+	if cmd.Extension.Fee != nil {
+		regCmd.Fee, feeData, err = ctrl.validateFee(ctx, cmd.Extension.Fee, "creData", &queries.QuoteRequest{
+			DomainName:      cmd.Name,
+			ClID:            clID,
+			TransactionType: entities.TransactionTypeRegistration,
+			Currency:        cmd.Extension.Fee.Currency,
+			Years:           years,
+			PhaseName:       regCmd.PhaseName,
+			AllocationToken: regCmd.AllocationToken,
+		})
+		if err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+	}
+
+	dom, err := ctrl.domainService.RegisterDomain(ctx, regCmd)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+
+	// Domains registered in a phase that requires validation are pending create until the registry validates them
+	code := epplib.StatusSuccess
+	if dom.Status.PendingCreate {
+		code = epplib.StatusActionPending
+	}
+	resp := NewResponse(code, cmd.ClTRID).WithResData(NewDomainCreData(dom))
+	if feeData != nil {
+		resp.WithExtension(feeData)
+	}
+	if cmd.Extension.Launch != nil {
+		resp.WithExtension(NewLaunchCreData(regCmd.PhaseName, ""))
+	}
+	writeResponse(ctx, rw, resp)
+}
+
+// Update handles the domain <update> command.
+// The command is processed as a whole (RFC 5730): every change is validated first and they are persisted together in a single transaction.
+// The service applies the changes to the domain as it is stored, in the following order:
+//  1. remove statuses, this allows a client to remove clientUpdateProhibited and make other changes in the same command
+//  2. contact, chg and secDNS changes, DS records are removed before they are added (RFC 5910)
+//  3. remove and add nameservers
+//  4. add statuses, this allows a client to make changes and set clientUpdateProhibited in the same command
+func (ctrl *DomainController) Update(ctx context.Context, rw epplib.Writer, doc *etree.Document) {
+	var cmd DomainUpdateCommand
+	if err := unmarshalCommand(doc, &cmd); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	clID, err := clIDFromContext(ctx)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	if cmd.Name == "" {
+		writeResponse(ctx, rw, NewErrorResponse(ErrMissingDomainName, cmd.ClTRID))
+		return
+	}
+	if cmd.Add == nil {
+		cmd.Add = &DomainAddRem{}
+	}
+	if cmd.Rem == nil {
+		cmd.Rem = &DomainAddRem{}
+	}
+	if cmd.Extension.Launch != nil {
+		ctrl.updateApplication(ctx, rw, &cmd, clID)
+		return
+	}
+	if cmd.Extension.RGP != nil {
+		ctrl.restore(ctx, rw, &cmd, clID)
+		return
+	}
+
+	// Clients can only manipulate client statuses
+	for _, s := range slices.Concat(cmd.Add.Statuses, cmd.Rem.Statuses) {
+		if !s.IsClientStatus() {
+			writeResponse(ctx, rw, NewErrorResponse(errors.Join(ErrServerStatusNotAllowed, fmt.Errorf("status: %s", s.S)), cmd.ClTRID))
+			return
+		}
+	}
+
+	// Only the changes are sent to the service, it applies them to the domain as it is stored when the update is saved
+	upCmd := &commands.ApplyDomainUpdateCommand{
+		Name:               cmd.Name,
+		ClID:               clID,
+		RemContacts:        domainContactChanges(cmd.Rem.Contacts),
+		AddContacts:        domainContactChanges(cmd.Add.Contacts),
+		RemHosts:           cmd.Rem.HostObjs,
+		AddHosts:           cmd.Add.HostObjs,
+		EnforcePhasePolicy: true,
+	}
+	for _, s := range cmd.Rem.Statuses {
+		upCmd.RemStatuses = append(upCmd.RemStatuses, s.S)
+	}
+	for _, s := range cmd.Add.Statuses {
+		upCmd.AddStatuses = append(upCmd.AddStatuses, s.S)
+	}
+	if cmd.Chg != nil {
+		upCmd.RegistrantID = cmd.Chg.Registrant
+		upCmd.AuthInfo = cmd.Chg.AuthInfo
+	}
+	if !cmd.Extension.SecDNS.IsEmpty() {
+		secDNS, err := secDNSChangesFromUpdateExtension(ctx, cmd.Extension.SecDNS)
+		if err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+		upCmd.SecDNS = secDNS
+	}
+
+	if _, err := ctrl.domainService.ApplyDomainUpdate(ctx, upCmd); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+
+	writeResponse(ctx, rw, NewResponse(epplib.StatusSuccess, cmd.ClTRID))
+}
+
+// Delete handles the domain <delete> command.
+// Domains are not deleted immediately but go through the RGP lifecycle, hence we respond with 1001 (action pending)
+func (ctrl *DomainController) Delete(ctx context.Context, rw epplib.Writer, doc *etree.Document) {
+	var cmd DomainDeleteCommand
+	if err := unmarshalCommand(doc, &cmd); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	clID, err := clIDFromContext(ctx)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	if cmd.Name == "" {
+		writeResponse(ctx, rw, NewErrorResponse(ErrMissingDomainName, cmd.ClTRID))
+		return
+	}
+	if cmd.Extension.Launch != nil {
+		ctrl.deleteApplication(ctx, rw, &cmd, clID)
+		return
+	}
+
+	dom, err := ctrl.domainService.GetDomainByName(ctx, cmd.Name, false)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	if dom.ClID.String() != clID {
+		writeResponse(ctx, rw, NewErrorResponse(entities.ErrInvalidRegistrar, cmd.ClTRID))
+		return
+	}
+
+	if _, err := ctrl.domainService.MarkDomainForDeletion(ctx, cmd.Name); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+
+	writeResponse(ctx, rw, NewResponse(epplib.StatusActionPending, cmd.ClTRID))
+}
+
+// Renew handles the domain <renew> command.
+// The curExpDate must match the current expiry date of the domain to prevent unintended replayed renewals
+func (ctrl *DomainController) Renew(ctx context.Context, rw epplib.Writer, doc *etree.Document) {
+	var cmd DomainRenewCommand
+	if err := unmarshalCommand(doc, &cmd); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	clID, err := clIDFromContext(ctx)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	if cmd.Name == "" {
+		writeResponse(ctx, rw, NewErrorResponse(ErrMissingDomainName, cmd.ClTRID))
+		return
+	}
+
+	years, err := cmd.Period.Years()
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+
+	dom, err := ctrl.domainService.GetDomainByName(ctx, cmd.Name, false)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	if dom.ClID.String() != clID {
+		writeResponse(ctx, rw, NewErrorResponse(entities.ErrInvalidRegistrar, cmd.ClTRID))
+		return
+	}
+	if dom.ExpiryDate.UTC().Format(CUR_EXP_DATE_FORMAT) != cmd.CurExpDate {
+		writeResponse(ctx, rw, NewErrorResponse(ErrCurExpDateMismatch, cmd.ClTRID))
+		return
+	}
+
+	renewCmd := &commands.RenewDomainCommand{
+		Name:  cmd.Name,
+		ClID:  clID,
+		Years: years,
+	}
+	var feeData *FeeTrnData
+	if cmd.Extension.Fee != nil {
+		renewCmd.Fee, feeData, err = ctrl.validateFee(ctx, cmd.Extension.Fee, "renData", &queries.QuoteRequest{
+			DomainName:      cmd.Name,
+			ClID:            clID,
+			TransactionType: entities.TransactionTypeRenewal,
+			Currency:        cmd.Extension.Fee.Currency,
+			Years:           years,
+		})
+		if err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+	}
+
+	renewed, err := ctrl.domainService.RenewDomain(ctx, renewCmd, false)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+
+	resp := NewResponse(epplib.StatusSuccess, cmd.ClTRID).WithResData(NewDomainRenData(renewed))
+	if feeData != nil {
+		resp.WithExtension(feeData)
+	}
+	writeResponse(ctx, rw, resp)
+}
+
+// Transfer handles the domain <transfer> command.
+// A request must include the domain's authInfo and returns 1001 as the losing registrar needs to act on it.
+// The losing registrar can approve or reject a pending transfer and the gaining registrar can cancel it, the service enforces these roles.
+// A query is answered for the sponsor, the gaining registrar of the most recent transfer, or any client that provides the correct authInfo.
+func (ctrl *DomainController) Transfer(ctx context.Context, rw epplib.Writer, doc *etree.Document) {
+	var cmd DomainTransferCommand
+	if err := unmarshalCommand(doc, &cmd); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	clID, err := clIDFromContext(ctx)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	if cmd.Transfer.Name == "" {
+		writeResponse(ctx, rw, NewErrorResponse(ErrMissingDomainName, cmd.ClTRID))
+		return
+	}
+
+	dom, err := ctrl.domainService.GetDomainByName(ctx, cmd.Transfer.Name, false)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+
+	switch cmd.Transfer.Op {
+	case "request":
+		ctrl.transferRequest(ctx, rw, &cmd, dom)
+	case "query":
+		ctrl.transferQuery(ctx, rw, &cmd, dom, clID)
+	case "approve", "reject", "cancel":
+		var transfer *entities.DomainTransfer
+		switch cmd.Transfer.Op {
+		case "approve":
+			transfer, err = ctrl.domainService.ApproveDomainTransfer(ctx, dom.Name.String(), clID)
+		case "reject":
+			transfer, err = ctrl.domainService.RejectDomainTransfer(ctx, dom.Name.String(), clID, "")
+		case "cancel":
+			transfer, err = ctrl.domainService.CancelDomainTransfer(ctx, dom.Name.String(), clID)
+		}
+		if err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+		var exDate time.Time
+		if transfer.Status == entities.TransferStatusApproved {
+			exDate = dom.ExpiryDate.AddDate(transfer.Years, 0, 0)
+		}
+		writeResponse(ctx, rw, NewResponse(epplib.StatusSuccess, cmd.ClTRID).WithResData(NewDomainTrnData(transfer, exDate)))
+	default:
+		writeResponse(ctx, rw, NewErrorResponse(errors.Join(ErrInvalidCommand, fmt.Errorf("unknown transfer op: %s", cmd.Transfer.Op)), cmd.ClTRID))
+	}
+}
+
+// transferRequest handles <transfer op="request">. The exDate in the response is the expiry date the domain will have once the transfer is approved.
+func (ctrl *DomainController) transferRequest(ctx context.Context, rw epplib.Writer, cmd *DomainTransferCommand, dom *entities.Domain) {
+	clID, err := clIDForCreateFromContext(ctx)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	if dom.ClID.String() != clID && cmd.Transfer.AuthInfo != dom.AuthInfo.String() {
+		writeResponse(ctx, rw, NewErrorResponse(ErrAuthInfoMismatch, cmd.ClTRID))
+		return
+	}
+	years, err := cmd.Transfer.Period.Years()
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	// Transfers are charged when they are approved, the fee is validated now so the gaining registrar knows what it will be charged
+	var feeData *FeeTrnData
+	if cmd.Extension.Fee != nil {
+		feeYears := years
+		if feeYears == 0 {
+			feeYears = entities.TRANSFER_YEARS
+		}
+		_, feeData, err = ctrl.validateFee(ctx, cmd.Extension.Fee, "trnData", &queries.QuoteRequest{
+			DomainName:      dom.Name.String(),
+			ClID:            clID,
+			TransactionType: entities.TransactionTypeTransfer,
+			Currency:        cmd.Extension.Fee.Currency,
+			Years:           feeYears,
+		})
+		if err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+	}
+
+	transfer, err := ctrl.domainService.RequestDomainTransfer(ctx, &entities.RequestTransferCommand{
+		DomainName:       dom.Name.String(),
+		GainingRegistrar: clID,
+		LosingRegistrar:  dom.ClID.String(),
+		AuthInfo:         cmd.Transfer.AuthInfo,
+		Years:            years,
+	})
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+
+	resp := NewResponse(epplib.StatusActionPending, cmd.ClTRID).WithResData(NewDomainTrnData(transfer, dom.ExpiryDate.AddDate(transfer.Years, 0, 0)))
+	if feeData != nil {
+		resp.WithExtension(feeData)
+	}
+	writeResponse(ctx, rw, resp)
+}
+
+// transferQuery handles <transfer op="query"> and reports on the most recent transfer of the domain
+func (ctrl *DomainController) transferQuery(ctx context.Context, rw epplib.Writer, cmd *DomainTransferCommand, dom *entities.Domain, clID string) {
+	transfer, err := ctrl.domainService.GetDomainTransfer(ctx, dom.Name.String())
+	if err != nil && !errors.Is(err, entities.ErrTransferNotFound) {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	isGainingRegistrar := transfer != nil && transfer.GainingRegistrar.String() == clID
+	if dom.ClID.String() != clID && !isGainingRegistrar && cmd.Transfer.AuthInfo != dom.AuthInfo.String() {
+		writeResponse(ctx, rw, NewErrorResponse(ErrAuthInfoMismatch, cmd.ClTRID))
+		return
+	}
+	if transfer == nil {
+		writeResponse(ctx, rw, NewErrorResponse(ErrTransferNotPending, cmd.ClTRID))
+		return
+	}
+
+	exDate := dom.ExpiryDate
+	if transfer.IsPending() {
+		exDate = dom.ExpiryDate.AddDate(transfer.Years, 0, 0)
+	}
+	writeResponse(ctx, rw, NewResponse(epplib.StatusSuccess, cmd.ClTRID).WithResData(NewDomainTrnData(transfer, exDate)))
+}
+
+// feeTransactionTypes maps the commands of the fee extension to the transaction types we quote for them
+var feeTransactionTypes = map[string]entities.TransactionType{
+	"create":   entities.TransactionTypeRegistration,
+	"renew":    entities.TransactionTypeRenewal,
+	"transfer": entities.TransactionTypeTransfer,
+	"restore":  entities.TransactionTypeRestore,
+}
+
+// feeCheck creates the <fee:chkData> element with the fees of the requested commands for each domain.
+// If the client did not request a currency, the currency of the first quote is used for all domains.
+// If we can't quote a domain (e.g. its TLD is unknown), its <fee:cd> is marked unavailable with the reason, errors we can't map fail the whole command.
+// The discount of the allocation token, if provided, is included in the create fees.
+func (ctrl *DomainController) feeCheck(ctx context.Context, clID string, names []string, ext *FeeCheck, allocationToken string) (*FeeChkData, error) {
+	chkData := NewFeeChkData()
+	chkData.Currency = strings.ToUpper(ext.Currency)
+	for _, name := range names {
+		cd, err := ctrl.feeCD(ctx, clID, name, &chkData.Currency, ext.Commands, allocationToken)
+		if err != nil {
+			if ResultCodeFromError(err) == epplib.StatusCommandFailed {
+				return nil, err
+			}
+			cd = &FeeCD{ObjID: name, Reason: err.Error()}
+		}
+		chkData.CD = append(chkData.CD, *cd)
+	}
+	// None of the domains could be quoted, the currency is still required in the response
+	if chkData.Currency == "" {
+		chkData.Currency = DEFAULT_FEE_CURRENCY
+	}
+	return chkData, nil
+}
+
+// feeCD quotes the commands for a single domain. The period defaults to one year, restores don't have a period.
+// The currency is set to the currency of the quote if it was empty.
+func (ctrl *DomainController) feeCD(ctx context.Context, clID, name string, currency *string, feeCommands []FeeCheckCommand, allocationToken string) (*FeeCD, error) {
+	cd := &FeeCD{Avail: 1, ObjID: name}
+	for _, c := range feeCommands {
+		transactionType, ok := feeTransactionTypes[c.Name]
+		if !ok {
+			return nil, errors.Join(ErrUnsupportedFeeCommand, fmt.Errorf("command: %s", c.Name))
+		}
+		years, err := c.Period.Years()
+		if err != nil {
+			return nil, err
+		}
+		if years == 0 {
+			years = 1
+		}
+		quote, err := ctrl.domainService.GetQuote(ctx, &queries.QuoteRequest{
+			DomainName:      name,
+			ClID:            clID,
+			TransactionType: transactionType,
+			Currency:        *currency,
+			Years:           years,
+			PhaseName:       c.Phase,
+			AllocationToken: allocationToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		fees, err := feesFromQuote(quote)
+		if err != nil {
+			return nil, err
+		}
+		if *currency == "" {
+			*currency = quote.Price.Currency().Code
+		}
+		if cd.Class == "" {
+			cd.Class = quote.Class
+		}
+		fc := FeeCommand{Name: c.Name, Phase: c.Phase, Fees: fees, Credits: creditsFromQuote(quote)}
+		if quote.Class == "standard" {
+			fc.Standard = 1
+		}
+		if transactionType != entities.TransactionTypeRestore {
+			fc.Period = &FeePeriod{Unit: "y", Value: years}
+		}
+		cd.Commands = append(cd.Commands, fc)
+	}
+	return cd, nil
+}
+
+// validateFee checks the fee the client agreed to pay in the fee extension of a transform command against our quote for the transaction.
+// It returns the fee to pass on to the service, which charges it, and the fee data for the response.
+func (ctrl *DomainController) validateFee(ctx context.Context, ext *FeeTransform, responseElement string, q *queries.QuoteRequest) (commands.FeeExtension, *FeeTrnData, error) {
+	if !hasExtensionFromContext(ctx, FEE_NAMESPACE) {
+		return commands.FeeExtension{}, nil, errors.Join(ErrExtensionNotRequested, fmt.Errorf("extURI: %s", FEE_NAMESPACE))
+	}
+	quote, err := ctrl.domainService.GetQuote(ctx, q)
+	if err != nil {
+		return commands.FeeExtension{}, nil, err
+	}
+	fee, err := ext.ToFeeExtension(quote)
+	if err != nil {
+		return commands.FeeExtension{}, nil, err
+	}
+	if err := fee.Validate(quote); err != nil {
+		return commands.FeeExtension{}, nil, err
+	}
+	feeData, err := NewFeeTrnData(responseElement, quote)
+	if err != nil {
+		return commands.FeeExtension{}, nil, err
+	}
+	return fee, feeData, nil
+}
+
+// domainContacts points to the contacts and authInfo of a domain or a domain application so the changes of an update command can be applied to both
+type domainContacts struct {
+	name       entities.DomainName
+	registrant *entities.ClIDType
+	admin      *entities.ClIDType
+	tech       *entities.ClIDType
+	billing    *entities.ClIDType
+	authInfo   *entities.AuthInfoType
+}
+
+// contactsOfApplication returns the contacts of a domain application
+func contactsOfApplication(app *entities.DomainApplication) domainContacts {
+	return domainContacts{
+		name:       app.DomainName,
+		registrant: &app.RegistrantID,
+		admin:      &app.AdminID,
+		tech:       &app.TechID,
+		billing:    &app.BillingID,
+		authInfo:   &app.AuthInfo,
+	}
+}
+
+// applyDomainContactChanges applies the contact changes of an update command to a domain or domain application.
+// Contacts are removed before they are added so a contact can be replaced in a single command.
+func applyDomainContactChanges(contacts domainContacts, add, rem []DomainContact, chg *DomainChg) error {
+	for _, c := range rem {
+		field, err := domainContactField(contacts, c.Type)
+		if err != nil {
+			return err
+		}
+		if field.String() != c.Value {
+			return errors.Join(entities.ErrContactNotFound, fmt.Errorf("%s is not the %s contact of %s", c.Value, c.Type, contacts.name))
+		}
+		*field = ""
+	}
+	for _, c := range add {
+		field, err := domainContactField(contacts, c.Type)
+		if err != nil {
+			return err
+		}
+		*field = entities.ClIDType(c.Value)
+	}
+	if chg != nil {
+		if chg.Registrant != nil {
+			*contacts.registrant = entities.ClIDType(*chg.Registrant)
+		}
+		if chg.AuthInfo != nil {
+			*contacts.authInfo = entities.AuthInfoType(*chg.AuthInfo)
+		}
+	}
+	return nil
+}
+
+// secDNSFromCreateExtension converts the secDNS extension of a create command to the DNSSEC delegation data of the new domain
+func secDNSFromCreateExtension(ctx context.Context, ext *SecDNSCreate) (*entities.DomainSecDNS, error) {
+	if !hasExtensionFromContext(ctx, SECDNS_NAMESPACE) {
+		return nil, errors.Join(ErrExtensionNotRequested, fmt.Errorf("extURI: %s", SECDNS_NAMESPACE))
+	}
+	if len(ext.KeyData) > 0 {
+		return nil, ErrSecDNSKeyDataNotSupported
+	}
+	secDNS := &entities.DomainSecDNS{MaxSigLife: ext.MaxSigLife}
+	for _, d := range ext.DSData {
+		ds, err := d.ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		secDNS.DSData = append(secDNS.DSData, *ds)
+	}
+	return secDNS, nil
+}
+
+// secDNSChangesFromUpdateExtension converts the secDNS extension of an update command to the changes of the DNSSEC delegation data of the domain
+func secDNSChangesFromUpdateExtension(ctx context.Context, ext *SecDNSUpdate) (*commands.DomainSecDNSChanges, error) {
+	if !hasExtensionFromContext(ctx, SECDNS_NAMESPACE) {
+		return nil, errors.Join(ErrExtensionNotRequested, fmt.Errorf("extURI: %s", SECDNS_NAMESPACE))
+	}
+	if ext.Urgent {
+		return nil, ErrSecDNSUrgentNotSupported
+	}
+	chg := &commands.DomainSecDNSChanges{}
+	if ext.Rem != nil {
+		if len(ext.Rem.KeyData) > 0 {
+			return nil, ErrSecDNSKeyDataNotSupported
+		}
+		chg.RemAll = ext.Rem.All
+		for _, d := range ext.Rem.DSData {
+			ds, err := d.ToEntity()
+			if err != nil {
+				return nil, err
+			}
+			chg.RemDSData = append(chg.RemDSData, *ds)
+		}
+	}
+	if ext.Add != nil {
+		if len(ext.Add.KeyData) > 0 {
+			return nil, ErrSecDNSKeyDataNotSupported
+		}
+		for _, d := range ext.Add.DSData {
+			ds, err := d.ToEntity()
+			if err != nil {
+				return nil, err
+			}
+			chg.AddDSData = append(chg.AddDSData, *ds)
+		}
+	}
+	if ext.Chg != nil && ext.Chg.MaxSigLife != nil {
+		// The schema requires a positive maxSigLife, 0 would remove the preference
+		if *ext.Chg.MaxSigLife < 1 {
+			return nil, entities.ErrInvalidMaxSigLife
+		}
+		chg.MaxSigLife = ext.Chg.MaxSigLife
+	}
+	return chg, nil
+}
+
+// domainContactChanges converts the contacts of an update command to the contact changes of the domain
+func domainContactChanges(contacts []DomainContact) []commands.DomainContactChange {
+	var changes []commands.DomainContactChange
+	for _, c := range contacts {
+		changes = append(changes, commands.DomainContactChange{Type: c.Type, ID: c.Value})
+	}
+	return changes
+}
+
+// domainContactField returns a pointer to the contact field for the provided contact type
+func domainContactField(contacts domainContacts, contactType string) (*entities.ClIDType, error) {
+	switch contactType {
+	case "admin":
+		return contacts.admin, nil
+	case "tech":
+		return contacts.tech, nil
+	case "billing":
+		return contacts.billing, nil
+	default:
+		return nil, errors.Join(entities.ErrInvalidContact, fmt.Errorf("unknown contact type: %s", contactType))
+	}
+}
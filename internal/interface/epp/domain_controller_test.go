@@ -0,0 +1,1359 @@
+package epp
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	epplib "github.com/dotse/epp-lib"
+	"github.com/miekg/dns"
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/application/services"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockDomainService is a mock implementation of the DomainService
+type MockDomainService struct {
+	mock.Mock
+}
+
+func (m *MockDomainService) GetDomainByName(ctx context.Context, name string, preloadHosts bool) (*entities.Domain, error) {
+	args := m.Called(ctx, name, preloadHosts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Domain), args.Error(1)
+}
+
+func (m *MockDomainService) Create(ctx context.Context, cmd *commands.CreateDomainCommand) (*entities.Domain, error) {
+	args := m.Called(ctx, cmd)
+	return args.Get(0).(*entities.Domain), args.Error(1)
+}
+
+func (m *MockDomainService) DeleteDomainByName(ctx context.Context, name string) error {
+	return m.Called(ctx, name).Error(0)
+}
+
+func (m *MockDomainService) ListDomains(ctx context.Context, params queries.ListItemsQuery) ([]*entities.Domain, string, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]*entities.Domain), args.String(1), args.Error(2)
+}
+
+func (m *MockDomainService) UpdateDomain(ctx context.Context, name string, cmd *commands.UpdateDomainCommand) (*entities.Domain, error) {
+	args := m.Called(ctx, name, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Domain), args.Error(1)
+}
+
+func (m *MockDomainService) ApplyDomainUpdate(ctx context.Context, cmd *commands.ApplyDomainUpdateCommand) (*entities.Domain, error) {
+	args := m.Called(ctx, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Domain), args.Error(1)
+}
+
+func (m *MockDomainService) AddHostToDomain(ctx context.Context, name string, hostRoID string, force bool) error {
+	return m.Called(ctx, name, hostRoID, force).Error(0)
+}
+
+func (m *MockDomainService) AddHostToDomainByHostName(ctx context.Context, domainName, hostName string, force bool) error {
+	return m.Called(ctx, domainName, hostName, force).Error(0)
+}
+
+func (m *MockDomainService) RemoveAllDomainHosts(ctx context.Context, name string) error {
+	return m.Called(ctx, name).Error(0)
+}
+
+func (m *MockDomainService) RemoveHostFromDomain(ctx context.Context, name string, hostRoID string) error {
+	return m.Called(ctx, name, hostRoID).Error(0)
+}
+
+func (m *MockDomainService) RemoveHostFromDomainByHostName(ctx context.Context, domainName, hostName string) error {
+	return m.Called(ctx, domainName, hostName).Error(0)
+}
+
+func (m *MockDomainService) DropCatchDomain(ctx context.Context, name string, dropcatch bool) error {
+	return m.Called(ctx, name, dropcatch).Error(0)
+}
+
+func (m *MockDomainService) Count(ctx context.Context, filter queries.ListDomainsFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockDomainService) ListExpiringDomains(ctx context.Context, q *queries.ExpiringDomainsQuery, pageSize int, cursor string) ([]*entities.Domain, error) {
+	args := m.Called(ctx, q, pageSize, cursor)
+	return args.Get(0).([]*entities.Domain), args.Error(1)
+}
+
+func (m *MockDomainService) CountExpiringDomains(ctx context.Context, q *queries.ExpiringDomainsQuery) (int64, error) {
+	args := m.Called(ctx, q)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockDomainService) ListPurgeableDomains(ctx context.Context, q *queries.PurgeableDomainsQuery, pageSize int, cursor string) ([]*entities.Domain, error) {
+	args := m.Called(ctx, q, pageSize, cursor)
+	return args.Get(0).([]*entities.Domain), args.Error(1)
+}
+
+func (m *MockDomainService) CountPurgeableDomains(ctx context.Context, q *queries.PurgeableDomainsQuery) (int64, error) {
+	args := m.Called(ctx, q)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockDomainService) ListRestoredDomains(ctx context.Context, q *queries.RestoredDomainsQuery, pageSize int, cursor string) ([]*entities.Domain, error) {
+	args := m.Called(ctx, q, pageSize, cursor)
+	return args.Get(0).([]*entities.Domain), args.Error(1)
+}
+
+func (m *MockDomainService) CountRestoredDomains(ctx context.Context, q *queries.RestoredDomainsQuery) (int64, error) {
+	args := m.Called(ctx, q)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockDomainService) BulkCreate(ctx context.Context, cmds []*commands.CreateDomainCommand) error {
+	return m.Called(ctx, cmds).Error(0)
+}
+
+func (m *MockDomainService) CheckDomainAvailability(ctx context.Context, domainname, phaseName, clID, allocationToken string) (*queries.DomainCheckResult, error) {
+	args := m.Called(ctx, domainname, phaseName, clID, allocationToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*queries.DomainCheckResult), args.Error(1)
+}
+
+func (m *MockDomainService) GetQuote(ctx context.Context, q *queries.QuoteRequest) (*entities.Quote, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Quote), args.Error(1)
+}
+
+func (m *MockDomainService) RegisterDomain(ctx context.Context, cmd *commands.RegisterDomainCommand) (*entities.Domain, error) {
+	args := m.Called(ctx, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Domain), args.Error(1)
+}
+
+func (m *MockDomainService) RenewDomain(ctx context.Context, cmd *commands.RenewDomainCommand, force bool) (*entities.Domain, error) {
+	args := m.Called(ctx, cmd, force)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Domain), args.Error(1)
+}
+
+func (m *MockDomainService) CanAutoRenew(ctx context.Context, domainName string) (bool, error) {
+	args := m.Called(ctx, domainName)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockDomainService) AutoRenewDomain(ctx context.Context, domainName string, years int) (*entities.Domain, error) {
+	args := m.Called(ctx, domainName, years)
+	return args.Get(0).(*entities.Domain), args.Error(1)
+}
+
+func (m *MockDomainService) MarkDomainForDeletion(ctx context.Context, domainName string) (*entities.Domain, error) {
+	args := m.Called(ctx, domainName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Domain), args.Error(1)
+}
+
+func (m *MockDomainService) ExpireDomain(ctx context.Context, domainName string) (*entities.Domain, error) {
+	args := m.Called(ctx, domainName)
+	return args.Get(0).(*entities.Domain), args.Error(1)
+}
+
+func (m *MockDomainService) RestoreDomain(ctx context.Context, domainName string, fee commands.FeeExtension) (*entities.Domain, error) {
+	args := m.Called(ctx, domainName, fee)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Domain), args.Error(1)
+}
+
+func (m *MockDomainService) ReportDomainRestore(ctx context.Context, cmd *commands.RestoreReportCommand) (*entities.Domain, error) {
+	args := m.Called(ctx, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Domain), args.Error(1)
+}
+
+func (m *MockDomainService) CompleteDomainRestore(ctx context.Context, domainName string) (*entities.Domain, error) {
+	args := m.Called(ctx, domainName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Domain), args.Error(1)
+}
+
+func (m *MockDomainService) PurgeDomain(ctx context.Context, domainName string) error {
+	return m.Called(ctx, domainName).Error(0)
+}
+
+func (m *MockDomainService) GetNSRecordsPerTLD(ctx context.Context, params queries.ActiveDomainsWithHostsQuery) ([]dns.RR, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]dns.RR), args.Error(1)
+}
+
+func (m *MockDomainService) GetGlueRecordsPerTLD(ctx context.Context, tld string) ([]dns.RR, error) {
+	args := m.Called(ctx, tld)
+	return args.Get(0).([]dns.RR), args.Error(1)
+}
+
+func (m *MockDomainService) SetStatus(ctx context.Context, name, status string) (*entities.Domain, error) {
+	args := m.Called(ctx, name, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Domain), args.Error(1)
+}
+
+func (m *MockDomainService) UnSetStatus(ctx context.Context, name, status string) (*entities.Domain, error) {
+	args := m.Called(ctx, name, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Domain), args.Error(1)
+}
+
+func (m *MockDomainService) RequestDomainTransfer(ctx context.Context, cmd *entities.RequestTransferCommand) (*entities.DomainTransfer, error) {
+	args := m.Called(ctx, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DomainTransfer), args.Error(1)
+}
+
+func (m *MockDomainService) ApproveDomainTransfer(ctx context.Context, domainName, clid string) (*entities.DomainTransfer, error) {
+	args := m.Called(ctx, domainName, clid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DomainTransfer), args.Error(1)
+}
+
+func (m *MockDomainService) RejectDomainTransfer(ctx context.Context, domainName, clid, reason string) (*entities.DomainTransfer, error) {
+	args := m.Called(ctx, domainName, clid, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DomainTransfer), args.Error(1)
+}
+
+func (m *MockDomainService) CancelDomainTransfer(ctx context.Context, domainName, clid string) (*entities.DomainTransfer, error) {
+	args := m.Called(ctx, domainName, clid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DomainTransfer), args.Error(1)
+}
+
+func (m *MockDomainService) GetDomainTransfer(ctx context.Context, domainName string) (*entities.DomainTransfer, error) {
+	args := m.Called(ctx, domainName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DomainTransfer), args.Error(1)
+}
+
+func (m *MockDomainService) ListDomainTransfers(ctx context.Context, params queries.ListItemsQuery) ([]*entities.DomainTransfer, string, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]*entities.DomainTransfer), args.String(1), args.Error(2)
+}
+
+func (m *MockDomainService) CountDomainTransfers(ctx context.Context, filter queries.ListDomainTransfersFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockDomainService) GetClaimKey(ctx context.Context, domainName string) (string, error) {
+	args := m.Called(ctx, domainName)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockDomainService) CreateDomainApplication(ctx context.Context, cmd *commands.CreateDomainApplicationCommand) (*entities.DomainApplication, error) {
+	args := m.Called(ctx, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DomainApplication), args.Error(1)
+}
+
+func (m *MockDomainService) GetDomainApplication(ctx context.Context, id string) (*entities.DomainApplication, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DomainApplication), args.Error(1)
+}
+
+func (m *MockDomainService) UpdateDomainApplication(ctx context.Context, app *entities.DomainApplication) (*entities.DomainApplication, error) {
+	args := m.Called(ctx, app)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DomainApplication), args.Error(1)
+}
+
+func (m *MockDomainService) WithdrawDomainApplication(ctx context.Context, id, clid string) error {
+	args := m.Called(ctx, id, clid)
+	return args.Error(0)
+}
+
+func (m *MockDomainService) SetDomainApplicationStatus(ctx context.Context, id string, status entities.ApplicationStatus, reason string) (*entities.DomainApplication, error) {
+	args := m.Called(ctx, id, status, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DomainApplication), args.Error(1)
+}
+
+func (m *MockDomainService) AllocateDomainApplication(ctx context.Context, id string) (*entities.DomainApplication, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DomainApplication), args.Error(1)
+}
+
+func (m *MockDomainService) ListDomainApplications(ctx context.Context, params queries.ListItemsQuery) ([]*entities.DomainApplication, string, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]*entities.DomainApplication), args.String(1), args.Error(2)
+}
+
+func (m *MockDomainService) CountDomainApplications(ctx context.Context, filter queries.ListDomainApplicationsFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// eppCommand wraps a command body in the <epp><command> frame
+func eppCommand(body string) string {
+	return `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<epp xmlns="urn:ietf:params:xml:ns:epp-1.0"><command>` + body + `<clTRID>ABC-12345</clTRID></command></epp>`
+}
+
+// getTestDomain returns a domain sponsored by ClID-1
+func getTestDomain() *entities.Domain {
+	return &entities.Domain{
+		RoID:         "12345_DOM-APEX",
+		Name:         "example.com",
+		RegistrantID: "reg-1",
+		AdminID:      "adm-1",
+		TechID:       "tech-1",
+		ClID:         "ClID-1",
+		CrRr:         "ClID-1",
+		AuthInfo:     "sTr0ngP@ss",
+		CreatedAt:    time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		ExpiryDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Status:       entities.DomainStatus{OK: true},
+		Hosts:        []*entities.Host{{Name: "ns1.example.net"}},
+	}
+}
+
+func TestDomainController_Check(t *testing.T) {
+	svc := new(MockDomainService)
+	ctrl := &DomainController{domainService: svc}
+
+	svc.On("CheckDomainAvailability", mock.Anything, "free.com", "", mock.Anything, "").Return(&queries.DomainCheckResult{Available: true}, nil)
+	svc.On("CheckDomainAvailability", mock.Anything, "taken.com", "", mock.Anything, "").Return(&queries.DomainCheckResult{Reason: services.ErrDomainExists.Error()}, nil)
+	svc.On("CheckDomainAvailability", mock.Anything, "-bad.com", "", mock.Anything, "").Return(&queries.DomainCheckResult{}, errors.Join(entities.ErrInvalidDomainName, entities.ErrInvalidLabelDash))
+
+	w := &testWriter{}
+	ctrl.Check(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<check><domain:check xmlns:domain="urn:ietf:params:xml:ns:domain-1.0"><domain:name>free.com</domain:name><domain:name>taken.com</domain:name><domain:name>-bad.com</domain:name></domain:check></check>`)))
+
+	s := w.String()
+	require.Contains(t, s, `<result code="1000">`)
+	require.Contains(t, s, `<domain:name avail="1">free.com</domain:name>`)
+	require.Contains(t, s, `<domain:name avail="0">taken.com</domain:name><domain:reason>domain exists</domain:reason>`)
+	require.Contains(t, s, `<domain:name avail="0">-bad.com</domain:name>`)
+	require.Contains(t, s, `<clTRID>ABC-12345</clTRID>`)
+}
+
+func TestDomainController_Check_NotLoggedIn(t *testing.T) {
+	ctrl := &DomainController{domainService: new(MockDomainService)}
+
+	w := &testWriter{}
+	ctrl.Check(context.Background(), w, newTestDoc(t, eppCommand(`<check><domain:check xmlns:domain="urn:ietf:params:xml:ns:domain-1.0"><domain:name>free.com</domain:name></domain:check></check>`)))
+
+	require.Contains(t, w.String(), `<result code="2002">`)
+}
+
+func TestDomainController_Check_ServiceFailure(t *testing.T) {
+	svc := new(MockDomainService)
+	ctrl := &DomainController{domainService: svc}
+	svc.On("CheckDomainAvailability", mock.Anything, "free.com", "", mock.Anything, "").Return(&queries.DomainCheckResult{}, errors.New("connection refused"))
+
+	w := &testWriter{}
+	ctrl.Check(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<check><domain:check xmlns:domain="urn:ietf:params:xml:ns:domain-1.0"><domain:name>free.com</domain:name></domain:check></check>`)))
+
+	require.Contains(t, w.String(), `<result code="2400">`)
+	require.NotContains(t, w.String(), "chkData")
+}
+
+func TestDomainController_Info(t *testing.T) {
+	tc := []struct {
+		name         string
+		clID         string
+		body         string
+		wantCode     string
+		wantAuthInfo bool
+		wantHosts    bool
+	}{
+		{
+			name:         "sponsor",
+			clID:         "ClID-1",
+			body:         `<domain:name>example.com</domain:name>`,
+			wantCode:     `<result code="1000">`,
+			wantAuthInfo: true,
+			wantHosts:    true,
+		},
+		{
+			name:      "non sponsor",
+			clID:      "ClID-2",
+			body:      `<domain:name>example.com</domain:name>`,
+			wantCode:  `<result code="1000">`,
+			wantHosts: true,
+		},
+		{
+			name:     "sponsor hosts none",
+			clID:     "ClID-1",
+			body:     `<domain:name hosts="none">example.com</domain:name>`,
+			wantCode: `<result code="1000">`,
+			// authInfo is still shown to the sponsor
+			wantAuthInfo: true,
+		},
+		{
+			name:     "non sponsor wrong authinfo",
+			clID:     "ClID-2",
+			body:     `<domain:name>example.com</domain:name><domain:authInfo><domain:pw>wrong</domain:pw></domain:authInfo>`,
+			wantCode: `<result code="2202">`,
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockDomainService)
+			ctrl := &DomainController{domainService: svc}
+			svc.On("GetDomainByName", mock.Anything, "example.com", true).Return(getTestDomain(), nil)
+
+			w := &testWriter{}
+			ctrl.Info(newTestContext(tt.clID), w, newTestDoc(t, eppCommand(`<info><domain:info xmlns:domain="urn:ietf:params:xml:ns:domain-1.0">`+tt.body+`</domain:info></info>`)))
+
+			s := w.String()
+			require.Contains(t, s, tt.wantCode)
+			if tt.wantCode != `<result code="1000">` {
+				require.NotContains(t, s, "infData")
+				return
+			}
+			require.Contains(t, s, `<domain:name>example.com</domain:name><domain:roid>12345_DOM-APEX</domain:roid><domain:status s="ok"></domain:status><domain:registrant>reg-1</domain:registrant>`)
+			require.Contains(t, s, `<domain:contact type="admin">adm-1</domain:contact><domain:contact type="tech">tech-1</domain:contact>`)
+			require.Contains(t, s, `<domain:exDate>2025-01-01T00:00:00.0Z</domain:exDate>`)
+			if tt.wantAuthInfo {
+				require.Contains(t, s, `<domain:authInfo><domain:pw>sTr0ngP@ss</domain:pw></domain:authInfo>`)
+			} else {
+				require.NotContains(t, s, "sTr0ngP@ss")
+			}
+			if tt.wantHosts {
+				require.Contains(t, s, `<domain:ns><domain:hostObj>ns1.example.net</domain:hostObj></domain:ns>`)
+			} else {
+				require.NotContains(t, s, "domain:ns")
+			}
+		})
+	}
+}
+
+func TestDomainController_Info_NotFound(t *testing.T) {
+	svc := new(MockDomainService)
+	ctrl := &DomainController{domainService: svc}
+	svc.On("GetDomainByName", mock.Anything, "example.com", true).Return(nil, entities.ErrDomainNotFound)
+
+	w := &testWriter{}
+	ctrl.Info(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<info><domain:info xmlns:domain="urn:ietf:params:xml:ns:domain-1.0"><domain:name>example.com</domain:name></domain:info></info>`)))
+
+	require.Contains(t, w.String(), `<result code="2303">`)
+}
+
+func TestDomainController_Create(t *testing.T) {
+	tc := []struct {
+		name      string
+		period    string
+		svcErr    error
+		wantYears int
+		wantCode  string
+	}{
+		{"default period", "", nil, 0, `<result code="1000">`},
+		{"years", `<domain:period unit="y">2</domain:period>`, nil, 2, `<result code="1000">`},
+		{"months", `<domain:period unit="m">24</domain:period>`, nil, 2, `<result code="1000">`},
+		{"invalid months", `<domain:period unit="m">6</domain:period>`, nil, 0, `<result code="2004">`},
+		{"exists", "", errors.Join(entities.ErrInvalidDomain, services.ErrDomainExists), 0, `<result code="2302">`},
+		{"not accredited", "", errors.Join(services.ErrRegistrarNotAccredited, errors.New("Registrar.ClID: ClID-1, TLD: com")), 0, `<result code="2201">`},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockDomainService)
+			ctrl := &DomainController{domainService: svc}
+			if tt.svcErr != nil {
+				svc.On("RegisterDomain", mock.Anything, mock.Anything).Return(nil, tt.svcErr)
+			} else {
+				svc.On("RegisterDomain", mock.Anything, mock.MatchedBy(func(cmd *commands.RegisterDomainCommand) bool {
+					return cmd.Name == "example.com" &&
+						cmd.ClID == "ClID-1" &&
+						cmd.Years == tt.wantYears &&
+						cmd.RegistrantID == "reg-1" &&
+						cmd.AdminID == "adm-1" &&
+						cmd.TechID == "tech-1" &&
+						cmd.AuthInfo == "sTr0ngP@ss" &&
+						len(cmd.HostNames) == 2
+				})).Return(getTestDomain(), nil)
+			}
+
+			w := &testWriter{}
+			ctrl.Create(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<create><domain:create xmlns:domain="urn:ietf:params:xml:ns:domain-1.0">
+				<domain:name>example.com</domain:name>`+tt.period+`
+				<domain:ns><domain:hostObj>ns1.example.net</domain:hostObj><domain:hostObj>ns2.example.net</domain:hostObj></domain:ns>
+				<domain:registrant>reg-1</domain:registrant>
+				<domain:contact type="admin">adm-1</domain:contact>
+				<domain:contact type="tech">tech-1</domain:contact>
+				<domain:authInfo><domain:pw>sTr0ngP@ss</domain:pw></domain:authInfo>
+			</domain:create></create>`)))
+
+			s := w.String()
+			require.Contains(t, s, tt.wantCode)
+			if tt.wantCode == `<result code="1000">` {
+				require.Contains(t, s, `<domain:creData xmlns:domain="urn:ietf:params:xml:ns:domain-1.0"><domain:name>example.com</domain:name><domain:crDate>2023-01-01T00:00:00.0Z</domain:crDate><domain:exDate>2025-01-01T00:00:00.0Z</domain:exDate></domain:creData>`)
+				svc.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestDomainController_Update(t *testing.T) {
+	svc := new(MockDomainService)
+	ctrl := &DomainController{domainService: svc}
+
+	// Only the changes are sent, the domain is not read by the controller
+	svc.On("ApplyDomainUpdate", mock.Anything, mock.MatchedBy(func(cmd *commands.ApplyDomainUpdateCommand) bool {
+		return cmd.Name == "example.com" && cmd.ClID == "ClID-1" &&
+			reflect.DeepEqual(cmd.RemHosts, []string{"ns1.example.net"}) && reflect.DeepEqual(cmd.AddHosts, []string{"ns2.example.net"}) &&
+			reflect.DeepEqual(cmd.AddStatuses, []string{"clientHold"}) && len(cmd.RemStatuses) == 0 &&
+			reflect.DeepEqual(cmd.RemContacts, []commands.DomainContactChange{{Type: "tech", ID: "tech-1"}}) &&
+			reflect.DeepEqual(cmd.AddContacts, []commands.DomainContactChange{{Type: "tech", ID: "tech-2"}}) &&
+			*cmd.RegistrantID == "reg-2" && *cmd.AuthInfo == "n3wP@ssw0rd" && cmd.SecDNS == nil && cmd.EnforcePhasePolicy
+	})).Return(getTestDomain(), nil)
+
+	w := &testWriter{}
+	ctrl.Update(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<update><domain:update xmlns:domain="urn:ietf:params:xml:ns:domain-1.0">
+		<domain:name>example.com</domain:name>
+		<domain:add>
+			<domain:ns><domain:hostObj>ns2.example.net</domain:hostObj></domain:ns>
+			<domain:contact type="tech">tech-2</domain:contact>
+			<domain:status s="clientHold" lang="en">Payment overdue.</domain:status>
+		</domain:add>
+		<domain:rem>
+			<domain:ns><domain:hostObj>ns1.example.net</domain:hostObj></domain:ns>
+			<domain:contact type="tech">tech-1</domain:contact>
+		</domain:rem>
+		<domain:chg>
+			<domain:registrant>reg-2</domain:registrant>
+			<domain:authInfo><domain:pw>n3wP@ssw0rd</domain:pw></domain:authInfo>
+		</domain:chg>
+	</domain:update></update>`)))
+
+	require.Contains(t, w.String(), `<result code="1000">`)
+	svc.AssertExpectations(t)
+}
+
+func TestDomainController_Update_Errors(t *testing.T) {
+	tc := []struct {
+		name     string
+		body     string
+		svcErr   error
+		wantCode string
+	}{
+		{
+			name:     "not sponsor",
+			body:     `<domain:add><domain:contact type="tech">tech-2</domain:contact></domain:add>`,
+			svcErr:   entities.ErrInvalidRegistrar,
+			wantCode: `<result code="2201">`,
+		},
+		{
+			name:     "server status",
+			body:     `<domain:add><domain:status s="serverHold"/></domain:add>`,
+			wantCode: `<result code="2306">`,
+		},
+		{
+			name:     "update prohibited",
+			body:     `<domain:add><domain:contact type="tech">tech-2</domain:contact></domain:add>`,
+			svcErr:   entities.ErrDomainUpdateNotAllowed,
+			wantCode: `<result code="2304">`,
+		},
+		{
+			name:     "remove wrong contact",
+			body:     `<domain:rem><domain:contact type="tech">someone-else</domain:contact></domain:rem>`,
+			svcErr:   errors.Join(entities.ErrContactNotFound, errors.New("someone-else is not the tech contact of example.com")),
+			wantCode: `<result code="2303">`,
+		},
+		{
+			name:     "unknown contact type",
+			body:     `<domain:add><domain:contact type="owner">tech-2</domain:contact></domain:add>`,
+			svcErr:   errors.Join(entities.ErrInvalidContact, errors.New("unknown contact type: owner")),
+			wantCode: `<result code="2005">`,
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockDomainService)
+			ctrl := &DomainController{domainService: svc}
+			if tt.svcErr != nil {
+				svc.On("ApplyDomainUpdate", mock.Anything, mock.Anything).Return(nil, tt.svcErr)
+			}
+
+			w := &testWriter{}
+			ctrl.Update(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<update><domain:update xmlns:domain="urn:ietf:params:xml:ns:domain-1.0"><domain:name>example.com</domain:name>`+tt.body+`</domain:update></update>`)))
+
+			require.Contains(t, w.String(), tt.wantCode)
+			if tt.svcErr == nil {
+				svc.AssertNotCalled(t, "ApplyDomainUpdate", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestDomainController_Update_RemoveUpdateProhibited(t *testing.T) {
+	svc := new(MockDomainService)
+	ctrl := &DomainController{domainService: svc}
+
+	svc.On("ApplyDomainUpdate", mock.Anything, mock.MatchedBy(func(cmd *commands.ApplyDomainUpdateCommand) bool {
+		return reflect.DeepEqual(cmd.RemStatuses, []string{"clientUpdateProhibited"}) &&
+			reflect.DeepEqual(cmd.AddContacts, []commands.DomainContactChange{{Type: "billing", ID: "bill-1"}})
+	})).Return(getTestDomain(), nil)
+
+	w := &testWriter{}
+	ctrl.Update(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<update><domain:update xmlns:domain="urn:ietf:params:xml:ns:domain-1.0"><domain:name>example.com</domain:name>
+		<domain:add><domain:contact type="billing">bill-1</domain:contact></domain:add>
+		<domain:rem><domain:status s="clientUpdateProhibited"/></domain:rem>
+	</domain:update></update>`)))
+
+	require.Contains(t, w.String(), `<result code="1000">`)
+	svc.AssertExpectations(t)
+}
+
+func TestDomainController_Delete(t *testing.T) {
+	tc := []struct {
+		name     string
+		clID     string
+		svcErr   error
+		wantCode string
+	}{
+		{"success", "ClID-1", nil, `<result code="1001">`},
+		{"not sponsor", "ClID-2", nil, `<result code="2201">`},
+		{"prohibited", "ClID-1", entities.ErrDomainDeleteNotAllowed, `<result code="2304">`},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockDomainService)
+			ctrl := &DomainController{domainService: svc}
+			svc.On("GetDomainByName", mock.Anything, "example.com", false).Return(getTestDomain(), nil)
+			if tt.svcErr != nil {
+				svc.On("MarkDomainForDeletion", mock.Anything, "example.com").Return(nil, tt.svcErr)
+			} else {
+				svc.On("MarkDomainForDeletion", mock.Anything, "example.com").Return(getTestDomain(), nil)
+			}
+
+			w := &testWriter{}
+			ctrl.Delete(newTestContext(tt.clID), w, newTestDoc(t, eppCommand(`<delete><domain:delete xmlns:domain="urn:ietf:params:xml:ns:domain-1.0"><domain:name>example.com</domain:name></domain:delete></delete>`)))
+
+			require.Contains(t, w.String(), tt.wantCode)
+			if tt.clID != "ClID-1" {
+				svc.AssertNotCalled(t, "MarkDomainForDeletion", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestDomainController_Renew(t *testing.T) {
+	tc := []struct {
+		name       string
+		curExpDate string
+		svcErr     error
+		wantCode   string
+	}{
+		{"success", "2025-01-01", nil, `<result code="1000">`},
+		{"wrong curExpDate", "2024-01-01", nil, `<result code="2004">`},
+		{"max horizon", "2025-01-01", errors.Join(entities.ErrInvalidRenewal, entities.ErrDomainRenewExceedsMaxHorizon), `<result code="2004">`},
+		{"renew prohibited", "2025-01-01", errors.Join(entities.ErrInvalidRenewal, entities.ErrDomainStatusProhibitsRenewal), `<result code="2304">`},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockDomainService)
+			ctrl := &DomainController{domainService: svc}
+			svc.On("GetDomainByName", mock.Anything, "example.com", false).Return(getTestDomain(), nil)
+			renewed := getTestDomain()
+			renewed.ExpiryDate = renewed.ExpiryDate.AddDate(3, 0, 0)
+			if tt.svcErr != nil {
+				svc.On("RenewDomain", mock.Anything, mock.Anything, false).Return(nil, tt.svcErr)
+			} else {
+				svc.On("RenewDomain", mock.Anything, &commands.RenewDomainCommand{Name: "example.com", ClID: "ClID-1", Years: 3}, false).Return(renewed, nil)
+			}
+
+			w := &testWriter{}
+			ctrl.Renew(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<renew><domain:renew xmlns:domain="urn:ietf:params:xml:ns:domain-1.0"><domain:name>example.com</domain:name><domain:curExpDate>`+tt.curExpDate+`</domain:curExpDate><domain:period unit="y">3</domain:period></domain:renew></renew>`)))
+
+			s := w.String()
+			require.Contains(t, s, tt.wantCode)
+			if tt.wantCode == `<result code="1000">` {
+				require.Contains(t, s, `<domain:renData xmlns:domain="urn:ietf:params:xml:ns:domain-1.0"><domain:name>example.com</domain:name><domain:exDate>2028-01-01T00:00:00.0Z</domain:exDate></domain:renData>`)
+			}
+		})
+	}
+}
+
+// getTestTransfer returns a transfer of example.com from ClID-1 to ClID-2 with the provided status
+func getTestTransfer(status entities.TransferStatus) *entities.DomainTransfer {
+	return &entities.DomainTransfer{
+		DomainName:       "example.com",
+		GainingRegistrar: "ClID-2",
+		LosingRegistrar:  "ClID-1",
+		Status:           status,
+		Years:            1,
+		CreatedAt:        time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		ExpiryDate:       time.Date(2024, 6, 6, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:        time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// transferCommand returns a domain <transfer> command for example.com
+func transferCommand(op, authInfo string) string {
+	return eppCommand(`<transfer op="` + op + `"><domain:transfer xmlns:domain="urn:ietf:params:xml:ns:domain-1.0"><domain:name>example.com</domain:name><domain:authInfo><domain:pw>` + authInfo + `</domain:pw></domain:authInfo></domain:transfer></transfer>`)
+}
+
+func TestDomainController_Transfer(t *testing.T) {
+	tc := []struct {
+		name     string
+		clID     string
+		op       string
+		authInfo string
+		transfer *entities.DomainTransfer
+		wantCode int
+	}{
+		{"query by sponsor without transfers", "ClID-1", "query", "", nil, epplib.StatusObjectNotPendingTransfer},
+		{"query with authinfo", "ClID-3", "query", "sTr0ngP@ss", getTestTransfer(entities.TransferStatusPending), epplib.StatusSuccess},
+		{"query by gaining registrar", "ClID-2", "query", "", getTestTransfer(entities.TransferStatusPending), epplib.StatusSuccess},
+		{"query wrong authinfo", "ClID-3", "query", "wrong", getTestTransfer(entities.TransferStatusPending), epplib.StatusInvalidAuthorizationInformation},
+		{"request wrong authinfo", "ClID-2", "request", "wrong", nil, epplib.StatusInvalidAuthorizationInformation},
+		{"unknown op", "ClID-1", "steal", "", nil, epplib.StatusCommandSyntaxError},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockDomainService)
+			ctrl := &DomainController{domainService: svc}
+			svc.On("GetDomainByName", mock.Anything, "example.com", false).Return(getTestDomain(), nil)
+			if tt.transfer != nil {
+				svc.On("GetDomainTransfer", mock.Anything, "example.com").Return(tt.transfer, nil)
+			} else {
+				svc.On("GetDomainTransfer", mock.Anything, "example.com").Return(nil, entities.ErrTransferNotFound)
+			}
+
+			w := &testWriter{}
+			ctrl.Transfer(newTestContext(tt.clID), w, newTestDoc(t, transferCommand(tt.op, tt.authInfo)))
+
+			require.Equal(t, tt.wantCode, decodeResultCode(t, w.Bytes()))
+		})
+	}
+}
+
+func TestDomainController_Transfer_Request(t *testing.T) {
+	tc := []struct {
+		name     string
+		svcErr   error
+		wantCode int
+	}{
+		{"success", nil, epplib.StatusActionPending},
+		{"pending transfer", entities.ErrDomainPendingTransfer, epplib.StatusObjectPendingTransfer},
+		{"transfer lock", entities.ErrDomainTransferLocked, epplib.StatusNotEligibleForTransfer},
+		{"transfer prohibited", entities.ErrDomainTransferNotAllowed, epplib.StatusObjectStatusProhibitsOperation},
+		{"not accredited", services.ErrRegistrarNotAccredited, epplib.StatusAuthorizationError},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockDomainService)
+			ctrl := &DomainController{domainService: svc}
+			svc.On("GetDomainByName", mock.Anything, "example.com", false).Return(getTestDomain(), nil)
+			cmd := &entities.RequestTransferCommand{DomainName: "example.com", GainingRegistrar: "ClID-2", LosingRegistrar: "ClID-1", AuthInfo: "sTr0ngP@ss"}
+			if tt.svcErr != nil {
+				svc.On("RequestDomainTransfer", mock.Anything, cmd).Return(nil, tt.svcErr)
+			} else {
+				svc.On("RequestDomainTransfer", mock.Anything, cmd).Return(getTestTransfer(entities.TransferStatusPending), nil)
+			}
+
+			w := &testWriter{}
+			ctrl.Transfer(newTestContext("ClID-2"), w, newTestDoc(t, transferCommand("request", "sTr0ngP@ss")))
+
+			require.Equal(t, tt.wantCode, decodeResultCode(t, w.Bytes()))
+			if tt.svcErr == nil {
+				require.Contains(t, w.String(), `<domain:trnData xmlns:domain="urn:ietf:params:xml:ns:domain-1.0"><domain:name>example.com</domain:name><domain:trStatus>pending</domain:trStatus><domain:reID>ClID-2</domain:reID><domain:reDate>2024-06-01T00:00:00.0Z</domain:reDate><domain:acID>ClID-1</domain:acID><domain:acDate>2024-06-06T00:00:00.0Z</domain:acDate><domain:exDate>2026-01-01T00:00:00.0Z</domain:exDate></domain:trnData>`)
+			}
+		})
+	}
+}
+
+func TestDomainController_Transfer_Actions(t *testing.T) {
+	tc := []struct {
+		name       string
+		clID       string
+		op         string
+		method     string
+		args       []interface{}
+		transfer   *entities.DomainTransfer
+		svcErr     error
+		wantCode   int
+		wantStatus string
+	}{
+		{"approve", "ClID-1", "approve", "ApproveDomainTransfer", []interface{}{mock.Anything, "example.com", "ClID-1"}, getTestTransfer(entities.TransferStatusApproved), nil, epplib.StatusSuccess, "clientApproved"},
+		{"reject", "ClID-1", "reject", "RejectDomainTransfer", []interface{}{mock.Anything, "example.com", "ClID-1", ""}, getTestTransfer(entities.TransferStatusDenied), nil, epplib.StatusSuccess, "clientRejected"},
+		{"cancel", "ClID-2", "cancel", "CancelDomainTransfer", []interface{}{mock.Anything, "example.com", "ClID-2"}, getTestTransfer(entities.TransferStatusCancelled), nil, epplib.StatusSuccess, "clientCancelled"},
+		{"approve by gaining registrar", "ClID-2", "approve", "ApproveDomainTransfer", []interface{}{mock.Anything, "example.com", "ClID-2"}, nil, entities.ErrInvalidRegistrar, epplib.StatusAuthorizationError, ""},
+		{"cancel without pending transfer", "ClID-2", "cancel", "CancelDomainTransfer", []interface{}{mock.Anything, "example.com", "ClID-2"}, nil, entities.ErrNoPendingTransfer, epplib.StatusObjectNotPendingTransfer, ""},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockDomainService)
+			ctrl := &DomainController{domainService: svc}
+			svc.On("GetDomainByName", mock.Anything, "example.com", false).Return(getTestDomain(), nil)
+			if tt.svcErr != nil {
+				svc.On(tt.method, tt.args...).Return(nil, tt.svcErr)
+			} else {
+				svc.On(tt.method, tt.args...).Return(tt.transfer, nil)
+			}
+
+			w := &testWriter{}
+			ctrl.Transfer(newTestContext(tt.clID), w, newTestDoc(t, transferCommand(tt.op, "")))
+
+			require.Equal(t, tt.wantCode, decodeResultCode(t, w.Bytes()))
+			if tt.wantStatus != "" {
+				require.Contains(t, w.String(), `<domain:trStatus>`+tt.wantStatus+`</domain:trStatus>`)
+			}
+		})
+	}
+}
+
+func TestPeriod_Years(t *testing.T) {
+	tc := []struct {
+		period  *Period
+		want    int
+		wantErr error
+	}{
+		{nil, 0, nil},
+		{&Period{Unit: "y", Value: 1}, 1, nil},
+		{&Period{Unit: "m", Value: 12}, 1, nil},
+		{&Period{Unit: "m", Value: 13}, 0, ErrInvalidPeriod},
+		{&Period{Unit: "y", Value: 0}, 0, ErrInvalidPeriod},
+		{&Period{Unit: "d", Value: 1}, 0, ErrInvalidPeriod},
+	}
+
+	for _, tt := range tc {
+		got, err := tt.period.Years()
+		require.ErrorIs(t, err, tt.wantErr)
+		require.Equal(t, tt.want, got)
+	}
+}
+
+const testDSDigest = "E2D3C916F6DEEAC73294E8268FB5885044A833FC5459588F4A9184CFC41A5766"
+
+// secDNSExtension wraps a secDNS extension element in the <extension> element
+func secDNSExtension(body string) string {
+	return `<extension>` + body + `</extension>`
+}
+
+func TestDomainController_Info_SecDNS(t *testing.T) {
+	svc := new(MockDomainService)
+	ctrl := &DomainController{domainService: svc}
+	dom := getTestDomain()
+	dom.SecDNS = entities.DomainSecDNS{MaxSigLife: 604800, DSData: []entities.DomainDSData{{KeyTag: 12345, Alg: 13, DigestType: 2, Digest: testDSDigest}}}
+	svc.On("GetDomainByName", mock.Anything, "example.com", true).Return(dom, nil)
+	cmd := eppCommand(`<info><domain:info xmlns:domain="urn:ietf:params:xml:ns:domain-1.0"><domain:name>example.com</domain:name></domain:info></info>`)
+
+	w := &testWriter{}
+	ctrl.Info(newTestContext("ClID-1"), w, newTestDoc(t, cmd))
+	require.Contains(t, w.String(), `<extension><secDNS:infData xmlns:secDNS="urn:ietf:params:xml:ns:secDNS-1.1"><secDNS:maxSigLife>604800</secDNS:maxSigLife><secDNS:dsData><secDNS:keyTag>12345</secDNS:keyTag><secDNS:alg>13</secDNS:alg><secDNS:digestType>2</secDNS:digestType><secDNS:digest>`+testDSDigest+`</secDNS:digest></secDNS:dsData></secDNS:infData></extension>`)
+
+	// Clients that did not request the extension at login don't get it
+	s := NewSession()
+	s.Login("ClID-1", entities.RegistrarStatusOK, nil)
+	w = &testWriter{}
+	ctrl.Info(ContextWithSession(context.Background(), s), w, newTestDoc(t, cmd))
+	require.Contains(t, w.String(), `<result code="1000">`)
+	require.NotContains(t, w.String(), "secDNS")
+}
+
+func TestDomainController_Create_SecDNS(t *testing.T) {
+	tc := []struct {
+		name       string
+		extensions []string
+		ext        string
+		wantSecDNS *entities.DomainSecDNS
+		wantCode   string
+	}{
+		{
+			name:       "dsData",
+			extensions: supportedExtURIs,
+			ext:        `<secDNS:create xmlns:secDNS="urn:ietf:params:xml:ns:secDNS-1.1"><secDNS:maxSigLife>604800</secDNS:maxSigLife><secDNS:dsData><secDNS:keyTag>12345</secDNS:keyTag><secDNS:alg>13</secDNS:alg><secDNS:digestType>2</secDNS:digestType><secDNS:digest>` + strings.ToLower(testDSDigest) + `</secDNS:digest></secDNS:dsData></secDNS:create>`,
+			wantSecDNS: &entities.DomainSecDNS{MaxSigLife: 604800, DSData: []entities.DomainDSData{{KeyTag: 12345, Alg: 13, DigestType: 2, Digest: testDSDigest}}},
+			wantCode:   `<result code="1000">`,
+		},
+		{
+			name:       "other extensions with the same element name are ignored",
+			extensions: supportedExtURIs,
+			ext:        `<other:create xmlns:other="urn:ietf:params:xml:ns:other-1.0"><other:dsData/></other:create>`,
+			wantSecDNS: &entities.DomainSecDNS{},
+			wantCode:   `<result code="1000">`,
+		},
+		{
+			name:       "keyData",
+			extensions: supportedExtURIs,
+			ext:        `<secDNS:create xmlns:secDNS="urn:ietf:params:xml:ns:secDNS-1.1"><secDNS:keyData><secDNS:flags>257</secDNS:flags><secDNS:protocol>3</secDNS:protocol><secDNS:alg>13</secDNS:alg><secDNS:pubKey>AQPJ////4Q==</secDNS:pubKey></secDNS:keyData></secDNS:create>`,
+			wantCode:   `<result code="2306">`,
+		},
+		{
+			name:       "invalid digest",
+			extensions: supportedExtURIs,
+			ext:        `<secDNS:create xmlns:secDNS="urn:ietf:params:xml:ns:secDNS-1.1"><secDNS:dsData><secDNS:keyTag>12345</secDNS:keyTag><secDNS:alg>13</secDNS:alg><secDNS:digestType>2</secDNS:digestType><secDNS:digest>ABCDEF</secDNS:digest></secDNS:dsData></secDNS:create>`,
+			wantCode:   `<result code="2005">`,
+		},
+		{
+			name:     "extension not requested at login",
+			ext:      `<secDNS:create xmlns:secDNS="urn:ietf:params:xml:ns:secDNS-1.1"><secDNS:dsData><secDNS:keyTag>12345</secDNS:keyTag><secDNS:alg>13</secDNS:alg><secDNS:digestType>2</secDNS:digestType><secDNS:digest>` + testDSDigest + `</secDNS:digest></secDNS:dsData></secDNS:create>`,
+			wantCode: `<result code="2103">`,
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockDomainService)
+			ctrl := &DomainController{domainService: svc}
+			if tt.wantSecDNS != nil {
+				svc.On("RegisterDomain", mock.Anything, mock.MatchedBy(func(cmd *commands.RegisterDomainCommand) bool {
+					return reflect.DeepEqual(cmd.SecDNS, *tt.wantSecDNS)
+				})).Return(getTestDomain(), nil)
+			}
+			s := NewSession()
+			s.Login("ClID-1", entities.RegistrarStatusOK, tt.extensions)
+
+			w := &testWriter{}
+			ctrl.Create(ContextWithSession(context.Background(), s), w, newTestDoc(t, eppCommand(`<create><domain:create xmlns:domain="urn:ietf:params:xml:ns:domain-1.0">
+				<domain:name>example.com</domain:name>
+				<domain:registrant>reg-1</domain:registrant>
+				<domain:authInfo><domain:pw>sTr0ngP@ss</domain:pw></domain:authInfo>
+			</domain:create></create>`+secDNSExtension(tt.ext))))
+
+			require.Contains(t, w.String(), tt.wantCode)
+			svc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDomainController_Update_SecDNS(t *testing.T) {
+	dsData := func(keyTag string) string {
+		return `<secDNS:dsData><secDNS:keyTag>` + keyTag + `</secDNS:keyTag><secDNS:alg>13</secDNS:alg><secDNS:digestType>2</secDNS:digestType><secDNS:digest>` + testDSDigest + `</secDNS:digest></secDNS:dsData>`
+	}
+	maxSigLife := 3600
+	tc := []struct {
+		name        string
+		ext         string
+		svcErr      error
+		wantChanges *commands.DomainSecDNSChanges
+		wantCode    string
+	}{
+		{
+			name: "replace a DS record and change maxSigLife",
+			ext:  `<secDNS:update xmlns:secDNS="urn:ietf:params:xml:ns:secDNS-1.1"><secDNS:rem>` + dsData("12345") + `</secDNS:rem><secDNS:add>` + dsData("54321") + `</secDNS:add><secDNS:chg><secDNS:maxSigLife>3600</secDNS:maxSigLife></secDNS:chg></secDNS:update>`,
+			wantChanges: &commands.DomainSecDNSChanges{
+				RemDSData:  []entities.DomainDSData{{KeyTag: 12345, Alg: 13, DigestType: 2, Digest: testDSDigest}},
+				AddDSData:  []entities.DomainDSData{{KeyTag: 54321, Alg: 13, DigestType: 2, Digest: testDSDigest}},
+				MaxSigLife: &maxSigLife,
+			},
+			wantCode: `<result code="1000">`,
+		},
+		{
+			name:        "remove all",
+			ext:         `<secDNS:update xmlns:secDNS="urn:ietf:params:xml:ns:secDNS-1.1"><secDNS:rem><secDNS:all>true</secDNS:all></secDNS:rem></secDNS:update>`,
+			wantChanges: &commands.DomainSecDNSChanges{RemAll: true},
+			wantCode:    `<result code="1000">`,
+		},
+		{
+			name:     "urgent",
+			ext:      `<secDNS:update xmlns:secDNS="urn:ietf:params:xml:ns:secDNS-1.1" urgent="true"><secDNS:add>` + dsData("54321") + `</secDNS:add></secDNS:update>`,
+			wantCode: `<result code="2102">`,
+		},
+		{
+			name:     "remove unknown DS record",
+			ext:      `<secDNS:update xmlns:secDNS="urn:ietf:params:xml:ns:secDNS-1.1"><secDNS:rem>` + dsData("54321") + `</secDNS:rem></secDNS:update>`,
+			svcErr:   entities.ErrDSDataNotFound,
+			wantCode: `<result code="2306">`,
+		},
+		{
+			name:     "add existing DS record",
+			ext:      `<secDNS:update xmlns:secDNS="urn:ietf:params:xml:ns:secDNS-1.1"><secDNS:add>` + dsData("12345") + `</secDNS:add></secDNS:update>`,
+			svcErr:   entities.ErrDuplicateDSData,
+			wantCode: `<result code="2306">`,
+		},
+		{
+			name:     "invalid maxSigLife",
+			ext:      `<secDNS:update xmlns:secDNS="urn:ietf:params:xml:ns:secDNS-1.1"><secDNS:chg><secDNS:maxSigLife>0</secDNS:maxSigLife></secDNS:chg></secDNS:update>`,
+			wantCode: `<result code="2004">`,
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockDomainService)
+			ctrl := &DomainController{domainService: svc}
+			if tt.wantChanges != nil {
+				svc.On("ApplyDomainUpdate", mock.Anything, mock.MatchedBy(func(cmd *commands.ApplyDomainUpdateCommand) bool {
+					return reflect.DeepEqual(cmd.SecDNS, tt.wantChanges)
+				})).Return(getTestDomain(), nil)
+			}
+			if tt.svcErr != nil {
+				svc.On("ApplyDomainUpdate", mock.Anything, mock.Anything).Return(nil, tt.svcErr)
+			}
+
+			w := &testWriter{}
+			ctrl.Update(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<update><domain:update xmlns:domain="urn:ietf:params:xml:ns:domain-1.0"><domain:name>example.com</domain:name></domain:update></update>`+secDNSExtension(tt.ext))))
+
+			require.Contains(t, w.String(), tt.wantCode)
+			svc.AssertExpectations(t)
+			if tt.wantChanges == nil && tt.svcErr == nil {
+				svc.AssertNotCalled(t, "ApplyDomainUpdate", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+// feeExtension wraps a fee extension element in the <extension> element
+func feeExtension(body string) string {
+	return `<extension>` + body + `</extension>`
+}
+
+// getTestQuote returns a quote in USD for a 2 year registration with a yearly registration fee of 10.00 and a one-time, non-refundable sunrise fee of 50.00
+func getTestQuote(t *testing.T) *entities.Quote {
+	refundable, nonRefundable := true, false
+	quote := entities.NewQuote("USD")
+	quote.Years = 2
+	quote.Class = "standard"
+	quote.FXRate = &entities.FX{BaseCurrency: "USD", TargetCurrency: "USD", Rate: 1}
+	require.NoError(t, quote.AddFeeAndUpdatePrice(&entities.Fee{Name: "registration fee", Amount: 1000, Currency: "USD", Refundable: &refundable}, true))
+	require.NoError(t, quote.AddFeeAndUpdatePrice(&entities.Fee{Name: "sunrise fee", Amount: 5000, Currency: "USD", Refundable: &nonRefundable}, false))
+	return quote
+}
+
+func TestDomainController_Check_Fee(t *testing.T) {
+	svc := new(MockDomainService)
+	ctrl := &DomainController{domainService: svc}
+	svc.On("CheckDomainAvailability", mock.Anything, "free.com", "", mock.Anything, "").Return(&queries.DomainCheckResult{Available: true}, nil)
+	svc.On("CheckDomainAvailability", mock.Anything, "free.unknown", "", mock.Anything, "").Return(&queries.DomainCheckResult{}, entities.ErrTLDNotFound)
+	svc.On("GetQuote", mock.Anything, &queries.QuoteRequest{DomainName: "free.com", ClID: "ClID-1", TransactionType: entities.TransactionTypeRegistration, Currency: "USD", Years: 2}).Return(getTestQuote(t), nil)
+	restoreQuote := entities.NewQuote("USD")
+	restoreQuote.Class = "premium"
+	restoreQuote.Price = money.New(4000, "USD")
+	svc.On("GetQuote", mock.Anything, &queries.QuoteRequest{DomainName: "free.com", ClID: "ClID-1", TransactionType: entities.TransactionTypeRestore, Currency: "USD", Years: 1}).Return(restoreQuote, nil)
+	svc.On("GetQuote", mock.Anything, mock.MatchedBy(func(q *queries.QuoteRequest) bool { return q.DomainName == "free.unknown" })).Return(nil, entities.ErrTLDNotFound)
+
+	w := &testWriter{}
+	ctrl.Check(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<check><domain:check xmlns:domain="urn:ietf:params:xml:ns:domain-1.0"><domain:name>free.com</domain:name><domain:name>free.unknown</domain:name></domain:check></check>`+
+		feeExtension(`<fee:check xmlns:fee="urn:ietf:params:xml:ns:epp:fee-1.0"><fee:currency>usd</fee:currency><fee:command name="create"><fee:period unit="y">2</fee:period></fee:command><fee:command name="restore"/></fee:check>`))))
+
+	s := w.String()
+	require.Contains(t, s, `<result code="1000">`)
+	require.Contains(t, s, `<extension><fee:chkData xmlns:fee="urn:ietf:params:xml:ns:epp:fee-1.0"><fee:currency>USD</fee:currency>`+
+		`<fee:cd avail="1"><fee:objID>free.com</fee:objID><fee:class>standard</fee:class>`+
+		`<fee:command name="create" standard="1"><fee:period unit="y">2</fee:period><fee:fee description="registration fee" refundable="1">20.00</fee:fee><fee:fee description="sunrise fee" refundable="0">50.00</fee:fee></fee:command>`+
+		`<fee:command name="restore"><fee:fee refundable="0">40.00</fee:fee></fee:command></fee:cd>`+
+		`<fee:cd avail="0"><fee:objID>free.unknown</fee:objID><fee:reason>TLD not found</fee:reason></fee:cd></fee:chkData></extension>`)
+}
+
+func TestDomainController_Check_Fee_Errors(t *testing.T) {
+	tc := []struct {
+		name       string
+		extensions []string
+		ext        string
+		quoteErr   error
+		wantCode   string
+		want       string
+	}{
+		{
+			name:       "extension not requested at login",
+			ext:        `<fee:check xmlns:fee="urn:ietf:params:xml:ns:epp:fee-1.0"><fee:command name="create"/></fee:check>`,
+			extensions: []string{SECDNS_NAMESPACE},
+			wantCode:   `<result code="2103">`,
+		},
+		{
+			name:       "no commands",
+			ext:        `<fee:check xmlns:fee="urn:ietf:params:xml:ns:epp:fee-1.0"><fee:currency>USD</fee:currency></fee:check>`,
+			extensions: supportedExtURIs,
+			wantCode:   `<result code="2003">`,
+		},
+		{
+			name:       "unsupported command",
+			ext:        `<fee:check xmlns:fee="urn:ietf:params:xml:ns:epp:fee-1.0"><fee:command name="delete"/></fee:check>`,
+			extensions: supportedExtURIs,
+			wantCode:   `<result code="1000">`,
+			want:       `<fee:cd avail="0"><fee:objID>free.com</fee:objID><fee:reason>` + ErrUnsupportedFeeCommand.Error(),
+		},
+		{
+			name:       "quote failure",
+			ext:        `<fee:check xmlns:fee="urn:ietf:params:xml:ns:epp:fee-1.0"><fee:command name="create"/></fee:check>`,
+			extensions: supportedExtURIs,
+			quoteErr:   errors.New("connection refused"),
+			wantCode:   `<result code="2400">`,
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockDomainService)
+			ctrl := &DomainController{domainService: svc}
+			svc.On("CheckDomainAvailability", mock.Anything, "free.com", "", mock.Anything, "").Return(&queries.DomainCheckResult{Available: true}, nil)
+			svc.On("GetQuote", mock.Anything, mock.Anything).Return(nil, tt.quoteErr)
+			s := NewSession()
+			s.Login("ClID-1", entities.RegistrarStatusOK, tt.extensions)
+
+			w := &testWriter{}
+			ctrl.Check(ContextWithSession(context.Background(), s), w, newTestDoc(t, eppCommand(`<check><domain:check xmlns:domain="urn:ietf:params:xml:ns:domain-1.0"><domain:name>free.com</domain:name></domain:check></check>`+feeExtension(tt.ext))))
+
+			require.Contains(t, w.String(), tt.wantCode)
+			require.Contains(t, w.String(), tt.want)
+		})
+	}
+}
+
+func TestDomainController_Create_Fee(t *testing.T) {
+	tc := []struct {
+		name     string
+		ext      string
+		wantFee  commands.FeeExtension
+		wantCode string
+	}{
+		{
+			name:     "matching fee",
+			ext:      `<fee:create xmlns:fee="urn:ietf:params:xml:ns:epp:fee-1.0"><fee:currency>USD</fee:currency><fee:fee>70.00</fee:fee></fee:create>`,
+			wantFee:  commands.FeeExtension{Currency: "USD", Amount: 7000, Provided: true},
+			wantCode: `<result code="1000">`,
+		},
+		{
+			name:     "matching fees without currency",
+			ext:      `<fee:create xmlns:fee="urn:ietf:params:xml:ns:epp:fee-1.0"><fee:fee>20</fee:fee><fee:fee>50.000</fee:fee></fee:create>`,
+			wantFee:  commands.FeeExtension{Currency: "USD", Amount: 7000, Provided: true},
+			wantCode: `<result code="1000">`,
+		},
+		{
+			name:     "fee too low",
+			ext:      `<fee:create xmlns:fee="urn:ietf:params:xml:ns:epp:fee-1.0"><fee:currency>USD</fee:currency><fee:fee>20.00</fee:fee></fee:create>`,
+			wantCode: `<result code="2004">`,
+		},
+		{
+			name:     "currency mismatch",
+			ext:      `<fee:create xmlns:fee="urn:ietf:params:xml:ns:epp:fee-1.0"><fee:currency>EUR</fee:currency><fee:fee>70.00</fee:fee></fee:create>`,
+			wantCode: `<result code="2004">`,
+		},
+		{
+			name:     "invalid amount",
+			ext:      `<fee:create xmlns:fee="urn:ietf:params:xml:ns:epp:fee-1.0"><fee:fee>seventy</fee:fee></fee:create>`,
+			wantCode: `<result code="2005">`,
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockDomainService)
+			ctrl := &DomainController{domainService: svc}
+			currency := "USD"
+			if strings.Contains(tt.ext, "EUR") {
+				currency = "EUR"
+			} else if !strings.Contains(tt.ext, "currency") {
+				currency = ""
+			}
+			svc.On("GetQuote", mock.Anything, &queries.QuoteRequest{DomainName: "example.com", ClID: "ClID-1", TransactionType: entities.TransactionTypeRegistration, Currency: currency, Years: 2}).Return(getTestQuote(t), nil)
+			if tt.wantCode == `<result code="1000">` {
+				svc.On("RegisterDomain", mock.Anything, mock.MatchedBy(func(cmd *commands.RegisterDomainCommand) bool {
+					return cmd.Fee == tt.wantFee
+				})).Return(getTestDomain(), nil)
+			}
+
+			w := &testWriter{}
+			ctrl.Create(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<create><domain:create xmlns:domain="urn:ietf:params:xml:ns:domain-1.0">
+				<domain:name>example.com</domain:name><domain:period unit="y">2</domain:period>
+				<domain:registrant>reg-1</domain:registrant>
+				<domain:authInfo><domain:pw>sTr0ngP@ss</domain:pw></domain:authInfo>
+			</domain:create></create>`+feeExtension(tt.ext))))
+
+			s := w.String()
+			require.Contains(t, s, tt.wantCode)
+			if tt.wantCode == `<result code="1000">` {
+				require.Contains(t, s, `<extension><fee:creData xmlns:fee="urn:ietf:params:xml:ns:epp:fee-1.0"><fee:currency>USD</fee:currency><fee:fee description="registration fee" refundable="1">20.00</fee:fee><fee:fee description="sunrise fee" refundable="0">50.00</fee:fee></fee:creData></extension>`)
+			}
+			svc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDomainController_Renew_Fee(t *testing.T) {
+	svc := new(MockDomainService)
+	ctrl := &DomainController{domainService: svc}
+	svc.On("GetDomainByName", mock.Anything, "example.com", false).Return(getTestDomain(), nil)
+	quote := entities.NewQuote("USD")
+	quote.Price = money.New(3000, "USD")
+	svc.On("GetQuote", mock.Anything, &queries.QuoteRequest{DomainName: "example.com", ClID: "ClID-1", TransactionType: entities.TransactionTypeRenewal, Currency: "USD", Years: 3}).Return(quote, nil)
+	renewed := getTestDomain()
+	renewed.ExpiryDate = renewed.ExpiryDate.AddDate(3, 0, 0)
+	svc.On("RenewDomain", mock.Anything, &commands.RenewDomainCommand{Name: "example.com", ClID: "ClID-1", Years: 3, Fee: commands.FeeExtension{Currency: "USD", Amount: 3000, Provided: true}}, false).Return(renewed, nil)
+	renew := func(fee string) string {
+		return eppCommand(`<renew><domain:renew xmlns:domain="urn:ietf:params:xml:ns:domain-1.0"><domain:name>example.com</domain:name><domain:curExpDate>2025-01-01</domain:curExpDate><domain:period unit="y">3</domain:period></domain:renew></renew>` +
+			feeExtension(`<fee:renew xmlns:fee="urn:ietf:params:xml:ns:epp:fee-1.0"><fee:currency>USD</fee:currency><fee:fee>`+fee+`</fee:fee></fee:renew>`))
+	}
+
+	w := &testWriter{}
+	ctrl.Renew(newTestContext("ClID-1"), w, newTestDoc(t, renew("30.00")))
+	require.Contains(t, w.String(), `<result code="1000">`)
+	require.Contains(t, w.String(), `<fee:renData xmlns:fee="urn:ietf:params:xml:ns:epp:fee-1.0"><fee:currency>USD</fee:currency><fee:fee refundable="0">30.00</fee:fee></fee:renData>`)
+
+	w = &testWriter{}
+	ctrl.Renew(newTestContext("ClID-1"), w, newTestDoc(t, renew("25.00")))
+	require.Contains(t, w.String(), `<result code="2004">`)
+	svc.AssertNumberOfCalls(t, "RenewDomain", 1)
+}
+
+func TestDomainController_Premium_WithoutFee(t *testing.T) {
+	// The service refuses premium creates and renews without the fee extension, the client must retry with the fee it agrees to pay
+	feeRequired := errors.Join(entities.ErrFeeMismatch, entities.ErrFeeRequired, errors.New("class: premium"))
+	svc := new(MockDomainService)
+	ctrl := &DomainController{domainService: svc}
+	svc.On("RegisterDomain", mock.Anything, mock.MatchedBy(func(cmd *commands.RegisterDomainCommand) bool {
+		return cmd.Name == "premium.com" && cmd.Fee.IsZero()
+	})).Return(nil, feeRequired)
+	svc.On("GetDomainByName", mock.Anything, "premium.com", false).Return(getTestDomain(), nil)
+	svc.On("RenewDomain", mock.Anything, &commands.RenewDomainCommand{Name: "premium.com", ClID: "ClID-1", Years: 1}, false).Return(nil, errors.Join(entities.ErrInvalidRenewal, feeRequired))
+
+	w := &testWriter{}
+	ctrl.Create(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<create><domain:create xmlns:domain="urn:ietf:params:xml:ns:domain-1.0">
+		<domain:name>premium.com</domain:name>
+		<domain:registrant>reg-1</domain:registrant>
+		<domain:authInfo><domain:pw>sTr0ngP@ss</domain:pw></domain:authInfo>
+	</domain:create></create>`)))
+	require.Contains(t, w.String(), `<result code="2004">`)
+	require.Contains(t, w.String(), entities.ErrFeeRequired.Error())
+
+	w = &testWriter{}
+	ctrl.Renew(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<renew><domain:renew xmlns:domain="urn:ietf:params:xml:ns:domain-1.0"><domain:name>premium.com</domain:name><domain:curExpDate>2025-01-01</domain:curExpDate><domain:period unit="y">1</domain:period></domain:renew></renew>`)))
+	require.Contains(t, w.String(), `<result code="2004">`)
+	require.Contains(t, w.String(), entities.ErrFeeRequired.Error())
+	svc.AssertExpectations(t)
+}
+
+func TestDomainController_Premium_ZeroFee(t *testing.T) {
+	// A premium label released for free (e.g. with a 100% discount allocation token) can be renewed by agreeing to a fee of 0
+	svc := new(MockDomainService)
+	ctrl := &DomainController{domainService: svc}
+	svc.On("GetDomainByName", mock.Anything, "premium.com", false).Return(getTestDomain(), nil)
+	quote := entities.NewQuote("USD")
+	quote.Class = "premium"
+	quote.Price = money.New(0, "USD")
+	svc.On("GetQuote", mock.Anything, &queries.QuoteRequest{DomainName: "premium.com", ClID: "ClID-1", TransactionType: entities.TransactionTypeRenewal, Currency: "USD", Years: 1}).Return(quote, nil)
+	svc.On("RenewDomain", mock.Anything, &commands.RenewDomainCommand{Name: "premium.com", ClID: "ClID-1", Years: 1, Fee: commands.FeeExtension{Currency: "USD", Provided: true}}, false).Return(getTestDomain(), nil)
+
+	w := &testWriter{}
+	ctrl.Renew(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<renew><domain:renew xmlns:domain="urn:ietf:params:xml:ns:domain-1.0"><domain:name>premium.com</domain:name><domain:curExpDate>2025-01-01</domain:curExpDate><domain:period unit="y">1</domain:period></domain:renew></renew>`+
+		feeExtension(`<fee:renew xmlns:fee="urn:ietf:params:xml:ns:epp:fee-1.0"><fee:currency>USD</fee:currency><fee:fee>0.00</fee:fee></fee:renew>`))))
+	require.Contains(t, w.String(), `<result code="1000">`)
+	svc.AssertExpectations(t)
+}
+
+func TestDomainController_Transfer_Request_Fee(t *testing.T) {
+	svc := new(MockDomainService)
+	ctrl := &DomainController{domainService: svc}
+	svc.On("GetDomainByName", mock.Anything, "example.com", false).Return(getTestDomain(), nil)
+	quote := entities.NewQuote("USD")
+	quote.Price = money.New(800, "USD")
+	svc.On("GetQuote", mock.Anything, &queries.QuoteRequest{DomainName: "example.com", ClID: "ClID-2", TransactionType: entities.TransactionTypeTransfer, Years: 1}).Return(quote, nil)
+	svc.On("RequestDomainTransfer", mock.Anything, mock.Anything).Return(getTestTransfer(entities.TransferStatusPending), nil)
+	transfer := func(fee string) string {
+		return eppCommand(`<transfer op="request"><domain:transfer xmlns:domain="urn:ietf:params:xml:ns:domain-1.0"><domain:name>example.com</domain:name><domain:authInfo><domain:pw>sTr0ngP@ss</domain:pw></domain:authInfo></domain:transfer></transfer>` +
+			feeExtension(`<fee:transfer xmlns:fee="urn:ietf:params:xml:ns:epp:fee-1.0"><fee:fee>`+fee+`</fee:fee></fee:transfer>`))
+	}
+
+	w := &testWriter{}
+	ctrl.Transfer(newTestContext("ClID-2"), w, newTestDoc(t, transfer("8.00")))
+	require.Equal(t, epplib.StatusActionPending, decodeResultCode(t, w.Bytes()))
+	require.Contains(t, w.String(), `<fee:trnData xmlns:fee="urn:ietf:params:xml:ns:epp:fee-1.0"><fee:currency>USD</fee:currency><fee:fee refundable="0">8.00</fee:fee></fee:trnData>`)
+
+	w = &testWriter{}
+	ctrl.Transfer(newTestContext("ClID-2"), w, newTestDoc(t, transfer("5.00")))
+	require.Equal(t, epplib.StatusValueRangeError, decodeResultCode(t, w.Bytes()))
+	svc.AssertNumberOfCalls(t, "RequestDomainTransfer", 1)
+}
+
+func TestParseFeeAmount(t *testing.T) {
+	tc := []struct {
+		value    string
+		fraction int
+		want     int64
+		wantErr  error
+	}{
+		{"10.00", 2, 1000, nil},
+		{"10", 2, 1000, nil},
+		{"10.5", 2, 1050, nil},
+		{" 10.50 ", 2, 1050, nil},
+		{"10.500", 2, 1050, nil},
+		{"1000", 0, 1000, nil},
+		{"0.00", 2, 0, nil},
+		{"10.005", 2, 0, ErrInvalidFeeAmount},
+		{"-10.00", 2, 0, ErrInvalidFeeAmount},
+		{".50", 2, 0, ErrInvalidFeeAmount},
+		{"ten", 2, 0, ErrInvalidFeeAmount},
+		{"", 2, 0, ErrInvalidFeeAmount},
+	}
+
+	for _, tt := range tc {
+		got, err := parseFeeAmount(tt.value, tt.fraction)
+		require.ErrorIs(t, err, tt.wantErr, tt.value)
+		require.Equal(t, tt.want, got, tt.value)
+	}
+}
+
+func TestFeesFromQuote_FX(t *testing.T) {
+	refundable := true
+	quote := entities.NewQuote("USD")
+	quote.Years = 2
+	quote.FXRate = &entities.FX{BaseCurrency: "EUR", TargetCurrency: "USD", Rate: 1.1}
+	require.NoError(t, quote.AddFeeAndUpdatePrice(&entities.Fee{Name: "registration fee", Amount: 1000, Currency: "EUR", Refundable: &refundable}, true))
+
+	fees, err := feesFromQuote(quote)
+	require.NoError(t, err)
+	require.Equal(t, []FeeFee{{Description: "registration fee", Refundable: 1, Value: "22.00"}}, fees)
+	require.Equal(t, "22.00", formatFeeAmount(quote.Price))
+	require.Equal(t, "1000", formatFeeAmount(money.New(1000, "JPY")))
+}
@@ -0,0 +1,191 @@
+package epp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	epplib "github.com/dotse/epp-lib"
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+	"github.com/onasunnymorning/domain-os/internal/application/services"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// The methods in this file handle the RFC 8334 launch phase extension of the domain commands.
+// The launch phases of a TLD are identified by their name, registrations in a phase are handled by the domain service like any other registration.
+// Phases that require validation accept applications instead, which are managed through the launch extension of the info, update and delete commands.
+// Ref: https://datatracker.ietf.org/doc/html/rfc8334
+
+// launchExtensionRequested returns an error if the client did not request the launch extension at login
+func launchExtensionRequested(ctx context.Context) error {
+	if !hasExtensionFromContext(ctx, LAUNCH_NAMESPACE) {
+		return errors.Join(ErrExtensionNotRequested, fmt.Errorf("extURI: %s", LAUNCH_NAMESPACE))
+	}
+	return nil
+}
+
+// claimsCheck handles the claims check form of the domain <check> command. It reports for each domain if it matches a trademark,
+// along with the key to retrieve the claims notice from the TMCH. The response does not include availability.
+func (ctrl *DomainController) claimsCheck(ctx context.Context, rw epplib.Writer, cmd *DomainCheckCommand) {
+	phaseName := cmd.Extension.Launch.Phase.PhaseName()
+	if cmd.Extension.Launch.Phase.Value == "" {
+		phaseName = entities.ClaimsPhaseName
+	}
+	chkData := NewLaunchChkData(phaseName)
+	for _, name := range cmd.Names {
+		claimKey, err := ctrl.domainService.GetClaimKey(ctx, name)
+		if err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+		chkData.Add(name, claimKey)
+	}
+	writeResponse(ctx, rw, NewResponse(epplib.StatusSuccess, cmd.ClTRID).WithExtension(chkData))
+}
+
+// applyLaunchCreate sets the phase, mark and claims notice of the launch extension on the registration
+func applyLaunchCreate(regCmd *commands.RegisterDomainCommand, ext *LaunchCreate) error {
+	mark, err := ext.Mark()
+	if err != nil {
+		return err
+	}
+	notice, err := ext.ClaimsNotice()
+	if err != nil {
+		return err
+	}
+	regCmd.PhaseName = ext.Phase.PhaseName()
+	regCmd.Mark = mark
+	regCmd.ClaimsNotice = notice
+	return nil
+}
+
+// createApplication handles the application form of the domain <create> command.
+// The application is pending validation, hence we respond with 1001 (action pending) and the applicationID in the launch extension.
+func (ctrl *DomainController) createApplication(ctx context.Context, rw epplib.Writer, cmd *DomainCreateCommand, regCmd *commands.RegisterDomainCommand) {
+	// Applications are charged when the domain is allocated and DNSSEC can be added once the domain exists
+	if cmd.Extension.SecDNS != nil || cmd.Extension.Fee != nil {
+		writeResponse(ctx, rw, NewErrorResponse(ErrApplicationExtensionNotSupported, cmd.ClTRID))
+		return
+	}
+	if err := applyLaunchCreate(regCmd, cmd.Extension.Launch); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	// The open phase is the current GA phase, which never accepts applications
+	if regCmd.PhaseName == "" {
+		writeResponse(ctx, rw, NewErrorResponse(errors.Join(services.ErrPhaseNotApplicationBased, errors.New("phase: open")), cmd.ClTRID))
+		return
+	}
+
+	app, err := ctrl.domainService.CreateDomainApplication(ctx, &commands.CreateDomainApplicationCommand{
+		Name:         regCmd.Name,
+		ClID:         regCmd.ClID,
+		PhaseName:    regCmd.PhaseName,
+		AuthInfo:     regCmd.AuthInfo,
+		RegistrantID: regCmd.RegistrantID,
+		AdminID:      regCmd.AdminID,
+		TechID:       regCmd.TechID,
+		BillingID:    regCmd.BillingID,
+		Years:        regCmd.Years,
+		HostNames:    regCmd.HostNames,
+		Mark:         regCmd.Mark,
+		ClaimsNotice: regCmd.ClaimsNotice,
+	})
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+
+	creData := &DomainCreData{
+		XMLNSDomain: DOMAIN_NAMESPACE,
+		Name:        app.DomainName.String(),
+		CrDate:      formatEPPDate(app.CreatedAt),
+	}
+	writeResponse(ctx, rw, NewResponse(epplib.StatusActionPending, cmd.ClTRID).WithResData(creData).WithExtension(NewLaunchCreData(app.PhaseName.String(), app.ID.String())))
+}
+
+// getApplication returns the application identified by the launch extension of an info, update or delete command.
+// The application must be for the domain and in the phase of the command, and only the applicant can access it.
+func (ctrl *DomainController) getApplication(ctx context.Context, name string, ext *LaunchApplication, clID string) (*entities.DomainApplication, error) {
+	if err := launchExtensionRequested(ctx); err != nil {
+		return nil, err
+	}
+	if ext.ApplicationID == "" {
+		return nil, ErrMissingApplicationID
+	}
+	app, err := ctrl.domainService.GetDomainApplication(ctx, ext.ApplicationID)
+	if err != nil {
+		return nil, err
+	}
+	if app.DomainName.String() != strings.ToLower(name) || app.PhaseName.String() != ext.Phase.PhaseName() {
+		return nil, errors.Join(entities.ErrDomainApplicationNotFound, fmt.Errorf("no application %s for %s in phase %s", ext.ApplicationID, name, ext.Phase.PhaseName()))
+	}
+	if app.ClID.String() != clID {
+		return nil, entities.ErrInvalidRegistrar
+	}
+	return app, nil
+}
+
+// applicationInfo handles the launch extension of the domain <info> command, it returns the application instead of the domain
+func (ctrl *DomainController) applicationInfo(ctx context.Context, rw epplib.Writer, cmd *DomainInfoCommand, clID string) {
+	app, err := ctrl.getApplication(ctx, cmd.Name.Value, cmd.Extension.Launch, clID)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	writeResponse(ctx, rw, NewResponse(epplib.StatusSuccess, cmd.ClTRID).WithResData(NewDomainInfDataFromApplication(app, true)).WithExtension(NewLaunchInfData(app)))
+}
+
+// updateApplication handles the launch extension of the domain <update> command.
+// Only the contacts, nameservers and authInfo of an application can be changed, the registry manages its status.
+func (ctrl *DomainController) updateApplication(ctx context.Context, rw epplib.Writer, cmd *DomainUpdateCommand, clID string) {
+	if len(cmd.Add.Statuses) > 0 || len(cmd.Rem.Statuses) > 0 || cmd.Extension.SecDNS != nil {
+		writeResponse(ctx, rw, NewErrorResponse(ErrApplicationUpdateNotSupported, cmd.ClTRID))
+		return
+	}
+	app, err := ctrl.getApplication(ctx, cmd.Name, cmd.Extension.Launch, clID)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+
+	if err := applyDomainContactChanges(contactsOfApplication(app), cmd.Add.Contacts, cmd.Rem.Contacts, cmd.Chg); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	for _, h := range cmd.Rem.HostObjs {
+		if err := app.RemoveHostName(h); err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+	}
+	for _, h := range cmd.Add.HostObjs {
+		if slices.Contains(app.HostNames, strings.ToLower(h)) {
+			writeResponse(ctx, rw, NewErrorResponse(errors.Join(entities.ErrDuplicateHost, fmt.Errorf("%s is already a nameserver of the application", h)), cmd.ClTRID))
+			return
+		}
+		app.AddHostName(h)
+	}
+
+	if _, err := ctrl.domainService.UpdateDomainApplication(ctx, app); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	writeResponse(ctx, rw, NewResponse(epplib.StatusSuccess, cmd.ClTRID))
+}
+
+// deleteApplication handles the launch extension of the domain <delete> command, it withdraws the application
+func (ctrl *DomainController) deleteApplication(ctx context.Context, rw epplib.Writer, cmd *DomainDeleteCommand, clID string) {
+	app, err := ctrl.getApplication(ctx, cmd.Name, cmd.Extension.Launch, clID)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	if err := ctrl.domainService.WithdrawDomainApplication(ctx, app.ID.String(), clID); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	writeResponse(ctx, rw, NewResponse(epplib.StatusSuccess, cmd.ClTRID))
+}
@@ -0,0 +1,342 @@
+package epp
+
+import (
+	"testing"
+	"time"
+
+	epplib "github.com/dotse/epp-lib"
+	"github.com/google/uuid"
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/application/services"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// launchExtension wraps a launch extension element in the <extension> element
+func launchExtension(body string) string {
+	return `<extension>` + body + `</extension>`
+}
+
+// getTestApplication returns an application for example.com in the landrush phase by ClID-1
+func getTestApplication() *entities.DomainApplication {
+	return &entities.DomainApplication{
+		ID:           uuid.MustParse("6a5b4c3d-2e1f-4a0b-9c8d-7e6f5a4b3c2d"),
+		DomainName:   "example.com",
+		PhaseName:    "landrush",
+		ClID:         "ClID-1",
+		Status:       entities.ApplicationStatusPendingValidation,
+		AuthInfo:     "sTr0ngP@ss",
+		RegistrantID: "reg-1",
+		AdminID:      "adm-1",
+		HostNames:    []string{"ns1.example.net"},
+		Years:        1,
+		CreatedAt:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestLaunchPhase(t *testing.T) {
+	tc := []struct {
+		phase     LaunchPhase
+		wantName  string
+		wantPhase LaunchPhaseData
+	}{
+		{LaunchPhase{Value: "sunrise"}, "sunrise", LaunchPhaseData{Value: "sunrise"}},
+		{LaunchPhase{Value: "open"}, "", LaunchPhaseData{Value: "open"}},
+		{LaunchPhase{Value: "custom", Name: "early-access"}, "early-access", LaunchPhaseData{Value: "custom", Name: "early-access"}},
+		{LaunchPhase{Value: "claims", Name: "claims"}, "claims", LaunchPhaseData{Value: "claims"}},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.phase.Value+tt.phase.Name, func(t *testing.T) {
+			require.Equal(t, tt.wantName, tt.phase.PhaseName())
+			require.Equal(t, tt.wantPhase, NewLaunchPhaseData(tt.phase.PhaseName()))
+		})
+	}
+}
+
+func TestDomainController_Check_Claims(t *testing.T) {
+	svc := new(MockDomainService)
+	ctrl := &DomainController{domainService: svc}
+	svc.On("GetClaimKey", mock.Anything, "example.com").Return("2013041500/2/6/9/rJ1NrDO92vDsAzf7EQzgjX4R0000000001", nil)
+	svc.On("GetClaimKey", mock.Anything, "example.net").Return("", nil)
+
+	w := &testWriter{}
+	ctrl.Check(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<check><domain:check xmlns:domain="urn:ietf:params:xml:ns:domain-1.0">
+		<domain:name>example.com</domain:name><domain:name>example.net</domain:name>
+	</domain:check></check>`+launchExtension(`<launch:check xmlns:launch="urn:ietf:params:xml:ns:launch-1.0" type="claims"><launch:phase>claims</launch:phase></launch:check>`))))
+
+	s := w.String()
+	require.Contains(t, s, `<result code="1000">`)
+	require.Contains(t, s, `<launch:chkData xmlns:launch="urn:ietf:params:xml:ns:launch-1.0"><launch:phase>claims</launch:phase>`)
+	require.Contains(t, s, `<launch:cd><launch:name exists="1">example.com</launch:name><launch:claimKey validatorID="tmch">2013041500/2/6/9/rJ1NrDO92vDsAzf7EQzgjX4R0000000001</launch:claimKey></launch:cd>`)
+	require.Contains(t, s, `<launch:cd><launch:name exists="0">example.net</launch:name></launch:cd>`)
+	require.NotContains(t, s, `<domain:chkData`)
+	svc.AssertExpectations(t)
+}
+
+func TestDomainController_Check_LaunchAvail(t *testing.T) {
+	svc := new(MockDomainService)
+	ctrl := &DomainController{domainService: svc}
+	svc.On("CheckDomainAvailability", mock.Anything, "example.com", "landrush", mock.Anything, "").Return(&queries.DomainCheckResult{Available: true}, nil)
+
+	w := &testWriter{}
+	ctrl.Check(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<check><domain:check xmlns:domain="urn:ietf:params:xml:ns:domain-1.0">
+		<domain:name>example.com</domain:name>
+	</domain:check></check>`+launchExtension(`<launch:check xmlns:launch="urn:ietf:params:xml:ns:launch-1.0" type="avail"><launch:phase>landrush</launch:phase></launch:check>`))))
+
+	s := w.String()
+	require.Contains(t, s, `<result code="1000">`)
+	require.Contains(t, s, `<domain:name avail="1">example.com</domain:name>`)
+	svc.AssertExpectations(t)
+}
+
+func TestDomainController_Create_LaunchRegistration(t *testing.T) {
+	acceptedDate := time.Now().UTC().Add(-time.Hour).Format(time.RFC3339)
+	notAfter := time.Now().UTC().Add(time.Hour).Format(time.RFC3339)
+	tc := []struct {
+		name      string
+		ext       string
+		wantPhase string
+		wantMark  string
+		wantCode  int
+	}{
+		{
+			name:      "sunrise with encoded signed mark",
+			ext:       `<launch:create xmlns:launch="urn:ietf:params:xml:ns:launch-1.0"><launch:phase>sunrise</launch:phase><smd:encodedSignedMark xmlns:smd="urn:ietf:params:xml:ns:signedMark-1.0">PD94bWwg dmVyc2lvbj0iMS4wIj8+</smd:encodedSignedMark></launch:create>`,
+			wantPhase: "sunrise",
+			wantMark:  "PD94bWwgdmVyc2lvbj0iMS4wIj8+",
+			wantCode:  epplib.StatusSuccess,
+		},
+		{
+			name:      "claims notice in the open phase",
+			ext:       `<launch:create xmlns:launch="urn:ietf:params:xml:ns:launch-1.0"><launch:phase>open</launch:phase><launch:notice><launch:noticeID validatorID="tmch">370d0b7c9223372036854775807</launch:noticeID><launch:notAfter>` + notAfter + `</launch:notAfter><launch:acceptedDate>` + acceptedDate + `</launch:acceptedDate></launch:notice></launch:create>`,
+			wantPhase: "",
+			wantCode:  epplib.StatusSuccess,
+		},
+		{
+			name:      "custom phase with code",
+			ext:       `<launch:create xmlns:launch="urn:ietf:params:xml:ns:launch-1.0"><launch:phase name="early-access">custom</launch:phase><launch:codeMark><launch:code>49FD46E6C4B45C55D4AC</launch:code></launch:codeMark></launch:create>`,
+			wantPhase: "early-access",
+			wantMark:  "49FD46E6C4B45C55D4AC",
+			wantCode:  epplib.StatusSuccess,
+		},
+		{
+			name:     "invalid encoded signed mark",
+			ext:      `<launch:create xmlns:launch="urn:ietf:params:xml:ns:launch-1.0"><launch:phase>sunrise</launch:phase><smd:encodedSignedMark xmlns:smd="urn:ietf:params:xml:ns:signedMark-1.0">not base64!</smd:encodedSignedMark></launch:create>`,
+			wantCode: epplib.StatusValueSyntaxError,
+		},
+		{
+			name:     "multiple marks",
+			ext:      `<launch:create xmlns:launch="urn:ietf:params:xml:ns:launch-1.0"><launch:phase>sunrise</launch:phase><launch:codeMark><launch:code>abc</launch:code></launch:codeMark><launch:codeMark><launch:code>def</launch:code></launch:codeMark></launch:create>`,
+			wantCode: epplib.StatusParameterPolicyError,
+		},
+		{
+			name:     "expired claims notice",
+			ext:      `<launch:create xmlns:launch="urn:ietf:params:xml:ns:launch-1.0"><launch:phase>claims</launch:phase><launch:notice><launch:noticeID>abc</launch:noticeID><launch:notAfter>2020-01-01T00:00:00Z</launch:notAfter><launch:acceptedDate>2019-12-31T00:00:00Z</launch:acceptedDate></launch:notice></launch:create>`,
+			wantCode: epplib.StatusParameterPolicyError,
+		},
+		{
+			name:     "unknown type",
+			ext:      `<launch:create xmlns:launch="urn:ietf:params:xml:ns:launch-1.0" type="auction"><launch:phase>sunrise</launch:phase></launch:create>`,
+			wantCode: epplib.StatusCommandSyntaxError,
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockDomainService)
+			ctrl := &DomainController{domainService: svc}
+			if tt.wantCode == epplib.StatusSuccess {
+				svc.On("RegisterDomain", mock.Anything, mock.MatchedBy(func(cmd *commands.RegisterDomainCommand) bool {
+					return cmd.PhaseName == tt.wantPhase && cmd.Mark == tt.wantMark
+				})).Return(getTestDomain(), nil)
+			}
+
+			w := &testWriter{}
+			ctrl.Create(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<create><domain:create xmlns:domain="urn:ietf:params:xml:ns:domain-1.0">
+				<domain:name>example.com</domain:name>
+				<domain:registrant>reg-1</domain:registrant>
+				<domain:authInfo><domain:pw>sTr0ngP@ss</domain:pw></domain:authInfo>
+			</domain:create></create>`+launchExtension(tt.ext))))
+
+			require.Equal(t, tt.wantCode, decodeResultCode(t, w.Bytes()))
+			if tt.wantCode == epplib.StatusSuccess {
+				require.Contains(t, w.String(), `<launch:creData xmlns:launch="urn:ietf:params:xml:ns:launch-1.0"><launch:phase`)
+			}
+			svc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDomainController_Create_LaunchRegistration_PendingValidation(t *testing.T) {
+	svc := new(MockDomainService)
+	ctrl := &DomainController{domainService: svc}
+	dom := getTestDomain()
+	dom.Status = entities.DomainStatus{PendingCreate: true}
+	svc.On("RegisterDomain", mock.Anything, mock.Anything).Return(dom, nil)
+
+	w := &testWriter{}
+	ctrl.Create(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<create><domain:create xmlns:domain="urn:ietf:params:xml:ns:domain-1.0">
+		<domain:name>example.com</domain:name>
+	</domain:create></create>`+launchExtension(`<launch:create xmlns:launch="urn:ietf:params:xml:ns:launch-1.0" type="registration"><launch:phase>sunrise</launch:phase><launch:codeMark><launch:code>abc</launch:code></launch:codeMark></launch:create>`))))
+
+	require.Equal(t, epplib.StatusActionPending, decodeResultCode(t, w.Bytes()))
+}
+
+func TestDomainController_Create_LaunchApplication(t *testing.T) {
+	tc := []struct {
+		name     string
+		phase    string
+		ext      string
+		svcErr   error
+		wantCode int
+	}{
+		{name: "application", phase: "landrush", wantCode: epplib.StatusActionPending},
+		{name: "open phase", phase: "open", wantCode: epplib.StatusParameterPolicyError},
+		{name: "phase does not take applications", phase: "sunrise", svcErr: services.ErrPhaseNotApplicationBased, wantCode: epplib.StatusParameterPolicyError},
+		{name: "claims notice required", phase: "landrush", svcErr: entities.ErrClaimsNoticeRequired, wantCode: epplib.StatusMissingParameter},
+		{name: "with secDNS", phase: "landrush", ext: secDNSExtensionElement, wantCode: epplib.StatusUnimplementedExtension},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockDomainService)
+			ctrl := &DomainController{domainService: svc}
+			if tt.wantCode == epplib.StatusActionPending || tt.svcErr != nil {
+				call := svc.On("CreateDomainApplication", mock.Anything, mock.MatchedBy(func(cmd *commands.CreateDomainApplicationCommand) bool {
+					return cmd.Name == "example.com" && cmd.PhaseName == tt.phase && cmd.ClID == "ClID-1" && cmd.AdminID == "adm-1" && len(cmd.HostNames) == 1
+				}))
+				if tt.svcErr != nil {
+					call.Return(nil, tt.svcErr)
+				} else {
+					call.Return(getTestApplication(), nil)
+				}
+			}
+
+			w := &testWriter{}
+			ctrl.Create(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<create><domain:create xmlns:domain="urn:ietf:params:xml:ns:domain-1.0">
+				<domain:name>example.com</domain:name>
+				<domain:ns><domain:hostObj>ns1.example.net</domain:hostObj></domain:ns>
+				<domain:registrant>reg-1</domain:registrant>
+				<domain:contact type="admin">adm-1</domain:contact>
+				<domain:authInfo><domain:pw>sTr0ngP@ss</domain:pw></domain:authInfo>
+			</domain:create></create>`+launchExtension(`<launch:create xmlns:launch="urn:ietf:params:xml:ns:launch-1.0" type="application"><launch:phase>`+tt.phase+`</launch:phase></launch:create>`+tt.ext))))
+
+			require.Equal(t, tt.wantCode, decodeResultCode(t, w.Bytes()))
+			if tt.wantCode == epplib.StatusActionPending {
+				s := w.String()
+				require.Contains(t, s, `<domain:creData xmlns:domain="urn:ietf:params:xml:ns:domain-1.0"><domain:name>example.com</domain:name><domain:crDate>2024-01-01T00:00:00.0Z</domain:crDate></domain:creData>`)
+				require.Contains(t, s, `<launch:creData xmlns:launch="urn:ietf:params:xml:ns:launch-1.0"><launch:phase>landrush</launch:phase><launch:applicationID>6a5b4c3d-2e1f-4a0b-9c8d-7e6f5a4b3c2d</launch:applicationID></launch:creData>`)
+			}
+			svc.AssertExpectations(t)
+		})
+	}
+}
+
+// secDNSExtensionElement is a secDNS create element that is not allowed on applications
+const secDNSExtensionElement = `<secDNS:create xmlns:secDNS="urn:ietf:params:xml:ns:secDNS-1.1"><secDNS:dsData><secDNS:keyTag>12345</secDNS:keyTag><secDNS:alg>13</secDNS:alg><secDNS:digestType>2</secDNS:digestType><secDNS:digest>49FD46E6C4B45C55D4AC49FD46E6C4B45C55D4AC49FD46E6C4B45C55D4AC1234</secDNS:digest></secDNS:dsData></secDNS:create>`
+
+// launchApplicationElement returns a launch info, update or delete element for the test application
+func launchApplicationElement(command, phase, applicationID string) string {
+	return `<launch:` + command + ` xmlns:launch="urn:ietf:params:xml:ns:launch-1.0"><launch:phase>` + phase + `</launch:phase><launch:applicationID>` + applicationID + `</launch:applicationID></launch:` + command + `>`
+}
+
+func TestDomainController_Info_Application(t *testing.T) {
+	tc := []struct {
+		name          string
+		clID          string
+		phase         string
+		applicationID string
+		wantCode      int
+	}{
+		{"applicant", "ClID-1", "landrush", "6a5b4c3d-2e1f-4a0b-9c8d-7e6f5a4b3c2d", epplib.StatusSuccess},
+		{"other registrar", "ClID-2", "landrush", "6a5b4c3d-2e1f-4a0b-9c8d-7e6f5a4b3c2d", epplib.StatusAuthorizationError},
+		{"other phase", "ClID-1", "sunrise", "6a5b4c3d-2e1f-4a0b-9c8d-7e6f5a4b3c2d", epplib.StatusObjectDoesNotExist},
+		{"missing applicationID", "ClID-1", "landrush", "", epplib.StatusMissingParameter},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockDomainService)
+			ctrl := &DomainController{domainService: svc}
+			svc.On("GetDomainApplication", mock.Anything, tt.applicationID).Return(getTestApplication(), nil)
+
+			w := &testWriter{}
+			ctrl.Info(newTestContext(tt.clID), w, newTestDoc(t, eppCommand(`<info><domain:info xmlns:domain="urn:ietf:params:xml:ns:domain-1.0">
+				<domain:name>example.com</domain:name>
+			</domain:info></info>`+launchExtension(launchApplicationElement("info", tt.phase, tt.applicationID)))))
+
+			require.Equal(t, tt.wantCode, decodeResultCode(t, w.Bytes()))
+			if tt.wantCode == epplib.StatusSuccess {
+				s := w.String()
+				require.Contains(t, s, `<domain:roid>6a5b4c3d-2e1f-4a0b-9c8d-7e6f5a4b3c2d</domain:roid><domain:status s="pendingCreate"></domain:status>`)
+				require.Contains(t, s, `<domain:ns><domain:hostObj>ns1.example.net</domain:hostObj></domain:ns>`)
+				require.Contains(t, s, `<launch:infData xmlns:launch="urn:ietf:params:xml:ns:launch-1.0"><launch:phase>landrush</launch:phase><launch:applicationID>6a5b4c3d-2e1f-4a0b-9c8d-7e6f5a4b3c2d</launch:applicationID><launch:status s="pendingValidation"></launch:status></launch:infData>`)
+			}
+		})
+	}
+}
+
+func TestDomainController_Update_Application(t *testing.T) {
+	svc := new(MockDomainService)
+	ctrl := &DomainController{domainService: svc}
+	svc.On("GetDomainApplication", mock.Anything, "6a5b4c3d-2e1f-4a0b-9c8d-7e6f5a4b3c2d").Return(getTestApplication(), nil)
+	svc.On("UpdateDomainApplication", mock.Anything, mock.MatchedBy(func(app *entities.DomainApplication) bool {
+		return app.RegistrantID == "reg-2" && app.TechID == "tech-1" && len(app.HostNames) == 1 && app.HostNames[0] == "ns2.example.net"
+	})).Return(getTestApplication(), nil)
+
+	w := &testWriter{}
+	ctrl.Update(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<update><domain:update xmlns:domain="urn:ietf:params:xml:ns:domain-1.0">
+		<domain:name>example.com</domain:name>
+		<domain:add><domain:ns><domain:hostObj>ns2.example.net</domain:hostObj></domain:ns><domain:contact type="tech">tech-1</domain:contact></domain:add>
+		<domain:rem><domain:ns><domain:hostObj>ns1.example.net</domain:hostObj></domain:ns></domain:rem>
+		<domain:chg><domain:registrant>reg-2</domain:registrant></domain:chg>
+	</domain:update></update>`+launchExtension(launchApplicationElement("update", "landrush", "6a5b4c3d-2e1f-4a0b-9c8d-7e6f5a4b3c2d")))))
+
+	require.Equal(t, epplib.StatusSuccess, decodeResultCode(t, w.Bytes()))
+	svc.AssertExpectations(t)
+}
+
+func TestDomainController_Update_Application_Status(t *testing.T) {
+	svc := new(MockDomainService)
+	ctrl := &DomainController{domainService: svc}
+
+	w := &testWriter{}
+	ctrl.Update(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<update><domain:update xmlns:domain="urn:ietf:params:xml:ns:domain-1.0">
+		<domain:name>example.com</domain:name>
+		<domain:add><domain:status s="clientHold"/></domain:add>
+	</domain:update></update>`+launchExtension(launchApplicationElement("update", "landrush", "6a5b4c3d-2e1f-4a0b-9c8d-7e6f5a4b3c2d")))))
+
+	require.Equal(t, epplib.StatusParameterPolicyError, decodeResultCode(t, w.Bytes()))
+	svc.AssertNotCalled(t, "UpdateDomainApplication", mock.Anything, mock.Anything)
+}
+
+func TestDomainController_Delete_Application(t *testing.T) {
+	tc := []struct {
+		name     string
+		svcErr   error
+		wantCode int
+	}{
+		{"withdraw", nil, epplib.StatusSuccess},
+		{"allocated", entities.ErrDomainApplicationFinal, epplib.StatusObjectStatusProhibitsOperation},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockDomainService)
+			ctrl := &DomainController{domainService: svc}
+			svc.On("GetDomainApplication", mock.Anything, "6a5b4c3d-2e1f-4a0b-9c8d-7e6f5a4b3c2d").Return(getTestApplication(), nil)
+			svc.On("WithdrawDomainApplication", mock.Anything, "6a5b4c3d-2e1f-4a0b-9c8d-7e6f5a4b3c2d", "ClID-1").Return(tt.svcErr)
+
+			w := &testWriter{}
+			ctrl.Delete(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<delete><domain:delete xmlns:domain="urn:ietf:params:xml:ns:domain-1.0">
+				<domain:name>example.com</domain:name>
+			</domain:delete></delete>`+launchExtension(launchApplicationElement("delete", "landrush", "6a5b4c3d-2e1f-4a0b-9c8d-7e6f5a4b3c2d")))))
+
+			require.Equal(t, tt.wantCode, decodeResultCode(t, w.Bytes()))
+			svc.AssertNotCalled(t, "MarkDomainForDeletion", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,519 @@
+package epp
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// The structs in this file are used to unmarshal the RFC 5731 domain commands.
+// Ref: https://datatracker.ietf.org/doc/html/rfc5731#section-3
+
+// Period is the <domain:period> element used in create, renew and transfer commands
+type Period struct {
+	Unit  string `xml:"unit,attr"`
+	Value int    `xml:",chardata"`
+}
+
+// Years returns the period expressed in years. Periods expressed in months must be a multiple of 12 as we only support yearly registrations.
+// A nil period returns 0 which lets the service apply its default.
+func (p *Period) Years() (int, error) {
+	if p == nil {
+		return 0, nil
+	}
+	if p.Value < 1 || p.Value > 99 {
+		return 0, ErrInvalidPeriod
+	}
+	switch p.Unit {
+	case "y", "":
+		return p.Value, nil
+	case "m":
+		if p.Value%12 != 0 {
+			return 0, ErrInvalidPeriod
+		}
+		return p.Value / 12, nil
+	default:
+		return 0, ErrInvalidPeriod
+	}
+}
+
+// DomainContact is the <domain:contact> element
+type DomainContact struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// DomainStatusValue is the <domain:status> element
+type DomainStatusValue struct {
+	S     string `xml:"s,attr"`
+	Lang  string `xml:"lang,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+// IsClientStatus returns true if the status can be set by the client (client* statuses)
+func (s DomainStatusValue) IsClientStatus() bool {
+	return strings.HasPrefix(s.S, "client")
+}
+
+// DomainCheckCommand is the <check> command for domains
+type DomainCheckCommand struct {
+	Names     []string       `xml:"command>check>check>name"`
+	Extension DomainCheckExt `xml:"command>extension"`
+	ClTRID    string         `xml:"command>clTRID"`
+}
+
+// DomainCheckExt holds the extensions of the domain check command we support
+type DomainCheckExt struct {
+	Fee             *FeeCheck    `xml:"urn:ietf:params:xml:ns:epp:fee-1.0 check"`
+	Launch          *LaunchCheck `xml:"urn:ietf:params:xml:ns:launch-1.0 check"`
+	AllocationToken string       `xml:"urn:ietf:params:xml:ns:allocationToken-1.0 allocationToken"`
+}
+
+// DomainInfoName is the <domain:name> element of the info command including the hosts attribute
+type DomainInfoName struct {
+	Hosts string `xml:"hosts,attr"`
+	Value string `xml:",chardata"`
+}
+
+// DomainInfoCommand is the <info> command for domains
+type DomainInfoCommand struct {
+	Name      DomainInfoName `xml:"command>info>info>name"`
+	AuthInfo  string         `xml:"command>info>info>authInfo>pw"`
+	Extension DomainInfoExt  `xml:"command>extension"`
+	ClTRID    string         `xml:"command>clTRID"`
+}
+
+// DomainInfoExt holds the extensions of the domain info command we support
+type DomainInfoExt struct {
+	Launch *LaunchApplication `xml:"urn:ietf:params:xml:ns:launch-1.0 info"`
+}
+
+// DomainCreateCommand is the <create> command for domains
+type DomainCreateCommand struct {
+	Name       string          `xml:"command>create>create>name"`
+	Period     *Period         `xml:"command>create>create>period"`
+	HostObjs   []string        `xml:"command>create>create>ns>hostObj"`
+	Registrant string          `xml:"command>create>create>registrant"`
+	Contacts   []DomainContact `xml:"command>create>create>contact"`
+	AuthInfo   string          `xml:"command>create>create>authInfo>pw"`
+	Extension  DomainCreateExt `xml:"command>extension"`
+	ClTRID     string          `xml:"command>clTRID"`
+}
+
+// DomainCreateExt holds the extensions of the domain create command we support.
+// The extension elements are matched on their namespace as different extensions use the same element names.
+type DomainCreateExt struct {
+	SecDNS          *SecDNSCreate `xml:"urn:ietf:params:xml:ns:secDNS-1.1 create"`
+	Fee             *FeeTransform `xml:"urn:ietf:params:xml:ns:epp:fee-1.0 create"`
+	Launch          *LaunchCreate `xml:"urn:ietf:params:xml:ns:launch-1.0 create"`
+	AllocationToken string        `xml:"urn:ietf:params:xml:ns:allocationToken-1.0 allocationToken"`
+}
+
+// DomainAddRem is the <domain:add> or <domain:rem> element of the update command
+type DomainAddRem struct {
+	HostObjs []string            `xml:"ns>hostObj"`
+	Contacts []DomainContact     `xml:"contact"`
+	Statuses []DomainStatusValue `xml:"status"`
+}
+
+// IsEmpty returns true if nothing is added or removed
+func (a *DomainAddRem) IsEmpty() bool {
+	return a == nil || (len(a.HostObjs) == 0 && len(a.Contacts) == 0 && len(a.Statuses) == 0)
+}
+
+// DomainChg is the <domain:chg> element of the update command.
+// Pointers are used so we can tell the difference between an omitted element and an empty one (e.g. removing the registrant)
+type DomainChg struct {
+	Registrant *string `xml:"registrant"`
+	AuthInfo   *string `xml:"authInfo>pw"`
+}
+
+// IsEmpty returns true if nothing is changed
+func (c *DomainChg) IsEmpty() bool {
+	return c == nil || (c.Registrant == nil && c.AuthInfo == nil)
+}
+
+// DomainUpdateCommand is the <update> command for domains
+type DomainUpdateCommand struct {
+	Name      string          `xml:"command>update>update>name"`
+	Add       *DomainAddRem   `xml:"command>update>update>add"`
+	Rem       *DomainAddRem   `xml:"command>update>update>rem"`
+	Chg       *DomainChg      `xml:"command>update>update>chg"`
+	Extension DomainUpdateExt `xml:"command>extension"`
+	ClTRID    string          `xml:"command>clTRID"`
+}
+
+// DomainUpdateExt holds the extensions of the domain update command we support
+type DomainUpdateExt struct {
+	SecDNS *SecDNSUpdate      `xml:"urn:ietf:params:xml:ns:secDNS-1.1 update"`
+	Launch *LaunchApplication `xml:"urn:ietf:params:xml:ns:launch-1.0 update"`
+	RGP    *RGPUpdate         `xml:"urn:ietf:params:xml:ns:rgp-1.0 update"`
+	Fee    *FeeTransform      `xml:"urn:ietf:params:xml:ns:epp:fee-1.0 update"`
+}
+
+// DomainDeleteCommand is the <delete> command for domains
+type DomainDeleteCommand struct {
+	Name      string          `xml:"command>delete>delete>name"`
+	Extension DomainDeleteExt `xml:"command>extension"`
+	ClTRID    string          `xml:"command>clTRID"`
+}
+
+// DomainDeleteExt holds the extensions of the domain delete command we support
+type DomainDeleteExt struct {
+	Launch *LaunchApplication `xml:"urn:ietf:params:xml:ns:launch-1.0 delete"`
+}
+
+// DomainRenewCommand is the <renew> command for domains
+type DomainRenewCommand struct {
+	Name       string         `xml:"command>renew>renew>name"`
+	CurExpDate string         `xml:"command>renew>renew>curExpDate"`
+	Period     *Period        `xml:"command>renew>renew>period"`
+	Extension  DomainRenewExt `xml:"command>extension"`
+	ClTRID     string         `xml:"command>clTRID"`
+}
+
+// DomainRenewExt holds the extensions of the domain renew command we support
+type DomainRenewExt struct {
+	Fee *FeeTransform `xml:"urn:ietf:params:xml:ns:epp:fee-1.0 renew"`
+}
+
+// DomainTransfer is the <transfer> element including the op attribute
+type DomainTransfer struct {
+	Op       string  `xml:"op,attr"`
+	Name     string  `xml:"transfer>name"`
+	Period   *Period `xml:"transfer>period"`
+	AuthInfo string  `xml:"transfer>authInfo>pw"`
+}
+
+// DomainTransferCommand is the <transfer> command for domains
+type DomainTransferCommand struct {
+	Transfer  DomainTransfer    `xml:"command>transfer"`
+	Extension DomainTransferExt `xml:"command>extension"`
+	ClTRID    string            `xml:"command>clTRID"`
+}
+
+// DomainTransferExt holds the extensions of the domain transfer command we support
+type DomainTransferExt struct {
+	Fee *FeeTransform `xml:"urn:ietf:params:xml:ns:epp:fee-1.0 transfer"`
+}
+
+// The structs below are used to unmarshal the RFC 5910 secDNS extension of the domain create and update commands.
+// We only support the DS data interface, DS records are generated by the registrar from the child's DNSKEY.
+// Ref: https://datatracker.ietf.org/doc/html/rfc5910#section-5
+
+// SecDNSDSData is the <secDNS:dsData> element
+type SecDNSDSData struct {
+	KeyTag     int    `xml:"keyTag"`
+	Alg        int    `xml:"alg"`
+	DigestType int    `xml:"digestType"`
+	Digest     string `xml:"digest"`
+}
+
+// ToEntity converts the <secDNS:dsData> element to a validated entities.DomainDSData
+func (d SecDNSDSData) ToEntity() (*entities.DomainDSData, error) {
+	return entities.NewDomainDSData(d.KeyTag, d.Alg, d.DigestType, d.Digest)
+}
+
+// SecDNSKeyData is the <secDNS:keyData> element, we only unmarshal it to reject the key data interface
+type SecDNSKeyData struct {
+	Flags int `xml:"flags"`
+}
+
+// SecDNSCreate is the <secDNS:create> element of the domain create command
+type SecDNSCreate struct {
+	MaxSigLife int             `xml:"maxSigLife"`
+	DSData     []SecDNSDSData  `xml:"dsData"`
+	KeyData    []SecDNSKeyData `xml:"keyData"`
+}
+
+// SecDNSRem is the <secDNS:rem> element of the domain update command, it either removes all DS data or the listed DS records
+type SecDNSRem struct {
+	All     bool            `xml:"all"`
+	DSData  []SecDNSDSData  `xml:"dsData"`
+	KeyData []SecDNSKeyData `xml:"keyData"`
+}
+
+// SecDNSAdd is the <secDNS:add> element of the domain update command
+type SecDNSAdd struct {
+	DSData  []SecDNSDSData  `xml:"dsData"`
+	KeyData []SecDNSKeyData `xml:"keyData"`
+}
+
+// SecDNSChg is the <secDNS:chg> element of the domain update command.
+// A pointer is used so we can tell the difference between an omitted maxSigLife and an invalid one.
+type SecDNSChg struct {
+	MaxSigLife *int `xml:"maxSigLife"`
+}
+
+// SecDNSUpdate is the <secDNS:update> element of the domain update command
+type SecDNSUpdate struct {
+	Urgent bool       `xml:"urgent,attr"`
+	Rem    *SecDNSRem `xml:"rem"`
+	Add    *SecDNSAdd `xml:"add"`
+	Chg    *SecDNSChg `xml:"chg"`
+}
+
+// IsEmpty returns true if the update does not change the DNSSEC data
+func (u *SecDNSUpdate) IsEmpty() bool {
+	return u == nil || (u.Rem == nil && u.Add == nil && u.Chg == nil)
+}
+
+// The structs below are used to unmarshal the RFC 8748 fee extension of the domain check and transform commands.
+// Ref: https://datatracker.ietf.org/doc/html/rfc8748#section-5
+
+// FeeCheckCommand is a <fee:command> element of the check command, it names the command the client wants to know the fee for
+type FeeCheckCommand struct {
+	Name   string  `xml:"name,attr"`
+	Phase  string  `xml:"phase,attr"`
+	Period *Period `xml:"period"`
+}
+
+// FeeCheck is the <fee:check> element of the domain check command
+type FeeCheck struct {
+	Currency string            `xml:"currency"`
+	Commands []FeeCheckCommand `xml:"command"`
+}
+
+// FeeTransform is the <fee:create>, <fee:renew>, <fee:transfer> or <fee:update> element of a transform command.
+// It holds the fee the client agrees to pay, which must match the fee we charge.
+type FeeTransform struct {
+	Currency string   `xml:"currency"`
+	Fees     []string `xml:"fee"`
+}
+
+// ToFeeExtension converts the fee element to a commands.FeeExtension. The fees are summed and converted to the smallest unit of the currency.
+// If the client did not provide a currency, the amount is interpreted in the currency of the quote it will be validated against.
+func (f *FeeTransform) ToFeeExtension(quote *entities.Quote) (commands.FeeExtension, error) {
+	currency := quote.Price.Currency()
+	if f.Currency != "" {
+		if currency = money.GetCurrency(strings.ToUpper(f.Currency)); currency == nil {
+			return commands.FeeExtension{}, errors.Join(ErrInvalidFeeAmount, fmt.Errorf("unknown currency: %s", f.Currency))
+		}
+	}
+	ext := commands.FeeExtension{Currency: currency.Code, Provided: true}
+	for _, fee := range f.Fees {
+		amount, err := parseFeeAmount(fee, currency.Fraction)
+		if err != nil {
+			return commands.FeeExtension{}, err
+		}
+		ext.Amount += amount
+	}
+	return ext, nil
+}
+
+// parseFeeAmount parses a decimal fee amount (e.g. 10.00) to the smallest unit of a currency with the provided number of decimals (e.g. 1000)
+func parseFeeAmount(value string, fraction int) (int64, error) {
+	value = strings.TrimSpace(value)
+	whole, decimals, _ := strings.Cut(value, ".")
+	// Trailing zeros beyond the precision of the currency don't change the amount
+	if len(decimals) > fraction {
+		if strings.Trim(decimals[fraction:], "0") != "" {
+			return 0, errors.Join(ErrInvalidFeeAmount, fmt.Errorf("%s has more decimals than the currency", value))
+		}
+		decimals = decimals[:fraction]
+	}
+	decimals += strings.Repeat("0", fraction-len(decimals))
+	if whole == "" || strings.Trim(whole+decimals, "0123456789") != "" {
+		return 0, errors.Join(ErrInvalidFeeAmount, fmt.Errorf("%q is not a non-negative decimal", value))
+	}
+	amount, err := strconv.ParseInt(whole+decimals, 10, 64)
+	if err != nil {
+		return 0, errors.Join(ErrInvalidFeeAmount, err)
+	}
+	return amount, nil
+}
+
+// The structs below are used to unmarshal the RFC 8334 launch phase extension of the domain commands.
+// Ref: https://datatracker.ietf.org/doc/html/rfc8334#section-3
+
+// LaunchPhase is the <launch:phase> element. Phases that are not defined in RFC 8334 are passed as "custom" with the name attribute.
+type LaunchPhase struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:",chardata"`
+}
+
+// PhaseName returns the name of the TLD phase the element refers to. The "open" phase is the current GA phase, which is an empty phase name for our services.
+// Our TLD phases are identified by name only, so the name attribute takes precedence over the phase value.
+func (p LaunchPhase) PhaseName() string {
+	if p.Name != "" {
+		return p.Name
+	}
+	phase := strings.TrimSpace(p.Value)
+	if phase == "open" {
+		return ""
+	}
+	return phase
+}
+
+// LaunchCheck is the <launch:check> element of the domain check command.
+// The "claims" type (the default) checks if the domains match a trademark, the "avail" type checks availability in a launch phase.
+type LaunchCheck struct {
+	Type  string      `xml:"type,attr"`
+	Phase LaunchPhase `xml:"phase"`
+}
+
+// LaunchNoticeID is the <launch:noticeID> element
+type LaunchNoticeID struct {
+	ValidatorID string `xml:"validatorID,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// LaunchNotice is the <launch:notice> element of the create command with the claims notice the registrant accepted
+type LaunchNotice struct {
+	NoticeID     LaunchNoticeID `xml:"noticeID"`
+	NotAfter     string         `xml:"notAfter"`
+	AcceptedDate string         `xml:"acceptedDate"`
+}
+
+// ToEntity converts the <launch:notice> element to a validated entities.ClaimsNotice
+func (n LaunchNotice) ToEntity() (*entities.ClaimsNotice, error) {
+	notAfter, err := time.Parse(time.RFC3339, strings.TrimSpace(n.NotAfter))
+	if err != nil {
+		return nil, errors.Join(entities.ErrInvalidClaimsNotice, fmt.Errorf("invalid notAfter: %s", n.NotAfter))
+	}
+	acceptedDate, err := time.Parse(time.RFC3339, strings.TrimSpace(n.AcceptedDate))
+	if err != nil {
+		return nil, errors.Join(entities.ErrInvalidClaimsNotice, fmt.Errorf("invalid acceptedDate: %s", n.AcceptedDate))
+	}
+	notice := &entities.ClaimsNotice{
+		NoticeID:     strings.TrimSpace(n.NoticeID.Value),
+		ValidatorID:  n.NoticeID.ValidatorID,
+		NotAfter:     notAfter.UTC(),
+		AcceptedDate: acceptedDate.UTC(),
+	}
+	if err := notice.Validate(); err != nil {
+		return nil, err
+	}
+	return notice, nil
+}
+
+// LaunchCodeMark is the <launch:codeMark> element, a code that validates the mark with or without the mark itself
+type LaunchCodeMark struct {
+	Code string    `xml:"code"`
+	Mark *InnerXML `xml:"urn:ietf:params:xml:ns:mark-1.0 mark"`
+}
+
+// InnerXML holds the raw XML of an element we store but don't interpret (e.g. a signed mark)
+type InnerXML struct {
+	Value string `xml:",innerxml"`
+}
+
+// LaunchCreate is the <launch:create> element of the domain create command.
+// The "registration" type (the default) registers the domain in the phase, the "application" type creates an application for the domain.
+type LaunchCreate struct {
+	Type               string           `xml:"type,attr"`
+	Phase              LaunchPhase      `xml:"phase"`
+	CodeMarks          []LaunchCodeMark `xml:"codeMark"`
+	SignedMarks        []InnerXML       `xml:"urn:ietf:params:xml:ns:signedMark-1.0 signedMark"`
+	EncodedSignedMarks []string         `xml:"urn:ietf:params:xml:ns:signedMark-1.0 encodedSignedMark"`
+	Notices            []LaunchNotice   `xml:"notice"`
+}
+
+// Mark returns the mark of the create command as provided by the client. An encoded signed mark must be valid base64, the mark is not verified here:
+// sunrise registrations are pendingCreate until the registry validated the mark.
+// We store a single mark per domain, an empty string is returned if no mark was provided.
+func (l *LaunchCreate) Mark() (string, error) {
+	if len(l.CodeMarks)+len(l.SignedMarks)+len(l.EncodedSignedMarks) > 1 {
+		return "", ErrMultipleMarksNotSupported
+	}
+	switch {
+	case len(l.EncodedSignedMarks) == 1:
+		smd := strings.Join(strings.Fields(l.EncodedSignedMarks[0]), "")
+		if _, err := base64.StdEncoding.DecodeString(smd); err != nil || smd == "" {
+			return "", errors.Join(ErrInvalidMark, errors.New("encodedSignedMark is not valid base64"))
+		}
+		return smd, nil
+	case len(l.SignedMarks) == 1:
+		return strings.TrimSpace(l.SignedMarks[0].Value), nil
+	case len(l.CodeMarks) == 1:
+		cm := l.CodeMarks[0]
+		if cm.Code == "" && cm.Mark == nil {
+			return "", errors.Join(ErrInvalidMark, errors.New("codeMark requires a code or a mark"))
+		}
+		if cm.Code != "" {
+			return strings.TrimSpace(cm.Code), nil
+		}
+		return strings.TrimSpace(cm.Mark.Value), nil
+	}
+	return "", nil
+}
+
+// ClaimsNotice returns the claims notice of the create command or nil if none was provided. We store a single notice per domain.
+func (l *LaunchCreate) ClaimsNotice() (*entities.ClaimsNotice, error) {
+	switch len(l.Notices) {
+	case 0:
+		return nil, nil
+	case 1:
+		return l.Notices[0].ToEntity()
+	default:
+		return nil, errors.Join(entities.ErrInvalidClaimsNotice, errors.New("only one claims notice is supported"))
+	}
+}
+
+// LaunchApplication is the <launch:info>, <launch:update> or <launch:delete> element that identifies a domain application
+type LaunchApplication struct {
+	Phase         LaunchPhase `xml:"phase"`
+	ApplicationID string      `xml:"applicationID"`
+}
+
+// The structs below are used to unmarshal the RFC 3915 registry grace period extension of the domain update command.
+// Ref: https://datatracker.ietf.org/doc/html/rfc3915#section-4.2.5
+
+const (
+	// RGPOpRequest requests the restore of a domain in the redemption period
+	RGPOpRequest = "request"
+	// RGPOpReport submits the restore report of a domain in pendingRestore status
+	RGPOpReport = "report"
+)
+
+// RGPUpdate is the <rgp:update> element of the domain update command
+type RGPUpdate struct {
+	Restore RGPRestore `xml:"restore"`
+}
+
+// RGPRestore is the <rgp:restore> element, the op attribute is either request or report
+type RGPRestore struct {
+	Op     string     `xml:"op,attr"`
+	Report *RGPReport `xml:"report"`
+}
+
+// RGPReport is the <rgp:report> element of a restore report
+type RGPReport struct {
+	PreData    string   `xml:"preData"`
+	PostData   string   `xml:"postData"`
+	DelTime    string   `xml:"delTime"`
+	ResTime    string   `xml:"resTime"`
+	ResReason  string   `xml:"resReason"`
+	Statements []string `xml:"statement"`
+	Other      string   `xml:"other"`
+}
+
+// ToCommand converts the report to a commands.RestoreReportCommand for the domain and registrar. The dates must be in RFC 3339 format.
+func (r *RGPReport) ToCommand(name, clID string) (*commands.RestoreReportCommand, error) {
+	delTime, err := time.Parse(time.RFC3339, strings.TrimSpace(r.DelTime))
+	if err != nil {
+		return nil, errors.Join(entities.ErrInvalidRestoreReport, fmt.Errorf("invalid delTime: %s", r.DelTime))
+	}
+	resTime, err := time.Parse(time.RFC3339, strings.TrimSpace(r.ResTime))
+	if err != nil {
+		return nil, errors.Join(entities.ErrInvalidRestoreReport, fmt.Errorf("invalid resTime: %s", r.ResTime))
+	}
+	return &commands.RestoreReportCommand{
+		Name:       name,
+		ClID:       clID,
+		PreData:    r.PreData,
+		PostData:   r.PostData,
+		DelTime:    delTime,
+		ResTime:    resTime,
+		ResReason:  r.ResReason,
+		Statements: r.Statements,
+		Other:      r.Other,
+	}, nil
+}
@@ -0,0 +1,552 @@
+package epp
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// The structs in this file are used to marshal the RFC 5731 <resData> elements.
+// encoding/xml does not support namespace prefixes, so we use the prefixed names as element names and set the xmlns:domain attribute ourselves.
+// Ref: https://datatracker.ietf.org/doc/html/rfc5731#section-3
+
+// DomainChkData is the <domain:chkData> element of the check response
+type DomainChkData struct {
+	XMLName     xml.Name   `xml:"domain:chkData"`
+	XMLNSDomain string     `xml:"xmlns:domain,attr"`
+	CD          []DomainCD `xml:"domain:cd"`
+}
+
+// DomainCD is a single <domain:cd> element of the check response
+type DomainCD struct {
+	Name   DomainCheckName `xml:"domain:name"`
+	Reason string          `xml:"domain:reason,omitempty"`
+}
+
+// DomainCheckName is the <domain:name> element of the check response
+type DomainCheckName struct {
+	Avail int    `xml:"avail,attr"`
+	Value string `xml:",chardata"`
+}
+
+// NewDomainChkData creates a new empty DomainChkData
+func NewDomainChkData() *DomainChkData {
+	return &DomainChkData{XMLNSDomain: DOMAIN_NAMESPACE}
+}
+
+// Add adds a check result to the chkData
+func (c *DomainChkData) Add(name string, available bool, reason string) {
+	cd := DomainCD{Name: DomainCheckName{Value: name}}
+	if available {
+		cd.Name.Avail = 1
+	} else {
+		cd.Reason = reason
+	}
+	c.CD = append(c.CD, cd)
+}
+
+// DomainNs is the <domain:ns> element
+type DomainNs struct {
+	HostObjs []string `xml:"domain:hostObj"`
+}
+
+// DomainAuthInfo is the <domain:authInfo> element
+type DomainAuthInfo struct {
+	Pw string `xml:"domain:pw"`
+}
+
+// DomainInfStatus is a <domain:status> element of the info response
+type DomainInfStatus struct {
+	S string `xml:"s,attr"`
+}
+
+// DomainInfContact is a <domain:contact> element of the info response
+type DomainInfContact struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// DomainInfData is the <domain:infData> element of the info response
+type DomainInfData struct {
+	XMLName     xml.Name           `xml:"domain:infData"`
+	XMLNSDomain string             `xml:"xmlns:domain,attr"`
+	Name        string             `xml:"domain:name"`
+	RoID        string             `xml:"domain:roid"`
+	Status      []DomainInfStatus  `xml:"domain:status"`
+	Registrant  string             `xml:"domain:registrant,omitempty"`
+	Contacts    []DomainInfContact `xml:"domain:contact"`
+	Ns          *DomainNs          `xml:"domain:ns,omitempty"`
+	ClID        string             `xml:"domain:clID"`
+	CrID        string             `xml:"domain:crID,omitempty"`
+	CrDate      string             `xml:"domain:crDate,omitempty"`
+	UpID        string             `xml:"domain:upID,omitempty"`
+	UpDate      string             `xml:"domain:upDate,omitempty"`
+	ExDate      string             `xml:"domain:exDate,omitempty"`
+	AuthInfo    *DomainAuthInfo    `xml:"domain:authInfo,omitempty"`
+}
+
+// NewDomainInfData creates a DomainInfData from a domain entity.
+// includeHosts controls whether the delegated hosts are included (hosts="all" or hosts="del").
+// includeAuthInfo should only be set if the requesting client is the sponsoring registrar.
+func NewDomainInfData(dom *entities.Domain, includeHosts, includeAuthInfo bool) *DomainInfData {
+	inf := &DomainInfData{
+		XMLNSDomain: DOMAIN_NAMESPACE,
+		Name:        dom.Name.String(),
+		RoID:        dom.RoID.String(),
+		Registrant:  dom.RegistrantID.String(),
+		ClID:        dom.ClID.String(),
+		CrID:        dom.CrRr.String(),
+		CrDate:      formatEPPDate(dom.CreatedAt),
+		UpID:        dom.UpRr.String(),
+		UpDate:      formatEPPDate(dom.UpdatedAt),
+		ExDate:      formatEPPDate(dom.ExpiryDate),
+	}
+	for _, s := range dom.Status.StringSlice() {
+		inf.Status = append(inf.Status, DomainInfStatus{S: s})
+	}
+	for _, c := range []struct {
+		t  string
+		id entities.ClIDType
+	}{
+		{"admin", dom.AdminID},
+		{"tech", dom.TechID},
+		{"billing", dom.BillingID},
+	} {
+		if c.id != "" {
+			inf.Contacts = append(inf.Contacts, DomainInfContact{Type: c.t, Value: c.id.String()})
+		}
+	}
+	if includeHosts && len(dom.Hosts) > 0 {
+		inf.Ns = &DomainNs{HostObjs: dom.GetHostsAsStringSlice()}
+	}
+	if includeAuthInfo {
+		inf.AuthInfo = &DomainAuthInfo{Pw: dom.AuthInfo.String()}
+	}
+	return inf
+}
+
+// SecDNSInfData is the <secDNS:infData> extension of the domain info response as defined in RFC 5910
+type SecDNSInfData struct {
+	XMLName     xml.Name          `xml:"secDNS:infData"`
+	XMLNSSecDNS string            `xml:"xmlns:secDNS,attr"`
+	MaxSigLife  int               `xml:"secDNS:maxSigLife,omitempty"`
+	DSData      []SecDNSInfDSData `xml:"secDNS:dsData"`
+}
+
+// SecDNSInfDSData is a <secDNS:dsData> element of the info response
+type SecDNSInfDSData struct {
+	KeyTag     uint16 `xml:"secDNS:keyTag"`
+	Alg        uint8  `xml:"secDNS:alg"`
+	DigestType uint8  `xml:"secDNS:digestType"`
+	Digest     string `xml:"secDNS:digest"`
+}
+
+// NewSecDNSInfData creates a new SecDNSInfData from the DNSSEC delegation data of a domain
+func NewSecDNSInfData(secDNS entities.DomainSecDNS) *SecDNSInfData {
+	inf := &SecDNSInfData{
+		XMLNSSecDNS: SECDNS_NAMESPACE,
+		MaxSigLife:  secDNS.MaxSigLife,
+	}
+	for _, ds := range secDNS.DSData {
+		inf.DSData = append(inf.DSData, SecDNSInfDSData(ds))
+	}
+	return inf
+}
+
+// DomainCreData is the <domain:creData> element of the create response
+type DomainCreData struct {
+	XMLName     xml.Name `xml:"domain:creData"`
+	XMLNSDomain string   `xml:"xmlns:domain,attr"`
+	Name        string   `xml:"domain:name"`
+	CrDate      string   `xml:"domain:crDate"`
+	ExDate      string   `xml:"domain:exDate,omitempty"`
+}
+
+// NewDomainCreData creates a DomainCreData from a domain entity
+func NewDomainCreData(dom *entities.Domain) *DomainCreData {
+	return &DomainCreData{
+		XMLNSDomain: DOMAIN_NAMESPACE,
+		Name:        dom.Name.String(),
+		CrDate:      formatEPPDate(dom.CreatedAt),
+		ExDate:      formatEPPDate(dom.ExpiryDate),
+	}
+}
+
+// DomainRenData is the <domain:renData> element of the renew response
+type DomainRenData struct {
+	XMLName     xml.Name `xml:"domain:renData"`
+	XMLNSDomain string   `xml:"xmlns:domain,attr"`
+	Name        string   `xml:"domain:name"`
+	ExDate      string   `xml:"domain:exDate,omitempty"`
+}
+
+// NewDomainRenData creates a DomainRenData from a domain entity
+func NewDomainRenData(dom *entities.Domain) *DomainRenData {
+	return &DomainRenData{
+		XMLNSDomain: DOMAIN_NAMESPACE,
+		Name:        dom.Name.String(),
+		ExDate:      formatEPPDate(dom.ExpiryDate),
+	}
+}
+
+// DomainTrnData is the <domain:trnData> element of the transfer response
+type DomainTrnData struct {
+	XMLName     xml.Name `xml:"domain:trnData"`
+	XMLNSDomain string   `xml:"xmlns:domain,attr"`
+	Name        string   `xml:"domain:name"`
+	TrStatus    string   `xml:"domain:trStatus"`
+	ReID        string   `xml:"domain:reID"`
+	ReDate      string   `xml:"domain:reDate"`
+	AcID        string   `xml:"domain:acID"`
+	AcDate      string   `xml:"domain:acDate"`
+	ExDate      string   `xml:"domain:exDate,omitempty"`
+}
+
+// transferStatuses maps our transfer statuses to the EPP trStatus values
+var transferStatuses = map[entities.TransferStatus]string{
+	entities.TransferStatusPending:   "pending",
+	entities.TransferStatusApproved:  "clientApproved",
+	entities.TransferStatusDenied:    "clientRejected",
+	entities.TransferStatusCancelled: "clientCancelled",
+}
+
+// NewDomainTrnData creates a DomainTrnData from a transfer entity.
+// For a pending transfer acDate is the date by which the losing registrar must act, otherwise it is the date the transfer was acted upon.
+// exDate is omitted when zero.
+func NewDomainTrnData(transfer *entities.DomainTransfer, exDate time.Time) *DomainTrnData {
+	acDate := transfer.UpdatedAt
+	if transfer.IsPending() {
+		acDate = transfer.ExpiryDate
+	}
+	return &DomainTrnData{
+		XMLNSDomain: DOMAIN_NAMESPACE,
+		Name:        transfer.DomainName.String(),
+		TrStatus:    transferStatuses[transfer.Status],
+		ReID:        transfer.GainingRegistrar.String(),
+		ReDate:      formatEPPDate(transfer.CreatedAt),
+		AcID:        transfer.LosingRegistrar.String(),
+		AcDate:      formatEPPDate(acDate),
+		ExDate:      formatEPPDate(exDate),
+	}
+}
+
+// The structs below are used to marshal the RFC 8748 fee extension of the domain check and transform responses.
+// Ref: https://datatracker.ietf.org/doc/html/rfc8748#section-5
+
+// FeeChkData is the <fee:chkData> extension of the domain check response
+type FeeChkData struct {
+	XMLName  xml.Name `xml:"fee:chkData"`
+	XMLNSFee string   `xml:"xmlns:fee,attr"`
+	Currency string   `xml:"fee:currency"`
+	CD       []FeeCD  `xml:"fee:cd"`
+}
+
+// FeeCD is a single <fee:cd> element of the check response. avail indicates if we could determine the fees for the domain, not if the domain is available.
+type FeeCD struct {
+	Avail    int          `xml:"avail,attr"`
+	ObjID    string       `xml:"fee:objID"`
+	Class    string       `xml:"fee:class,omitempty"`
+	Commands []FeeCommand `xml:"fee:command"`
+	Reason   string       `xml:"fee:reason,omitempty"`
+}
+
+// FeeCommand is a <fee:command> element of the check response with the fees for that command
+type FeeCommand struct {
+	Name     string      `xml:"name,attr"`
+	Phase    string      `xml:"phase,attr,omitempty"`
+	Standard int         `xml:"standard,attr,omitempty"`
+	Period   *FeePeriod  `xml:"fee:period,omitempty"`
+	Fees     []FeeFee    `xml:"fee:fee"`
+	Credits  []FeeCredit `xml:"fee:credit,omitempty"`
+}
+
+// FeePeriod is the <fee:period> element of the check response
+type FeePeriod struct {
+	Unit  string `xml:"unit,attr"`
+	Value int    `xml:",chardata"`
+}
+
+// FeeFee is a <fee:fee> element, amounts are formatted as decimals in the currency of the response
+type FeeFee struct {
+	Description string `xml:"description,attr,omitempty"`
+	Refundable  int    `xml:"refundable,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// FeeCredit is a <fee:credit> element, a negative amount that is deducted from the fees (e.g. the discount of an allocation token)
+type FeeCredit struct {
+	Description string `xml:"description,attr,omitempty"`
+	Value       string `xml:",chardata"`
+}
+
+// NewFeeChkData creates a new empty FeeChkData
+func NewFeeChkData() *FeeChkData {
+	return &FeeChkData{XMLNSFee: FEE_NAMESPACE}
+}
+
+// FeeTrnData is the <fee:creData>, <fee:renData> or <fee:trnData> extension of a transform response with the fees we charged (or will charge)
+type FeeTrnData struct {
+	XMLName  xml.Name
+	XMLNSFee string      `xml:"xmlns:fee,attr"`
+	Currency string      `xml:"fee:currency"`
+	Fees     []FeeFee    `xml:"fee:fee"`
+	Credits  []FeeCredit `xml:"fee:credit,omitempty"`
+}
+
+// NewFeeTrnData creates a new FeeTrnData with the provided element name (e.g. creData) from a quote
+func NewFeeTrnData(name string, quote *entities.Quote) (*FeeTrnData, error) {
+	fees, err := feesFromQuote(quote)
+	if err != nil {
+		return nil, err
+	}
+	return &FeeTrnData{
+		XMLName:  xml.Name{Local: "fee:" + name},
+		XMLNSFee: FEE_NAMESPACE,
+		Currency: quote.Price.Currency().Code,
+		Fees:     fees,
+		Credits:  creditsFromQuote(quote),
+	}, nil
+}
+
+// feesFromQuote returns the <fee:fee> elements for the fees in the quote in the currency of the quote.
+// Fees with the same name (e.g. a yearly fee for a multi-year registration) are combined into a single element.
+func feesFromQuote(quote *entities.Quote) ([]FeeFee, error) {
+	if len(quote.Fees) == 0 {
+		// The price is after the discount, which is reported as a credit
+		price := quote.Price
+		if quote.Discount != nil {
+			var err error
+			if price, err = price.Add(quote.Discount); err != nil {
+				return nil, err
+			}
+		}
+		return []FeeFee{{Value: formatFeeAmount(price)}}, nil
+	}
+	var names []entities.ClIDType
+	totals := map[entities.ClIDType]*money.Money{}
+	refundable := map[entities.ClIDType]bool{}
+	for _, fee := range quote.Fees {
+		amount := fee.GetMoney()
+		if amount.Currency().Code != quote.Price.Currency().Code {
+			var err error
+			if amount, err = quote.FXRate.Convert(amount); err != nil {
+				return nil, err
+			}
+		}
+		total, ok := totals[fee.Name]
+		if !ok {
+			names = append(names, fee.Name)
+			totals[fee.Name] = amount
+			refundable[fee.Name] = fee.Refundable != nil && *fee.Refundable
+			continue
+		}
+		sum, err := total.Add(amount)
+		if err != nil {
+			return nil, err
+		}
+		totals[fee.Name] = sum
+	}
+	fees := make([]FeeFee, len(names))
+	for i, name := range names {
+		fees[i] = FeeFee{Description: name.String(), Value: formatFeeAmount(totals[name])}
+		if refundable[name] {
+			fees[i].Refundable = 1
+		}
+	}
+	return fees, nil
+}
+
+// creditsFromQuote returns the <fee:credit> element for the discount of the quote, the fees plus the credits add up to the price of the quote
+func creditsFromQuote(quote *entities.Quote) []FeeCredit {
+	if quote.Discount == nil || quote.Discount.IsZero() {
+		return nil
+	}
+	return []FeeCredit{{Description: "discount", Value: formatFeeAmount(quote.Discount.Negative())}}
+}
+
+// formatFeeAmount formats an amount as a decimal with the number of decimals of its currency (e.g. 10.00 for USD), credits are negative (e.g. -10.00)
+func formatFeeAmount(m *money.Money) string {
+	sign := ""
+	if m.IsNegative() {
+		sign = "-"
+		m = m.Absolute()
+	}
+	fraction := m.Currency().Fraction
+	if fraction == 0 {
+		return sign + strconv.FormatInt(m.Amount(), 10)
+	}
+	unit := int64(math.Pow10(fraction))
+	return fmt.Sprintf("%s%d.%0*d", sign, m.Amount()/unit, fraction, m.Amount()%unit)
+}
+
+// The structs below are used to marshal the RFC 8334 launch phase extension of the domain check, create and info responses.
+// Ref: https://datatracker.ietf.org/doc/html/rfc8334#section-3
+
+// LaunchPhaseData is the <launch:phase> element of a response
+type LaunchPhaseData struct {
+	Name  string `xml:"name,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+// launchPhases are the phases defined in RFC 8334, other phases are returned as "custom" with the phase name
+var launchPhases = []string{"sunrise", "landrush", "claims", "open"}
+
+// NewLaunchPhaseData creates the <launch:phase> element for a TLD phase, the current GA phase (empty name) is the "open" phase
+func NewLaunchPhaseData(phaseName string) LaunchPhaseData {
+	if phaseName == "" {
+		return LaunchPhaseData{Value: "open"}
+	}
+	if slices.Contains(launchPhases, phaseName) {
+		return LaunchPhaseData{Value: phaseName}
+	}
+	return LaunchPhaseData{Name: phaseName, Value: "custom"}
+}
+
+// LaunchChkData is the <launch:chkData> extension of the claims check response
+type LaunchChkData struct {
+	XMLName     xml.Name        `xml:"launch:chkData"`
+	XMLNSLaunch string          `xml:"xmlns:launch,attr"`
+	Phase       LaunchPhaseData `xml:"launch:phase"`
+	CD          []LaunchCD      `xml:"launch:cd"`
+}
+
+// LaunchCD is a single <launch:cd> element of the claims check response
+type LaunchCD struct {
+	Name     LaunchCDName    `xml:"launch:name"`
+	ClaimKey *LaunchClaimKey `xml:"launch:claimKey,omitempty"`
+}
+
+// LaunchCDName is the <launch:name> element of the claims check response, exists indicates the domain matches a trademark
+type LaunchCDName struct {
+	Exists int    `xml:"exists,attr"`
+	Value  string `xml:",chardata"`
+}
+
+// LaunchClaimKey is the <launch:claimKey> element with the key to retrieve the claims notice from the TMCH
+type LaunchClaimKey struct {
+	ValidatorID string `xml:"validatorID,attr,omitempty"`
+	Value       string `xml:",chardata"`
+}
+
+// NewLaunchChkData creates a new empty LaunchChkData for the phase
+func NewLaunchChkData(phaseName string) *LaunchChkData {
+	return &LaunchChkData{XMLNSLaunch: LAUNCH_NAMESPACE, Phase: NewLaunchPhaseData(phaseName)}
+}
+
+// Add adds a claims check result to the chkData, an empty claim key means the domain does not match a trademark
+func (c *LaunchChkData) Add(name, claimKey string) {
+	cd := LaunchCD{Name: LaunchCDName{Value: name}}
+	if claimKey != "" {
+		cd.Name.Exists = 1
+		cd.ClaimKey = &LaunchClaimKey{ValidatorID: TMCH_VALIDATOR_ID, Value: claimKey}
+	}
+	c.CD = append(c.CD, cd)
+}
+
+// LaunchCreData is the <launch:creData> extension of the create response
+type LaunchCreData struct {
+	XMLName       xml.Name        `xml:"launch:creData"`
+	XMLNSLaunch   string          `xml:"xmlns:launch,attr"`
+	Phase         LaunchPhaseData `xml:"launch:phase"`
+	ApplicationID string          `xml:"launch:applicationID,omitempty"`
+}
+
+// NewLaunchCreData creates a LaunchCreData for a registration (empty applicationID) or an application in the phase
+func NewLaunchCreData(phaseName, applicationID string) *LaunchCreData {
+	return &LaunchCreData{
+		XMLNSLaunch:   LAUNCH_NAMESPACE,
+		Phase:         NewLaunchPhaseData(phaseName),
+		ApplicationID: applicationID,
+	}
+}
+
+// LaunchStatus is the <launch:status> element of the info response
+type LaunchStatus struct {
+	S string `xml:"s,attr"`
+}
+
+// LaunchInfData is the <launch:infData> extension of the info response of a domain application
+type LaunchInfData struct {
+	XMLName       xml.Name        `xml:"launch:infData"`
+	XMLNSLaunch   string          `xml:"xmlns:launch,attr"`
+	Phase         LaunchPhaseData `xml:"launch:phase"`
+	ApplicationID string          `xml:"launch:applicationID"`
+	Status        LaunchStatus    `xml:"launch:status"`
+}
+
+// NewLaunchInfData creates a LaunchInfData from a domain application
+func NewLaunchInfData(app *entities.DomainApplication) *LaunchInfData {
+	return &LaunchInfData{
+		XMLNSLaunch:   LAUNCH_NAMESPACE,
+		Phase:         NewLaunchPhaseData(app.PhaseName.String()),
+		ApplicationID: app.ID.String(),
+		Status:        LaunchStatus{S: string(app.Status)},
+	}
+}
+
+// NewDomainInfDataFromApplication creates a DomainInfData for a domain application. The application is not a domain object yet,
+// so it has no expiry date and the applicationID is used as the roid. Its status is pendingCreate until it is allocated.
+func NewDomainInfDataFromApplication(app *entities.DomainApplication, includeAuthInfo bool) *DomainInfData {
+	dom := &entities.Domain{
+		RoID:         entities.RoidType(app.ID.String()),
+		Name:         app.DomainName,
+		ClID:         app.ClID,
+		CrRr:         app.ClID,
+		RegistrantID: app.RegistrantID,
+		AdminID:      app.AdminID,
+		TechID:       app.TechID,
+		BillingID:    app.BillingID,
+		AuthInfo:     app.AuthInfo,
+		CreatedAt:    app.CreatedAt,
+		UpdatedAt:    app.UpdatedAt,
+		Status:       entities.DomainStatus{PendingCreate: true},
+	}
+	inf := NewDomainInfData(dom, false, includeAuthInfo)
+	if len(app.HostNames) > 0 {
+		inf.Ns = &DomainNs{HostObjs: app.HostNames}
+	}
+	return inf
+}
+
+// RGPStatus is the <rgp:rgpStatus> element
+type RGPStatus struct {
+	S string `xml:"s,attr"`
+}
+
+// RGPData is the <rgp:infData> extension of the domain info response or the <rgp:upData> extension of the update response as defined in RFC 3915
+type RGPData struct {
+	XMLName  xml.Name
+	XMLNSRGP string      `xml:"xmlns:rgp,attr"`
+	Statuses []RGPStatus `xml:"rgp:rgpStatus"`
+}
+
+// NewRGPInfData creates the <rgp:infData> element with the RGP statuses of the domain, it returns nil if no grace period applies to the domain
+func NewRGPInfData(dom *entities.Domain, at time.Time) *RGPData {
+	statuses := dom.RGPStatuses(at)
+	if len(statuses) == 0 {
+		return nil
+	}
+	return newRGPData("infData", statuses)
+}
+
+// NewRGPUpData creates the <rgp:upData> element of the response to a restore request
+func NewRGPUpData() *RGPData {
+	return newRGPData("upData", []string{entities.RGPStatusPendingRestore})
+}
+
+func newRGPData(name string, statuses []string) *RGPData {
+	data := &RGPData{
+		XMLName:  xml.Name{Local: "rgp:" + name},
+		XMLNSRGP: RGP_NAMESPACE,
+	}
+	for _, s := range statuses {
+		data.Statuses = append(data.Statuses, RGPStatus{S: s})
+	}
+	return data
+}
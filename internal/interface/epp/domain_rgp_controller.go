@@ -0,0 +1,104 @@
+package epp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	epplib "github.com/dotse/epp-lib"
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// The methods in this file handle the RFC 3915 registry grace period extension of the domain update command.
+// A restore is a two step process: the restore request puts the domain in pendingRestore status and the registrar then submits a restore report.
+// The restore is completed by the registry once the report is received, if it is not received within the pendingRestore period the domain returns to the redemption period.
+// Ref: https://datatracker.ietf.org/doc/html/rfc3915
+
+// restore handles the rgp extension of the domain <update> command. The update must not contain any other changes to the domain.
+func (ctrl *DomainController) restore(ctx context.Context, rw epplib.Writer, cmd *DomainUpdateCommand, clID string) {
+	if !hasExtensionFromContext(ctx, RGP_NAMESPACE) {
+		writeResponse(ctx, rw, NewErrorResponse(errors.Join(ErrExtensionNotRequested, fmt.Errorf("extURI: %s", RGP_NAMESPACE)), cmd.ClTRID))
+		return
+	}
+	if !cmd.Add.IsEmpty() || !cmd.Rem.IsEmpty() || !cmd.Chg.IsEmpty() || cmd.Extension.SecDNS != nil {
+		writeResponse(ctx, rw, NewErrorResponse(ErrRestoreUpdateNotSupported, cmd.ClTRID))
+		return
+	}
+
+	dom, err := ctrl.domainService.GetDomainByName(ctx, cmd.Name, false)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	if dom.ClID.String() != clID {
+		writeResponse(ctx, rw, NewErrorResponse(entities.ErrInvalidRegistrar, cmd.ClTRID))
+		return
+	}
+
+	switch cmd.Extension.RGP.Restore.Op {
+	case RGPOpRequest:
+		ctrl.restoreRequest(ctx, rw, cmd, clID)
+	case RGPOpReport:
+		ctrl.restoreReport(ctx, rw, cmd, clID)
+	default:
+		writeResponse(ctx, rw, NewErrorResponse(errors.Join(ErrInvalidRestoreOp, fmt.Errorf("op: %s", cmd.Extension.RGP.Restore.Op)), cmd.ClTRID))
+	}
+}
+
+// restoreRequest puts the domain in pendingRestore status. The fee the client agrees to pay for the restore can be provided with the fee extension, it is passed on to the service which charges it.
+func (ctrl *DomainController) restoreRequest(ctx context.Context, rw epplib.Writer, cmd *DomainUpdateCommand, clID string) {
+	var fee commands.FeeExtension
+	var feeData *FeeTrnData
+	if cmd.Extension.Fee != nil {
+		var err error
+		fee, feeData, err = ctrl.validateFee(ctx, cmd.Extension.Fee, "updData", &queries.QuoteRequest{
+			DomainName:      cmd.Name,
+			ClID:            clID,
+			TransactionType: entities.TransactionTypeRestore,
+			Currency:        cmd.Extension.Fee.Currency,
+			Years:           1,
+		})
+		if err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+	}
+
+	if _, err := ctrl.domainService.RestoreDomain(ctx, cmd.Name, fee); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+
+	resp := NewResponse(epplib.StatusSuccess, cmd.ClTRID).WithExtension(NewRGPUpData())
+	if feeData != nil {
+		resp.WithExtension(feeData)
+	}
+	writeResponse(ctx, rw, resp)
+}
+
+// restoreReport submits the restore report of a domain in pendingRestore status
+func (ctrl *DomainController) restoreReport(ctx context.Context, rw epplib.Writer, cmd *DomainUpdateCommand, clID string) {
+	if cmd.Extension.Fee != nil {
+		writeResponse(ctx, rw, NewErrorResponse(errors.Join(ErrUnsupportedFeeCommand, errors.New("the restore report is not charged")), cmd.ClTRID))
+		return
+	}
+	report := cmd.Extension.RGP.Restore.Report
+	if report == nil {
+		writeResponse(ctx, rw, NewErrorResponse(ErrMissingRestoreReport, cmd.ClTRID))
+		return
+	}
+	reportCmd, err := report.ToCommand(cmd.Name, clID)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+
+	if _, err := ctrl.domainService.ReportDomainRestore(ctx, reportCmd); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+
+	writeResponse(ctx, rw, NewResponse(epplib.StatusSuccess, cmd.ClTRID))
+}
@@ -0,0 +1,221 @@
+package epp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	epplib "github.com/dotse/epp-lib"
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// restoreCommand returns a domain update command for example.com with the provided rgp and other extension elements
+func restoreCommand(ext string) string {
+	return eppCommand(`<update><domain:update xmlns:domain="urn:ietf:params:xml:ns:domain-1.0">
+		<domain:name>example.com</domain:name>
+		<domain:chg/>
+	</domain:update></update><extension>` + ext + `</extension>`)
+}
+
+const testRestoreReport = `<rgp:update xmlns:rgp="urn:ietf:params:xml:ns:rgp-1.0"><rgp:restore op="report"><rgp:report>
+	<rgp:preData>Pre-delete registration data goes here.</rgp:preData>
+	<rgp:postData>Post-restore registration data goes here.</rgp:postData>
+	<rgp:delTime>2024-01-01T22:00:00.0Z</rgp:delTime>
+	<rgp:resTime>2024-01-03T22:00:00.0Z</rgp:resTime>
+	<rgp:resReason>Registrant error.</rgp:resReason>
+	<rgp:statement>This registrar has not restored the Registered Name in order to assume the rights to use or sell the Registered Name for itself or for any third party.</rgp:statement>
+	<rgp:statement>The information in this report is true to best of this registrar's knowledge.</rgp:statement>
+	<rgp:other>Supporting information goes here.</rgp:other>
+</rgp:report></rgp:restore></rgp:update>`
+
+func TestDomainController_Info_RGP(t *testing.T) {
+	svc := new(MockDomainService)
+	ctrl := &DomainController{domainService: svc}
+	dom := getTestDomain()
+	dom.Status = entities.DomainStatus{PendingDelete: true}
+	dom.RGPStatus.RedemptionPeriodEnd = time.Now().UTC().AddDate(0, 0, 10)
+	svc.On("GetDomainByName", mock.Anything, "example.com", true).Return(dom, nil)
+	cmd := eppCommand(`<info><domain:info xmlns:domain="urn:ietf:params:xml:ns:domain-1.0"><domain:name>example.com</domain:name></domain:info></info>`)
+
+	w := &testWriter{}
+	ctrl.Info(newTestContext("ClID-1"), w, newTestDoc(t, cmd))
+	require.Contains(t, w.String(), `<extension><rgp:infData xmlns:rgp="urn:ietf:params:xml:ns:rgp-1.0"><rgp:rgpStatus s="redemptionPeriod"></rgp:rgpStatus></rgp:infData></extension>`)
+
+	// Clients that did not request the extension at login don't get it
+	s := NewSession()
+	s.Login("ClID-1", entities.RegistrarStatusOK, nil)
+	w = &testWriter{}
+	ctrl.Info(ContextWithSession(context.Background(), s), w, newTestDoc(t, cmd))
+	require.Contains(t, w.String(), `<result code="1000">`)
+	require.NotContains(t, w.String(), "rgp")
+
+	// Domains without grace periods have no rgp:infData
+	svc = new(MockDomainService)
+	ctrl = &DomainController{domainService: svc}
+	svc.On("GetDomainByName", mock.Anything, "example.com", true).Return(getTestDomain(), nil)
+	w = &testWriter{}
+	ctrl.Info(newTestContext("ClID-1"), w, newTestDoc(t, cmd))
+	require.Contains(t, w.String(), `<result code="1000">`)
+	require.NotContains(t, w.String(), "rgp")
+}
+
+func TestDomainController_Update_RestoreRequest(t *testing.T) {
+	svc := new(MockDomainService)
+	ctrl := &DomainController{domainService: svc}
+	svc.On("GetDomainByName", mock.Anything, "example.com", false).Return(getTestDomain(), nil)
+	svc.On("RestoreDomain", mock.Anything, "example.com", commands.FeeExtension{}).Return(getTestDomain(), nil)
+
+	w := &testWriter{}
+	ctrl.Update(newTestContext("ClID-1"), w, newTestDoc(t, restoreCommand(`<rgp:update xmlns:rgp="urn:ietf:params:xml:ns:rgp-1.0"><rgp:restore op="request"/></rgp:update>`)))
+	require.Contains(t, w.String(), `<result code="1000">`)
+	require.Contains(t, w.String(), `<rgp:upData xmlns:rgp="urn:ietf:params:xml:ns:rgp-1.0"><rgp:rgpStatus s="pendingRestore"></rgp:rgpStatus></rgp:upData>`)
+	svc.AssertNotCalled(t, "UpdateDomain", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDomainController_Update_RestoreRequest_Fee(t *testing.T) {
+	svc := new(MockDomainService)
+	ctrl := &DomainController{domainService: svc}
+	svc.On("GetDomainByName", mock.Anything, "example.com", false).Return(getTestDomain(), nil)
+	// The fee that was validated is passed on to the service so the restore is charged the amount the client agreed to
+	svc.On("RestoreDomain", mock.Anything, "example.com", commands.FeeExtension{Currency: "USD", Amount: 4000, Provided: true}).Return(getTestDomain(), nil)
+	quote := entities.NewQuote("USD")
+	quote.Price = money.New(4000, "USD")
+	svc.On("GetQuote", mock.Anything, &queries.QuoteRequest{DomainName: "example.com", ClID: "ClID-1", TransactionType: entities.TransactionTypeRestore, Currency: "USD", Years: 1}).Return(quote, nil)
+	restore := func(fee string) string {
+		return restoreCommand(`<rgp:update xmlns:rgp="urn:ietf:params:xml:ns:rgp-1.0"><rgp:restore op="request"/></rgp:update>` +
+			`<fee:update xmlns:fee="urn:ietf:params:xml:ns:epp:fee-1.0"><fee:currency>USD</fee:currency><fee:fee>` + fee + `</fee:fee></fee:update>`)
+	}
+
+	w := &testWriter{}
+	ctrl.Update(newTestContext("ClID-1"), w, newTestDoc(t, restore("40.00")))
+	require.Contains(t, w.String(), `<result code="1000">`)
+	require.Contains(t, w.String(), `<fee:updData xmlns:fee="urn:ietf:params:xml:ns:epp:fee-1.0"><fee:currency>USD</fee:currency><fee:fee refundable="0">40.00</fee:fee></fee:updData>`)
+
+	w = &testWriter{}
+	ctrl.Update(newTestContext("ClID-1"), w, newTestDoc(t, restore("30.00")))
+	require.Contains(t, w.String(), `<result code="2004">`)
+	svc.AssertNumberOfCalls(t, "RestoreDomain", 1)
+	svc.AssertExpectations(t)
+}
+
+func TestDomainController_Update_RestoreReport(t *testing.T) {
+	svc := new(MockDomainService)
+	ctrl := &DomainController{domainService: svc}
+	svc.On("GetDomainByName", mock.Anything, "example.com", false).Return(getTestDomain(), nil)
+	svc.On("ReportDomainRestore", mock.Anything, mock.MatchedBy(func(cmd *commands.RestoreReportCommand) bool {
+		return cmd.Name == "example.com" && cmd.ClID == "ClID-1" && cmd.ResReason == "Registrant error." && len(cmd.Statements) == 2 &&
+			cmd.DelTime.Equal(time.Date(2024, 1, 1, 22, 0, 0, 0, time.UTC)) && cmd.ResTime.Equal(time.Date(2024, 1, 3, 22, 0, 0, 0, time.UTC))
+	})).Return(getTestDomain(), nil)
+
+	w := &testWriter{}
+	ctrl.Update(newTestContext("ClID-1"), w, newTestDoc(t, restoreCommand(testRestoreReport)))
+	require.Equal(t, epplib.StatusSuccess, decodeResultCode(t, w.Bytes()))
+	require.NotContains(t, w.String(), "rgp:upData")
+	svc.AssertExpectations(t)
+}
+
+func TestDomainController_Update_Restore_Errors(t *testing.T) {
+	tc := []struct {
+		name       string
+		clID       string
+		extensions []string
+		cmd        string
+		svcErr     error
+		wantCode   int
+	}{
+		{
+			name:       "extension not requested",
+			clID:       "ClID-1",
+			extensions: []string{SECDNS_NAMESPACE},
+			cmd:        restoreCommand(`<rgp:update xmlns:rgp="urn:ietf:params:xml:ns:rgp-1.0"><rgp:restore op="request"/></rgp:update>`),
+			wantCode:   epplib.StatusUnimplementedExtension,
+		},
+		{
+			name:       "not the sponsor",
+			clID:       "ClID-2",
+			extensions: supportedExtURIs,
+			cmd:        restoreCommand(`<rgp:update xmlns:rgp="urn:ietf:params:xml:ns:rgp-1.0"><rgp:restore op="request"/></rgp:update>`),
+			wantCode:   epplib.StatusAuthorizationError,
+		},
+		{
+			name:       "invalid op",
+			clID:       "ClID-1",
+			extensions: supportedExtURIs,
+			cmd:        restoreCommand(`<rgp:update xmlns:rgp="urn:ietf:params:xml:ns:rgp-1.0"><rgp:restore op="undo"/></rgp:update>`),
+			wantCode:   epplib.StatusValueSyntaxError,
+		},
+		{
+			name:       "missing report",
+			clID:       "ClID-1",
+			extensions: supportedExtURIs,
+			cmd:        restoreCommand(`<rgp:update xmlns:rgp="urn:ietf:params:xml:ns:rgp-1.0"><rgp:restore op="report"/></rgp:update>`),
+			wantCode:   epplib.StatusMissingParameter,
+		},
+		{
+			name:       "invalid report date",
+			clID:       "ClID-1",
+			extensions: supportedExtURIs,
+			cmd:        restoreCommand(`<rgp:update xmlns:rgp="urn:ietf:params:xml:ns:rgp-1.0"><rgp:restore op="report"><rgp:report><rgp:delTime>yesterday</rgp:delTime></rgp:report></rgp:restore></rgp:update>`),
+			wantCode:   epplib.StatusParameterPolicyError,
+		},
+		{
+			name:       "restore combined with other changes",
+			clID:       "ClID-1",
+			extensions: supportedExtURIs,
+			cmd: eppCommand(`<update><domain:update xmlns:domain="urn:ietf:params:xml:ns:domain-1.0">
+				<domain:name>example.com</domain:name>
+				<domain:add><domain:status s="clientHold"/></domain:add>
+			</domain:update></update><extension><rgp:update xmlns:rgp="urn:ietf:params:xml:ns:rgp-1.0"><rgp:restore op="request"/></rgp:update></extension>`),
+			wantCode: epplib.StatusParameterPolicyError,
+		},
+		{
+			name:       "domain not in redemption period",
+			clID:       "ClID-1",
+			extensions: supportedExtURIs,
+			cmd:        restoreCommand(`<rgp:update xmlns:rgp="urn:ietf:params:xml:ns:rgp-1.0"><rgp:restore op="request"/></rgp:update>`),
+			svcErr:     entities.ErrDomainRestoreNotAllowed,
+			wantCode:   epplib.StatusObjectStatusProhibitsOperation,
+		},
+		{
+			name:       "report overdue",
+			clID:       "ClID-1",
+			extensions: supportedExtURIs,
+			cmd:        restoreCommand(testRestoreReport),
+			svcErr:     entities.ErrRestoreReportNotAllowed,
+			wantCode:   epplib.StatusObjectStatusProhibitsOperation,
+		},
+		{
+			name:       "invalid report",
+			clID:       "ClID-1",
+			extensions: supportedExtURIs,
+			cmd:        restoreCommand(testRestoreReport),
+			svcErr:     entities.ErrInvalidRestoreReport,
+			wantCode:   epplib.StatusParameterPolicyError,
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockDomainService)
+			ctrl := &DomainController{domainService: svc}
+			svc.On("GetDomainByName", mock.Anything, "example.com", false).Return(getTestDomain(), nil)
+			svc.On("RestoreDomain", mock.Anything, "example.com", mock.Anything).Return(nil, tt.svcErr)
+			svc.On("ReportDomainRestore", mock.Anything, mock.Anything).Return(nil, tt.svcErr)
+
+			s := NewSession()
+			s.Login(tt.clID, entities.RegistrarStatusOK, tt.extensions)
+			w := &testWriter{}
+			ctrl.Update(ContextWithSession(context.Background(), s), w, newTestDoc(t, tt.cmd))
+			require.Equal(t, tt.wantCode, decodeResultCode(t, w.Bytes()))
+			if tt.svcErr == nil {
+				svc.AssertNotCalled(t, "RestoreDomain", mock.Anything, mock.Anything, mock.Anything)
+				svc.AssertNotCalled(t, "ReportDomainRestore", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
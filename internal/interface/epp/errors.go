@@ -0,0 +1,268 @@
+package epp
+
+import (
+	"errors"
+
+	epplib "github.com/dotse/epp-lib"
+	"github.com/onasunnymorning/domain-os/internal/application/services"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+var (
+	// ErrInvalidCommand is returned when the command XML can't be parsed into the expected structure
+	ErrInvalidCommand = errors.New("invalid command")
+	// ErrMissingDomainName is returned when a domain command does not contain a domain name
+	ErrMissingDomainName = errors.New("missing domain name")
+	// ErrMissingContactID is returned when a contact command does not contain a contact id
+	ErrMissingContactID = errors.New("missing contact id")
+	// ErrMissingHostName is returned when a host command does not contain a host name
+	ErrMissingHostName = errors.New("missing host name")
+	// ErrMissingPostalInfo is returned when a contact create command does not contain a postalInfo element
+	ErrMissingPostalInfo = errors.New("at least one postalInfo element is required")
+	// ErrInvalidPeriod is returned when the period in a create or renew command is invalid
+	ErrInvalidPeriod = errors.New("invalid period, must be a whole number of years")
+	// ErrCurExpDateMismatch is returned when the curExpDate in a renew command does not match the domain's expiry date
+	ErrCurExpDateMismatch = errors.New("curExpDate does not match the current expiry date")
+	// ErrServerStatusNotAllowed is returned when a client tries to add or remove a server status
+	ErrServerStatusNotAllowed = errors.New("only client statuses can be set by the client")
+	// ErrTransferNotPending is returned when a transfer action is requested on an object that is not pending transfer
+	ErrTransferNotPending = errors.New("object is not pending transfer")
+	// ErrUnimplementedCommand is returned when a command is recognized but not (yet) supported
+	ErrUnimplementedCommand = errors.New("unimplemented command")
+	// ErrMissingCredentials is returned when a login command does not contain a clID or pw
+	ErrMissingCredentials = errors.New("missing clID or pw")
+	// ErrUnsupportedVersion is returned when a client requests a protocol version other than 1.0 at login
+	ErrUnsupportedVersion = errors.New("unsupported protocol version")
+	// ErrUnsupportedLanguage is returned when a client requests a response language we don't support at login
+	ErrUnsupportedLanguage = errors.New("unsupported language")
+	// ErrUnsupportedObjectService is returned when a client requests an object service we don't support at login
+	ErrUnsupportedObjectService = errors.New("unsupported object service")
+	// ErrUnsupportedExtension is returned when a client requests an extension we don't support at login
+	ErrUnsupportedExtension = errors.New("unsupported extension")
+	// ErrMissingMsgID is returned when a <poll op="ack"> command does not contain a msgID
+	ErrMissingMsgID = errors.New("missing msgID")
+	// ErrInvalidMsgID is returned when the msgID of a <poll op="ack"> command is not one of our message IDs
+	ErrInvalidMsgID = errors.New("invalid msgID")
+	// ErrAuthInfoMismatch is returned when the authInfo provided by a non-sponsoring client does not match the object's authInfo
+	ErrAuthInfoMismatch = errors.New("authInfo does not match")
+	// ErrExtensionNotRequested is returned when a command contains an extension the client did not request at login
+	ErrExtensionNotRequested = errors.New("extension was not requested at login")
+	// ErrSecDNSKeyDataNotSupported is returned when a client uses the secDNS key data interface, we only support the DS data interface
+	ErrSecDNSKeyDataNotSupported = errors.New("the secDNS key data interface is not supported, please provide dsData")
+	// ErrSecDNSUrgentNotSupported is returned when a client requests an urgent secDNS update
+	ErrSecDNSUrgentNotSupported = errors.New("urgent secDNS updates are not supported")
+	// ErrMissingFeeCommand is returned when the fee extension of a check command does not name any command
+	ErrMissingFeeCommand = errors.New("the fee check extension requires at least one command")
+	// ErrUnsupportedFeeCommand is returned when the fees are requested for a command we don't charge for through the fee extension
+	ErrUnsupportedFeeCommand = errors.New("fees are only available for the create, renew, transfer and restore commands")
+	// ErrInvalidFeeAmount is returned when a fee in the fee extension is not a valid amount in its currency
+	ErrInvalidFeeAmount = errors.New("invalid fee amount")
+	// ErrMissingApplicationID is returned when a launch info, update or delete command does not identify the domain application
+	ErrMissingApplicationID = errors.New("missing applicationID")
+	// ErrInvalidMark is returned when the mark in the launch extension of a create command is malformed
+	ErrInvalidMark = errors.New("invalid mark")
+	// ErrMultipleMarksNotSupported is returned when the launch extension of a create command contains more than one mark
+	ErrMultipleMarksNotSupported = errors.New("only one mark per domain is supported")
+	// ErrApplicationExtensionNotSupported is returned when a domain application is created with an extension we only support for registrations
+	ErrApplicationExtensionNotSupported = errors.New("the secDNS and fee extensions are not supported for domain applications")
+	// ErrApplicationUpdateNotSupported is returned when an update of a domain application changes more than its contacts, nameservers and authInfo
+	ErrApplicationUpdateNotSupported = errors.New("only the contacts, nameservers and authInfo of a domain application can be updated")
+	// ErrMissingRestoreReport is returned when a restore report command does not contain the report
+	ErrMissingRestoreReport = errors.New("missing restore report")
+	// ErrInvalidRestoreOp is returned when the op attribute of a restore is not request or report
+	ErrInvalidRestoreOp = errors.New("invalid restore op, must be request or report")
+	// ErrRestoreUpdateNotSupported is returned when a restore is combined with other changes to the domain
+	ErrRestoreUpdateNotSupported = errors.New("a restore can't be combined with other changes to the domain")
+	// ErrInvalidLoginSecPassword is returned when the pw or newPW of a login command is not set to [LOGIN-SECURITY] while the password is provided in the login security extension, or the other way around
+	ErrInvalidLoginSecPassword = errors.New("pw and newPW must be set to [LOGIN-SECURITY] when, and only when, the password is provided in the loginSec extension")
+)
+
+// errorCodeMapping maps an error to an EPP result code.
+type errorCodeMapping struct {
+	err  error
+	code int
+}
+
+// errorCodeMappings is evaluated in order and the first match wins.
+// Our services tend to join a generic error (e.g. ErrInvalidDomain) with a more specific error (e.g. ErrDomainExists),
+// so the specific errors MUST be listed before the generic ones.
+var errorCodeMappings = []errorCodeMapping{
+	// Command parsing and session errors
+	{ErrInvalidCommand, epplib.StatusCommandSyntaxError},
+	{ErrNotLoggedIn, epplib.StatusCommandUseError},
+	{ErrAlreadyLoggedIn, epplib.StatusCommandUseError},
+	{ErrMissingCredentials, epplib.StatusMissingParameter},
+	{ErrUnsupportedVersion, epplib.StatusUnimplementedProtocolVersion},
+	{ErrUnsupportedLanguage, epplib.StatusUnimplementedOption},
+	{ErrUnsupportedObjectService, epplib.StatusUnimplementedObjectService},
+	{ErrUnsupportedExtension, epplib.StatusUnimplementedExtension},
+	{ErrExtensionNotRequested, epplib.StatusUnimplementedExtension},
+	{ErrSecDNSUrgentNotSupported, epplib.StatusUnimplementedOption},
+	{ErrMissingDomainName, epplib.StatusMissingParameter},
+	{ErrMissingContactID, epplib.StatusMissingParameter},
+	{ErrMissingHostName, epplib.StatusMissingParameter},
+	{ErrMissingPostalInfo, epplib.StatusMissingParameter},
+	{ErrMissingMsgID, epplib.StatusMissingParameter},
+	{ErrMissingFeeCommand, epplib.StatusMissingParameter},
+	{ErrMissingApplicationID, epplib.StatusMissingParameter},
+	{entities.ErrMarkRequired, epplib.StatusMissingParameter},
+	{entities.ErrClaimsNoticeRequired, epplib.StatusMissingParameter},
+	{ErrMissingRestoreReport, epplib.StatusMissingParameter},
+	{ErrApplicationExtensionNotSupported, epplib.StatusUnimplementedExtension},
+	{ErrInvalidMsgID, epplib.StatusValueSyntaxError},
+	{ErrUnimplementedCommand, epplib.StatusUnimplementedCommand},
+	{ErrTransferNotPending, epplib.StatusObjectNotPendingTransfer},
+	{entities.ErrNoPendingTransfer, epplib.StatusObjectNotPendingTransfer},
+
+	// 2300 Object pending transfer
+	{entities.ErrDomainPendingTransfer, epplib.StatusObjectPendingTransfer},
+
+	// 2106 Object is not eligible for transfer
+	{entities.ErrTransferToSponsor, epplib.StatusNotEligibleForTransfer},
+	{entities.ErrDomainTransferLocked, epplib.StatusNotEligibleForTransfer},
+
+	// 2303 Object does not exist
+	{entities.ErrDomainNotFound, epplib.StatusObjectDoesNotExist},
+	{entities.ErrHostNotFound, epplib.StatusObjectDoesNotExist},
+	{entities.ErrContactNotFound, epplib.StatusObjectDoesNotExist},
+	{entities.ErrTLDNotFound, epplib.StatusObjectDoesNotExist},
+	{entities.ErrPollMessageNotFound, epplib.StatusObjectDoesNotExist},
+	{entities.ErrDomainApplicationNotFound, epplib.StatusObjectDoesNotExist},
+
+	// 2302 Object exists
+	{services.ErrDomainExists, epplib.StatusObjectExists},
+	{entities.ErrDomainAlreadyExists, epplib.StatusObjectExists},
+	{entities.ErrHostAlreadyExists, epplib.StatusObjectExists},
+	{entities.ErrContactAlreadyExists, epplib.StatusObjectExists},
+
+	// 2202 Invalid authorization information
+	{ErrAuthInfoMismatch, epplib.StatusInvalidAuthorizationInformation},
+	{entities.ErrTransferAuthInfoMismatch, epplib.StatusInvalidAuthorizationInformation},
+
+	// 2200 Authentication error
+	{entities.ErrEPPAuthenticationFailed, epplib.StatusAuthenticationError},
+	{entities.ErrEPPPasswordExpired, epplib.StatusAuthenticationError},
+	{entities.ErrEPPLoginLocked, epplib.StatusAuthenticationError},
+
+	// 2201 Authorization error
+	{entities.ErrRegistrarStatusPreventsCreate, epplib.StatusAuthorizationError},
+	{services.ErrRegistrarNotAccredited, epplib.StatusAuthorizationError},
+	{entities.ErrInvalidRegistrar, epplib.StatusAuthorizationError},
+	{entities.ErrAllocationTokenMismatch, epplib.StatusAuthorizationError},
+	{entities.ErrAllocationTokenExpired, epplib.StatusAuthorizationError},
+	{entities.ErrAllocationTokenRedeemed, epplib.StatusAuthorizationError},
+
+	// 2305 Object association prohibits operation
+	{entities.ErrHostSponsorMismatch, epplib.StatusObjectAssociationProhibitsOperation},
+	{entities.ErrHostIsLinked, epplib.StatusObjectAssociationProhibitsOperation},
+	{entities.ErrContactIsLinked, epplib.StatusObjectAssociationProhibitsOperation},
+
+	// 2304 Object status prohibits operation
+	{entities.ErrDomainUpdateNotAllowed, epplib.StatusObjectStatusProhibitsOperation},
+	{entities.ErrDomainDeleteNotAllowed, epplib.StatusObjectStatusProhibitsOperation},
+	{entities.ErrDomainStatusProhibitsRenewal, epplib.StatusObjectStatusProhibitsOperation},
+	{entities.ErrDomainRenewNotAllowed, epplib.StatusObjectStatusProhibitsOperation},
+	{entities.ErrDomainRestoreNotAllowed, epplib.StatusObjectStatusProhibitsOperation},
+	{entities.ErrDomainTransferNotAllowed, epplib.StatusObjectStatusProhibitsOperation},
+	{entities.ErrHostUpdateProhibited, epplib.StatusObjectStatusProhibitsOperation},
+	{entities.ErrHostDeleteProhibited, epplib.StatusObjectStatusProhibitsOperation},
+	{entities.ErrContactUpdateNotAllowed, epplib.StatusObjectStatusProhibitsOperation},
+	{entities.ErrContactDeleteNotAllowed, epplib.StatusObjectStatusProhibitsOperation},
+	{entities.ErrDomainApplicationFinal, epplib.StatusObjectStatusProhibitsOperation},
+	{entities.ErrInvalidApplicationStatus, epplib.StatusObjectStatusProhibitsOperation},
+	{entities.ErrRestoreReportNotAllowed, epplib.StatusObjectStatusProhibitsOperation},
+
+	// 2004 Parameter value range error
+	{ErrInvalidPeriod, epplib.StatusValueRangeError},
+	{ErrCurExpDateMismatch, epplib.StatusValueRangeError},
+	{entities.ErrInvalidTransferPeriod, epplib.StatusValueRangeError},
+	{entities.ErrDomainRenewExceedsMaxHorizon, epplib.StatusValueRangeError},
+	{entities.ErrZeroRenewalPeriod, epplib.StatusValueRangeError},
+	{entities.ErrMaxHostsPerDomainExceeded, epplib.StatusValueRangeError},
+	{entities.ErrMaxAddressesPerHostExceeded, epplib.StatusValueRangeError},
+	{entities.ErrMaxDSDataPerDomainExceeded, epplib.StatusValueRangeError},
+	{entities.ErrInvalidMaxSigLife, epplib.StatusValueRangeError},
+	{entities.ErrFeeMismatch, epplib.StatusValueRangeError},
+	{entities.ErrInvalidNumberOfYears, epplib.StatusValueRangeError},
+
+	// 2306 Parameter value policy error
+	{entities.ErrInvalidEPPPassword, epplib.StatusParameterPolicyError},
+	{entities.ErrEPPPasswordReused, epplib.StatusParameterPolicyError},
+	{ErrInvalidLoginSecPassword, epplib.StatusParameterPolicyError},
+	{ErrServerStatusNotAllowed, epplib.StatusParameterPolicyError},
+	{services.ErrDomainBlocked, epplib.StatusParameterPolicyError},
+	{entities.ErrLabelNotValidInPhase, epplib.StatusParameterPolicyError},
+	{entities.ErrContactDataPolicyViolation, epplib.StatusParameterPolicyError},
+	{entities.ErrRegistrantIDRequiredButNotSet, epplib.StatusParameterPolicyError},
+	{entities.ErrAdminIDRequiredButNotSet, epplib.StatusParameterPolicyError},
+	{entities.ErrTechIDRequiredButNotSet, epplib.StatusParameterPolicyError},
+	{entities.ErrBillingIDRequiredButNotSet, epplib.StatusParameterPolicyError},
+	{entities.ErrInBailiwickHostsMustHaveAddress, epplib.StatusParameterPolicyError},
+	{entities.ErrPhaseNotFound, epplib.StatusParameterPolicyError},
+	{entities.ErrNoActivePhase, epplib.StatusParameterPolicyError},
+	{ErrSecDNSKeyDataNotSupported, epplib.StatusParameterPolicyError},
+	{entities.ErrUnsupportedDSDigestType, epplib.StatusParameterPolicyError},
+	{entities.ErrDuplicateDSData, epplib.StatusParameterPolicyError},
+	{entities.ErrDSDataNotFound, epplib.StatusParameterPolicyError},
+	{entities.ErrDuplicateHost, epplib.StatusParameterPolicyError},
+	{ErrUnsupportedFeeCommand, epplib.StatusParameterPolicyError},
+	{services.ErrMissingFXRate, epplib.StatusParameterPolicyError},
+	{entities.ErrInvalidPhaseName, epplib.StatusParameterPolicyError},
+	{entities.ErrInvalidClaimsNotice, epplib.StatusParameterPolicyError},
+	{services.ErrPhaseNotApplicationBased, epplib.StatusParameterPolicyError},
+	{ErrMultipleMarksNotSupported, epplib.StatusParameterPolicyError},
+	{ErrApplicationUpdateNotSupported, epplib.StatusParameterPolicyError},
+	{ErrRestoreUpdateNotSupported, epplib.StatusParameterPolicyError},
+	{entities.ErrInvalidRestoreReport, epplib.StatusParameterPolicyError},
+
+	// 2105 Object is not eligible for renewal
+	{entities.ErrInvalidRenewal, epplib.StatusNotEligibleForRenewal},
+
+	// 2005 Parameter value syntax error
+	{ErrInvalidRestoreOp, epplib.StatusValueSyntaxError},
+	{entities.ErrInvalidDomainName, epplib.StatusValueSyntaxError},
+	{entities.ErrInvalidLabelLength, epplib.StatusValueSyntaxError},
+	{entities.ErrInvalidLabelDash, epplib.StatusValueSyntaxError},
+	{entities.ErrInvalidLabelDoubleDash, epplib.StatusValueSyntaxError},
+	{entities.ErrInvalidLabelIDN, epplib.StatusValueSyntaxError},
+	{entities.ErrLabelContainsInvalidCharacter, epplib.StatusValueSyntaxError},
+	{entities.ErrInvalidAuthInfo, epplib.StatusValueSyntaxError},
+	{entities.ErrInvalidDomainStatus, epplib.StatusValueSyntaxError},
+	{entities.ErrInvalidDomainStatusCombination, epplib.StatusValueSyntaxError},
+	{entities.ErrInvalidHostStatus, epplib.StatusValueSyntaxError},
+	{entities.ErrHostStatusIncompatible, epplib.StatusValueSyntaxError},
+	{entities.ErrInvalidContactStatus, epplib.StatusValueSyntaxError},
+	{entities.ErrInvalidContactStatusCombination, epplib.StatusValueSyntaxError},
+	{entities.ErrInvalidContactPostalInfo, epplib.StatusValueSyntaxError},
+	{entities.ErrInvalidPostalInfoEnumType, epplib.StatusValueSyntaxError},
+	{entities.ErrInvalidPostalLineType, epplib.StatusValueSyntaxError},
+	{entities.ErrInvalidOptPostalLineType, epplib.StatusValueSyntaxError},
+	{entities.ErrInvalidPCType, epplib.StatusValueSyntaxError},
+	{entities.ErrInvalidCountryCode, epplib.StatusValueSyntaxError},
+	{entities.ErrInvalidStreetCount, epplib.StatusValueSyntaxError},
+	{entities.ErrInvalidE164Type, epplib.StatusValueSyntaxError},
+	{entities.ErrInvalidEmail, epplib.StatusValueSyntaxError},
+	{entities.ErrInvalidClIDType, epplib.StatusValueSyntaxError},
+	{entities.ErrInvalidDomain, epplib.StatusValueSyntaxError},
+	{entities.ErrInvalidHost, epplib.StatusValueSyntaxError},
+	{entities.ErrInvalidContact, epplib.StatusValueSyntaxError},
+	{entities.ErrInvalidIP, epplib.StatusValueSyntaxError},
+	{entities.ErrInvalidDSData, epplib.StatusValueSyntaxError},
+	{ErrInvalidFeeAmount, epplib.StatusValueSyntaxError},
+	{ErrInvalidMark, epplib.StatusValueSyntaxError},
+	{entities.ErrUnknownCurrency, epplib.StatusValueSyntaxError},
+}
+
+// ResultCodeFromError maps an error to the corresponding EPP result code.
+// If the error is not known, StatusCommandFailed (2400) is returned.
+func ResultCodeFromError(err error) int {
+	if err == nil {
+		return epplib.StatusSuccess
+	}
+	for _, m := range errorCodeMappings {
+		if errors.Is(err, m.err) {
+			return m.code
+		}
+	}
+	return epplib.StatusCommandFailed
+}
@@ -0,0 +1,57 @@
+package epp
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	epplib "github.com/dotse/epp-lib"
+	"github.com/onasunnymorning/domain-os/internal/application/services"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultCodeFromError(t *testing.T) {
+	tc := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, epplib.StatusSuccess},
+		{"unknown", errors.New("boom"), epplib.StatusCommandFailed},
+		{"not found", entities.ErrDomainNotFound, epplib.StatusObjectDoesNotExist},
+		{"wrapped not found", fmt.Errorf("lookup failed: %w", entities.ErrHostNotFound), epplib.StatusObjectDoesNotExist},
+		{"exists joined with invalid domain", errors.Join(entities.ErrInvalidDomain, services.ErrDomainExists), epplib.StatusObjectExists},
+		{"blocked joined with invalid domain", errors.Join(entities.ErrInvalidDomain, services.ErrDomainBlocked), epplib.StatusParameterPolicyError},
+		{"label not valid in phase", errors.Join(entities.ErrInvalidDomain, entities.ErrLabelNotValidInPhase), epplib.StatusParameterPolicyError},
+		{"invalid renewal not found", errors.Join(entities.ErrInvalidRenewal, entities.ErrDomainNotFound), epplib.StatusObjectDoesNotExist},
+		{"invalid renewal wrong registrar", errors.Join(entities.ErrInvalidRenewal, entities.ErrInvalidRegistrar), epplib.StatusAuthorizationError},
+		{"invalid renewal", entities.ErrInvalidRenewal, epplib.StatusNotEligibleForRenewal},
+		{"max horizon", errors.Join(entities.ErrInvalidRenewal, entities.ErrDomainRenewExceedsMaxHorizon), epplib.StatusValueRangeError},
+		{"update prohibited", entities.ErrDomainUpdateNotAllowed, epplib.StatusObjectStatusProhibitsOperation},
+		{"delete prohibited", entities.ErrDomainDeleteNotAllowed, epplib.StatusObjectStatusProhibitsOperation},
+		{"not accredited", services.ErrRegistrarNotAccredited, epplib.StatusAuthorizationError},
+		{"host sponsor mismatch", entities.ErrHostSponsorMismatch, epplib.StatusObjectAssociationProhibitsOperation},
+		{"host is linked", errors.Join(entities.ErrHostIsLinked, errors.New("in use")), epplib.StatusObjectAssociationProhibitsOperation},
+		{"contact is linked", errors.Join(entities.ErrContactIsLinked, errors.New("in use")), epplib.StatusObjectAssociationProhibitsOperation},
+		{"contact delete prohibited", entities.ErrContactDeleteNotAllowed, epplib.StatusObjectStatusProhibitsOperation},
+		{"invalid contact postalinfo", errors.Join(entities.ErrInvalidContact, entities.ErrInvalidContactPostalInfo), epplib.StatusValueSyntaxError},
+		{"missing contact id", ErrMissingContactID, epplib.StatusMissingParameter},
+		{"invalid domain name", errors.Join(entities.ErrInvalidDomain, entities.ErrInvalidLabelLength), epplib.StatusValueSyntaxError},
+		{"not logged in", ErrNotLoggedIn, epplib.StatusCommandUseError},
+		{"already logged in", ErrAlreadyLoggedIn, epplib.StatusCommandUseError},
+		{"authentication failed", entities.ErrEPPAuthenticationFailed, epplib.StatusAuthenticationError},
+		{"registrar status prevents create", errors.Join(entities.ErrRegistrarStatusPreventsCreate, errors.New("registrar status: readonly")), epplib.StatusAuthorizationError},
+		{"unsupported object service", ErrUnsupportedObjectService, epplib.StatusUnimplementedObjectService},
+		{"authinfo mismatch", ErrAuthInfoMismatch, epplib.StatusInvalidAuthorizationInformation},
+		{"invalid command", ErrInvalidCommand, epplib.StatusCommandSyntaxError},
+		{"poll message not found", errors.Join(entities.ErrPollMessageNotFound, errors.New("msgID: 1")), epplib.StatusObjectDoesNotExist},
+		{"missing msgID", ErrMissingMsgID, epplib.StatusMissingParameter},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ResultCodeFromError(tt.err))
+		})
+	}
+}
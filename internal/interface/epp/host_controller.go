@@ -0,0 +1,290 @@
+package epp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/beevik/etree"
+	epplib "github.com/dotse/epp-lib"
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+	"github.com/onasunnymorning/domain-os/internal/application/interfaces"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// HostController handles the RFC 5732 host commands.
+// Host names are unique per registrar, so all lookups are scoped to the ClID of the session.
+type HostController struct {
+	hostService interfaces.HostService
+}
+
+// NewHostController creates a new HostController and binds the host commands to the provided CommandMux
+func NewHostController(mux *epplib.CommandMux, hostService interfaces.HostService) *HostController {
+	controller := &HostController{
+		hostService: hostService,
+	}
+
+	ns := epplib.NamespaceIETFHost10.String()
+	mux.BindCommand("check", ns, controller.Check)
+	mux.BindCommand("info", ns, controller.Info)
+	mux.BindCommand("create", ns, controller.Create)
+	mux.BindCommand("update", ns, controller.Update)
+	mux.BindCommand("delete", ns, controller.Delete)
+
+	return controller
+}
+
+// Check handles the host <check> command
+func (ctrl *HostController) Check(ctx context.Context, rw epplib.Writer, doc *etree.Document) {
+	var cmd HostCheckCommand
+	if err := unmarshalCommand(doc, &cmd); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	clID, err := clIDFromContext(ctx)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	if len(cmd.Names) == 0 {
+		writeResponse(ctx, rw, NewErrorResponse(ErrMissingHostName, cmd.ClTRID))
+		return
+	}
+
+	chkData := NewHostChkData()
+	for _, name := range cmd.Names {
+		_, err := ctrl.hostService.GetHostByNameAndClID(ctx, name, clID)
+		if err != nil {
+			if errors.Is(err, entities.ErrHostNotFound) {
+				chkData.Add(name, true, "")
+				continue
+			}
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+		chkData.Add(name, false, entities.ErrHostAlreadyExists.Error())
+	}
+
+	writeResponse(ctx, rw, NewResponse(epplib.StatusSuccess, cmd.ClTRID).WithResData(chkData))
+}
+
+// Info handles the host <info> command
+func (ctrl *HostController) Info(ctx context.Context, rw epplib.Writer, doc *etree.Document) {
+	var cmd HostInfoCommand
+	if err := unmarshalCommand(doc, &cmd); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	clID, err := clIDFromContext(ctx)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	if cmd.Name == "" {
+		writeResponse(ctx, rw, NewErrorResponse(ErrMissingHostName, cmd.ClTRID))
+		return
+	}
+
+	h, err := ctrl.hostService.GetHostByNameAndClID(ctx, cmd.Name, clID)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+
+	writeResponse(ctx, rw, NewResponse(epplib.StatusSuccess, cmd.ClTRID).WithResData(NewHostInfData(h)))
+}
+
+// Create handles the host <create> command
+func (ctrl *HostController) Create(ctx context.Context, rw epplib.Writer, doc *etree.Document) {
+	var cmd HostCreateCommand
+	if err := unmarshalCommand(doc, &cmd); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	clID, err := clIDForCreateFromContext(ctx)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	if cmd.Name == "" {
+		writeResponse(ctx, rw, NewErrorResponse(ErrMissingHostName, cmd.ClTRID))
+		return
+	}
+
+	createCmd := &commands.CreateHostCommand{
+		Name: cmd.Name,
+		ClID: entities.ClIDType(clID),
+		CrRr: entities.ClIDType(clID),
+	}
+	for _, a := range cmd.Addrs {
+		if err := a.Validate(); err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+		createCmd.Addresses = append(createCmd.Addresses, a.Value)
+	}
+
+	h, err := ctrl.hostService.CreateHost(ctx, createCmd)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+
+	writeResponse(ctx, rw, NewResponse(epplib.StatusSuccess, cmd.ClTRID).WithResData(NewHostCreData(h)))
+}
+
+// Update handles the host <update> command.
+// The changes are applied in the following order:
+//  1. remove statuses, this allows a client to remove clientUpdateProhibited and make other changes in the same command
+//  2. remove and add addresses
+//  3. change the name and add statuses, this allows a client to make changes and set clientUpdateProhibited in the same command
+func (ctrl *HostController) Update(ctx context.Context, rw epplib.Writer, doc *etree.Document) {
+	var cmd HostUpdateCommand
+	if err := unmarshalCommand(doc, &cmd); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	clID, err := clIDFromContext(ctx)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	if cmd.Name == "" {
+		writeResponse(ctx, rw, NewErrorResponse(ErrMissingHostName, cmd.ClTRID))
+		return
+	}
+	if cmd.Add == nil {
+		cmd.Add = &HostAddRem{}
+	}
+	if cmd.Rem == nil {
+		cmd.Rem = &HostAddRem{}
+	}
+
+	// Clients can only manipulate client statuses
+	for _, s := range slices.Concat(cmd.Add.Statuses, cmd.Rem.Statuses) {
+		if !s.IsClientStatus() {
+			writeResponse(ctx, rw, NewErrorResponse(errors.Join(ErrServerStatusNotAllowed, fmt.Errorf("status: %s", s.S)), cmd.ClTRID))
+			return
+		}
+	}
+	for _, a := range slices.Concat(cmd.Add.Addrs, cmd.Rem.Addrs) {
+		if err := a.Validate(); err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+	}
+
+	h, err := ctrl.hostService.GetHostByNameAndClID(ctx, cmd.Name, clID)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+
+	// 1. Remove statuses
+	if len(cmd.Rem.Statuses) > 0 {
+		for _, s := range cmd.Rem.Statuses {
+			if err := h.UnsetStatus(s.S); err != nil {
+				writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+				return
+			}
+		}
+		h.UpRr = entities.ClIDType(clID)
+		if _, err := ctrl.hostService.UpdateHost(ctx, h); err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+	}
+
+	// If the host still can't be updated, only status changes are allowed (which are validated by the host entity)
+	hasAddrChanges := len(cmd.Add.Addrs) > 0 || len(cmd.Rem.Addrs) > 0
+	hasNameChange := cmd.Chg != nil && cmd.Chg.Name != "" && cmd.Chg.Name != h.Name.String()
+	if (hasAddrChanges || hasNameChange) && !h.CanBeUpdated() {
+		writeResponse(ctx, rw, NewErrorResponse(entities.ErrHostUpdateProhibited, cmd.ClTRID))
+		return
+	}
+
+	// 2. Remove and add addresses
+	roid := h.RoID.String()
+	for _, a := range cmd.Rem.Addrs {
+		if _, err := ctrl.hostService.RemoveHostAddress(ctx, roid, a.Value); err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+	}
+	for _, a := range cmd.Add.Addrs {
+		if _, err := ctrl.hostService.AddHostAddress(ctx, roid, a.Value); err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+	}
+
+	// 3. Change the name and add statuses
+	if !hasNameChange && len(cmd.Add.Statuses) == 0 {
+		writeResponse(ctx, rw, NewResponse(epplib.StatusSuccess, cmd.ClTRID))
+		return
+	}
+	if hasNameChange {
+		name, err := entities.NewDomainName(cmd.Chg.Name)
+		if err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(errors.Join(entities.ErrInvalidHost, err), cmd.ClTRID))
+			return
+		}
+		// Host names are unique per registrar
+		_, err = ctrl.hostService.GetHostByNameAndClID(ctx, name.String(), clID)
+		if err == nil {
+			writeResponse(ctx, rw, NewErrorResponse(entities.ErrHostAlreadyExists, cmd.ClTRID))
+			return
+		}
+		if !errors.Is(err, entities.ErrHostNotFound) {
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+		h.Name = *name
+	}
+	for _, s := range cmd.Add.Statuses {
+		if err := h.SetStatus(s.S); err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+	}
+	h.UpRr = entities.ClIDType(clID)
+	if _, err := ctrl.hostService.UpdateHost(ctx, h); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+
+	writeResponse(ctx, rw, NewResponse(epplib.StatusSuccess, cmd.ClTRID))
+}
+
+// Delete handles the host <delete> command.
+// Hosts that are linked to a domain can't be deleted (2305)
+func (ctrl *HostController) Delete(ctx context.Context, rw epplib.Writer, doc *etree.Document) {
+	var cmd HostDeleteCommand
+	if err := unmarshalCommand(doc, &cmd); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	clID, err := clIDFromContext(ctx)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	if cmd.Name == "" {
+		writeResponse(ctx, rw, NewErrorResponse(ErrMissingHostName, cmd.ClTRID))
+		return
+	}
+
+	h, err := ctrl.hostService.GetHostByNameAndClID(ctx, cmd.Name, clID)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+
+	if err := ctrl.hostService.DeleteHostByRoID(ctx, h.RoID.String()); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+
+	writeResponse(ctx, rw, NewResponse(epplib.StatusSuccess, cmd.ClTRID))
+}
@@ -0,0 +1,266 @@
+package epp
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockHostService is a mock implementation of the HostService
+type MockHostService struct {
+	mock.Mock
+}
+
+func (m *MockHostService) CreateHost(ctx context.Context, cmd *commands.CreateHostCommand) (*entities.Host, error) {
+	args := m.Called(ctx, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Host), args.Error(1)
+}
+
+func (m *MockHostService) GetHostByRoID(ctx context.Context, roid string) (*entities.Host, error) {
+	args := m.Called(ctx, roid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Host), args.Error(1)
+}
+
+func (m *MockHostService) GetHostByNameAndClID(ctx context.Context, name, clid string) (*entities.Host, error) {
+	args := m.Called(ctx, name, clid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Host), args.Error(1)
+}
+
+func (m *MockHostService) DeleteHostByRoID(ctx context.Context, roid string) error {
+	return m.Called(ctx, roid).Error(0)
+}
+
+func (m *MockHostService) UpdateHost(ctx context.Context, h *entities.Host) (*entities.Host, error) {
+	args := m.Called(ctx, h)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Host), args.Error(1)
+}
+
+func (m *MockHostService) ListHosts(ctx context.Context, params queries.ListItemsQuery) ([]*entities.Host, string, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]*entities.Host), args.String(1), args.Error(2)
+}
+
+func (m *MockHostService) AddHostAddress(ctx context.Context, roid, ip string) (*entities.Host, error) {
+	args := m.Called(ctx, roid, ip)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Host), args.Error(1)
+}
+
+func (m *MockHostService) RemoveHostAddress(ctx context.Context, roid, ip string) (*entities.Host, error) {
+	args := m.Called(ctx, roid, ip)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Host), args.Error(1)
+}
+
+func (m *MockHostService) BulkCreate(ctx context.Context, cmds []*commands.CreateHostCommand) error {
+	return m.Called(ctx, cmds).Error(0)
+}
+
+// getTestHost returns a linked host sponsored by ClID-1
+func getTestHost() *entities.Host {
+	return &entities.Host{
+		RoID:      "12345_HOST-APEX",
+		Name:      "ns1.example.com",
+		Addresses: []netip.Addr{netip.MustParseAddr("192.0.2.2"), netip.MustParseAddr("2001:db8::1")},
+		ClID:      "ClID-1",
+		CrRr:      "ClID-1",
+		CreatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		Status:    entities.HostStatus{OK: true, Linked: true},
+	}
+}
+
+func TestHostController_Check(t *testing.T) {
+	svc := new(MockHostService)
+	ctrl := &HostController{hostService: svc}
+
+	svc.On("GetHostByNameAndClID", mock.Anything, "ns1.example.com", "ClID-1").Return(getTestHost(), nil)
+	svc.On("GetHostByNameAndClID", mock.Anything, "ns2.example.com", "ClID-1").Return(nil, entities.ErrHostNotFound)
+
+	w := &testWriter{}
+	ctrl.Check(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<check><host:check xmlns:host="urn:ietf:params:xml:ns:host-1.0"><host:name>ns1.example.com</host:name><host:name>ns2.example.com</host:name></host:check></check>`)))
+
+	s := w.String()
+	require.Contains(t, s, `<result code="1000">`)
+	require.Contains(t, s, `<host:chkData xmlns:host="urn:ietf:params:xml:ns:host-1.0">`)
+	require.Contains(t, s, `<host:name avail="0">ns1.example.com</host:name><host:reason>host already exists - hostnames must be unique for every registrar</host:reason>`)
+	require.Contains(t, s, `<host:name avail="1">ns2.example.com</host:name>`)
+}
+
+func TestHostController_Info(t *testing.T) {
+	svc := new(MockHostService)
+	ctrl := &HostController{hostService: svc}
+	svc.On("GetHostByNameAndClID", mock.Anything, "ns1.example.com", "ClID-1").Return(getTestHost(), nil)
+
+	w := &testWriter{}
+	ctrl.Info(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<info><host:info xmlns:host="urn:ietf:params:xml:ns:host-1.0"><host:name>ns1.example.com</host:name></host:info></info>`)))
+
+	s := w.String()
+	require.Contains(t, s, `<result code="1000">`)
+	require.Contains(t, s, `<host:name>ns1.example.com</host:name><host:roid>12345_HOST-APEX</host:roid><host:status s="ok"></host:status><host:status s="linked"></host:status>`)
+	require.Contains(t, s, `<host:addr ip="v4">192.0.2.2</host:addr><host:addr ip="v6">2001:db8::1</host:addr>`)
+	require.Contains(t, s, `<host:clID>ClID-1</host:clID><host:crID>ClID-1</host:crID><host:crDate>2023-01-01T00:00:00.0Z</host:crDate>`)
+}
+
+func TestHostController_Info_NotFound(t *testing.T) {
+	svc := new(MockHostService)
+	ctrl := &HostController{hostService: svc}
+	svc.On("GetHostByNameAndClID", mock.Anything, "ns1.example.com", "ClID-2").Return(nil, entities.ErrHostNotFound)
+
+	w := &testWriter{}
+	ctrl.Info(newTestContext("ClID-2"), w, newTestDoc(t, eppCommand(`<info><host:info xmlns:host="urn:ietf:params:xml:ns:host-1.0"><host:name>ns1.example.com</host:name></host:info></info>`)))
+
+	require.Equal(t, 2303, decodeResultCode(t, w.Bytes()))
+}
+
+func TestHostController_Create(t *testing.T) {
+	svc := new(MockHostService)
+	ctrl := &HostController{hostService: svc}
+
+	svc.On("CreateHost", mock.Anything, mock.MatchedBy(func(cmd *commands.CreateHostCommand) bool {
+		return cmd.Name == "ns1.example.com" &&
+			cmd.ClID == "ClID-1" &&
+			cmd.CrRr == "ClID-1" &&
+			len(cmd.Addresses) == 2 && cmd.Addresses[0] == "192.0.2.2" && cmd.Addresses[1] == "2001:db8::1"
+	})).Return(getTestHost(), nil)
+
+	w := &testWriter{}
+	ctrl.Create(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<create><host:create xmlns:host="urn:ietf:params:xml:ns:host-1.0"><host:name>ns1.example.com</host:name><host:addr ip="v4">192.0.2.2</host:addr><host:addr ip="v6">2001:db8::1</host:addr></host:create></create>`)))
+
+	s := w.String()
+	require.Contains(t, s, `<result code="1000">`)
+	require.Contains(t, s, `<host:creData xmlns:host="urn:ietf:params:xml:ns:host-1.0"><host:name>ns1.example.com</host:name><host:crDate>2023-01-01T00:00:00.0Z</host:crDate></host:creData>`)
+	svc.AssertExpectations(t)
+}
+
+func TestHostController_Create_Errors(t *testing.T) {
+	tc := []struct {
+		name     string
+		body     string
+		wantCode int
+	}{
+		{"missing name", `<host:addr ip="v4">192.0.2.2</host:addr>`, 2003},
+		{"invalid address", `<host:name>ns1.example.com</host:name><host:addr ip="v4">not-an-ip</host:addr>`, 2005},
+		{"ip version mismatch", `<host:name>ns1.example.com</host:name><host:addr ip="v6">192.0.2.2</host:addr>`, 2005},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockHostService)
+			ctrl := &HostController{hostService: svc}
+
+			w := &testWriter{}
+			ctrl.Create(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<create><host:create xmlns:host="urn:ietf:params:xml:ns:host-1.0">`+tt.body+`</host:create></create>`)))
+
+			require.Equal(t, tt.wantCode, decodeResultCode(t, w.Bytes()))
+			svc.AssertNotCalled(t, "CreateHost", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestHostController_Update(t *testing.T) {
+	svc := new(MockHostService)
+	ctrl := &HostController{hostService: svc}
+
+	h := getTestHost()
+	h.Status = entities.HostStatus{ClientUpdateProhibited: true, Linked: true}
+	svc.On("GetHostByNameAndClID", mock.Anything, "ns1.example.com", "ClID-1").Return(h, nil)
+	svc.On("GetHostByNameAndClID", mock.Anything, "ns2.example.com", "ClID-1").Return(nil, entities.ErrHostNotFound)
+	svc.On("RemoveHostAddress", mock.Anything, "12345_HOST-APEX", "192.0.2.2").Return(h, nil)
+	svc.On("AddHostAddress", mock.Anything, "12345_HOST-APEX", "192.0.2.22").Return(h, nil)
+	svc.On("UpdateHost", mock.Anything, mock.Anything).Return(h, nil)
+
+	w := &testWriter{}
+	ctrl.Update(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<update><host:update xmlns:host="urn:ietf:params:xml:ns:host-1.0">
+<host:name>ns1.example.com</host:name>
+<host:add><host:addr ip="v4">192.0.2.22</host:addr><host:status s="clientDeleteProhibited"/></host:add>
+<host:rem><host:addr ip="v4">192.0.2.2</host:addr><host:status s="clientUpdateProhibited"/></host:rem>
+<host:chg><host:name>ns2.example.com</host:name></host:chg>
+</host:update></update>`)))
+
+	require.Contains(t, w.String(), `<result code="1000">`)
+	require.Equal(t, "ns2.example.com", h.Name.String())
+	require.Equal(t, entities.ClIDType("ClID-1"), h.UpRr)
+	require.True(t, h.Status.ClientDeleteProhibited)
+	require.False(t, h.Status.ClientUpdateProhibited)
+	svc.AssertExpectations(t)
+}
+
+func TestHostController_Update_Errors(t *testing.T) {
+	tc := []struct {
+		name     string
+		status   entities.HostStatus
+		body     string
+		wantCode int
+	}{
+		{"server status", entities.HostStatus{OK: true}, `<host:add><host:status s="serverUpdateProhibited"/></host:add>`, 2306},
+		{"invalid address", entities.HostStatus{OK: true}, `<host:add><host:addr ip="v4">2001:db8::2</host:addr></host:add>`, 2005},
+		{"update prohibited", entities.HostStatus{ClientUpdateProhibited: true}, `<host:add><host:addr ip="v4">192.0.2.22</host:addr></host:add>`, 2304},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockHostService)
+			ctrl := &HostController{hostService: svc}
+			h := getTestHost()
+			h.Status = tt.status
+			svc.On("GetHostByNameAndClID", mock.Anything, "ns1.example.com", "ClID-1").Return(h, nil)
+
+			w := &testWriter{}
+			ctrl.Update(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<update><host:update xmlns:host="urn:ietf:params:xml:ns:host-1.0"><host:name>ns1.example.com</host:name>`+tt.body+`</host:update></update>`)))
+
+			require.Equal(t, tt.wantCode, decodeResultCode(t, w.Bytes()))
+			svc.AssertNotCalled(t, "AddHostAddress", mock.Anything, mock.Anything, mock.Anything)
+			svc.AssertNotCalled(t, "UpdateHost", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestHostController_Delete(t *testing.T) {
+	tc := []struct {
+		name      string
+		deleteErr error
+		wantCode  int
+	}{
+		{"success", nil, 1000},
+		{"linked", errors.Join(entities.ErrHostIsLinked, errors.New("associated with 1 domain(s)")), 2305},
+		{"delete prohibited", entities.ErrHostDeleteProhibited, 2304},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockHostService)
+			ctrl := &HostController{hostService: svc}
+			svc.On("GetHostByNameAndClID", mock.Anything, "ns1.example.com", "ClID-1").Return(getTestHost(), nil)
+			svc.On("DeleteHostByRoID", mock.Anything, "12345_HOST-APEX").Return(tt.deleteErr)
+
+			w := &testWriter{}
+			ctrl.Delete(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<delete><host:delete xmlns:host="urn:ietf:params:xml:ns:host-1.0"><host:name>ns1.example.com</host:name></host:delete></delete>`)))
+
+			require.Equal(t, tt.wantCode, decodeResultCode(t, w.Bytes()))
+		})
+	}
+}
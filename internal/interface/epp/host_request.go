@@ -0,0 +1,97 @@
+package epp
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// The structs in this file are used to unmarshal the RFC 5732 host commands.
+// Ref: https://datatracker.ietf.org/doc/html/rfc5732#section-3
+
+// HostAddr is the <host:addr> element
+type HostAddr struct {
+	IP    string `xml:"ip,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Validate checks the address is a valid IP address of the version indicated by the ip attribute (v4 is the default)
+func (a HostAddr) Validate() error {
+	ip, err := netip.ParseAddr(a.Value)
+	if err != nil {
+		return errors.Join(entities.ErrInvalidIP, err)
+	}
+	switch a.IP {
+	case "v4", "":
+		if !ip.Is4() {
+			return errors.Join(entities.ErrInvalidIP, fmt.Errorf("%s is not an IPv4 address", a.Value))
+		}
+	case "v6":
+		if !ip.Is6() {
+			return errors.Join(entities.ErrInvalidIP, fmt.Errorf("%s is not an IPv6 address", a.Value))
+		}
+	default:
+		return errors.Join(entities.ErrInvalidIP, fmt.Errorf("invalid ip attribute: %s", a.IP))
+	}
+	return nil
+}
+
+// HostStatusValue is the <host:status> element
+type HostStatusValue struct {
+	S     string `xml:"s,attr"`
+	Lang  string `xml:"lang,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+// IsClientStatus returns true if the status can be set by the client (client* statuses)
+func (s HostStatusValue) IsClientStatus() bool {
+	return strings.HasPrefix(s.S, "client")
+}
+
+// HostCheckCommand is the <check> command for hosts
+type HostCheckCommand struct {
+	Names  []string `xml:"command>check>check>name"`
+	ClTRID string   `xml:"command>clTRID"`
+}
+
+// HostInfoCommand is the <info> command for hosts
+type HostInfoCommand struct {
+	Name   string `xml:"command>info>info>name"`
+	ClTRID string `xml:"command>clTRID"`
+}
+
+// HostCreateCommand is the <create> command for hosts
+type HostCreateCommand struct {
+	Name   string     `xml:"command>create>create>name"`
+	Addrs  []HostAddr `xml:"command>create>create>addr"`
+	ClTRID string     `xml:"command>clTRID"`
+}
+
+// HostAddRem is the <host:add> and <host:rem> element of the update command
+type HostAddRem struct {
+	Addrs    []HostAddr        `xml:"addr"`
+	Statuses []HostStatusValue `xml:"status"`
+}
+
+// HostChg is the <host:chg> element of the update command
+type HostChg struct {
+	Name string `xml:"name"`
+}
+
+// HostUpdateCommand is the <update> command for hosts
+type HostUpdateCommand struct {
+	Name   string      `xml:"command>update>update>name"`
+	Add    *HostAddRem `xml:"command>update>update>add"`
+	Rem    *HostAddRem `xml:"command>update>update>rem"`
+	Chg    *HostChg    `xml:"command>update>update>chg"`
+	ClTRID string      `xml:"command>clTRID"`
+}
+
+// HostDeleteCommand is the <delete> command for hosts
+type HostDeleteCommand struct {
+	Name   string `xml:"command>delete>delete>name"`
+	ClTRID string `xml:"command>clTRID"`
+}
@@ -0,0 +1,113 @@
+package epp
+
+import (
+	"encoding/xml"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// The structs in this file are used to marshal the RFC 5732 <resData> elements.
+// Ref: https://datatracker.ietf.org/doc/html/rfc5732#section-3
+
+// HostChkData is the <host:chkData> element of the check response
+type HostChkData struct {
+	XMLName   xml.Name `xml:"host:chkData"`
+	XMLNSHost string   `xml:"xmlns:host,attr"`
+	CD        []HostCD `xml:"host:cd"`
+}
+
+// HostCD is a single <host:cd> element of the check response
+type HostCD struct {
+	Name   HostCheckName `xml:"host:name"`
+	Reason string        `xml:"host:reason,omitempty"`
+}
+
+// HostCheckName is the <host:name> element of the check response
+type HostCheckName struct {
+	Avail int    `xml:"avail,attr"`
+	Value string `xml:",chardata"`
+}
+
+// NewHostChkData creates a new empty HostChkData
+func NewHostChkData() *HostChkData {
+	return &HostChkData{XMLNSHost: HOST_NAMESPACE}
+}
+
+// Add adds a check result to the chkData
+func (c *HostChkData) Add(name string, available bool, reason string) {
+	cd := HostCD{Name: HostCheckName{Value: name}}
+	if available {
+		cd.Name.Avail = 1
+	} else {
+		cd.Reason = reason
+	}
+	c.CD = append(c.CD, cd)
+}
+
+// HostInfStatus is a <host:status> element of the info response
+type HostInfStatus struct {
+	S string `xml:"s,attr"`
+}
+
+// HostInfAddr is a <host:addr> element of the info response
+type HostInfAddr struct {
+	IP    string `xml:"ip,attr"`
+	Value string `xml:",chardata"`
+}
+
+// HostInfData is the <host:infData> element of the info response
+type HostInfData struct {
+	XMLName   xml.Name        `xml:"host:infData"`
+	XMLNSHost string          `xml:"xmlns:host,attr"`
+	Name      string          `xml:"host:name"`
+	RoID      string          `xml:"host:roid"`
+	Status    []HostInfStatus `xml:"host:status"`
+	Addrs     []HostInfAddr   `xml:"host:addr"`
+	ClID      string          `xml:"host:clID"`
+	CrID      string          `xml:"host:crID,omitempty"`
+	CrDate    string          `xml:"host:crDate,omitempty"`
+	UpID      string          `xml:"host:upID,omitempty"`
+	UpDate    string          `xml:"host:upDate,omitempty"`
+}
+
+// NewHostInfData creates a HostInfData from a host entity
+func NewHostInfData(h *entities.Host) *HostInfData {
+	inf := &HostInfData{
+		XMLNSHost: HOST_NAMESPACE,
+		Name:      h.Name.String(),
+		RoID:      h.RoID.String(),
+		ClID:      h.ClID.String(),
+		CrID:      h.CrRr.String(),
+		CrDate:    formatEPPDate(h.CreatedAt),
+		UpID:      h.UpRr.String(),
+		UpDate:    formatEPPDate(h.UpdatedAt),
+	}
+	for _, s := range h.Status.StringSlice() {
+		inf.Status = append(inf.Status, HostInfStatus{S: s})
+	}
+	for _, a := range h.Addresses {
+		addr := HostInfAddr{IP: "v4", Value: a.String()}
+		if a.Is6() {
+			addr.IP = "v6"
+		}
+		inf.Addrs = append(inf.Addrs, addr)
+	}
+	return inf
+}
+
+// HostCreData is the <host:creData> element of the create response
+type HostCreData struct {
+	XMLName   xml.Name `xml:"host:creData"`
+	XMLNSHost string   `xml:"xmlns:host,attr"`
+	Name      string   `xml:"host:name"`
+	CrDate    string   `xml:"host:crDate"`
+}
+
+// NewHostCreData creates a HostCreData from a host entity
+func NewHostCreData(h *entities.Host) *HostCreData {
+	return &HostCreData{
+		XMLNSHost: HOST_NAMESPACE,
+		Name:      h.Name.String(),
+		CrDate:    formatEPPDate(h.CreatedAt),
+	}
+}
@@ -0,0 +1,80 @@
+package epp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/beevik/etree"
+	epplib "github.com/dotse/epp-lib"
+	"github.com/onasunnymorning/domain-os/internal/application/interfaces"
+)
+
+// PollController handles the RFC 5730 <poll> command.
+// Registrars use it to retrieve and acknowledge messages about actions on their objects that they did not initiate themselves.
+type PollController struct {
+	pollService interfaces.PollService
+}
+
+// NewPollController creates a new PollController and binds the poll command to the provided CommandMux
+func NewPollController(mux *epplib.CommandMux, pollService interfaces.PollService) *PollController {
+	controller := &PollController{
+		pollService: pollService,
+	}
+
+	mux.Bind(epplib.NewXMLPathBuilder().AddOrphan("//command", EPP_NAMESPACE).Add("poll", EPP_NAMESPACE).String(), controller.Poll)
+
+	return controller
+}
+
+// Poll handles the <poll> command.
+// op="req" returns the oldest message in the queue without removing it, op="ack" removes the message with the provided msgID from the queue.
+func (ctrl *PollController) Poll(ctx context.Context, rw epplib.Writer, doc *etree.Document) {
+	var cmd PollCommand
+	if err := unmarshalCommand(doc, &cmd); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	clID, err := clIDFromContext(ctx)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+
+	switch cmd.Poll.Op {
+	case POLL_OP_REQ:
+		msg, count, err := ctrl.pollService.Peek(ctx, clID)
+		if err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+		if msg == nil {
+			writeResponse(ctx, rw, NewResponse(epplib.StatusNoMessage, cmd.ClTRID))
+			return
+		}
+		writeResponse(ctx, rw, NewResponse(epplib.StatusAckToDequeue, cmd.ClTRID).WithMsgQ(NewMsgQ(msg, count, true)))
+	case POLL_OP_ACK:
+		if cmd.Poll.MsgID == "" {
+			writeResponse(ctx, rw, NewErrorResponse(ErrMissingMsgID, cmd.ClTRID))
+			return
+		}
+		id, err := strconv.ParseInt(cmd.Poll.MsgID, 10, 64)
+		if err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(errors.Join(ErrInvalidMsgID, fmt.Errorf("msgID: %s", cmd.Poll.MsgID)), cmd.ClTRID))
+			return
+		}
+		next, count, err := ctrl.pollService.Ack(ctx, clID, id)
+		if err != nil {
+			writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+			return
+		}
+		response := NewResponse(epplib.StatusSuccess, cmd.ClTRID)
+		if next != nil {
+			response.WithMsgQ(NewMsgQ(next, count, false))
+		}
+		writeResponse(ctx, rw, response)
+	default:
+		writeResponse(ctx, rw, NewErrorResponse(errors.Join(ErrInvalidCommand, fmt.Errorf("unknown poll op: %s", cmd.Poll.Op)), cmd.ClTRID))
+	}
+}
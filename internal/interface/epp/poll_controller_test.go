@@ -0,0 +1,169 @@
+package epp
+
+import (
+	"context"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockPollService is a mock implementation of the PollService
+type MockPollService struct {
+	mock.Mock
+}
+
+func (m *MockPollService) Enqueue(ctx context.Context, msg *entities.PollMessage) (*entities.PollMessage, error) {
+	args := m.Called(ctx, msg)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PollMessage), args.Error(1)
+}
+
+func (m *MockPollService) Peek(ctx context.Context, clid string) (*entities.PollMessage, int64, error) {
+	args := m.Called(ctx, clid)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).(*entities.PollMessage), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockPollService) Ack(ctx context.Context, clid string, id int64) (*entities.PollMessage, int64, error) {
+	args := m.Called(ctx, clid, id)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).(*entities.PollMessage), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockPollService) GetByID(ctx context.Context, id int64) (*entities.PollMessage, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PollMessage), args.Error(1)
+}
+
+func (m *MockPollService) Delete(ctx context.Context, id int64) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *MockPollService) List(ctx context.Context, params queries.ListItemsQuery) ([]*entities.PollMessage, string, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]*entities.PollMessage), args.String(1), args.Error(2)
+}
+
+func (m *MockPollService) Count(ctx context.Context, filter queries.ListPollMessagesFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// getTestPollMessage returns a poll message queued for ClID-1
+func getTestPollMessage(id int64) *entities.PollMessage {
+	return &entities.PollMessage{
+		ID:              id,
+		ClID:            "ClID-1",
+		Msg:             "Domain example.com auto-renewed for 1 years",
+		DomainName:      "example.com",
+		TransactionType: entities.TransactionTypeAutoRenewal,
+		CreatedAt:       time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC),
+	}
+}
+
+// decodeMsgQ returns the msgQ element of the response
+func decodeMsgQ(t *testing.T, b []byte) *MsgQ {
+	t.Helper()
+	var r Response
+	require.NoError(t, xml.Unmarshal(b, &r))
+	return r.MsgQ
+}
+
+func TestPollController_Req(t *testing.T) {
+	svc := new(MockPollService)
+	ctrl := &PollController{pollService: svc}
+	svc.On("Peek", mock.Anything, "ClID-1").Return(getTestPollMessage(12345), int64(5), nil)
+
+	w := &testWriter{}
+	ctrl.Poll(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<poll op="req"/>`)))
+
+	require.Equal(t, 1301, decodeResultCode(t, w.Bytes()))
+	require.Contains(t, w.String(), `<msgQ count="5" id="12345"><qDate>2024-03-01T12:30:00.0Z</qDate><msg>Domain example.com auto-renewed for 1 years</msg></msgQ>`)
+}
+
+func TestPollController_Req_Empty(t *testing.T) {
+	svc := new(MockPollService)
+	ctrl := &PollController{pollService: svc}
+	svc.On("Peek", mock.Anything, "ClID-1").Return(nil, int64(0), nil)
+
+	w := &testWriter{}
+	ctrl.Poll(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<poll op="req"/>`)))
+
+	require.Equal(t, 1300, decodeResultCode(t, w.Bytes()))
+	require.Nil(t, decodeMsgQ(t, w.Bytes()))
+}
+
+func TestPollController_Ack(t *testing.T) {
+	svc := new(MockPollService)
+	ctrl := &PollController{pollService: svc}
+	svc.On("Ack", mock.Anything, "ClID-1", int64(12345)).Return(getTestPollMessage(12346), int64(4), nil)
+
+	w := &testWriter{}
+	ctrl.Poll(newTestContext("ClID-1"), w, newTestDoc(t, eppCommand(`<poll op="ack" msgID="12345"/>`)))
+
+	require.Equal(t, 1000, decodeResultCode(t, w.Bytes()))
+	require.Equal(t, &MsgQ{Count: 4, ID: "12346"}, decodeMsgQ(t, w.Bytes()))
+}
+
+func TestPollController_Errors(t *testing.T) {
+	tc := []struct {
+		name     string
+		ctx      context.Context
+		cmd      string
+		wantCode int
+	}{
+		{"not logged in", context.Background(), eppCommand(`<poll op="req"/>`), 2002},
+		{"unknown op", newTestContext("ClID-1"), eppCommand(`<poll op="peek"/>`), 2001},
+		{"ack without msgID", newTestContext("ClID-1"), eppCommand(`<poll op="ack"/>`), 2003},
+		{"ack invalid msgID", newTestContext("ClID-1"), eppCommand(`<poll op="ack" msgID="abc"/>`), 2005},
+		{"ack unknown msgID", newTestContext("ClID-1"), eppCommand(`<poll op="ack" msgID="999"/>`), 2303},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockPollService)
+			ctrl := &PollController{pollService: svc}
+			svc.On("Ack", mock.Anything, "ClID-1", int64(999)).Return(nil, int64(0), entities.ErrPollMessageNotFound)
+
+			w := &testWriter{}
+			ctrl.Poll(tt.ctx, w, newTestDoc(t, tt.cmd))
+
+			require.Equal(t, tt.wantCode, decodeResultCode(t, w.Bytes()))
+		})
+	}
+}
+
+func TestWriteResponse_MsgQ(t *testing.T) {
+	svc := new(MockPollService)
+	svc.On("Peek", mock.Anything, "ClID-1").Return(getTestPollMessage(12345), int64(2), nil)
+	svc.On("Peek", mock.Anything, "ClID-2").Return(nil, int64(0), nil)
+
+	// Responses to other commands include the count and ID of the next message, without the message itself
+	w := &testWriter{}
+	writeResponse(ContextWithPollService(newTestContext("ClID-1"), svc), w, NewResponse(1000, "ABC-123"))
+	require.Equal(t, &MsgQ{Count: 2, ID: "12345"}, decodeMsgQ(t, w.Bytes()))
+
+	// An empty queue doesn't add a msgQ element
+	w = &testWriter{}
+	writeResponse(ContextWithPollService(newTestContext("ClID-2"), svc), w, NewResponse(1000, "ABC-123"))
+	require.NotContains(t, w.String(), "msgQ")
+
+	// Without a logged in client there is no queue to report on
+	w = &testWriter{}
+	writeResponse(ContextWithPollService(context.Background(), svc), w, NewResponse(1000, "ABC-123"))
+	require.NotContains(t, w.String(), "msgQ")
+}
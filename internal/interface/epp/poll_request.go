@@ -0,0 +1,23 @@
+package epp
+
+// The structs in this file are used to unmarshal the RFC 5730 <poll> command.
+// Ref: https://datatracker.ietf.org/doc/html/rfc5730#section-2.9.2.3
+
+const (
+	// POLL_OP_REQ is the op attribute value to retrieve the next message from the queue
+	POLL_OP_REQ = "req"
+	// POLL_OP_ACK is the op attribute value to acknowledge (dequeue) a message
+	POLL_OP_ACK = "ack"
+)
+
+// Poll is the <poll> element of the poll command
+type Poll struct {
+	Op    string `xml:"op,attr"`
+	MsgID string `xml:"msgID,attr"`
+}
+
+// PollCommand is the <poll> command
+type PollCommand struct {
+	Poll   Poll   `xml:"command>poll"`
+	ClTRID string `xml:"command>clTRID"`
+}
@@ -0,0 +1,21 @@
+package epp
+
+import (
+	"strconv"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// NewMsgQ creates the <msgQ> element for the provided message at the head of a queue holding count messages.
+// The qDate and msg elements are only included if withMsg is true, as RFC 5730 only allows them in the response to a <poll op="req"> command.
+func NewMsgQ(msg *entities.PollMessage, count int64, withMsg bool) *MsgQ {
+	msgQ := &MsgQ{
+		Count: count,
+		ID:    strconv.FormatInt(msg.ID, 10),
+	}
+	if withMsg {
+		msgQ.QDate = formatEPPDate(msg.CreatedAt)
+		msgQ.Msg = msg.Msg
+	}
+	return msgQ
+}
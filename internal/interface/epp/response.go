@@ -0,0 +1,184 @@
+package epp
+
+import (
+	"context"
+	"encoding/xml"
+	"log"
+	"time"
+
+	epplib "github.com/dotse/epp-lib"
+	"github.com/google/uuid"
+)
+
+const (
+	// EPP_NAMESPACE is the EPP base namespace as defined in RFC 5730
+	EPP_NAMESPACE = "urn:ietf:params:xml:ns:epp-1.0"
+	// DOMAIN_NAMESPACE is the EPP domain mapping namespace as defined in RFC 5731
+	DOMAIN_NAMESPACE = "urn:ietf:params:xml:ns:domain-1.0"
+	// CONTACT_NAMESPACE is the EPP contact mapping namespace as defined in RFC 5733
+	CONTACT_NAMESPACE = "urn:ietf:params:xml:ns:contact-1.0"
+	// HOST_NAMESPACE is the EPP host mapping namespace as defined in RFC 5732
+	HOST_NAMESPACE = "urn:ietf:params:xml:ns:host-1.0"
+	// SECDNS_NAMESPACE is the EPP DNSSEC extension namespace as defined in RFC 5910
+	SECDNS_NAMESPACE = "urn:ietf:params:xml:ns:secDNS-1.1"
+	// FEE_NAMESPACE is the EPP fee extension namespace as defined in RFC 8748
+	FEE_NAMESPACE = "urn:ietf:params:xml:ns:epp:fee-1.0"
+	// LAUNCH_NAMESPACE is the EPP launch phase extension namespace as defined in RFC 8334
+	LAUNCH_NAMESPACE = "urn:ietf:params:xml:ns:launch-1.0"
+	// RGP_NAMESPACE is the EPP registry grace period extension namespace as defined in RFC 3915
+	RGP_NAMESPACE = "urn:ietf:params:xml:ns:rgp-1.0"
+	// ALLOCATION_TOKEN_NAMESPACE is the EPP allocation token extension namespace as defined in RFC 8495
+	ALLOCATION_TOKEN_NAMESPACE = "urn:ietf:params:xml:ns:allocationToken-1.0"
+	// LOGIN_SEC_NAMESPACE is the EPP login security extension namespace as defined in RFC 8807
+	LOGIN_SEC_NAMESPACE = "urn:ietf:params:xml:ns:epp:loginSec-1.0"
+	// TMCH_VALIDATOR_ID is the validator ID of the Trademark Clearinghouse, the default validator of marks and claims notices
+	TMCH_VALIDATOR_ID = "tmch"
+
+	// EPP_DATE_FORMAT is the date format used in EPP responses
+	EPP_DATE_FORMAT = "2006-01-02T15:04:05.0Z"
+
+	// internalErrorReason is the reason sent to the client when a command fails with an error we can't map
+	internalErrorReason = "internal server error"
+)
+
+// Response is the <epp><response> frame that is sent back to the client
+// Ref: https://datatracker.ietf.org/doc/html/rfc5730#section-2.6
+type Response struct {
+	XMLName   xml.Name   `xml:"epp"`
+	XMLNS     string     `xml:"xmlns,attr"`
+	Result    []Result   `xml:"response>result"`
+	MsgQ      *MsgQ      `xml:"response>msgQ,omitempty"`
+	ResData   *ResData   `xml:"response>resData,omitempty"`
+	Extension *Extension `xml:"response>extension,omitempty"`
+	TrID      TrID       `xml:"response>trID"`
+}
+
+// Result is the <result> element of an EPP response
+type Result struct {
+	Code     int        `xml:"code,attr"`
+	Msg      string     `xml:"msg"`
+	ExtValue []ExtValue `xml:"extValue,omitempty"`
+}
+
+// ExtValue is the <extValue> element that provides additional error diagnostic information.
+// Since we don't echo the offending client element, the value is set to <undef/> as allowed by RFC 5730
+type ExtValue struct {
+	Undef  struct{} `xml:"value>undef"`
+	Reason string   `xml:"reason"`
+}
+
+// MsgQ is the <msgQ> element that tells the client how many poll messages are queued and which one is next.
+// QDate and Msg are only included in the response to a <poll op="req"> command
+// Ref: https://datatracker.ietf.org/doc/html/rfc5730#section-2.6
+type MsgQ struct {
+	Count int64  `xml:"count,attr"`
+	ID    string `xml:"id,attr"`
+	QDate string `xml:"qDate,omitempty"`
+	Msg   string `xml:"msg,omitempty"`
+}
+
+// ResData holds the object specific <resData> element. Data should be one of the *Data structs in this package (e.g. DomainInfData)
+type ResData struct {
+	Data any
+}
+
+// Extension holds the optional <extension> elements of a response
+type Extension struct {
+	Data []any
+}
+
+// TrID is the transaction identifier of the response
+type TrID struct {
+	ClTRID string `xml:"clTRID,omitempty"`
+	SvTRID string `xml:"svTRID"`
+}
+
+// NewResponse creates a new Response with the provided result code and the default message for that code.
+// The server transaction ID is generated and the client transaction ID is copied from the command.
+func NewResponse(code int, clTRID string) *Response {
+	return &Response{
+		XMLNS: EPP_NAMESPACE,
+		Result: []Result{
+			{
+				Code: code,
+				Msg:  epplib.StatusText(code),
+			},
+		},
+		TrID: TrID{
+			ClTRID: clTRID,
+			SvTRID: newSvTRID(),
+		},
+	}
+}
+
+// NewErrorResponse creates a new Response that maps the provided error to an EPP result code.
+// The message of an error we can map is included as the reason so the client has some context on why the command failed.
+// Errors we can't map are internal errors, their message may contain internal details so it is logged and a generic reason is sent to the client instead.
+func NewErrorResponse(err error, clTRID string) *Response {
+	code := ResultCodeFromError(err)
+	reason := err.Error()
+	if code == epplib.StatusCommandFailed {
+		log.Printf("EPP command failed (clTRID: %s): %v", clTRID, err)
+		reason = internalErrorReason
+	}
+	r := NewResponse(code, clTRID)
+	r.Result[0].ExtValue = []ExtValue{{Reason: reason}}
+	return r
+}
+
+// WithResData sets the resData element of the response and returns the response
+func (r *Response) WithResData(data any) *Response {
+	r.ResData = &ResData{Data: data}
+	return r
+}
+
+// WithMsgQ sets the msgQ element of the response and returns the response
+func (r *Response) WithMsgQ(msgQ *MsgQ) *Response {
+	r.MsgQ = msgQ
+	return r
+}
+
+// WithExtension adds an extension element to the response and returns the response
+func (r *Response) WithExtension(data any) *Response {
+	if r.Extension == nil {
+		r.Extension = &Extension{}
+	}
+	r.Extension.Data = append(r.Extension.Data, data)
+	return r
+}
+
+// Marshal returns the XML representation of the response including the XML header
+func (r *Response) Marshal() ([]byte, error) {
+	b, err := xml.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), b...), nil
+}
+
+// writeResponse marshals the response and writes it to the epplib.Writer.
+// If the response doesn't have a msgQ element yet, the message queue of the logged in client is added so the client knows when to poll.
+// If the response can't be marshalled, a generic command failed response is written instead.
+func writeResponse(ctx context.Context, rw epplib.Writer, r *Response) {
+	if r.MsgQ == nil {
+		r.MsgQ = msgQFromContext(ctx)
+	}
+	b, err := r.Marshal()
+	if err != nil {
+		b, _ = NewResponse(epplib.StatusCommandFailed, r.TrID.ClTRID).Marshal()
+	}
+	rw.Write(b)
+}
+
+// formatEPPDate formats a time as expected in EPP responses. Zero times result in an empty string so they can be omitted
+func formatEPPDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(EPP_DATE_FORMAT)
+}
+
+// newSvTRID generates a new unique server transaction ID
+func newSvTRID() string {
+	return "DOS-" + uuid.NewString()
+}
@@ -0,0 +1,80 @@
+package epp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	epplib "github.com/dotse/epp-lib"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewResponse_Marshal(t *testing.T) {
+	r := NewResponse(epplib.StatusSuccess, "ABC-123")
+
+	b, err := r.Marshal()
+	require.NoError(t, err)
+
+	s := string(b)
+	require.Contains(t, s, `<?xml version="1.0" encoding="UTF-8"?>`)
+	require.Contains(t, s, `<epp xmlns="urn:ietf:params:xml:ns:epp-1.0"><response><result code="1000"><msg>Command completed successfully</msg></result>`)
+	require.Contains(t, s, `<clTRID>ABC-123</clTRID><svTRID>DOS-`)
+	require.NotContains(t, s, "resData")
+	require.NotContains(t, s, "extValue")
+}
+
+func TestNewErrorResponse_Marshal(t *testing.T) {
+	r := NewErrorResponse(entities.ErrDomainNotFound, "")
+
+	b, err := r.Marshal()
+	require.NoError(t, err)
+
+	s := string(b)
+	require.Contains(t, s, `<result code="2303"><msg>Object does not exist</msg><extValue><value><undef></undef></value><reason>domain not found</reason></extValue></result>`)
+	require.NotContains(t, s, "clTRID")
+}
+
+func TestNewErrorResponse_InternalError(t *testing.T) {
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	b, err := NewErrorResponse(errors.New("dial tcp 10.0.0.5:5432: connection refused"), "ABC-123").Marshal()
+	require.NoError(t, err)
+
+	s := string(b)
+	require.Contains(t, s, `<result code="2400"><msg>Command failed</msg><extValue><value><undef></undef></value><reason>internal server error</reason></extValue></result>`)
+	require.NotContains(t, s, "10.0.0.5")
+	require.Contains(t, logged.String(), "ABC-123")
+	require.Contains(t, logged.String(), "dial tcp 10.0.0.5:5432: connection refused")
+}
+
+func TestResponse_WithResData(t *testing.T) {
+	chk := NewDomainChkData()
+	chk.Add("example.com", true, "")
+	chk.Add("taken.com", false, "domain exists")
+
+	b, err := NewResponse(epplib.StatusSuccess, "ABC-123").WithResData(chk).Marshal()
+	require.NoError(t, err)
+
+	s := string(b)
+	require.Contains(t, s, `<resData><domain:chkData xmlns:domain="urn:ietf:params:xml:ns:domain-1.0">`)
+	require.Contains(t, s, `<domain:cd><domain:name avail="1">example.com</domain:name></domain:cd>`)
+	require.Contains(t, s, `<domain:cd><domain:name avail="0">taken.com</domain:name><domain:reason>domain exists</domain:reason></domain:cd>`)
+}
+
+func TestFormatEPPDate(t *testing.T) {
+	require.Equal(t, "", formatEPPDate(time.Time{}))
+	require.Equal(t, "2024-03-01T12:30:00.0Z", formatEPPDate(time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)))
+}
+
+func TestWriteResponse(t *testing.T) {
+	w := &testWriter{}
+	writeResponse(context.Background(), w, NewErrorResponse(errors.New("boom"), "ABC-123"))
+	require.Contains(t, w.String(), `<result code="2400">`)
+}
@@ -0,0 +1,161 @@
+package epp
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/beevik/etree"
+	"github.com/onasunnymorning/domain-os/internal/application/interfaces"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+var (
+	// ErrNotLoggedIn is returned when a command that requires an authenticated session is received before the client is identified
+	ErrNotLoggedIn = errors.New("no client identified for this session")
+	// ErrAlreadyLoggedIn is returned when a <login> command is received on a session that is already authenticated
+	ErrAlreadyLoggedIn = errors.New("a client is already logged in on this session")
+)
+
+// sessionContextKey is the key under which the Session is stored in the connection context
+type sessionContextKey struct{}
+
+// pollServiceContextKey is the key under which the PollService is stored in the connection context
+type pollServiceContextKey struct{}
+
+// Session holds the state of a single EPP connection.
+// epplib only allows us to set the context once per connection (in Server.ConnContext), so the Session is stored as a pointer in the context and mutated by the handlers
+type Session struct {
+	mu      sync.RWMutex
+	clID    string
+	status  entities.RegistrarStatus
+	extURIs []string
+}
+
+// NewSession creates a new empty Session
+func NewSession() *Session {
+	return &Session{}
+}
+
+// ClID returns the ClID of the registrar bound to the session, or an empty string if no registrar is bound yet
+func (s *Session) ClID() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.clID
+}
+
+// Status returns the status the registrar bound to the session had when it logged in
+func (s *Session) Status() entities.RegistrarStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.status
+}
+
+// HasExtension reports whether the client requested the extension with the provided URI at login.
+// Extension elements are only included in responses to clients that requested the extension.
+func (s *Session) HasExtension(uri string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Contains(s.extURIs, uri)
+}
+
+// Login binds the authenticated registrar with the provided ClID and status to the session together with the extensions it requested
+func (s *Session) Login(clID string, status entities.RegistrarStatus, extURIs []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clID = clID
+	s.status = status
+	s.extURIs = extURIs
+}
+
+// Logout removes the registrar from the session
+func (s *Session) Logout() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clID = ""
+	s.status = ""
+	s.extURIs = nil
+}
+
+// ContextWithSession returns a copy of ctx that carries the provided Session
+func ContextWithSession(ctx context.Context, s *Session) context.Context {
+	return context.WithValue(ctx, sessionContextKey{}, s)
+}
+
+// SessionFromContext returns the Session stored in the context or nil if there is none
+func SessionFromContext(ctx context.Context) *Session {
+	s, ok := ctx.Value(sessionContextKey{}).(*Session)
+	if !ok {
+		return nil
+	}
+	return s
+}
+
+// ContextWithPollService returns a copy of ctx that carries the provided PollService.
+// It is used to add the message queue of the logged in client to every response.
+func ContextWithPollService(ctx context.Context, svc interfaces.PollService) context.Context {
+	return context.WithValue(ctx, pollServiceContextKey{}, svc)
+}
+
+// msgQFromContext returns the <msgQ> element for the client logged in on the session in the context.
+// It returns nil if nobody is logged in, no PollService is available, the queue is empty or the queue can't be read.
+// We don't want to fail a command because we can't tell the client about its messages, it will learn about them the next time it polls.
+func msgQFromContext(ctx context.Context) *MsgQ {
+	clID, err := clIDFromContext(ctx)
+	if err != nil {
+		return nil
+	}
+	svc, ok := ctx.Value(pollServiceContextKey{}).(interfaces.PollService)
+	if !ok || svc == nil {
+		return nil
+	}
+	msg, count, err := svc.Peek(ctx, clID)
+	if err != nil || msg == nil {
+		return nil
+	}
+	return NewMsgQ(msg, count, false)
+}
+
+// clIDFromContext returns the ClID bound to the session in the context or ErrNotLoggedIn if there is none
+func clIDFromContext(ctx context.Context) (string, error) {
+	s := SessionFromContext(ctx)
+	if s == nil || s.ClID() == "" {
+		return "", ErrNotLoggedIn
+	}
+	return s.ClID(), nil
+}
+
+// hasExtensionFromContext reports whether the client logged in on the session in the context requested the extension with the provided URI
+func hasExtensionFromContext(ctx context.Context, uri string) bool {
+	s := SessionFromContext(ctx)
+	return s != nil && s.HasExtension(uri)
+}
+
+// clIDForCreateFromContext returns the ClID bound to the session in the context if the status of the registrar allows it to create new objects
+func clIDForCreateFromContext(ctx context.Context) (string, error) {
+	clID, err := clIDFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	status := SessionFromContext(ctx).Status()
+	if !status.AllowsCreate() {
+		return "", errors.Join(entities.ErrRegistrarStatusPreventsCreate, fmt.Errorf("registrar status: %s", status))
+	}
+	return clID, nil
+}
+
+// unmarshalCommand unmarshals the command document into v.
+// The paths in the command structs don't include namespaces, so they match regardless of the prefix the client chose for the object namespace
+func unmarshalCommand(doc *etree.Document, v any) error {
+	b, err := doc.WriteToBytes()
+	if err != nil {
+		return errors.Join(ErrInvalidCommand, err)
+	}
+	if err := xml.Unmarshal(b, v); err != nil {
+		return errors.Join(ErrInvalidCommand, err)
+	}
+	return nil
+}
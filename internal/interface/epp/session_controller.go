@@ -0,0 +1,187 @@
+package epp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/beevik/etree"
+	epplib "github.com/dotse/epp-lib"
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+	"github.com/onasunnymorning/domain-os/internal/application/interfaces"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+const (
+	// EPP_VERSION is the only protocol version we support
+	EPP_VERSION = "1.0"
+	// EPP_LANG is the only language we support for the text in our responses
+	EPP_LANG = "en"
+	// EPP_SERVER_ID is the name of the server in the greeting
+	EPP_SERVER_ID = "DomainOS EPP Server"
+)
+
+var (
+	// supportedObjURIs are the object services a client can request at login
+	supportedObjURIs = []string{DOMAIN_NAMESPACE, CONTACT_NAMESPACE, HOST_NAMESPACE}
+	// supportedExtURIs are the extension services a client can request at login
+	supportedExtURIs = []string{SECDNS_NAMESPACE, FEE_NAMESPACE, LAUNCH_NAMESPACE, RGP_NAMESPACE, ALLOCATION_TOKEN_NAMESPACE, LOGIN_SEC_NAMESPACE}
+)
+
+// SessionController handles the RFC 5730 greeting, <hello>, <login> and <logout> commands.
+// The registrar is authenticated against its stored EPP credentials and bound to the Session, all object commands act on behalf of that registrar.
+type SessionController struct {
+	registrarService interfaces.RegistrarService
+}
+
+// NewSessionController creates a new SessionController and binds the session commands to the provided CommandMux
+func NewSessionController(mux *epplib.CommandMux, registrarService interfaces.RegistrarService) *SessionController {
+	controller := &SessionController{
+		registrarService: registrarService,
+	}
+
+	mux.BindGreeting(controller.Greeting)
+	mux.Bind(epplib.NewXMLPathBuilder().AddOrphan("//hello", EPP_NAMESPACE).String(), controller.Greeting)
+	mux.Bind(epplib.NewXMLPathBuilder().AddOrphan("//command", EPP_NAMESPACE).Add("login", EPP_NAMESPACE).String(), controller.Login)
+	mux.Bind(epplib.NewXMLPathBuilder().AddOrphan("//command", EPP_NAMESPACE).Add("logout", EPP_NAMESPACE).String(), controller.Logout)
+
+	return controller
+}
+
+// Greeting sends the server greeting, it is sent when a client connects and in response to a <hello> command
+func (ctrl *SessionController) Greeting(ctx context.Context, rw epplib.Writer, _ *etree.Document) {
+	b, err := NewGreeting(time.Now().UTC()).Marshal()
+	if err != nil {
+		b, _ = NewResponse(epplib.StatusCommandFailed, "").Marshal()
+	}
+	rw.Write(b)
+}
+
+// Login handles the <login> command.
+// If a newPW is provided, it replaces the current password after successful authentication.
+// Clients that request the login security extension (RFC 8807) can provide long passwords and their user agent through the extension and are notified of security events in the response.
+func (ctrl *SessionController) Login(ctx context.Context, rw epplib.Writer, doc *etree.Document) {
+	var cmd LoginCommand
+	if err := unmarshalCommand(doc, &cmd); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	session := SessionFromContext(ctx)
+	if session == nil {
+		writeResponse(ctx, rw, NewResponse(epplib.StatusCommandFailed, cmd.ClTRID))
+		return
+	}
+	if session.ClID() != "" {
+		writeResponse(ctx, rw, NewErrorResponse(ErrAlreadyLoggedIn, cmd.ClTRID))
+		return
+	}
+	if err := validateLoginCommand(&cmd); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	loginCmd, err := loginCommandFromRequest(&cmd)
+	if err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	loginSec := slices.Contains(cmd.Svcs.ExtURIs, LOGIN_SEC_NAMESPACE)
+
+	result, err := ctrl.registrarService.LoginEPP(ctx, loginCmd)
+	if err != nil {
+		resp := NewErrorResponse(err, cmd.ClTRID)
+		if data := NewLoginSecErrorData(err); loginSec && data != nil {
+			resp.WithExtension(data)
+		}
+		writeResponse(ctx, rw, resp)
+		return
+	}
+
+	session.Login(result.Registrar.ClID.String(), result.Registrar.Status, cmd.Svcs.ExtURIs)
+
+	resp := NewResponse(epplib.StatusSuccess, cmd.ClTRID)
+	if data := NewLoginSecData(result, time.Now().UTC()); loginSec && data != nil {
+		resp.WithExtension(data)
+	}
+	writeResponse(ctx, rw, resp)
+}
+
+// Logout handles the <logout> command. The session is ended and the connection is closed after the response is sent
+func (ctrl *SessionController) Logout(ctx context.Context, rw epplib.Writer, doc *etree.Document) {
+	var cmd LogoutCommand
+	if err := unmarshalCommand(doc, &cmd); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+	if _, err := clIDFromContext(ctx); err != nil {
+		writeResponse(ctx, rw, NewErrorResponse(err, cmd.ClTRID))
+		return
+	}
+
+	SessionFromContext(ctx).Logout()
+
+	writeResponse(ctx, rw, NewResponse(epplib.StatusEndingSession, cmd.ClTRID))
+	rw.CloseAfterWrite()
+}
+
+// validateLoginCommand checks the login command contains credentials and only requests options and services we support
+func validateLoginCommand(cmd *LoginCommand) error {
+	if cmd.ClID == "" || cmd.PW == "" {
+		return ErrMissingCredentials
+	}
+	if cmd.Options.Version != EPP_VERSION {
+		return errors.Join(ErrUnsupportedVersion, fmt.Errorf("version: %s", cmd.Options.Version))
+	}
+	if cmd.Options.Lang != EPP_LANG {
+		return errors.Join(ErrUnsupportedLanguage, fmt.Errorf("lang: %s", cmd.Options.Lang))
+	}
+	for _, uri := range cmd.Svcs.ObjURIs {
+		if !slices.Contains(supportedObjURIs, uri) {
+			return errors.Join(ErrUnsupportedObjectService, fmt.Errorf("objURI: %s", uri))
+		}
+	}
+	for _, uri := range cmd.Svcs.ExtURIs {
+		if !slices.Contains(supportedExtURIs, uri) {
+			return errors.Join(ErrUnsupportedExtension, fmt.Errorf("extURI: %s", uri))
+		}
+	}
+	return nil
+}
+
+// loginCommandFromRequest creates the EPPLoginCommand from the login command.
+// If the client uses the login security extension, the passwords in the extension replace the [LOGIN-SECURITY] placeholders in the pw and newPW elements.
+func loginCommandFromRequest(cmd *LoginCommand) (*commands.EPPLoginCommand, error) {
+	loginCmd := &commands.EPPLoginCommand{
+		ClID:        cmd.ClID,
+		Password:    cmd.PW,
+		NewPassword: cmd.NewPW,
+	}
+	ext := cmd.Extension.LoginSec
+	if ext == nil {
+		if cmd.PW == LOGIN_SEC_PASSWORD_PLACEHOLDER || cmd.NewPW == LOGIN_SEC_PASSWORD_PLACEHOLDER {
+			return nil, ErrInvalidLoginSecPassword
+		}
+		return loginCmd, nil
+	}
+	if !slices.Contains(cmd.Svcs.ExtURIs, LOGIN_SEC_NAMESPACE) {
+		return nil, errors.Join(ErrExtensionNotRequested, fmt.Errorf("extURI: %s", LOGIN_SEC_NAMESPACE))
+	}
+	if (ext.PW != "") != (cmd.PW == LOGIN_SEC_PASSWORD_PLACEHOLDER) || (ext.NewPW != "") != (cmd.NewPW == LOGIN_SEC_PASSWORD_PLACEHOLDER) {
+		return nil, ErrInvalidLoginSecPassword
+	}
+	if ext.PW != "" {
+		loginCmd.Password = ext.PW
+	}
+	if ext.NewPW != "" {
+		loginCmd.NewPassword = ext.NewPW
+	}
+	if ext.UserAgent != nil {
+		loginCmd.UserAgent = entities.EPPUserAgent{
+			App:  ext.UserAgent.App,
+			Tech: ext.UserAgent.Tech,
+			OS:   ext.UserAgent.OS,
+		}
+	}
+	return loginCmd, nil
+}
@@ -0,0 +1,304 @@
+package epp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockRegistrarService is a mock implementation of the RegistrarService
+type MockRegistrarService struct {
+	mock.Mock
+}
+
+func (m *MockRegistrarService) GetByClID(ctx context.Context, clid string, preloadTLDs bool) (*entities.Registrar, error) {
+	args := m.Called(ctx, clid, preloadTLDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Registrar), args.Error(1)
+}
+
+func (m *MockRegistrarService) GetByGurID(ctx context.Context, gurID int) (*entities.Registrar, error) {
+	args := m.Called(ctx, gurID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Registrar), args.Error(1)
+}
+
+func (m *MockRegistrarService) Create(ctx context.Context, cmd *commands.CreateRegistrarCommand) (*entities.Registrar, error) {
+	args := m.Called(ctx, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Registrar), args.Error(1)
+}
+
+func (m *MockRegistrarService) BulkCreate(ctx context.Context, cmds []*commands.CreateRegistrarCommand) error {
+	return m.Called(ctx, cmds).Error(0)
+}
+
+func (m *MockRegistrarService) Update(ctx context.Context, rar *entities.Registrar) (*entities.Registrar, error) {
+	args := m.Called(ctx, rar)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Registrar), args.Error(1)
+}
+
+func (m *MockRegistrarService) Delete(ctx context.Context, clid string) error {
+	return m.Called(ctx, clid).Error(0)
+}
+
+func (m *MockRegistrarService) List(ctx context.Context, params queries.ListItemsQuery) ([]*entities.RegistrarListItem, string, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]*entities.RegistrarListItem), args.String(1), args.Error(2)
+}
+
+func (m *MockRegistrarService) Count(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRegistrarService) SetStatus(ctx context.Context, clid string, status entities.RegistrarStatus) error {
+	return m.Called(ctx, clid, status).Error(0)
+}
+
+func (m *MockRegistrarService) SetEPPPassword(ctx context.Context, clid, password string) error {
+	return m.Called(ctx, clid, password).Error(0)
+}
+
+func (m *MockRegistrarService) LoginEPP(ctx context.Context, cmd *commands.EPPLoginCommand) (*commands.EPPLoginResult, error) {
+	args := m.Called(ctx, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*commands.EPPLoginResult), args.Error(1)
+}
+
+// loginCommand returns a login command with the provided credentials and elements inside the <svcs> element
+func loginCommand(clID, pw, extra, svcs string) string {
+	return eppCommand(`<login><clID>` + clID + `</clID><pw>` + pw + `</pw>` + extra + `<options><version>1.0</version><lang>en</lang></options><svcs>` + svcs + `</svcs></login>`)
+}
+
+func TestSessionController_Login(t *testing.T) {
+	svc := new(MockRegistrarService)
+	ctrl := &SessionController{registrarService: svc}
+	svc.On("LoginEPP", mock.Anything, &commands.EPPLoginCommand{ClID: "ClID-1", Password: "s3cr3tPW"}).Return(&commands.EPPLoginResult{Registrar: &entities.Registrar{ClID: "ClID-1", Status: entities.RegistrarStatusReadonly}}, nil)
+
+	s := NewSession()
+	ctx := ContextWithSession(context.Background(), s)
+
+	w := &testWriter{}
+	ctrl.Login(ctx, w, newTestDoc(t, loginCommand("ClID-1", "s3cr3tPW", "", `<objURI>urn:ietf:params:xml:ns:domain-1.0</objURI><objURI>urn:ietf:params:xml:ns:host-1.0</objURI>`)))
+
+	require.Equal(t, 1000, decodeResultCode(t, w.Bytes()))
+	require.Equal(t, "ClID-1", s.ClID())
+	require.Equal(t, entities.RegistrarStatusReadonly, s.Status())
+
+	// A second login on the same session is not allowed
+	w = &testWriter{}
+	ctrl.Login(ctx, w, newTestDoc(t, loginCommand("ClID-1", "s3cr3tPW", "", "")))
+	require.Equal(t, 2002, decodeResultCode(t, w.Bytes()))
+}
+
+func TestSessionController_Login_NewPW(t *testing.T) {
+	svc := new(MockRegistrarService)
+	ctrl := &SessionController{registrarService: svc}
+	svc.On("LoginEPP", mock.Anything, &commands.EPPLoginCommand{ClID: "ClID-1", Password: "s3cr3tPW", NewPassword: "n3wS3cr3t"}).Return(&commands.EPPLoginResult{Registrar: &entities.Registrar{ClID: "ClID-1", Status: entities.RegistrarStatusOK}, PasswordChanged: true}, nil)
+
+	s := NewSession()
+	w := &testWriter{}
+	ctrl.Login(ContextWithSession(context.Background(), s), w, newTestDoc(t, loginCommand("ClID-1", "s3cr3tPW", "<newPW>n3wS3cr3t</newPW>", "")))
+
+	require.Equal(t, 1000, decodeResultCode(t, w.Bytes()))
+	require.Equal(t, "ClID-1", s.ClID())
+	svc.AssertExpectations(t)
+}
+
+func TestSessionController_Login_Errors(t *testing.T) {
+	tc := []struct {
+		name     string
+		cmd      string
+		wantCode int
+	}{
+		{"wrong password", loginCommand("ClID-1", "wr0ngPW!", "", ""), 2200},
+		{"missing password", loginCommand("ClID-1", "", "", ""), 2003},
+		{"unsupported version", eppCommand(`<login><clID>ClID-1</clID><pw>s3cr3tPW</pw><options><version>2.0</version><lang>en</lang></options></login>`), 2100},
+		{"unsupported language", eppCommand(`<login><clID>ClID-1</clID><pw>s3cr3tPW</pw><options><version>1.0</version><lang>fr</lang></options></login>`), 2102},
+		{"unsupported object", loginCommand("ClID-1", "s3cr3tPW", "", `<objURI>urn:ietf:params:xml:ns:obj-1.0</objURI>`), 2307},
+		{"unsupported extension", loginCommand("ClID-1", "s3cr3tPW", "", `<svcExtension><extURI>urn:ietf:params:xml:ns:ext-1.0</extURI></svcExtension>`), 2103},
+		{"loginSec not requested", eppCommand(`<login><clID>ClID-1</clID><pw>[LOGIN-SECURITY]</pw><options><version>1.0</version><lang>en</lang></options></login>` + loginSecExtension(`<loginSec:pw>this is a long password</loginSec:pw>`)), 2103},
+		{"loginSec placeholder without extension", loginCommand("ClID-1", "[LOGIN-SECURITY]", "", loginSecSvcs), 2306},
+		{"loginSec password without placeholder", eppCommand(`<login><clID>ClID-1</clID><pw>s3cr3tPW</pw><options><version>1.0</version><lang>en</lang></options><svcs>` + loginSecSvcs + `</svcs></login>` + loginSecExtension(`<loginSec:pw>this is a long password</loginSec:pw>`)), 2306},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockRegistrarService)
+			ctrl := &SessionController{registrarService: svc}
+			svc.On("LoginEPP", mock.Anything, &commands.EPPLoginCommand{ClID: "ClID-1", Password: "wr0ngPW!"}).Return(nil, entities.ErrEPPAuthenticationFailed)
+
+			s := NewSession()
+			w := &testWriter{}
+			ctrl.Login(ContextWithSession(context.Background(), s), w, newTestDoc(t, tt.cmd))
+
+			require.Equal(t, tt.wantCode, decodeResultCode(t, w.Bytes()))
+			require.Empty(t, s.ClID())
+		})
+	}
+}
+
+const loginSecSvcs = `<svcExtension><extURI>urn:ietf:params:xml:ns:epp:loginSec-1.0</extURI></svcExtension>`
+
+// loginSecExtension returns the login security extension with the provided elements
+func loginSecExtension(elements string) string {
+	return `<extension><loginSec:loginSec xmlns:loginSec="urn:ietf:params:xml:ns:epp:loginSec-1.0">` + elements + `</loginSec:loginSec></extension>`
+}
+
+// loginSecCommand returns a login command that requests the login security extension and provides the passwords and user agent in the extension
+func loginSecCommand(newPW bool) string {
+	login := `<login><clID>ClID-1</clID><pw>[LOGIN-SECURITY]</pw>`
+	ext := `<loginSec:userAgent><loginSec:app>EPP SDK 1.0.0</loginSec:app><loginSec:tech>Go 1.22</loginSec:tech><loginSec:os>x86_64 Linux</loginSec:os></loginSec:userAgent><loginSec:pw>this is a long password 1</loginSec:pw>`
+	if newPW {
+		login += `<newPW>[LOGIN-SECURITY]</newPW>`
+		ext += `<loginSec:newPW>this is a new long password 2</loginSec:newPW>`
+	}
+	login += `<options><version>1.0</version><lang>en</lang></options><svcs>` + loginSecSvcs + `</svcs></login>`
+	return eppCommand(login + loginSecExtension(ext))
+}
+
+func TestSessionController_Login_LoginSec(t *testing.T) {
+	ua := entities.EPPUserAgent{App: "EPP SDK 1.0.0", Tech: "Go 1.22", OS: "x86_64 Linux"}
+	expiringRar := &entities.Registrar{ClID: "ClID-1", Status: entities.RegistrarStatusOK}
+	expiringRar.EPPLogin.PasswordChangedAt = time.Now().UTC().Add(-entities.EPP_PASSWORD_MAX_AGE).Add(24 * time.Hour)
+
+	tc := []struct {
+		name       string
+		newPW      bool
+		result     *commands.EPPLoginResult
+		err        error
+		wantCode   int
+		wantEvents []string
+	}{
+		{
+			name:     "no events",
+			result:   &commands.EPPLoginResult{Registrar: &entities.Registrar{ClID: "ClID-1", Status: entities.RegistrarStatusOK}},
+			wantCode: 1000,
+		},
+		{
+			name:       "password expiry and failed logins",
+			result:     &commands.EPPLoginResult{Registrar: expiringRar, FailedLogins: 3},
+			wantCode:   1000,
+			wantEvents: []string{`type="password" level="warning" exDate=`, `type="stat" name="failedLogins" level="warning" value="3"`},
+		},
+		{
+			name:       "password expired",
+			err:        entities.ErrEPPPasswordExpired,
+			wantCode:   2200,
+			wantEvents: []string{`type="password" level="error"`},
+		},
+		{
+			name:       "locked out",
+			err:        entities.ErrEPPLoginLocked,
+			wantCode:   2200,
+			wantEvents: []string{`type="stat" name="failedLogins" level="error"`},
+		},
+		{
+			name:       "new password does not meet the policy",
+			newPW:      true,
+			err:        entities.ErrInvalidEPPPassword,
+			wantCode:   2306,
+			wantEvents: []string{`type="newPW" level="error"`},
+		},
+		{
+			name:     "wrong password",
+			err:      entities.ErrEPPAuthenticationFailed,
+			wantCode: 2200,
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockRegistrarService)
+			ctrl := &SessionController{registrarService: svc}
+			cmd := &commands.EPPLoginCommand{ClID: "ClID-1", Password: "this is a long password 1", UserAgent: ua}
+			if tt.newPW {
+				cmd.NewPassword = "this is a new long password 2"
+			}
+			if tt.err != nil {
+				svc.On("LoginEPP", mock.Anything, cmd).Return(nil, tt.err)
+			} else {
+				svc.On("LoginEPP", mock.Anything, cmd).Return(tt.result, nil)
+			}
+
+			s := NewSession()
+			w := &testWriter{}
+			ctrl.Login(ContextWithSession(context.Background(), s), w, newTestDoc(t, loginSecCommand(tt.newPW)))
+
+			require.Equal(t, tt.wantCode, decodeResultCode(t, w.Bytes()))
+			svc.AssertExpectations(t)
+			if tt.wantEvents == nil {
+				require.NotContains(t, w.String(), "loginSecData")
+				return
+			}
+			require.Contains(t, w.String(), `<loginSec:loginSecData xmlns:loginSec="urn:ietf:params:xml:ns:epp:loginSec-1.0">`)
+			for _, e := range tt.wantEvents {
+				require.Contains(t, w.String(), e)
+			}
+		})
+	}
+}
+
+func TestSessionController_Login_LoginSecNotRequested(t *testing.T) {
+	// Security events are only reported to clients that requested the login security extension
+	svc := new(MockRegistrarService)
+	ctrl := &SessionController{registrarService: svc}
+	svc.On("LoginEPP", mock.Anything, &commands.EPPLoginCommand{ClID: "ClID-1", Password: "s3cr3tPW"}).Return(nil, entities.ErrEPPPasswordExpired)
+
+	w := &testWriter{}
+	ctrl.Login(ContextWithSession(context.Background(), NewSession()), w, newTestDoc(t, loginCommand("ClID-1", "s3cr3tPW", "", "")))
+
+	require.Equal(t, 2200, decodeResultCode(t, w.Bytes()))
+	require.NotContains(t, w.String(), "loginSecData")
+}
+
+func TestSessionController_Logout(t *testing.T) {
+	ctrl := &SessionController{registrarService: new(MockRegistrarService)}
+	ctx := newTestContext("ClID-1")
+
+	w := &testWriter{}
+	ctrl.Logout(ctx, w, newTestDoc(t, eppCommand(`<logout/>`)))
+
+	require.Equal(t, 1500, decodeResultCode(t, w.Bytes()))
+	require.True(t, w.closed)
+	require.Empty(t, SessionFromContext(ctx).ClID())
+
+	// Logging out twice is a command use error
+	w = &testWriter{}
+	ctrl.Logout(ctx, w, newTestDoc(t, eppCommand(`<logout/>`)))
+	require.Equal(t, 2002, decodeResultCode(t, w.Bytes()))
+}
+
+func TestSessionController_Greeting(t *testing.T) {
+	ctrl := &SessionController{registrarService: new(MockRegistrarService)}
+
+	w := &testWriter{}
+	ctrl.Greeting(context.Background(), w, newTestDoc(t, `<?xml version="1.0" encoding="UTF-8"?><epp xmlns="urn:ietf:params:xml:ns:epp-1.0"><hello/></epp>`))
+
+	s := w.String()
+	require.Contains(t, s, `<epp xmlns="urn:ietf:params:xml:ns:epp-1.0"><greeting><svID>DomainOS EPP Server</svID><svDate>`)
+	require.Contains(t, s, `<svcMenu><version>1.0</version><lang>en</lang><objURI>urn:ietf:params:xml:ns:domain-1.0</objURI><objURI>urn:ietf:params:xml:ns:contact-1.0</objURI><objURI>urn:ietf:params:xml:ns:host-1.0</objURI><svcExtension>`)
+	for _, uri := range supportedExtURIs {
+		require.Contains(t, s, `<extURI>`+uri+`</extURI>`)
+	}
+	require.Contains(t, s, `<dcp><access><all></all></access>`)
+	require.NotContains(t, s, `<result`)
+}
@@ -0,0 +1,53 @@
+package epp
+
+// The structs in this file are used to unmarshal the RFC 5730 session management commands and the RFC 8807 login security extension.
+// Ref: https://datatracker.ietf.org/doc/html/rfc5730#section-2.9.1
+// Ref: https://datatracker.ietf.org/doc/html/rfc8807#section-4.1
+
+// LoginOptions is the <options> element of the login command
+type LoginOptions struct {
+	Version string `xml:"version"`
+	Lang    string `xml:"lang"`
+}
+
+// LoginSvcs is the <svcs> element of the login command
+type LoginSvcs struct {
+	ObjURIs []string `xml:"objURI"`
+	ExtURIs []string `xml:"svcExtension>extURI"`
+}
+
+// LoginCommand is the <login> command
+type LoginCommand struct {
+	ClID      string       `xml:"command>login>clID"`
+	PW        string       `xml:"command>login>pw"`
+	NewPW     string       `xml:"command>login>newPW"`
+	Options   LoginOptions `xml:"command>login>options"`
+	Svcs      LoginSvcs    `xml:"command>login>svcs"`
+	Extension LoginExt     `xml:"command>extension"`
+	ClTRID    string       `xml:"command>clTRID"`
+}
+
+// LoginExt holds the extensions of the login command we support
+type LoginExt struct {
+	LoginSec *LoginSec `xml:"urn:ietf:params:xml:ns:epp:loginSec-1.0 loginSec"`
+}
+
+// LoginSec is the <loginSec:loginSec> element of the login command.
+// It carries passwords that don't fit the RFC 5730 pwType, in which case the <pw> and <newPW> elements of the login command are set to LOGIN_SEC_PASSWORD_PLACEHOLDER.
+type LoginSec struct {
+	UserAgent *LoginSecUserAgent `xml:"userAgent"`
+	PW        string             `xml:"pw"`
+	NewPW     string             `xml:"newPW"`
+}
+
+// LoginSecUserAgent is the <loginSec:userAgent> element that identifies the client software
+type LoginSecUserAgent struct {
+	App  string `xml:"app"`
+	Tech string `xml:"tech"`
+	OS   string `xml:"os"`
+}
+
+// LogoutCommand is the <logout> command
+type LogoutCommand struct {
+	ClTRID string `xml:"command>clTRID"`
+}
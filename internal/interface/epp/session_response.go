@@ -0,0 +1,156 @@
+package epp
+
+import (
+	"encoding/xml"
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+const (
+	// LOGIN_SEC_PASSWORD_PLACEHOLDER is the value of the <pw> and <newPW> elements of the login command when the password is provided in the login security extension
+	LOGIN_SEC_PASSWORD_PLACEHOLDER = "[LOGIN-SECURITY]"
+
+	// Login security event types and levels as defined in RFC 8807
+	LOGIN_SEC_EVENT_TYPE_PASSWORD = "password"
+	LOGIN_SEC_EVENT_TYPE_NEW_PW   = "newPW"
+	LOGIN_SEC_EVENT_TYPE_STAT     = "stat"
+	LOGIN_SEC_EVENT_LEVEL_WARNING = "warning"
+	LOGIN_SEC_EVENT_LEVEL_ERROR   = "error"
+	// LOGIN_SEC_STAT_FAILED_LOGINS is the name of the stat event that reports failed login attempts
+	LOGIN_SEC_STAT_FAILED_LOGINS = "failedLogins"
+)
+
+// Greeting is the <epp><greeting> frame the server sends when a client connects and in response to a <hello> command.
+// It advertises the protocol version, language, object and extension services the server supports.
+// Ref: https://datatracker.ietf.org/doc/html/rfc5730#section-2.4
+type Greeting struct {
+	XMLName xml.Name `xml:"epp"`
+	XMLNS   string   `xml:"xmlns,attr"`
+	SvID    string   `xml:"greeting>svID"`
+	SvDate  string   `xml:"greeting>svDate"`
+	SvcMenu SvcMenu  `xml:"greeting>svcMenu"`
+	DCP     DCP      `xml:"greeting>dcp"`
+}
+
+// SvcMenu is the <svcMenu> element of the greeting
+type SvcMenu struct {
+	Version []string `xml:"version"`
+	Lang    []string `xml:"lang"`
+	ObjURIs []string `xml:"objURI"`
+	ExtURIs []string `xml:"svcExtension>extURI,omitempty"`
+}
+
+// DCP is the <dcp> element of the greeting that describes the data collection policy of the server.
+// The registry collects the data for provisioning and administrative purposes, publishes it to the public (e.g. through RDDS) and retains it as stated in its policies.
+type DCP struct {
+	Access    struct{} `xml:"access>all"`
+	Statement struct {
+		Admin     struct{} `xml:"purpose>admin"`
+		Prov      struct{} `xml:"purpose>prov"`
+		Ours      struct{} `xml:"recipient>ours"`
+		Public    struct{} `xml:"recipient>public"`
+		Retention struct{} `xml:"retention>stated"`
+	} `xml:"statement"`
+}
+
+// NewGreeting creates the greeting of the server at the provided time with the supported object and extension services
+func NewGreeting(at time.Time) *Greeting {
+	return &Greeting{
+		XMLNS:  EPP_NAMESPACE,
+		SvID:   EPP_SERVER_ID,
+		SvDate: formatEPPDate(at),
+		SvcMenu: SvcMenu{
+			Version: []string{EPP_VERSION},
+			Lang:    []string{EPP_LANG},
+			ObjURIs: slices.Clone(supportedObjURIs),
+			ExtURIs: slices.Clone(supportedExtURIs),
+		},
+	}
+}
+
+// Marshal returns the XML representation of the greeting including the XML header
+func (g *Greeting) Marshal() ([]byte, error) {
+	b, err := xml.Marshal(g)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), b...), nil
+}
+
+// LoginSecEvent is the <loginSec:event> element that notifies the client of a security event
+type LoginSecEvent struct {
+	Type   string `xml:"type,attr"`
+	Name   string `xml:"name,attr,omitempty"`
+	Level  string `xml:"level,attr"`
+	ExDate string `xml:"exDate,attr,omitempty"`
+	Value  string `xml:"value,attr,omitempty"`
+	Lang   string `xml:"lang,attr,omitempty"`
+	Msg    string `xml:",chardata"`
+}
+
+// LoginSecData is the <loginSec:loginSecData> extension of the login response as defined in RFC 8807
+// Ref: https://datatracker.ietf.org/doc/html/rfc8807#section-4.1
+type LoginSecData struct {
+	XMLName       xml.Name        `xml:"loginSec:loginSecData"`
+	XMLNSLoginSec string          `xml:"xmlns:loginSec,attr"`
+	Events        []LoginSecEvent `xml:"loginSec:event"`
+}
+
+// NewLoginSecData creates the <loginSec:loginSecData> element with the security events of a successful login, it returns nil if there are no events to report.
+// The client is warned when its password is about to expire and when there were failed login attempts since its previous login.
+func NewLoginSecData(result *commands.EPPLoginResult, at time.Time) *LoginSecData {
+	var events []LoginSecEvent
+	if result.Registrar.EPPPasswordExpiresSoon(at) {
+		events = append(events, LoginSecEvent{
+			Type:   LOGIN_SEC_EVENT_TYPE_PASSWORD,
+			Level:  LOGIN_SEC_EVENT_LEVEL_WARNING,
+			ExDate: formatEPPDate(result.Registrar.EPPPasswordExpiresAt()),
+			Lang:   EPP_LANG,
+			Msg:    "Password expiring soon",
+		})
+	}
+	if result.FailedLogins > 0 {
+		events = append(events, LoginSecEvent{
+			Type:  LOGIN_SEC_EVENT_TYPE_STAT,
+			Name:  LOGIN_SEC_STAT_FAILED_LOGINS,
+			Level: LOGIN_SEC_EVENT_LEVEL_WARNING,
+			Value: strconv.Itoa(result.FailedLogins),
+			Lang:  EPP_LANG,
+			Msg:   "Failed logins since the last successful login",
+		})
+	}
+	return newLoginSecData(events)
+}
+
+// NewLoginSecErrorData creates the <loginSec:loginSecData> element that explains why a login failed, it returns nil if the error is not a login security event
+func NewLoginSecErrorData(err error) *LoginSecData {
+	var event LoginSecEvent
+	switch {
+	case errors.Is(err, entities.ErrEPPPasswordExpired):
+		event = LoginSecEvent{Type: LOGIN_SEC_EVENT_TYPE_PASSWORD, Msg: "Password has expired"}
+	case errors.Is(err, entities.ErrInvalidEPPPassword), errors.Is(err, entities.ErrEPPPasswordReused):
+		event = LoginSecEvent{Type: LOGIN_SEC_EVENT_TYPE_NEW_PW, Msg: "New password does not meet the password policy"}
+	case errors.Is(err, entities.ErrEPPLoginLocked):
+		event = LoginSecEvent{Type: LOGIN_SEC_EVENT_TYPE_STAT, Name: LOGIN_SEC_STAT_FAILED_LOGINS, Msg: "Login locked after too many failed attempts"}
+	default:
+		return nil
+	}
+	event.Level = LOGIN_SEC_EVENT_LEVEL_ERROR
+	event.Lang = EPP_LANG
+	return newLoginSecData([]LoginSecEvent{event})
+}
+
+func newLoginSecData(events []LoginSecEvent) *LoginSecData {
+	if len(events) == 0 {
+		return nil
+	}
+	return &LoginSecData{
+		XMLNSLoginSec: LOGIN_SEC_NAMESPACE,
+		Events:        events,
+	}
+}
@@ -0,0 +1,118 @@
+package epp
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"testing"
+
+	"github.com/beevik/etree"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/require"
+)
+
+// testWriter is a epplib.Writer that buffers the response so we can inspect it
+type testWriter struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (w *testWriter) CloseAfterWrite() {
+	w.closed = true
+}
+
+// newTestDoc parses the provided XML into a document as the epplib.CommandMux would
+func newTestDoc(t *testing.T, xml string) *etree.Document {
+	t.Helper()
+	doc := etree.NewDocument()
+	require.NoError(t, doc.ReadFromString(xml))
+	return doc
+}
+
+// newTestContext returns a context with a session bound to the provided ClID that requested all supported extensions
+func newTestContext(clID string) context.Context {
+	s := NewSession()
+	s.Login(clID, entities.RegistrarStatusOK, supportedExtURIs)
+	return ContextWithSession(context.Background(), s)
+}
+
+// decodeResultCode returns the result code of the response
+func decodeResultCode(t *testing.T, b []byte) int {
+	t.Helper()
+	var r Response
+	require.NoError(t, xml.Unmarshal(b, &r))
+	require.NotEmpty(t, r.Result)
+	return r.Result[0].Code
+}
+
+func TestSession_ClID(t *testing.T) {
+	ctx := context.Background()
+	_, err := clIDFromContext(ctx)
+	require.ErrorIs(t, err, ErrNotLoggedIn)
+
+	s := NewSession()
+	ctx = ContextWithSession(ctx, s)
+	_, err = clIDFromContext(ctx)
+	require.ErrorIs(t, err, ErrNotLoggedIn)
+
+	// The session is a pointer so changes are visible to future commands on the same connection
+	s.Login("ClID-1", entities.RegistrarStatusOK, []string{SECDNS_NAMESPACE})
+	clID, err := clIDFromContext(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "ClID-1", clID)
+	require.Same(t, s, SessionFromContext(ctx))
+	require.True(t, hasExtensionFromContext(ctx, SECDNS_NAMESPACE))
+
+	s.Logout()
+	_, err = clIDFromContext(ctx)
+	require.ErrorIs(t, err, ErrNotLoggedIn)
+	require.False(t, hasExtensionFromContext(ctx, SECDNS_NAMESPACE))
+}
+
+func TestSession_ClIDForCreate(t *testing.T) {
+	testcases := []struct {
+		status  entities.RegistrarStatus
+		wantErr error
+	}{
+		{entities.RegistrarStatusOK, nil},
+		{entities.RegistrarStatusReadonly, entities.ErrRegistrarStatusPreventsCreate},
+		{entities.RegistrarStatusTerminated, entities.ErrRegistrarStatusPreventsCreate},
+	}
+
+	for _, tc := range testcases {
+		t.Run(string(tc.status), func(t *testing.T) {
+			s := NewSession()
+			s.Login("ClID-1", tc.status, nil)
+			clID, err := clIDForCreateFromContext(ContextWithSession(context.Background(), s))
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "ClID-1", clID)
+		})
+	}
+
+	_, err := clIDForCreateFromContext(context.Background())
+	require.ErrorIs(t, err, ErrNotLoggedIn)
+}
+
+func TestUnmarshalCommand(t *testing.T) {
+	doc := newTestDoc(t, `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<epp xmlns="urn:ietf:params:xml:ns:epp-1.0">
+  <command>
+    <check>
+      <domain:check xmlns:domain="urn:ietf:params:xml:ns:domain-1.0">
+        <domain:name>example.com</domain:name>
+        <domain:name>example.net</domain:name>
+      </domain:check>
+    </check>
+    <clTRID>ABC-12345</clTRID>
+  </command>
+</epp>`)
+
+	var cmd DomainCheckCommand
+	require.NoError(t, unmarshalCommand(doc, &cmd))
+	require.Equal(t, []string{"example.com", "example.net"}, cmd.Names)
+	require.Equal(t, "ABC-12345", cmd.ClTRID)
+}
@@ -0,0 +1,242 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+	"github.com/onasunnymorning/domain-os/internal/application/interfaces"
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/interface/rest/response"
+)
+
+// AllocationTokenController manages the allocation tokens (RFC 8495) that release blocked and premium domains to registrars
+type AllocationTokenController struct {
+	tokenService interfaces.AllocationTokenService
+}
+
+// NewAllocationTokenController returns a new AllocationTokenController and registers its routes
+func NewAllocationTokenController(e *gin.Engine, tokenService interfaces.AllocationTokenService, handler gin.HandlerFunc) *AllocationTokenController {
+	controller := &AllocationTokenController{
+		tokenService: tokenService,
+	}
+
+	tokenRouter := e.Group("/allocation-tokens", handler)
+	{
+		tokenRouter.GET("", controller.ListAllocationTokens)
+		tokenRouter.POST("", controller.CreateAllocationToken)
+		tokenRouter.GET("/count", controller.CountAllocationTokens)
+		tokenRouter.GET(":token", controller.GetAllocationToken)
+		tokenRouter.PUT(":token", controller.UpdateAllocationToken)
+		tokenRouter.DELETE(":token", controller.DeleteAllocationToken)
+	}
+
+	return controller
+}
+
+// CreateAllocationToken godoc
+// @Summary Create an allocation token
+// @Description Create an allocation token that allows a registrar to register a blocked domain and optionally gives a discount on the registration price.
+// @Description The token is bound to the TLD, or to the domain and its TLD if a domain is provided. If no token is provided a random token is generated.
+// @Tags AllocationTokens
+// @Accept json
+// @Produce json
+// @Param token body commands.CreateAllocationTokenCommand true "Allocation Token"
+// @Success 201 {object} entities.AllocationToken
+// @Failure 400
+// @Failure 500
+// @Router /allocation-tokens [post]
+func (ctrl *AllocationTokenController) CreateAllocationToken(ctx *gin.Context) {
+	var req commands.CreateAllocationTokenCommand
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		if err.Error() == "EOF" {
+			ctx.JSON(400, gin.H{"error": "missing request body"})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	token, err := ctrl.tokenService.CreateAllocationToken(ctx, &req)
+	if err != nil {
+		ctx.JSON(allocationTokenErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(201, token)
+}
+
+// GetAllocationToken godoc
+// @Summary Get an allocation token
+// @Description Get an allocation token including the number of times it was redeemed
+// @Tags AllocationTokens
+// @Produce json
+// @Param token path string true "Allocation Token"
+// @Success 200 {object} entities.AllocationToken
+// @Failure 404
+// @Failure 500
+// @Router /allocation-tokens/{token} [get]
+func (ctrl *AllocationTokenController) GetAllocationToken(ctx *gin.Context) {
+	token, err := ctrl.tokenService.GetAllocationToken(ctx, ctx.Param("token"))
+	if err != nil {
+		ctx.JSON(allocationTokenErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(200, token)
+}
+
+// UpdateAllocationToken godoc
+// @Summary Update an allocation token
+// @Description Replace the domain, registrar and phase the token is bound to and the conditions under which it can be used. The TLD of a token can't be changed.
+// @Tags AllocationTokens
+// @Accept json
+// @Produce json
+// @Param token path string true "Allocation Token"
+// @Param binding body commands.UpdateAllocationTokenCommand true "Binding"
+// @Success 200 {object} entities.AllocationToken
+// @Failure 400
+// @Failure 404
+// @Failure 500
+// @Router /allocation-tokens/{token} [put]
+func (ctrl *AllocationTokenController) UpdateAllocationToken(ctx *gin.Context) {
+	var req commands.UpdateAllocationTokenCommand
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		if err.Error() == "EOF" {
+			ctx.JSON(400, gin.H{"error": "missing request body"})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	token, err := ctrl.tokenService.UpdateAllocationToken(ctx, ctx.Param("token"), &req)
+	if err != nil {
+		ctx.JSON(allocationTokenErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(200, token)
+}
+
+// DeleteAllocationToken godoc
+// @Summary Delete an allocation token
+// @Description Delete an allocation token, it can't be used anymore
+// @Tags AllocationTokens
+// @Produce json
+// @Param token path string true "Allocation Token"
+// @Success 204
+// @Failure 500
+// @Router /allocation-tokens/{token} [delete]
+func (ctrl *AllocationTokenController) DeleteAllocationToken(ctx *gin.Context) {
+	if err := ctrl.tokenService.DeleteAllocationToken(ctx, ctx.Param("token")); err != nil {
+		ctx.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(204, nil)
+}
+
+// ListAllocationTokens godoc
+// @Summary List allocation tokens
+// @Description List allocation tokens
+// @Tags AllocationTokens
+// @Produce json
+// @Param pageSize query int false "Page Size"
+// @Param cursor query string false "Cursor"
+// @Param tld_equals query string false "TLD equals"
+// @Param domain_name_equals query string false "Domain name equals"
+// @Param clid_equals query string false "Registrar ClID equals"
+// @Param phase_name_equals query string false "Phase name equals"
+// @Success 200 {object} response.ListItemResult
+// @Failure 400
+// @Failure 500
+// @Router /allocation-tokens [get]
+func (ctrl *AllocationTokenController) ListAllocationTokens(ctx *gin.Context) {
+	query := queries.ListItemsQuery{}
+	resp := response.ListItemResult{}
+
+	var err error
+	query.Filter = getListAllocationTokensFilterFromContext(ctx)
+
+	query.PageSize, err = GetPageSize(ctx)
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	query.PageCursor, err = GetAndDecodeCursor(ctx)
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokens, cursor, err := ctrl.tokenService.ListAllocationTokens(ctx, query)
+	if err != nil {
+		ctx.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp.Data = tokens
+	resp.SetMeta(ctx, cursor, len(tokens), query.PageSize, query.Filter)
+
+	ctx.JSON(200, resp)
+}
+
+// CountAllocationTokens godoc
+// @Summary Returns a count of the allocation tokens that match the filter.
+// @Description Counts the allocation tokens that match the filter and returns a timestamped count including the filters that were used.
+// @Tags AllocationTokens
+// @Produce json
+// @Param tld_equals query string false "TLD equals"
+// @Param domain_name_equals query string false "Domain name equals"
+// @Param clid_equals query string false "Registrar ClID equals"
+// @Param phase_name_equals query string false "Phase name equals"
+// @Success 200 {object} response.CountResult
+// @Failure 500
+// @Router /allocation-tokens/count [get]
+func (ctrl *AllocationTokenController) CountAllocationTokens(ctx *gin.Context) {
+	result := response.CountResult{}
+
+	filter := getListAllocationTokensFilterFromContext(ctx)
+	result.Filter = filter
+
+	var err error
+	result.Count, err = ctrl.tokenService.CountAllocationTokens(ctx, filter)
+	if err != nil {
+		ctx.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(200, result)
+}
+
+func getListAllocationTokensFilterFromContext(ctx *gin.Context) queries.ListAllocationTokensFilter {
+	return queries.ListAllocationTokensFilter{
+		TldEquals:        ctx.Query("tld_equals"),
+		DomainNameEquals: ctx.Query("domain_name_equals"),
+		ClIDEquals:       ctx.Query("clid_equals"),
+		PhaseNameEquals:  ctx.Query("phase_name_equals"),
+	}
+}
+
+// allocationTokenErrorStatusCode maps the errors returned by the allocation token service to an HTTP status code
+func allocationTokenErrorStatusCode(err error) int {
+	switch {
+	case errors.Is(err, entities.ErrAllocationTokenNotFound):
+		return 404
+	case errors.Is(err, entities.ErrInvalidAllocationToken):
+		return 400
+	default:
+		return 500
+	}
+}
+
+// isAllocationTokenError checks if the error means an allocation token can't be used for a domain
+func isAllocationTokenError(err error) bool {
+	return errors.Is(err, entities.ErrAllocationTokenMismatch) ||
+		errors.Is(err, entities.ErrAllocationTokenExpired) ||
+		errors.Is(err, entities.ErrAllocationTokenRedeemed)
+}
@@ -0,0 +1,53 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetListAllocationTokensFilterFromContext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	req, err := http.NewRequest(http.MethodGet, "/test?tld_equals=apex&domain_name_equals=reserved.apex&clid_equals=rar1&phase_name_equals=GA", nil)
+	assert.NoError(t, err)
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = req
+
+	assert.Equal(t, queries.ListAllocationTokensFilter{
+		TldEquals:        "apex",
+		DomainNameEquals: "reserved.apex",
+		ClIDEquals:       "rar1",
+		PhaseNameEquals:  "GA",
+	}, getListAllocationTokensFilterFromContext(ctx))
+}
+
+func TestAllocationTokenErrorStatusCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{entities.ErrAllocationTokenNotFound, 404},
+		{errors.Join(entities.ErrInvalidAllocationToken, entities.ErrDuplicateAllocationToken), 400},
+		{errors.New("db down"), 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			assert.Equal(t, tt.want, allocationTokenErrorStatusCode(tt.err))
+		})
+	}
+}
+
+func TestIsAllocationTokenError(t *testing.T) {
+	assert.True(t, isAllocationTokenError(errors.Join(entities.ErrAllocationTokenMismatch, entities.ErrAllocationTokenNotFound)))
+	assert.True(t, isAllocationTokenError(entities.ErrAllocationTokenExpired))
+	assert.True(t, isAllocationTokenError(entities.ErrAllocationTokenRedeemed))
+	assert.False(t, isAllocationTokenError(entities.ErrInvalidAllocationToken))
+}
@@ -234,6 +234,9 @@ func (ctrl *ContactController) DeleteContactByID(ctx *gin.Context) {
 	if err != nil {
 		if errors.Is(err, entities.ErrContactNotFound) {
 			ctx.JSON(http.StatusNoContent, nil)
+		} else if errors.Is(err, entities.ErrContactIsLinked) || errors.Is(err, entities.ErrContactDeleteNotAllowed) {
+			e.Details.Error = err.Error()
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 		} else {
 			e.Details.Error = err.Error()
 			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -0,0 +1,248 @@
+package rest
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/miekg/dns"
+	"github.com/onasunnymorning/domain-os/internal/application/interfaces"
+	"github.com/onasunnymorning/domain-os/internal/application/services"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/interface/rest/request"
+	"github.com/onasunnymorning/domain-os/internal/interface/rest/response"
+)
+
+// DNSSECController manages the DNSSEC keys of the TLD zones, the zones are signed by the DNS server using these keys
+type DNSSECController struct {
+	dnssecService interfaces.DNSSECService
+}
+
+// NewDNSSECController creates a new DNSSECController and registers the routes
+func NewDNSSECController(e *gin.Engine, dnssecService interfaces.DNSSECService, handler gin.HandlerFunc) *DNSSECController {
+	controller := &DNSSECController{
+		dnssecService: dnssecService,
+	}
+
+	dnssecRoutes := e.Group("/tlds/:tldName/dnssec", handler)
+	{
+		dnssecRoutes.GET("keys", controller.ListKeys)
+		dnssecRoutes.POST("keys", controller.CreateKey)
+		dnssecRoutes.POST("zsk/prepublish", controller.PrepublishZSK)
+		dnssecRoutes.POST("zsk/:keyTag/activate", controller.ActivateZSK)
+		dnssecRoutes.DELETE("zsk/retired", controller.RemoveRetiredZSKs)
+		dnssecRoutes.GET("ds", controller.GetDS)
+		dnssecRoutes.POST("ds", controller.PublishDS)
+	}
+
+	return controller
+}
+
+// ListKeys godoc
+// @Summary List the DNSSEC keys of a TLD
+// @Description List the DNSSEC keys of the TLD zone with their timing metadata. The private keys are never returned.
+// @Tags DNSSEC
+// @Produce json
+// @Param tldName path string true "TLD Name"
+// @Success 200 {array} response.DNSSECKey
+// @Failure 404
+// @Failure 500
+// @Router /tlds/{tldName}/dnssec/keys [get]
+func (ctrl *DNSSECController) ListKeys(ctx *gin.Context) {
+	keys, err := ctrl.dnssecService.ListKeys(ctx, strings.ToLower(ctx.Param("tldName")))
+	if err != nil {
+		ctx.JSON(dnssecErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(200, toDNSSECKeyResponses(keys))
+}
+
+// CreateKey godoc
+// @Summary Create a DNSSEC key for a TLD
+// @Description Generates a new KSK or ZSK for the TLD zone that is published and active immediately, using the configured algorithm.
+// @Description Use this to sign a zone for the first time and publish the DS afterwards. Use the ZSK rollover workflow to replace a ZSK of a signed zone.
+// @Tags DNSSEC
+// @Accept json
+// @Produce json
+// @Param tldName path string true "TLD Name"
+// @Param key body request.CreateDNSSECKeyRequest true "Key type (KSK or ZSK)"
+// @Success 201 {object} response.DNSSECKey
+// @Failure 400
+// @Failure 404
+// @Failure 500
+// @Router /tlds/{tldName}/dnssec/keys [post]
+func (ctrl *DNSSECController) CreateKey(ctx *gin.Context) {
+	var req request.CreateDNSSECKeyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	key, err := ctrl.dnssecService.CreateKey(ctx, strings.ToLower(ctx.Param("tldName")), entities.DNSSECKeyType(strings.ToUpper(req.Type)))
+	if err != nil {
+		ctx.JSON(dnssecErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(201, response.FromDNSSECKey(key))
+}
+
+// PrepublishZSK godoc
+// @Summary Pre-publish a new ZSK for a TLD
+// @Description Generates a new ZSK that is added to the DNSKEY RRset of the TLD zone, but does not sign the zone until it is activated. This is the first step of a ZSK rollover.
+// @Tags DNSSEC
+// @Produce json
+// @Param tldName path string true "TLD Name"
+// @Success 201 {object} response.DNSSECKey
+// @Failure 404
+// @Failure 500
+// @Router /tlds/{tldName}/dnssec/zsk/prepublish [post]
+func (ctrl *DNSSECController) PrepublishZSK(ctx *gin.Context) {
+	key, err := ctrl.dnssecService.PrepublishZSK(ctx, strings.ToLower(ctx.Param("tldName")))
+	if err != nil {
+		ctx.JSON(dnssecErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(201, response.FromDNSSECKey(key))
+}
+
+// ActivateZSK godoc
+// @Summary Activate a pre-published ZSK of a TLD
+// @Description The ZSK starts signing the TLD zone, the ZSKs that were active until now stop signing but remain in the DNSKEY RRset until they are removed.
+// @Tags DNSSEC
+// @Produce json
+// @Param tldName path string true "TLD Name"
+// @Param keyTag path int true "Key tag of the ZSK"
+// @Success 200 {object} response.DNSSECKey
+// @Failure 400
+// @Failure 404
+// @Failure 500
+// @Router /tlds/{tldName}/dnssec/zsk/{keyTag}/activate [post]
+func (ctrl *DNSSECController) ActivateZSK(ctx *gin.Context) {
+	keyTag, err := strconv.ParseUint(ctx.Param("keyTag"), 10, 16)
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": "invalid key tag"})
+		return
+	}
+
+	key, err := ctrl.dnssecService.ActivateZSK(ctx, strings.ToLower(ctx.Param("tldName")), uint16(keyTag))
+	if err != nil {
+		ctx.JSON(dnssecErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(200, response.FromDNSSECKey(key))
+}
+
+// RemoveRetiredZSKs godoc
+// @Summary Remove the retired ZSKs of a TLD
+// @Description Removes the ZSKs that no longer sign the TLD zone from the DNSKEY RRset and deletes them. This is the last step of a ZSK rollover,
+// @Description only call it once the signatures of these keys have expired from the caches.
+// @Tags DNSSEC
+// @Produce json
+// @Param tldName path string true "TLD Name"
+// @Success 200 {array} response.DNSSECKey
+// @Failure 404
+// @Failure 500
+// @Router /tlds/{tldName}/dnssec/zsk/retired [delete]
+func (ctrl *DNSSECController) RemoveRetiredZSKs(ctx *gin.Context) {
+	keys, err := ctrl.dnssecService.RemoveRetiredZSKs(ctx, strings.ToLower(ctx.Param("tldName")))
+	if err != nil {
+		ctx.JSON(dnssecErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(200, toDNSSECKeyResponses(keys))
+}
+
+// GetDS godoc
+// @Summary Get the DS records of a TLD
+// @Description Get the DS records (SHA-256) of the published KSKs of the TLD zone. Use format=text to get the records in presentation format.
+// @Tags DNSSEC
+// @Produce json
+// @Param tldName path string true "TLD Name"
+// @Param format query string false "Output format, json or text"
+// @Success 200 {object} response.DSResponse
+// @Failure 404
+// @Failure 500
+// @Router /tlds/{tldName}/dnssec/ds [get]
+func (ctrl *DNSSECController) GetDS(ctx *gin.Context) {
+	tldName := strings.ToLower(ctx.Param("tldName"))
+	ds, err := ctrl.dnssecService.GetDS(ctx, tldName)
+	if err != nil {
+		ctx.JSON(dnssecErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	writeDSResponse(ctx, tldName, ds)
+}
+
+// PublishDS godoc
+// @Summary Publish the DS records of a TLD
+// @Description Stores the DS records (SHA-256) of the published KSKs of the TLD zone for submission to the parent zone and returns them.
+// @Tags DNSSEC
+// @Produce json
+// @Param tldName path string true "TLD Name"
+// @Param format query string false "Output format, json or text"
+// @Success 200 {object} response.DSResponse
+// @Failure 404
+// @Failure 500
+// @Router /tlds/{tldName}/dnssec/ds [post]
+func (ctrl *DNSSECController) PublishDS(ctx *gin.Context) {
+	tldName := strings.ToLower(ctx.Param("tldName"))
+	ds, err := ctrl.dnssecService.PublishDS(ctx, tldName)
+	if err != nil {
+		ctx.JSON(dnssecErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	writeDSResponse(ctx, tldName, ds)
+}
+
+// writeDSResponse writes the DS records as JSON, or in presentation format if format=text
+func writeDSResponse(ctx *gin.Context, tldName string, ds []*dns.DS) {
+	records := make([]string, len(ds))
+	for i, rr := range ds {
+		records[i] = rr.String()
+	}
+
+	if format := ctx.Query("format"); format == "text" {
+		var stringResponse string
+		for _, rr := range records {
+			stringResponse += rr + "\n"
+		}
+		ctx.String(200, "%s", stringResponse)
+		return
+	}
+
+	ctx.JSON(200, response.DSResponse{
+		TLD:       tldName,
+		Timestamp: time.Now().UTC(),
+		DS:        records,
+	})
+}
+
+// toDNSSECKeyResponses converts the keys to their response
+func toDNSSECKeyResponses(keys []*entities.DNSSECKey) []response.DNSSECKey {
+	resp := make([]response.DNSSECKey, len(keys))
+	for i, key := range keys {
+		resp[i] = response.FromDNSSECKey(key)
+	}
+	return resp
+}
+
+// dnssecErrorStatusCode maps the errors of the DNSSECService to an HTTP status code
+func dnssecErrorStatusCode(err error) int {
+	switch {
+	case errors.Is(err, entities.ErrTLDNotFound), errors.Is(err, entities.ErrDNSSECKeyNotFound):
+		return 404
+	case errors.Is(err, entities.ErrInvalidDNSSECKeyType), errors.Is(err, services.ErrNotAZSK), errors.Is(err, services.ErrZSKNotPublished):
+		return 400
+	default:
+		return 500
+	}
+}
@@ -0,0 +1,125 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/miekg/dns"
+	"github.com/onasunnymorning/domain-os/internal/application/services"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/interface/rest/response"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockDNSSECService is a mock implementation of the DNSSECService
+type MockDNSSECService struct {
+	mock.Mock
+}
+
+func (m *MockDNSSECService) ListKeys(ctx context.Context, tldName string) ([]*entities.DNSSECKey, error) {
+	args := m.Called(ctx, tldName)
+	return args.Get(0).([]*entities.DNSSECKey), args.Error(1)
+}
+
+func (m *MockDNSSECService) CreateKey(ctx context.Context, tldName string, keyType entities.DNSSECKeyType) (*entities.DNSSECKey, error) {
+	args := m.Called(ctx, tldName, keyType)
+	return args.Get(0).(*entities.DNSSECKey), args.Error(1)
+}
+
+func (m *MockDNSSECService) PrepublishZSK(ctx context.Context, tldName string) (*entities.DNSSECKey, error) {
+	args := m.Called(ctx, tldName)
+	return args.Get(0).(*entities.DNSSECKey), args.Error(1)
+}
+
+func (m *MockDNSSECService) ActivateZSK(ctx context.Context, tldName string, keyTag uint16) (*entities.DNSSECKey, error) {
+	args := m.Called(ctx, tldName, keyTag)
+	return args.Get(0).(*entities.DNSSECKey), args.Error(1)
+}
+
+func (m *MockDNSSECService) RemoveRetiredZSKs(ctx context.Context, tldName string) ([]*entities.DNSSECKey, error) {
+	args := m.Called(ctx, tldName)
+	return args.Get(0).([]*entities.DNSSECKey), args.Error(1)
+}
+
+func (m *MockDNSSECService) GetDS(ctx context.Context, tldName string) ([]*dns.DS, error) {
+	args := m.Called(ctx, tldName)
+	return args.Get(0).([]*dns.DS), args.Error(1)
+}
+
+func (m *MockDNSSECService) PublishDS(ctx context.Context, tldName string) ([]*dns.DS, error) {
+	args := m.Called(ctx, tldName)
+	return args.Get(0).([]*dns.DS), args.Error(1)
+}
+
+func TestDNSSECController(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	zsk, err := entities.NewDNSSECKey("example", dns.ECDSAP256SHA256, entities.DNSSECKeyTypeZSK, 3600, now)
+	require.NoError(t, err)
+	zsk.Publish = now
+
+	svc := new(MockDNSSECService)
+	svc.On("CreateKey", mock.Anything, "example", entities.DNSSECKeyTypeKSK).Return(zsk, nil)
+	svc.On("CreateKey", mock.Anything, "example", entities.DNSSECKeyType("CSK")).Return((*entities.DNSSECKey)(nil), entities.ErrInvalidDNSSECKeyType)
+	svc.On("PrepublishZSK", mock.Anything, "missing").Return((*entities.DNSSECKey)(nil), entities.ErrTLDNotFound)
+	svc.On("ActivateZSK", mock.Anything, "example", zsk.KeyTag()).Return(zsk, nil)
+	svc.On("ActivateZSK", mock.Anything, "example", uint16(1)).Return((*entities.DNSSECKey)(nil), services.ErrZSKNotPublished)
+	svc.On("GetDS", mock.Anything, "example").Return([]*dns.DS{zsk.DS()}, nil)
+
+	router := gin.New()
+	NewDNSSECController(router, svc, MockGinHandler())
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{name: "create key", method: http.MethodPost, path: "/tlds/EXAMPLE/dnssec/keys", body: `{"Type":"ksk"}`, wantStatus: http.StatusCreated},
+		{name: "create key without type", method: http.MethodPost, path: "/tlds/example/dnssec/keys", body: `{}`, wantStatus: http.StatusBadRequest},
+		{name: "create key with invalid type", method: http.MethodPost, path: "/tlds/example/dnssec/keys", body: `{"Type":"CSK"}`, wantStatus: http.StatusBadRequest},
+		{name: "prepublish for unknown TLD", method: http.MethodPost, path: "/tlds/missing/dnssec/zsk/prepublish", wantStatus: http.StatusNotFound},
+		{name: "activate with invalid key tag", method: http.MethodPost, path: "/tlds/example/dnssec/zsk/70000/activate", wantStatus: http.StatusBadRequest},
+		{name: "activate unpublished key", method: http.MethodPost, path: "/tlds/example/dnssec/zsk/1/activate", wantStatus: http.StatusBadRequest},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, tc.wantStatus, w.Code, w.Body.String())
+		})
+	}
+
+	t.Run("activated key", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/tlds/example/dnssec/zsk/"+strconv.Itoa(int(zsk.KeyTag()))+"/activate", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		var key response.DNSSECKey
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &key))
+		require.Equal(t, zsk.KeyTag(), key.KeyTag)
+		require.Equal(t, "ZSK", key.Type)
+		require.Equal(t, "ECDSAP256SHA256", key.Algorithm)
+		require.Equal(t, now, *key.Publish)
+		require.Nil(t, key.Activate)
+		require.NotContains(t, w.Body.String(), "rivate")
+	})
+
+	t.Run("DS as text", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/tlds/example/dnssec/ds?format=text", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, zsk.DS().String()+"\n", w.Body.String())
+	})
+}
@@ -0,0 +1,250 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/application/services"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/interface/rest/response"
+)
+
+// CreateDomainApplication godoc
+// @Summary EPP-style launch application create command
+// @Description Apply for a domain in a launch phase that requires validation on behalf of the registrar in the body.
+// @Description The application is pending validation until the registry validates it, competing applications for the same domain are allowed.
+// @Tags Domains
+// @Accept json
+// @Produce json
+// @Param application body commands.CreateDomainApplicationCommand true "Application"
+// @Success 201 {object} entities.DomainApplication
+// @Failure 400
+// @Failure 403
+// @Failure 500
+// @Router /domains/applications [post]
+func (ctrl *DomainController) CreateDomainApplication(ctx *gin.Context) {
+	var req commands.CreateDomainApplicationCommand
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		if err.Error() == "EOF" {
+			ctx.JSON(400, gin.H{"error": "missing request body"})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	app, err := ctrl.domainService.CreateDomainApplication(ctx, &req)
+	if err != nil {
+		ctx.JSON(applicationErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(201, app)
+}
+
+// GetDomainApplication godoc
+// @Summary Get a domain application
+// @Description Get a domain application by its ID
+// @Tags Domains
+// @Produce json
+// @Param id path string true "Application ID"
+// @Success 200 {object} entities.DomainApplication
+// @Failure 404
+// @Failure 500
+// @Router /domains/applications/{id} [get]
+func (ctrl *DomainController) GetDomainApplication(ctx *gin.Context) {
+	app, err := ctrl.domainService.GetDomainApplication(ctx, ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(applicationErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(200, app)
+}
+
+// WithdrawDomainApplication godoc
+// @Summary Withdraw a domain application
+// @Description Delete a domain application on behalf of the registry. Allocated, rejected and invalid applications can't be withdrawn.
+// @Tags Domains
+// @Produce json
+// @Param id path string true "Application ID"
+// @Success 204
+// @Failure 400
+// @Failure 404
+// @Failure 500
+// @Router /domains/applications/{id} [delete]
+func (ctrl *DomainController) WithdrawDomainApplication(ctx *gin.Context) {
+	if err := ctrl.domainService.WithdrawDomainApplication(ctx, ctx.Param("id"), ""); err != nil {
+		ctx.JSON(applicationErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(204, nil)
+}
+
+// SetDomainApplicationStatus godoc
+// @Summary Set the status of a domain application
+// @Description Set the status of a domain application after validating it (validated, invalid, pendingAllocation or rejected).
+// @Description Use the allocate endpoint to allocate the domain. The registrar is notified through a poll message.
+// @Tags Domains
+// @Accept json
+// @Produce json
+// @Param id path string true "Application ID"
+// @Param status body commands.DomainApplicationStatusCommand true "Status"
+// @Success 200 {object} entities.DomainApplication
+// @Failure 400
+// @Failure 404
+// @Failure 500
+// @Router /domains/applications/{id}/status [post]
+func (ctrl *DomainController) SetDomainApplicationStatus(ctx *gin.Context) {
+	var req commands.DomainApplicationStatusCommand
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	app, err := ctrl.domainService.SetDomainApplicationStatus(ctx, ctx.Param("id"), entities.ApplicationStatus(req.Status), req.Reason)
+	if err != nil {
+		ctx.JSON(applicationErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(200, app)
+}
+
+// AllocateDomainApplication godoc
+// @Summary Allocate a domain application
+// @Description Register the domain of a validated application to the applicant, who is charged for the registration in the phase of the application.
+// @Description The competing applications for the domain are rejected. All applicants are notified through a poll message.
+// @Tags Domains
+// @Produce json
+// @Param id path string true "Application ID"
+// @Success 200 {object} entities.DomainApplication
+// @Failure 400
+// @Failure 404
+// @Failure 500
+// @Router /domains/applications/{id}/allocate [post]
+func (ctrl *DomainController) AllocateDomainApplication(ctx *gin.Context) {
+	app, err := ctrl.domainService.AllocateDomainApplication(ctx, ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(applicationErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(200, app)
+}
+
+// ListDomainApplications godoc
+// @Summary List domain applications
+// @Description List domain applications
+// @Tags Domains
+// @Produce json
+// @Param pageSize query int false "Page Size"
+// @Param cursor query string false "Cursor"
+// @Param domain_name_equals query string false "Domain name equals"
+// @Param domain_name_like query string false "Domain name like"
+// @Param clid_equals query string false "Registrar ClID equals"
+// @Param phase_name_equals query string false "Phase name equals"
+// @Param status_equals query string false "Application status equals"
+// @Success 200 {object} response.ListItemResult
+// @Failure 400
+// @Failure 500
+// @Router /domains/applications [get]
+func (ctrl *DomainController) ListDomainApplications(ctx *gin.Context) {
+	query := queries.ListItemsQuery{}
+	resp := response.ListItemResult{}
+
+	var err error
+	query.Filter = getListDomainApplicationsFilterFromContext(ctx)
+
+	query.PageSize, err = GetPageSize(ctx)
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	query.PageCursor, err = GetAndDecodeCursor(ctx)
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	apps, cursor, err := ctrl.domainService.ListDomainApplications(ctx, query)
+	if err != nil {
+		ctx.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp.Data = apps
+	resp.SetMeta(ctx, cursor, len(apps), query.PageSize, query.Filter)
+
+	ctx.JSON(200, resp)
+}
+
+// CountDomainApplications godoc
+// @Summary Returns a count of the domain applications that match the filter.
+// @Description Counts the domain applications that match the filter and returns a timestamped count including the filters that were used.
+// @Tags Domains
+// @Produce json
+// @Param domain_name_equals query string false "Domain name equals"
+// @Param domain_name_like query string false "Domain name like"
+// @Param clid_equals query string false "Registrar ClID equals"
+// @Param phase_name_equals query string false "Phase name equals"
+// @Param status_equals query string false "Application status equals"
+// @Success 200 {object} response.CountResult
+// @Failure 500
+// @Router /domains/applications/count [get]
+func (ctrl *DomainController) CountDomainApplications(ctx *gin.Context) {
+	result := response.CountResult{}
+
+	filter := getListDomainApplicationsFilterFromContext(ctx)
+	result.Filter = filter
+
+	var err error
+	result.Count, err = ctrl.domainService.CountDomainApplications(ctx, filter)
+	if err != nil {
+		ctx.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(200, result)
+}
+
+func getListDomainApplicationsFilterFromContext(ctx *gin.Context) queries.ListDomainApplicationsFilter {
+	return queries.ListDomainApplicationsFilter{
+		DomainNameEquals: ctx.Query("domain_name_equals"),
+		DomainNameLike:   ctx.Query("domain_name_like"),
+		ClIDEquals:       ctx.Query("clid_equals"),
+		PhaseNameEquals:  ctx.Query("phase_name_equals"),
+		StatusEquals:     ctx.Query("status_equals"),
+	}
+}
+
+// applicationErrorStatusCode maps the errors returned by the domain application services to an HTTP status code
+func applicationErrorStatusCode(err error) int {
+	switch {
+	case errors.Is(err, entities.ErrDomainApplicationNotFound), errors.Is(err, entities.ErrTLDNotFound):
+		return 404
+	case errors.Is(err, entities.ErrInvalidRegistrar), errors.Is(err, services.ErrRegistrarNotAccredited):
+		return 403
+	case errors.Is(err, entities.ErrDomainApplicationFinal),
+		errors.Is(err, entities.ErrInvalidApplicationStatus),
+		errors.Is(err, entities.ErrInvalidDomainApplication),
+		errors.Is(err, services.ErrPhaseNotApplicationBased),
+		errors.Is(err, services.ErrDomainExists),
+		errors.Is(err, services.ErrDomainBlocked),
+		errors.Is(err, entities.ErrPhaseNotFound),
+		errors.Is(err, entities.ErrNoActivePhase),
+		errors.Is(err, entities.ErrMarkRequired),
+		errors.Is(err, entities.ErrClaimsNoticeRequired),
+		errors.Is(err, entities.ErrInvalidClaimsNotice),
+		errors.Is(err, entities.ErrHostNotFound),
+		errors.Is(err, entities.ErrInvalidAuthInfo):
+		return 400
+	default:
+		return 500
+	}
+}
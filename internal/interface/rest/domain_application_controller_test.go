@@ -0,0 +1,51 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/application/services"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetListDomainApplicationsFilterFromContext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	req, err := http.NewRequest(http.MethodGet, "/test?domain_name_equals=example.com&domain_name_like=example&clid_equals=ClID-1&phase_name_equals=landrush&status_equals=validated", nil)
+	assert.NoError(t, err)
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = req
+
+	assert.Equal(t, queries.ListDomainApplicationsFilter{
+		DomainNameEquals: "example.com",
+		DomainNameLike:   "example",
+		ClIDEquals:       "ClID-1",
+		PhaseNameEquals:  "landrush",
+		StatusEquals:     "validated",
+	}, getListDomainApplicationsFilterFromContext(ctx))
+}
+
+func TestApplicationErrorStatusCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{entities.ErrDomainApplicationNotFound, 404},
+		{entities.ErrInvalidRegistrar, 403},
+		{errors.Join(services.ErrRegistrarNotAccredited, errors.New("details")), 403},
+		{entities.ErrDomainApplicationFinal, 400},
+		{errors.Join(entities.ErrInvalidApplicationStatus, errors.New("details")), 400},
+		{errors.Join(services.ErrPhaseNotApplicationBased, errors.New("details")), 400},
+		{entities.ErrClaimsNoticeRequired, 400},
+		{errors.New("db down"), 500},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, applicationErrorStatusCode(tt.err), tt.err.Error())
+	}
+}
@@ -60,6 +60,25 @@ func NewDomainController(e *gin.Engine, domService interfaces.DomainService, han
 		domainGroup.POST(":name/renew/force", controller.ForceRenew)
 		domainGroup.DELETE(":name/markdelete", controller.MarkDomainForDeletion)
 		domainGroup.POST(":name/restore", controller.RestoreDomain)
+		domainGroup.POST(":name/restore/report", controller.ReportDomainRestore)
+
+		// Transfer endpoints
+		domainGroup.GET("transfers", controller.ListDomainTransfers)
+		domainGroup.GET("transfers/count", controller.CountDomainTransfers)
+		domainGroup.GET(":name/transfer", controller.GetDomainTransfer)
+		domainGroup.POST(":name/transfer", controller.RequestDomainTransfer)
+		domainGroup.POST(":name/transfer/approve", controller.ApproveDomainTransfer)
+		domainGroup.POST(":name/transfer/reject", controller.RejectDomainTransfer)
+		domainGroup.POST(":name/transfer/cancel", controller.CancelDomainTransfer)
+
+		// Launch application endpoints
+		domainGroup.GET("applications", controller.ListDomainApplications)
+		domainGroup.GET("applications/count", controller.CountDomainApplications)
+		domainGroup.POST("applications", controller.CreateDomainApplication)
+		domainGroup.GET("applications/:id", controller.GetDomainApplication)
+		domainGroup.DELETE("applications/:id", controller.WithdrawDomainApplication)
+		domainGroup.POST("applications/:id/status", controller.SetDomainApplicationStatus)
+		domainGroup.POST("applications/:id/allocate", controller.AllocateDomainApplication)
 
 		// Lifecycle endpoints
 		domainGroup.GET("expiring", controller.ListExpiringDomains)
@@ -68,6 +87,7 @@ func NewDomainController(e *gin.Engine, domService interfaces.DomainService, han
 		domainGroup.GET("purgeable/count", controller.CountPurgeableDomains)
 		domainGroup.GET("restored", controller.ListRestoredDomains)
 		domainGroup.GET("restored/count", controller.CountRestoredDomains)
+		domainGroup.POST(":name/restore/complete", controller.CompleteDomainRestore)
 		domainGroup.GET(":name/canautorenew", controller.CanAutoRenew)
 		domainGroup.POST(":name/autorenew", controller.AutoRenewDomain)
 		domainGroup.DELETE(":name/expire", controller.Expire)
@@ -224,12 +244,15 @@ func (ctrl *DomainController) DeleteDomainByName(ctx *gin.Context) {
 // @Param tld_equals query string false "TLD Equals"
 // @Param name_equals query string false "Name Equals"
 // @Param name_like query string false "Name Like"
+// @Param name_matches query string false "Name Matches, * matches any sequence of characters"
+// @Param ns_name_matches query string false "Nameserver Name Matches, * matches any sequence of characters"
 // @Param roid_greater_than query string false "RoID Greater Than"
 // @Param roid_less_than query string false "RoID Less Than"
 // @Param created_after query string false "Created After"
 // @Param created_before query string false "Created Before"
 // @Param expires_after query string false "Expires After"
 // @Param expires_before query string false "Expires Before"
+// @Param updated_after query string false "Updated After"
 // @Success 200 {array} response.ListItemResult
 // @Failure 400
 // @Failure 500
@@ -456,7 +479,8 @@ func (ctrl *DomainController) RemoveHostFromDomainByHostName(ctx *gin.Context) {
 // @Description This operation requires the Registrar to be accredited for the TLD.
 // @Description Any references to Contact or Host objects must exist in the system prior to calling this endpoint.
 // @Description The optional Phase parameter can be used to register a domain in a specific phase. The phase must be active at the moment of regisration.
-// @Description If the Registrar is not accredited, the request will fail with a 403 status code.
+// @Description The optional AllocationToken parameter allows the registration of a blocked domain and applies the discount of the token.
+// @Description If the Registrar is not accredited or the allocation token can't be used, the request will fail with a 403 status code.
 // @Description If the domain is invalid in some way, the request will fail with a 400 status code with an error message.
 // @Tags Domains
 // @Accept json
@@ -492,7 +516,7 @@ func (ctrl *DomainController) RegisterDomain(ctx *gin.Context) {
 			ctx.JSON(400, gin.H{"error": err.Error()})
 			return
 		}
-		if errors.Is(err, services.ErrRegistrarNotAccredited) {
+		if errors.Is(err, services.ErrRegistrarNotAccredited) || isAllocationTokenError(err) {
 			ctx.JSON(403, gin.H{"error": err.Error()})
 			return
 		}
@@ -507,28 +531,32 @@ func (ctrl *DomainController) RegisterDomain(ctx *gin.Context) {
 // @Summary Check if a domain is available
 // @Description A Domain is available if:
 // @Description - The domain does not exist
-// @Description - No NNDN exists with the same name
+// @Description - No NNDN exists with the same name, unless a valid allocation token is provided
 // @Description - The domain label is valid in the TLDs current GA phase OR the provided phase name)
-// @Description It will return a 400 error if the TLD is not found, the phase is not found, the phase is not active, or the label is not valid in the phase.
+// @Description - The allocation token, if provided, can be used by the registrar for the domain in the phase
+// @Description It will return a 400 error if the TLD is not found, the phase is not found, the phase is not active, the label is not valid in the phase or the allocation token can't be used.
 // @Description It will return a 500 error if an unexpected error occurs.
 // @Tags Domains
 // @Produce json
 // @Param name path string true "Domain Name"
 // @Param phase query string false "Phase Name"
+// @Param clid query string false "Registrar ClID, required to check an allocation token that is bound to a registrar"
+// @Param allocation_token query string false "Allocation Token"
 // @Success 200 {object} queries.DomainCheckResult
 // @Failure 400
 // @Failure 500
 // @Router /domains/{name}/available [get]
 func (ctrl *DomainController) CheckDomainAvailability(ctx *gin.Context) {
 	// Call the service to check the domain
-	result, err := ctrl.domainService.CheckDomainAvailability(ctx, ctx.Param("name"), ctx.Query("phase"))
+	result, err := ctrl.domainService.CheckDomainAvailability(ctx, ctx.Param("name"), ctx.Query("phase"), ctx.Query("clid"), ctx.Query("allocation_token"))
 	if err != nil {
 		// Return 400 if we encounter missing configuration to make a decision
 		if errors.Is(
 			err, entities.ErrTLDNotFound) ||
 			errors.Is(err, entities.ErrPhaseNotFound) ||
 			errors.Is(err, entities.ErrNoActivePhase) ||
-			errors.Is(err, entities.ErrLabelNotValidInPhase) {
+			errors.Is(err, entities.ErrLabelNotValidInPhase) ||
+			isAllocationTokenError(err) {
 			ctx.JSON(400, gin.H{"error": err.Error()})
 			return
 		}
@@ -787,7 +815,7 @@ func (ctrl *DomainController) Expire(ctx *gin.Context) {
 // @Failure 500
 // @Router /domains/{name}/restore [post]
 func (ctrl *DomainController) RestoreDomain(ctx *gin.Context) {
-	dom, err := ctrl.domainService.RestoreDomain(ctx, ctx.Param("name"))
+	dom, err := ctrl.domainService.RestoreDomain(ctx, ctx.Param("name"), commands.FeeExtension{})
 	if err != nil {
 		if errors.Is(err, entities.ErrDomainNotFound) {
 			ctx.JSON(404, gin.H{"error": err.Error()})
@@ -865,12 +893,15 @@ func (ctrl *DomainController) UnSetDropCatch(ctx *gin.Context) {
 // @Param tld_equals query string false "TLD Equals"
 // @Param name_equals query string false "Name Equals"
 // @Param name_like query string false "Name Like"
+// @Param name_matches query string false "Name Matches, * matches any sequence of characters"
+// @Param ns_name_matches query string false "Nameserver Name Matches, * matches any sequence of characters"
 // @Param roid_greater_than query string false "RoID Greater Than"
 // @Param roid_less_than query string false "RoID Less Than"
 // @Param created_after query string false "Created After"
 // @Param created_before query string false "Created Before"
 // @Param expires_after query string false "Expires After"
 // @Param expires_before query string false "Expires Before"
+// @Param updated_after query string false "Updated After"
 // @Produce json
 // @Success 200 {object} response.CountResult
 // @Failure 500
@@ -1319,6 +1350,8 @@ func getDomainListFilterFromContext(ctx *gin.Context) (*queries.ListDomainsFilte
 	filter.TldEquals = ctx.Query("tld_equals")
 	filter.NameEquals = ctx.Query("name_equals")
 	filter.NameLike = ctx.Query("name_like")
+	filter.NameMatches = ctx.Query("name_matches")
+	filter.NsNameMatches = ctx.Query("ns_name_matches")
 	filter.RoidGreaterThan = ctx.Query("roid_greater_than")
 	filter.RoidLessThan = ctx.Query("roid_less_than")
 	if ctx.Query("created_after") != "" {
@@ -1345,5 +1378,11 @@ func getDomainListFilterFromContext(ctx *gin.Context) (*queries.ListDomainsFilte
 			return nil, errors.Join(errors.New("invalid expires_before date: "), err)
 		}
 	}
+	if ctx.Query("updated_after") != "" {
+		filter.UpdatedAfter, err = time.Parse(time.RFC3339, ctx.Query("updated_after"))
+		if err != nil {
+			return nil, errors.Join(errors.New("invalid updated_after date: "), err)
+		}
+	}
 	return filter, nil
 }
@@ -0,0 +1,88 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// ReportDomainRestore godoc
+// @Summary Submit the restore report of a domain
+// @Description Submit the restore report of a domain in pendingRestore status (RFC 3915). The report must be received within the pendingRestore period, otherwise the domain returns to the redemption period.
+// @Description Omit the ClID to submit the report on behalf of the registry.
+// @Tags Domains
+// @Accept json
+// @Produce json
+// @Param domain path string true "Domain Name"
+// @Param report body commands.RestoreReportCommand true "Restore report"
+// @Success 200 {object} entities.Domain
+// @Failure 400
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Router /domains/{name}/restore/report [post]
+func (ctrl *DomainController) ReportDomainRestore(ctx *gin.Context) {
+	name := ctx.Param("name")
+	var req commands.RestoreReportCommand
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		if err.Error() == "EOF" {
+			ctx.JSON(400, gin.H{"error": "missing request body"})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Name != name {
+		ctx.JSON(400, gin.H{"error": "name in body must match name in path"})
+		return
+	}
+
+	dom, err := ctrl.domainService.ReportDomainRestore(ctx, &req)
+	if err != nil {
+		ctx.JSON(restoreErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(200, dom)
+}
+
+// CompleteDomainRestore godoc
+// @Summary Complete the restore of a domain
+// @Description Unsets the pendingRestore status of a domain for which the restore report was received. The domain should be renewed afterwards to charge the restore.
+// @Description If the report was not received within the pendingRestore period the domain is returned to the redemption period (pendingDelete status). If the pendingRestore period has not ended yet the domain is returned unchanged.
+// @Tags Domains
+// @Produce json
+// @Param domain path string true "Domain Name"
+// @Success 200 {object} entities.Domain
+// @Failure 400
+// @Failure 404
+// @Failure 500
+// @Router /domains/{name}/restore/complete [post]
+func (ctrl *DomainController) CompleteDomainRestore(ctx *gin.Context) {
+	dom, err := ctrl.domainService.CompleteDomainRestore(ctx, ctx.Param("name"))
+	if err != nil {
+		ctx.JSON(restoreErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(200, dom)
+}
+
+// restoreErrorStatusCode maps the errors returned by the restore services to an HTTP status code
+func restoreErrorStatusCode(err error) int {
+	switch {
+	case errors.Is(err, entities.ErrDomainNotFound):
+		return 404
+	case errors.Is(err, entities.ErrInvalidRegistrar):
+		return 403
+	case errors.Is(err, entities.ErrDomainRestoreNotAllowed),
+		errors.Is(err, entities.ErrInvalidRestoreReport),
+		errors.Is(err, entities.ErrRestoreReportNotAllowed):
+		return 400
+	default:
+		return 500
+	}
+}
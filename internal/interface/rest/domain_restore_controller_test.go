@@ -0,0 +1,27 @@
+package rest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestoreErrorStatusCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{entities.ErrDomainNotFound, 404},
+		{entities.ErrInvalidRegistrar, 403},
+		{errors.Join(entities.ErrDomainRestoreNotAllowed, errors.New("details")), 400},
+		{errors.Join(entities.ErrInvalidRestoreReport, errors.New("details")), 400},
+		{errors.Join(entities.ErrRestoreReportNotAllowed, entities.ErrRestoreReportOverdue), 400},
+		{errors.New("db down"), 500},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, restoreErrorStatusCode(tt.err), tt.err.Error())
+	}
+}
@@ -0,0 +1,282 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onasunnymorning/domain-os/internal/application/commands"
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/application/services"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/interface/rest/response"
+)
+
+// RequestDomainTransfer godoc
+// @Summary EPP-style transfer request command
+// @Description Request the transfer of a domain to the registrar in the body. The domain's AuthInfo must be provided.
+// @Description The domain is set to pendingTransfer until the losing registrar approves or rejects the transfer or the gaining registrar cancels it.
+// @Description If the domain is not found, the request will fail with a 404 status code.
+// @Tags Domains
+// @Accept json
+// @Produce json
+// @Param name path string true "Domain Name"
+// @Param transfer body commands.TransferDomainCommand true "Transfer"
+// @Success 201 {object} entities.DomainTransfer
+// @Failure 400
+// @Failure 404
+// @Failure 500
+// @Router /domains/{name}/transfer [post]
+func (ctrl *DomainController) RequestDomainTransfer(ctx *gin.Context) {
+	name := ctx.Param("name")
+	var req commands.TransferDomainCommand
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		if err.Error() == "EOF" {
+			ctx.JSON(400, gin.H{"error": "missing request body"})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Name != name {
+		ctx.JSON(400, gin.H{"error": "name in body must match name in path"})
+		return
+	}
+
+	transfer, err := ctrl.domainService.RequestDomainTransfer(ctx, req.ToEntity())
+	if err != nil {
+		ctx.JSON(transferErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(201, transfer)
+}
+
+// GetDomainTransfer godoc
+// @Summary Get the most recent transfer of a domain
+// @Description Get the most recent transfer of a domain, which may be pending or completed
+// @Tags Domains
+// @Produce json
+// @Param name path string true "Domain Name"
+// @Success 200 {object} entities.DomainTransfer
+// @Failure 404
+// @Failure 500
+// @Router /domains/{name}/transfer [get]
+func (ctrl *DomainController) GetDomainTransfer(ctx *gin.Context) {
+	transfer, err := ctrl.domainService.GetDomainTransfer(ctx, ctx.Param("name"))
+	if err != nil {
+		ctx.JSON(transferErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(200, transfer)
+}
+
+// ApproveDomainTransfer godoc
+// @Summary EPP-style transfer approve command
+// @Description Approve the pending transfer of a domain as the losing registrar. Leave the ClID empty to approve on behalf of the registry.
+// @Description The domain moves to the gaining registrar and its registration is extended by one year.
+// @Tags Domains
+// @Accept json
+// @Produce json
+// @Param name path string true "Domain Name"
+// @Param transfer body commands.DomainTransferActionCommand true "Transfer action"
+// @Success 200 {object} entities.DomainTransfer
+// @Failure 400
+// @Failure 404
+// @Failure 500
+// @Router /domains/{name}/transfer/approve [post]
+func (ctrl *DomainController) ApproveDomainTransfer(ctx *gin.Context) {
+	var req commands.DomainTransferActionCommand
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	transfer, err := ctrl.domainService.ApproveDomainTransfer(ctx, ctx.Param("name"), req.ClID)
+	if err != nil {
+		ctx.JSON(transferErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(200, transfer)
+}
+
+// RejectDomainTransfer godoc
+// @Summary EPP-style transfer reject command
+// @Description Reject the pending transfer of a domain as the losing registrar. Leave the ClID empty to reject on behalf of the registry.
+// @Tags Domains
+// @Accept json
+// @Produce json
+// @Param name path string true "Domain Name"
+// @Param transfer body commands.DomainTransferActionCommand true "Transfer action"
+// @Success 200 {object} entities.DomainTransfer
+// @Failure 400
+// @Failure 404
+// @Failure 500
+// @Router /domains/{name}/transfer/reject [post]
+func (ctrl *DomainController) RejectDomainTransfer(ctx *gin.Context) {
+	var req commands.DomainTransferActionCommand
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	transfer, err := ctrl.domainService.RejectDomainTransfer(ctx, ctx.Param("name"), req.ClID, req.Reason)
+	if err != nil {
+		ctx.JSON(transferErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(200, transfer)
+}
+
+// CancelDomainTransfer godoc
+// @Summary EPP-style transfer cancel command
+// @Description Cancel the pending transfer of a domain as the gaining registrar. Leave the ClID empty to cancel on behalf of the registry.
+// @Tags Domains
+// @Accept json
+// @Produce json
+// @Param name path string true "Domain Name"
+// @Param transfer body commands.DomainTransferActionCommand true "Transfer action"
+// @Success 200 {object} entities.DomainTransfer
+// @Failure 400
+// @Failure 404
+// @Failure 500
+// @Router /domains/{name}/transfer/cancel [post]
+func (ctrl *DomainController) CancelDomainTransfer(ctx *gin.Context) {
+	var req commands.DomainTransferActionCommand
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	transfer, err := ctrl.domainService.CancelDomainTransfer(ctx, ctx.Param("name"), req.ClID)
+	if err != nil {
+		ctx.JSON(transferErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(200, transfer)
+}
+
+// ListDomainTransfers godoc
+// @Summary List domain transfers
+// @Description List domain transfers
+// @Tags Domains
+// @Produce json
+// @Param pageSize query int false "Page Size"
+// @Param cursor query string false "Cursor"
+// @Param domain_name_like query string false "Domain name like"
+// @Param gaining_registrar_equals query string false "Gaining registrar ClID equals"
+// @Param losing_registrar_equals query string false "Losing registrar ClID equals"
+// @Param status_equals query string false "Transfer status equals"
+// @Param expires_before query string false "Transfer expires before (RFC3339)"
+// @Success 200 {object} response.ListItemResult
+// @Failure 400
+// @Failure 500
+// @Router /domains/transfers [get]
+func (ctrl *DomainController) ListDomainTransfers(ctx *gin.Context) {
+	query := queries.ListItemsQuery{}
+	resp := response.ListItemResult{}
+
+	var err error
+	query.Filter, err = getListDomainTransfersFilterFromContext(ctx)
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	query.PageSize, err = GetPageSize(ctx)
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	query.PageCursor, err = GetAndDecodeCursor(ctx)
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	transfers, cursor, err := ctrl.domainService.ListDomainTransfers(ctx, query)
+	if err != nil {
+		ctx.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp.Data = transfers
+	resp.SetMeta(ctx, cursor, len(transfers), query.PageSize, query.Filter)
+
+	ctx.JSON(200, resp)
+}
+
+// CountDomainTransfers godoc
+// @Summary Returns a count of the domain transfers that match the filter.
+// @Description Counts the domain transfers that match the filter and returns a timestamped count including the filters that were used.
+// @Tags Domains
+// @Produce json
+// @Param domain_name_like query string false "Domain name like"
+// @Param gaining_registrar_equals query string false "Gaining registrar ClID equals"
+// @Param losing_registrar_equals query string false "Losing registrar ClID equals"
+// @Param status_equals query string false "Transfer status equals"
+// @Param expires_before query string false "Transfer expires before (RFC3339)"
+// @Success 200 {object} response.CountResult
+// @Failure 400
+// @Failure 500
+// @Router /domains/transfers/count [get]
+func (ctrl *DomainController) CountDomainTransfers(ctx *gin.Context) {
+	result := response.CountResult{}
+
+	filter, err := getListDomainTransfersFilterFromContext(ctx)
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	result.Filter = filter
+
+	result.Count, err = ctrl.domainService.CountDomainTransfers(ctx, filter)
+	if err != nil {
+		ctx.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(200, result)
+}
+
+func getListDomainTransfersFilterFromContext(ctx *gin.Context) (queries.ListDomainTransfersFilter, error) {
+	filter := queries.ListDomainTransfersFilter{
+		DomainNameLike:         ctx.Query("domain_name_like"),
+		GainingRegistrarEquals: ctx.Query("gaining_registrar_equals"),
+		LosingRegistrarEquals:  ctx.Query("losing_registrar_equals"),
+		StatusEquals:           ctx.Query("status_equals"),
+	}
+	if ctx.Query("expires_before") != "" {
+		var err error
+		filter.ExpiresBefore, err = time.Parse(time.RFC3339, ctx.Query("expires_before"))
+		if err != nil {
+			return filter, errors.Join(errors.New("invalid expires_before date: "), err)
+		}
+	}
+	return filter, nil
+}
+
+// transferErrorStatusCode maps the errors returned by the transfer services to an HTTP status code
+func transferErrorStatusCode(err error) int {
+	switch {
+	case errors.Is(err, entities.ErrDomainNotFound), errors.Is(err, entities.ErrTransferNotFound):
+		return 404
+	case errors.Is(err, entities.ErrInvalidRegistrar), errors.Is(err, services.ErrRegistrarNotAccredited), errors.Is(err, entities.ErrTransferAuthInfoMismatch):
+		return 403
+	case errors.Is(err, entities.ErrNoPendingTransfer),
+		errors.Is(err, entities.ErrDomainPendingTransfer),
+		errors.Is(err, entities.ErrTransferToSponsor),
+		errors.Is(err, entities.ErrDomainTransferLocked),
+		errors.Is(err, entities.ErrDomainTransferNotAllowed),
+		errors.Is(err, entities.ErrInvalidTransferPeriod):
+		return 400
+	default:
+		return 500
+	}
+}
@@ -0,0 +1,62 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/application/services"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetListDomainTransfersFilterFromContext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	req, err := http.NewRequest(http.MethodGet, "/test?domain_name_like=example&gaining_registrar_equals=ClID-1&losing_registrar_equals=ClID-2&status_equals=pending&expires_before=2024-01-01T00:00:00Z", nil)
+	assert.NoError(t, err)
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = req
+
+	filter, err := getListDomainTransfersFilterFromContext(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, queries.ListDomainTransfersFilter{
+		DomainNameLike:         "example",
+		GainingRegistrarEquals: "ClID-1",
+		LosingRegistrarEquals:  "ClID-2",
+		StatusEquals:           "pending",
+		ExpiresBefore:          time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, filter)
+
+	// An invalid date is an error
+	req, err = http.NewRequest(http.MethodGet, "/test?expires_before=yesterday", nil)
+	assert.NoError(t, err)
+	ctx, _ = gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = req
+	_, err = getListDomainTransfersFilterFromContext(ctx)
+	assert.Error(t, err)
+}
+
+func TestTransferErrorStatusCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{entities.ErrDomainNotFound, 404},
+		{entities.ErrTransferNotFound, 404},
+		{entities.ErrInvalidRegistrar, 403},
+		{errors.Join(services.ErrRegistrarNotAccredited, errors.New("details")), 403},
+		{entities.ErrTransferAuthInfoMismatch, 403},
+		{entities.ErrNoPendingTransfer, 400},
+		{errors.Join(entities.ErrDomainTransferLocked, errors.New("details")), 400},
+		{errors.New("db down"), 500},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, transferErrorStatusCode(tt.err), tt.err.Error())
+	}
+}
@@ -72,6 +72,7 @@ func (ctrl *HostController) GetHostByRoID(ctx *gin.Context) {
 // @Tags Hosts
 // @Param roid path int true "RoID"
 // @Success 204
+// @Failure 409
 // @Failure 500
 // @Router /hosts/{roid} [delete]
 func (ctrl *HostController) DeleteHostByRoID(ctx *gin.Context) {
@@ -87,6 +88,10 @@ func (ctrl *HostController) DeleteHostByRoID(ctx *gin.Context) {
 			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		if errors.Is(err, entities.ErrHostIsLinked) || errors.Is(err, entities.ErrHostDeleteProhibited) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -160,6 +165,9 @@ func (ctrl *HostController) ListHosts(ctx *gin.Context) {
 	// Get the filter from the query string
 	filter := queries.ListHostsFilter{
 		NameLike:        ctx.Query("name_like"),
+		NameEquals:      ctx.Query("name_equals"),
+		NameMatches:     ctx.Query("name_matches"),
+		AddressEquals:   ctx.Query("address_equals"),
 		ClidEquals:      ctx.Query("clid_equals"),
 		RoidGreaterThan: ctx.Query("roid_greater_than"),
 		RoidLessThan:    ctx.Query("roid_less_than"),
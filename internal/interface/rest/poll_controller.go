@@ -0,0 +1,171 @@
+package rest
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onasunnymorning/domain-os/internal/application/interfaces"
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/onasunnymorning/domain-os/internal/interface/rest/response"
+)
+
+// PollController exposes the registrar poll message queues that registrars consume through the EPP <poll> command
+type PollController struct {
+	pollService interfaces.PollService
+}
+
+// NewPollController creates a new PollController and registers the routes
+func NewPollController(e *gin.Engine, pollService interfaces.PollService, handler gin.HandlerFunc) *PollController {
+	controller := &PollController{
+		pollService: pollService,
+	}
+
+	pollRouter := e.Group("/pollmessages", handler)
+	{
+		pollRouter.GET("", controller.List)
+		pollRouter.GET("/count", controller.Count)
+		pollRouter.GET(":id", controller.GetByID)
+		pollRouter.DELETE(":id", controller.Delete)
+	}
+
+	return controller
+}
+
+// GetByID godoc
+// @Summary Get a poll message by ID
+// @Description Get a poll message by ID
+// @Tags PollMessages
+// @Produce json
+// @Param id path int true "Poll message ID"
+// @Success 200 {object} entities.PollMessage
+// @Failure 400
+// @Failure 404
+// @Failure 500
+// @Router /pollmessages/{id} [get]
+func (ctrl *PollController) GetByID(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	msg, err := ctrl.pollService.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, entities.ErrPollMessageNotFound) {
+			ctx.JSON(404, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(200, msg)
+}
+
+// Delete godoc
+// @Summary Delete a poll message
+// @Description Removes a poll message from the queue, the equivalent of the registrar acknowledging the message through EPP
+// @Tags PollMessages
+// @Produce json
+// @Param id path int true "Poll message ID"
+// @Success 204
+// @Failure 400
+// @Failure 500
+// @Router /pollmessages/{id} [delete]
+func (ctrl *PollController) Delete(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = ctrl.pollService.Delete(ctx, id)
+	if err != nil {
+		ctx.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(204, nil)
+}
+
+// Count godoc
+// @Summary Returns a count of the poll messages that match the filter.
+// @Description Counts the queued poll messages that match the filter and returns a timestamped count including the filters that were used.
+// @Tags PollMessages
+// @Produce json
+// @Param clid_equals query string false "Registrar ClID equals"
+// @Param domain_name_like query string false "Domain name like"
+// @Param transaction_type_equals query string false "Transaction type equals"
+// @Success 200 {object} response.CountResult
+// @Failure 500
+// @Router /pollmessages/count [get]
+func (ctrl *PollController) Count(ctx *gin.Context) {
+	result := response.CountResult{}
+
+	filter := getListPollMessagesFilterFromContext(ctx)
+	result.Filter = filter
+
+	var err error
+	result.Count, err = ctrl.pollService.Count(ctx, filter)
+	if err != nil {
+		ctx.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(200, result)
+}
+
+// List godoc
+// @Summary List poll messages
+// @Description List the queued poll messages, oldest first
+// @Tags PollMessages
+// @Produce json
+// @Param cursor query string false "Cursor"
+// @Param pagesize query int false "Page size"
+// @Param clid_equals query string false "Registrar ClID equals"
+// @Param domain_name_like query string false "Domain name like"
+// @Param transaction_type_equals query string false "Transaction type equals"
+// @Success 200 {object} response.ListItemResult
+// @Failure 400
+// @Failure 500
+// @Router /pollmessages [get]
+func (ctrl *PollController) List(ctx *gin.Context) {
+	query := queries.ListItemsQuery{}
+	resp := response.ListItemResult{}
+
+	query.Filter = getListPollMessagesFilterFromContext(ctx)
+
+	var err error
+	query.PageSize, err = GetPageSize(ctx)
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	query.PageCursor, err = GetAndDecodeCursor(ctx)
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	msgs, cursor, err := ctrl.pollService.List(ctx, query)
+	if err != nil {
+		ctx.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp.Data = msgs
+	resp.SetMeta(ctx, cursor, len(msgs), query.PageSize, query.Filter)
+
+	ctx.JSON(200, resp)
+}
+
+func getListPollMessagesFilterFromContext(ctx *gin.Context) queries.ListPollMessagesFilter {
+	return queries.ListPollMessagesFilter{
+		ClidEquals:            ctx.Query("clid_equals"),
+		DomainNameLike:        ctx.Query("domain_name_like"),
+		TransactionTypeEquals: ctx.Query("transaction_type_equals"),
+	}
+}
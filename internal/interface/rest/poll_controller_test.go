@@ -0,0 +1,49 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onasunnymorning/domain-os/internal/application/queries"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetListPollMessagesFilterFromContext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		query    string
+		expected queries.ListPollMessagesFilter
+	}{
+		{
+			name:     "Empty query parameters",
+			query:    "",
+			expected: queries.ListPollMessagesFilter{},
+		},
+		{
+			name:  "All query parameters provided",
+			query: "clid_equals=ClID-1&domain_name_like=example&transaction_type_equals=purge",
+			expected: queries.ListPollMessagesFilter{
+				ClidEquals:            "ClID-1",
+				DomainNameLike:        "example",
+				TransactionTypeEquals: "purge",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/test?"+tt.query, nil)
+			assert.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+
+			assert.Equal(t, tt.expected, getListPollMessagesFilterFromContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package rest
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onasunnymorning/domain-os/internal/application/interfaces"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// RDAPController is the controller for the RDAP service (RFC 9082)
+type RDAPController struct {
+	rdapService interfaces.RDAPService
+}
+
+// NewRDAPController creates a new instance of RDAPController
+func NewRDAPController(e *gin.Engine, rdapService interfaces.RDAPService, handler gin.HandlerFunc) *RDAPController {
+	ctrl := &RDAPController{
+		rdapService: rdapService,
+	}
+
+	e.GET("/domain/:name", handler, ctrl.GetDomain)
+	e.GET("/nameserver/:name", handler, ctrl.GetNameserver)
+	e.GET("/entity/:handle", handler, ctrl.GetEntity)
+	e.GET("/help", handler, ctrl.Help)
+	e.GET("/domains", handler, ctrl.SearchDomains)
+	e.GET("/nameservers", handler, ctrl.SearchNameservers)
+	e.GET("/entities", handler, ctrl.SearchEntities)
+
+	return ctrl
+}
+
+// GetDomain godoc
+// @Summary Get the RDAP response for a domain
+// @Description Get the RDAP response for a domain as defined in RFC 9083 and the ICANN RDAP Response Profile. Contact data is redacted (RFC 9537) unless a bearer token with full access to the TLD is provided.
+// @Tags RDAP
+// @Produce json
+// @Param name path string true "Domain Name"
+// @Param Authorization header string false "Bearer token for the unredacted view"
+// @Success 200 {object} entities.RDAPDomain
+// @Failure 400 {object} entities.RDAPError
+// @Failure 404 {object} entities.RDAPError
+// @Failure 500 {object} entities.RDAPError
+// @Router /domain/{name} [get]
+func (ctrl *RDAPController) GetDomain(ctx *gin.Context) {
+	dom, err := ctrl.rdapService.GetDomain(ctx, ctx.Param("name"), bearerToken(ctx))
+	if err != nil {
+		ctrl.writeError(ctx, err)
+		return
+	}
+	ctrl.write(ctx, http.StatusOK, dom)
+}
+
+// GetNameserver godoc
+// @Summary Get the RDAP response for a nameserver
+// @Description Get the RDAP response for a nameserver as defined in RFC 9083 and the ICANN RDAP Response Profile
+// @Tags RDAP
+// @Produce json
+// @Param name path string true "Nameserver Name"
+// @Success 200 {object} entities.RDAPNameserver
+// @Failure 400 {object} entities.RDAPError
+// @Failure 404 {object} entities.RDAPError
+// @Failure 500 {object} entities.RDAPError
+// @Router /nameserver/{name} [get]
+func (ctrl *RDAPController) GetNameserver(ctx *gin.Context) {
+	ns, err := ctrl.rdapService.GetNameserver(ctx, ctx.Param("name"))
+	if err != nil {
+		ctrl.writeError(ctx, err)
+		return
+	}
+	ctrl.write(ctx, http.StatusOK, ns)
+}
+
+// GetEntity godoc
+// @Summary Get the RDAP response for an entity
+// @Description Get the RDAP response for a registrar (by IANA ID or ClID) or a contact (by ID) as defined in RFC 9083 and the ICANN RDAP Response Profile. Contact data is redacted (RFC 9537) unless a bearer token with full access to all TLDs is provided.
+// @Tags RDAP
+// @Produce json
+// @Param handle path string true "Entity Handle"
+// @Param Authorization header string false "Bearer token for the unredacted view"
+// @Success 200 {object} entities.RDAPEntity
+// @Failure 404 {object} entities.RDAPError
+// @Failure 500 {object} entities.RDAPError
+// @Router /entity/{handle} [get]
+func (ctrl *RDAPController) GetEntity(ctx *gin.Context) {
+	entity, err := ctrl.rdapService.GetEntity(ctx, ctx.Param("handle"), bearerToken(ctx))
+	if err != nil {
+		ctrl.writeError(ctx, err)
+		return
+	}
+	ctrl.write(ctx, http.StatusOK, entity)
+}
+
+// Help godoc
+// @Summary Get the RDAP help
+// @Description Get the RDAP help response as defined in RFC 9083
+// @Tags RDAP
+// @Produce json
+// @Success 200 {object} entities.RDAPHelp
+// @Router /help [get]
+func (ctrl *RDAPController) Help(ctx *gin.Context) {
+	ctrl.write(ctx, http.StatusOK, ctrl.rdapService.Help(ctx))
+}
+
+// SearchDomains godoc
+// @Summary Search domains
+// @Description Search domains by name or nameserver name as defined in RFC 9082, * matches any sequence of characters (e.g. exa*.apex or ns1.*). Results are paged (RFC 8977), follow the next link in the paging metadata to get the next page.
+// @Tags RDAP
+// @Produce json
+// @Param name query string false "Domain Name Pattern"
+// @Param nsLdhName query string false "Nameserver Name Pattern"
+// @Param cursor query string false "Cursor"
+// @Success 200 {object} entities.RDAPDomainSearchResults
+// @Failure 400 {object} entities.RDAPError
+// @Failure 500 {object} entities.RDAPError
+// @Router /domains [get]
+func (ctrl *RDAPController) SearchDomains(ctx *gin.Context) {
+	cursor, err := GetAndDecodeCursor(ctx)
+	if err != nil {
+		ctrl.writeError(ctx, errors.Join(entities.ErrInvalidRDAPQuery, err))
+		return
+	}
+	results, err := ctrl.rdapService.SearchDomains(ctx, ctx.Query("name"), ctx.Query("nsLdhName"), cursor)
+	if err != nil {
+		ctrl.writeError(ctx, err)
+		return
+	}
+	ctrl.write(ctx, http.StatusOK, results)
+}
+
+// SearchNameservers godoc
+// @Summary Search nameservers
+// @Description Search nameservers by name or IP address as defined in RFC 9082, * matches any sequence of characters in the name (e.g. ns1.*). Results are paged (RFC 8977), follow the next link in the paging metadata to get the next page.
+// @Tags RDAP
+// @Produce json
+// @Param name query string false "Nameserver Name Pattern"
+// @Param ip query string false "IP Address"
+// @Param cursor query string false "Cursor"
+// @Success 200 {object} entities.RDAPNameserverSearchResults
+// @Failure 400 {object} entities.RDAPError
+// @Failure 500 {object} entities.RDAPError
+// @Router /nameservers [get]
+func (ctrl *RDAPController) SearchNameservers(ctx *gin.Context) {
+	cursor, err := GetAndDecodeCursor(ctx)
+	if err != nil {
+		ctrl.writeError(ctx, errors.Join(entities.ErrInvalidRDAPQuery, err))
+		return
+	}
+	results, err := ctrl.rdapService.SearchNameservers(ctx, ctx.Query("name"), ctx.Query("ip"), cursor)
+	if err != nil {
+		ctrl.writeError(ctx, err)
+		return
+	}
+	ctrl.write(ctx, http.StatusOK, results)
+}
+
+// SearchEntities godoc
+// @Summary Search entities
+// @Description Search contacts by name as defined in RFC 9082, * matches any sequence of characters (e.g. John*). Only requesters with a bearer token with full access to all TLDs get results. Results are paged (RFC 8977), follow the next link in the paging metadata to get the next page.
+// @Tags RDAP
+// @Produce json
+// @Param fn query string true "Name Pattern"
+// @Param cursor query string false "Cursor"
+// @Param Authorization header string false "Bearer token for the unredacted view"
+// @Success 200 {object} entities.RDAPEntitySearchResults
+// @Failure 400 {object} entities.RDAPError
+// @Failure 500 {object} entities.RDAPError
+// @Router /entities [get]
+func (ctrl *RDAPController) SearchEntities(ctx *gin.Context) {
+	cursor, err := GetAndDecodeCursor(ctx)
+	if err != nil {
+		ctrl.writeError(ctx, errors.Join(entities.ErrInvalidRDAPQuery, err))
+		return
+	}
+	results, err := ctrl.rdapService.SearchEntities(ctx, ctx.Query("fn"), cursor, bearerToken(ctx))
+	if err != nil {
+		ctrl.writeError(ctx, err)
+		return
+	}
+	ctrl.write(ctx, http.StatusOK, results)
+}
+
+// bearerToken returns the token of the Authorization header, or an empty string for anonymous requests
+func bearerToken(ctx *gin.Context) string {
+	token, ok := strings.CutPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// write writes the response with the RDAP content type
+func (ctrl *RDAPController) write(ctx *gin.Context, code int, obj any) {
+	ctx.Header("Content-Type", entities.RDAP_CONTENT_TYPE)
+	ctx.JSON(code, obj)
+}
+
+// writeError maps the error to an RDAP error response.
+// Errors we can't map are internal errors, their message may contain internal details so it is logged and not included in the response.
+func (ctrl *RDAPController) writeError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entities.ErrDomainNotFound),
+		errors.Is(err, entities.ErrHostNotFound),
+		errors.Is(err, entities.ErrContactNotFound),
+		errors.Is(err, entities.ErrRegistrarNotFound):
+		ctrl.write(ctx, http.StatusNotFound, entities.NewRDAPError(http.StatusNotFound, "Not Found", err.Error()))
+	case errors.Is(err, entities.ErrInvalidRDAPQuery):
+		ctrl.write(ctx, http.StatusBadRequest, entities.NewRDAPError(http.StatusBadRequest, "Bad Request", err.Error()))
+	default:
+		log.Printf("RDAP query %s failed: %v", ctx.Request.URL.Path, err)
+		ctrl.write(ctx, http.StatusInternalServerError, entities.NewRDAPError(http.StatusInternalServerError, "Internal Server Error"))
+	}
+}
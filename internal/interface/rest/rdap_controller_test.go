@@ -0,0 +1,222 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRDAPService is a mock implementation of the RDAPService
+type MockRDAPService struct {
+	mock.Mock
+}
+
+func (m *MockRDAPService) GetDomain(ctx context.Context, name, token string) (*entities.RDAPDomain, error) {
+	args := m.Called(ctx, name, token)
+	return args.Get(0).(*entities.RDAPDomain), args.Error(1)
+}
+
+func (m *MockRDAPService) GetNameserver(ctx context.Context, name string) (*entities.RDAPNameserver, error) {
+	args := m.Called(ctx, name)
+	return args.Get(0).(*entities.RDAPNameserver), args.Error(1)
+}
+
+func (m *MockRDAPService) GetEntity(ctx context.Context, handle, token string) (*entities.RDAPEntity, error) {
+	args := m.Called(ctx, handle, token)
+	return args.Get(0).(*entities.RDAPEntity), args.Error(1)
+}
+
+func (m *MockRDAPService) Help(ctx context.Context) *entities.RDAPHelp {
+	args := m.Called(ctx)
+	return args.Get(0).(*entities.RDAPHelp)
+}
+
+func (m *MockRDAPService) SearchDomains(ctx context.Context, name, nsLdhName, cursor string) (*entities.RDAPDomainSearchResults, error) {
+	args := m.Called(ctx, name, nsLdhName, cursor)
+	return args.Get(0).(*entities.RDAPDomainSearchResults), args.Error(1)
+}
+
+func (m *MockRDAPService) SearchNameservers(ctx context.Context, name, ip, cursor string) (*entities.RDAPNameserverSearchResults, error) {
+	args := m.Called(ctx, name, ip, cursor)
+	return args.Get(0).(*entities.RDAPNameserverSearchResults), args.Error(1)
+}
+
+func (m *MockRDAPService) SearchEntities(ctx context.Context, fn, cursor, token string) (*entities.RDAPEntitySearchResults, error) {
+	args := m.Called(ctx, fn, cursor, token)
+	return args.Get(0).(*entities.RDAPEntitySearchResults), args.Error(1)
+}
+
+func getTestRDAPRouter(svc *MockRDAPService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	NewRDAPController(router, svc, MockGinHandler())
+	return router
+}
+
+func TestRDAPController_GetDomain(t *testing.T) {
+	svc := new(MockRDAPService)
+	svc.On("GetDomain", mock.Anything, "apex.domains", "").Return(&entities.RDAPDomain{ObjectClassName: entities.RDAPObjectClassDomain, LdhName: "apex.domains"}, nil)
+	svc.On("GetDomain", mock.Anything, "missing.domains", "").Return((*entities.RDAPDomain)(nil), entities.ErrDomainNotFound)
+	svc.On("GetDomain", mock.Anything, "-invalid.domains", "").Return((*entities.RDAPDomain)(nil), entities.ErrInvalidRDAPQuery)
+	router := getTestRDAPRouter(svc)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/domain/apex.domains", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), entities.RDAP_CONTENT_TYPE)
+	assert.Contains(t, w.Body.String(), `"ldhName":"apex.domains"`)
+
+	// The bearer token is passed to the service
+	svc.On("GetDomain", mock.Anything, "apex.domains", "s3cr3t").Return(&entities.RDAPDomain{ObjectClassName: entities.RDAPObjectClassDomain, LdhName: "apex.domains"}, nil)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/domain/apex.domains", nil)
+	req.Header.Set("Authorization", "Bearer s3cr3t")
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/domain/missing.domains", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), `"errorCode":404`)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/domain/-invalid.domains", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"errorCode":400`)
+
+	svc.AssertExpectations(t)
+}
+
+func TestRDAPController_GetNameserver(t *testing.T) {
+	svc := new(MockRDAPService)
+	svc.On("GetNameserver", mock.Anything, "ns1.apex.domains").Return(&entities.RDAPNameserver{ObjectClassName: entities.RDAPObjectClassNameserver, LdhName: "ns1.apex.domains"}, nil)
+	svc.On("GetNameserver", mock.Anything, "ns2.apex.domains").Return((*entities.RDAPNameserver)(nil), errors.New("dial tcp 10.0.0.5:5432: connection refused"))
+	router := getTestRDAPRouter(svc)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/nameserver/ns1.apex.domains", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"ldhName":"ns1.apex.domains"`)
+
+	// Internal errors are logged, not sent to the client
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/nameserver/ns2.apex.domains", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), `"errorCode":500`)
+	assert.NotContains(t, w.Body.String(), "10.0.0.5")
+	assert.Contains(t, logged.String(), "dial tcp 10.0.0.5:5432: connection refused")
+	svc.AssertExpectations(t)
+}
+
+func TestRDAPController_GetEntity(t *testing.T) {
+	svc := new(MockRDAPService)
+	svc.On("GetEntity", mock.Anything, "1234", "").Return(&entities.RDAPEntity{ObjectClassName: entities.RDAPObjectClassEntity, Handle: "1234"}, nil)
+	svc.On("GetEntity", mock.Anything, "missing", "").Return((*entities.RDAPEntity)(nil), entities.ErrRegistrarNotFound)
+	router := getTestRDAPRouter(svc)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/entity/1234", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"handle":"1234"`)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/entity/missing", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	svc.AssertExpectations(t)
+}
+
+func TestRDAPController_Help(t *testing.T) {
+	svc := new(MockRDAPService)
+	svc.On("Help", mock.Anything).Return(&entities.RDAPHelp{RDAPConformance: entities.RDAPConformance})
+	router := getTestRDAPRouter(svc)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/help", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"rdap_level_0"`)
+	svc.AssertExpectations(t)
+}
+
+func TestRDAPController_SearchDomains(t *testing.T) {
+	svc := new(MockRDAPService)
+	svc.On("SearchDomains", mock.Anything, "exa*.apex", "", "").Return(&entities.RDAPDomainSearchResults{DomainSearchResults: []entities.RDAPDomain{{LdhName: "example.apex"}}}, nil)
+	svc.On("SearchDomains", mock.Anything, "", "ns1.*", "123_DOM-APEX").Return(&entities.RDAPDomainSearchResults{DomainSearchResults: []entities.RDAPDomain{}}, nil)
+	svc.On("SearchDomains", mock.Anything, "", "", "").Return((*entities.RDAPDomainSearchResults)(nil), entities.ErrInvalidRDAPQuery)
+	router := getTestRDAPRouter(svc)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/domains?name=exa*.apex", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), entities.RDAP_CONTENT_TYPE)
+	assert.Contains(t, w.Body.String(), `"domainSearchResults":[{`)
+
+	// The cursor is decoded before it is passed to the service
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/domains?nsLdhName=ns1.*&cursor="+base64.URLEncoding.EncodeToString([]byte("123_DOM-APEX")), nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"domainSearchResults":[]`)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/domains?name=exa*.apex&cursor=not-base64!", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/domains", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"errorCode":400`)
+
+	svc.AssertExpectations(t)
+}
+
+func TestRDAPController_SearchNameservers(t *testing.T) {
+	svc := new(MockRDAPService)
+	svc.On("SearchNameservers", mock.Anything, "", "192.0.2.1", "").Return(&entities.RDAPNameserverSearchResults{NameserverSearchResults: []entities.RDAPNameserver{{LdhName: "ns1.apex.domains"}}}, nil)
+	router := getTestRDAPRouter(svc)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/nameservers?ip=192.0.2.1", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"nameserverSearchResults":[{`)
+	svc.AssertExpectations(t)
+}
+
+func TestRDAPController_SearchEntities(t *testing.T) {
+	svc := new(MockRDAPService)
+	svc.On("SearchEntities", mock.Anything, "John*", "", "s3cr3t").Return(&entities.RDAPEntitySearchResults{EntitySearchResults: []entities.RDAPEntity{{Handle: "cont1"}}}, nil)
+	router := getTestRDAPRouter(svc)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/entities?fn=John*", nil)
+	req.Header.Set("Authorization", "Bearer s3cr3t")
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"entitySearchResults":[{`)
+	svc.AssertExpectations(t)
+}
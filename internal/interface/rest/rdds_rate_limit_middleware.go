@@ -0,0 +1,44 @@
+package rest
+
+import (
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onasunnymorning/domain-os/internal/application/interfaces"
+	"github.com/onasunnymorning/domain-os/internal/application/services"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// RDDSRateLimitMiddleware counts the queries to an RDDS service (e.g. services.RDDS_SERVICE_RDAP) and aborts the queries
+// of a client that exceeds its limit with HTTP 429. RDAP clients get an RDAP error response.
+func RDDSRateLimitMiddleware(limiter interfaces.RDDSRateLimiter, service string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// An unparsable client IP results in an invalid address, which the limiter does not limit
+		addr, _ := netip.ParseAddr(ctx.ClientIP())
+		if limiter.Allow(service, addr) {
+			ctx.Next()
+			return
+		}
+		if service == services.RDDS_SERVICE_RDAP {
+			ctx.Header("Content-Type", entities.RDAP_CONTENT_TYPE)
+			ctx.AbortWithStatusJSON(http.StatusTooManyRequests, entities.NewRDAPError(http.StatusTooManyRequests, "Too Many Requests", services.ErrRDDSQueryLimitExceeded.Error()))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": services.ErrRDDSQueryLimitExceeded.Error()})
+	}
+}
+
+// ParseTrustedProxies returns the proxies from a comma separated list of addresses and CIDRs, e.g. "10.0.0.0/8, 192.0.2.1", to be passed to
+// gin.Engine.SetTrustedProxies. An empty list returns nil so the X-Forwarded-For header is never trusted and the client IP used to count
+// and limit RDDS queries is the address of the peer.
+func ParseTrustedProxies(proxies string) []string {
+	var trusted []string
+	for _, s := range strings.Split(proxies, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			trusted = append(trusted, s)
+		}
+	}
+	return trusted
+}
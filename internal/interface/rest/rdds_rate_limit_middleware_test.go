@@ -0,0 +1,94 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onasunnymorning/domain-os/internal/application/services"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRDDSRateLimiter allows the configured number of queries and records the services and sources
+type fakeRDDSRateLimiter struct {
+	remaining int
+	services  []string
+	sources   []netip.Addr
+}
+
+func (f *fakeRDDSRateLimiter) Allow(service string, addr netip.Addr) bool {
+	f.services = append(f.services, service)
+	f.sources = append(f.sources, addr)
+	f.remaining--
+	return f.remaining >= 0
+}
+
+func TestRDDSRateLimitMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limiter := &fakeRDDSRateLimiter{remaining: 1}
+	router := gin.New()
+	router.GET("/rdap", RDDSRateLimitMiddleware(limiter, services.RDDS_SERVICE_RDAP), func(ctx *gin.Context) { ctx.String(http.StatusOK, "ok") })
+	router.GET("/whois", RDDSRateLimitMiddleware(limiter, services.RDDS_SERVICE_WEB_WHOIS), func(ctx *gin.Context) { ctx.String(http.StatusOK, "ok") })
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/rdap", nil)
+	req.RemoteAddr = "192.0.2.1:12345"
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// RDAP clients get an RDAP error
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), entities.RDAP_CONTENT_TYPE)
+	assert.Contains(t, w.Body.String(), `"errorCode":429`)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/whois", nil)
+	req.RemoteAddr = "192.0.2.1:12345"
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Contains(t, w.Body.String(), services.ErrRDDSQueryLimitExceeded.Error())
+
+	assert.Equal(t, []string{services.RDDS_SERVICE_RDAP, services.RDDS_SERVICE_RDAP, services.RDDS_SERVICE_WEB_WHOIS}, limiter.services)
+	assert.Equal(t, netip.MustParseAddr("192.0.2.1"), limiter.sources[0])
+}
+
+func TestRDDSRateLimitMiddleware_TrustedProxies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name    string
+		proxies string
+		want    netip.Addr
+	}{
+		// A client can't escape its limit by spoofing the X-Forwarded-For header
+		{"no trusted proxies", "", netip.MustParseAddr("192.0.2.1")},
+		{"untrusted peer", "10.0.0.0/8", netip.MustParseAddr("192.0.2.1")},
+		{"trusted proxy", "10.0.0.0/8, 192.0.2.1", netip.MustParseAddr("198.51.100.7")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := &fakeRDDSRateLimiter{remaining: 1}
+			router := gin.New()
+			require.NoError(t, router.SetTrustedProxies(ParseTrustedProxies(tt.proxies)))
+			router.GET("/rdap", RDDSRateLimitMiddleware(limiter, services.RDDS_SERVICE_RDAP), func(ctx *gin.Context) { ctx.String(http.StatusOK, "ok") })
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/rdap", nil)
+			req.RemoteAddr = "192.0.2.1:12345"
+			req.Header.Set("X-Forwarded-For", "198.51.100.7")
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, []netip.Addr{tt.want}, limiter.sources)
+		})
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	assert.Nil(t, ParseTrustedProxies(""))
+	assert.Equal(t, []string{"10.0.0.0/8", "192.0.2.1"}, ParseTrustedProxies(" 10.0.0.0/8, 192.0.2.1,"))
+}
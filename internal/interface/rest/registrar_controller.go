@@ -38,6 +38,7 @@ func NewRegistrarController(e *gin.Engine, rarService interfaces.RegistrarServic
 		rarGroup.POST("/bulk", controller.BulkCreate)
 		rarGroup.PUT(":clid", controller.UpdateRegistrar)
 		rarGroup.PUT(":clid/status/:status", controller.SetRegistrarStatus)
+		rarGroup.PUT(":clid/epp-password", controller.SetEPPPassword)
 		// REQUEST REMOVAL rarGroup.POST(":gurid", controller.CreateRegistrarByGurID)
 		rarGroup.DELETE(":clid", controller.DeleteRegistrarByClID)
 	}
@@ -115,6 +116,7 @@ func (ctrl *RegistrarController) GetByGurID(ctx *gin.Context) {
 // @Param cursor query string false "Cursor"
 // @Param clid_like query string false "ClID like"
 // @Param name_like query string false "Name like"
+// @Param name_equals query string false "Name equals (case insensitive)"
 // @Param nick_name_like query string false "NickName like"
 // @Param gurid_equals query int false "GurID equals"
 // @Param email_like query string false "Email like"
@@ -331,6 +333,48 @@ func (ctrl *RegistrarController) SetRegistrarStatus(ctx *gin.Context) {
 	ctx.JSON(204, nil)
 }
 
+// SetEPPPassword godoc
+// @Summary Set the EPP password of a Registrar
+// @Description Set the password the Registrar uses to login to the EPP server, only its hash is stored.
+// @Description The password must be between 12 and 128 characters, contain at least 3 of lowercase letters, uppercase letters, digits and other characters and differ from the current password.
+// @Description Passwords longer than 16 characters can only be used by clients that support the EPP login security extension (RFC 8807). Setting the password lifts a failed login lockout.
+// @Tags Registrars
+// @Accept json
+// @Param clid path string true "Registrar Client ID"
+// @Param password body commands.SetRegistrarEPPPasswordCommand true "EPP password"
+// @Success 204
+// @Failure 400
+// @Failure 404
+// @Failure 500
+// @Router /registrars/{clid}/epp-password [put]
+func (ctrl *RegistrarController) SetEPPPassword(ctx *gin.Context) {
+	var cmd commands.SetRegistrarEPPPasswordCommand
+	if err := ctx.ShouldBindJSON(&cmd); err != nil {
+		if err.Error() == "EOF" {
+			ctx.JSON(400, gin.H{"error": "missing request body"})
+			return
+		}
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := ctrl.rarService.SetEPPPassword(ctx, ctx.Param("clid"), cmd.Password)
+	if err != nil {
+		if errors.Is(err, entities.ErrRegistrarNotFound) {
+			ctx.JSON(404, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, entities.ErrInvalidEPPPassword) || errors.Is(err, entities.ErrEPPPasswordReused) {
+			ctx.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(204, nil)
+}
+
 // GetRegistrarCount godoc
 // @Summary Get the number of registrars
 // @Description Get the number of registrars
@@ -358,6 +402,7 @@ func getRegistrarListFilterFromContext(ctx *gin.Context) (*queries.ListRegistrar
 	filter := &queries.ListRegistrarsFilter{}
 	filter.ClidLike = ctx.Query("clid_like")
 	filter.NameLike = ctx.Query("name_like")
+	filter.NameEquals = ctx.Query("name_equals")
 	filter.NickNameLike = ctx.Query("nick_name_like")
 
 	if ctx.Query("gurid_equals") != "" {
@@ -0,0 +1,6 @@
+package request
+
+// CreateDNSSECKeyRequest is the request to create a new DNSSEC key for a TLD zone
+type CreateDNSSECKeyRequest struct {
+	Type string `json:"Type" binding:"required" example:"KSK"`
+}
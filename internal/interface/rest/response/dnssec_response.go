@@ -0,0 +1,52 @@
+package response
+
+import (
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+)
+
+// DNSSECKey is the response structure for a DNSSEC key, it holds the public key and its timing metadata. Unscheduled events are omitted.
+type DNSSECKey struct {
+	Zone      string     `json:"zone"`
+	KeyTag    uint16     `json:"keyTag"`
+	Type      string     `json:"type"`
+	Algorithm string     `json:"algorithm"`
+	DNSKEY    string     `json:"dnskey"`
+	Created   *time.Time `json:"created,omitempty"`
+	Publish   *time.Time `json:"publish,omitempty"`
+	Activate  *time.Time `json:"activate,omitempty"`
+	Inactive  *time.Time `json:"inactive,omitempty"`
+	Delete    *time.Time `json:"delete,omitempty"`
+}
+
+// FromDNSSECKey creates a DNSSECKey response from the key entity, without its private key
+func FromDNSSECKey(key *entities.DNSSECKey) DNSSECKey {
+	return DNSSECKey{
+		Zone:      key.Zone(),
+		KeyTag:    key.KeyTag(),
+		Type:      string(key.Type()),
+		Algorithm: dns.AlgorithmToString[key.DNSKEY.Algorithm],
+		DNSKEY:    key.DNSKEY.String(),
+		Created:   timeOrNil(key.Created),
+		Publish:   timeOrNil(key.Publish),
+		Activate:  timeOrNil(key.Activate),
+		Inactive:  timeOrNil(key.Inactive),
+		Delete:    timeOrNil(key.Delete),
+	}
+}
+
+// DSResponse holds the DS records of a TLD for the parent zone
+type DSResponse struct {
+	TLD       string    `json:"tld"`
+	Timestamp time.Time `json:"timestamp"`
+	DS        []string  `json:"ds"`
+}
+
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
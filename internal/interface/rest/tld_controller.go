@@ -16,14 +16,16 @@ import (
 )
 
 type TLDController struct {
-	tldService interfaces.TLDService
-	domService interfaces.DomainService
+	tldService  interfaces.TLDService
+	domService  interfaces.DomainService
+	zoneService interfaces.ZoneService
 }
 
-func NewTLDController(e *gin.Engine, tldService interfaces.TLDService, dnss interfaces.DomainService, handler gin.HandlerFunc) *TLDController {
+func NewTLDController(e *gin.Engine, tldService interfaces.TLDService, dnss interfaces.DomainService, zoneService interfaces.ZoneService, handler gin.HandlerFunc) *TLDController {
 	controller := &TLDController{
-		tldService: tldService,
-		domService: dnss,
+		tldService:  tldService,
+		domService:  dnss,
+		zoneService: zoneService,
 	}
 
 	tldRoutes := e.Group("/tlds", handler)
@@ -39,6 +41,7 @@ func NewTLDController(e *gin.Engine, tldService interfaces.TLDService, dnss inte
 		tldRoutes.GET(":tldName/dns/resource-records", controller.GetTLDHeader)
 		tldRoutes.GET(":tldName/dns/domain-delegations", controller.GetNSRecordsPerTLD)
 		tldRoutes.GET(":tldName/dns/glue-records", controller.GetGlueRecordsPerTLD)
+		tldRoutes.GET(":tldName/dns/zone", controller.GetZoneFile)
 	}
 	return controller
 }
@@ -222,7 +225,7 @@ func (ctrl *TLDController) GetTLDHeader(ctx *gin.Context) {
 
 // GetNSRecordsPerTLD godoc
 // @Summary Get NS records for a TLD
-// @Description Get NS records for a TLD in JSON format (default) or text format
+// @Description Get NS records and the DS records of signed delegations for a TLD in JSON format (default) or text format
 // @Tags TLDs
 // @Produce json
 // @Param tld path string true "TLD"
@@ -321,6 +324,40 @@ func (c *TLDController) GetGlueRecordsPerTLD(ctx *gin.Context) {
 	ctx.JSON(200, rrs)
 }
 
+// GetZoneFile godoc
+// @Summary Get the zone file for a TLD
+// @Description Generates the complete zone for a TLD as an RFC 1035 master file. This includes the TLD DNS records (see resource-records), the NS and DS records of the delegated domains and the glue records of the hosts within the TLD.
+// @Description Domains that are inactive, pending delete or on hold (clientHold or serverHold) are not published.
+// @Description The zone is validated before it is returned and each call increments the SOA serial.
+// @Tags TLDs
+// @Produce plain
+// @Param tldName path string true "TLD Name"
+// @Success 200 {string} string
+// @Failure 404
+// @Failure 500
+// @Router /tlds/{tldName}/dns/zone [get]
+func (c *TLDController) GetZoneFile(ctx *gin.Context) {
+	tldName := strings.ToLower(ctx.Param("tldName"))
+
+	zone, err := c.zoneService.GenerateZone(ctx, tldName)
+	if err != nil {
+		if errors.Is(err, entities.ErrTLDNotFound) {
+			ctx.JSON(404, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Stream the zone to the client
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.zone", tldName))
+	ctx.Header("Content-Type", "text/dns")
+	ctx.Status(200)
+	if _, err := zone.WriteTo(ctx.Writer); err != nil {
+		_ = ctx.Error(err)
+	}
+}
+
 // GetTLDCount godoc
 // @Summary Get TLD count
 // @Description Get TLD count
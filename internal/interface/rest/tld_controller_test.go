@@ -0,0 +1,100 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/miekg/dns"
+	"github.com/onasunnymorning/domain-os/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockZoneService is a mock implementation of the ZoneService
+type MockZoneService struct {
+	mock.Mock
+}
+
+func (m *MockZoneService) GenerateZone(ctx context.Context, tldName string) (*entities.Zone, error) {
+	args := m.Called(ctx, tldName)
+	return args.Get(0).(*entities.Zone), args.Error(1)
+}
+
+func (m *MockZoneService) BuildZone(ctx context.Context, tldName string) (*entities.Zone, error) {
+	args := m.Called(ctx, tldName)
+	return args.Get(0).(*entities.Zone), args.Error(1)
+}
+
+func (m *MockZoneService) PublishZone(ctx context.Context, zone *entities.Zone) error {
+	args := m.Called(ctx, zone)
+	return args.Error(0)
+}
+
+func (m *MockZoneService) ListZones(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func TestGetZoneFile(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	soa, err := dns.NewRR("example. 3600 IN SOA ns1.nic.example. hostmaster.nic.example. 42 1800 900 604800 86400")
+	require.NoError(t, err)
+	zone, err := entities.NewZone("example.", soa.(*dns.SOA))
+	require.NoError(t, err)
+	ns, err := dns.NewRR("example. 3600 IN NS ns.other.net.")
+	require.NoError(t, err)
+	require.NoError(t, zone.AddRecords(ns))
+
+	tests := []struct {
+		name       string
+		zone       *entities.Zone
+		err        error
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "success",
+			zone:       zone,
+			wantStatus: http.StatusOK,
+			wantBody:   zone.String(),
+		},
+		{
+			name:       "TLD not found",
+			zone:       (*entities.Zone)(nil),
+			err:        entities.ErrTLDNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "invalid zone",
+			zone:       (*entities.Zone)(nil),
+			err:        errors.Join(entities.ErrInvalidZone, entities.ErrZoneMissingApexNS),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			router := gin.New()
+			zoneService := new(MockZoneService)
+			zoneService.On("GenerateZone", mock.Anything, "example").Return(tc.zone, tc.err)
+			NewTLDController(router, nil, nil, zoneService, MockGinHandler())
+
+			req, _ := http.NewRequest(http.MethodGet, "/tlds/EXAMPLE/dns/zone", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+			if tc.wantBody != "" {
+				assert.Equal(t, tc.wantBody, w.Body.String())
+				assert.Equal(t, "text/dns", w.Header().Get("Content-Type"))
+			}
+			zoneService.AssertExpectations(t)
+		})
+	}
+}
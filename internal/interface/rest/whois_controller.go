@@ -13,13 +13,13 @@ type WhoisController struct {
 	whoisService interfaces.WhoisService
 }
 
-// NewWhoisController creates a new instance of WhoisController
-func NewWhoisController(e *gin.Engine, whoisService interfaces.WhoisService, handler gin.HandlerFunc) *WhoisController {
+// NewWhoisController creates a new instance of WhoisController, the handlers run in order before the whois handler (e.g. authentication and rate limiting)
+func NewWhoisController(e *gin.Engine, whoisService interfaces.WhoisService, handlers ...gin.HandlerFunc) *WhoisController {
 	ctrl := &WhoisController{
 		whoisService: whoisService,
 	}
 
-	e.GET("/whois/:domainName", handler, ctrl.GetWhois)
+	e.GET("/whois/:domainName", append(handlers, ctrl.GetWhois)...)
 
 	return ctrl
 }
@@ -33,6 +33,7 @@ func NewWhoisController(e *gin.Engine, whoisService interfaces.WhoisService, han
 // @Success 200 {object} entities.WhoisResponse
 // @Failure 400
 // @Failure 404
+// @Failure 429
 // @Failure 500
 // @Router /whois/{domainName} [get]
 func (ctrl *WhoisController) GetWhois(ctx *gin.Context) {
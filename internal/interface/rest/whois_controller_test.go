@@ -23,6 +23,21 @@ func (m *MockWhoisService) GetDomainWhois(ctx context.Context, domainName string
 	return args.Get(0).(*entities.WhoisResponse), args.Error(1)
 }
 
+func (m *MockWhoisService) GetNameserverWhois(ctx context.Context, name string) (*entities.WhoisNameserverResponse, error) {
+	args := m.Called(ctx, name)
+	return args.Get(0).(*entities.WhoisNameserverResponse), args.Error(1)
+}
+
+func (m *MockWhoisService) GetNameserverWhoisByIP(ctx context.Context, ip string) ([]*entities.WhoisNameserverResponse, error) {
+	args := m.Called(ctx, ip)
+	return args.Get(0).([]*entities.WhoisNameserverResponse), args.Error(1)
+}
+
+func (m *MockWhoisService) GetRegistrarWhois(ctx context.Context, query string) (*entities.WhoisRegistrarResponse, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(*entities.WhoisRegistrarResponse), args.Error(1)
+}
+
 // MockGinHandler checks for the constant JWT token in the Authorization header
 func MockGinHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {